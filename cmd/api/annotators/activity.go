@@ -0,0 +1,115 @@
+package annotators
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/categorized"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// Activity returns a report with the activity and throughput of each annotator: the verdicts given per day during the
+// last days, the verdicts distribution, the median time between consecutive categorizations and the progress per
+// criteria and month. Only admins are allowed to retrieve it
+type Activity func(ctx context.Context, token string, days int) (ActivityDTO, error)
+
+// MakeActivity creates a new Activity
+func MakeActivity(authorize auth.Authorize, selectDailyVerdicts SelectDailyVerdicts, selectVerdictsDistribution SelectVerdictsDistribution, selectMedianIntervals SelectMedianIntervals, selectProgress SelectProgress) Activity {
+	return func(ctx context.Context, token string, days int) (ActivityDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ActivityDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		today := time.Now().Truncate(24 * time.Hour)
+		dailyVerdicts, err := selectDailyVerdicts(ctx, today.AddDate(0, 0, -(days-1)))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ActivityDTO{}, FailedToRetrieveDailyVerdicts
+		}
+
+		distribution, err := selectVerdictsDistribution(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ActivityDTO{}, FailedToRetrieveVerdictsDistribution
+		}
+
+		medianIntervals, err := selectMedianIntervals(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ActivityDTO{}, FailedToRetrieveMedianIntervals
+		}
+
+		progress, err := selectProgress(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ActivityDTO{}, FailedToRetrieveProgress
+		}
+
+		annotators := make(map[int]*AnnotatorActivityDTO)
+		annotator := func(userID int, username string) *AnnotatorActivityDTO {
+			if _, ok := annotators[userID]; !ok {
+				annotators[userID] = &AnnotatorActivityDTO{
+					UserID:         userID,
+					Username:       username,
+					VerdictsPerDay: make([]DailyVerdictsDTO, 0),
+					Progress:       make([]ProgressDTO, 0),
+				}
+			}
+
+			return annotators[userID]
+		}
+
+		for _, dao := range dailyVerdicts {
+			a := annotator(dao.UserID, dao.Username)
+			a.VerdictsPerDay = append(a.VerdictsPerDay, DailyVerdictsDTO{Day: dao.Day.Format(time.DateOnly), Verdicts: dao.Verdicts})
+		}
+
+		for _, dao := range distribution {
+			a := annotator(dao.UserID, dao.Username)
+			switch dao.Categorization {
+			case categorized.VerdictPositive:
+				a.Distribution.Positive += dao.Verdicts
+			case categorized.VerdictIndeterminate:
+				a.Distribution.Indeterminate += dao.Verdicts
+			case categorized.VerdictNegative:
+				a.Distribution.Negative += dao.Verdicts
+			}
+			a.Distribution.Total += dao.Verdicts
+		}
+
+		for _, dao := range medianIntervals {
+			medianSeconds := dao.MedianSeconds
+			annotator(dao.UserID, dao.Username).MedianSecondsBetweenCategorizations = &medianSeconds
+		}
+
+		for _, dao := range progress {
+			a := annotator(dao.UserID, dao.Username)
+			a.Progress = append(a.Progress, ProgressDTO{
+				SearchCriteriaID: dao.SearchCriteriaID,
+				Year:             dao.Year,
+				Month:            dao.Month,
+				Analyzed:         dao.Analyzed,
+				Total:            dao.Total,
+			})
+		}
+
+		report := ActivityDTO{Days: days, Annotators: make([]AnnotatorActivityDTO, 0, len(annotators))}
+		for _, a := range annotators {
+			if a.Distribution.Total > 0 {
+				a.Distribution.IndeterminateRatio = float64(a.Distribution.Indeterminate) / float64(a.Distribution.Total)
+			}
+			report.Annotators = append(report.Annotators, *a)
+		}
+
+		slices.SortFunc(report.Annotators, func(a, b AnnotatorActivityDTO) int {
+			return a.UserID - b.UserID
+		})
+
+		return report, nil
+	}
+}
@@ -0,0 +1,107 @@
+package annotators_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/annotators"
+	"ahbcc/cmd/api/auth"
+)
+
+func TestActivity_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectDailyVerdicts := annotators.MockSelectDailyVerdicts(annotators.MockDailyVerdictsDAOs(), nil)
+	mockSelectVerdictsDistribution := annotators.MockSelectVerdictsDistribution(annotators.MockVerdictsDistributionDAOs(), nil)
+	mockSelectMedianIntervals := annotators.MockSelectMedianIntervals(annotators.MockMedianIntervalDAOs(), nil)
+	mockSelectProgress := annotators.MockSelectProgress(annotators.MockProgressDAOs(), nil)
+
+	activity := annotators.MakeActivity(mockAuthorize, mockSelectDailyVerdicts, mockSelectVerdictsDistribution, mockSelectMedianIntervals, mockSelectProgress)
+
+	medianSeconds := 12.5
+	want := annotators.ActivityDTO{
+		Days: 7,
+		Annotators: []annotators.AnnotatorActivityDTO{
+			{
+				UserID:   1,
+				Username: "first",
+				VerdictsPerDay: []annotators.DailyVerdictsDTO{
+					{Day: "2025-03-01", Verdicts: 10},
+					{Day: "2025-03-02", Verdicts: 5},
+				},
+				Distribution:                        annotators.DistributionDTO{Positive: 6, Negative: 9, Total: 15},
+				MedianSecondsBetweenCategorizations: &medianSeconds,
+				Progress:                            []annotators.ProgressDTO{{SearchCriteriaID: 1, Year: 2025, Month: 3, Analyzed: 15, Total: 100}},
+			},
+			{
+				UserID:         2,
+				Username:       "second",
+				VerdictsPerDay: []annotators.DailyVerdictsDTO{{Day: "2025-03-01", Verdicts: 4}},
+				Distribution:   annotators.DistributionDTO{Positive: 1, Indeterminate: 3, Total: 4, IndeterminateRatio: 0.75},
+				Progress:       []annotators.ProgressDTO{{SearchCriteriaID: 1, Year: 2025, Month: 3, Analyzed: 4, Total: 100}},
+			},
+		},
+	}
+	got, err := activity(context.Background(), "token", 7)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestActivity_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: annotators.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectDailyVerdicts := annotators.MockSelectDailyVerdicts(annotators.MockDailyVerdictsDAOs(), nil)
+		mockSelectVerdictsDistribution := annotators.MockSelectVerdictsDistribution(annotators.MockVerdictsDistributionDAOs(), nil)
+		mockSelectMedianIntervals := annotators.MockSelectMedianIntervals(annotators.MockMedianIntervalDAOs(), nil)
+		mockSelectProgress := annotators.MockSelectProgress(annotators.MockProgressDAOs(), nil)
+
+		activity := annotators.MakeActivity(mockAuthorize, mockSelectDailyVerdicts, mockSelectVerdictsDistribution, mockSelectMedianIntervals, mockSelectProgress)
+
+		want := tt.expected
+		_, got := activity(context.Background(), "token", 7)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestActivity_failsWhenAnySelectThrowsError(t *testing.T) {
+	err := errors.New("failed to select")
+	tests := []struct {
+		dailyVerdictsErr error
+		distributionErr  error
+		medianErr        error
+		progressErr      error
+		expected         error
+	}{
+		{dailyVerdictsErr: err, expected: annotators.FailedToRetrieveDailyVerdicts},
+		{distributionErr: err, expected: annotators.FailedToRetrieveVerdictsDistribution},
+		{medianErr: err, expected: annotators.FailedToRetrieveMedianIntervals},
+		{progressErr: err, expected: annotators.FailedToRetrieveProgress},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockSelectDailyVerdicts := annotators.MockSelectDailyVerdicts(nil, tt.dailyVerdictsErr)
+		mockSelectVerdictsDistribution := annotators.MockSelectVerdictsDistribution(nil, tt.distributionErr)
+		mockSelectMedianIntervals := annotators.MockSelectMedianIntervals(nil, tt.medianErr)
+		mockSelectProgress := annotators.MockSelectProgress(nil, tt.progressErr)
+
+		activity := annotators.MakeActivity(mockAuthorize, mockSelectDailyVerdicts, mockSelectVerdictsDistribution, mockSelectMedianIntervals, mockSelectProgress)
+
+		want := tt.expected
+		_, got := activity(context.Background(), "token", 7)
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,40 @@
+package annotators
+
+import "time"
+
+type (
+	// DailyVerdictsDAO represents the amount of verdicts given by an annotator in a single day
+	DailyVerdictsDAO struct {
+		UserID   int       `json:"user_id"`
+		Username string    `json:"username"`
+		Day      time.Time `json:"day"`
+		Verdicts int       `json:"verdicts"`
+	}
+
+	// VerdictsDistributionDAO represents the amount of times an annotator gave a specific verdict
+	VerdictsDistributionDAO struct {
+		UserID         int    `json:"user_id"`
+		Username       string `json:"username"`
+		Categorization string `json:"categorization"`
+		Verdicts       int    `json:"verdicts"`
+	}
+
+	// MedianIntervalDAO represents the median time, in seconds, between two consecutive categorizations of an annotator
+	MedianIntervalDAO struct {
+		UserID        int     `json:"user_id"`
+		Username      string  `json:"username"`
+		MedianSeconds float64 `json:"median_seconds"`
+	}
+
+	// ProgressDAO represents the amount of tweets of a criteria, year and month analyzed by an annotator, alongside
+	// the total amount of tweets retrieved for that criteria, year and month
+	ProgressDAO struct {
+		UserID           int    `json:"user_id"`
+		Username         string `json:"username"`
+		SearchCriteriaID int    `json:"search_criteria_id"`
+		Year             int    `json:"year"`
+		Month            int    `json:"month"`
+		Analyzed         int    `json:"analyzed"`
+		Total            int    `json:"total"`
+	}
+)
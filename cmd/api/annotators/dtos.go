@@ -0,0 +1,43 @@
+package annotators
+
+type (
+	// ActivityDTO is the response of the /annotators/activity/v1 endpoint
+	ActivityDTO struct {
+		Days       int                    `json:"days"`
+		Annotators []AnnotatorActivityDTO `json:"annotators"`
+	}
+
+	// AnnotatorActivityDTO represents the activity and throughput of a single annotator
+	AnnotatorActivityDTO struct {
+		UserID                              int                `json:"user_id"`
+		Username                            string             `json:"username"`
+		VerdictsPerDay                      []DailyVerdictsDTO `json:"verdicts_per_day"`
+		Distribution                        DistributionDTO    `json:"distribution"`
+		MedianSecondsBetweenCategorizations *float64           `json:"median_seconds_between_categorizations,omitempty"`
+		Progress                            []ProgressDTO      `json:"progress"`
+	}
+
+	// DailyVerdictsDTO represents the amount of verdicts given by an annotator in a single day
+	DailyVerdictsDTO struct {
+		Day      string `json:"day"`
+		Verdicts int    `json:"verdicts"`
+	}
+
+	// DistributionDTO represents how the verdicts of an annotator are distributed among the possible categorizations
+	DistributionDTO struct {
+		Positive           int     `json:"positive"`
+		Indeterminate      int     `json:"indeterminate"`
+		Negative           int     `json:"negative"`
+		Total              int     `json:"total"`
+		IndeterminateRatio float64 `json:"indeterminate_ratio"`
+	}
+
+	// ProgressDTO represents the amount of tweets of a criteria, year and month analyzed by an annotator
+	ProgressDTO struct {
+		SearchCriteriaID int `json:"search_criteria_id"`
+		Year             int `json:"year"`
+		Month            int `json:"month"`
+		Analyzed         int `json:"analyzed"`
+		Total            int `json:"total"`
+	}
+)
@@ -0,0 +1,29 @@
+package annotators
+
+import "errors"
+
+var (
+	FailedToExecuteSelectDailyVerdicts                     = errors.New("failed to execute select daily verdicts")
+	FailedToExecuteCollectRowsInSelectDailyVerdicts        = errors.New("failed to execute collect rows in select daily verdicts")
+	FailedToExecuteSelectVerdictsDistribution              = errors.New("failed to execute select verdicts distribution")
+	FailedToExecuteCollectRowsInSelectVerdictsDistribution = errors.New("failed to execute collect rows in select verdicts distribution")
+	FailedToExecuteSelectMedianIntervals                   = errors.New("failed to execute select median intervals")
+	FailedToExecuteCollectRowsInSelectMedianIntervals      = errors.New("failed to execute collect rows in select median intervals")
+	FailedToExecuteSelectProgress                          = errors.New("failed to execute select progress")
+	FailedToExecuteCollectRowsInSelectProgress             = errors.New("failed to execute collect rows in select progress")
+	FailedToAuthorizeUser                                  = errors.New("failed to authorize user")
+	FailedToRetrieveDailyVerdicts                          = errors.New("failed to retrieve daily verdicts")
+	FailedToRetrieveVerdictsDistribution                   = errors.New("failed to retrieve verdicts distribution")
+	FailedToRetrieveMedianIntervals                        = errors.New("failed to retrieve median intervals")
+	FailedToRetrieveProgress                               = errors.New("failed to retrieve progress")
+	AuthorizationTokenIsRequired                           = errors.New("authorization token is required")
+	InvalidDays                                            = errors.New("invalid days")
+)
+
+const (
+	AuthorizationTokenRequired     string = "Authorization token is required"
+	InvalidQueryParameterFormat    string = "Invalid query parameter format"
+	UserNotAuthorized              string = "User not authorized"
+	InvalidOrExpiredToken          string = "Invalid or expired session token"
+	FailedToRetrieveActivityReport string = "Failed to retrieve activity report"
+)
@@ -0,0 +1,58 @@
+package annotators
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// ActivityHandlerV1 HTTP Handler of the endpoint /annotators/activity/v1. The days query parameter must be between 1 and
+// maxDays
+func ActivityHandlerV1(activity Activity) http.HandlerFunc {
+	const (
+		defaultDays int = 30
+		maxDays     int = 365
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		days := defaultDays
+		daysQueryParamStr := r.URL.Query().Get("days")
+		if daysQueryParamStr != "" {
+			var err error
+			days, err = strconv.Atoi(daysQueryParamStr)
+			if err != nil || days <= 0 || days > maxDays {
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, InvalidDays)
+				return
+			}
+		}
+		ctx = log.With(ctx, log.Param("days", days))
+
+		report, err := activity(ctx, token, days)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveActivityReport, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Activity report successfully retrieved", report, nil)
+	}
+}
@@ -0,0 +1,118 @@
+package annotators_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/annotators"
+	"ahbcc/cmd/api/auth"
+)
+
+func TestActivityHandlerV1_success(t *testing.T) {
+	mockReport := annotators.ActivityDTO{Days: 7, Annotators: []annotators.AnnotatorActivityDTO{{UserID: 1, Username: "first"}}}
+	mockActivity := annotators.MockActivity(mockReport, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/activity/v1?days=7", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	activityHandlerV1 := annotators.ActivityHandlerV1(mockActivity)
+
+	activityHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+
+	var response struct {
+		Data annotators.ActivityDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, mockReport, response.Data)
+}
+
+func TestActivityHandlerV1_successWithTheMaximumDays(t *testing.T) {
+	var gotDays int
+	mockActivity := func(ctx context.Context, token string, days int) (annotators.ActivityDTO, error) {
+		gotDays = days
+		return annotators.ActivityDTO{Days: days}, nil
+	}
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/activity/v1?days=365", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	activityHandlerV1 := annotators.ActivityHandlerV1(mockActivity)
+
+	activityHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, 365, gotDays)
+}
+
+func TestActivityHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockActivity := annotators.MockActivity(annotators.ActivityDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/activity/v1", nil)
+
+	activityHandlerV1 := annotators.ActivityHandlerV1(mockActivity)
+
+	activityHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestActivityHandlerV1_failsWhenDaysIsInvalid(t *testing.T) {
+	for _, days := range []string{"abc", "0", "-1", "366"} {
+		mockActivity := annotators.MockActivity(annotators.ActivityDTO{}, nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/activity/v1?days="+days, nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		activityHandlerV1 := annotators.ActivityHandlerV1(mockActivity)
+
+		activityHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestActivityHandlerV1_failsWhenActivityThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to retrieve activity"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockActivity := annotators.MockActivity(annotators.ActivityDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/activity/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		activityHandlerV1 := annotators.ActivityHandlerV1(mockActivity)
+
+		activityHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
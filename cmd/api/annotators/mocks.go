@@ -0,0 +1,75 @@
+package annotators
+
+import (
+	"context"
+	"time"
+)
+
+// MockSelectDailyVerdicts mocks a SelectDailyVerdicts function
+func MockSelectDailyVerdicts(daos []DailyVerdictsDAO, err error) SelectDailyVerdicts {
+	return func(ctx context.Context, since time.Time) ([]DailyVerdictsDAO, error) {
+		return daos, err
+	}
+}
+
+// MockSelectVerdictsDistribution mocks a SelectVerdictsDistribution function
+func MockSelectVerdictsDistribution(daos []VerdictsDistributionDAO, err error) SelectVerdictsDistribution {
+	return func(ctx context.Context) ([]VerdictsDistributionDAO, error) {
+		return daos, err
+	}
+}
+
+// MockSelectMedianIntervals mocks a SelectMedianIntervals function
+func MockSelectMedianIntervals(daos []MedianIntervalDAO, err error) SelectMedianIntervals {
+	return func(ctx context.Context) ([]MedianIntervalDAO, error) {
+		return daos, err
+	}
+}
+
+// MockSelectProgress mocks a SelectProgress function
+func MockSelectProgress(daos []ProgressDAO, err error) SelectProgress {
+	return func(ctx context.Context) ([]ProgressDAO, error) {
+		return daos, err
+	}
+}
+
+// MockActivity mocks an Activity function
+func MockActivity(report ActivityDTO, err error) Activity {
+	return func(ctx context.Context, token string, days int) (ActivityDTO, error) {
+		return report, err
+	}
+}
+
+// MockDailyVerdictsDAOs mocks a []DailyVerdictsDAO
+func MockDailyVerdictsDAOs() []DailyVerdictsDAO {
+	return []DailyVerdictsDAO{
+		{UserID: 2, Username: "second", Day: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), Verdicts: 4},
+		{UserID: 1, Username: "first", Day: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), Verdicts: 10},
+		{UserID: 1, Username: "first", Day: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC), Verdicts: 5},
+	}
+}
+
+// MockVerdictsDistributionDAOs mocks a []VerdictsDistributionDAO
+func MockVerdictsDistributionDAOs() []VerdictsDistributionDAO {
+	return []VerdictsDistributionDAO{
+		{UserID: 1, Username: "first", Categorization: "POSITIVE", Verdicts: 6},
+		{UserID: 1, Username: "first", Categorization: "NEGATIVE", Verdicts: 9},
+		{UserID: 2, Username: "second", Categorization: "INDETERMINATE", Verdicts: 3},
+		{UserID: 2, Username: "second", Categorization: "POSITIVE", Verdicts: 1},
+	}
+}
+
+// MockMedianIntervalDAOs mocks a []MedianIntervalDAO
+func MockMedianIntervalDAOs() []MedianIntervalDAO {
+	return []MedianIntervalDAO{
+		{UserID: 1, Username: "first", MedianSeconds: 12.5},
+	}
+}
+
+// MockProgressDAOs mocks a []ProgressDAO
+func MockProgressDAOs() []ProgressDAO {
+	return []ProgressDAO{
+		{UserID: 1, Username: "first", SearchCriteriaID: 1, Year: 2025, Month: 3, Analyzed: 15, Total: 100},
+		{UserID: 2, Username: "second", SearchCriteriaID: 1, Year: 2025, Month: 3, Analyzed: 4, Total: 100},
+	}
+}
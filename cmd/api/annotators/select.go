@@ -0,0 +1,143 @@
+package annotators
+
+import (
+	"context"
+	"time"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// SelectDailyVerdicts returns the amount of verdicts given by each annotator per day, since the given date
+	SelectDailyVerdicts func(ctx context.Context, since time.Time) ([]DailyVerdictsDAO, error)
+
+	// SelectVerdictsDistribution returns the amount of verdicts given by each annotator, grouped by categorization
+	SelectVerdictsDistribution func(ctx context.Context) ([]VerdictsDistributionDAO, error)
+
+	// SelectMedianIntervals returns the median time between two consecutive categorizations of each annotator.
+	// The categorizations without a created_at are ignored
+	SelectMedianIntervals func(ctx context.Context) ([]MedianIntervalDAO, error)
+
+	// SelectProgress returns the amount of tweets analyzed by each annotator per criteria, year and month, alongside
+	// the total amount of tweets retrieved for them
+	SelectProgress func(ctx context.Context) ([]ProgressDAO, error)
+)
+
+// MakeSelectDailyVerdicts creates a new SelectDailyVerdicts
+func MakeSelectDailyVerdicts(db database.Connection, collectRows database.CollectRows[DailyVerdictsDAO]) SelectDailyVerdicts {
+	const query string = `
+		SELECT u.id, u.username, DATE(c.created_at) AS day, COUNT(c.id)
+		FROM categorized_tweets AS c
+		INNER JOIN users AS u ON u.id = c.user_id
+		WHERE c.created_at >= $1
+		GROUP BY u.id, u.username, day
+		ORDER BY u.id, day;
+	`
+
+	return func(ctx context.Context, since time.Time) ([]DailyVerdictsDAO, error) {
+		rows, err := db.Query(ctx, query, since)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectDailyVerdicts
+		}
+
+		dailyVerdicts, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectDailyVerdicts
+		}
+
+		return dailyVerdicts, nil
+	}
+}
+
+// MakeSelectVerdictsDistribution creates a new SelectVerdictsDistribution
+func MakeSelectVerdictsDistribution(db database.Connection, collectRows database.CollectRows[VerdictsDistributionDAO]) SelectVerdictsDistribution {
+	const query string = `
+		SELECT u.id, u.username, c.categorization, COUNT(c.id)
+		FROM categorized_tweets AS c
+		INNER JOIN users AS u ON u.id = c.user_id
+		GROUP BY u.id, u.username, c.categorization
+		ORDER BY u.id;
+	`
+
+	return func(ctx context.Context) ([]VerdictsDistributionDAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectVerdictsDistribution
+		}
+
+		distribution, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectVerdictsDistribution
+		}
+
+		return distribution, nil
+	}
+}
+
+// MakeSelectMedianIntervals creates a new SelectMedianIntervals
+func MakeSelectMedianIntervals(db database.Connection, collectRows database.CollectRows[MedianIntervalDAO]) SelectMedianIntervals {
+	const query string = `
+		WITH intervals AS (
+			SELECT user_id, EXTRACT(EPOCH FROM created_at - LAG(created_at) OVER (PARTITION BY user_id ORDER BY created_at)) AS seconds
+			FROM categorized_tweets
+			WHERE created_at IS NOT NULL
+		)
+		SELECT u.id, u.username, PERCENTILE_CONT(0.5) WITHIN GROUP (ORDER BY i.seconds)
+		FROM intervals AS i
+		INNER JOIN users AS u ON u.id = i.user_id
+		WHERE i.seconds IS NOT NULL
+		GROUP BY u.id, u.username
+		ORDER BY u.id;
+	`
+
+	return func(ctx context.Context) ([]MedianIntervalDAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectMedianIntervals
+		}
+
+		medianIntervals, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectMedianIntervals
+		}
+
+		return medianIntervals, nil
+	}
+}
+
+// MakeSelectProgress creates a new SelectProgress
+func MakeSelectProgress(db database.Connection, collectRows database.CollectRows[ProgressDAO]) SelectProgress {
+	const query string = `
+		SELECT u.id, u.username, tc.search_criteria_id, c.tweet_year, c.tweet_month, COUNT(c.id), COALESCE(MAX(s.total_tweets), 0)
+		FROM categorized_tweets AS c
+		INNER JOIN users AS u ON u.id = c.user_id
+		INNER JOIN tweets_search_criteria AS tc ON tc.tweet_id = c.tweet_id
+		LEFT JOIN search_criteria_executions_summary AS s 
+			ON s.search_criteria_id = tc.search_criteria_id AND s.tweets_year = c.tweet_year AND s.tweets_month = c.tweet_month
+		GROUP BY u.id, u.username, tc.search_criteria_id, c.tweet_year, c.tweet_month
+		ORDER BY u.id, tc.search_criteria_id, c.tweet_year, c.tweet_month;
+	`
+
+	return func(ctx context.Context) ([]ProgressDAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectProgress
+		}
+
+		progress, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectProgress
+		}
+
+		return progress, nil
+	}
+}
@@ -0,0 +1,202 @@
+package annotators_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/annotators"
+	"ahbcc/internal/database"
+)
+
+func TestSelectDailyVerdicts_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := annotators.MockDailyVerdictsDAOs()
+	mockCollectRows := database.MockCollectRows[annotators.DailyVerdictsDAO](mockDAOs, nil)
+
+	selectDailyVerdicts := annotators.MakeSelectDailyVerdicts(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectDailyVerdicts(context.Background(), time.Now())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectDailyVerdicts_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[annotators.DailyVerdictsDAO](nil, nil)
+
+	selectDailyVerdicts := annotators.MakeSelectDailyVerdicts(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteSelectDailyVerdicts
+	_, got := selectDailyVerdicts(context.Background(), time.Now())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectDailyVerdicts_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[annotators.DailyVerdictsDAO](nil, errors.New("failed to collect rows"))
+
+	selectDailyVerdicts := annotators.MakeSelectDailyVerdicts(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteCollectRowsInSelectDailyVerdicts
+	_, got := selectDailyVerdicts(context.Background(), time.Now())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectVerdictsDistribution_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := annotators.MockVerdictsDistributionDAOs()
+	mockCollectRows := database.MockCollectRows[annotators.VerdictsDistributionDAO](mockDAOs, nil)
+
+	selectVerdictsDistribution := annotators.MakeSelectVerdictsDistribution(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectVerdictsDistribution(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectVerdictsDistribution_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[annotators.VerdictsDistributionDAO](nil, nil)
+
+	selectVerdictsDistribution := annotators.MakeSelectVerdictsDistribution(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteSelectVerdictsDistribution
+	_, got := selectVerdictsDistribution(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectVerdictsDistribution_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[annotators.VerdictsDistributionDAO](nil, errors.New("failed to collect rows"))
+
+	selectVerdictsDistribution := annotators.MakeSelectVerdictsDistribution(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteCollectRowsInSelectVerdictsDistribution
+	_, got := selectVerdictsDistribution(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectMedianIntervals_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := annotators.MockMedianIntervalDAOs()
+	mockCollectRows := database.MockCollectRows[annotators.MedianIntervalDAO](mockDAOs, nil)
+
+	selectMedianIntervals := annotators.MakeSelectMedianIntervals(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectMedianIntervals(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectMedianIntervals_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[annotators.MedianIntervalDAO](nil, nil)
+
+	selectMedianIntervals := annotators.MakeSelectMedianIntervals(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteSelectMedianIntervals
+	_, got := selectMedianIntervals(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectMedianIntervals_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[annotators.MedianIntervalDAO](nil, errors.New("failed to collect rows"))
+
+	selectMedianIntervals := annotators.MakeSelectMedianIntervals(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteCollectRowsInSelectMedianIntervals
+	_, got := selectMedianIntervals(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectProgress_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := annotators.MockProgressDAOs()
+	mockCollectRows := database.MockCollectRows[annotators.ProgressDAO](mockDAOs, nil)
+
+	selectProgress := annotators.MakeSelectProgress(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectProgress(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectProgress_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[annotators.ProgressDAO](nil, nil)
+
+	selectProgress := annotators.MakeSelectProgress(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteSelectProgress
+	_, got := selectProgress(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectProgress_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[annotators.ProgressDAO](nil, errors.New("failed to collect rows"))
+
+	selectProgress := annotators.MakeSelectProgress(mockPostgresConnection, mockCollectRows)
+
+	want := annotators.FailedToExecuteCollectRowsInSelectProgress
+	_, got := selectProgress(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,16 @@
+package audit
+
+import "time"
+
+// DAO represents an entry of the audit log
+type DAO struct {
+	ID            int       `json:"id"`
+	ActorID       *int      `json:"actor_id"`
+	Action        string    `json:"action"`
+	Target        string    `json:"target"`
+	Status        int       `json:"status"`
+	RequestID     string    `json:"request_id"`
+	IP            string    `json:"ip"`
+	PayloadDigest string    `json:"payload_digest"`
+	OccurredAt    time.Time `json:"occurred_at"`
+}
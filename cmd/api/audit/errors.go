@@ -0,0 +1,26 @@
+package audit
+
+import "errors"
+
+var (
+	FailedToInsertAuditEntry                       = errors.New("failed to insert audit entry")
+	FailedToExecuteSelectAuditEntries              = errors.New("failed to execute select audit entries")
+	FailedToExecuteCollectRowsInSelectAuditEntries = errors.New("failed to execute collect rows in select audit entries")
+	FailedToAuthorizeUser                          = errors.New("failed to authorize user")
+	FailedToRetrieveAuditEntries                   = errors.New("failed to retrieve audit entries")
+	FailedToEncodeAuditEntries                     = errors.New("failed to encode audit entries")
+	AuthorizationTokenIsRequired                   = errors.New("authorization token is required")
+	InvalidActorID                                 = errors.New("invalid actor_id")
+	InvalidDate                                    = errors.New("invalid date, it must be in RFC 3339 format")
+	InvalidBeforeID                                = errors.New("invalid before_id")
+	InvalidLimit                                   = errors.New("invalid limit")
+)
+
+const (
+	AuthorizationTokenRequired  string = "Authorization token is required"
+	InvalidQueryParameterFormat string = "Invalid query parameter format"
+	UserNotAuthorized           string = "User not authorized"
+	InvalidOrExpiredToken       string = "Invalid or expired session token"
+	FailedToQueryAuditLog       string = "Failed to retrieve the audit log"
+	FailedToExportAuditLog      string = "Failed to export the audit log"
+)
@@ -0,0 +1,115 @@
+package audit
+
+import (
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+type (
+	// Filters represents the filters that can be applied when retrieving the entries of the audit log. The entries are
+	// sorted from the newest to the oldest, and BeforeID only retrieves the ones older than the entry of that ID, to
+	// page through them. A Limit of 0 retrieves all of them
+	Filters struct {
+		ActorID   *int
+		Action    string
+		Target    string
+		RequestID string
+		From      *time.Time
+		To        *time.Time
+		BeforeID  int
+		Limit     int
+	}
+
+	// arguments holds the arguments of a query that is being built
+	arguments []any
+)
+
+// add appends the value to the arguments and returns its placeholder
+func (a *arguments) add(value any) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// predicates returns the SQL predicates needed to apply the filters to the audit_log table
+func (f Filters) predicates(args *arguments) []string {
+	predicates := make([]string, 0)
+
+	if f.ActorID != nil {
+		predicates = append(predicates, fmt.Sprintf("actor_id = %s", args.add(*f.ActorID)))
+	}
+
+	if f.Action != "" {
+		predicates = append(predicates, fmt.Sprintf("action = %s", args.add(f.Action)))
+	}
+
+	if f.Target != "" {
+		predicates = append(predicates, fmt.Sprintf("target = %s", args.add(f.Target)))
+	}
+
+	if f.RequestID != "" {
+		predicates = append(predicates, fmt.Sprintf("request_id = %s", args.add(f.RequestID)))
+	}
+
+	if f.From != nil {
+		predicates = append(predicates, fmt.Sprintf("occurred_at >= %s", args.add(*f.From)))
+	}
+
+	if f.To != nil {
+		predicates = append(predicates, fmt.Sprintf("occurred_at < %s", args.add(*f.To)))
+	}
+
+	if f.BeforeID > 0 {
+		predicates = append(predicates, fmt.Sprintf("id < %s", args.add(f.BeforeID)))
+	}
+
+	return predicates
+}
+
+// parseFilters parses the filters from the query params of a request. The dates are expected in RFC 3339 format, and
+// the limit falls back to defaultLimit when it is missing, and to maxLimit when it is greater than it
+func parseFilters(query url.Values, defaultLimit, maxLimit int) (Filters, error) {
+	filters := Filters{
+		Action:    query.Get("action"),
+		Target:    query.Get("target"),
+		RequestID: query.Get("request_id"),
+		Limit:     defaultLimit,
+	}
+
+	if actorIDQueryParam := query.Get("actor_id"); actorIDQueryParam != "" {
+		actorID, err := strconv.Atoi(actorIDQueryParam)
+		if err != nil {
+			return Filters{}, InvalidActorID
+		}
+		filters.ActorID = &actorID
+	}
+
+	for param, filter := range map[string]**time.Time{"from": &filters.From, "to": &filters.To} {
+		if value := query.Get(param); value != "" {
+			parsed, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return Filters{}, InvalidDate
+			}
+			*filter = &parsed
+		}
+	}
+
+	if beforeIDQueryParam := query.Get("before_id"); beforeIDQueryParam != "" {
+		beforeID, err := strconv.Atoi(beforeIDQueryParam)
+		if err != nil || beforeID <= 0 {
+			return Filters{}, InvalidBeforeID
+		}
+		filters.BeforeID = beforeID
+	}
+
+	if limitQueryParam := query.Get("limit"); limitQueryParam != "" {
+		limit, err := strconv.Atoi(limitQueryParam)
+		if err != nil || limit <= 0 {
+			return Filters{}, InvalidLimit
+		}
+		filters.Limit = min(limit, maxLimit)
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,56 @@
+package audit
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseFilters_success(t *testing.T) {
+	actorID := 3
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		query string
+		want  Filters
+	}{
+		{query: "", want: Filters{Limit: 50}},
+		{query: "limit=1000", want: Filters{Limit: 500}},
+		{
+			query: "actor_id=3&action=POST+%2Fcorpus%2Fv1&target=execution_id%3D12&request_id=abc&from=2025-03-01T00:00:00Z&to=2025-03-02T00:00:00Z&before_id=20&limit=10",
+			want:  Filters{ActorID: &actorID, Action: "POST /corpus/v1", Target: "execution_id=12", RequestID: "abc", From: &from, To: &to, BeforeID: 20, Limit: 10},
+		},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+
+		got, err := parseFilters(query, 50, 500)
+
+		assert.Nil(t, err)
+		assert.Equal(t, tt.want, got)
+	}
+}
+
+func TestParseFilters_failsWhenAQueryParamIsInvalid(t *testing.T) {
+	tests := []struct {
+		query string
+		want  error
+	}{
+		{query: "actor_id=abc", want: InvalidActorID},
+		{query: "from=2025-03-01", want: InvalidDate},
+		{query: "to=yesterday", want: InvalidDate},
+		{query: "before_id=0", want: InvalidBeforeID},
+		{query: "limit=-1", want: InvalidLimit},
+	}
+
+	for _, tt := range tests {
+		query, _ := url.ParseQuery(tt.query)
+
+		_, got := parseFilters(query, 50, 500)
+
+		assert.Equal(t, tt.want, got)
+	}
+}
@@ -0,0 +1,87 @@
+package audit
+
+import (
+	"errors"
+	"net/http"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// QueryHandlerV1 HTTP Handler of the endpoint GET /audit/v1
+func QueryHandlerV1(query Query) http.HandlerFunc {
+	const (
+		defaultLimit int = 50
+		maxLimit     int = 500
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		filters, err := parseFilters(r.URL.Query(), defaultLimit, maxLimit)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, err)
+			return
+		}
+		ctx = log.With(ctx, log.Param("query", r.URL.RawQuery))
+
+		entries, err := query(ctx, token, filters)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToQueryAuditLog, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Audit log successfully retrieved", entries, nil)
+	}
+}
+
+// ExportHandlerV1 HTTP Handler of the endpoint GET /audit/export/v1
+func ExportHandlerV1(export Export) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		filters, err := parseFilters(r.URL.Query(), 0, 0)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, err)
+			return
+		}
+		ctx = log.With(ctx, log.Param("query", r.URL.RawQuery))
+
+		data, err := export(ctx, token, filters)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToExportAuditLog, nil, err)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		w.Header().Set("Content-Disposition", "attachment; filename=audit.jsonl")
+		w.Write(data)
+	}
+}
@@ -0,0 +1,170 @@
+package audit_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/audit"
+	"ahbcc/cmd/api/auth"
+)
+
+func TestQueryHandlerV1_success(t *testing.T) {
+	mockQuery := audit.MockQuery(audit.MockDAOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/v1?actor_id=1&limit=2", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	queryHandlerV1 := audit.QueryHandlerV1(mockQuery)
+
+	queryHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+
+	var response struct {
+		Data []audit.DAO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Equal(t, audit.MockDAOs(), response.Data)
+}
+
+func TestQueryHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockQuery := audit.MockQuery(audit.MockDAOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/v1", nil)
+
+	queryHandlerV1 := audit.QueryHandlerV1(mockQuery)
+
+	queryHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestQueryHandlerV1_failsWhenAQueryParamIsInvalid(t *testing.T) {
+	mockQuery := audit.MockQuery(audit.MockDAOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/v1?actor_id=abc", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	queryHandlerV1 := audit.QueryHandlerV1(mockQuery)
+
+	queryHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestQueryHandlerV1_failsWhenQueryThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to query audit log"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockQuery := audit.MockQuery(nil, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		queryHandlerV1 := audit.QueryHandlerV1(mockQuery)
+
+		queryHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestExportHandlerV1_success(t *testing.T) {
+	mockExport := audit.MockExport([]byte("{\"id\":1}\n"), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/export/v1?action=POST+%2Ftweets%2Fv1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	exportHandlerV1 := audit.ExportHandlerV1(mockExport)
+
+	exportHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, "application/x-ndjson", mockResponseWriter.Header().Get("Content-Type"))
+	assert.Equal(t, "attachment; filename=audit.jsonl", mockResponseWriter.Header().Get("Content-Disposition"))
+	assert.Equal(t, "{\"id\":1}\n", mockResponseWriter.Body.String())
+}
+
+func TestExportHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockExport := audit.MockExport(nil, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/export/v1", nil)
+
+	exportHandlerV1 := audit.ExportHandlerV1(mockExport)
+
+	exportHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestExportHandlerV1_failsWhenAQueryParamIsInvalid(t *testing.T) {
+	mockExport := audit.MockExport(nil, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/export/v1?from=yesterday", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	exportHandlerV1 := audit.ExportHandlerV1(mockExport)
+
+	exportHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestExportHandlerV1_failsWhenExportThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to export audit log"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockExport := audit.MockExport(nil, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/audit/export/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		exportHandlerV1 := audit.ExportHandlerV1(mockExport)
+
+		exportHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
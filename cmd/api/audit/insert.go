@@ -0,0 +1,29 @@
+package audit
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// Insert appends an entry to the audit log. The ID and the time in which it occurred are set by the database
+type Insert func(ctx context.Context, entry DAO) error
+
+// MakeInsert creates a new Insert
+func MakeInsert(db database.Connection) Insert {
+	const query string = `
+		INSERT INTO audit_log(actor_id, action, target, status, request_id, ip, payload_digest)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);
+	`
+
+	return func(ctx context.Context, entry DAO) error {
+		_, err := db.Exec(ctx, query, entry.ActorID, entry.Action, entry.Target, entry.Status, entry.RequestID, entry.IP, entry.PayloadDigest)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertAuditEntry
+		}
+
+		return nil
+	}
+}
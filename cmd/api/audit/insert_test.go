@@ -0,0 +1,40 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/audit"
+	"ahbcc/internal/database"
+)
+
+func TestInsert_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockEntry := audit.MockDAOs()[0]
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{mockEntry.ActorID, mockEntry.Action, mockEntry.Target, mockEntry.Status, mockEntry.RequestID, mockEntry.IP, mockEntry.PayloadDigest}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	insert := audit.MakeInsert(mockPostgresConnection)
+
+	got := insert(context.Background(), mockEntry)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsert_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert audit entry"))
+
+	insert := audit.MakeInsert(mockPostgresConnection)
+
+	want := audit.FailedToInsertAuditEntry
+	got := insert(context.Background(), audit.MockDAOs()[0])
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
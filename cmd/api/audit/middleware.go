@@ -0,0 +1,145 @@
+package audit
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"hash"
+	"io"
+	"net/http"
+	"strings"
+
+	"ahbcc/cmd/api/middleware"
+	"ahbcc/internal/log"
+)
+
+// credentialRoutes are the routes whose body carries credentials, as passwords or second factor codes, so not even a
+// digest of it is recorded
+var credentialRoutes = map[string]bool{
+	"POST /auth/signup/v1":         true,
+	"POST /auth/login/v1":          true,
+	"POST /auth/login/2fa/v1":      true,
+	"PUT /auth/password/v1":        true,
+	"POST /auth/password/reset/v1": true,
+	"POST /auth/2fa/confirm/v1":    true,
+	"DELETE /auth/2fa/v1":          true,
+}
+
+// Middleware is an HTTP middleware that records in the audit log, with their status code, the requests that change the
+// state of the application, the ones with a method other than GET, HEAD and OPTIONS, and the ones in which a service
+// identified the user that made them. Their actor is the one set by the auth layer while serving them, and the body of
+// the requests that change the state is recorded as an HMAC-SHA256 digest with the given key, computed while the body
+// is streamed to the handler, except in the credentialRoutes, or when there is no key.
+// It must be wrapped by middleware.RequestID, and it must wrap the router, so it knows the route of the request once
+// it is served
+type Middleware func(next http.Handler) http.Handler
+
+// MakeMiddleware creates a new Middleware
+func MakeMiddleware(insert Insert, payloadKey []byte) Middleware {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := r.Context()
+
+			changesState := r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions
+
+			var body *digestingBody
+			if changesState && len(payloadKey) > 0 && r.Body != nil {
+				body = &digestingBody{ReadCloser: r.Body, mac: hmac.New(sha256.New, payloadKey)}
+				r.Body = body
+			}
+
+			recorder := &statusRecorder{ResponseWriter: w, status: http.StatusOK}
+			next.ServeHTTP(recorder, r)
+
+			request := middleware.RequestFrom(ctx)
+			if request == nil || r.Pattern == "" || (!changesState && !request.Identified) {
+				return
+			}
+
+			err := insert(ctx, DAO{
+				ActorID:       request.ActorID,
+				Action:        r.Pattern,
+				Target:        target(r),
+				Status:        recorder.status,
+				RequestID:     request.ID,
+				IP:            request.IP,
+				PayloadDigest: body.digest(r.Pattern),
+			})
+			if err != nil {
+				log.Error(ctx, err.Error())
+			}
+		})
+	}
+}
+
+// digestingBody is a request body that computes the HMAC of the bytes read from it, so the body doesn't need to be
+// kept in memory to be digested
+type digestingBody struct {
+	io.ReadCloser
+	mac  hash.Hash
+	size int64
+}
+
+// Read reads from the original body, adding the bytes read to the HMAC
+func (d *digestingBody) Read(p []byte) (int, error) {
+	n, err := d.ReadCloser.Read(p)
+	d.mac.Write(p[:n])
+	d.size += int64(n)
+
+	return n, err
+}
+
+// digest returns the hex encoded HMAC-SHA256 of the whole body, reading the part the handler left unread. It is empty
+// when there is no body or no key, or when the route is one of the credentialRoutes
+func (d *digestingBody) digest(pattern string) string {
+	if d == nil || credentialRoutes[pattern] {
+		return ""
+	}
+
+	_, _ = io.Copy(io.Discard, d)
+	if d.size == 0 {
+		return ""
+	}
+
+	return hex.EncodeToString(d.mac.Sum(nil))
+}
+
+// statusRecorder is an http.ResponseWriter that keeps the status code of the response
+type statusRecorder struct {
+	http.ResponseWriter
+	status      int
+	wroteHeader bool
+}
+
+// WriteHeader keeps the status code of the response before writing it
+func (s *statusRecorder) WriteHeader(status int) {
+	if !s.wroteHeader {
+		s.status = status
+		s.wroteHeader = true
+	}
+	s.ResponseWriter.WriteHeader(status)
+}
+
+// Unwrap returns the original http.ResponseWriter, so http.ResponseController can reach it
+func (s *statusRecorder) Unwrap() http.ResponseWriter {
+	return s.ResponseWriter
+}
+
+// target returns the path parameters of the request as name=value pairs joined by '&', in the order of its route
+func target(r *http.Request) string {
+	params := make([]string, 0)
+	for _, segment := range strings.Split(r.Pattern, "/") {
+		if !strings.HasPrefix(segment, "{") || !strings.HasSuffix(segment, "}") {
+			continue
+		}
+
+		name := strings.TrimSuffix(strings.Trim(segment, "{}"), "...")
+		if name == "$" {
+			continue
+		}
+
+		params = append(params, name+"="+r.PathValue(name))
+	}
+
+	return strings.Join(params, "&")
+}
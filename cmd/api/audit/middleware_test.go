@@ -0,0 +1,176 @@
+package audit_test
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/audit"
+	"ahbcc/cmd/api/middleware"
+)
+
+var payloadKey = []byte("payload-key")
+
+// serve serves the given request through the audit middleware, with a router that has the routes used by the tests,
+// and returns the response and the entries recorded
+func serve(insertErr error, req *http.Request) (*httptest.ResponseRecorder, []audit.DAO) {
+	router := http.NewServeMux()
+	router.HandleFunc("PUT /criteria-executions/{execution_id}/v1", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("X-Session-Token") == "token" {
+			middleware.SetActor(r.Context(), 3)
+		}
+
+		body, _ := io.ReadAll(r.Body)
+		if string(body) == "invalid" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+	router.HandleFunc("POST /tweets/v1", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	router.HandleFunc("POST /auth/login/v1", func(w http.ResponseWriter, r *http.Request) {
+		middleware.Identify(r.Context(), 7)
+		w.WriteHeader(http.StatusOK)
+	})
+	router.HandleFunc("GET /auth/oidc/callback/v1", func(w http.ResponseWriter, r *http.Request) {
+		middleware.Identify(r.Context(), 7)
+		w.WriteHeader(http.StatusFound)
+	})
+	router.HandleFunc("GET /criteria/v1", func(w http.ResponseWriter, r *http.Request) {
+		middleware.SetActor(r.Context(), 3)
+		w.WriteHeader(http.StatusOK)
+	})
+
+	entries := make([]audit.DAO, 0)
+	mockInsert := func(ctx context.Context, entry audit.DAO) error {
+		entries = append(entries, entry)
+		return insertErr
+	}
+
+	auditMiddleware := audit.MakeMiddleware(mockInsert, payloadKey)
+	handler := middleware.RequestID(auditMiddleware(router))
+
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	return rr, entries
+}
+
+func TestMiddleware_success(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/criteria-executions/12/v1", strings.NewReader(`{"status":"DONE"}`))
+	req.Header.Set("X-Session-Token", "token")
+	req.Header.Set("X-Request-ID", "request-id")
+	req.RemoteAddr = "10.0.0.1:54321"
+
+	rr, got := serve(nil, req)
+
+	actorID := 3
+	mac := hmac.New(sha256.New, payloadKey)
+	mac.Write([]byte(`{"status":"DONE"}`))
+	want := []audit.DAO{{
+		ActorID:       &actorID,
+		Action:        "PUT /criteria-executions/{execution_id}/v1",
+		Target:        "execution_id=12",
+		Status:        http.StatusOK,
+		RequestID:     "request-id",
+		IP:            "10.0.0.1",
+		PayloadDigest: hex.EncodeToString(mac.Sum(nil)),
+	}}
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Equal(t, want, got)
+}
+
+func TestMiddleware_successWithoutActorWhenTheAuthLayerDidNotResolveIt(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/criteria-executions/12/v1", nil)
+	req.Header.Set("X-Session-Token", "invalid")
+
+	_, got := serve(nil, req)
+
+	assert.Len(t, got, 1)
+	assert.Nil(t, got[0].ActorID)
+	assert.Empty(t, got[0].PayloadDigest)
+}
+
+func TestMiddleware_successRecordingTheFailedRequestsWithTheirStatus(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/criteria-executions/12/v1", strings.NewReader("invalid"))
+
+	rr, got := serve(nil, req)
+
+	assert.Equal(t, http.StatusBadRequest, rr.Code)
+	assert.Len(t, got, 1)
+	assert.Equal(t, http.StatusBadRequest, got[0].Status)
+}
+
+func TestMiddleware_successNotDigestingTheBodyOfTheCredentialRoutes(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/auth/login/v1", strings.NewReader(`{"username":"user","password":"password"}`))
+
+	_, got := serve(nil, req)
+
+	assert.Len(t, got, 1)
+	assert.Equal(t, 7, *got[0].ActorID)
+	assert.Empty(t, got[0].PayloadDigest)
+}
+
+func TestMiddleware_successRecordingTheRequestsIdentifiedByAService(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/auth/oidc/callback/v1", nil)
+
+	rr, got := serve(nil, req)
+
+	assert.Equal(t, http.StatusFound, rr.Code)
+	assert.Len(t, got, 1)
+	assert.Equal(t, 7, *got[0].ActorID)
+	assert.Equal(t, "GET /auth/oidc/callback/v1", got[0].Action)
+}
+
+func TestMiddleware_successNotRecordingTheRequest(t *testing.T) {
+	tests := []struct {
+		method string
+		target string
+	}{
+		{method: http.MethodGet, target: "/criteria/v1"},
+		{method: http.MethodPost, target: "/unknown/v1"},
+	}
+
+	for _, tt := range tests {
+		req := httptest.NewRequest(tt.method, tt.target, nil)
+
+		_, got := serve(nil, req)
+
+		assert.Empty(t, got)
+	}
+}
+
+func TestMiddleware_successWhenInsertThrowsError(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPut, "/criteria-executions/12/v1", nil)
+
+	rr, got := serve(errors.New("failed to insert"), req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Len(t, got, 1)
+}
+
+func TestMiddleware_successDigestingTheWholeBodyWhenTheHandlerDoesNotReadIt(t *testing.T) {
+	body := strings.Repeat("tweet", 1<<20)
+	req := httptest.NewRequest(http.MethodPost, "/tweets/v1", strings.NewReader(body))
+
+	rr, got := serve(nil, req)
+
+	mac := hmac.New(sha256.New, payloadKey)
+	mac.Write([]byte(body))
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	assert.Len(t, got, 1)
+	assert.Equal(t, hex.EncodeToString(mac.Sum(nil)), got[0].PayloadDigest)
+}
@@ -0,0 +1,44 @@
+package audit
+
+import (
+	"context"
+	"time"
+)
+
+// MockInsert mocks an Insert function
+func MockInsert(err error) Insert {
+	return func(ctx context.Context, entry DAO) error {
+		return err
+	}
+}
+
+// MockSelect mocks a Select function
+func MockSelect(daos []DAO, err error) Select {
+	return func(ctx context.Context, filters Filters) ([]DAO, error) {
+		return daos, err
+	}
+}
+
+// MockQuery mocks a Query function
+func MockQuery(entries []DAO, err error) Query {
+	return func(ctx context.Context, token string, filters Filters) ([]DAO, error) {
+		return entries, err
+	}
+}
+
+// MockExport mocks an Export function
+func MockExport(data []byte, err error) Export {
+	return func(ctx context.Context, token string, filters Filters) ([]byte, error) {
+		return data, err
+	}
+}
+
+// MockDAOs mocks a []DAO, from the newest to the oldest
+func MockDAOs() []DAO {
+	actorID := 1
+
+	return []DAO{
+		{ID: 2, ActorID: &actorID, Action: "PUT /criteria-executions/{execution_id}/v1", Target: "execution_id=12", Status: 200, RequestID: "request-2", IP: "10.0.0.1", PayloadDigest: "8d5e957f297893487bd98fa830fa6413", OccurredAt: time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC)},
+		{ID: 1, Action: "POST /tweets/v1", Status: 200, RequestID: "request-1", IP: "10.0.0.2", PayloadDigest: "2c26b46b68ffc68ff99b453c1d304134", OccurredAt: time.Date(2025, time.March, 8, 11, 0, 0, 0, time.UTC)},
+	}
+}
@@ -0,0 +1,72 @@
+package audit
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"slices"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+type (
+	// Query returns the entries of the audit log that match the given filters, from the newest to the oldest. Only the
+	// admins are allowed to do it
+	Query func(ctx context.Context, token string, filters Filters) ([]DAO, error)
+
+	// Export returns all the entries of the audit log that match the given filters as JSON Lines, from the oldest to the
+	// newest. Only the admins are allowed to do it
+	Export func(ctx context.Context, token string, filters Filters) ([]byte, error)
+)
+
+// MakeQuery creates a new Query
+func MakeQuery(authorize auth.Authorize, selectEntries Select) Query {
+	return func(ctx context.Context, token string, filters Filters) ([]DAO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		entries, err := selectEntries(ctx, filters)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveAuditEntries
+		}
+
+		return entries, nil
+	}
+}
+
+// MakeExport creates a new Export
+func MakeExport(authorize auth.Authorize, selectEntries Select) Export {
+	return func(ctx context.Context, token string, filters Filters) ([]byte, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		filters.Limit = 0
+		entries, err := selectEntries(ctx, filters)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveAuditEntries
+		}
+		slices.Reverse(entries)
+
+		var data bytes.Buffer
+		encoder := json.NewEncoder(&data)
+		for _, entry := range entries {
+			err = encoder.Encode(entry)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return nil, FailedToEncodeAuditEntries
+			}
+		}
+
+		return data.Bytes(), nil
+	}
+}
@@ -0,0 +1,113 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/audit"
+	"ahbcc/cmd/api/auth"
+)
+
+func TestQuery_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelect := audit.MockSelect(audit.MockDAOs(), nil)
+
+	query := audit.MakeQuery(mockAuthorize, mockSelect)
+
+	want := audit.MockDAOs()
+	got, err := query(context.Background(), "token", audit.Filters{Limit: 50})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestQuery_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: audit.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelect := audit.MockSelect(audit.MockDAOs(), nil)
+
+		query := audit.MakeQuery(mockAuthorize, mockSelect)
+
+		want := tt.expected
+		_, got := query(context.Background(), "token", audit.Filters{Limit: 50})
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestQuery_failsWhenSelectThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelect := audit.MockSelect(nil, audit.FailedToExecuteSelectAuditEntries)
+
+	query := audit.MakeQuery(mockAuthorize, mockSelect)
+
+	want := audit.FailedToRetrieveAuditEntries
+	_, got := query(context.Background(), "token", audit.Filters{Limit: 50})
+
+	assert.Equal(t, want, got)
+}
+
+func TestExport_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	var selectedFilters audit.Filters
+	mockSelect := func(ctx context.Context, filters audit.Filters) ([]audit.DAO, error) {
+		selectedFilters = filters
+		return audit.MockDAOs(), nil
+	}
+
+	export := audit.MakeExport(mockAuthorize, mockSelect)
+
+	want := `{"id":1,"actor_id":null,"action":"POST /tweets/v1","target":"","status":200,"request_id":"request-1","ip":"10.0.0.2","payload_digest":"2c26b46b68ffc68ff99b453c1d304134","occurred_at":"2025-03-08T11:00:00Z"}
+{"id":2,"actor_id":1,"action":"PUT /criteria-executions/{execution_id}/v1","target":"execution_id=12","status":200,"request_id":"request-2","ip":"10.0.0.1","payload_digest":"8d5e957f297893487bd98fa830fa6413","occurred_at":"2025-03-08T12:00:00Z"}
+`
+	got, err := export(context.Background(), "token", audit.Filters{Limit: 50})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, string(got))
+	assert.Equal(t, 0, selectedFilters.Limit)
+}
+
+func TestExport_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: audit.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelect := audit.MockSelect(audit.MockDAOs(), nil)
+
+		export := audit.MakeExport(mockAuthorize, mockSelect)
+
+		want := tt.expected
+		_, got := export(context.Background(), "token", audit.Filters{})
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestExport_failsWhenSelectThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelect := audit.MockSelect(nil, audit.FailedToExecuteSelectAuditEntries)
+
+	export := audit.MakeExport(mockAuthorize, mockSelect)
+
+	want := audit.FailedToRetrieveAuditEntries
+	_, got := export(context.Background(), "token", audit.Filters{})
+
+	assert.Equal(t, want, got)
+}
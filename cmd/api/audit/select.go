@@ -0,0 +1,49 @@
+package audit
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// Select returns the entries of the audit log that match the given filters, from the newest to the oldest
+type Select func(ctx context.Context, filters Filters) ([]DAO, error)
+
+// MakeSelect creates a new Select
+func MakeSelect(db database.Connection, collectRows database.CollectRows[DAO]) Select {
+	const query string = `
+		SELECT id, actor_id, action, target, status, request_id, ip, payload_digest, occurred_at
+		FROM audit_log
+		WHERE %s
+		ORDER BY id DESC
+		%s;
+	`
+
+	return func(ctx context.Context, filters Filters) ([]DAO, error) {
+		args := make(arguments, 0)
+		predicates := append([]string{"TRUE"}, filters.predicates(&args)...)
+
+		var limit string
+		if filters.Limit > 0 {
+			limit = "LIMIT " + args.add(filters.Limit)
+		}
+
+		queryToExecute := fmt.Sprintf(query, strings.Join(predicates, " AND "), limit)
+		rows, err := db.Query(ctx, queryToExecute, args...)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectAuditEntries
+		}
+
+		entries, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectAuditEntries
+		}
+
+		return entries, nil
+	}
+}
@@ -0,0 +1,74 @@
+package audit_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/audit"
+	"ahbcc/internal/database"
+)
+
+func TestSelect_success(t *testing.T) {
+	actorID := 1
+	from := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		filters audit.Filters
+		args    []any
+	}{
+		{filters: audit.Filters{}, args: []any{}},
+		{filters: audit.Filters{Limit: 50}, args: []any{50}},
+		{filters: audit.Filters{ActorID: &actorID, Action: "POST /tweets/v1", From: &from, BeforeID: 10, Limit: 50}, args: []any{1, "POST /tweets/v1", from, 10, 50}},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRows := new(database.MockPgxRows)
+		mockPostgresConnection.On("Query", mock.Anything, mock.Anything, tt.args).Return(mockPgxRows, nil)
+		mockDAOs := audit.MockDAOs()
+		mockCollectRows := database.MockCollectRows[audit.DAO](mockDAOs, nil)
+
+		selectEntries := audit.MakeSelect(mockPostgresConnection, mockCollectRows)
+
+		want := mockDAOs
+		got, err := selectEntries(context.Background(), tt.filters)
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestSelect_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[audit.DAO](nil, nil)
+
+	selectEntries := audit.MakeSelect(mockPostgresConnection, mockCollectRows)
+
+	want := audit.FailedToExecuteSelectAuditEntries
+	_, got := selectEntries(context.Background(), audit.Filters{})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelect_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[audit.DAO](nil, errors.New("failed to collect rows"))
+
+	selectEntries := audit.MakeSelect(mockPostgresConnection, mockCollectRows)
+
+	want := audit.FailedToExecuteCollectRowsInSelectAuditEntries
+	_, got := selectEntries(context.Background(), audit.Filters{})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
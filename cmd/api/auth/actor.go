@@ -0,0 +1,22 @@
+package auth
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/middleware"
+	"ahbcc/cmd/api/user/session"
+)
+
+// IdentifyActor wraps the given session.SelectUserIDByToken, so the user that owns the token is set as the actor of the
+// request in the audit log once it is retrieved
+func IdentifyActor(selectUserIDByToken session.SelectUserIDByToken) session.SelectUserIDByToken {
+	return func(ctx context.Context, token string) (int, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			return userID, err
+		}
+		middleware.SetActor(ctx, userID)
+
+		return userID, nil
+	}
+}
@@ -0,0 +1,50 @@
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/middleware"
+	"ahbcc/cmd/api/user/session"
+)
+
+// requestContext serves a request through middleware.RequestID and runs the given function with its context
+func requestContext(run func(ctx context.Context)) {
+	handler := middleware.RequestID(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		run(r.Context())
+	}))
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestIdentifyActor_success(t *testing.T) {
+	selectUserIDByToken := auth.IdentifyActor(session.MockSelectUserIDByToken(5, nil))
+
+	var got *middleware.Request
+	requestContext(func(ctx context.Context) {
+		userID, err := selectUserIDByToken(ctx, "token")
+		assert.Nil(t, err)
+		assert.Equal(t, 5, userID)
+		got = middleware.RequestFrom(ctx)
+	})
+
+	assert.Equal(t, 5, *got.ActorID)
+	assert.False(t, got.Identified)
+}
+
+func TestIdentifyActor_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	selectUserIDByToken := auth.IdentifyActor(session.MockSelectUserIDByToken(-1, session.NoUserIDFoundForTheGivenToken))
+
+	var got *middleware.Request
+	requestContext(func(ctx context.Context) {
+		_, err := selectUserIDByToken(ctx, "token")
+		assert.Equal(t, session.NoUserIDFoundForTheGivenToken, err)
+		got = middleware.RequestFrom(ctx)
+	})
+
+	assert.Nil(t, got.ActorID)
+}
@@ -0,0 +1,33 @@
+package attempts
+
+import "time"
+
+type (
+	// DAO represents a login attempt of the audit trail
+	DAO struct {
+		Username    string    `json:"username"`
+		IP          string    `json:"ip"`
+		Outcome     string    `json:"outcome"`
+		AttemptedAt time.Time `json:"attempted_at"`
+	}
+
+	// LockoutDAO represents the lockout state of a username. LockedUntil is nil when the account isn't locked, and the
+	// failed attempts previous to ResetAt aren't taken into account
+	LockoutDAO struct {
+		Username    string     `json:"username"`
+		LockedUntil *time.Time `json:"locked_until"`
+		ResetAt     time.Time  `json:"reset_at"`
+	}
+)
+
+const (
+	OutcomeSucceeded string = "SUCCEEDED"
+	OutcomeFailed    string = "FAILED"
+	OutcomeThrottled string = "THROTTLED"
+	OutcomeLocked    string = "LOCKED"
+)
+
+const (
+	ByUsername string = "username"
+	ByIP       string = "ip"
+)
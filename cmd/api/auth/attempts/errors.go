@@ -0,0 +1,18 @@
+package attempts
+
+import "errors"
+
+var (
+	FailedToInsertLoginAttempt                 = errors.New("failed to insert login attempt")
+	FailedToExecuteSelectFailures              = errors.New("failed to execute select failures")
+	FailedToExecuteCollectRowsInSelectFailures = errors.New("failed to execute collect rows in select failures")
+	InvalidFailuresKey                         = errors.New("invalid failures key, it must be the username or the ip")
+	FailedToExecuteSelectLockout               = errors.New("failed to execute select lockout")
+	FailedToUpsertLockout                      = errors.New("failed to upsert lockout")
+	FailedToCheckLoginAttempts                 = errors.New("failed to check login attempts")
+	FailedToRecordLoginAttempt                 = errors.New("failed to record login attempt")
+	FailedToUnlockAccount                      = errors.New("failed to unlock account")
+	TooManyLoginAttempts                       = errors.New("too many failed login attempts")
+	AccountLocked                              = errors.New("account locked due too many failed login attempts")
+	AccountNotLocked                           = errors.New("account not locked")
+)
@@ -0,0 +1,29 @@
+package attempts
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// Insert inserts a login attempt into the 'login_attempts' table, the audit trail of the logins
+type Insert func(ctx context.Context, attempt DAO) error
+
+// MakeInsert creates a new Insert
+func MakeInsert(db database.Connection) Insert {
+	const query string = `
+		INSERT INTO login_attempts(username, ip, outcome, attempted_at)
+		VALUES ($1, $2, $3, $4);
+	`
+
+	return func(ctx context.Context, attempt DAO) error {
+		_, err := db.Exec(ctx, query, attempt.Username, attempt.IP, attempt.Outcome, attempt.AttemptedAt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertLoginAttempt
+		}
+
+		return nil
+	}
+}
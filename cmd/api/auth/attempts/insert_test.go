@@ -0,0 +1,40 @@
+package attempts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/internal/database"
+)
+
+func TestInsert_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockAttempt := attempts.MockDAO(attempts.OutcomeFailed)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{mockAttempt.Username, mockAttempt.IP, mockAttempt.Outcome, mockAttempt.AttemptedAt}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	insertAttempt := attempts.MakeInsert(mockPostgresConnection)
+
+	got := insertAttempt(context.Background(), mockAttempt)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsert_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert login attempt"))
+
+	insertAttempt := attempts.MakeInsert(mockPostgresConnection)
+
+	want := attempts.FailedToInsertLoginAttempt
+	got := insertAttempt(context.Background(), attempts.MockDAO(attempts.OutcomeFailed))
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,124 @@
+package attempts
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// sweepInterval is how often the memory store evicts the failures and the lockouts that are no longer taken into account
+const sweepInterval time.Duration = time.Hour
+
+// memoryState is the state of the memory store. The times of the failures are kept by username and by IP, from the
+// oldest to the newest, so only the failures of the requested key are scanned
+type memoryState struct {
+	mu        sync.Mutex
+	usernames map[string][]time.Time
+	ips       map[string][]time.Time
+	lockouts  map[string]LockoutDAO
+	sweptAt   time.Time
+}
+
+// MakeMemoryStore creates a new Store that keeps its state in-process. The state is lost when the application restarts
+// and it isn't shared between instances, so deployments with more than one instance should use MakePostgresStore
+func MakeMemoryStore() Store {
+	state := &memoryState{
+		usernames: make(map[string][]time.Time),
+		ips:       make(map[string][]time.Time),
+		lockouts:  make(map[string]LockoutDAO),
+		sweptAt:   time.Now(),
+	}
+
+	return Store{
+		SelectFailures: func(ctx context.Context, by string, value string, since time.Time, limit int) ([]time.Time, error) {
+			state.mu.Lock()
+			defer state.mu.Unlock()
+
+			var failures []time.Time
+			switch by {
+			case ByUsername:
+				failures = state.usernames[value]
+			case ByIP:
+				failures = state.ips[value]
+			default:
+				return nil, InvalidFailuresKey
+			}
+
+			times := make([]time.Time, 0, limit)
+			for i := len(failures) - 1; i >= 0 && len(times) < limit && failures[i].After(since); i-- {
+				times = append(times, failures[i])
+			}
+
+			return times, nil
+		},
+		AddFailure: func(ctx context.Context, attempt DAO) error {
+			state.mu.Lock()
+			defer state.mu.Unlock()
+
+			expiredAt := attempt.AttemptedAt.Add(-lockoutWindow)
+			state.usernames[attempt.Username] = append(unexpired(state.usernames[attempt.Username], expiredAt), attempt.AttemptedAt)
+			state.ips[attempt.IP] = append(unexpired(state.ips[attempt.IP], expiredAt), attempt.AttemptedAt)
+			state.sweepIfDue(time.Now())
+
+			return nil
+		},
+		SelectLockout: func(ctx context.Context, username string) (LockoutDAO, error) {
+			state.mu.Lock()
+			defer state.mu.Unlock()
+
+			lockout, found := state.lockouts[username]
+			if !found {
+				return LockoutDAO{Username: username}, nil
+			}
+
+			return lockout, nil
+		},
+		UpsertLockout: func(ctx context.Context, lockout LockoutDAO) error {
+			state.mu.Lock()
+			defer state.mu.Unlock()
+
+			state.lockouts[lockout.Username] = lockout
+			state.sweepIfDue(time.Now())
+
+			return nil
+		},
+	}
+}
+
+// sweepIfDue evicts, once every sweepInterval, the failures older than the lockout window, the keys left without
+// failures and the lockouts that no longer lock their accounts nor reset their failures. It must be called holding the
+// lock of the state
+func (s *memoryState) sweepIfDue(now time.Time) {
+	if now.Sub(s.sweptAt) < sweepInterval {
+		return
+	}
+	s.sweptAt = now
+
+	expiredAt := now.Add(-lockoutWindow)
+	for _, failures := range []map[string][]time.Time{s.usernames, s.ips} {
+		for key, times := range failures {
+			remaining := unexpired(times, expiredAt)
+			if len(remaining) == 0 {
+				delete(failures, key)
+			} else {
+				failures[key] = remaining
+			}
+		}
+	}
+
+	for username, lockout := range s.lockouts {
+		if (lockout.LockedUntil == nil || !now.Before(*lockout.LockedUntil)) && !lockout.ResetAt.After(expiredAt) {
+			delete(s.lockouts, username)
+		}
+	}
+}
+
+// unexpired returns the times after the given expiration. As the times are sorted, the expired ones are at the start
+func unexpired(times []time.Time, expiredAt time.Time) []time.Time {
+	expired := 0
+	for expired < len(times) && !times[expired].After(expiredAt) {
+		expired++
+	}
+
+	return times[expired:]
+}
@@ -0,0 +1,62 @@
+package attempts
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStateSweepIfDue_successEvictingTheExpiredFailuresAndLockouts(t *testing.T) {
+	now := time.Now()
+	lockedUntil := now.Add(time.Hour)
+	unlockedAt := now.Add(-time.Hour)
+	state := &memoryState{
+		usernames: map[string][]time.Time{
+			"expired": {now.Add(-2 * lockoutWindow)},
+			"partial": {now.Add(-2 * lockoutWindow), now.Add(-time.Minute)},
+		},
+		ips: map[string][]time.Time{
+			"1.1.1.1": {now.Add(-2 * lockoutWindow)},
+		},
+		lockouts: map[string]LockoutDAO{
+			"locked":   {Username: "locked", LockedUntil: &lockedUntil, ResetAt: now.Add(-2 * lockoutWindow)},
+			"unlocked": {Username: "unlocked", LockedUntil: &unlockedAt, ResetAt: now.Add(-2 * lockoutWindow)},
+			"reset":    {Username: "reset", ResetAt: now.Add(-time.Minute)},
+			"stale":    {Username: "stale", ResetAt: now.Add(-2 * lockoutWindow)},
+		},
+		sweptAt: now.Add(-sweepInterval),
+	}
+
+	state.sweepIfDue(now)
+
+	assert.Equal(t, map[string][]time.Time{"partial": {now.Add(-time.Minute)}}, state.usernames)
+	assert.Empty(t, state.ips)
+	assert.ElementsMatch(t, []string{"locked", "reset"}, keys(state.lockouts))
+	assert.Equal(t, now, state.sweptAt)
+}
+
+func TestMemoryStateSweepIfDue_successWithoutSweepingBeforeTheInterval(t *testing.T) {
+	now := time.Now()
+	state := &memoryState{
+		usernames: map[string][]time.Time{"expired": {now.Add(-2 * lockoutWindow)}},
+		ips:       map[string][]time.Time{},
+		lockouts:  map[string]LockoutDAO{"stale": {Username: "stale", ResetAt: now.Add(-2 * lockoutWindow)}},
+		sweptAt:   now.Add(-time.Minute),
+	}
+
+	state.sweepIfDue(now)
+
+	assert.Len(t, state.usernames, 1)
+	assert.Len(t, state.lockouts, 1)
+}
+
+// keys returns the keys of the lockouts
+func keys(lockouts map[string]LockoutDAO) []string {
+	usernames := make([]string, 0, len(lockouts))
+	for username := range lockouts {
+		usernames = append(usernames, username)
+	}
+
+	return usernames
+}
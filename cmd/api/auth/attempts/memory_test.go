@@ -0,0 +1,71 @@
+package attempts_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/attempts"
+)
+
+func TestMemoryStore_successSelectingTheNewestFailuresOfTheKey(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	now := time.Now()
+	mockFailures := []attempts.DAO{
+		{Username: "username", IP: "1.1.1.1", Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-30 * time.Minute)},
+		{Username: "username", IP: "2.2.2.2", Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-3 * time.Minute)},
+		{Username: "another", IP: "1.1.1.1", Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-2 * time.Minute)},
+		{Username: "username", IP: "1.1.1.1", Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-time.Minute)},
+	}
+	for _, failure := range mockFailures {
+		_ = store.AddFailure(context.Background(), failure)
+	}
+
+	tests := []struct {
+		by       string
+		value    string
+		limit    int
+		expected []time.Time
+	}{
+		{by: attempts.ByUsername, value: "username", limit: 5, expected: []time.Time{now.Add(-time.Minute), now.Add(-3 * time.Minute)}},
+		{by: attempts.ByUsername, value: "username", limit: 1, expected: []time.Time{now.Add(-time.Minute)}},
+		{by: attempts.ByIP, value: "1.1.1.1", limit: 5, expected: []time.Time{now.Add(-time.Minute), now.Add(-2 * time.Minute)}},
+		{by: attempts.ByIP, value: "3.3.3.3", limit: 5, expected: []time.Time{}},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got, err := store.SelectFailures(context.Background(), tt.by, tt.value, now.Add(-15*time.Minute), tt.limit)
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestMemoryStore_successStoringTheLockouts(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	lockedUntil := time.Now().Add(time.Hour)
+	mockLockout := attempts.LockoutDAO{Username: "username", LockedUntil: &lockedUntil, ResetAt: time.Now()}
+
+	got, err := store.SelectLockout(context.Background(), "username")
+	assert.Nil(t, err)
+	assert.Equal(t, attempts.LockoutDAO{Username: "username"}, got)
+
+	err = store.UpsertLockout(context.Background(), mockLockout)
+	assert.Nil(t, err)
+
+	got, err = store.SelectLockout(context.Background(), "username")
+	assert.Nil(t, err)
+	assert.Equal(t, mockLockout, got)
+}
+
+func TestMemoryStore_failsWhenTheFailuresKeyIsInvalid(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+
+	want := attempts.InvalidFailuresKey
+	_, got := store.SelectFailures(context.Background(), "password", "value", time.Now(), 5)
+
+	assert.Equal(t, want, got)
+}
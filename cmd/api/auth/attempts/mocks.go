@@ -0,0 +1,93 @@
+package attempts
+
+import (
+	"context"
+	"time"
+)
+
+// MockInsert mocks an Insert function
+func MockInsert(err error) Insert {
+	return func(ctx context.Context, attempt DAO) error {
+		return err
+	}
+}
+
+// MockSelectFailures mocks a SelectFailures function
+func MockSelectFailures(failures []time.Time, err error) SelectFailures {
+	return func(ctx context.Context, by string, value string, since time.Time, limit int) ([]time.Time, error) {
+		return failures, err
+	}
+}
+
+// MockAddFailure mocks an AddFailure function
+func MockAddFailure(err error) AddFailure {
+	return func(ctx context.Context, attempt DAO) error {
+		return err
+	}
+}
+
+// MockSelectLockout mocks a SelectLockout function
+func MockSelectLockout(lockout LockoutDAO, err error) SelectLockout {
+	return func(ctx context.Context, username string) (LockoutDAO, error) {
+		return lockout, err
+	}
+}
+
+// MockUpsertLockout mocks an UpsertLockout function
+func MockUpsertLockout(err error) UpsertLockout {
+	return func(ctx context.Context, lockout LockoutDAO) error {
+		return err
+	}
+}
+
+// MockCheck mocks a Check function
+func MockCheck(retryAt time.Time, err error) Check {
+	return func(ctx context.Context, username string, ip string) (time.Time, error) {
+		return retryAt, err
+	}
+}
+
+// MockRecord mocks a Record function
+func MockRecord(err error) Record {
+	return func(ctx context.Context, attempt DAO) error {
+		return err
+	}
+}
+
+// MockUnlock mocks an Unlock function
+func MockUnlock(err error) Unlock {
+	return func(ctx context.Context, username string) error {
+		return err
+	}
+}
+
+// MockStore mocks a Store whose functions return the given values
+func MockStore(failures []time.Time, lockout LockoutDAO, err error) Store {
+	return Store{
+		SelectFailures: MockSelectFailures(failures, err),
+		AddFailure:     MockAddFailure(err),
+		SelectLockout:  MockSelectLockout(lockout, err),
+		UpsertLockout:  MockUpsertLockout(err),
+	}
+}
+
+// MockLimits mocks the Limits
+func MockLimits() Limits {
+	return Limits{
+		Window:                 15 * time.Minute,
+		MaxFailuresPerUsername: 3,
+		MaxFailuresPerIP:       5,
+		LockoutThreshold:       4,
+		LockoutDuration:        time.Hour,
+	}
+}
+
+// MockDAO mocks a login attempt DAO with the given outcome
+func MockDAO(outcome string) DAO {
+	return DAO{
+		Username:    "username",
+		IP:          "127.0.0.1",
+		Outcome:     outcome,
+		AttemptedAt: time.Now(),
+	}
+}
@@ -0,0 +1,80 @@
+package attempts
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// MakeSelectFailures creates a new SelectFailures that reads the failed attempts from the 'login_attempts' table
+func MakeSelectFailures(db database.Connection, collectRows database.CollectRows[time.Time]) SelectFailures {
+	queries := map[string]string{
+		ByUsername: `
+			SELECT attempted_at
+			FROM login_attempts
+			WHERE username = $1
+			  AND outcome = 'FAILED'
+			  AND attempted_at > $2
+			ORDER BY attempted_at DESC
+			LIMIT $3;
+		`,
+		ByIP: `
+			SELECT attempted_at
+			FROM login_attempts
+			WHERE ip = $1
+			  AND outcome = 'FAILED'
+			  AND attempted_at > $2
+			ORDER BY attempted_at DESC
+			LIMIT $3;
+		`,
+	}
+
+	return func(ctx context.Context, by string, value string, since time.Time, limit int) ([]time.Time, error) {
+		query, found := queries[by]
+		if !found {
+			log.Error(ctx, InvalidFailuresKey.Error())
+			return nil, InvalidFailuresKey
+		}
+
+		rows, err := db.Query(ctx, query, value, since, limit)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectFailures
+		}
+
+		failures, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectFailures
+		}
+
+		return failures, nil
+	}
+}
+
+// MakeSelectLockout creates a new SelectLockout that reads the lockout state from the 'login_lockouts' table
+func MakeSelectLockout(db database.Connection) SelectLockout {
+	const query string = `
+		SELECT username, locked_until, reset_at
+		FROM login_lockouts
+		WHERE username = $1;
+	`
+
+	return func(ctx context.Context, username string) (LockoutDAO, error) {
+		var lockout LockoutDAO
+		err := db.QueryRow(ctx, query, username).Scan(&lockout.Username, &lockout.LockedUntil, &lockout.ResetAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return LockoutDAO{Username: username}, nil
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return LockoutDAO{}, FailedToExecuteSelectLockout
+		}
+
+		return lockout, nil
+	}
+}
@@ -0,0 +1,125 @@
+package attempts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/internal/database"
+)
+
+func TestSelectFailures_success(t *testing.T) {
+	for _, by := range []string{attempts.ByUsername, attempts.ByIP} {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRows := new(database.MockPgxRows)
+		since := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+		mockPostgresConnection.On("Query", mock.Anything, mock.Anything, []any{"value", since, 3}).Return(mockPgxRows, nil)
+		mockFailures := []time.Time{since.Add(2 * time.Minute), since.Add(time.Minute)}
+		mockCollectRows := database.MockCollectRows[time.Time](mockFailures, nil)
+
+		selectFailures := attempts.MakeSelectFailures(mockPostgresConnection, mockCollectRows)
+
+		want := mockFailures
+		got, err := selectFailures(context.Background(), by, "value", since, 3)
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestSelectFailures_failsWhenTheKeyIsInvalid(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockCollectRows := database.MockCollectRows[time.Time](nil, nil)
+
+	selectFailures := attempts.MakeSelectFailures(mockPostgresConnection, mockCollectRows)
+
+	want := attempts.InvalidFailuresKey
+	_, got := selectFailures(context.Background(), "password", "value", time.Now(), 3)
+
+	assert.Equal(t, want, got)
+}
+
+func TestSelectFailures_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[time.Time](nil, nil)
+
+	selectFailures := attempts.MakeSelectFailures(mockPostgresConnection, mockCollectRows)
+
+	want := attempts.FailedToExecuteSelectFailures
+	_, got := selectFailures(context.Background(), attempts.ByUsername, "value", time.Now(), 3)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectFailures_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[time.Time](nil, errors.New("failed to collect rows"))
+
+	selectFailures := attempts.MakeSelectFailures(mockPostgresConnection, mockCollectRows)
+
+	want := attempts.FailedToExecuteCollectRowsInSelectFailures
+	_, got := selectFailures(context.Background(), attempts.ByIP, "value", time.Now(), 3)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectLockout_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	lockedUntil := time.Date(2025, time.March, 1, 1, 0, 0, 0, time.UTC)
+	mockLockout := attempts.LockoutDAO{Username: "username", LockedUntil: &lockedUntil, ResetAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	database.MockScan(mockPgxRow, []any{mockLockout.Username, mockLockout.LockedUntil, mockLockout.ResetAt}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectLockout := attempts.MakeSelectLockout(mockPostgresConnection)
+
+	want := mockLockout
+	got, err := selectLockout(context.Background(), "username")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectLockout_successWhenTheUsernameHasNoLockout(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectLockout := attempts.MakeSelectLockout(mockPostgresConnection)
+
+	want := attempts.LockoutDAO{Username: "username"}
+	got, err := selectLockout(context.Background(), "username")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestSelectLockout_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(errors.New("failed to execute select"))
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectLockout := attempts.MakeSelectLockout(mockPostgresConnection)
+
+	want := attempts.FailedToExecuteSelectLockout
+	_, got := selectLockout(context.Background(), "username")
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,43 @@
+package attempts
+
+import (
+	"context"
+	"time"
+
+	"ahbcc/internal/database"
+)
+
+type (
+	// SelectFailures retrieves the times of the newest failed login attempts, up to the given limit, of the given
+	// username or IP since the given time, excluded. The newest attempt is the first one
+	SelectFailures func(ctx context.Context, by string, value string, since time.Time, limit int) ([]time.Time, error)
+
+	// AddFailure stores a failed login attempt, so it is retrieved by SelectFailures
+	AddFailure func(ctx context.Context, attempt DAO) error
+
+	// SelectLockout retrieves the lockout state of the given username. If there is none, an empty state is returned
+	SelectLockout func(ctx context.Context, username string) (LockoutDAO, error)
+
+	// UpsertLockout stores the lockout state of a username, replacing the previous one
+	UpsertLockout func(ctx context.Context, lockout LockoutDAO) error
+
+	// Store keeps the state of the login attempts used to throttle the logins and to lock the accounts
+	Store struct {
+		SelectFailures SelectFailures
+		AddFailure     AddFailure
+		SelectLockout  SelectLockout
+		UpsertLockout  UpsertLockout
+	}
+)
+
+// MakePostgresStore creates a new Store that keeps its state in the database, so it is shared between all the instances
+// of the application
+func MakePostgresStore(db database.Connection, collectRows database.CollectRows[time.Time]) Store {
+	return Store{
+		SelectFailures: MakeSelectFailures(db, collectRows),
+		// The failed attempts are already inserted in the audit trail, which is where SelectFailures reads them from
+		AddFailure:    func(ctx context.Context, attempt DAO) error { return nil },
+		SelectLockout: MakeSelectLockout(db),
+		UpsertLockout: MakeUpsertLockout(db),
+	}
+}
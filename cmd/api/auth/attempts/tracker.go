@@ -0,0 +1,174 @@
+package attempts
+
+import (
+	"context"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+type (
+	// Check verifies if a login attempt of the given username, from the given IP, is allowed. When it isn't, it returns
+	// AccountLocked or TooManyLoginAttempts with the time from which the login can be retried
+	Check func(ctx context.Context, username string, ip string) (time.Time, error)
+
+	// Record inserts the given login attempt into the audit trail. A failed attempt is also added to the failures of
+	// its username and its IP, locking the account when they reach the lockout threshold, while a successful one resets
+	// the failures of its username
+	Record func(ctx context.Context, attempt DAO) error
+
+	// Unlock unlocks the account of the given username before its lock expires, resetting its failures
+	Unlock func(ctx context.Context, username string) error
+
+	// Limits configures the failed login attempts allowed in the sliding Window, per username and per IP, and the
+	// amount of failures of a username, since its last successful login, that lock its account for the LockoutDuration.
+	// A limit of 0 disables it
+	Limits struct {
+		Window                 time.Duration
+		MaxFailuresPerUsername int
+		MaxFailuresPerIP       int
+		LockoutThreshold       int
+		LockoutDuration        time.Duration
+	}
+)
+
+// lockoutWindow is how long the failures of a username are taken into account to lock its account
+const lockoutWindow time.Duration = 24 * time.Hour
+
+// MakeCheck creates a new Check
+func MakeCheck(store Store, limits Limits) Check {
+	return func(ctx context.Context, username string, ip string) (time.Time, error) {
+		now := time.Now()
+
+		lockout, err := store.SelectLockout(ctx, username)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return time.Time{}, FailedToCheckLoginAttempts
+		}
+
+		if lockout.LockedUntil != nil && now.Before(*lockout.LockedUntil) {
+			log.Warn(ctx, AccountLocked.Error())
+			return *lockout.LockedUntil, AccountLocked
+		}
+
+		usernameFailures, err := store.SelectFailures(ctx, ByUsername, username, latest(now.Add(-limits.Window), lockout.ResetAt), limits.MaxFailuresPerUsername)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return time.Time{}, FailedToCheckLoginAttempts
+		}
+
+		ipFailures, err := store.SelectFailures(ctx, ByIP, ip, now.Add(-limits.Window), limits.MaxFailuresPerIP)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return time.Time{}, FailedToCheckLoginAttempts
+		}
+
+		retryAt := latest(retryTime(usernameFailures, limits.MaxFailuresPerUsername, limits.Window), retryTime(ipFailures, limits.MaxFailuresPerIP, limits.Window))
+		if now.Before(retryAt) {
+			log.Warn(ctx, TooManyLoginAttempts.Error())
+			return retryAt, TooManyLoginAttempts
+		}
+
+		return time.Time{}, nil
+	}
+}
+
+// MakeRecord creates a new Record
+func MakeRecord(insertAttempt Insert, store Store, limits Limits) Record {
+	return func(ctx context.Context, attempt DAO) error {
+		err := insertAttempt(ctx, attempt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToRecordLoginAttempt
+		}
+
+		switch attempt.Outcome {
+		case OutcomeFailed:
+			err = recordFailure(ctx, store, limits, attempt)
+		case OutcomeSucceeded:
+			err = store.UpsertLockout(ctx, LockoutDAO{Username: attempt.Username, ResetAt: attempt.AttemptedAt})
+		}
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToRecordLoginAttempt
+		}
+
+		return nil
+	}
+}
+
+// MakeUnlock creates a new Unlock
+func MakeUnlock(store Store) Unlock {
+	return func(ctx context.Context, username string) error {
+		lockout, err := store.SelectLockout(ctx, username)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUnlockAccount
+		}
+
+		now := time.Now()
+		if lockout.LockedUntil == nil || !now.Before(*lockout.LockedUntil) {
+			log.Error(ctx, AccountNotLocked.Error())
+			return AccountNotLocked
+		}
+
+		err = store.UpsertLockout(ctx, LockoutDAO{Username: username, ResetAt: now})
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUnlockAccount
+		}
+
+		return nil
+	}
+}
+
+// recordFailure adds the failed attempt to the store and locks the account of its username if its failures, since the
+// last reset, reached the lockout threshold
+func recordFailure(ctx context.Context, store Store, limits Limits, attempt DAO) error {
+	err := store.AddFailure(ctx, attempt)
+	if err != nil {
+		return err
+	}
+
+	if limits.LockoutThreshold <= 0 {
+		return nil
+	}
+
+	lockout, err := store.SelectLockout(ctx, attempt.Username)
+	if err != nil {
+		return err
+	}
+
+	failures, err := store.SelectFailures(ctx, ByUsername, attempt.Username, latest(attempt.AttemptedAt.Add(-lockoutWindow), lockout.ResetAt), limits.LockoutThreshold)
+	if err != nil {
+		return err
+	}
+
+	if len(failures) < limits.LockoutThreshold {
+		return nil
+	}
+
+	lockedUntil := attempt.AttemptedAt.Add(limits.LockoutDuration)
+	log.Warn(ctx, "account locked until "+lockedUntil.Format(time.RFC3339))
+
+	return store.UpsertLockout(ctx, LockoutDAO{Username: attempt.Username, LockedUntil: &lockedUntil, ResetAt: attempt.AttemptedAt})
+}
+
+// retryTime returns the time from which the given failures, newest first, are below the limit inside the window again.
+// If they already are, or the limit is disabled, the zero time is returned
+func retryTime(failures []time.Time, limit int, window time.Duration) time.Time {
+	if limit <= 0 || len(failures) < limit {
+		return time.Time{}
+	}
+
+	return failures[limit-1].Add(window)
+}
+
+// latest returns the latest of the given times
+func latest(a time.Time, b time.Time) time.Time {
+	if a.After(b) {
+		return a
+	}
+
+	return b
+}
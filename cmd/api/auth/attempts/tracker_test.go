@@ -0,0 +1,216 @@
+package attempts_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/attempts"
+)
+
+func TestCheck_success(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	_ = store.AddFailure(context.Background(), attempts.MockDAO(attempts.OutcomeFailed))
+
+	check := attempts.MakeCheck(store, attempts.MockLimits())
+
+	retryAt, err := check(context.Background(), "username", "127.0.0.1")
+
+	assert.Nil(t, err)
+	assert.True(t, retryAt.IsZero())
+}
+
+func TestCheck_successWhenTheFailuresAreOutsideTheWindowOrPreviousToTheReset(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	now := time.Now()
+	for i := 0; i < 3; i++ {
+		_ = store.AddFailure(context.Background(), attempts.DAO{Username: "username", IP: "127.0.0.1", Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-time.Hour)})
+	}
+	for i := 0; i < 3; i++ {
+		_ = store.AddFailure(context.Background(), attempts.DAO{Username: "username", IP: fmt.Sprintf("10.0.0.%d", i), Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-2 * time.Minute)})
+	}
+	_ = store.UpsertLockout(context.Background(), attempts.LockoutDAO{Username: "username", ResetAt: now.Add(-time.Minute)})
+
+	check := attempts.MakeCheck(store, attempts.MockLimits())
+
+	_, err := check(context.Background(), "username", "127.0.0.1")
+
+	assert.Nil(t, err)
+}
+
+func TestCheck_failsWhenTheAccountIsLocked(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Hour)
+	store := attempts.MockStore(nil, attempts.LockoutDAO{Username: "username", LockedUntil: &lockedUntil}, nil)
+
+	check := attempts.MakeCheck(store, attempts.MockLimits())
+
+	retryAt, err := check(context.Background(), "username", "127.0.0.1")
+
+	assert.Equal(t, attempts.AccountLocked, err)
+	assert.Equal(t, lockedUntil, retryAt)
+}
+
+func TestCheck_failsWhenTheUsernameReachedItsLimit(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	now := time.Now()
+	for i := 3; i > 0; i-- {
+		_ = store.AddFailure(context.Background(), attempts.DAO{Username: "username", IP: fmt.Sprintf("10.0.0.%d", i), Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	limits := attempts.MockLimits()
+
+	check := attempts.MakeCheck(store, limits)
+
+	retryAt, err := check(context.Background(), "username", "127.0.0.1")
+
+	assert.Equal(t, attempts.TooManyLoginAttempts, err)
+	assert.Equal(t, now.Add(-3*time.Minute).Add(limits.Window), retryAt)
+}
+
+func TestCheck_failsWhenTheIPReachedItsLimit(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	now := time.Now()
+	for i := 5; i > 0; i-- {
+		_ = store.AddFailure(context.Background(), attempts.DAO{Username: fmt.Sprintf("username %d", i), IP: "127.0.0.1", Outcome: attempts.OutcomeFailed, AttemptedAt: now.Add(-time.Duration(i) * time.Minute)})
+	}
+	limits := attempts.MockLimits()
+
+	check := attempts.MakeCheck(store, limits)
+
+	retryAt, err := check(context.Background(), "username", "127.0.0.1")
+
+	assert.Equal(t, attempts.TooManyLoginAttempts, err)
+	assert.Equal(t, now.Add(-5*time.Minute).Add(limits.Window), retryAt)
+}
+
+func TestCheck_failsWhenTheStoreThrowsError(t *testing.T) {
+	tests := []struct {
+		store attempts.Store
+	}{
+		{store: attempts.Store{SelectLockout: attempts.MockSelectLockout(attempts.LockoutDAO{}, errors.New("failed to select lockout"))}},
+		{store: attempts.Store{SelectLockout: attempts.MockSelectLockout(attempts.LockoutDAO{}, nil), SelectFailures: attempts.MockSelectFailures(nil, errors.New("failed to select failures"))}},
+	}
+
+	for _, tt := range tests {
+		check := attempts.MakeCheck(tt.store, attempts.MockLimits())
+
+		want := attempts.FailedToCheckLoginAttempts
+		_, got := check(context.Background(), "username", "127.0.0.1")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRecord_successLockingTheAccountWhenItsFailuresReachTheThreshold(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	limits := attempts.MockLimits()
+
+	record := attempts.MakeRecord(attempts.MockInsert(nil), store, limits)
+
+	var lastAttempt attempts.DAO
+	for i := 0; i < limits.LockoutThreshold; i++ {
+		lastAttempt = attempts.DAO{Username: "username", IP: fmt.Sprintf("10.0.0.%d", i), Outcome: attempts.OutcomeFailed, AttemptedAt: time.Now()}
+		err := record(context.Background(), lastAttempt)
+		assert.Nil(t, err)
+	}
+
+	lockout, _ := store.SelectLockout(context.Background(), "username")
+	lockedUntil := lastAttempt.AttemptedAt.Add(limits.LockoutDuration)
+	assert.Equal(t, attempts.LockoutDAO{Username: "username", LockedUntil: &lockedUntil, ResetAt: lastAttempt.AttemptedAt}, lockout)
+
+	_, err := attempts.MakeCheck(store, limits)(context.Background(), "username", "127.0.0.1")
+	assert.Equal(t, attempts.AccountLocked, err)
+}
+
+func TestRecord_successResettingTheFailuresOfTheUsernameAfterASuccessfulLogin(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	limits := attempts.MockLimits()
+
+	record := attempts.MakeRecord(attempts.MockInsert(nil), store, limits)
+
+	_ = record(context.Background(), attempts.DAO{Username: "username", IP: "10.0.0.1", Outcome: attempts.OutcomeFailed, AttemptedAt: time.Now().Add(-2 * time.Second)})
+	_ = record(context.Background(), attempts.DAO{Username: "username", IP: "10.0.0.2", Outcome: attempts.OutcomeFailed, AttemptedAt: time.Now().Add(-2 * time.Second)})
+	_ = record(context.Background(), attempts.DAO{Username: "username", IP: "10.0.0.3", Outcome: attempts.OutcomeSucceeded, AttemptedAt: time.Now().Add(-time.Second)})
+	_ = record(context.Background(), attempts.DAO{Username: "username", IP: "10.0.0.4", Outcome: attempts.OutcomeFailed, AttemptedAt: time.Now()})
+
+	_, err := attempts.MakeCheck(store, limits)(context.Background(), "username", "127.0.0.1")
+	assert.Nil(t, err)
+
+	lockout, _ := store.SelectLockout(context.Background(), "username")
+	assert.Nil(t, lockout.LockedUntil)
+}
+
+func TestRecord_failsWhenInsertThrowsError(t *testing.T) {
+	record := attempts.MakeRecord(attempts.MockInsert(errors.New("failed to insert")), attempts.MakeMemoryStore(), attempts.MockLimits())
+
+	want := attempts.FailedToRecordLoginAttempt
+	got := record(context.Background(), attempts.MockDAO(attempts.OutcomeFailed))
+
+	assert.Equal(t, want, got)
+}
+
+func TestRecord_failsWhenTheStoreThrowsError(t *testing.T) {
+	for _, outcome := range []string{attempts.OutcomeFailed, attempts.OutcomeSucceeded} {
+		record := attempts.MakeRecord(attempts.MockInsert(nil), attempts.MockStore(nil, attempts.LockoutDAO{}, errors.New("store error")), attempts.MockLimits())
+
+		want := attempts.FailedToRecordLoginAttempt
+		got := record(context.Background(), attempts.MockDAO(outcome))
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestUnlock_success(t *testing.T) {
+	store := attempts.MakeMemoryStore()
+	lockedUntil := time.Now().Add(time.Hour)
+	_ = store.UpsertLockout(context.Background(), attempts.LockoutDAO{Username: "username", LockedUntil: &lockedUntil, ResetAt: time.Now()})
+
+	unlock := attempts.MakeUnlock(store)
+
+	err := unlock(context.Background(), "username")
+	assert.Nil(t, err)
+
+	_, err = attempts.MakeCheck(store, attempts.MockLimits())(context.Background(), "username", "127.0.0.1")
+	assert.Nil(t, err)
+}
+
+func TestUnlock_failsWhenTheAccountIsNotLocked(t *testing.T) {
+	expiredLock := time.Now().Add(-time.Minute)
+	tests := []struct {
+		lockout attempts.LockoutDAO
+	}{
+		{lockout: attempts.LockoutDAO{Username: "username"}},
+		{lockout: attempts.LockoutDAO{Username: "username", LockedUntil: &expiredLock}},
+	}
+
+	for _, tt := range tests {
+		unlock := attempts.MakeUnlock(attempts.MockStore(nil, tt.lockout, nil))
+
+		want := attempts.AccountNotLocked
+		got := unlock(context.Background(), "username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestUnlock_failsWhenTheStoreThrowsError(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Hour)
+	tests := []struct {
+		store attempts.Store
+	}{
+		{store: attempts.Store{SelectLockout: attempts.MockSelectLockout(attempts.LockoutDAO{}, errors.New("failed to select lockout"))}},
+		{store: attempts.Store{SelectLockout: attempts.MockSelectLockout(attempts.LockoutDAO{LockedUntil: &lockedUntil}, nil), UpsertLockout: attempts.MockUpsertLockout(errors.New("failed to upsert lockout"))}},
+	}
+
+	for _, tt := range tests {
+		unlock := attempts.MakeUnlock(tt.store)
+
+		want := attempts.FailedToUnlockAccount
+		got := unlock(context.Background(), "username")
+
+		assert.Equal(t, want, got)
+	}
+}
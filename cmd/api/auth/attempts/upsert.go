@@ -0,0 +1,29 @@
+package attempts
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// MakeUpsertLockout creates a new UpsertLockout that stores the lockout state in the 'login_lockouts' table
+func MakeUpsertLockout(db database.Connection) UpsertLockout {
+	const query string = `
+		INSERT INTO login_lockouts(username, locked_until, reset_at)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (username) DO UPDATE SET
+			locked_until = EXCLUDED.locked_until,
+			reset_at = EXCLUDED.reset_at;
+	`
+
+	return func(ctx context.Context, lockout LockoutDAO) error {
+		_, err := db.Exec(ctx, query, lockout.Username, lockout.LockedUntil, lockout.ResetAt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpsertLockout
+		}
+
+		return nil
+	}
+}
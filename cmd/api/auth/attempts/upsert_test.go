@@ -0,0 +1,42 @@
+package attempts_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/internal/database"
+)
+
+func TestUpsertLockout_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	lockedUntil := time.Date(2025, time.March, 1, 1, 0, 0, 0, time.UTC)
+	mockLockout := attempts.LockoutDAO{Username: "username", LockedUntil: &lockedUntil, ResetAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)}
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{mockLockout.Username, mockLockout.LockedUntil, mockLockout.ResetAt}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	upsertLockout := attempts.MakeUpsertLockout(mockPostgresConnection)
+
+	got := upsertLockout(context.Background(), mockLockout)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpsertLockout_failsWhenUpsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to upsert lockout"))
+
+	upsertLockout := attempts.MakeUpsertLockout(mockPostgresConnection)
+
+	want := attempts.FailedToUpsertLockout
+	got := upsertLockout(context.Background(), attempts.LockoutDAO{Username: "username"})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
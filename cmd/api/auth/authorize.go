@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/internal/log"
+)
+
+// Authorize retrieves the user that owns the given session token and validates that its role is one of the given
+// roles. If no roles are passed, any logged-in user is authorized. It returns the ID of the user, or InvalidSessionToken
+// when the session of the token doesn't exist, expired or was revoked.
+// When roles are passed and the role of the user is one of the ones that require the two-factor authentication, the user
+// must also have it enabled
+type Authorize func(ctx context.Context, token string, roles ...string) (int, error)
+
+// MakeAuthorize creates a new Authorize
+func MakeAuthorize(selectUserIDByToken session.SelectUserIDByToken, selectUserByID user.SelectByID, isTwoFactorEnabled totp.IsEnabled, twoFactorRequiredRoles []string) Authorize {
+	return func(ctx context.Context, token string, roles ...string) (int, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return -1, sessionTokenError(err)
+		}
+
+		if len(roles) == 0 {
+			return userID, nil
+		}
+
+		userDAO, err := selectUserByID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return -1, FailedToSelectUserByID
+		}
+
+		if !slices.Contains(roles, userDAO.Role) {
+			log.Error(ctx, UserNotAuthorized.Error())
+			return -1, UserNotAuthorized
+		}
+
+		if slices.Contains(twoFactorRequiredRoles, userDAO.Role) {
+			enabled, err := isTwoFactorEnabled(ctx, userID)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return -1, FailedToCheckTwoFactor
+			}
+
+			if !enabled {
+				log.Error(ctx, TwoFactorRequiredForRole.Error())
+				return -1, UserNotAuthorized
+			}
+		}
+
+		return userID, nil
+	}
+}
+
+// AuthorizationError keeps the UserNotAuthorized and InvalidSessionToken errors, so the handlers can distinguish them
+// from the rest of the authorization errors, which are replaced by the given one
+func AuthorizationError(err error, fallback error) error {
+	switch {
+	case errors.Is(err, UserNotAuthorized):
+		return UserNotAuthorized
+	case errors.Is(err, InvalidSessionToken):
+		return InvalidSessionToken
+	}
+
+	return fallback
+}
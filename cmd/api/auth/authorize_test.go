@@ -0,0 +1,160 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestAuthorize_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockUserDAO := user.MockDAO()
+	mockUserDAO.Role = user.AdminRole
+	mockSelectUserByID := user.MockSelectByID(mockUserDAO, nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+
+	authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, nil)
+
+	want := 1
+	got, err := authorize(context.Background(), "token", user.AdminRole)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestAuthorize_successWithoutRolesDoesNotRetrieveTheUser(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.DAO{}, errors.New("should not be called"))
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+
+	authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, nil)
+
+	want := 1
+	got, err := authorize(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestAuthorize_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.FailedToExecuteQueryToRetrieveUserID, expected: auth.FailedToRetrieveUserID},
+		{err: session.NoUserIDFoundForTheGivenToken, expected: auth.InvalidSessionToken},
+		{err: session.InvalidSessionTokenSignature, expected: auth.InvalidSessionToken},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(-1, tt.err)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+
+		authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, nil)
+
+		want := tt.expected
+		_, got := authorize(context.Background(), "token", user.AdminRole)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestAuthorize_failsWhenSelectUserByIDThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.DAO{}, errors.New("failed to select user by id"))
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+
+	authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, nil)
+
+	want := auth.FailedToSelectUserByID
+	_, got := authorize(context.Background(), "token", user.AdminRole)
+
+	assert.Equal(t, want, got)
+}
+
+func TestAuthorize_failsWhenTheUserDoesNotHaveTheRequiredRole(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+
+	authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, nil)
+
+	want := auth.UserNotAuthorized
+	_, got := authorize(context.Background(), "token", user.AdminRole)
+
+	assert.Equal(t, want, got)
+}
+
+func TestAuthorize_successWhenTheRoleRequiresTwoFactorAndTheUserHasItEnabled(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockUserDAO := user.MockDAO()
+	mockUserDAO.Role = user.AdminRole
+	mockSelectUserByID := user.MockSelectByID(mockUserDAO, nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(true, nil)
+
+	authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, []string{user.AdminRole})
+
+	want := 1
+	got, err := authorize(context.Background(), "token", user.AdminRole)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestAuthorize_failsWhenTheRoleRequiresTwoFactorAndTheUserDoesNotHaveItEnabled(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockUserDAO := user.MockDAO()
+	mockUserDAO.Role = user.AdminRole
+	mockSelectUserByID := user.MockSelectByID(mockUserDAO, nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+
+	authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, []string{user.AdminRole})
+
+	want := auth.UserNotAuthorized
+	_, got := authorize(context.Background(), "token", user.AdminRole)
+
+	assert.Equal(t, want, got)
+}
+
+func TestAuthorize_failsWhenIsTwoFactorEnabledThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockUserDAO := user.MockDAO()
+	mockUserDAO.Role = user.AdminRole
+	mockSelectUserByID := user.MockSelectByID(mockUserDAO, nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, errors.New("failed to check if two-factor is enabled"))
+
+	authorize := auth.MakeAuthorize(mockSelectUserIDByToken, mockSelectUserByID, mockIsTwoFactorEnabled, []string{user.AdminRole})
+
+	want := auth.FailedToCheckTwoFactor
+	_, got := authorize(context.Background(), "token", user.AdminRole)
+
+	assert.Equal(t, want, got)
+}
+
+func TestAuthorizationError_success(t *testing.T) {
+	fallback := errors.New("failed to authorize user")
+
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: auth.InvalidSessionToken, expected: auth.InvalidSessionToken},
+		{err: auth.FailedToRetrieveUserID, expected: fallback},
+		{err: auth.FailedToCheckTwoFactor, expected: fallback},
+	}
+
+	for _, tt := range tests {
+		got := auth.AuthorizationError(tt.err, fallback)
+
+		assert.Equal(t, tt.expected, got)
+	}
+}
@@ -1,9 +1,126 @@
 package auth
 
-import "time"
+import (
+	"time"
 
-// LoginResponseDTO represents the response of the LogIn endpoint
-type LoginResponseDTO struct {
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
-}
+	"ahbcc/cmd/api/user"
+)
+
+type (
+	// LoginResponseDTO represents the response of the LogIn and RefreshSession endpoints
+	LoginResponseDTO struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// SessionDTO represents an active session of a user. Current is true for the session of the token of the request
+	SessionDTO struct {
+		ID        int       `json:"id"`
+		CreatedAt time.Time `json:"created_at"`
+		ExpiresAt time.Time `json:"expires_at"`
+		Current   bool      `json:"current"`
+	}
+
+	// SigningKeyDTO represents a session signing key with the amount of sessions, signed with it, that haven't expired
+	// yet. LastExpiresAt is the expiration of the last of them, nil when there are none
+	SigningKeyDTO struct {
+		KeyID         string     `json:"key_id"`
+		Status        string     `json:"status"`
+		LiveSessions  int        `json:"live_sessions"`
+		LastExpiresAt *time.Time `json:"last_expires_at"`
+	}
+
+	// ChangePasswordDTO represents the request body of the ChangePassword endpoint
+	ChangePasswordDTO struct {
+		CurrentPassword string `json:"current_password"`
+		NewPassword     string `json:"new_password"`
+	}
+
+	// PasswordResetRequestDTO represents the request body of the IssuePasswordResetToken endpoint
+	PasswordResetRequestDTO struct {
+		Username string `json:"username"`
+	}
+
+	// PasswordResetTokenDTO represents the response of the IssuePasswordResetToken endpoint
+	PasswordResetTokenDTO struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// ResetPasswordDTO represents the request body of the ResetPassword endpoint
+	ResetPasswordDTO struct {
+		Token       string `json:"token"`
+		NewPassword string `json:"new_password"`
+	}
+
+	// SignUpDTO represents the request body of the SignUp endpoint. The InviteCode is required when the sign up is
+	// invite-only
+	SignUpDTO struct {
+		user.DTO
+		InviteCode string `json:"invite_code"`
+	}
+
+	// CreateInviteDTO represents the request body of the CreateInvite endpoint
+	CreateInviteDTO struct {
+		Role           string `json:"role"`
+		MaxUses        int    `json:"max_uses"`
+		ExpiresInHours int    `json:"expires_in_hours"`
+	}
+
+	// InviteCodeDTO represents the response of the CreateInvite endpoint. The Code isn't retrievable afterward
+	InviteCodeDTO struct {
+		ID        int       `json:"id"`
+		Code      string    `json:"code"`
+		Role      string    `json:"role"`
+		MaxUses   int       `json:"max_uses"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// InviteDTO represents an invite that can still be redeemed, with the users that signed up with it
+	InviteDTO struct {
+		ID          int             `json:"id"`
+		Role        string          `json:"role"`
+		MaxUses     int             `json:"max_uses"`
+		Uses        int             `json:"uses"`
+		ExpiresAt   time.Time       `json:"expires_at"`
+		CreatedBy   *int            `json:"created_by"`
+		CreatedAt   time.Time       `json:"created_at"`
+		Redemptions []RedemptionDTO `json:"redemptions"`
+	}
+
+	// RedemptionDTO represents the sign up of a user with an invite
+	RedemptionDTO struct {
+		UserID     int       `json:"user_id"`
+		Username   string    `json:"username"`
+		RedeemedAt time.Time `json:"redeemed_at"`
+	}
+
+	// LoginChallengeDTO represents the response of the LogIn endpoint when the user has the two-factor authentication
+	// enabled. The challenge is completed with a code of the second factor in the CompleteLogIn endpoint
+	LoginChallengeDTO struct {
+		Challenge string    `json:"challenge"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// CompleteLogInDTO represents the request body of the CompleteLogIn endpoint. The Code is either a TOTP code or a
+	// recovery code
+	CompleteLogInDTO struct {
+		Challenge string `json:"challenge"`
+		Code      string `json:"code"`
+	}
+
+	// TwoFactorCodeDTO represents the request body of the ConfirmTwoFactor and DisableTwoFactor endpoints
+	TwoFactorCodeDTO struct {
+		Code string `json:"code"`
+	}
+
+	// RecoveryCodesDTO represents the response of the ConfirmTwoFactor endpoint. The codes aren't retrievable afterward
+	RecoveryCodesDTO struct {
+		RecoveryCodes []string `json:"recovery_codes"`
+	}
+
+	// RevokedSessionsDTO represents the response of the RevokeOtherSessions and ChangePassword endpoints
+	RevokedSessionsDTO struct {
+		Revoked int `json:"revoked"`
+	}
+)
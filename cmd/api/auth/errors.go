@@ -14,6 +14,64 @@ var (
 	FailedToCreateUserSession                 = errors.New("failed to create user session")
 	FailedToDeleteUserSession                 = errors.New("failed to delete user session")
 	AuthorizationTokenIsRequired              = errors.New("authorization token is required")
+	FailedToRetrieveUserID                    = errors.New("failed to retrieve user id")
+	FailedToSelectUserByID                    = errors.New("failed to execute select user by id")
+	UserNotAuthorized                         = errors.New("user not authorized")
+	FailedToRetrieveActiveSessions            = errors.New("failed to retrieve active sessions")
+	FailedToDeleteOtherUserSessions           = errors.New("failed to delete other user sessions")
+	FailedToRefreshUserSession                = errors.New("failed to refresh user session")
+	NoSessionFoundForTheGivenID               = errors.New("no session found for the given id")
+	InvalidSessionToken                       = errors.New("invalid session token")
+	InvalidSessionID                          = errors.New("invalid session id")
+	FailedToAuthorizeUser                     = errors.New("failed to authorize user")
+	FailedToRetrieveSigningKeysUsage          = errors.New("failed to retrieve signing keys usage")
+	FailedToCheckLoginAttempts                = errors.New("failed to check login attempts")
+	TooManyLoginAttempts                      = errors.New("too many failed login attempts")
+	AccountLocked                             = errors.New("account locked due too many failed login attempts")
+	AccountNotLocked                          = errors.New("account not locked")
+	FailedToUnlockUserAccount                 = errors.New("failed to unlock user account")
+	MissingCurrentPassword                    = errors.New("missing current password")
+	MissingNewPassword                        = errors.New("missing new password")
+	MissingPasswordResetToken                 = errors.New("missing password reset token")
+	WrongCurrentPassword                      = errors.New("wrong current password")
+	FailedToUpdateUserPassword                = errors.New("failed to update user password")
+	NoUserFoundForTheGivenUsername            = errors.New("no user found for the given username")
+	FailedToCreatePasswordResetToken          = errors.New("failed to create password reset token")
+	InvalidPasswordResetToken                 = errors.New("invalid, expired or already used password reset token")
+	FailedToResetUserPassword                 = errors.New("failed to reset user password")
+	FailedToDeleteAllUserSessions             = errors.New("failed to delete all user sessions")
+	InviteCodeIsRequired                      = errors.New("invite code is required")
+	InvalidInviteCode                         = errors.New("invalid, expired or exhausted invite code")
+	FailedToRedeemInviteCode                  = errors.New("failed to redeem invite code")
+	InvalidInviteRole                         = errors.New("invalid invite role, it must be ANNOTATOR or ADMIN")
+	InvalidInviteMaxUses                      = errors.New("invalid invite max uses, it can't be negative")
+	InvalidInviteExpiration                   = errors.New("invalid invite expiration, it can't be negative")
+	FailedToCreateUserInvite                  = errors.New("failed to create user invite")
+	FailedToRetrieveInvites                   = errors.New("failed to retrieve invites")
+	InvalidOIDCState                          = errors.New("invalid, expired or already used single sign-on state")
+	OIDCAuthenticationFailed                  = errors.New("the single sign-on provider didn't authenticate the user")
+	FailedToAuthenticateWithOIDCProvider      = errors.New("failed to authenticate with the single sign-on provider")
+	OIDCUserNotProvisioned                    = errors.New("the single sign-on user is not provisioned")
+	OIDCUsernameTaken                         = errors.New("the single sign-on username belongs to another user")
+	FailedToResolveOIDCUser                   = errors.New("failed to resolve the single sign-on user")
+	SecondFactorRequired                      = errors.New("a second factor is required to complete the login")
+	FailedToCheckTwoFactor                    = errors.New("failed to check if two-factor authentication is enabled")
+	FailedToCreateLoginChallenge              = errors.New("failed to create login challenge")
+	InvalidLoginChallenge                     = errors.New("invalid, expired or exhausted login challenge")
+	FailedToCompleteLogIn                     = errors.New("failed to complete login")
+	WrongSecondFactorCode                     = errors.New("wrong, expired or already used second factor code")
+	MissingLoginChallenge                     = errors.New("missing login challenge")
+	MissingSecondFactorCode                   = errors.New("missing second factor code")
+	TwoFactorAlreadyEnabled                   = errors.New("two-factor authentication is already enabled")
+	TwoFactorNotEnrolled                      = errors.New("two-factor authentication is not enrolled")
+	TwoFactorNotEnabled                       = errors.New("two-factor authentication is not enabled")
+	TwoFactorRequiredForRole                  = errors.New("two-factor authentication is required for the role of the user")
+	FailedToEnrollTwoFactor                   = errors.New("failed to enroll two-factor authentication")
+	FailedToConfirmTwoFactor                  = errors.New("failed to confirm two-factor authentication")
+	FailedToDisableTwoFactor                  = errors.New("failed to disable two-factor authentication")
+	AccountDisabled                           = errors.New("the account is disabled")
+	MissingOIDCCodeOrState                    = errors.New("missing single sign-on code or state")
+	OIDCProviderError                         = errors.New("the single sign-on provider responded with an error")
 )
 
 const (
@@ -22,4 +80,44 @@ const (
 	FailedToLogIn              string = "Failed to log in"
 	FailedToLogOut             string = "Failed to log out"
 	AuthorizationTokenRequired string = "Authorization token is required"
+	InvalidOrExpiredToken      string = "Invalid or expired session token"
+	InvalidURLParameter        string = "Invalid url parameter"
+	FailedToListSessions       string = "Failed to list sessions"
+	FailedToRevokeSession      string = "Failed to revoke session"
+	FailedToRevokeSessions     string = "Failed to revoke sessions"
+	FailedToRefreshSession     string = "Failed to refresh session"
+	SessionNotFound            string = "Session not found"
+	NotAuthorized              string = "User not authorized"
+	FailedToRetrieveKeys       string = "Failed to retrieve signing keys"
+	TooManyAttempts            string = "Too many failed login attempts, try again later"
+	AccountTemporarilyLocked   string = "Account temporarily locked due too many failed login attempts"
+	FailedToUnlockAccount      string = "Failed to unlock account"
+	AccountLockNotFound        string = "The account is not locked"
+	PasswordPolicyNotMet       string = "The password does not meet the password policy"
+	FailedToChangePassword     string = "Failed to change password"
+	CurrentPasswordIsWrong     string = "The current password is wrong"
+	FailedToIssueResetToken    string = "Failed to issue password reset token"
+	UserNotFound               string = "User not found"
+	FailedToResetPassword      string = "Failed to reset password"
+	InvalidOrExpiredResetToken string = "Invalid, expired or already used password reset token"
+	InviteCodeRequired         string = "An invite code is required to sign up"
+	InvalidOrExpiredInvite     string = "Invalid, expired or exhausted invite code"
+	FailedToCreateInvite       string = "Failed to create invite"
+	FailedToListInvites        string = "Failed to list invites"
+	FailedToStartSSO           string = "Failed to start single sign-on"
+	FailedToLogInWithSSO       string = "Failed to log in with single sign-on"
+	InvalidOrExpiredSSOLogin   string = "Invalid or expired single sign-on login, start it again"
+	SSOUserNotProvisioned      string = "The user is not provisioned, ask an admin for access"
+	SSOUsernameTaken           string = "The username already belongs to a local user"
+	SecondFactorNeeded         string = "A second factor is required to complete the login"
+	InvalidOrExpiredChallenge  string = "Invalid, expired or exhausted login challenge, log in again"
+	WrongCode                  string = "Wrong, expired or already used code"
+	FailedToEnroll2FA          string = "Failed to enroll two-factor authentication"
+	FailedToConfirm2FA         string = "Failed to confirm two-factor authentication"
+	FailedToDisable2FA         string = "Failed to disable two-factor authentication"
+	TwoFactorAlreadyOn         string = "Two-factor authentication is already enabled"
+	TwoFactorNotOn             string = "Two-factor authentication is not enabled"
+	TwoFactorNotStarted        string = "Two-factor authentication is not enrolled"
+	TwoFactorMandatory         string = "Two-factor authentication is required for your role"
+	AccountIsDisabled          string = "The account is disabled, contact an admin"
 )
@@ -3,9 +3,16 @@ package auth
 import (
 	"encoding/json"
 	"errors"
+	"math"
 	"net/http"
+	"net/url"
+	"strconv"
+	"time"
 
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/cmd/api/middleware"
 	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/password"
 	"ahbcc/internal/http/response"
 	"ahbcc/internal/log"
 )
@@ -15,23 +22,32 @@ func SignUpHandlerV1(signUp SignUp) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		var userDTO user.DTO
-		err := json.NewDecoder(r.Body).Decode(&userDTO)
+		var body SignUpDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
 		if err != nil {
 			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
 			return
 		}
-		ctx = log.With(ctx, log.Param("username", userDTO.Username))
+		ctx = log.With(ctx, log.Param("username", body.Username))
 
-		err = validateBody(userDTO)
+		err = validateBody(body.DTO)
 		if err != nil {
 			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
 			return
 		}
 
-		err = signUp(ctx, userDTO)
+		err = signUp(ctx, body.DTO, body.InviteCode)
 		if err != nil {
-			response.Send(ctx, w, http.StatusInternalServerError, FailedToSignUp, nil, err)
+			switch {
+			case password.IsPolicyViolation(err):
+				response.Send(ctx, w, http.StatusBadRequest, PasswordPolicyNotMet, nil, err)
+			case errors.Is(err, InviteCodeIsRequired):
+				response.Send(ctx, w, http.StatusForbidden, InviteCodeRequired, nil, err)
+			case errors.Is(err, InvalidInviteCode):
+				response.Send(ctx, w, http.StatusForbidden, InvalidOrExpiredInvite, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToSignUp, nil, err)
+			}
 			return
 		}
 
@@ -57,12 +73,26 @@ func LogInHandlerV1(logIn LogIn) http.HandlerFunc {
 			return
 		}
 
-		token, expiresAt, err := logIn(ctx, userDTO)
+		token, expiresAt, err := logIn(ctx, userDTO, middleware.ClientIP(r))
 		if err != nil {
 			switch {
 			case errors.Is(err, FailedToLoginDueWrongPassword):
 				response.Send(ctx, w, http.StatusUnauthorized, FailedToLogIn, nil, err)
 				return
+			case errors.Is(err, TooManyLoginAttempts):
+				w.Header().Set("Retry-After", retryAfter(expiresAt))
+				response.Send(ctx, w, http.StatusTooManyRequests, TooManyAttempts, nil, err)
+				return
+			case errors.Is(err, AccountLocked):
+				w.Header().Set("Retry-After", retryAfter(expiresAt))
+				response.Send(ctx, w, http.StatusTooManyRequests, AccountTemporarilyLocked, nil, err)
+				return
+			case errors.Is(err, SecondFactorRequired):
+				response.Send(ctx, w, http.StatusAccepted, SecondFactorNeeded, LoginChallengeDTO{Challenge: token, ExpiresAt: expiresAt}, nil)
+				return
+			case errors.Is(err, AccountDisabled):
+				response.Send(ctx, w, http.StatusForbidden, AccountIsDisabled, nil, err)
+				return
 			default:
 				response.Send(ctx, w, http.StatusInternalServerError, FailedToLogIn, nil, err)
 				return
@@ -99,6 +129,628 @@ func LogOutHandlerV1(logOut LogOut) http.HandlerFunc {
 	}
 }
 
+// RefreshSessionHandlerV1 HTTP Handler of the endpoint /auth/sessions/refresh/v1
+func RefreshSessionHandlerV1(refreshSession RefreshSession) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		newToken, expiresAt, err := refreshSession(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRefreshSession, nil, err)
+			}
+			return
+		}
+
+		refreshResponse := LoginResponseDTO{
+			Token:     newToken,
+			ExpiresAt: expiresAt,
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Session successfully refreshed", refreshResponse, nil)
+	}
+}
+
+// ListSessionsHandlerV1 HTTP Handler of the endpoint GET /auth/sessions/v1
+func ListSessionsHandlerV1(listSessions ListSessions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		sessions, err := listSessions(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToListSessions, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Sessions successfully retrieved", sessions, nil)
+	}
+}
+
+// RevokeSessionHandlerV1 HTTP Handler of the endpoint DELETE /auth/sessions/{session_id}/v1
+func RevokeSessionHandlerV1(revokeSession RevokeSession) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		sessionID, err := strconv.Atoi(r.PathValue("session_id"))
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidURLParameter, nil, InvalidSessionID)
+			return
+		}
+		ctx = log.With(ctx, log.Param("session_id", sessionID))
+
+		err = revokeSession(ctx, token, sessionID)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, NoSessionFoundForTheGivenID):
+				response.Send(ctx, w, http.StatusNotFound, SessionNotFound, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRevokeSession, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Session successfully revoked", nil, nil)
+	}
+}
+
+// RevokeOtherSessionsHandlerV1 HTTP Handler of the endpoint DELETE /auth/sessions/v1
+func RevokeOtherSessionsHandlerV1(revokeOtherSessions RevokeOtherSessions) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		revoked, err := revokeOtherSessions(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRevokeSessions, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Sessions successfully revoked", RevokedSessionsDTO{Revoked: revoked}, nil)
+	}
+}
+
+// SigningKeysHandlerV1 HTTP Handler of the endpoint GET /auth/sessions/keys/v1
+func SigningKeysHandlerV1(signingKeys SigningKeys) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		keys, err := signingKeys(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, NotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveKeys, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Signing keys successfully retrieved", keys, nil)
+	}
+}
+
+// UnlockAccountHandlerV1 HTTP Handler of the endpoint DELETE /auth/lockouts/{username}/v1
+func UnlockAccountHandlerV1(unlockAccount UnlockAccount) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		username := r.PathValue("username")
+		ctx = log.With(ctx, log.Param("username", username))
+
+		err := unlockAccount(ctx, token, username)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, NotAuthorized, nil, err)
+			case errors.Is(err, AccountNotLocked):
+				response.Send(ctx, w, http.StatusNotFound, AccountLockNotFound, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToUnlockAccount, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Account successfully unlocked", nil, nil)
+	}
+}
+
+// ChangePasswordHandlerV1 HTTP Handler of the endpoint PUT /auth/password/v1
+func ChangePasswordHandlerV1(changePassword ChangePassword) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		var body ChangePasswordDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		err = validateChangePasswordBody(body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		revoked, err := changePassword(ctx, token, body)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, WrongCurrentPassword):
+				response.Send(ctx, w, http.StatusForbidden, CurrentPasswordIsWrong, nil, err)
+			case password.IsPolicyViolation(err):
+				response.Send(ctx, w, http.StatusBadRequest, PasswordPolicyNotMet, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToChangePassword, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Password successfully changed", RevokedSessionsDTO{Revoked: revoked}, nil)
+	}
+}
+
+// IssuePasswordResetTokenHandlerV1 HTTP Handler of the endpoint POST /auth/password/reset/tokens/v1
+func IssuePasswordResetTokenHandlerV1(issuePasswordResetToken IssuePasswordResetToken) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		var body PasswordResetRequestDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		if body.Username == "" {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, MissingUsername)
+			return
+		}
+		ctx = log.With(ctx, log.Param("username", body.Username))
+
+		resetToken, err := issuePasswordResetToken(ctx, token, body.Username)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, NotAuthorized, nil, err)
+			case errors.Is(err, NoUserFoundForTheGivenUsername):
+				response.Send(ctx, w, http.StatusNotFound, UserNotFound, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToIssueResetToken, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Password reset token successfully issued", resetToken, nil)
+	}
+}
+
+// ResetPasswordHandlerV1 HTTP Handler of the endpoint POST /auth/password/reset/v1
+func ResetPasswordHandlerV1(resetPassword ResetPassword) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var body ResetPasswordDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		err = validateResetPasswordBody(body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		err = resetPassword(ctx, body)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidPasswordResetToken):
+				response.Send(ctx, w, http.StatusBadRequest, InvalidOrExpiredResetToken, nil, err)
+			case password.IsPolicyViolation(err):
+				response.Send(ctx, w, http.StatusBadRequest, PasswordPolicyNotMet, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToResetPassword, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Password successfully reset", nil, nil)
+	}
+}
+
+// CreateInviteHandlerV1 HTTP Handler of the endpoint POST /auth/invites/v1
+func CreateInviteHandlerV1(createInvite CreateInvite) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		var body CreateInviteDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		invite, err := createInvite(ctx, token, body)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, NotAuthorized, nil, err)
+			case errors.Is(err, InvalidInviteRole), errors.Is(err, InvalidInviteMaxUses), errors.Is(err, InvalidInviteExpiration):
+				response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToCreateInvite, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Invite successfully created", invite, nil)
+	}
+}
+
+// ListInvitesHandlerV1 HTTP Handler of the endpoint GET /auth/invites/v1
+func ListInvitesHandlerV1(listInvites ListInvites) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		invites, err := listInvites(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, NotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToListInvites, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Invites successfully retrieved", invites, nil)
+	}
+}
+
+// OIDCLogInHandlerV1 HTTP Handler of the endpoint /auth/oidc/login/v1. It redirects the user to the single sign-on
+// provider
+func OIDCLogInHandlerV1(authorizationURL oidc.AuthorizationURL) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		redirectURL, err := authorizationURL(ctx)
+		if err != nil {
+			response.Send(ctx, w, http.StatusInternalServerError, FailedToStartSSO, nil, err)
+			return
+		}
+
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+	}
+}
+
+// OIDCCallbackHandlerV1 HTTP Handler of the endpoint /auth/oidc/callback/v1, where the single sign-on provider
+// redirects the user back. When a post login redirect URL is given, the user is redirected to it with the session in
+// the fragment of the URL, so it never reaches a server log, otherwise the session is responded as in the LogIn endpoint
+func OIDCCallbackHandlerV1(oidcLogIn OIDCLogIn, postLoginRedirectURL string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		query := r.URL.Query()
+		if providerError := query.Get("error"); providerError != "" {
+			ctx = log.With(ctx, log.Param("error", providerError), log.Param("error_description", query.Get("error_description")))
+			response.Send(ctx, w, http.StatusUnauthorized, FailedToLogInWithSSO, nil, OIDCProviderError)
+			return
+		}
+
+		code, state := query.Get("code"), query.Get("state")
+		if code == "" || state == "" {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidURLParameter, nil, MissingOIDCCodeOrState)
+			return
+		}
+
+		token, expiresAt, err := oidcLogIn(ctx, code, state)
+		if errors.Is(err, SecondFactorRequired) {
+			if postLoginRedirectURL != "" {
+				fragment := url.Values{}
+				fragment.Set("challenge", token)
+				fragment.Set("expires_at", expiresAt.Format(time.RFC3339))
+				http.Redirect(w, r, postLoginRedirectURL+"#"+fragment.Encode(), http.StatusFound)
+				return
+			}
+
+			response.Send(ctx, w, http.StatusAccepted, SecondFactorNeeded, LoginChallengeDTO{Challenge: token, ExpiresAt: expiresAt}, nil)
+			return
+		} else if err != nil {
+			switch {
+			case errors.Is(err, InvalidOIDCState):
+				response.Send(ctx, w, http.StatusBadRequest, InvalidOrExpiredSSOLogin, nil, err)
+			case errors.Is(err, OIDCAuthenticationFailed):
+				response.Send(ctx, w, http.StatusUnauthorized, FailedToLogInWithSSO, nil, err)
+			case errors.Is(err, OIDCUserNotProvisioned):
+				response.Send(ctx, w, http.StatusForbidden, SSOUserNotProvisioned, nil, err)
+			case errors.Is(err, OIDCUsernameTaken):
+				response.Send(ctx, w, http.StatusConflict, SSOUsernameTaken, nil, err)
+			case errors.Is(err, AccountDisabled):
+				response.Send(ctx, w, http.StatusForbidden, AccountIsDisabled, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToLogInWithSSO, nil, err)
+			}
+			return
+		}
+
+		if postLoginRedirectURL != "" {
+			fragment := url.Values{}
+			fragment.Set("token", token)
+			fragment.Set("expires_at", expiresAt.Format(time.RFC3339))
+			http.Redirect(w, r, postLoginRedirectURL+"#"+fragment.Encode(), http.StatusFound)
+			return
+		}
+
+		loginResponse := LoginResponseDTO{
+			Token:     token,
+			ExpiresAt: expiresAt,
+		}
+
+		response.Send(ctx, w, http.StatusOK, "User successfully logged in", loginResponse, nil)
+	}
+}
+
+// CompleteLogInHandlerV1 HTTP Handler of the endpoint POST /auth/login/2fa/v1
+func CompleteLogInHandlerV1(completeLogIn CompleteLogIn) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		var body CompleteLogInDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		err = validateCompleteLogInBody(body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		token, expiresAt, err := completeLogIn(ctx, body.Challenge, body.Code, middleware.ClientIP(r))
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidLoginChallenge):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredChallenge, nil, err)
+			case errors.Is(err, WrongSecondFactorCode):
+				response.Send(ctx, w, http.StatusUnauthorized, WrongCode, nil, err)
+			case errors.Is(err, TooManyLoginAttempts):
+				w.Header().Set("Retry-After", retryAfter(expiresAt))
+				response.Send(ctx, w, http.StatusTooManyRequests, TooManyAttempts, nil, err)
+			case errors.Is(err, AccountLocked):
+				w.Header().Set("Retry-After", retryAfter(expiresAt))
+				response.Send(ctx, w, http.StatusTooManyRequests, AccountTemporarilyLocked, nil, err)
+			case errors.Is(err, AccountDisabled):
+				response.Send(ctx, w, http.StatusForbidden, AccountIsDisabled, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToLogIn, nil, err)
+			}
+			return
+		}
+
+		loginResponse := LoginResponseDTO{
+			Token:     token,
+			ExpiresAt: expiresAt,
+		}
+
+		response.Send(ctx, w, http.StatusOK, "User successfully logged in", loginResponse, nil)
+	}
+}
+
+// EnrollTwoFactorHandlerV1 HTTP Handler of the endpoint POST /auth/2fa/v1
+func EnrollTwoFactorHandlerV1(enrollTwoFactor EnrollTwoFactor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		enrollment, err := enrollTwoFactor(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, TwoFactorAlreadyEnabled):
+				response.Send(ctx, w, http.StatusConflict, TwoFactorAlreadyOn, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToEnroll2FA, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Two-factor authentication successfully enrolled, confirm it with a code", enrollment, nil)
+	}
+}
+
+// ConfirmTwoFactorHandlerV1 HTTP Handler of the endpoint POST /auth/2fa/confirm/v1
+func ConfirmTwoFactorHandlerV1(confirmTwoFactor ConfirmTwoFactor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		var body TwoFactorCodeDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		if body.Code == "" {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, MissingSecondFactorCode)
+			return
+		}
+
+		recoveryCodes, err := confirmTwoFactor(ctx, token, body.Code)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, TwoFactorNotEnrolled):
+				response.Send(ctx, w, http.StatusNotFound, TwoFactorNotStarted, nil, err)
+			case errors.Is(err, TwoFactorAlreadyEnabled):
+				response.Send(ctx, w, http.StatusConflict, TwoFactorAlreadyOn, nil, err)
+			case errors.Is(err, WrongSecondFactorCode):
+				response.Send(ctx, w, http.StatusForbidden, WrongCode, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToConfirm2FA, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Two-factor authentication successfully enabled", RecoveryCodesDTO{RecoveryCodes: recoveryCodes}, nil)
+	}
+}
+
+// DisableTwoFactorHandlerV1 HTTP Handler of the endpoint DELETE /auth/2fa/v1
+func DisableTwoFactorHandlerV1(disableTwoFactor DisableTwoFactor) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		var body TwoFactorCodeDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		if body.Code == "" {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, MissingSecondFactorCode)
+			return
+		}
+
+		err = disableTwoFactor(ctx, token, body.Code)
+		if err != nil {
+			switch {
+			case errors.Is(err, InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, TwoFactorRequiredForRole):
+				response.Send(ctx, w, http.StatusForbidden, TwoFactorMandatory, nil, err)
+			case errors.Is(err, TwoFactorNotEnabled):
+				response.Send(ctx, w, http.StatusNotFound, TwoFactorNotOn, nil, err)
+			case errors.Is(err, WrongSecondFactorCode):
+				response.Send(ctx, w, http.StatusForbidden, WrongCode, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToDisable2FA, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Two-factor authentication successfully disabled", nil, nil)
+	}
+}
+
+// retryAfter returns the value of the Retry-After header, the amount of seconds until the given time rounded up
+func retryAfter(retryAt time.Time) string {
+	seconds := int(math.Ceil(time.Until(retryAt).Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+
+	return strconv.Itoa(seconds)
+}
+
 // validateBody validates that mandatory fields are present
 func validateBody(user user.DTO) error {
 	if user.Username == "" {
@@ -111,3 +763,42 @@ func validateBody(user user.DTO) error {
 
 	return nil
 }
+
+// validateChangePasswordBody validates that mandatory fields are present
+func validateChangePasswordBody(body ChangePasswordDTO) error {
+	if body.CurrentPassword == "" {
+		return MissingCurrentPassword
+	}
+
+	if body.NewPassword == "" {
+		return MissingNewPassword
+	}
+
+	return nil
+}
+
+// validateCompleteLogInBody validates that mandatory fields are present
+func validateCompleteLogInBody(body CompleteLogInDTO) error {
+	if body.Challenge == "" {
+		return MissingLoginChallenge
+	}
+
+	if body.Code == "" {
+		return MissingSecondFactorCode
+	}
+
+	return nil
+}
+
+// validateResetPasswordBody validates that mandatory fields are present
+func validateResetPasswordBody(body ResetPasswordDTO) error {
+	if body.Token == "" {
+		return MissingPasswordResetToken
+	}
+
+	if body.NewPassword == "" {
+		return MissingNewPassword
+	}
+
+	return nil
+}
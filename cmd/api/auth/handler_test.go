@@ -14,7 +14,10 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/oidc"
 	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/password"
+	"ahbcc/cmd/api/user/totp"
 	"ahbcc/internal/http/response"
 )
 
@@ -92,6 +95,48 @@ func TestSignUpHandlerV1_failsWhenSignUpThrowsError(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestSignUpHandlerV1_failsWhenTheInviteCodeIsMissingOrInvalid(t *testing.T) {
+	tests := []struct {
+		err error
+	}{
+		{err: auth.InviteCodeIsRequired},
+		{err: auth.InvalidInviteCode},
+	}
+
+	for _, tt := range tests {
+		mockSignUp := auth.MockSignUp(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(auth.SignUpDTO{DTO: user.MockDTO(), InviteCode: "invite code"})
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/signup/v1", bytes.NewReader(mockBody))
+
+		signUpHandlerV1 := auth.SignUpHandlerV1(mockSignUp)
+
+		signUpHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusForbidden
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSignUpHandlerV1_failsWhenThePasswordDoesNotMeetThePolicy(t *testing.T) {
+	mockSignUp := auth.MockSignUp(password.PasswordTooWeak)
+	mockResponseWriter := httptest.NewRecorder()
+	mockUser := user.MockDTO()
+	mockBody, _ := json.Marshal(mockUser)
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/signup/v1", bytes.NewReader(mockBody))
+
+	signUpHandlerV1 := auth.SignUpHandlerV1(mockSignUp)
+
+	signUpHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
 func TestLoginHandlerV1_success(t *testing.T) {
 	mockToken := "abcd"
 	mockExpiresAt := time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)
@@ -182,6 +227,7 @@ func TestLoginHandlerV1_failsWhenLogInThrowsError(t *testing.T) {
 		want       int
 	}{
 		{logInError: auth.FailedToLoginDueWrongPassword, want: http.StatusUnauthorized},
+		{logInError: auth.AccountDisabled, want: http.StatusForbidden},
 		{logInError: errors.New("failed to log in"), want: http.StatusInternalServerError},
 	} {
 		mockLogIn := auth.MockLogIn("", time.Time{}, test.logInError)
@@ -202,6 +248,40 @@ func TestLoginHandlerV1_failsWhenLogInThrowsError(t *testing.T) {
 
 }
 
+func TestLoginHandlerV1_failsWhenTheLoginAttemptIsNotAllowed(t *testing.T) {
+	for _, logInError := range []error{auth.TooManyLoginAttempts, auth.AccountLocked} {
+		mockLogIn := auth.MockLogIn("", time.Now().Add(90*time.Second), logInError)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(user.MockDTO())
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/login/v1", bytes.NewReader(mockBody))
+
+		logInHandlerV1 := auth.LogInHandlerV1(mockLogIn)
+
+		logInHandlerV1(mockResponseWriter, mockRequest)
+
+		assert.Equal(t, http.StatusTooManyRequests, mockResponseWriter.Result().StatusCode)
+		assert.Equal(t, "90", mockResponseWriter.Result().Header.Get("Retry-After"))
+	}
+}
+
+func TestLoginHandlerV1_successRespondingALoginChallengeWhenASecondFactorIsRequired(t *testing.T) {
+	mockExpiresAt := time.Date(2025, time.March, 8, 0, 5, 0, 0, time.UTC)
+	mockLogIn := auth.MockLogIn("challenge", mockExpiresAt, auth.SecondFactorRequired)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(user.MockDTO())
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/login/v1", bytes.NewReader(mockBody))
+
+	logInHandlerV1 := auth.LogInHandlerV1(mockLogIn)
+
+	logInHandlerV1(mockResponseWriter, mockRequest)
+
+	var got response.DTO
+	_ = json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+
+	assert.Equal(t, http.StatusAccepted, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, map[string]any{"challenge": "challenge", "expires_at": "2025-03-08T00:05:00Z"}, got.Data)
+}
+
 func TestLogoutHandlerV1_success(t *testing.T) {
 	mockLogOut := auth.MockLogout(nil)
 	mockResponseWriter := httptest.NewRecorder()
@@ -248,3 +328,1219 @@ func TestLogoutHandlerV1_failsWhenLogOutThrowsError(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestRefreshSessionHandlerV1_success(t *testing.T) {
+	mockExpiresAt := time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)
+	mockRefreshSession := auth.MockRefreshSession("new token", mockExpiresAt, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/sessions/refresh/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	refreshSessionHandlerV1 := auth.RefreshSessionHandlerV1(mockRefreshSession)
+
+	refreshSessionHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+
+	var got struct {
+		Data auth.LoginResponseDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+	assert.NoError(t, err)
+
+	assert.Equal(t, auth.LoginResponseDTO{Token: "new token", ExpiresAt: mockExpiresAt}, got.Data)
+}
+
+func TestRefreshSessionHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockRefreshSession := auth.MockRefreshSession("", time.Time{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/sessions/refresh/v1", nil)
+
+	refreshSessionHandlerV1 := auth.RefreshSessionHandlerV1(mockRefreshSession)
+
+	refreshSessionHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRefreshSessionHandlerV1_failsWhenRefreshSessionThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.FailedToRefreshUserSession, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockRefreshSession := auth.MockRefreshSession("", time.Time{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/sessions/refresh/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		refreshSessionHandlerV1 := auth.RefreshSessionHandlerV1(mockRefreshSession)
+
+		refreshSessionHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestListSessionsHandlerV1_success(t *testing.T) {
+	mockSessions := auth.MockSessionDTOs()
+	mockListSessions := auth.MockListSessions(mockSessions, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/sessions/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	listSessionsHandlerV1 := auth.ListSessionsHandlerV1(mockListSessions)
+
+	listSessionsHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+
+	var got struct {
+		Data []auth.SessionDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+	assert.NoError(t, err)
+
+	assert.Equal(t, mockSessions, got.Data)
+}
+
+func TestListSessionsHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockListSessions := auth.MockListSessions(nil, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/sessions/v1", nil)
+
+	listSessionsHandlerV1 := auth.ListSessionsHandlerV1(mockListSessions)
+
+	listSessionsHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestListSessionsHandlerV1_failsWhenListSessionsThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.FailedToRetrieveActiveSessions, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockListSessions := auth.MockListSessions(nil, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/sessions/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		listSessionsHandlerV1 := auth.ListSessionsHandlerV1(mockListSessions)
+
+		listSessionsHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRevokeSessionHandlerV1_success(t *testing.T) {
+	mockRevokeSession := auth.MockRevokeSession(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/2/v1", nil)
+	mockRequest.SetPathValue("session_id", "2")
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	revokeSessionHandlerV1 := auth.RevokeSessionHandlerV1(mockRevokeSession)
+
+	revokeSessionHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeSessionHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockRevokeSession := auth.MockRevokeSession(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/2/v1", nil)
+	mockRequest.SetPathValue("session_id", "2")
+
+	revokeSessionHandlerV1 := auth.RevokeSessionHandlerV1(mockRevokeSession)
+
+	revokeSessionHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeSessionHandlerV1_failsWhenSessionIDIsInvalid(t *testing.T) {
+	mockRevokeSession := auth.MockRevokeSession(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/invalid/v1", nil)
+	mockRequest.SetPathValue("session_id", "invalid")
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	revokeSessionHandlerV1 := auth.RevokeSessionHandlerV1(mockRevokeSession)
+
+	revokeSessionHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeSessionHandlerV1_failsWhenRevokeSessionThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.NoSessionFoundForTheGivenID, expected: http.StatusNotFound},
+		{err: auth.FailedToDeleteUserSession, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockRevokeSession := auth.MockRevokeSession(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/2/v1", nil)
+		mockRequest.SetPathValue("session_id", "2")
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		revokeSessionHandlerV1 := auth.RevokeSessionHandlerV1(mockRevokeSession)
+
+		revokeSessionHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRevokeOtherSessionsHandlerV1_success(t *testing.T) {
+	mockRevokeOtherSessions := auth.MockRevokeOtherSessions(3, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	revokeOtherSessionsHandlerV1 := auth.RevokeOtherSessionsHandlerV1(mockRevokeOtherSessions)
+
+	revokeOtherSessionsHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+
+	var got struct {
+		Data auth.RevokedSessionsDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+	assert.NoError(t, err)
+
+	assert.Equal(t, auth.RevokedSessionsDTO{Revoked: 3}, got.Data)
+}
+
+func TestRevokeOtherSessionsHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockRevokeOtherSessions := auth.MockRevokeOtherSessions(0, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/v1", nil)
+
+	revokeOtherSessionsHandlerV1 := auth.RevokeOtherSessionsHandlerV1(mockRevokeOtherSessions)
+
+	revokeOtherSessionsHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeOtherSessionsHandlerV1_failsWhenRevokeOtherSessionsThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.FailedToDeleteOtherUserSessions, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockRevokeOtherSessions := auth.MockRevokeOtherSessions(0, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/sessions/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		revokeOtherSessionsHandlerV1 := auth.RevokeOtherSessionsHandlerV1(mockRevokeOtherSessions)
+
+		revokeOtherSessionsHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSigningKeysHandlerV1_success(t *testing.T) {
+	mockSigningKeys := auth.MockSigningKeys(auth.MockSigningKeyDTOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/sessions/keys/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	signingKeysHandlerV1 := auth.SigningKeysHandlerV1(mockSigningKeys)
+
+	signingKeysHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+
+	var got struct {
+		Data []auth.SigningKeyDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+	assert.NoError(t, err)
+
+	assert.Equal(t, auth.MockSigningKeyDTOs(), got.Data)
+}
+
+func TestSigningKeysHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockSigningKeys := auth.MockSigningKeys(auth.MockSigningKeyDTOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/sessions/keys/v1", nil)
+
+	signingKeysHandlerV1 := auth.SigningKeysHandlerV1(mockSigningKeys)
+
+	signingKeysHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestSigningKeysHandlerV1_failsWhenSigningKeysThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.FailedToAuthorizeUser, expected: http.StatusInternalServerError},
+		{err: auth.FailedToRetrieveSigningKeysUsage, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockSigningKeys := auth.MockSigningKeys(nil, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/sessions/keys/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		signingKeysHandlerV1 := auth.SigningKeysHandlerV1(mockSigningKeys)
+
+		signingKeysHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestUnlockAccountHandlerV1_success(t *testing.T) {
+	mockUnlockAccount := auth.MockUnlockAccount(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/lockouts/username/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+	mockRequest.SetPathValue("username", "username")
+
+	unlockAccountHandlerV1 := auth.UnlockAccountHandlerV1(mockUnlockAccount)
+
+	unlockAccountHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestUnlockAccountHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockUnlockAccount := auth.MockUnlockAccount(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/lockouts/username/v1", nil)
+	mockRequest.SetPathValue("username", "username")
+
+	unlockAccountHandlerV1 := auth.UnlockAccountHandlerV1(mockUnlockAccount)
+
+	unlockAccountHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestUnlockAccountHandlerV1_failsWhenUnlockAccountThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.AccountNotLocked, expected: http.StatusNotFound},
+		{err: auth.FailedToUnlockUserAccount, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockUnlockAccount := auth.MockUnlockAccount(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/lockouts/username/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("username", "username")
+
+		unlockAccountHandlerV1 := auth.UnlockAccountHandlerV1(mockUnlockAccount)
+
+		unlockAccountHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestChangePasswordHandlerV1_success(t *testing.T) {
+	mockChangePassword := auth.MockChangePassword(2, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.MockChangePasswordDTO())
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/auth/password/v1", bytes.NewReader(mockBody))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	changePasswordHandlerV1 := auth.ChangePasswordHandlerV1(mockChangePassword)
+
+	changePasswordHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestChangePasswordHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockChangePassword := auth.MockChangePassword(2, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.MockChangePasswordDTO())
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/auth/password/v1", bytes.NewReader(mockBody))
+
+	changePasswordHandlerV1 := auth.ChangePasswordHandlerV1(mockChangePassword)
+
+	changePasswordHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestChangePasswordHandlerV1_failsWhenTheBodyIsInvalid(t *testing.T) {
+	tests := []struct {
+		body string
+	}{
+		{body: `{"current_password": "password", "new_password": 1}`},
+		{body: `{"new_password": "new password 1"}`},
+		{body: `{"current_password": "password"}`},
+	}
+
+	for _, tt := range tests {
+		mockChangePassword := auth.MockChangePassword(2, nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/auth/password/v1", bytes.NewReader([]byte(tt.body)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		changePasswordHandlerV1 := auth.ChangePasswordHandlerV1(mockChangePassword)
+
+		changePasswordHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestChangePasswordHandlerV1_failsWhenChangePasswordThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.WrongCurrentPassword, expected: http.StatusForbidden},
+		{err: password.PasswordTooShort, expected: http.StatusBadRequest},
+		{err: auth.FailedToUpdateUserPassword, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockChangePassword := auth.MockChangePassword(0, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(auth.MockChangePasswordDTO())
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/auth/password/v1", bytes.NewReader(mockBody))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		changePasswordHandlerV1 := auth.ChangePasswordHandlerV1(mockChangePassword)
+
+		changePasswordHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestIssuePasswordResetTokenHandlerV1_success(t *testing.T) {
+	mockIssuePasswordResetToken := auth.MockIssuePasswordResetToken(auth.MockPasswordResetTokenDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.PasswordResetRequestDTO{Username: "username"})
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/password/reset/tokens/v1", bytes.NewReader(mockBody))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	issuePasswordResetTokenHandlerV1 := auth.IssuePasswordResetTokenHandlerV1(mockIssuePasswordResetToken)
+
+	issuePasswordResetTokenHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestIssuePasswordResetTokenHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockIssuePasswordResetToken := auth.MockIssuePasswordResetToken(auth.MockPasswordResetTokenDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.PasswordResetRequestDTO{Username: "username"})
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/password/reset/tokens/v1", bytes.NewReader(mockBody))
+
+	issuePasswordResetTokenHandlerV1 := auth.IssuePasswordResetTokenHandlerV1(mockIssuePasswordResetToken)
+
+	issuePasswordResetTokenHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestIssuePasswordResetTokenHandlerV1_failsWhenTheBodyIsInvalid(t *testing.T) {
+	tests := []struct {
+		body string
+	}{
+		{body: `{"username": 1}`},
+		{body: `{}`},
+	}
+
+	for _, tt := range tests {
+		mockIssuePasswordResetToken := auth.MockIssuePasswordResetToken(auth.MockPasswordResetTokenDTO(), nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/password/reset/tokens/v1", bytes.NewReader([]byte(tt.body)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		issuePasswordResetTokenHandlerV1 := auth.IssuePasswordResetTokenHandlerV1(mockIssuePasswordResetToken)
+
+		issuePasswordResetTokenHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestIssuePasswordResetTokenHandlerV1_failsWhenIssuePasswordResetTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.NoUserFoundForTheGivenUsername, expected: http.StatusNotFound},
+		{err: auth.FailedToCreatePasswordResetToken, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockIssuePasswordResetToken := auth.MockIssuePasswordResetToken(auth.PasswordResetTokenDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(auth.PasswordResetRequestDTO{Username: "username"})
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/password/reset/tokens/v1", bytes.NewReader(mockBody))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		issuePasswordResetTokenHandlerV1 := auth.IssuePasswordResetTokenHandlerV1(mockIssuePasswordResetToken)
+
+		issuePasswordResetTokenHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestResetPasswordHandlerV1_success(t *testing.T) {
+	mockResetPassword := auth.MockResetPassword(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.MockResetPasswordDTO())
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/password/reset/v1", bytes.NewReader(mockBody))
+
+	resetPasswordHandlerV1 := auth.ResetPasswordHandlerV1(mockResetPassword)
+
+	resetPasswordHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestResetPasswordHandlerV1_failsWhenTheBodyIsInvalid(t *testing.T) {
+	tests := []struct {
+		body string
+	}{
+		{body: `{"token": 1}`},
+		{body: `{"new_password": "new password 1"}`},
+		{body: `{"token": "reset token"}`},
+	}
+
+	for _, tt := range tests {
+		mockResetPassword := auth.MockResetPassword(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/password/reset/v1", bytes.NewReader([]byte(tt.body)))
+
+		resetPasswordHandlerV1 := auth.ResetPasswordHandlerV1(mockResetPassword)
+
+		resetPasswordHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestResetPasswordHandlerV1_failsWhenResetPasswordThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidPasswordResetToken, expected: http.StatusBadRequest},
+		{err: password.PasswordEqualToUsername, expected: http.StatusBadRequest},
+		{err: auth.FailedToResetUserPassword, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockResetPassword := auth.MockResetPassword(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(auth.MockResetPasswordDTO())
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/password/reset/v1", bytes.NewReader(mockBody))
+
+		resetPasswordHandlerV1 := auth.ResetPasswordHandlerV1(mockResetPassword)
+
+		resetPasswordHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCreateInviteHandlerV1_success(t *testing.T) {
+	mockCreateInvite := auth.MockCreateInvite(auth.InviteCodeDTO{ID: 1, Code: "invite code"}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.CreateInviteDTO{Role: user.AnnotatorRole, MaxUses: 5, ExpiresInHours: 48})
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/invites/v1", bytes.NewReader(mockBody))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	createInviteHandlerV1 := auth.CreateInviteHandlerV1(mockCreateInvite)
+
+	createInviteHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreateInviteHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockCreateInvite := auth.MockCreateInvite(auth.InviteCodeDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/invites/v1", bytes.NewReader([]byte(`{}`)))
+
+	createInviteHandlerV1 := auth.CreateInviteHandlerV1(mockCreateInvite)
+
+	createInviteHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreateInviteHandlerV1_failsWhenTheBodyCannotBeParsed(t *testing.T) {
+	mockCreateInvite := auth.MockCreateInvite(auth.InviteCodeDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/invites/v1", bytes.NewReader([]byte(`{"max_uses": "five"}`)))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	createInviteHandlerV1 := auth.CreateInviteHandlerV1(mockCreateInvite)
+
+	createInviteHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreateInviteHandlerV1_failsWhenCreateInviteThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.InvalidInviteRole, expected: http.StatusBadRequest},
+		{err: auth.InvalidInviteMaxUses, expected: http.StatusBadRequest},
+		{err: auth.InvalidInviteExpiration, expected: http.StatusBadRequest},
+		{err: auth.FailedToCreateUserInvite, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockCreateInvite := auth.MockCreateInvite(auth.InviteCodeDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/invites/v1", bytes.NewReader([]byte(`{}`)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		createInviteHandlerV1 := auth.CreateInviteHandlerV1(mockCreateInvite)
+
+		createInviteHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestListInvitesHandlerV1_success(t *testing.T) {
+	mockListInvites := auth.MockListInvites(auth.MockInviteDTOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/invites/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	listInvitesHandlerV1 := auth.ListInvitesHandlerV1(mockListInvites)
+
+	listInvitesHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestListInvitesHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockListInvites := auth.MockListInvites(auth.MockInviteDTOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/invites/v1", nil)
+
+	listInvitesHandlerV1 := auth.ListInvitesHandlerV1(mockListInvites)
+
+	listInvitesHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestListInvitesHandlerV1_failsWhenListInvitesThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.FailedToRetrieveInvites, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockListInvites := auth.MockListInvites(nil, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/invites/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		listInvitesHandlerV1 := auth.ListInvitesHandlerV1(mockListInvites)
+
+		listInvitesHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestOIDCLogInHandlerV1_success(t *testing.T) {
+	mockAuthorizationURL := oidc.MockAuthorizationURL("https://idp.example.edu/authorize?state=state", nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/login/v1", nil)
+
+	oidcLogInHandlerV1 := auth.OIDCLogInHandlerV1(mockAuthorizationURL)
+
+	oidcLogInHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusFound, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, "https://idp.example.edu/authorize?state=state", mockResponseWriter.Header().Get("Location"))
+}
+
+func TestOIDCLogInHandlerV1_failsWhenAuthorizationURLThrowsError(t *testing.T) {
+	mockAuthorizationURL := oidc.MockAuthorizationURL("", oidc.FailedToCreateAuthorizationURL)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/login/v1", nil)
+
+	oidcLogInHandlerV1 := auth.OIDCLogInHandlerV1(mockAuthorizationURL)
+
+	oidcLogInHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusInternalServerError
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestOIDCCallbackHandlerV1_success(t *testing.T) {
+	mockExpiresAt := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+	mockOIDCLogIn := auth.MockOIDCLogIn("token", mockExpiresAt, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/callback/v1?code=code&state=state", nil)
+
+	oidcCallbackHandlerV1 := auth.OIDCCallbackHandlerV1(mockOIDCLogIn, "")
+
+	oidcCallbackHandlerV1(mockResponseWriter, mockRequest)
+
+	var got response.DTO
+	_ = json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, map[string]any{"token": "token", "expires_at": "2025-03-08T00:00:00Z"}, got.Data)
+}
+
+func TestOIDCCallbackHandlerV1_successRedirectingToThePostLoginURL(t *testing.T) {
+	mockExpiresAt := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+	mockOIDCLogIn := auth.MockOIDCLogIn("token", mockExpiresAt, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/callback/v1?code=code&state=state", nil)
+
+	oidcCallbackHandlerV1 := auth.OIDCCallbackHandlerV1(mockOIDCLogIn, "https://ahbcc.example.edu/login")
+
+	oidcCallbackHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusFound, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, "https://ahbcc.example.edu/login#expires_at=2025-03-08T00%3A00%3A00Z&token=token", mockResponseWriter.Header().Get("Location"))
+}
+
+func TestOIDCCallbackHandlerV1_failsWhenTheCallbackIsInvalid(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected int
+	}{
+		{url: "/auth/oidc/callback/v1?error=access_denied&state=state", expected: http.StatusUnauthorized},
+		{url: "/auth/oidc/callback/v1?state=state", expected: http.StatusBadRequest},
+		{url: "/auth/oidc/callback/v1?code=code", expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		mockOIDCLogIn := auth.MockOIDCLogIn("token", time.Now(), nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, tt.url, nil)
+
+		oidcCallbackHandlerV1 := auth.OIDCCallbackHandlerV1(mockOIDCLogIn, "")
+
+		oidcCallbackHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestOIDCCallbackHandlerV1_failsWhenOIDCLogInThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidOIDCState, expected: http.StatusBadRequest},
+		{err: auth.OIDCAuthenticationFailed, expected: http.StatusUnauthorized},
+		{err: auth.OIDCUserNotProvisioned, expected: http.StatusForbidden},
+		{err: auth.OIDCUsernameTaken, expected: http.StatusConflict},
+		{err: auth.AccountDisabled, expected: http.StatusForbidden},
+		{err: auth.FailedToResolveOIDCUser, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockOIDCLogIn := auth.MockOIDCLogIn("", time.Time{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/callback/v1?code=code&state=state", nil)
+
+		oidcCallbackHandlerV1 := auth.OIDCCallbackHandlerV1(mockOIDCLogIn, "https://ahbcc.example.edu/login")
+
+		oidcCallbackHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestOIDCCallbackHandlerV1_successWhenASecondFactorIsRequired(t *testing.T) {
+	tests := []struct {
+		postLoginRedirectURL string
+		expected             int
+		expectedLocation     string
+	}{
+		{postLoginRedirectURL: "", expected: http.StatusAccepted},
+		{postLoginRedirectURL: "https://ahbcc.example.edu/login", expected: http.StatusFound, expectedLocation: "https://ahbcc.example.edu/login#challenge=challenge&expires_at=2025-03-08T00%3A05%3A00Z"},
+	}
+
+	for _, tt := range tests {
+		mockExpiresAt := time.Date(2025, time.March, 8, 0, 5, 0, 0, time.UTC)
+		mockOIDCLogIn := auth.MockOIDCLogIn("challenge", mockExpiresAt, auth.SecondFactorRequired)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/auth/oidc/callback/v1?code=code&state=state", nil)
+
+		oidcCallbackHandlerV1 := auth.OIDCCallbackHandlerV1(mockOIDCLogIn, tt.postLoginRedirectURL)
+
+		oidcCallbackHandlerV1(mockResponseWriter, mockRequest)
+
+		assert.Equal(t, tt.expected, mockResponseWriter.Result().StatusCode)
+		assert.Equal(t, tt.expectedLocation, mockResponseWriter.Header().Get("Location"))
+	}
+}
+
+func TestCompleteLogInHandlerV1_success(t *testing.T) {
+	mockExpiresAt := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+	mockCompleteLogIn := auth.MockCompleteLogIn("token", mockExpiresAt, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.CompleteLogInDTO{Challenge: "challenge", Code: "123456"})
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/login/2fa/v1", bytes.NewReader(mockBody))
+
+	completeLogInHandlerV1 := auth.CompleteLogInHandlerV1(mockCompleteLogIn)
+
+	completeLogInHandlerV1(mockResponseWriter, mockRequest)
+
+	var got response.DTO
+	_ = json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, map[string]any{"token": "token", "expires_at": "2025-03-08T00:00:00Z"}, got.Data)
+}
+
+func TestCompleteLogInHandlerV1_failsWhenTheBodyIsInvalid(t *testing.T) {
+	tests := []struct {
+		body string
+	}{
+		{body: `{"challenge": 1}`},
+		{body: `{"code": "123456"}`},
+		{body: `{"challenge": "challenge"}`},
+	}
+
+	for _, tt := range tests {
+		mockCompleteLogIn := auth.MockCompleteLogIn("token", time.Now(), nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/login/2fa/v1", bytes.NewReader([]byte(tt.body)))
+
+		completeLogInHandlerV1 := auth.CompleteLogInHandlerV1(mockCompleteLogIn)
+
+		completeLogInHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCompleteLogInHandlerV1_failsWhenCompleteLogInThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidLoginChallenge, expected: http.StatusUnauthorized},
+		{err: auth.WrongSecondFactorCode, expected: http.StatusUnauthorized},
+		{err: auth.TooManyLoginAttempts, expected: http.StatusTooManyRequests},
+		{err: auth.AccountLocked, expected: http.StatusTooManyRequests},
+		{err: auth.AccountDisabled, expected: http.StatusForbidden},
+		{err: auth.FailedToCompleteLogIn, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockCompleteLogIn := auth.MockCompleteLogIn("", time.Time{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(auth.CompleteLogInDTO{Challenge: "challenge", Code: "123456"})
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/login/2fa/v1", bytes.NewReader(mockBody))
+
+		completeLogInHandlerV1 := auth.CompleteLogInHandlerV1(mockCompleteLogIn)
+
+		completeLogInHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestEnrollTwoFactorHandlerV1_success(t *testing.T) {
+	mockEnrollTwoFactor := auth.MockEnrollTwoFactor(totp.MockEnrollmentDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	enrollTwoFactorHandlerV1 := auth.EnrollTwoFactorHandlerV1(mockEnrollTwoFactor)
+
+	enrollTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnrollTwoFactorHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockEnrollTwoFactor := auth.MockEnrollTwoFactor(totp.MockEnrollmentDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/v1", nil)
+
+	enrollTwoFactorHandlerV1 := auth.EnrollTwoFactorHandlerV1(mockEnrollTwoFactor)
+
+	enrollTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnrollTwoFactorHandlerV1_failsWhenEnrollTwoFactorThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.TwoFactorAlreadyEnabled, expected: http.StatusConflict},
+		{err: auth.FailedToEnrollTwoFactor, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockEnrollTwoFactor := auth.MockEnrollTwoFactor(totp.EnrollmentDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		enrollTwoFactorHandlerV1 := auth.EnrollTwoFactorHandlerV1(mockEnrollTwoFactor)
+
+		enrollTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestConfirmTwoFactorHandlerV1_success(t *testing.T) {
+	mockConfirmTwoFactor := auth.MockConfirmTwoFactor(totp.MockRecoveryCodes(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.TwoFactorCodeDTO{Code: "123456"})
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/confirm/v1", bytes.NewReader(mockBody))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	confirmTwoFactorHandlerV1 := auth.ConfirmTwoFactorHandlerV1(mockConfirmTwoFactor)
+
+	confirmTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+	var got response.DTO
+	_ = json.NewDecoder(mockResponseWriter.Body).Decode(&got)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Result().StatusCode)
+	assert.Equal(t, map[string]any{"recovery_codes": []any{"abcd-efgh-ijkl-mnop", "qrst-uvwx-yz23-4567"}}, got.Data)
+}
+
+func TestConfirmTwoFactorHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockConfirmTwoFactor := auth.MockConfirmTwoFactor(totp.MockRecoveryCodes(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/confirm/v1", bytes.NewReader([]byte(`{"code": "123456"}`)))
+
+	confirmTwoFactorHandlerV1 := auth.ConfirmTwoFactorHandlerV1(mockConfirmTwoFactor)
+
+	confirmTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestConfirmTwoFactorHandlerV1_failsWhenTheBodyIsInvalid(t *testing.T) {
+	tests := []struct {
+		body string
+	}{
+		{body: `{"code": 1}`},
+		{body: `{}`},
+	}
+
+	for _, tt := range tests {
+		mockConfirmTwoFactor := auth.MockConfirmTwoFactor(totp.MockRecoveryCodes(), nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/confirm/v1", bytes.NewReader([]byte(tt.body)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		confirmTwoFactorHandlerV1 := auth.ConfirmTwoFactorHandlerV1(mockConfirmTwoFactor)
+
+		confirmTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestConfirmTwoFactorHandlerV1_failsWhenConfirmTwoFactorThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.TwoFactorNotEnrolled, expected: http.StatusNotFound},
+		{err: auth.TwoFactorAlreadyEnabled, expected: http.StatusConflict},
+		{err: auth.WrongSecondFactorCode, expected: http.StatusForbidden},
+		{err: auth.FailedToConfirmTwoFactor, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockConfirmTwoFactor := auth.MockConfirmTwoFactor(nil, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(auth.TwoFactorCodeDTO{Code: "123456"})
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/auth/2fa/confirm/v1", bytes.NewReader(mockBody))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		confirmTwoFactorHandlerV1 := auth.ConfirmTwoFactorHandlerV1(mockConfirmTwoFactor)
+
+		confirmTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestDisableTwoFactorHandlerV1_success(t *testing.T) {
+	mockDisableTwoFactor := auth.MockDisableTwoFactor(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(auth.TwoFactorCodeDTO{Code: "123456"})
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/2fa/v1", bytes.NewReader(mockBody))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	disableTwoFactorHandlerV1 := auth.DisableTwoFactorHandlerV1(mockDisableTwoFactor)
+
+	disableTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestDisableTwoFactorHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockDisableTwoFactor := auth.MockDisableTwoFactor(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/2fa/v1", bytes.NewReader([]byte(`{"code": "123456"}`)))
+
+	disableTwoFactorHandlerV1 := auth.DisableTwoFactorHandlerV1(mockDisableTwoFactor)
+
+	disableTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestDisableTwoFactorHandlerV1_failsWhenTheBodyIsInvalid(t *testing.T) {
+	tests := []struct {
+		body string
+	}{
+		{body: `{"code": 1}`},
+		{body: `{}`},
+	}
+
+	for _, tt := range tests {
+		mockDisableTwoFactor := auth.MockDisableTwoFactor(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/2fa/v1", bytes.NewReader([]byte(tt.body)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		disableTwoFactorHandlerV1 := auth.DisableTwoFactorHandlerV1(mockDisableTwoFactor)
+
+		disableTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestDisableTwoFactorHandlerV1_failsWhenDisableTwoFactorThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.TwoFactorRequiredForRole, expected: http.StatusForbidden},
+		{err: auth.TwoFactorNotEnabled, expected: http.StatusNotFound},
+		{err: auth.WrongSecondFactorCode, expected: http.StatusForbidden},
+		{err: auth.FailedToDisableTwoFactor, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockDisableTwoFactor := auth.MockDisableTwoFactor(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockBody, _ := json.Marshal(auth.TwoFactorCodeDTO{Code: "123456"})
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/auth/2fa/v1", bytes.NewReader(mockBody))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		disableTwoFactorHandlerV1 := auth.DisableTwoFactorHandlerV1(mockDisableTwoFactor)
+
+		disableTwoFactorHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
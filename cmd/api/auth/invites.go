@@ -0,0 +1,128 @@
+package auth
+
+import (
+	"context"
+	"time"
+
+	"ahbcc/cmd/api/auth/invites"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+type (
+	// CreateInvite creates an invite to sign up. When they aren't given, the role of the invite is ANNOTATOR, it can be
+	// used once and it lasts the default duration. Only the admins are allowed to do it
+	CreateInvite func(ctx context.Context, token string, body CreateInviteDTO) (InviteCodeDTO, error)
+
+	// ListInvites returns the invites that can still be redeemed, with the users that signed up with each of them. Only
+	// the admins are allowed to do it
+	ListInvites func(ctx context.Context, token string) ([]InviteDTO, error)
+)
+
+// MakeCreateInvite creates a new CreateInvite
+func MakeCreateInvite(authorize Authorize, createInvite invites.Create, defaultDuration time.Duration) CreateInvite {
+	return func(ctx context.Context, token string, body CreateInviteDTO) (InviteCodeDTO, error) {
+		adminID, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return InviteCodeDTO{}, AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		role := body.Role
+		switch role {
+		case "":
+			role = user.AnnotatorRole
+		case user.AnnotatorRole, user.AdminRole:
+		default:
+			log.Error(ctx, InvalidInviteRole.Error())
+			return InviteCodeDTO{}, InvalidInviteRole
+		}
+
+		maxUses := body.MaxUses
+		if maxUses < 0 {
+			log.Error(ctx, InvalidInviteMaxUses.Error())
+			return InviteCodeDTO{}, InvalidInviteMaxUses
+		} else if maxUses == 0 {
+			maxUses = 1
+		}
+
+		duration := time.Duration(body.ExpiresInHours) * time.Hour
+		if duration < 0 {
+			log.Error(ctx, InvalidInviteExpiration.Error())
+			return InviteCodeDTO{}, InvalidInviteExpiration
+		} else if duration == 0 {
+			duration = defaultDuration
+		}
+		expiresAt := time.Now().Add(duration)
+
+		id, code, err := createInvite(ctx, role, maxUses, expiresAt, adminID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return InviteCodeDTO{}, FailedToCreateUserInvite
+		}
+
+		return InviteCodeDTO{ID: id, Code: code, Role: role, MaxUses: maxUses, ExpiresAt: expiresAt}, nil
+	}
+}
+
+// MakeListInvites creates a new ListInvites
+func MakeListInvites(authorize Authorize, selectOutstandingInvites invites.SelectOutstanding, selectRedemptions invites.SelectRedemptions) ListInvites {
+	return func(ctx context.Context, token string) ([]InviteDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		outstandingInvites, err := selectOutstandingInvites(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveInvites
+		}
+
+		result := make([]InviteDTO, 0, len(outstandingInvites))
+		if len(outstandingInvites) == 0 {
+			return result, nil
+		}
+
+		inviteIDs := make([]int, 0, len(outstandingInvites))
+		for _, invite := range outstandingInvites {
+			inviteIDs = append(inviteIDs, invite.ID)
+		}
+
+		redemptions, err := selectRedemptions(ctx, inviteIDs)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveInvites
+		}
+
+		redemptionsByInviteID := make(map[int][]RedemptionDTO)
+		for _, redemption := range redemptions {
+			redemptionsByInviteID[redemption.InviteID] = append(redemptionsByInviteID[redemption.InviteID], RedemptionDTO{
+				UserID:     redemption.UserID,
+				Username:   redemption.Username,
+				RedeemedAt: redemption.RedeemedAt,
+			})
+		}
+
+		for _, invite := range outstandingInvites {
+			inviteRedemptions := redemptionsByInviteID[invite.ID]
+			if inviteRedemptions == nil {
+				inviteRedemptions = []RedemptionDTO{}
+			}
+
+			result = append(result, InviteDTO{
+				ID:          invite.ID,
+				Role:        invite.Role,
+				MaxUses:     invite.MaxUses,
+				Uses:        invite.Uses,
+				ExpiresAt:   invite.ExpiresAt,
+				CreatedBy:   invite.CreatedBy,
+				CreatedAt:   invite.CreatedAt,
+				Redemptions: inviteRedemptions,
+			})
+		}
+
+		return result, nil
+	}
+}
@@ -0,0 +1,45 @@
+package invites
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+// Create creates a new invite with a random code, that can be redeemed maxUses times until it expires, assigning the
+// given role to the users that sign up with it. It returns the ID of the invite with its code
+type Create func(ctx context.Context, role string, maxUses int, expiresAt time.Time, createdBy int) (int, string, error)
+
+// codeSize is the amount of random bytes of each invite code
+const codeSize int = 16
+
+// MakeCreate creates a new Create
+func MakeCreate(insertInvite Insert) Create {
+	return func(ctx context.Context, role string, maxUses int, expiresAt time.Time, createdBy int) (int, string, error) {
+		random := make([]byte, codeSize)
+		_, err := rand.Read(random)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, "", FailedToCreateInvite
+		}
+
+		invite := DAO{
+			Code:      base64.RawURLEncoding.EncodeToString(random),
+			Role:      role,
+			MaxUses:   maxUses,
+			ExpiresAt: expiresAt,
+			CreatedBy: createdBy,
+		}
+
+		id, err := insertInvite(ctx, invite)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, "", FailedToCreateInvite
+		}
+
+		return id, invite.Code, nil
+	}
+}
@@ -0,0 +1,49 @@
+package invites_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/invites"
+	"ahbcc/cmd/api/user"
+)
+
+func TestCreate_success(t *testing.T) {
+	var inserted invites.DAO
+	insertInvite := func(ctx context.Context, invite invites.DAO) (int, error) {
+		inserted = invite
+		return 1, nil
+	}
+	expiresAt := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+
+	createInvite := invites.MakeCreate(insertInvite)
+
+	id, code, err := createInvite(context.Background(), user.AdminRole, 3, expiresAt, 2)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, id)
+	assert.NotEmpty(t, code)
+	assert.Equal(t, invites.DAO{Code: code, Role: user.AdminRole, MaxUses: 3, ExpiresAt: expiresAt, CreatedBy: 2}, inserted)
+}
+
+func TestCreate_successCreatesDifferentCodes(t *testing.T) {
+	createInvite := invites.MakeCreate(invites.MockInsert(1, nil))
+
+	_, first, _ := createInvite(context.Background(), user.AnnotatorRole, 1, time.Now(), 2)
+	_, second, _ := createInvite(context.Background(), user.AnnotatorRole, 1, time.Now(), 2)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestCreate_failsWhenInsertThrowsError(t *testing.T) {
+	createInvite := invites.MakeCreate(invites.MockInsert(0, errors.New("failed to insert invite")))
+
+	want := invites.FailedToCreateInvite
+	_, _, got := createInvite(context.Background(), user.AnnotatorRole, 1, time.Now(), 2)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,35 @@
+package invites
+
+import "time"
+
+type (
+	// DAO represents an invite to sign up, created by an admin. The users that sign up with its Code get its Role. The
+	// Code is never stored, only its hash
+	DAO struct {
+		Code      string    `json:"code"`
+		Role      string    `json:"role"`
+		MaxUses   int       `json:"max_uses"`
+		ExpiresAt time.Time `json:"expires_at"`
+		CreatedBy int       `json:"created_by"`
+	}
+
+	// OutstandingDAO represents an invite that can still be redeemed: it hasn't expired and it has uses left.
+	// CreatedBy is nil when the admin that created it was deleted
+	OutstandingDAO struct {
+		ID        int       `json:"id"`
+		Role      string    `json:"role"`
+		MaxUses   int       `json:"max_uses"`
+		Uses      int       `json:"uses"`
+		ExpiresAt time.Time `json:"expires_at"`
+		CreatedBy *int      `json:"created_by"`
+		CreatedAt time.Time `json:"created_at"`
+	}
+
+	// RedemptionDAO represents the sign up of a user with an invite
+	RedemptionDAO struct {
+		InviteID   int       `json:"invite_id"`
+		UserID     int       `json:"user_id"`
+		Username   string    `json:"username"`
+		RedeemedAt time.Time `json:"redeemed_at"`
+	}
+)
@@ -0,0 +1,14 @@
+package invites
+
+import "errors"
+
+var (
+	FailedToCreateInvite                          = errors.New("failed to create invite")
+	FailedToInsertInvite                          = errors.New("failed to insert invite")
+	NoValidInviteFound                            = errors.New("no valid invite found, it may be expired or exhausted")
+	FailedToRedeemInvite                          = errors.New("failed to redeem invite")
+	FailedToExecuteSelectOutstanding              = errors.New("failed to execute select outstanding invites")
+	FailedToExecuteCollectRowsInSelectOutstanding = errors.New("failed to execute collect rows in select outstanding invites")
+	FailedToExecuteSelectRedemptions              = errors.New("failed to execute select invites redemptions")
+	FailedToExecuteCollectRowsInSelectRedemptions = errors.New("failed to execute collect rows in select invites redemptions")
+)
@@ -0,0 +1,32 @@
+package invites
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// Insert inserts an invite into the 'invites' table and returns its ID. Only the hash of the code is stored
+type Insert func(ctx context.Context, invite DAO) (int, error)
+
+// MakeInsert creates a new Insert
+func MakeInsert(db database.Connection) Insert {
+	const query string = `
+		INSERT INTO invites(code_hash, role, max_uses, expires_at, created_by)
+		VALUES ($1, $2, $3, $4, $5)
+		RETURNING id;
+	`
+
+	return func(ctx context.Context, invite DAO) (int, error) {
+		var id int
+		err := db.QueryRow(ctx, query, session.HashToken(invite.Code), invite.Role, invite.MaxUses, invite.ExpiresAt, invite.CreatedBy).Scan(&id)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToInsertInvite
+		}
+
+		return id, nil
+	}
+}
@@ -0,0 +1,48 @@
+package invites_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/invites"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+)
+
+func TestInsert_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{1}, t)
+	mockInviteDAO := invites.MockDAO()
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{session.HashToken(mockInviteDAO.Code), mockInviteDAO.Role, mockInviteDAO.MaxUses, mockInviteDAO.ExpiresAt, mockInviteDAO.CreatedBy}).Return(mockPgxRow)
+
+	insertInvite := invites.MakeInsert(mockPostgresConnection)
+
+	want := 1
+	got, err := insertInvite(context.Background(), mockInviteDAO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestInsert_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(errors.New("failed to insert invite"))
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	insertInvite := invites.MakeInsert(mockPostgresConnection)
+
+	want := invites.FailedToInsertInvite
+	_, got := insertInvite(context.Background(), invites.MockDAO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
@@ -0,0 +1,72 @@
+package invites
+
+import (
+	"context"
+	"time"
+
+	"ahbcc/cmd/api/user"
+)
+
+// MockCreate mocks a Create function
+func MockCreate(id int, code string, err error) Create {
+	return func(ctx context.Context, role string, maxUses int, expiresAt time.Time, createdBy int) (int, string, error) {
+		return id, code, err
+	}
+}
+
+// MockInsert mocks an Insert function
+func MockInsert(id int, err error) Insert {
+	return func(ctx context.Context, invite DAO) (int, error) {
+		return id, err
+	}
+}
+
+// MockRedeem mocks a Redeem function
+func MockRedeem(err error) Redeem {
+	return func(ctx context.Context, code string, user user.DTO) error {
+		return err
+	}
+}
+
+// MockSelectOutstanding mocks a SelectOutstanding function
+func MockSelectOutstanding(invites []OutstandingDAO, err error) SelectOutstanding {
+	return func(ctx context.Context) ([]OutstandingDAO, error) {
+		return invites, err
+	}
+}
+
+// MockSelectRedemptions mocks a SelectRedemptions function
+func MockSelectRedemptions(redemptions []RedemptionDAO, err error) SelectRedemptions {
+	return func(ctx context.Context, inviteIDs []int) ([]RedemptionDAO, error) {
+		return redemptions, err
+	}
+}
+
+// MockDAO mocks a DAO
+func MockDAO() DAO {
+	return DAO{
+		Code:      "invite code",
+		Role:      user.AnnotatorRole,
+		MaxUses:   5,
+		ExpiresAt: time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC),
+		CreatedBy: 2,
+	}
+}
+
+// MockOutstandingDAOs mocks a slice of OutstandingDAO
+func MockOutstandingDAOs() []OutstandingDAO {
+	createdBy := 2
+
+	return []OutstandingDAO{
+		{ID: 1, Role: user.AnnotatorRole, MaxUses: 5, Uses: 2, ExpiresAt: time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC), CreatedBy: &createdBy, CreatedAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 2, Role: user.AdminRole, MaxUses: 1, Uses: 0, ExpiresAt: time.Date(2025, time.March, 9, 0, 0, 0, 0, time.UTC), CreatedAt: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+// MockRedemptionDAOs mocks a slice of RedemptionDAO of the first invite of MockOutstandingDAOs
+func MockRedemptionDAOs() []RedemptionDAO {
+	return []RedemptionDAO{
+		{InviteID: 1, UserID: 3, Username: "first", RedeemedAt: time.Date(2025, time.March, 3, 0, 0, 0, 0, time.UTC)},
+		{InviteID: 1, UserID: 4, Username: "second", RedeemedAt: time.Date(2025, time.March, 4, 0, 0, 0, 0, time.UTC)},
+	}
+}
@@ -0,0 +1,56 @@
+package invites
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// Redeem consumes a use of the invite of the given code, inserting the given user, whose password must be already
+// hashed, with the role of the invite, and recording the redemption. It is done in a single statement, so an invite
+// can't be redeemed more times than its maximum uses, and no use is consumed when the user can't be inserted
+type Redeem func(ctx context.Context, code string, user user.DTO) error
+
+// MakeRedeem creates a new Redeem
+func MakeRedeem(db database.Connection) Redeem {
+	const query string = `
+		WITH invite AS (
+			UPDATE invites
+			SET uses = uses + 1
+			WHERE code_hash = $1
+			  AND uses < max_uses
+			  AND expires_at > NOW()
+			RETURNING id, role
+		),
+		new_user AS (
+			INSERT INTO users(username, password_hash, role)
+			SELECT $2, $3, invite.role
+			FROM invite
+			RETURNING id
+		)
+		INSERT INTO invites_redemptions(invite_id, user_id)
+		SELECT invite.id, new_user.id
+		FROM invite, new_user
+		RETURNING invite_id;
+	`
+
+	return func(ctx context.Context, code string, user user.DTO) error {
+		var inviteID int
+		err := db.QueryRow(ctx, query, session.HashToken(code), user.Username, user.Password).Scan(&inviteID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return NoValidInviteFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToRedeemInvite
+		}
+
+		return nil
+	}
+}
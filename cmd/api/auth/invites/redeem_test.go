@@ -0,0 +1,58 @@
+package invites_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/invites"
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+)
+
+func TestRedeem_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{1}, t)
+	mockUserDTO := user.MockDTO()
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{session.HashToken("invite code"), mockUserDTO.Username, mockUserDTO.Password}).Return(mockPgxRow)
+
+	redeemInvite := invites.MakeRedeem(mockPostgresConnection)
+
+	got := redeemInvite(context.Background(), "invite code", mockUserDTO)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestRedeem_failsWhenRedeemOperationThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: invites.NoValidInviteFound},
+		{err: errors.New("failed to execute redeem operation"), expected: invites.FailedToRedeemInvite},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		redeemInvite := invites.MakeRedeem(mockPostgresConnection)
+
+		want := tt.expected
+		got := redeemInvite(context.Background(), "invite code", user.MockDTO())
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRow.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,71 @@
+package invites
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// SelectOutstanding retrieves the invites that can still be redeemed, the ones that haven't expired and have uses
+	// left, sorted by their expiration
+	SelectOutstanding func(ctx context.Context) ([]OutstandingDAO, error)
+
+	// SelectRedemptions retrieves the redemptions of the given invites, sorted by the time they were redeemed
+	SelectRedemptions func(ctx context.Context, inviteIDs []int) ([]RedemptionDAO, error)
+)
+
+// MakeSelectOutstanding creates a new SelectOutstanding
+func MakeSelectOutstanding(db database.Connection, collectRows database.CollectRows[OutstandingDAO]) SelectOutstanding {
+	const query string = `
+		SELECT id, role, max_uses, uses, expires_at, created_by, created_at
+		FROM invites
+		WHERE uses < max_uses
+		  AND expires_at > NOW()
+		ORDER BY expires_at, id;
+	`
+
+	return func(ctx context.Context) ([]OutstandingDAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectOutstanding
+		}
+
+		invites, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectOutstanding
+		}
+
+		return invites, nil
+	}
+}
+
+// MakeSelectRedemptions creates a new SelectRedemptions
+func MakeSelectRedemptions(db database.Connection, collectRows database.CollectRows[RedemptionDAO]) SelectRedemptions {
+	const query string = `
+		SELECT ir.invite_id, ir.user_id, u.username, ir.redeemed_at
+		FROM invites_redemptions AS ir
+		INNER JOIN users AS u ON u.id = ir.user_id
+		WHERE ir.invite_id = ANY($1)
+		ORDER BY ir.redeemed_at, ir.id;
+	`
+
+	return func(ctx context.Context, inviteIDs []int) ([]RedemptionDAO, error) {
+		rows, err := db.Query(ctx, query, inviteIDs)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectRedemptions
+		}
+
+		redemptions, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectRedemptions
+		}
+
+		return redemptions, nil
+	}
+}
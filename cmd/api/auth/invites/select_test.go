@@ -0,0 +1,107 @@
+package invites_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/invites"
+	"ahbcc/internal/database"
+)
+
+func TestSelectOutstanding_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockOutstandingDAOs := invites.MockOutstandingDAOs()
+	mockCollectRows := database.MockCollectRows[invites.OutstandingDAO](mockOutstandingDAOs, nil)
+
+	selectOutstanding := invites.MakeSelectOutstanding(mockPostgresConnection, mockCollectRows)
+
+	want := mockOutstandingDAOs
+	got, err := selectOutstanding(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectOutstanding_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[invites.OutstandingDAO](nil, nil)
+
+	selectOutstanding := invites.MakeSelectOutstanding(mockPostgresConnection, mockCollectRows)
+
+	want := invites.FailedToExecuteSelectOutstanding
+	_, got := selectOutstanding(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectOutstanding_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[invites.OutstandingDAO](nil, errors.New("failed to collect rows"))
+
+	selectOutstanding := invites.MakeSelectOutstanding(mockPostgresConnection, mockCollectRows)
+
+	want := invites.FailedToExecuteCollectRowsInSelectOutstanding
+	_, got := selectOutstanding(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectRedemptions_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, []any{[]int{1, 2}}).Return(mockPgxRows, nil)
+	mockRedemptionDAOs := invites.MockRedemptionDAOs()
+	mockCollectRows := database.MockCollectRows[invites.RedemptionDAO](mockRedemptionDAOs, nil)
+
+	selectRedemptions := invites.MakeSelectRedemptions(mockPostgresConnection, mockCollectRows)
+
+	want := mockRedemptionDAOs
+	got, err := selectRedemptions(context.Background(), []int{1, 2})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectRedemptions_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[invites.RedemptionDAO](nil, nil)
+
+	selectRedemptions := invites.MakeSelectRedemptions(mockPostgresConnection, mockCollectRows)
+
+	want := invites.FailedToExecuteSelectRedemptions
+	_, got := selectRedemptions(context.Background(), []int{1, 2})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectRedemptions_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[invites.RedemptionDAO](nil, errors.New("failed to collect rows"))
+
+	selectRedemptions := invites.MakeSelectRedemptions(mockPostgresConnection, mockCollectRows)
+
+	want := invites.FailedToExecuteCollectRowsInSelectRedemptions
+	_, got := selectRedemptions(context.Background(), []int{1, 2})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
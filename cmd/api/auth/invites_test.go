@@ -0,0 +1,171 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/invites"
+	"ahbcc/cmd/api/user"
+)
+
+func TestCreateInvite_success(t *testing.T) {
+	var (
+		createdRole      string
+		createdMaxUses   int
+		createdExpiresAt time.Time
+		createdBy        int
+	)
+	mockCreateInvite := func(ctx context.Context, role string, maxUses int, expiresAt time.Time, adminID int) (int, string, error) {
+		createdRole, createdMaxUses, createdExpiresAt, createdBy = role, maxUses, expiresAt, adminID
+		return 1, "invite code", nil
+	}
+
+	createInvite := auth.MakeCreateInvite(auth.MockAuthorize(2, nil), mockCreateInvite, 24*time.Hour)
+
+	before := time.Now()
+	got, err := createInvite(context.Background(), "token", auth.CreateInviteDTO{Role: user.AdminRole, MaxUses: 3, ExpiresInHours: 2})
+
+	assert.Nil(t, err)
+	assert.Equal(t, auth.InviteCodeDTO{ID: 1, Code: "invite code", Role: user.AdminRole, MaxUses: 3, ExpiresAt: createdExpiresAt}, got)
+	assert.Equal(t, user.AdminRole, createdRole)
+	assert.Equal(t, 3, createdMaxUses)
+	assert.Equal(t, 2, createdBy)
+	assert.WithinDuration(t, before.Add(2*time.Hour), createdExpiresAt, time.Minute)
+}
+
+func TestCreateInvite_successWithTheDefaults(t *testing.T) {
+	createInvite := auth.MakeCreateInvite(auth.MockAuthorize(2, nil), invites.MockCreate(1, "invite code", nil), 24*time.Hour)
+
+	before := time.Now()
+	got, err := createInvite(context.Background(), "token", auth.CreateInviteDTO{})
+
+	assert.Nil(t, err)
+	assert.Equal(t, user.AnnotatorRole, got.Role)
+	assert.Equal(t, 1, got.MaxUses)
+	assert.WithinDuration(t, before.Add(24*time.Hour), got.ExpiresAt, time.Minute)
+}
+
+func TestCreateInvite_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: auth.FailedToRetrieveUserID, expected: auth.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		createInvite := auth.MakeCreateInvite(auth.MockAuthorize(-1, tt.err), invites.MockCreate(1, "invite code", nil), 24*time.Hour)
+
+		want := tt.expected
+		_, got := createInvite(context.Background(), "token", auth.CreateInviteDTO{})
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCreateInvite_failsWhenTheBodyIsInvalid(t *testing.T) {
+	tests := []struct {
+		body     auth.CreateInviteDTO
+		expected error
+	}{
+		{body: auth.CreateInviteDTO{Role: "OWNER"}, expected: auth.InvalidInviteRole},
+		{body: auth.CreateInviteDTO{MaxUses: -1}, expected: auth.InvalidInviteMaxUses},
+		{body: auth.CreateInviteDTO{ExpiresInHours: -1}, expected: auth.InvalidInviteExpiration},
+	}
+
+	for _, tt := range tests {
+		createInvite := auth.MakeCreateInvite(auth.MockAuthorize(2, nil), invites.MockCreate(1, "invite code", nil), 24*time.Hour)
+
+		want := tt.expected
+		_, got := createInvite(context.Background(), "token", tt.body)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCreateInvite_failsWhenCreateThrowsError(t *testing.T) {
+	createInvite := auth.MakeCreateInvite(auth.MockAuthorize(2, nil), invites.MockCreate(0, "", invites.FailedToCreateInvite), 24*time.Hour)
+
+	want := auth.FailedToCreateUserInvite
+	_, got := createInvite(context.Background(), "token", auth.CreateInviteDTO{})
+
+	assert.Equal(t, want, got)
+}
+
+func TestListInvites_success(t *testing.T) {
+	mockSelectOutstanding := invites.MockSelectOutstanding(invites.MockOutstandingDAOs(), nil)
+	mockSelectRedemptions := invites.MockSelectRedemptions(invites.MockRedemptionDAOs(), nil)
+
+	listInvites := auth.MakeListInvites(auth.MockAuthorize(2, nil), mockSelectOutstanding, mockSelectRedemptions)
+
+	want := auth.MockInviteDTOs()
+	got, err := listInvites(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestListInvites_successWhenThereAreNoOutstandingInvites(t *testing.T) {
+	mockSelectOutstanding := invites.MockSelectOutstanding([]invites.OutstandingDAO{}, nil)
+	mockSelectRedemptions := invites.MockSelectRedemptions(nil, errors.New("the redemptions must not be selected"))
+
+	listInvites := auth.MakeListInvites(auth.MockAuthorize(2, nil), mockSelectOutstanding, mockSelectRedemptions)
+
+	want := []auth.InviteDTO{}
+	got, err := listInvites(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestListInvites_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: auth.FailedToRetrieveUserID, expected: auth.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockSelectOutstanding := invites.MockSelectOutstanding(invites.MockOutstandingDAOs(), nil)
+		mockSelectRedemptions := invites.MockSelectRedemptions(invites.MockRedemptionDAOs(), nil)
+
+		listInvites := auth.MakeListInvites(auth.MockAuthorize(-1, tt.err), mockSelectOutstanding, mockSelectRedemptions)
+
+		want := tt.expected
+		_, got := listInvites(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestListInvites_failsWhenSelectOutstandingThrowsError(t *testing.T) {
+	mockSelectOutstanding := invites.MockSelectOutstanding(nil, invites.FailedToExecuteSelectOutstanding)
+	mockSelectRedemptions := invites.MockSelectRedemptions(invites.MockRedemptionDAOs(), nil)
+
+	listInvites := auth.MakeListInvites(auth.MockAuthorize(2, nil), mockSelectOutstanding, mockSelectRedemptions)
+
+	want := auth.FailedToRetrieveInvites
+	_, got := listInvites(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestListInvites_failsWhenSelectRedemptionsThrowsError(t *testing.T) {
+	mockSelectOutstanding := invites.MockSelectOutstanding(invites.MockOutstandingDAOs(), nil)
+	mockSelectRedemptions := invites.MockSelectRedemptions(nil, invites.FailedToExecuteSelectRedemptions)
+
+	listInvites := auth.MakeListInvites(auth.MockAuthorize(2, nil), mockSelectOutstanding, mockSelectRedemptions)
+
+	want := auth.FailedToRetrieveInvites
+	_, got := listInvites(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,73 @@
+package auth
+
+import (
+	"context"
+	"sort"
+
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/log"
+)
+
+// SigningKeys returns the keys of the keyring, and the ones that are no longer in it, with the amount of sessions signed
+// with each of them that haven't expired yet. A retiring key can be removed from the keyring once it has no live
+// sessions
+type SigningKeys func(ctx context.Context, token string) ([]SigningKeyDTO, error)
+
+const (
+	SigningKeyActive   string = "ACTIVE"
+	SigningKeyRetiring string = "RETIRING"
+	SigningKeyRemoved  string = "REMOVED"
+)
+
+// MakeSigningKeys creates a new SigningKeys
+func MakeSigningKeys(authorize Authorize, keyring session.Keyring, selectKeysUsage session.SelectKeysUsage) SigningKeys {
+	return func(ctx context.Context, token string) ([]SigningKeyDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		keysUsage, err := selectKeysUsage(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveSigningKeysUsage
+		}
+
+		signingKeys := make(map[string]*SigningKeyDTO, len(keyring.Keys))
+		for keyID := range keyring.Keys {
+			status := SigningKeyRetiring
+			if keyID == keyring.ActiveKeyID {
+				status = SigningKeyActive
+			}
+			signingKeys[keyID] = &SigningKeyDTO{KeyID: keyID, Status: status}
+		}
+
+		// The sessions signed with a key that is no longer in the keyring can't be verified anymore
+		for _, keyUsage := range keysUsage {
+			signingKey, found := signingKeys[keyUsage.KeyID]
+			if !found {
+				signingKey = &SigningKeyDTO{KeyID: keyUsage.KeyID, Status: SigningKeyRemoved}
+				signingKeys[keyUsage.KeyID] = signingKey
+			}
+			lastExpiresAt := keyUsage.LastExpiresAt
+			signingKey.LiveSessions = keyUsage.LiveSessions
+			signingKey.LastExpiresAt = &lastExpiresAt
+		}
+
+		statusOrder := map[string]int{SigningKeyActive: 0, SigningKeyRetiring: 1, SigningKeyRemoved: 2}
+		response := make([]SigningKeyDTO, 0, len(signingKeys))
+		for _, signingKey := range signingKeys {
+			response = append(response, *signingKey)
+		}
+		sort.Slice(response, func(i, j int) bool {
+			if response[i].Status != response[j].Status {
+				return statusOrder[response[i].Status] < statusOrder[response[j].Status]
+			}
+			return response[i].KeyID < response[j].KeyID
+		})
+
+		return response, nil
+	}
+}
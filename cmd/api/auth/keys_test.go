@@ -0,0 +1,59 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user/session"
+)
+
+func TestSigningKeys_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectKeysUsage := session.MockSelectKeysUsage(session.MockKeyUsageDAOs(), nil)
+
+	signingKeys := auth.MakeSigningKeys(mockAuthorize, session.MockKeyring(), mockSelectKeysUsage)
+
+	want := auth.MockSigningKeyDTOs()
+	got, err := signingKeys(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestSigningKeys_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: auth.FailedToRetrieveUserID, expected: auth.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectKeysUsage := session.MockSelectKeysUsage(session.MockKeyUsageDAOs(), nil)
+
+		signingKeys := auth.MakeSigningKeys(mockAuthorize, session.MockKeyring(), mockSelectKeysUsage)
+
+		want := tt.expected
+		_, got := signingKeys(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSigningKeys_failsWhenSelectKeysUsageThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectKeysUsage := session.MockSelectKeysUsage(nil, errors.New("failed to select keys usage"))
+
+	signingKeys := auth.MakeSigningKeys(mockAuthorize, session.MockKeyring(), mockSelectKeysUsage)
+
+	want := auth.FailedToRetrieveSigningKeysUsage
+	_, got := signingKeys(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
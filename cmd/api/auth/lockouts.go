@@ -0,0 +1,36 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// UnlockAccount unlocks the account of the given username, locked due too many failed login attempts, before its lock
+// expires. Only the admins are allowed to do it
+type UnlockAccount func(ctx context.Context, token string, username string) error
+
+// MakeUnlockAccount creates a new UnlockAccount
+func MakeUnlockAccount(authorize Authorize, unlock attempts.Unlock) UnlockAccount {
+	return func(ctx context.Context, token string, username string) error {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		err = unlock(ctx, username)
+		if errors.Is(err, attempts.AccountNotLocked) {
+			log.Error(ctx, err.Error())
+			return AccountNotLocked
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUnlockUserAccount
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,66 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/attempts"
+)
+
+func TestUnlockAccount_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockUnlock := attempts.MockUnlock(nil)
+
+	unlockAccount := auth.MakeUnlockAccount(mockAuthorize, mockUnlock)
+
+	got := unlockAccount(context.Background(), "token", "username")
+
+	assert.Nil(t, got)
+}
+
+func TestUnlockAccount_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: auth.FailedToRetrieveUserID, expected: auth.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockUnlock := attempts.MockUnlock(nil)
+
+		unlockAccount := auth.MakeUnlockAccount(mockAuthorize, mockUnlock)
+
+		want := tt.expected
+		got := unlockAccount(context.Background(), "token", "username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestUnlockAccount_failsWhenUnlockThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: attempts.AccountNotLocked, expected: auth.AccountNotLocked},
+		{err: attempts.FailedToUnlockAccount, expected: auth.FailedToUnlockUserAccount},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockUnlock := attempts.MockUnlock(tt.err)
+
+		unlockAccount := auth.MakeUnlockAccount(mockAuthorize, mockUnlock)
+
+		want := tt.expected
+		got := unlockAccount(context.Background(), "token", "username")
+
+		assert.Equal(t, want, got)
+	}
+}
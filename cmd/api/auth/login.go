@@ -2,35 +2,70 @@ package auth
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"golang.org/x/crypto/bcrypt"
 
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/cmd/api/middleware"
 	"ahbcc/cmd/api/user"
 	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
 	"ahbcc/internal/log"
 )
 
-// LogIn logs the user in. It first verifies if the user exists in the database and then compares its password hash with
-// a hashed version of the password given throw parameter, if they match, the user is allowed to log in.
-// Lastly, it creates the user session and returns it with its expiration time.
-type LogIn func(ctx context.Context, user user.DTO) (string, time.Time, error)
+// LogIn logs the user in. It first verifies that the login attempts of the username, and of the IP it is made from,
+// are allowed, then that the user exists in the database and compares its password hash with a hashed version of the
+// password given throw parameter, if they match, the user is allowed to log in.
+// Lastly, it creates the user session and returns it with its expiration time, identifying the user as the actor of
+// the request in the audit log.
+// Every attempt is recorded. When it isn't allowed, TooManyLoginAttempts or AccountLocked is returned with the time from
+// which the login can be retried. The disabled users can't log in.
+// When the user has the two-factor authentication enabled, no session is created: SecondFactorRequired is returned
+// with a login challenge and its expiration time, which is completed with CompleteLogIn. The attempt isn't recorded as
+// succeeded until then
+type LogIn func(ctx context.Context, user user.DTO, ip string) (string, time.Time, error)
 
 // MakeLogIn creates a new LogIn
-func MakeLogIn(selectUserByUsername user.SelectByUsername, deleteExpiredUserSessions session.DeleteExpiredSessions, createSessionToken session.CreateToken) LogIn {
-	return func(ctx context.Context, user user.DTO) (string, time.Time, error) {
-		userDAO, err := selectUserByUsername(ctx, user.Username)
+func MakeLogIn(selectUserByUsername user.SelectByUsername, deleteExpiredUserSessions session.DeleteExpiredSessions, createSessionToken session.CreateToken, checkLoginAttempts attempts.Check, recordLoginAttempt attempts.Record, isTwoFactorEnabled totp.IsEnabled, createLoginChallenge totp.CreateChallenge) LogIn {
+	return func(ctx context.Context, userDTO user.DTO, ip string) (string, time.Time, error) {
+		retryAt, err := checkAttempts(ctx, checkLoginAttempts, recordLoginAttempt, userDTO.Username, ip)
+		if err != nil {
+			return "", retryAt, err
+		}
+
+		userDAO, err := selectUserByUsername(ctx, userDTO.Username)
 		if err != nil {
 			log.Error(ctx, err.Error())
+			if errors.Is(err, user.NoUserFoundForTheGivenUsername) {
+				recordAttempt(ctx, recordLoginAttempt, userDTO.Username, ip, attempts.OutcomeFailed)
+			}
 			return "", time.Time{}, FailedToSelectUserByUsername
 		}
 
-		err = bcrypt.CompareHashAndPassword([]byte(userDAO.PasswordHash), []byte(user.Password))
+		err = bcrypt.CompareHashAndPassword([]byte(userDAO.PasswordHash), []byte(userDTO.Password))
 		if err != nil {
 			log.Error(ctx, err.Error())
+			recordAttempt(ctx, recordLoginAttempt, userDTO.Username, ip, attempts.OutcomeFailed)
 			return "", time.Time{}, FailedToLoginDueWrongPassword
 		}
 
+		if userDAO.DisabledAt != nil {
+			log.Error(ctx, AccountDisabled.Error())
+			recordAttempt(ctx, recordLoginAttempt, userDTO.Username, ip, attempts.OutcomeFailed)
+			return "", time.Time{}, AccountDisabled
+		}
+
+		challenge, challengeExpiresAt, err := secondFactorChallenge(ctx, isTwoFactorEnabled, createLoginChallenge, userDAO.ID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, err
+		} else if challenge != "" {
+			// The attempt only succeeds once the second factor is verified, so its failures aren't reset yet
+			return challenge, challengeExpiresAt, SecondFactorRequired
+		}
+
 		err = deleteExpiredUserSessions(ctx, userDAO.ID)
 		if err != nil {
 			log.Warn(ctx, err.Error())
@@ -38,11 +73,45 @@ func MakeLogIn(selectUserByUsername user.SelectByUsername, deleteExpiredUserSess
 		}
 
 		token, expiresAt, err := createSessionToken(ctx, userDAO.ID)
-		if err != nil {
+		if errors.Is(err, session.UserDisabled) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, AccountDisabled
+		} else if err != nil {
 			log.Error(ctx, err.Error())
 			return "", time.Time{}, FailedToCreateUserSession
 		}
 
+		recordAttempt(ctx, recordLoginAttempt, userDTO.Username, ip, attempts.OutcomeSucceeded)
+		middleware.Identify(ctx, userDAO.ID)
+
 		return token, expiresAt, nil
 	}
 }
+
+// checkAttempts verifies that a login attempt of the given username, from the given IP, is allowed, recording it as
+// locked or throttled when it isn't. In that case, it returns the time from which the login can be retried
+func checkAttempts(ctx context.Context, checkLoginAttempts attempts.Check, recordLoginAttempt attempts.Record, username string, ip string) (time.Time, error) {
+	retryAt, err := checkLoginAttempts(ctx, username, ip)
+	if errors.Is(err, attempts.AccountLocked) {
+		log.Error(ctx, err.Error())
+		recordAttempt(ctx, recordLoginAttempt, username, ip, attempts.OutcomeLocked)
+		return retryAt, AccountLocked
+	} else if errors.Is(err, attempts.TooManyLoginAttempts) {
+		log.Error(ctx, err.Error())
+		recordAttempt(ctx, recordLoginAttempt, username, ip, attempts.OutcomeThrottled)
+		return retryAt, TooManyLoginAttempts
+	} else if err != nil {
+		log.Error(ctx, err.Error())
+		return time.Time{}, FailedToCheckLoginAttempts
+	}
+
+	return time.Time{}, nil
+}
+
+// recordAttempt records the login attempt with the given outcome. A failure to record it doesn't abort the login
+func recordAttempt(ctx context.Context, recordLoginAttempt attempts.Record, username string, ip string, outcome string) {
+	err := recordLoginAttempt(ctx, attempts.DAO{Username: username, IP: ip, Outcome: outcome, AttemptedAt: time.Now()})
+	if err != nil {
+		log.Warn(ctx, err.Error())
+	}
+}
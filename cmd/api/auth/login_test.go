@@ -9,8 +9,10 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/attempts"
 	"ahbcc/cmd/api/user"
 	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
 )
 
 func TestLogIn_success(t *testing.T) {
@@ -22,9 +24,14 @@ func TestLogIn_success(t *testing.T) {
 	mockCreateSessionToken := session.MockCreateToken(mockToken, mockExpiresAt, nil)
 	mockUserDTO := user.MockDTO()
 
-	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	mockRecordLoginAttempt := attempts.MockRecord(nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
 
-	token, expiresAt, err := logIn(context.Background(), mockUserDTO)
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+	token, expiresAt, err := logIn(context.Background(), mockUserDTO, "127.0.0.1")
 
 	assert.Nil(t, err)
 	assert.Equal(t, mockToken, token)
@@ -40,9 +47,14 @@ func TestLogIn_successEvenWhenDeleteExpiredSessionsThrowsError(t *testing.T) {
 	mockCreateSessionToken := session.MockCreateToken(mockToken, mockExpiresAt, nil)
 	mockUserDTO := user.MockDTO()
 
-	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	mockRecordLoginAttempt := attempts.MockRecord(nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
 
-	token, expiresAt, err := logIn(context.Background(), mockUserDTO)
+	token, expiresAt, err := logIn(context.Background(), mockUserDTO, "127.0.0.1")
 
 	assert.Nil(t, err)
 	assert.Equal(t, mockToken, token)
@@ -58,10 +70,15 @@ func TestLogIn_failsWhenSelectUserByUsernameThrowsError(t *testing.T) {
 	mockCreateSessionToken := session.MockCreateToken(mockToken, mockExpiresAt, nil)
 	mockUserDTO := user.MockDTO()
 
-	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	mockRecordLoginAttempt := attempts.MockRecord(nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
 
 	want := auth.FailedToSelectUserByUsername
-	_, _, got := logIn(context.Background(), mockUserDTO)
+	_, _, got := logIn(context.Background(), mockUserDTO, "127.0.0.1")
 
 	assert.Equal(t, want, got)
 }
@@ -75,10 +92,15 @@ func TestLogIn_failsWhenCompareHashAndPasswordThrowsError(t *testing.T) {
 	mockUserDTO := user.MockDTO()
 	mockUserDTO.Password = "wrong password"
 
-	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	mockRecordLoginAttempt := attempts.MockRecord(nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
 
 	want := auth.FailedToLoginDueWrongPassword
-	_, _, got := logIn(context.Background(), mockUserDTO)
+	_, _, got := logIn(context.Background(), mockUserDTO, "127.0.0.1")
 
 	assert.Equal(t, want, got)
 }
@@ -90,10 +112,195 @@ func TestLogIn_failsWhenCreateSessionTokenThrowsError(t *testing.T) {
 	mockCreateSessionToken := session.MockCreateToken("abcd", time.Time{}, errors.New("error while executing CreateSessionToken"))
 	mockUserDTO := user.MockDTO()
 
-	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	mockRecordLoginAttempt := attempts.MockRecord(nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
 
 	want := auth.FailedToCreateUserSession
-	_, _, got := logIn(context.Background(), mockUserDTO)
+	_, _, got := logIn(context.Background(), mockUserDTO, "127.0.0.1")
+
+	assert.Equal(t, want, got)
+}
+
+func TestLogIn_successRecordingTheOutcomeOfTheAttempt(t *testing.T) {
+	tests := []struct {
+		password           string
+		selectUserErr      error
+		expectedOutcome    string
+		expectedLoginError error
+	}{
+		{password: user.MockDTO().Password, expectedOutcome: attempts.OutcomeSucceeded},
+		{password: "wrong password", expectedOutcome: attempts.OutcomeFailed, expectedLoginError: auth.FailedToLoginDueWrongPassword},
+		{selectUserErr: user.NoUserFoundForTheGivenUsername, expectedOutcome: attempts.OutcomeFailed, expectedLoginError: auth.FailedToSelectUserByUsername},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), tt.selectUserErr)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateSessionToken := session.MockCreateToken("abcd", time.Now(), nil)
+		mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+		var recorded []attempts.DAO
+		mockRecordLoginAttempt := func(ctx context.Context, attempt attempts.DAO) error {
+			recorded = append(recorded, attempt)
+			return nil
+		}
+		mockUserDTO := user.MockDTO()
+		mockUserDTO.Password = tt.password
+		mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+		mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+		logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+		_, _, err := logIn(context.Background(), mockUserDTO, "127.0.0.1")
+
+		assert.Equal(t, tt.expectedLoginError, err)
+		assert.Len(t, recorded, 1)
+		assert.Equal(t, mockUserDTO.Username, recorded[0].Username)
+		assert.Equal(t, "127.0.0.1", recorded[0].IP)
+		assert.Equal(t, tt.expectedOutcome, recorded[0].Outcome)
+	}
+}
+
+func TestLogIn_successEvenWhenRecordLoginAttemptThrowsError(t *testing.T) {
+	mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+	mockCreateSessionToken := session.MockCreateToken("abcd", time.Now(), nil)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	mockRecordLoginAttempt := attempts.MockRecord(errors.New("failed to record login attempt"))
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+	token, _, err := logIn(context.Background(), user.MockDTO(), "127.0.0.1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "abcd", token)
+}
+
+func TestLogIn_failsWhenCheckLoginAttemptsThrowsError(t *testing.T) {
+	mockRetryAt := time.Now().Add(time.Minute)
+	tests := []struct {
+		err             error
+		expected        error
+		expectedRetryAt time.Time
+		expectedOutcome string
+	}{
+		{err: attempts.TooManyLoginAttempts, expected: auth.TooManyLoginAttempts, expectedRetryAt: mockRetryAt, expectedOutcome: attempts.OutcomeThrottled},
+		{err: attempts.AccountLocked, expected: auth.AccountLocked, expectedRetryAt: mockRetryAt, expectedOutcome: attempts.OutcomeLocked},
+		{err: attempts.FailedToCheckLoginAttempts, expected: auth.FailedToCheckLoginAttempts},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateSessionToken := session.MockCreateToken("abcd", time.Now(), nil)
+		mockCheckLoginAttempts := attempts.MockCheck(mockRetryAt, tt.err)
+		var recorded []attempts.DAO
+		mockRecordLoginAttempt := func(ctx context.Context, attempt attempts.DAO) error {
+			recorded = append(recorded, attempt)
+			return nil
+		}
+		mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+		mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+		logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+		_, retryAt, got := logIn(context.Background(), user.MockDTO(), "127.0.0.1")
+
+		assert.Equal(t, tt.expected, got)
+		assert.Equal(t, tt.expectedRetryAt, retryAt)
+		if tt.expectedOutcome != "" {
+			assert.Len(t, recorded, 1)
+			assert.Equal(t, tt.expectedOutcome, recorded[0].Outcome)
+		} else {
+			assert.Empty(t, recorded)
+		}
+	}
+}
+
+func TestLogIn_returnsALoginChallengeWhenTheUserHasTwoFactorEnabled(t *testing.T) {
+	mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+	mockCreateSessionToken := session.MockCreateToken("", time.Time{}, errors.New("should not be called"))
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	var recorded []attempts.DAO
+	mockRecordLoginAttempt := func(ctx context.Context, attempt attempts.DAO) error {
+		recorded = append(recorded, attempt)
+		return nil
+	}
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(true, nil)
+	mockChallengeExpiresAt := time.Date(2006, time.January, 1, 0, 5, 0, 0, time.Local)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("challenge", mockChallengeExpiresAt, nil)
+
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+	challenge, expiresAt, err := logIn(context.Background(), user.MockDTO(), "127.0.0.1")
+
+	assert.Equal(t, auth.SecondFactorRequired, err)
+	assert.Equal(t, "challenge", challenge)
+	assert.Equal(t, mockChallengeExpiresAt, expiresAt)
+	assert.Empty(t, recorded)
+}
+
+func TestLogIn_failsWhenTheSecondFactorChallengeCannotBeIssued(t *testing.T) {
+	tests := []struct {
+		isTwoFactorEnabled   totp.IsEnabled
+		createLoginChallenge totp.CreateChallenge
+		expected             error
+	}{
+		{
+			isTwoFactorEnabled:   totp.MockIsEnabled(false, errors.New("failed to check if two-factor is enabled")),
+			createLoginChallenge: totp.MockCreateChallenge("challenge", time.Now(), nil),
+			expected:             auth.FailedToCheckTwoFactor,
+		},
+		{
+			isTwoFactorEnabled:   totp.MockIsEnabled(true, nil),
+			createLoginChallenge: totp.MockCreateChallenge("", time.Time{}, errors.New("failed to create login challenge")),
+			expected:             auth.FailedToCreateLoginChallenge,
+		},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateSessionToken := session.MockCreateToken("abcd", time.Now(), nil)
+		mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+		mockRecordLoginAttempt := attempts.MockRecord(nil)
+
+		logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, tt.isTwoFactorEnabled, tt.createLoginChallenge)
+
+		_, _, got := logIn(context.Background(), user.MockDTO(), "127.0.0.1")
+
+		assert.Equal(t, tt.expected, got)
+	}
+}
+
+func TestLogIn_failsWhenTheUserIsDisabled(t *testing.T) {
+	mockUserDAO := user.MockDAO()
+	mockDisabledAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	mockUserDAO.DisabledAt = &mockDisabledAt
+	mockSelectUserByUsername := user.MockSelectByUsername(mockUserDAO, nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+	mockCreateSessionToken := session.MockCreateToken("abcd", time.Now(), nil)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	var recorded []attempts.DAO
+	mockRecordLoginAttempt := func(ctx context.Context, attempt attempts.DAO) error {
+		recorded = append(recorded, attempt)
+		return nil
+	}
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+	logIn := auth.MakeLogIn(mockSelectUserByUsername, mockDeleteExpiredSessions, mockCreateSessionToken, mockCheckLoginAttempts, mockRecordLoginAttempt, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+	want := auth.AccountDisabled
+	_, _, got := logIn(context.Background(), user.MockDTO(), "127.0.0.1")
 
 	assert.Equal(t, want, got)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, attempts.OutcomeFailed, recorded[0].Outcome)
 }
@@ -8,11 +8,12 @@ import (
 	"github.com/stretchr/testify/assert"
 	
 	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/middleware"
 	"ahbcc/cmd/api/user/session"
 )
 
 func TestLogOut_success(t *testing.T) {
-	mockDeleteUserSession := session.MockDelete(nil)
+	mockDeleteUserSession := session.MockDelete(1, nil)
 
 	logOut := auth.MakeLogOut(mockDeleteUserSession)
 
@@ -22,7 +23,7 @@ func TestLogOut_success(t *testing.T) {
 }
 
 func TestLogOut_failsWhenDeleteSessionThrowsError(t *testing.T) {
-	mockDeleteUserSession := session.MockDelete(errors.New("failed to delete session"))
+	mockDeleteUserSession := session.MockDelete(-1, errors.New("failed to delete session"))
 
 	logOut := auth.MakeLogOut(mockDeleteUserSession)
 
@@ -31,3 +32,18 @@ func TestLogOut_failsWhenDeleteSessionThrowsError(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestLogOut_successSettingTheUserOfTheSessionAsTheActor(t *testing.T) {
+	mockDeleteUserSession := session.MockDelete(7, nil)
+
+	logOut := auth.MakeLogOut(mockDeleteUserSession)
+
+	var got *middleware.Request
+	requestContext(func(ctx context.Context) {
+		err := logOut(ctx, "token")
+		assert.Nil(t, err)
+		got = middleware.RequestFrom(ctx)
+	})
+
+	assert.Equal(t, 7, *got.ActorID)
+}
@@ -4,19 +4,22 @@ import (
 	"context"
 	"time"
 
+	"ahbcc/cmd/api/auth/invites"
 	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
 )
 
 // MockSignUp mocks SignUp function
 func MockSignUp(err error) SignUp {
-	return func(ctx context.Context, user user.DTO) error {
+	return func(ctx context.Context, user user.DTO, inviteCode string) error {
 		return err
 	}
 }
 
 // MockLogIn mocks LogIn function
 func MockLogIn(token string, expiresAt time.Time, err error) LogIn {
-	return func(ctx context.Context, user user.DTO) (string, time.Time, error) {
+	return func(ctx context.Context, user user.DTO, ip string) (string, time.Time, error) {
 		return token, expiresAt, err
 	}
 }
@@ -27,3 +30,200 @@ func MockLogout(err error) LogOut {
 		return err
 	}
 }
+
+// MockAuthorize mocks Authorize function
+func MockAuthorize(userID int, err error) Authorize {
+	return func(ctx context.Context, token string, roles ...string) (int, error) {
+		return userID, err
+	}
+}
+
+// MockListSessions mocks ListSessions function
+func MockListSessions(sessions []SessionDTO, err error) ListSessions {
+	return func(ctx context.Context, token string) ([]SessionDTO, error) {
+		return sessions, err
+	}
+}
+
+// MockRevokeSession mocks RevokeSession function
+func MockRevokeSession(err error) RevokeSession {
+	return func(ctx context.Context, token string, sessionID int) error {
+		return err
+	}
+}
+
+// MockRevokeOtherSessions mocks RevokeOtherSessions function
+func MockRevokeOtherSessions(revoked int, err error) RevokeOtherSessions {
+	return func(ctx context.Context, token string) (int, error) {
+		return revoked, err
+	}
+}
+
+// MockRefreshSession mocks RefreshSession function
+func MockRefreshSession(token string, expiresAt time.Time, err error) RefreshSession {
+	return func(ctx context.Context, oldToken string) (string, time.Time, error) {
+		return token, expiresAt, err
+	}
+}
+
+// MockSigningKeys mocks a SigningKeys function
+func MockSigningKeys(signingKeys []SigningKeyDTO, err error) SigningKeys {
+	return func(ctx context.Context, token string) ([]SigningKeyDTO, error) {
+		return signingKeys, err
+	}
+}
+
+// MockChangePassword mocks a ChangePassword function
+func MockChangePassword(revoked int, err error) ChangePassword {
+	return func(ctx context.Context, token string, body ChangePasswordDTO) (int, error) {
+		return revoked, err
+	}
+}
+
+// MockIssuePasswordResetToken mocks an IssuePasswordResetToken function
+func MockIssuePasswordResetToken(resetToken PasswordResetTokenDTO, err error) IssuePasswordResetToken {
+	return func(ctx context.Context, token string, username string) (PasswordResetTokenDTO, error) {
+		return resetToken, err
+	}
+}
+
+// MockResetPassword mocks a ResetPassword function
+func MockResetPassword(err error) ResetPassword {
+	return func(ctx context.Context, body ResetPasswordDTO) error {
+		return err
+	}
+}
+
+// MockUnlockAccount mocks an UnlockAccount function
+func MockUnlockAccount(err error) UnlockAccount {
+	return func(ctx context.Context, token string, username string) error {
+		return err
+	}
+}
+
+// MockCreateInvite mocks a CreateInvite function
+func MockCreateInvite(invite InviteCodeDTO, err error) CreateInvite {
+	return func(ctx context.Context, token string, body CreateInviteDTO) (InviteCodeDTO, error) {
+		return invite, err
+	}
+}
+
+// MockListInvites mocks a ListInvites function
+func MockListInvites(invites []InviteDTO, err error) ListInvites {
+	return func(ctx context.Context, token string) ([]InviteDTO, error) {
+		return invites, err
+	}
+}
+
+// MockOIDCLogIn mocks an OIDCLogIn function
+func MockOIDCLogIn(token string, expiresAt time.Time, err error) OIDCLogIn {
+	return func(ctx context.Context, code string, state string) (string, time.Time, error) {
+		return token, expiresAt, err
+	}
+}
+
+// MockCompleteLogIn mocks a CompleteLogIn function
+func MockCompleteLogIn(token string, expiresAt time.Time, err error) CompleteLogIn {
+	return func(ctx context.Context, challenge string, code string, ip string) (string, time.Time, error) {
+		return token, expiresAt, err
+	}
+}
+
+// MockEnrollTwoFactor mocks an EnrollTwoFactor function
+func MockEnrollTwoFactor(enrollment totp.EnrollmentDTO, err error) EnrollTwoFactor {
+	return func(ctx context.Context, token string) (totp.EnrollmentDTO, error) {
+		return enrollment, err
+	}
+}
+
+// MockConfirmTwoFactor mocks a ConfirmTwoFactor function
+func MockConfirmTwoFactor(recoveryCodes []string, err error) ConfirmTwoFactor {
+	return func(ctx context.Context, token string, code string) ([]string, error) {
+		return recoveryCodes, err
+	}
+}
+
+// MockDisableTwoFactor mocks a DisableTwoFactor function
+func MockDisableTwoFactor(err error) DisableTwoFactor {
+	return func(ctx context.Context, token string, code string) error {
+		return err
+	}
+}
+
+// MockSessionDTOs mocks the []SessionDTO built from session.MockActiveDAOs for the token "token"
+func MockSessionDTOs() []SessionDTO {
+	return []SessionDTO{
+		{ID: 2, CreatedAt: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC), ExpiresAt: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC), Current: true},
+		{ID: 1, CreatedAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), ExpiresAt: time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+// MockSigningKeyDTOs mocks the []SigningKeyDTO built from session.MockKeyring and session.MockKeyUsageDAOs
+func MockSigningKeyDTOs() []SigningKeyDTO {
+	activeLastExpiresAt := time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)
+	legacyLastExpiresAt := time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)
+	removedLastExpiresAt := time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)
+
+	return []SigningKeyDTO{
+		{KeyID: "v2", Status: SigningKeyActive, LiveSessions: 5, LastExpiresAt: &activeLastExpiresAt},
+		{KeyID: session.LegacyKeyID, Status: SigningKeyRetiring, LiveSessions: 3, LastExpiresAt: &legacyLastExpiresAt},
+		{KeyID: "v1", Status: SigningKeyRetiring},
+		{KeyID: "v0", Status: SigningKeyRemoved, LiveSessions: 1, LastExpiresAt: &removedLastExpiresAt},
+	}
+}
+
+// MockChangePasswordDTO mocks a ChangePasswordDTO
+func MockChangePasswordDTO() ChangePasswordDTO {
+	return ChangePasswordDTO{
+		CurrentPassword: "password",
+		NewPassword:     "new password 1",
+	}
+}
+
+// MockResetPasswordDTO mocks a ResetPasswordDTO
+func MockResetPasswordDTO() ResetPasswordDTO {
+	return ResetPasswordDTO{
+		Token:       "reset token",
+		NewPassword: "new password 1",
+	}
+}
+
+// MockPasswordResetTokenDTO mocks a PasswordResetTokenDTO
+func MockPasswordResetTokenDTO() PasswordResetTokenDTO {
+	return PasswordResetTokenDTO{
+		Token:     "reset token",
+		ExpiresAt: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// MockInviteDTOs mocks the []InviteDTO built from invites.MockOutstandingDAOs and invites.MockRedemptionDAOs
+func MockInviteDTOs() []InviteDTO {
+	outstandingInvites := invites.MockOutstandingDAOs()
+	redemptions := invites.MockRedemptionDAOs()
+
+	return []InviteDTO{
+		{
+			ID:        outstandingInvites[0].ID,
+			Role:      outstandingInvites[0].Role,
+			MaxUses:   outstandingInvites[0].MaxUses,
+			Uses:      outstandingInvites[0].Uses,
+			ExpiresAt: outstandingInvites[0].ExpiresAt,
+			CreatedBy: outstandingInvites[0].CreatedBy,
+			CreatedAt: outstandingInvites[0].CreatedAt,
+			Redemptions: []RedemptionDTO{
+				{UserID: redemptions[0].UserID, Username: redemptions[0].Username, RedeemedAt: redemptions[0].RedeemedAt},
+				{UserID: redemptions[1].UserID, Username: redemptions[1].Username, RedeemedAt: redemptions[1].RedeemedAt},
+			},
+		},
+		{
+			ID:          outstandingInvites[1].ID,
+			Role:        outstandingInvites[1].Role,
+			MaxUses:     outstandingInvites[1].MaxUses,
+			Uses:        outstandingInvites[1].Uses,
+			ExpiresAt:   outstandingInvites[1].ExpiresAt,
+			CreatedBy:   outstandingInvites[1].CreatedBy,
+			CreatedAt:   outstandingInvites[1].CreatedAt,
+			Redemptions: []RedemptionDTO{},
+		},
+	}
+}
@@ -0,0 +1,94 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"ahbcc/internal/log"
+)
+
+// Authenticate finishes a login against the provider. It consumes the login state the provider redirected the user
+// back with, exchanges the authorization code for the ID token and verifies it, returning the identity of the user
+type Authenticate func(ctx context.Context, code string, state string) (IdentityDTO, error)
+
+const (
+	// defaultUsernameClaim is the claim used as username when none is configured
+	defaultUsernameClaim string = "preferred_username"
+
+	// emailClaim is the claim of the email of the user, which is only used as username when it is verified
+	emailClaim string = "email"
+)
+
+// MakeAuthenticate creates a new Authenticate
+func MakeAuthenticate(consumeState ConsumeState, exchange Exchange, verifyIDToken VerifyIDToken, config Config) Authenticate {
+	usernameClaim := config.UsernameClaim
+	if usernameClaim == "" {
+		usernameClaim = defaultUsernameClaim
+	}
+
+	return func(ctx context.Context, code string, state string) (IdentityDTO, error) {
+		loginState, err := consumeState(ctx, state)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return IdentityDTO{}, err
+		}
+
+		rawIDToken, err := exchange(ctx, code, loginState.CodeVerifier)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return IdentityDTO{}, err
+		}
+
+		claims, err := verifyIDToken(ctx, rawIDToken, loginState.Nonce)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return IdentityDTO{}, err
+		}
+
+		username, ok := claims.Extra[usernameClaim].(string)
+		if !ok || strings.TrimSpace(username) == "" {
+			log.Error(ctx, fmt.Sprintf("Username claim: %s", usernameClaim))
+			return IdentityDTO{}, MissingUsernameClaim
+		}
+
+		if usernameClaim == emailClaim && !isTrue(claims.Extra["email_verified"]) {
+			log.Error(ctx, EmailNotVerified.Error())
+			return IdentityDTO{}, EmailNotVerified
+		}
+
+		return IdentityDTO{Issuer: claims.Issuer, Subject: claims.Subject, Username: strings.TrimSpace(username)}, nil
+	}
+}
+
+// IsAuthenticationFailure reports whether the error is caused by the login itself, rather than by a failure of the
+// provider or the database
+func IsAuthenticationFailure(err error) bool {
+	return errors.Is(err, NoValidStateFound) ||
+		errors.Is(err, MalformedIDToken) ||
+		errors.Is(err, UnsupportedSigningAlgorithm) ||
+		errors.Is(err, UnknownSigningKey) ||
+		errors.Is(err, InvalidIDTokenSignature) ||
+		errors.Is(err, InvalidIDTokenIssuer) ||
+		errors.Is(err, InvalidIDTokenAudience) ||
+		errors.Is(err, ExpiredIDToken) ||
+		errors.Is(err, InvalidIDTokenNonce) ||
+		errors.Is(err, MissingIDTokenSubject) ||
+		errors.Is(err, MissingIDToken) ||
+		errors.Is(err, FailedToExchangeCode) ||
+		errors.Is(err, MissingUsernameClaim) ||
+		errors.Is(err, EmailNotVerified)
+}
+
+// isTrue reports whether the claim is true. Some providers send the boolean claims as strings
+func isTrue(claim any) bool {
+	switch value := claim.(type) {
+	case bool:
+		return value
+	case string:
+		return value == "true"
+	default:
+		return false
+	}
+}
@@ -0,0 +1,113 @@
+package oidc_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+)
+
+func TestAuthenticate_success(t *testing.T) {
+	mockClaims := oidc.Claims{Issuer: "https://idp.example.edu", Subject: "248289761001", Extra: map[string]any{"preferred_username": " jdoe "}}
+	mockConsumeState := oidc.MockConsumeState(oidc.MockStateDAO(), nil)
+	mockExchange := oidc.MockExchange("id token", nil)
+	mockVerifyIDToken := oidc.MockVerifyIDToken(mockClaims, nil)
+
+	authenticate := oidc.MakeAuthenticate(mockConsumeState, mockExchange, mockVerifyIDToken, oidc.MockConfig("https://idp.example.edu"))
+
+	want := oidc.MockIdentityDTO()
+	got, err := authenticate(context.Background(), "code", "state")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestAuthenticate_successWithTheEmailAsUsername(t *testing.T) {
+	tests := []struct {
+		emailVerified any
+		expected      error
+	}{
+		{emailVerified: true, expected: nil},
+		{emailVerified: "true", expected: nil},
+		{emailVerified: false, expected: oidc.EmailNotVerified},
+		{emailVerified: nil, expected: oidc.EmailNotVerified},
+	}
+
+	for _, tt := range tests {
+		mockClaims := oidc.Claims{Issuer: "https://idp.example.edu", Subject: "248289761001", Extra: map[string]any{"email": "jdoe@example.edu", "email_verified": tt.emailVerified}}
+		mockConfig := oidc.MockConfig("https://idp.example.edu")
+		mockConfig.UsernameClaim = "email"
+
+		authenticate := oidc.MakeAuthenticate(oidc.MockConsumeState(oidc.MockStateDAO(), nil), oidc.MockExchange("id token", nil), oidc.MockVerifyIDToken(mockClaims, nil), mockConfig)
+
+		want := tt.expected
+		_, got := authenticate(context.Background(), "code", "state")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestAuthenticate_failsWhenADependencyThrowsError(t *testing.T) {
+	tests := []struct {
+		consumeState  oidc.ConsumeState
+		exchange      oidc.Exchange
+		verifyIDToken oidc.VerifyIDToken
+		expected      error
+	}{
+		{consumeState: oidc.MockConsumeState(oidc.StateDAO{}, oidc.NoValidStateFound), exchange: oidc.MockExchange("id token", nil), verifyIDToken: oidc.MockVerifyIDToken(oidc.Claims{}, nil), expected: oidc.NoValidStateFound},
+		{consumeState: oidc.MockConsumeState(oidc.MockStateDAO(), nil), exchange: oidc.MockExchange("", oidc.FailedToExchangeCode), verifyIDToken: oidc.MockVerifyIDToken(oidc.Claims{}, nil), expected: oidc.FailedToExchangeCode},
+		{consumeState: oidc.MockConsumeState(oidc.MockStateDAO(), nil), exchange: oidc.MockExchange("id token", nil), verifyIDToken: oidc.MockVerifyIDToken(oidc.Claims{}, oidc.InvalidIDTokenNonce), expected: oidc.InvalidIDTokenNonce},
+		{consumeState: oidc.MockConsumeState(oidc.MockStateDAO(), nil), exchange: oidc.MockExchange("id token", nil), verifyIDToken: oidc.MockVerifyIDToken(oidc.Claims{Extra: map[string]any{"preferred_username": " "}}, nil), expected: oidc.MissingUsernameClaim},
+	}
+
+	for _, tt := range tests {
+		authenticate := oidc.MakeAuthenticate(tt.consumeState, tt.exchange, tt.verifyIDToken, oidc.MockConfig("https://idp.example.edu"))
+
+		want := tt.expected
+		_, got := authenticate(context.Background(), "code", "state")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestAuthenticate_successWithTheStubProvider(t *testing.T) {
+	idp := oidc.NewMockIdP(t, "ahbcc")
+	config := oidc.MockConfig(idp.Issuer())
+	states := map[string]oidc.StateDAO{}
+	insertState := func(ctx context.Context, state oidc.StateDAO) error {
+		states[state.State] = state
+		return nil
+	}
+	consumeState := func(ctx context.Context, state string) (oidc.StateDAO, error) {
+		loginState, ok := states[state]
+		if !ok {
+			return oidc.StateDAO{}, oidc.NoValidStateFound
+		}
+		delete(states, state)
+		return loginState, nil
+	}
+	discover := oidc.MakeDiscover(idp.Client(), idp.Issuer())
+	selectKey := oidc.MakeSelectKey(idp.Client(), discover, 0)
+	verifyIDToken := oidc.MakeVerifyIDToken(selectKey, idp.Issuer(), config.ClientID)
+	exchange := oidc.MakeExchange(idp.Client(), discover, config)
+	authorizationURL := oidc.MakeAuthorizationURL(discover, insertState, config)
+
+	authenticate := oidc.MakeAuthenticate(consumeState, exchange, verifyIDToken, config)
+
+	loginURL, err := authorizationURL(context.Background())
+	assert.Nil(t, err)
+	code, state := idp.Authorize(loginURL, "248289761001", "jdoe")
+
+	want := oidc.IdentityDTO{Issuer: idp.Issuer(), Subject: "248289761001", Username: "jdoe"}
+	got, err := authenticate(context.Background(), code, state)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+
+	// The same state and code can't be used again
+	_, err = authenticate(context.Background(), code, state)
+
+	assert.Equal(t, oidc.NoValidStateFound, err)
+}
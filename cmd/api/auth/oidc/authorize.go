@@ -0,0 +1,94 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/url"
+	"strings"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+// AuthorizationURL starts a login against the provider. It creates a random state, nonce and PKCE code verifier, stores
+// them until the provider redirects the user back, and returns the URL of the provider the user must be redirected to
+type AuthorizationURL func(ctx context.Context) (string, error)
+
+const (
+	// randomValueSize is the amount of random bytes of each state, nonce and code verifier
+	randomValueSize int = 32
+
+	// stateDuration is the time the user has to log in against the provider
+	stateDuration time.Duration = 10 * time.Minute
+)
+
+// MakeAuthorizationURL creates a new AuthorizationURL
+func MakeAuthorizationURL(discover Discover, insertState InsertState, config Config) AuthorizationURL {
+	return func(ctx context.Context) (string, error) {
+		metadata, err := discover(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToCreateAuthorizationURL
+		}
+
+		values := make([]string, 3)
+		for i := range values {
+			values[i], err = randomValue()
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return "", FailedToCreateAuthorizationURL
+			}
+		}
+
+		state := StateDAO{
+			State:        values[0],
+			Nonce:        values[1],
+			CodeVerifier: values[2],
+			ExpiresAt:    time.Now().Add(stateDuration),
+		}
+
+		err = insertState(ctx, state)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToCreateAuthorizationURL
+		}
+
+		authorizationURL, err := url.Parse(metadata.AuthorizationEndpoint)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToCreateAuthorizationURL
+		}
+
+		query := authorizationURL.Query()
+		query.Set("response_type", "code")
+		query.Set("client_id", config.ClientID)
+		query.Set("redirect_uri", config.RedirectURL)
+		query.Set("scope", strings.Join(config.Scopes, " "))
+		query.Set("state", state.State)
+		query.Set("nonce", state.Nonce)
+		query.Set("code_challenge", CodeChallenge(state.CodeVerifier))
+		query.Set("code_challenge_method", "S256")
+		authorizationURL.RawQuery = query.Encode()
+
+		return authorizationURL.String(), nil
+	}
+}
+
+// CodeChallenge returns the S256 PKCE code challenge of the given code verifier
+func CodeChallenge(codeVerifier string) string {
+	hash := sha256.Sum256([]byte(codeVerifier))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+// randomValue returns a base64url encoded random value, used as state, nonce and code verifier
+func randomValue() (string, error) {
+	random := make([]byte, randomValueSize)
+	_, err := rand.Read(random)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(random), nil
+}
@@ -0,0 +1,72 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+)
+
+func TestAuthorizationURL_success(t *testing.T) {
+	var insertedState oidc.StateDAO
+	mockDiscover := oidc.MockDiscover(oidc.ProviderMetadata{AuthorizationEndpoint: "https://idp.example.edu/authorize?tenant=university"}, nil)
+	mockInsertState := func(ctx context.Context, state oidc.StateDAO) error {
+		insertedState = state
+		return nil
+	}
+	mockConfig := oidc.MockConfig("https://idp.example.edu")
+
+	authorizationURL := oidc.MakeAuthorizationURL(mockDiscover, mockInsertState, mockConfig)
+
+	got, err := authorizationURL(context.Background())
+
+	assert.Nil(t, err)
+	parsed, err := url.Parse(got)
+	assert.Nil(t, err)
+	assert.Equal(t, "idp.example.edu", parsed.Host)
+	assert.Equal(t, "/authorize", parsed.Path)
+	query := parsed.Query()
+	assert.Equal(t, "university", query.Get("tenant"))
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, mockConfig.ClientID, query.Get("client_id"))
+	assert.Equal(t, mockConfig.RedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid profile email", query.Get("scope"))
+	assert.Equal(t, insertedState.State, query.Get("state"))
+	assert.Equal(t, insertedState.Nonce, query.Get("nonce"))
+	assert.Equal(t, oidc.CodeChallenge(insertedState.CodeVerifier), query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+	assert.Len(t, insertedState.CodeVerifier, 43)
+	assert.NotEqual(t, insertedState.State, insertedState.Nonce)
+}
+
+func TestAuthorizationURL_failsWhenADependencyThrowsError(t *testing.T) {
+	tests := []struct {
+		discover    oidc.Discover
+		insertState oidc.InsertState
+	}{
+		{discover: oidc.MockDiscover(oidc.ProviderMetadata{}, oidc.FailedToDiscoverProvider), insertState: oidc.MockInsertState(nil)},
+		{discover: oidc.MockDiscover(oidc.ProviderMetadata{AuthorizationEndpoint: "https://idp.example.edu/authorize"}, nil), insertState: oidc.MockInsertState(errors.New("failed to insert state"))},
+		{discover: oidc.MockDiscover(oidc.ProviderMetadata{AuthorizationEndpoint: "://invalid"}, nil), insertState: oidc.MockInsertState(nil)},
+	}
+
+	for _, tt := range tests {
+		authorizationURL := oidc.MakeAuthorizationURL(tt.discover, tt.insertState, oidc.MockConfig("https://idp.example.edu"))
+
+		want := oidc.FailedToCreateAuthorizationURL
+		_, got := authorizationURL(context.Background())
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCodeChallenge_success(t *testing.T) {
+	// Example of the RFC 7636, Appendix B
+	want := "E9Melhoa2OwvFrEMTJguCHaoeK1t8URWbuGJSstw-cM"
+	got := oidc.CodeChallenge("dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk")
+
+	assert.Equal(t, want, got)
+}
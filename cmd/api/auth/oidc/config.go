@@ -0,0 +1,41 @@
+package oidc
+
+import (
+	"slices"
+	"strings"
+)
+
+// Config contains the settings of the OpenID Connect provider and of this client registered in it.
+// The UsernameClaim is the claim of the ID token used as the username of the users. When AutoProvision is true, a user
+// is created the first time an unknown identity logs in. When LinkExistingUsers is true, an unknown identity whose
+// username matches a local user is linked to it, which must only be enabled when the provider controls the usernames
+type Config struct {
+	IssuerURL         string
+	ClientID          string
+	ClientSecret      string
+	RedirectURL       string
+	Scopes            []string
+	UsernameClaim     string
+	AutoProvision     bool
+	LinkExistingUsers bool
+}
+
+// openIDScope is the scope that makes an OAuth 2.0 authorization request an OpenID Connect one
+const openIDScope string = "openid"
+
+// ParseScopes returns the space or comma separated scopes, making sure that the openid scope is the first of them
+func ParseScopes(scopes string) []string {
+	parsed := []string{openIDScope}
+	for _, scope := range strings.FieldsFunc(scopes, func(r rune) bool { return r == ' ' || r == ',' }) {
+		if !slices.Contains(parsed, scope) {
+			parsed = append(parsed, scope)
+		}
+	}
+
+	return parsed
+}
+
+// Enabled reports whether the single sign-on is configured
+func (c Config) Enabled() bool {
+	return c.IssuerURL != "" && c.ClientID != "" && c.RedirectURL != ""
+}
@@ -0,0 +1,46 @@
+package oidc_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+)
+
+func TestParseScopes_success(t *testing.T) {
+	tests := []struct {
+		scopes   string
+		expected []string
+	}{
+		{scopes: "openid profile email", expected: []string{"openid", "profile", "email"}},
+		{scopes: "profile,email", expected: []string{"openid", "profile", "email"}},
+		{scopes: "email openid email", expected: []string{"openid", "email"}},
+		{scopes: "", expected: []string{"openid"}},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got := oidc.ParseScopes(tt.scopes)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestConfigEnabled_success(t *testing.T) {
+	tests := []struct {
+		config   oidc.Config
+		expected bool
+	}{
+		{config: oidc.MockConfig("https://idp.example.edu"), expected: true},
+		{config: oidc.MockConfig(""), expected: false},
+		{config: oidc.Config{IssuerURL: "https://idp.example.edu"}, expected: false},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got := tt.config.Enabled()
+
+		assert.Equal(t, want, got)
+	}
+}
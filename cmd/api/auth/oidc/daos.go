@@ -0,0 +1,21 @@
+package oidc
+
+import "time"
+
+type (
+	// StateDAO represents a login started against the provider that hasn't finished yet. The State is never stored,
+	// only its hash. The Nonce and the CodeVerifier are checked when the provider redirects back
+	StateDAO struct {
+		State        string    `json:"state"`
+		Nonce        string    `json:"nonce"`
+		CodeVerifier string    `json:"code_verifier"`
+		ExpiresAt    time.Time `json:"expires_at"`
+	}
+
+	// IdentityDAO represents the link between a user and its identity in a provider, the subject of the issuer
+	IdentityDAO struct {
+		UserID  int    `json:"user_id"`
+		Issuer  string `json:"issuer"`
+		Subject string `json:"subject"`
+	}
+)
@@ -0,0 +1,40 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// ConsumeState deletes the unexpired login state of the given state value and returns it, so each login state can
+// only be used once
+type ConsumeState func(ctx context.Context, state string) (StateDAO, error)
+
+// MakeConsumeState creates a new ConsumeState
+func MakeConsumeState(db database.Connection) ConsumeState {
+	const query string = `
+		DELETE FROM oidc_login_states
+		WHERE state_hash = $1
+		  AND expires_at > NOW()
+		RETURNING nonce, code_verifier, expires_at;
+	`
+
+	return func(ctx context.Context, state string) (StateDAO, error) {
+		consumed := StateDAO{State: state}
+		err := db.QueryRow(ctx, query, session.HashToken(state)).Scan(&consumed.Nonce, &consumed.CodeVerifier, &consumed.ExpiresAt)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return StateDAO{}, NoValidStateFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return StateDAO{}, FailedToConsumeState
+		}
+
+		return consumed, nil
+	}
+}
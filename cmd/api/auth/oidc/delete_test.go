@@ -0,0 +1,59 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+)
+
+func TestConsumeState_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockStateDAO := oidc.MockStateDAO()
+	database.MockScan(mockPgxRow, []any{mockStateDAO.Nonce, mockStateDAO.CodeVerifier, mockStateDAO.ExpiresAt}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{session.HashToken(mockStateDAO.State)}).Return(mockPgxRow)
+
+	consumeState := oidc.MakeConsumeState(mockPostgresConnection)
+
+	want := mockStateDAO
+	got, err := consumeState(context.Background(), mockStateDAO.State)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestConsumeState_failsWhenDeleteOperationThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: oidc.NoValidStateFound},
+		{err: errors.New("failed to execute delete operation"), expected: oidc.FailedToConsumeState},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		consumeState := oidc.MakeConsumeState(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := consumeState(context.Background(), "state")
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRow.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,100 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"ahbcc/internal/log"
+)
+
+// Discover retrieves the metadata of the provider from its discovery document. Once it is retrieved successfully, it
+// is kept in memory and the provider isn't requested again
+type Discover func(ctx context.Context) (ProviderMetadata, error)
+
+// discoveryPath is the path, relative to the issuer, of the discovery document
+const discoveryPath string = "/.well-known/openid-configuration"
+
+// maxResponseSize is the maximum amount of bytes read from any response of the provider
+const maxResponseSize int64 = 1 << 20
+
+// MakeDiscover creates a new Discover
+func MakeDiscover(httpClient *http.Client, issuerURL string) Discover {
+	var mu sync.Mutex
+	var metadata *ProviderMetadata
+
+	return func(ctx context.Context) (ProviderMetadata, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if metadata != nil {
+			return *metadata, nil
+		}
+
+		var discovered ProviderMetadata
+		err := getJSON(ctx, httpClient, strings.TrimSuffix(issuerURL, "/")+discoveryPath, &discovered)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ProviderMetadata{}, FailedToDiscoverProvider
+		}
+
+		if discovered.Issuer != issuerURL {
+			log.Error(ctx, fmt.Sprintf("Discovered issuer: %s, configured issuer: %s", discovered.Issuer, issuerURL))
+			return ProviderMetadata{}, IssuerMismatch
+		}
+
+		if discovered.AuthorizationEndpoint == "" || discovered.TokenEndpoint == "" || discovered.JWKSURI == "" {
+			log.Error(ctx, "The discovery document is missing the authorization, token or JWKS endpoint")
+			return ProviderMetadata{}, FailedToDiscoverProvider
+		}
+
+		metadata = &discovered
+
+		return discovered, nil
+	}
+}
+
+// getJSON requests the given URL and decodes its JSON response into the given value
+func getJSON(ctx context.Context, httpClient *http.Client, url string, value any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return FailedToCreateRequest
+	}
+	req.Header.Set("Accept", "application/json")
+
+	return doJSON(ctx, httpClient, req, value)
+}
+
+// doJSON executes the given request and decodes its JSON response into the given value. Only a 200 OK response is
+// accepted
+func doJSON(ctx context.Context, httpClient *http.Client, req *http.Request, value any) error {
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return FailedToExecuteRequest
+	}
+	defer func(body io.ReadCloser) {
+		err = body.Close()
+		if err != nil {
+			log.Error(ctx, err.Error())
+		}
+	}(resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		log.Error(ctx, fmt.Sprintf("Provider request responded with status: %s", resp.Status))
+		return UnexpectedResponseStatus
+	}
+
+	err = json.NewDecoder(io.LimitReader(resp.Body, maxResponseSize)).Decode(value)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return FailedToReadResponse
+	}
+
+	return nil
+}
@@ -0,0 +1,87 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+)
+
+func TestDiscover_success(t *testing.T) {
+	idp := oidc.NewMockIdP(t, "ahbcc")
+
+	discover := oidc.MakeDiscover(idp.Client(), idp.Issuer())
+
+	want := oidc.ProviderMetadata{
+		Issuer:                idp.Issuer(),
+		AuthorizationEndpoint: idp.Issuer() + "/authorize",
+		TokenEndpoint:         idp.Issuer() + "/token",
+		JWKSURI:               idp.Issuer() + "/jwks",
+	}
+	got, err := discover(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestDiscover_successRequestingTheProviderOnlyOnce(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"issuer":"` + "http://" + r.Host + `","authorization_endpoint":"a","token_endpoint":"t","jwks_uri":"j"}`))
+	}))
+	defer server.Close()
+
+	discover := oidc.MakeDiscover(server.Client(), server.URL)
+
+	_, _ = discover(context.Background())
+	_, err := discover(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestDiscover_failsWhenTheProviderRespondsWithAnInvalidDocument(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		expected error
+	}{
+		{status: http.StatusInternalServerError, body: `{}`, expected: oidc.FailedToDiscoverProvider},
+		{status: http.StatusOK, body: `not json`, expected: oidc.FailedToDiscoverProvider},
+		{status: http.StatusOK, body: `{"issuer":"https://another.example.edu","authorization_endpoint":"a","token_endpoint":"t","jwks_uri":"j"}`, expected: oidc.IssuerMismatch},
+		{status: http.StatusOK, body: `{"issuer":"ISSUER","authorization_endpoint":"a"}`, expected: oidc.FailedToDiscoverProvider},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			_, _ = w.Write([]byte(strings.ReplaceAll(tt.body, "ISSUER", "http://"+r.Host)))
+		}))
+
+		discover := oidc.MakeDiscover(server.Client(), server.URL)
+
+		want := tt.expected
+		_, got := discover(context.Background())
+
+		assert.Equal(t, want, got)
+		server.Close()
+	}
+}
+
+func TestDiscover_failsWhenTheProviderIsUnreachable(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	discover := oidc.MakeDiscover(server.Client(), server.URL)
+
+	want := oidc.FailedToDiscoverProvider
+	_, got := discover(context.Background())
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,87 @@
+package oidc
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type (
+	// ProviderMetadata represents the endpoints of the provider, published in its discovery document
+	ProviderMetadata struct {
+		Issuer                string `json:"issuer"`
+		AuthorizationEndpoint string `json:"authorization_endpoint"`
+		TokenEndpoint         string `json:"token_endpoint"`
+		JWKSURI               string `json:"jwks_uri"`
+	}
+
+	// IdentityDTO represents the user authenticated by the provider
+	IdentityDTO struct {
+		Issuer   string `json:"issuer"`
+		Subject  string `json:"subject"`
+		Username string `json:"username"`
+	}
+
+	// Claims represents the verified claims of an ID token. Extra contains all of them, including the standard ones
+	Claims struct {
+		Issuer   string         `json:"iss"`
+		Subject  string         `json:"sub"`
+		Audience audience       `json:"aud"`
+		AZP      string         `json:"azp"`
+		Expiry   int64          `json:"exp"`
+		Nonce    string         `json:"nonce"`
+		Extra    map[string]any `json:"-"`
+	}
+
+	// audience is the aud claim, which can be a single string or an array of them
+	audience []string
+
+	// tokenResponse represents the response of the token endpoint
+	tokenResponse struct {
+		IDToken string `json:"id_token"`
+	}
+
+	// jwks represents the JSON Web Key Set published by the provider
+	jwks struct {
+		Keys []jwk `json:"keys"`
+	}
+
+	// jwk represents a JSON Web Key. Only the RSA and EC keys are supported
+	jwk struct {
+		Kty string `json:"kty"`
+		Kid string `json:"kid"`
+		Use string `json:"use"`
+		N   string `json:"n"`
+		E   string `json:"e"`
+		Crv string `json:"crv"`
+		X   string `json:"x"`
+		Y   string `json:"y"`
+	}
+
+	// jwtHeader represents the header of a JSON Web Token
+	jwtHeader struct {
+		Alg string `json:"alg"`
+		Kid string `json:"kid"`
+	}
+)
+
+// UnmarshalJSON parses the aud claim either from a string or from an array of strings
+func (a *audience) UnmarshalJSON(data []byte) error {
+	var single string
+	if err := json.Unmarshal(data, &single); err == nil {
+		*a = audience{single}
+		return nil
+	}
+
+	var multiple []string
+	if err := json.Unmarshal(data, &multiple); err != nil {
+		return err
+	}
+	*a = multiple
+
+	return nil
+}
+
+// ExpiresAt returns the expiration of the ID token
+func (c Claims) ExpiresAt() time.Time {
+	return time.Unix(c.Expiry, 0)
+}
@@ -0,0 +1,38 @@
+package oidc
+
+import "errors"
+
+var (
+	FailedToCreateRequest                 = errors.New("failed to create request to the provider")
+	FailedToExecuteRequest                = errors.New("failed to execute request to the provider")
+	UnexpectedResponseStatus              = errors.New("the provider responded with an unexpected status")
+	FailedToReadResponse                  = errors.New("failed to read the response of the provider")
+	FailedToDiscoverProvider              = errors.New("failed to discover the provider metadata")
+	IssuerMismatch                        = errors.New("the issuer of the discovery document doesn't match the configured one")
+	FailedToRetrieveSigningKeys           = errors.New("failed to retrieve the signing keys of the provider")
+	UnknownSigningKey                     = errors.New("the ID token was signed with an unknown key")
+	UnsupportedSigningKey                 = errors.New("unsupported signing key")
+	MalformedIDToken                      = errors.New("malformed ID token")
+	UnsupportedSigningAlgorithm           = errors.New("unsupported ID token signing algorithm")
+	InvalidIDTokenSignature               = errors.New("invalid ID token signature")
+	InvalidIDTokenIssuer                  = errors.New("the ID token was issued by another issuer")
+	InvalidIDTokenAudience                = errors.New("the ID token was issued for another client")
+	ExpiredIDToken                        = errors.New("expired ID token")
+	InvalidIDTokenNonce                   = errors.New("the nonce of the ID token doesn't match the one of the login")
+	MissingIDTokenSubject                 = errors.New("the ID token has no subject")
+	FailedToExchangeCode                  = errors.New("failed to exchange the authorization code")
+	MissingIDToken                        = errors.New("the token response has no ID token")
+	FailedToCreateAuthorizationURL        = errors.New("failed to create the authorization url")
+	FailedToInsertState                   = errors.New("failed to insert login state")
+	NoValidStateFound                     = errors.New("no valid login state found, it may be expired or already used")
+	FailedToConsumeState                  = errors.New("failed to consume login state")
+	MissingUsernameClaim                  = errors.New("the ID token has no username claim")
+	EmailNotVerified                      = errors.New("the email of the ID token is not verified")
+	NoUserLinkedToIdentity                = errors.New("no user linked to the identity")
+	FailedToExecuteSelectUserIDByIdentity = errors.New("failed to execute select user id by identity")
+	FailedToInsertIdentity                = errors.New("failed to insert identity")
+	FailedToProvisionUser                 = errors.New("failed to provision user")
+	UserNotProvisioned                    = errors.New("no user found for the identity and the automatic provisioning is disabled")
+	UsernameTaken                         = errors.New("the username of the identity belongs to a local user that isn't linked to it")
+	FailedToResolveUser                   = errors.New("failed to resolve the user of the identity")
+)
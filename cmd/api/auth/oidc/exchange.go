@@ -0,0 +1,58 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"ahbcc/internal/log"
+)
+
+// Exchange exchanges the given authorization code, together with the PKCE code verifier of the login, for the tokens
+// of the provider, returning the ID token
+type Exchange func(ctx context.Context, code string, codeVerifier string) (string, error)
+
+// MakeExchange creates a new Exchange. When the client has a secret, it authenticates against the token endpoint
+// with HTTP basic authentication
+func MakeExchange(httpClient *http.Client, discover Discover, config Config) Exchange {
+	return func(ctx context.Context, code string, codeVerifier string) (string, error) {
+		metadata, err := discover(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToExchangeCode
+		}
+
+		form := url.Values{}
+		form.Set("grant_type", "authorization_code")
+		form.Set("code", code)
+		form.Set("redirect_uri", config.RedirectURL)
+		form.Set("client_id", config.ClientID)
+		form.Set("code_verifier", codeVerifier)
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, metadata.TokenEndpoint, strings.NewReader(form.Encode()))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToExchangeCode
+		}
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("Accept", "application/json")
+		if config.ClientSecret != "" {
+			req.SetBasicAuth(url.QueryEscape(config.ClientID), url.QueryEscape(config.ClientSecret))
+		}
+
+		var tokens tokenResponse
+		err = doJSON(ctx, httpClient, req, &tokens)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToExchangeCode
+		}
+
+		if tokens.IDToken == "" {
+			log.Error(ctx, MissingIDToken.Error())
+			return "", MissingIDToken
+		}
+
+		return tokens.IDToken, nil
+	}
+}
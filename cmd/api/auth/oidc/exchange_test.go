@@ -0,0 +1,80 @@
+package oidc_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+)
+
+func TestExchange_success(t *testing.T) {
+	var form map[string]string
+	var clientID, clientSecret string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		form = map[string]string{}
+		for key := range r.PostForm {
+			form[key] = r.PostForm.Get(key)
+		}
+		clientID, clientSecret, _ = r.BasicAuth()
+		_, _ = w.Write([]byte(`{"access_token":"access token","id_token":"id token"}`))
+	}))
+	defer server.Close()
+	mockConfig := oidc.MockConfig("https://idp.example.edu")
+
+	exchange := oidc.MakeExchange(server.Client(), oidc.MockDiscover(oidc.ProviderMetadata{TokenEndpoint: server.URL}, nil), mockConfig)
+
+	got, err := exchange(context.Background(), "code", "code verifier")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "id token", got)
+	assert.Equal(t, map[string]string{
+		"grant_type":    "authorization_code",
+		"code":          "code",
+		"redirect_uri":  mockConfig.RedirectURL,
+		"client_id":     mockConfig.ClientID,
+		"code_verifier": "code verifier",
+	}, form)
+	assert.Equal(t, mockConfig.ClientID, clientID)
+	assert.Equal(t, mockConfig.ClientSecret, clientSecret)
+}
+
+func TestExchange_failsWhenTheTokenEndpointFails(t *testing.T) {
+	tests := []struct {
+		status   int
+		body     string
+		expected error
+	}{
+		{status: http.StatusBadRequest, body: `{"error":"invalid_grant"}`, expected: oidc.FailedToExchangeCode},
+		{status: http.StatusOK, body: `not json`, expected: oidc.FailedToExchangeCode},
+		{status: http.StatusOK, body: `{"access_token":"access token"}`, expected: oidc.MissingIDToken},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(tt.status)
+			_, _ = w.Write([]byte(tt.body))
+		}))
+
+		exchange := oidc.MakeExchange(server.Client(), oidc.MockDiscover(oidc.ProviderMetadata{TokenEndpoint: server.URL}, nil), oidc.MockConfig("https://idp.example.edu"))
+
+		want := tt.expected
+		_, got := exchange(context.Background(), "code", "code verifier")
+
+		assert.Equal(t, want, got)
+		server.Close()
+	}
+}
+
+func TestExchange_failsWhenDiscoverThrowsError(t *testing.T) {
+	exchange := oidc.MakeExchange(http.DefaultClient, oidc.MockDiscover(oidc.ProviderMetadata{}, oidc.FailedToDiscoverProvider), oidc.MockConfig("https://idp.example.edu"))
+
+	want := oidc.FailedToExchangeCode
+	_, got := exchange(context.Background(), "code", "code verifier")
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,181 @@
+package oidc
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"slices"
+	"strings"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+// VerifyIDToken verifies the signature of the given ID token with the keys of the provider and its issuer, audience,
+// expiration and nonce, returning its claims. Only the RS256, RS384, RS512, ES256, ES384 and ES512 algorithms are
+// accepted, so unsigned tokens are always rejected
+type VerifyIDToken func(ctx context.Context, rawIDToken string, nonce string) (Claims, error)
+
+// clockSkew is the leeway given to the expiration of the ID tokens, for the clock differences with the provider
+const clockSkew time.Duration = time.Minute
+
+// signingAlgorithms maps each accepted signing algorithm to its hash function
+var signingAlgorithms = map[string]crypto.Hash{
+	"RS256": crypto.SHA256,
+	"RS384": crypto.SHA384,
+	"RS512": crypto.SHA512,
+	"ES256": crypto.SHA256,
+	"ES384": crypto.SHA384,
+	"ES512": crypto.SHA512,
+}
+
+// MakeVerifyIDToken creates a new VerifyIDToken
+func MakeVerifyIDToken(selectKey SelectKey, issuer string, clientID string) VerifyIDToken {
+	return func(ctx context.Context, rawIDToken string, nonce string) (Claims, error) {
+		parts := strings.Split(rawIDToken, ".")
+		if len(parts) != 3 {
+			log.Error(ctx, "The ID token doesn't have three parts")
+			return Claims{}, MalformedIDToken
+		}
+
+		var header jwtHeader
+		err := decodeSegment(parts[0], &header)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return Claims{}, MalformedIDToken
+		}
+
+		hash, ok := signingAlgorithms[header.Alg]
+		if !ok {
+			log.Error(ctx, fmt.Sprintf("ID token signing algorithm: %s", header.Alg))
+			return Claims{}, UnsupportedSigningAlgorithm
+		}
+
+		signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return Claims{}, MalformedIDToken
+		}
+
+		key, err := selectKey(ctx, header.Kid)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return Claims{}, err
+		}
+
+		if !verifySignature(key, header.Alg, hash, []byte(parts[0]+"."+parts[1]), signature) {
+			log.Error(ctx, InvalidIDTokenSignature.Error())
+			return Claims{}, InvalidIDTokenSignature
+		}
+
+		var claims Claims
+		err = decodeSegment(parts[1], &claims)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return Claims{}, MalformedIDToken
+		}
+
+		err = decodeSegment(parts[1], &claims.Extra)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return Claims{}, MalformedIDToken
+		}
+
+		if claims.Issuer != issuer {
+			log.Error(ctx, fmt.Sprintf("ID token issuer: %s", claims.Issuer))
+			return Claims{}, InvalidIDTokenIssuer
+		}
+
+		if !slices.Contains(claims.Audience, clientID) || (len(claims.Audience) > 1 && claims.AZP != clientID) {
+			log.Error(ctx, fmt.Sprintf("ID token audience: %v, authorized party: %s", claims.Audience, claims.AZP))
+			return Claims{}, InvalidIDTokenAudience
+		}
+
+		if claims.Expiry == 0 || time.Now().After(claims.ExpiresAt().Add(clockSkew)) {
+			log.Error(ctx, fmt.Sprintf("ID token expiration: %s", claims.ExpiresAt()))
+			return Claims{}, ExpiredIDToken
+		}
+
+		if claims.Nonce == "" || claims.Nonce != nonce {
+			log.Error(ctx, InvalidIDTokenNonce.Error())
+			return Claims{}, InvalidIDTokenNonce
+		}
+
+		if claims.Subject == "" {
+			log.Error(ctx, MissingIDTokenSubject.Error())
+			return Claims{}, MissingIDTokenSubject
+		}
+
+		return claims, nil
+	}
+}
+
+// decodeSegment decodes a base64url encoded JSON segment of a JSON Web Token into the given value
+func decodeSegment(segment string, value any) error {
+	data, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	return decoder.Decode(value)
+}
+
+// verifySignature verifies the signature of the given signing input with the given key, whose type must match the one
+// of the algorithm
+func verifySignature(key crypto.PublicKey, alg string, hash crypto.Hash, signingInput []byte, signature []byte) bool {
+	digest := digestOf(hash, signingInput)
+
+	switch pub := key.(type) {
+	case *rsa.PublicKey:
+		if !strings.HasPrefix(alg, "RS") {
+			return false
+		}
+
+		return rsa.VerifyPKCS1v15(pub, hash, digest, signature) == nil
+	case *ecdsa.PublicKey:
+		size := (pub.Curve.Params().BitSize + 7) / 8
+		if !strings.HasPrefix(alg, "ES") || pub.Curve.Params().BitSize != ecdsaBitSizes[alg] || len(signature) != 2*size {
+			return false
+		}
+
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+
+		return ecdsa.Verify(pub, digest, r, s)
+	default:
+		return false
+	}
+}
+
+// ecdsaBitSizes maps each ECDSA signing algorithm to the bit size of its curve
+var ecdsaBitSizes = map[string]int{
+	"ES256": 256,
+	"ES384": 384,
+	"ES512": 521,
+}
+
+// digestOf returns the digest of the data with the given hash function
+func digestOf(hash crypto.Hash, data []byte) []byte {
+	switch hash {
+	case crypto.SHA384:
+		digest := sha512.Sum384(data)
+		return digest[:]
+	case crypto.SHA512:
+		digest := sha512.Sum512(data)
+		return digest[:]
+	default:
+		digest := sha256.Sum256(data)
+		return digest[:]
+	}
+}
@@ -0,0 +1,187 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+)
+
+func TestVerifyIDToken_success(t *testing.T) {
+	idp := oidc.NewMockIdP(t, "ahbcc")
+	rawIDToken := idp.SignIDToken(idp.Claims("248289761001", "jdoe", "nonce"))
+
+	verifyIDToken := oidc.MakeVerifyIDToken(oidc.MockSelectKey(idp.PublicKey(), nil), idp.Issuer(), "ahbcc")
+
+	got, err := verifyIDToken(context.Background(), rawIDToken, "nonce")
+
+	assert.Nil(t, err)
+	assert.Equal(t, idp.Issuer(), got.Issuer)
+	assert.Equal(t, "248289761001", got.Subject)
+	assert.Equal(t, "jdoe", got.Extra["preferred_username"])
+}
+
+func TestVerifyIDToken_successWithSeveralAudiences(t *testing.T) {
+	idp := oidc.NewMockIdP(t, "ahbcc")
+	claims := idp.Claims("248289761001", "jdoe", "nonce")
+	claims["aud"] = []string{"ahbcc", "another client"}
+	claims["azp"] = "ahbcc"
+	rawIDToken := idp.SignIDToken(claims)
+
+	verifyIDToken := oidc.MakeVerifyIDToken(oidc.MockSelectKey(idp.PublicKey(), nil), idp.Issuer(), "ahbcc")
+
+	_, err := verifyIDToken(context.Background(), rawIDToken, "nonce")
+
+	assert.Nil(t, err)
+}
+
+func TestVerifyIDToken_successWithECKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := oidc.NewMockIdP(t, "ahbcc")
+	rawIDToken := signES256(t, key, idp.Claims("248289761001", "jdoe", "nonce"))
+
+	verifyIDToken := oidc.MakeVerifyIDToken(oidc.MockSelectKey(&key.PublicKey, nil), idp.Issuer(), "ahbcc")
+
+	_, err = verifyIDToken(context.Background(), rawIDToken, "nonce")
+
+	assert.Nil(t, err)
+}
+
+func TestVerifyIDToken_failsWhenTheClaimsAreInvalid(t *testing.T) {
+	idp := oidc.NewMockIdP(t, "ahbcc")
+
+	tests := []struct {
+		claim    string
+		value    any
+		expected error
+	}{
+		{claim: "iss", value: "https://another.example.edu", expected: oidc.InvalidIDTokenIssuer},
+		{claim: "aud", value: "another client", expected: oidc.InvalidIDTokenAudience},
+		{claim: "aud", value: []string{"ahbcc", "another client"}, expected: oidc.InvalidIDTokenAudience},
+		{claim: "exp", value: time.Now().Add(-2 * time.Minute).Unix(), expected: oidc.ExpiredIDToken},
+		{claim: "exp", value: nil, expected: oidc.ExpiredIDToken},
+		{claim: "nonce", value: "another nonce", expected: oidc.InvalidIDTokenNonce},
+		{claim: "nonce", value: nil, expected: oidc.InvalidIDTokenNonce},
+		{claim: "sub", value: nil, expected: oidc.MissingIDTokenSubject},
+	}
+
+	for _, tt := range tests {
+		claims := idp.Claims("248289761001", "jdoe", "nonce")
+		if tt.value == nil {
+			delete(claims, tt.claim)
+		} else {
+			claims[tt.claim] = tt.value
+		}
+		rawIDToken := idp.SignIDToken(claims)
+
+		verifyIDToken := oidc.MakeVerifyIDToken(oidc.MockSelectKey(idp.PublicKey(), nil), idp.Issuer(), "ahbcc")
+
+		want := tt.expected
+		_, got := verifyIDToken(context.Background(), rawIDToken, "nonce")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestVerifyIDToken_failsWhenTheTokenIsNotProperlySigned(t *testing.T) {
+	idp := oidc.NewMockIdP(t, "ahbcc")
+	anotherIdP := oidc.NewMockIdP(t, "ahbcc")
+	rawIDToken := idp.SignIDToken(idp.Claims("248289761001", "jdoe", "nonce"))
+	parts := strings.Split(rawIDToken, ".")
+	unsignedHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"none"}`))
+	symmetricHeader := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"HS256","kid":"mock-key"}`))
+	tamperedPayload := base64.RawURLEncoding.EncodeToString([]byte(`{"sub":"1"}`))
+
+	tests := []struct {
+		rawIDToken string
+		expected   error
+	}{
+		{rawIDToken: "not a token", expected: oidc.MalformedIDToken},
+		{rawIDToken: "!." + parts[1] + "." + parts[2], expected: oidc.MalformedIDToken},
+		{rawIDToken: parts[0] + "." + parts[1] + ".!", expected: oidc.MalformedIDToken},
+		{rawIDToken: unsignedHeader + "." + parts[1] + ".", expected: oidc.UnsupportedSigningAlgorithm},
+		{rawIDToken: symmetricHeader + "." + parts[1] + "." + parts[2], expected: oidc.UnsupportedSigningAlgorithm},
+		{rawIDToken: parts[0] + "." + tamperedPayload + "." + parts[2], expected: oidc.InvalidIDTokenSignature},
+		{rawIDToken: anotherIdP.SignIDToken(idp.Claims("248289761001", "jdoe", "nonce")), expected: oidc.InvalidIDTokenSignature},
+	}
+
+	for _, tt := range tests {
+		verifyIDToken := oidc.MakeVerifyIDToken(oidc.MockSelectKey(idp.PublicKey(), nil), idp.Issuer(), "ahbcc")
+
+		want := tt.expected
+		_, got := verifyIDToken(context.Background(), tt.rawIDToken, "nonce")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestVerifyIDToken_failsWhenTheKeyTypeDoesNotMatchTheAlgorithm(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := oidc.NewMockIdP(t, "ahbcc")
+	rawIDToken := idp.SignIDToken(idp.Claims("248289761001", "jdoe", "nonce"))
+
+	verifyIDToken := oidc.MakeVerifyIDToken(oidc.MockSelectKey(&key.PublicKey, nil), idp.Issuer(), "ahbcc")
+
+	want := oidc.InvalidIDTokenSignature
+	_, got := verifyIDToken(context.Background(), rawIDToken, "nonce")
+
+	assert.Equal(t, want, got)
+}
+
+func TestVerifyIDToken_failsWhenSelectKeyThrowsError(t *testing.T) {
+	tests := []struct {
+		err error
+	}{
+		{err: oidc.UnknownSigningKey},
+		{err: oidc.FailedToRetrieveSigningKeys},
+	}
+
+	for _, tt := range tests {
+		idp := oidc.NewMockIdP(t, "ahbcc")
+		rawIDToken := idp.SignIDToken(idp.Claims("248289761001", "jdoe", "nonce"))
+
+		verifyIDToken := oidc.MakeVerifyIDToken(oidc.MockSelectKey(nil, tt.err), idp.Issuer(), "ahbcc")
+
+		want := tt.err
+		_, got := verifyIDToken(context.Background(), rawIDToken, "nonce")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+// signES256 returns an ID token with the given claims, signed with the given key
+func signES256(t *testing.T, key *ecdsa.PrivateKey, claims map[string]any) string {
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString([]byte(`{"alg":"ES256","kid":"ec"}`)) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
@@ -0,0 +1,89 @@
+package oidc
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// InsertState inserts a login state into the 'oidc_login_states' table, deleting the expired ones. Only the hash
+	// of the state is stored
+	InsertState func(ctx context.Context, state StateDAO) error
+
+	// InsertIdentity links the identity of the provider to the user, inserting it into the 'users_identities' table
+	InsertIdentity func(ctx context.Context, identity IdentityDAO) error
+
+	// ProvisionUser inserts a new user with the given username, without a password, and links the identity of the
+	// provider to it. It is done in a single statement, so no user is left without its identity. It returns the ID of
+	// the new user
+	ProvisionUser func(ctx context.Context, identity IdentityDTO) (int, error)
+)
+
+// MakeInsertState creates a new InsertState
+func MakeInsertState(db database.Connection) InsertState {
+	const query string = `
+		WITH expired AS (
+			DELETE FROM oidc_login_states
+			WHERE expires_at <= NOW()
+		)
+		INSERT INTO oidc_login_states(state_hash, nonce, code_verifier, expires_at)
+		VALUES ($1, $2, $3, $4);
+	`
+
+	return func(ctx context.Context, state StateDAO) error {
+		_, err := db.Exec(ctx, query, session.HashToken(state.State), state.Nonce, state.CodeVerifier, state.ExpiresAt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertState
+		}
+
+		return nil
+	}
+}
+
+// MakeInsertIdentity creates a new InsertIdentity
+func MakeInsertIdentity(db database.Connection) InsertIdentity {
+	const query string = `
+		INSERT INTO users_identities(user_id, issuer, subject)
+		VALUES ($1, $2, $3);
+	`
+
+	return func(ctx context.Context, identity IdentityDAO) error {
+		_, err := db.Exec(ctx, query, identity.UserID, identity.Issuer, identity.Subject)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertIdentity
+		}
+
+		return nil
+	}
+}
+
+// MakeProvisionUser creates a new ProvisionUser
+func MakeProvisionUser(db database.Connection) ProvisionUser {
+	const query string = `
+		WITH new_user AS (
+			INSERT INTO users(username, password_hash)
+			VALUES ($1, '')
+			RETURNING id
+		)
+		INSERT INTO users_identities(user_id, issuer, subject)
+		SELECT new_user.id, $2, $3
+		FROM new_user
+		RETURNING user_id;
+	`
+
+	return func(ctx context.Context, identity IdentityDTO) (int, error) {
+		var userID int
+		err := db.QueryRow(ctx, query, identity.Username, identity.Issuer, identity.Subject).Scan(&userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToProvisionUser
+		}
+
+		return userID, nil
+	}
+}
@@ -0,0 +1,101 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+)
+
+func TestInsertState_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockStateDAO := oidc.MockStateDAO()
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{session.HashToken(mockStateDAO.State), mockStateDAO.Nonce, mockStateDAO.CodeVerifier, mockStateDAO.ExpiresAt}).Return(pgconn.CommandTag{}, nil)
+
+	insertState := oidc.MakeInsertState(mockPostgresConnection)
+
+	got := insertState(context.Background(), mockStateDAO)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertState_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert state"))
+
+	insertState := oidc.MakeInsertState(mockPostgresConnection)
+
+	want := oidc.FailedToInsertState
+	got := insertState(context.Background(), oidc.MockStateDAO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertIdentity_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockIdentityDAO := oidc.IdentityDAO{UserID: 1, Issuer: "https://idp.example.edu", Subject: "248289761001"}
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{mockIdentityDAO.UserID, mockIdentityDAO.Issuer, mockIdentityDAO.Subject}).Return(pgconn.CommandTag{}, nil)
+
+	insertIdentity := oidc.MakeInsertIdentity(mockPostgresConnection)
+
+	got := insertIdentity(context.Background(), mockIdentityDAO)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertIdentity_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert identity"))
+
+	insertIdentity := oidc.MakeInsertIdentity(mockPostgresConnection)
+
+	want := oidc.FailedToInsertIdentity
+	got := insertIdentity(context.Background(), oidc.IdentityDAO{})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestProvisionUser_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{7}, t)
+	mockIdentityDTO := oidc.MockIdentityDTO()
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{mockIdentityDTO.Username, mockIdentityDTO.Issuer, mockIdentityDTO.Subject}).Return(mockPgxRow)
+
+	provisionUser := oidc.MakeProvisionUser(mockPostgresConnection)
+
+	want := 7
+	got, err := provisionUser(context.Background(), mockIdentityDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestProvisionUser_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(errors.New("failed to provision user"))
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	provisionUser := oidc.MakeProvisionUser(mockPostgresConnection)
+
+	want := oidc.FailedToProvisionUser
+	_, got := provisionUser(context.Background(), oidc.MockIdentityDTO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
@@ -0,0 +1,133 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sync"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+// SelectKey retrieves the public key of the provider with the given key ID. The keys are kept in memory, and are only
+// requested again when the key ID is unknown, at most once per refresh interval, so the keys rotated by the provider
+// are picked up without allowing the unknown key IDs to flood it with requests
+type SelectKey func(ctx context.Context, kid string) (crypto.PublicKey, error)
+
+// MakeSelectKey creates a new SelectKey
+func MakeSelectKey(httpClient *http.Client, discover Discover, refreshInterval time.Duration) SelectKey {
+	var mu sync.Mutex
+	var keys map[string]crypto.PublicKey
+	var refreshedAt time.Time
+
+	return func(ctx context.Context, kid string) (crypto.PublicKey, error) {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if key, ok := keys[kid]; ok {
+			return key, nil
+		}
+
+		if keys != nil && time.Since(refreshedAt) < refreshInterval {
+			log.Error(ctx, fmt.Sprintf("Unknown signing key: %s", kid))
+			return nil, UnknownSigningKey
+		}
+
+		metadata, err := discover(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveSigningKeys
+		}
+
+		var set jwks
+		err = getJSON(ctx, httpClient, metadata.JWKSURI, &set)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveSigningKeys
+		}
+
+		keys = make(map[string]crypto.PublicKey, len(set.Keys))
+		refreshedAt = time.Now()
+		for _, k := range set.Keys {
+			if k.Use != "" && k.Use != "sig" {
+				continue
+			}
+
+			key, err := k.publicKey()
+			if err != nil {
+				log.Warn(ctx, fmt.Sprintf("Ignoring signing key %s: %s", k.Kid, err.Error()))
+				continue
+			}
+			keys[k.Kid] = key
+		}
+
+		key, ok := keys[kid]
+		if !ok {
+			log.Error(ctx, fmt.Sprintf("Unknown signing key: %s", kid))
+			return nil, UnknownSigningKey
+		}
+
+		return key, nil
+	}
+}
+
+// publicKey parses the RSA or EC public key of the JSON Web Key
+func (k jwk) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+
+		exponent := new(big.Int).SetBytes(e)
+		if len(n) == 0 || !exponent.IsInt64() || exponent.Int64() < 3 || exponent.Int64() > 1<<31-1 {
+			return nil, UnsupportedSigningKey
+		}
+
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(exponent.Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, UnsupportedSigningKey
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, UnsupportedSigningKey
+		}
+
+		return ecdsa.ParseUncompressedPublicKey(curve, append(append([]byte{4}, x...), y...))
+	default:
+		return nil, UnsupportedSigningKey
+	}
+}
@@ -0,0 +1,117 @@
+package oidc_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+)
+
+func TestSelectKey_success(t *testing.T) {
+	idp := oidc.NewMockIdP(t, "ahbcc")
+	discover := oidc.MakeDiscover(idp.Client(), idp.Issuer())
+
+	selectKey := oidc.MakeSelectKey(idp.Client(), discover, time.Minute)
+
+	want := idp.PublicKey()
+	got, err := selectKey(context.Background(), "mock-key")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestSelectKey_successWithECKey(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecdhKey, err := key.PublicKey.ECDH()
+	if err != nil {
+		t.Fatal(err)
+	}
+	point := ecdhKey.Bytes()
+	server := mockJWKSServer(t, `{"keys":[{"kty":"EC","kid":"ec","crv":"P-256","x":"`+base64.RawURLEncoding.EncodeToString(point[1:33])+`","y":"`+base64.RawURLEncoding.EncodeToString(point[33:])+`"}]}`)
+
+	selectKey := oidc.MakeSelectKey(server.Client(), oidc.MockDiscover(oidc.ProviderMetadata{JWKSURI: server.URL}, nil), time.Minute)
+
+	got, err := selectKey(context.Background(), "ec")
+
+	assert.Nil(t, err)
+	assert.True(t, key.PublicKey.Equal(got))
+}
+
+func TestSelectKey_successIgnoringUnsupportedKeys(t *testing.T) {
+	server := mockJWKSServer(t, `{"keys":[{"kty":"oct","kid":"symmetric","k":"c2VjcmV0"},{"kty":"RSA","kid":"encryption","use":"enc","n":"AQAB","e":"AQAB"},{"kty":"EC","kid":"curve","crv":"P-192","x":"AQ","y":"AQ"}]}`)
+
+	selectKey := oidc.MakeSelectKey(server.Client(), oidc.MockDiscover(oidc.ProviderMetadata{JWKSURI: server.URL}, nil), time.Minute)
+
+	for _, kid := range []string{"symmetric", "encryption", "curve"} {
+		_, err := selectKey(context.Background(), kid)
+
+		assert.Equal(t, oidc.UnknownSigningKey, err)
+	}
+}
+
+func TestSelectKey_successRequestingTheKeysAgainOnlyAfterTheRefreshInterval(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write([]byte(`{"keys":[]}`))
+	}))
+	defer server.Close()
+	discover := oidc.MockDiscover(oidc.ProviderMetadata{JWKSURI: server.URL}, nil)
+
+	selectKey := oidc.MakeSelectKey(server.Client(), discover, time.Hour)
+	_, _ = selectKey(context.Background(), "unknown")
+	_, _ = selectKey(context.Background(), "unknown")
+
+	assert.Equal(t, 1, requests)
+
+	selectKey = oidc.MakeSelectKey(server.Client(), discover, 0)
+	_, _ = selectKey(context.Background(), "unknown")
+	_, _ = selectKey(context.Background(), "unknown")
+
+	assert.Equal(t, 3, requests)
+}
+
+func TestSelectKey_failsWhenTheKeysCantBeRetrieved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		discover oidc.Discover
+	}{
+		{discover: oidc.MockDiscover(oidc.ProviderMetadata{}, oidc.FailedToDiscoverProvider)},
+		{discover: oidc.MockDiscover(oidc.ProviderMetadata{JWKSURI: server.URL}, nil)},
+	}
+
+	for _, tt := range tests {
+		selectKey := oidc.MakeSelectKey(server.Client(), tt.discover, time.Minute)
+
+		want := oidc.FailedToRetrieveSigningKeys
+		_, got := selectKey(context.Background(), "mock-key")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+// mockJWKSServer starts a server responding with the given JSON Web Key Set
+func mockJWKSServer(t *testing.T, body string) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+
+	return server
+}
@@ -0,0 +1,325 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+)
+
+// MockDiscover mocks a Discover function
+func MockDiscover(metadata ProviderMetadata, err error) Discover {
+	return func(ctx context.Context) (ProviderMetadata, error) {
+		return metadata, err
+	}
+}
+
+// MockSelectKey mocks a SelectKey function
+func MockSelectKey(key crypto.PublicKey, err error) SelectKey {
+	return func(ctx context.Context, kid string) (crypto.PublicKey, error) {
+		return key, err
+	}
+}
+
+// MockVerifyIDToken mocks a VerifyIDToken function
+func MockVerifyIDToken(claims Claims, err error) VerifyIDToken {
+	return func(ctx context.Context, rawIDToken string, nonce string) (Claims, error) {
+		return claims, err
+	}
+}
+
+// MockAuthorizationURL mocks an AuthorizationURL function
+func MockAuthorizationURL(authorizationURL string, err error) AuthorizationURL {
+	return func(ctx context.Context) (string, error) {
+		return authorizationURL, err
+	}
+}
+
+// MockExchange mocks an Exchange function
+func MockExchange(rawIDToken string, err error) Exchange {
+	return func(ctx context.Context, code string, codeVerifier string) (string, error) {
+		return rawIDToken, err
+	}
+}
+
+// MockAuthenticate mocks an Authenticate function
+func MockAuthenticate(identity IdentityDTO, err error) Authenticate {
+	return func(ctx context.Context, code string, state string) (IdentityDTO, error) {
+		return identity, err
+	}
+}
+
+// MockResolveUser mocks a ResolveUser function
+func MockResolveUser(userID int, err error) ResolveUser {
+	return func(ctx context.Context, identity IdentityDTO) (int, error) {
+		return userID, err
+	}
+}
+
+// MockInsertState mocks an InsertState function
+func MockInsertState(err error) InsertState {
+	return func(ctx context.Context, state StateDAO) error {
+		return err
+	}
+}
+
+// MockConsumeState mocks a ConsumeState function
+func MockConsumeState(state StateDAO, err error) ConsumeState {
+	return func(ctx context.Context, value string) (StateDAO, error) {
+		return state, err
+	}
+}
+
+// MockSelectUserIDByIdentity mocks a SelectUserIDByIdentity function
+func MockSelectUserIDByIdentity(userID int, err error) SelectUserIDByIdentity {
+	return func(ctx context.Context, issuer string, subject string) (int, error) {
+		return userID, err
+	}
+}
+
+// MockInsertIdentity mocks an InsertIdentity function
+func MockInsertIdentity(err error) InsertIdentity {
+	return func(ctx context.Context, identity IdentityDAO) error {
+		return err
+	}
+}
+
+// MockProvisionUser mocks a ProvisionUser function
+func MockProvisionUser(userID int, err error) ProvisionUser {
+	return func(ctx context.Context, identity IdentityDTO) (int, error) {
+		return userID, err
+	}
+}
+
+// MockConfig mocks a Config
+func MockConfig(issuerURL string) Config {
+	return Config{
+		IssuerURL:     issuerURL,
+		ClientID:      "ahbcc",
+		ClientSecret:  "client-secret",
+		RedirectURL:   "http://localhost:4000/auth/oidc/callback/v1",
+		Scopes:        []string{"openid", "profile", "email"},
+		UsernameClaim: "preferred_username",
+	}
+}
+
+// MockStateDAO mocks a StateDAO
+func MockStateDAO() StateDAO {
+	return StateDAO{
+		State:        "state",
+		Nonce:        "nonce",
+		CodeVerifier: "code verifier",
+		ExpiresAt:    time.Date(2025, time.March, 8, 0, 10, 0, 0, time.UTC),
+	}
+}
+
+// MockIdentityDTO mocks an IdentityDTO
+func MockIdentityDTO() IdentityDTO {
+	return IdentityDTO{
+		Issuer:   "https://idp.example.edu",
+		Subject:  "248289761001",
+		Username: "jdoe",
+	}
+}
+
+// MockIdP is a local OpenID Connect provider, serving the discovery document, the signing keys and the token endpoint.
+// It only issues ID tokens for the authorization codes returned by Authorize, and only when the PKCE code verifier
+// and the client ID match the ones of the authorization request
+type MockIdP struct {
+	t        *testing.T
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+
+	mu    sync.Mutex
+	codes map[string]mockAuthorization
+}
+
+// mockAuthorization represents an authorization code issued by the MockIdP
+type mockAuthorization struct {
+	clientID      string
+	redirectURI   string
+	codeChallenge string
+	nonce         string
+	subject       string
+	username      string
+}
+
+// mockKeyID is the key ID of the signing key of the MockIdP
+const mockKeyID string = "mock-key"
+
+// NewMockIdP starts a new MockIdP for the given client ID, which is stopped when the test finishes
+func NewMockIdP(t *testing.T, clientID string) *MockIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idp := &MockIdP{t: t, key: key, clientID: clientID, codes: make(map[string]mockAuthorization)}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	mux.HandleFunc("POST /token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+
+	return idp
+}
+
+// Issuer returns the issuer URL of the MockIdP
+func (idp *MockIdP) Issuer() string {
+	return idp.server.URL
+}
+
+// Client returns an HTTP client for the MockIdP
+func (idp *MockIdP) Client() *http.Client {
+	return idp.server.Client()
+}
+
+// Authorize simulates the login of the user with the given subject and username in the MockIdP, for the given
+// authorization URL. It returns the authorization code and the state the MockIdP would redirect the user back with
+func (idp *MockIdP) Authorize(authorizationURL string, subject string, username string) (string, string) {
+	parsed, err := url.Parse(authorizationURL)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+
+	query := parsed.Query()
+	if query.Get("response_type") != "code" || query.Get("code_challenge_method") != "S256" {
+		idp.t.Fatalf("unexpected authorization request: %s", authorizationURL)
+	}
+
+	code := base64.RawURLEncoding.EncodeToString([]byte(subject + ":" + query.Get("state")))
+
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+	idp.codes[code] = mockAuthorization{
+		clientID:      query.Get("client_id"),
+		redirectURI:   query.Get("redirect_uri"),
+		codeChallenge: query.Get("code_challenge"),
+		nonce:         query.Get("nonce"),
+		subject:       subject,
+		username:      username,
+	}
+
+	return code, query.Get("state")
+}
+
+// SignIDToken returns an ID token with the given claims, signed with the key of the MockIdP
+func (idp *MockIdP) SignIDToken(claims map[string]any) string {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "kid": mockKeyID, "typ": "JWT"})
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, idp.key, crypto.SHA256, digest[:])
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// Claims returns valid ID token claims of the MockIdP for the given subject, username and nonce
+func (idp *MockIdP) Claims(subject string, username string, nonce string) map[string]any {
+	return map[string]any{
+		"iss":                idp.Issuer(),
+		"sub":                subject,
+		"aud":                idp.clientID,
+		"exp":                time.Now().Add(time.Hour).Unix(),
+		"iat":                time.Now().Unix(),
+		"nonce":              nonce,
+		"preferred_username": username,
+		"email":              username + "@example.edu",
+		"email_verified":     true,
+	}
+}
+
+// PublicKey returns the public key of the MockIdP
+func (idp *MockIdP) PublicKey() crypto.PublicKey {
+	return &idp.key.PublicKey
+}
+
+// discovery serves the discovery document of the MockIdP
+func (idp *MockIdP) discovery(w http.ResponseWriter, _ *http.Request) {
+	idp.writeJSON(w, ProviderMetadata{
+		Issuer:                idp.Issuer(),
+		AuthorizationEndpoint: idp.Issuer() + "/authorize",
+		TokenEndpoint:         idp.Issuer() + "/token",
+		JWKSURI:               idp.Issuer() + "/jwks",
+	})
+}
+
+// jwks serves the signing keys of the MockIdP
+func (idp *MockIdP) jwks(w http.ResponseWriter, _ *http.Request) {
+	idp.writeJSON(w, jwks{Keys: []jwk{{
+		Kty: "RSA",
+		Kid: mockKeyID,
+		Use: "sig",
+		N:   base64.RawURLEncoding.EncodeToString(idp.key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(idp.key.E)).Bytes()),
+	}}})
+}
+
+// token serves the token endpoint of the MockIdP. Each authorization code can only be exchanged once
+func (idp *MockIdP) token(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseForm()
+	if err != nil {
+		http.Error(w, `{"error":"invalid_request"}`, http.StatusBadRequest)
+		return
+	}
+
+	idp.mu.Lock()
+	authorization, ok := idp.codes[r.PostForm.Get("code")]
+	delete(idp.codes, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+
+	clientID, _, hasBasicAuth := r.BasicAuth()
+	if !hasBasicAuth {
+		clientID = r.PostForm.Get("client_id")
+	}
+
+	if !ok ||
+		r.PostForm.Get("grant_type") != "authorization_code" ||
+		clientID != idp.clientID ||
+		authorization.clientID != idp.clientID ||
+		r.PostForm.Get("redirect_uri") != authorization.redirectURI ||
+		CodeChallenge(r.PostForm.Get("code_verifier")) != authorization.codeChallenge {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	idp.writeJSON(w, map[string]any{
+		"access_token": "access token",
+		"token_type":   "Bearer",
+		"expires_in":   3600,
+		"id_token":     idp.SignIDToken(idp.Claims(authorization.subject, authorization.username, authorization.nonce)),
+	})
+}
+
+// writeJSON writes the given value as a JSON response
+func (idp *MockIdP) writeJSON(w http.ResponseWriter, value any) {
+	w.Header().Set("Content-Type", "application/json")
+	err := json.NewEncoder(w).Encode(value)
+	if err != nil {
+		idp.t.Error(err)
+	}
+}
@@ -0,0 +1,59 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// ResolveUser returns the ID of the user of the given identity. When no user is linked to it yet, a local user with the
+// same username is linked to it if it is allowed by the configuration, otherwise a new user is provisioned if it is
+// allowed by the configuration
+type ResolveUser func(ctx context.Context, identity IdentityDTO) (int, error)
+
+// MakeResolveUser creates a new ResolveUser
+func MakeResolveUser(selectUserIDByIdentity SelectUserIDByIdentity, selectUserByUsername user.SelectByUsername, insertIdentity InsertIdentity, provisionUser ProvisionUser, config Config) ResolveUser {
+	return func(ctx context.Context, identity IdentityDTO) (int, error) {
+		userID, err := selectUserIDByIdentity(ctx, identity.Issuer, identity.Subject)
+		if err == nil {
+			return userID, nil
+		} else if !errors.Is(err, NoUserLinkedToIdentity) {
+			log.Error(ctx, err.Error())
+			return 0, FailedToResolveUser
+		}
+
+		userDAO, err := selectUserByUsername(ctx, identity.Username)
+		if err == nil {
+			if !config.LinkExistingUsers {
+				log.Error(ctx, UsernameTaken.Error())
+				return 0, UsernameTaken
+			}
+
+			err = insertIdentity(ctx, IdentityDAO{UserID: userDAO.ID, Issuer: identity.Issuer, Subject: identity.Subject})
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return 0, FailedToResolveUser
+			}
+
+			return userDAO.ID, nil
+		} else if !errors.Is(err, user.NoUserFoundForTheGivenUsername) {
+			log.Error(ctx, err.Error())
+			return 0, FailedToResolveUser
+		}
+
+		if !config.AutoProvision {
+			log.Error(ctx, UserNotProvisioned.Error())
+			return 0, UserNotProvisioned
+		}
+
+		userID, err = provisionUser(ctx, identity)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToResolveUser
+		}
+
+		return userID, nil
+	}
+}
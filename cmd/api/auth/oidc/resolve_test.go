@@ -0,0 +1,76 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/cmd/api/user"
+)
+
+func TestResolveUser_success(t *testing.T) {
+	mockUserDAO := user.MockDAO()
+
+	tests := []struct {
+		selectUserIDByIdentity oidc.SelectUserIDByIdentity
+		selectUserByUsername   user.SelectByUsername
+		linkExistingUsers      bool
+		autoProvision          bool
+		expected               int
+	}{
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(5, nil), selectUserByUsername: user.MockSelectByUsername(mockUserDAO, nil), expected: 5},
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.NoUserLinkedToIdentity), selectUserByUsername: user.MockSelectByUsername(mockUserDAO, nil), linkExistingUsers: true, expected: mockUserDAO.ID},
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.NoUserLinkedToIdentity), selectUserByUsername: user.MockSelectByUsername(user.DAO{}, user.NoUserFoundForTheGivenUsername), autoProvision: true, expected: 7},
+	}
+
+	for _, tt := range tests {
+		mockConfig := oidc.MockConfig("https://idp.example.edu")
+		mockConfig.LinkExistingUsers = tt.linkExistingUsers
+		mockConfig.AutoProvision = tt.autoProvision
+
+		resolveUser := oidc.MakeResolveUser(tt.selectUserIDByIdentity, tt.selectUserByUsername, oidc.MockInsertIdentity(nil), oidc.MockProvisionUser(7, nil), mockConfig)
+
+		want := tt.expected
+		got, err := resolveUser(context.Background(), oidc.MockIdentityDTO())
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestResolveUser_failsWhenTheUserCantBeResolved(t *testing.T) {
+	mockUserDAO := user.MockDAO()
+
+	tests := []struct {
+		selectUserIDByIdentity oidc.SelectUserIDByIdentity
+		selectUserByUsername   user.SelectByUsername
+		insertIdentity         oidc.InsertIdentity
+		provisionUser          oidc.ProvisionUser
+		linkExistingUsers      bool
+		autoProvision          bool
+		expected               error
+	}{
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.FailedToExecuteSelectUserIDByIdentity), selectUserByUsername: user.MockSelectByUsername(mockUserDAO, nil), insertIdentity: oidc.MockInsertIdentity(nil), provisionUser: oidc.MockProvisionUser(7, nil), expected: oidc.FailedToResolveUser},
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.NoUserLinkedToIdentity), selectUserByUsername: user.MockSelectByUsername(mockUserDAO, nil), insertIdentity: oidc.MockInsertIdentity(nil), provisionUser: oidc.MockProvisionUser(7, nil), autoProvision: true, expected: oidc.UsernameTaken},
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.NoUserLinkedToIdentity), selectUserByUsername: user.MockSelectByUsername(mockUserDAO, nil), insertIdentity: oidc.MockInsertIdentity(oidc.FailedToInsertIdentity), provisionUser: oidc.MockProvisionUser(7, nil), linkExistingUsers: true, expected: oidc.FailedToResolveUser},
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.NoUserLinkedToIdentity), selectUserByUsername: user.MockSelectByUsername(user.DAO{}, errors.New("failed to select user")), insertIdentity: oidc.MockInsertIdentity(nil), provisionUser: oidc.MockProvisionUser(7, nil), autoProvision: true, expected: oidc.FailedToResolveUser},
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.NoUserLinkedToIdentity), selectUserByUsername: user.MockSelectByUsername(user.DAO{}, user.NoUserFoundForTheGivenUsername), insertIdentity: oidc.MockInsertIdentity(nil), provisionUser: oidc.MockProvisionUser(7, nil), expected: oidc.UserNotProvisioned},
+		{selectUserIDByIdentity: oidc.MockSelectUserIDByIdentity(0, oidc.NoUserLinkedToIdentity), selectUserByUsername: user.MockSelectByUsername(user.DAO{}, user.NoUserFoundForTheGivenUsername), insertIdentity: oidc.MockInsertIdentity(nil), provisionUser: oidc.MockProvisionUser(0, oidc.FailedToProvisionUser), autoProvision: true, expected: oidc.FailedToResolveUser},
+	}
+
+	for _, tt := range tests {
+		mockConfig := oidc.MockConfig("https://idp.example.edu")
+		mockConfig.LinkExistingUsers = tt.linkExistingUsers
+		mockConfig.AutoProvision = tt.autoProvision
+
+		resolveUser := oidc.MakeResolveUser(tt.selectUserIDByIdentity, tt.selectUserByUsername, tt.insertIdentity, tt.provisionUser, mockConfig)
+
+		want := tt.expected
+		_, got := resolveUser(context.Background(), oidc.MockIdentityDTO())
+
+		assert.Equal(t, want, got)
+	}
+}
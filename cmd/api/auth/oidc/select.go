@@ -0,0 +1,38 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// SelectUserIDByIdentity retrieves the ID of the user linked to the given subject of the given issuer
+type SelectUserIDByIdentity func(ctx context.Context, issuer string, subject string) (int, error)
+
+// MakeSelectUserIDByIdentity creates a new SelectUserIDByIdentity
+func MakeSelectUserIDByIdentity(db database.Connection) SelectUserIDByIdentity {
+	const query string = `
+		SELECT user_id
+		FROM users_identities
+		WHERE issuer = $1
+		  AND subject = $2;
+	`
+
+	return func(ctx context.Context, issuer string, subject string) (int, error) {
+		var userID int
+		err := db.QueryRow(ctx, query, issuer, subject).Scan(&userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return 0, NoUserLinkedToIdentity
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToExecuteSelectUserIDByIdentity
+		}
+
+		return userID, nil
+	}
+}
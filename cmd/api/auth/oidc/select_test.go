@@ -0,0 +1,57 @@
+package oidc_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/internal/database"
+)
+
+func TestSelectUserIDByIdentity_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{5}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{"https://idp.example.edu", "248289761001"}).Return(mockPgxRow)
+
+	selectUserIDByIdentity := oidc.MakeSelectUserIDByIdentity(mockPostgresConnection)
+
+	want := 5
+	got, err := selectUserIDByIdentity(context.Background(), "https://idp.example.edu", "248289761001")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectUserIDByIdentity_failsWhenSelectOperationThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: oidc.NoUserLinkedToIdentity},
+		{err: errors.New("failed to execute select operation"), expected: oidc.FailedToExecuteSelectUserIDByIdentity},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		selectUserIDByIdentity := oidc.MakeSelectUserIDByIdentity(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := selectUserIDByIdentity(context.Background(), "https://idp.example.edu", "248289761001")
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRow.AssertExpectations(t)
+	}
+}
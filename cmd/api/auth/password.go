@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/password"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/log"
+)
+
+type (
+	// ChangePassword replaces the password of the user that owns the given token, after verifying its current password
+	// and that the new one meets the password policy. The rest of the sessions of the user are revoked, and the amount
+	// of them is returned
+	ChangePassword func(ctx context.Context, token string, body ChangePasswordDTO) (int, error)
+
+	// IssuePasswordResetToken creates a single-use password reset token for the user of the given username. Only the
+	// admins are allowed to do it
+	IssuePasswordResetToken func(ctx context.Context, token string, username string) (PasswordResetTokenDTO, error)
+
+	// ResetPassword replaces the password of the user of the given password reset token, as long as the new password
+	// meets the password policy. The token is consumed, and all the sessions of the user are revoked
+	ResetPassword func(ctx context.Context, body ResetPasswordDTO) error
+)
+
+// MakeChangePassword creates a new ChangePassword
+func MakeChangePassword(selectUserIDByToken session.SelectUserIDByToken, selectUserByID user.SelectByID, validatePassword password.Validate, updatePassword user.UpdatePassword, deleteOtherSessions session.DeleteOthers) ChangePassword {
+	return func(ctx context.Context, token string, body ChangePasswordDTO) (int, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, sessionTokenError(err)
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		userDAO, err := selectUserByID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToSelectUserByID
+		}
+
+		err = bcrypt.CompareHashAndPassword([]byte(userDAO.PasswordHash), []byte(body.CurrentPassword))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, WrongCurrentPassword
+		}
+
+		err = validatePassword(userDAO.Username, body.NewPassword)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, err
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(body.NewPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToGenerateHashFromPassword
+		}
+
+		err = updatePassword(ctx, userID, string(hash))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToUpdateUserPassword
+		}
+
+		revoked, err := deleteOtherSessions(ctx, userID, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToDeleteOtherUserSessions
+		}
+
+		return revoked, nil
+	}
+}
+
+// MakeIssuePasswordResetToken creates a new IssuePasswordResetToken
+func MakeIssuePasswordResetToken(authorize Authorize, selectUserByUsername user.SelectByUsername, createResetToken password.CreateResetToken) IssuePasswordResetToken {
+	return func(ctx context.Context, token string, username string) (PasswordResetTokenDTO, error) {
+		adminID, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return PasswordResetTokenDTO{}, AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		userDAO, err := selectUserByUsername(ctx, username)
+		if errors.Is(err, user.NoUserFoundForTheGivenUsername) {
+			log.Error(ctx, err.Error())
+			return PasswordResetTokenDTO{}, NoUserFoundForTheGivenUsername
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return PasswordResetTokenDTO{}, FailedToSelectUserByUsername
+		}
+
+		resetToken, expiresAt, err := createResetToken(ctx, userDAO.ID, adminID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return PasswordResetTokenDTO{}, FailedToCreatePasswordResetToken
+		}
+
+		return PasswordResetTokenDTO{Token: resetToken, ExpiresAt: expiresAt}, nil
+	}
+}
+
+// MakeResetPassword creates a new ResetPassword
+func MakeResetPassword(selectUserIDByResetToken password.SelectUserIDByResetToken, selectUserByID user.SelectByID, validatePassword password.Validate, resetWithToken password.ResetWithToken, deleteAllSessions session.DeleteAll) ResetPassword {
+	return func(ctx context.Context, body ResetPasswordDTO) error {
+		userID, err := selectUserIDByResetToken(ctx, body.Token)
+		if errors.Is(err, password.NoValidResetTokenFound) {
+			log.Error(ctx, err.Error())
+			return InvalidPasswordResetToken
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToResetUserPassword
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		userDAO, err := selectUserByID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToSelectUserByID
+		}
+
+		err = validatePassword(userDAO.Username, body.NewPassword)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return err
+		}
+
+		hash, err := bcrypt.GenerateFromPassword([]byte(body.NewPassword), bcrypt.DefaultCost)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToGenerateHashFromPassword
+		}
+
+		// The token is verified again while it is consumed, since it could have been used, or expired, in the meantime
+		_, err = resetWithToken(ctx, body.Token, string(hash))
+		if errors.Is(err, password.NoValidResetTokenFound) {
+			log.Error(ctx, err.Error())
+			return InvalidPasswordResetToken
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToResetUserPassword
+		}
+
+		_, err = deleteAllSessions(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDeleteAllUserSessions
+		}
+
+		return nil
+	}
+}
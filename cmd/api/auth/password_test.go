@@ -0,0 +1,316 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/password"
+	"ahbcc/cmd/api/user/session"
+)
+
+func TestChangePassword_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(nil)
+	mockUpdatePassword := user.MockUpdatePassword(nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(2, nil)
+
+	changePassword := auth.MakeChangePassword(mockSelectUserIDByToken, mockSelectUserByID, mockValidatePassword, mockUpdatePassword, mockDeleteOtherSessions)
+
+	want := 2
+	got, err := changePassword(context.Background(), "token", auth.MockChangePasswordDTO())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestChangePassword_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.ExpiredSessionToken, expected: auth.InvalidSessionToken},
+		{err: session.FailedToExecuteQueryToRetrieveUserID, expected: auth.FailedToRetrieveUserID},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(-1, tt.err)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockValidatePassword := password.MockValidate(nil)
+		mockUpdatePassword := user.MockUpdatePassword(nil)
+		mockDeleteOtherSessions := session.MockDeleteOthers(2, nil)
+
+		changePassword := auth.MakeChangePassword(mockSelectUserIDByToken, mockSelectUserByID, mockValidatePassword, mockUpdatePassword, mockDeleteOtherSessions)
+
+		want := tt.expected
+		_, got := changePassword(context.Background(), "token", auth.MockChangePasswordDTO())
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestChangePassword_failsWhenSelectUserByIDThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.DAO{}, user.NoUserFoundForTheGivenID)
+	mockValidatePassword := password.MockValidate(nil)
+	mockUpdatePassword := user.MockUpdatePassword(nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(2, nil)
+
+	changePassword := auth.MakeChangePassword(mockSelectUserIDByToken, mockSelectUserByID, mockValidatePassword, mockUpdatePassword, mockDeleteOtherSessions)
+
+	want := auth.FailedToSelectUserByID
+	_, got := changePassword(context.Background(), "token", auth.MockChangePasswordDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestChangePassword_failsWhenTheCurrentPasswordIsWrong(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(nil)
+	mockUpdatePassword := user.MockUpdatePassword(nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(2, nil)
+	mockChangePasswordDTO := auth.MockChangePasswordDTO()
+	mockChangePasswordDTO.CurrentPassword = "wrong password"
+
+	changePassword := auth.MakeChangePassword(mockSelectUserIDByToken, mockSelectUserByID, mockValidatePassword, mockUpdatePassword, mockDeleteOtherSessions)
+
+	want := auth.WrongCurrentPassword
+	_, got := changePassword(context.Background(), "token", mockChangePasswordDTO)
+
+	assert.Equal(t, want, got)
+}
+
+func TestChangePassword_failsWhenTheNewPasswordDoesNotMeetThePolicy(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(password.PasswordTooWeak)
+	mockUpdatePassword := user.MockUpdatePassword(nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(2, nil)
+
+	changePassword := auth.MakeChangePassword(mockSelectUserIDByToken, mockSelectUserByID, mockValidatePassword, mockUpdatePassword, mockDeleteOtherSessions)
+
+	want := password.PasswordTooWeak
+	_, got := changePassword(context.Background(), "token", auth.MockChangePasswordDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestChangePassword_failsWhenUpdatePasswordThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(nil)
+	mockUpdatePassword := user.MockUpdatePassword(errors.New("failed to update user password"))
+	mockDeleteOtherSessions := session.MockDeleteOthers(2, nil)
+
+	changePassword := auth.MakeChangePassword(mockSelectUserIDByToken, mockSelectUserByID, mockValidatePassword, mockUpdatePassword, mockDeleteOtherSessions)
+
+	want := auth.FailedToUpdateUserPassword
+	_, got := changePassword(context.Background(), "token", auth.MockChangePasswordDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestChangePassword_failsWhenDeleteOtherSessionsThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(nil)
+	mockUpdatePassword := user.MockUpdatePassword(nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(0, errors.New("failed to delete other user sessions"))
+
+	changePassword := auth.MakeChangePassword(mockSelectUserIDByToken, mockSelectUserByID, mockValidatePassword, mockUpdatePassword, mockDeleteOtherSessions)
+
+	want := auth.FailedToDeleteOtherUserSessions
+	_, got := changePassword(context.Background(), "token", auth.MockChangePasswordDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestIssuePasswordResetToken_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(2, nil)
+	mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), nil)
+	mockPasswordResetTokenDTO := auth.MockPasswordResetTokenDTO()
+	mockCreateResetToken := password.MockCreateResetToken(mockPasswordResetTokenDTO.Token, mockPasswordResetTokenDTO.ExpiresAt, nil)
+
+	issuePasswordResetToken := auth.MakeIssuePasswordResetToken(mockAuthorize, mockSelectUserByUsername, mockCreateResetToken)
+
+	want := mockPasswordResetTokenDTO
+	got, err := issuePasswordResetToken(context.Background(), "token", "username")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestIssuePasswordResetToken_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: auth.FailedToRetrieveUserID, expected: auth.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), nil)
+		mockCreateResetToken := password.MockCreateResetToken("reset token", time.Now(), nil)
+
+		issuePasswordResetToken := auth.MakeIssuePasswordResetToken(mockAuthorize, mockSelectUserByUsername, mockCreateResetToken)
+
+		want := tt.expected
+		_, got := issuePasswordResetToken(context.Background(), "token", "username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestIssuePasswordResetToken_failsWhenSelectUserByUsernameThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: user.NoUserFoundForTheGivenUsername, expected: auth.NoUserFoundForTheGivenUsername},
+		{err: user.FailedExecuteQueryToRetrieveUser, expected: auth.FailedToSelectUserByUsername},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(2, nil)
+		mockSelectUserByUsername := user.MockSelectByUsername(user.DAO{}, tt.err)
+		mockCreateResetToken := password.MockCreateResetToken("reset token", time.Now(), nil)
+
+		issuePasswordResetToken := auth.MakeIssuePasswordResetToken(mockAuthorize, mockSelectUserByUsername, mockCreateResetToken)
+
+		want := tt.expected
+		_, got := issuePasswordResetToken(context.Background(), "token", "username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestIssuePasswordResetToken_failsWhenCreateResetTokenThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(2, nil)
+	mockSelectUserByUsername := user.MockSelectByUsername(user.MockDAO(), nil)
+	mockCreateResetToken := password.MockCreateResetToken("", time.Time{}, password.FailedToCreateResetToken)
+
+	issuePasswordResetToken := auth.MakeIssuePasswordResetToken(mockAuthorize, mockSelectUserByUsername, mockCreateResetToken)
+
+	want := auth.FailedToCreatePasswordResetToken
+	_, got := issuePasswordResetToken(context.Background(), "token", "username")
+
+	assert.Equal(t, want, got)
+}
+
+func TestResetPassword_success(t *testing.T) {
+	mockSelectUserIDByResetToken := password.MockSelectUserIDByResetToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(nil)
+	mockResetWithToken := password.MockResetWithToken(1, nil)
+	mockDeleteAllSessions := session.MockDeleteAll(3, nil)
+
+	resetPassword := auth.MakeResetPassword(mockSelectUserIDByResetToken, mockSelectUserByID, mockValidatePassword, mockResetWithToken, mockDeleteAllSessions)
+
+	got := resetPassword(context.Background(), auth.MockResetPasswordDTO())
+
+	assert.Nil(t, got)
+}
+
+func TestResetPassword_failsWhenSelectUserIDByResetTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: password.NoValidResetTokenFound, expected: auth.InvalidPasswordResetToken},
+		{err: password.FailedToExecuteSelectUserIDByResetToken, expected: auth.FailedToResetUserPassword},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByResetToken := password.MockSelectUserIDByResetToken(0, tt.err)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockValidatePassword := password.MockValidate(nil)
+		mockResetWithToken := password.MockResetWithToken(1, nil)
+		mockDeleteAllSessions := session.MockDeleteAll(3, nil)
+
+		resetPassword := auth.MakeResetPassword(mockSelectUserIDByResetToken, mockSelectUserByID, mockValidatePassword, mockResetWithToken, mockDeleteAllSessions)
+
+		want := tt.expected
+		got := resetPassword(context.Background(), auth.MockResetPasswordDTO())
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestResetPassword_failsWhenSelectUserByIDThrowsError(t *testing.T) {
+	mockSelectUserIDByResetToken := password.MockSelectUserIDByResetToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.DAO{}, user.NoUserFoundForTheGivenID)
+	mockValidatePassword := password.MockValidate(nil)
+	mockResetWithToken := password.MockResetWithToken(1, nil)
+	mockDeleteAllSessions := session.MockDeleteAll(3, nil)
+
+	resetPassword := auth.MakeResetPassword(mockSelectUserIDByResetToken, mockSelectUserByID, mockValidatePassword, mockResetWithToken, mockDeleteAllSessions)
+
+	want := auth.FailedToSelectUserByID
+	got := resetPassword(context.Background(), auth.MockResetPasswordDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestResetPassword_failsWhenTheNewPasswordDoesNotMeetThePolicy(t *testing.T) {
+	mockSelectUserIDByResetToken := password.MockSelectUserIDByResetToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(password.PasswordEqualToUsername)
+	mockResetWithToken := password.MockResetWithToken(1, nil)
+	mockDeleteAllSessions := session.MockDeleteAll(3, nil)
+
+	resetPassword := auth.MakeResetPassword(mockSelectUserIDByResetToken, mockSelectUserByID, mockValidatePassword, mockResetWithToken, mockDeleteAllSessions)
+
+	want := password.PasswordEqualToUsername
+	got := resetPassword(context.Background(), auth.MockResetPasswordDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestResetPassword_failsWhenResetWithTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: password.NoValidResetTokenFound, expected: auth.InvalidPasswordResetToken},
+		{err: password.FailedToResetPasswordWithToken, expected: auth.FailedToResetUserPassword},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByResetToken := password.MockSelectUserIDByResetToken(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockValidatePassword := password.MockValidate(nil)
+		mockResetWithToken := password.MockResetWithToken(0, tt.err)
+		mockDeleteAllSessions := session.MockDeleteAll(3, nil)
+
+		resetPassword := auth.MakeResetPassword(mockSelectUserIDByResetToken, mockSelectUserByID, mockValidatePassword, mockResetWithToken, mockDeleteAllSessions)
+
+		want := tt.expected
+		got := resetPassword(context.Background(), auth.MockResetPasswordDTO())
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestResetPassword_failsWhenDeleteAllSessionsThrowsError(t *testing.T) {
+	mockSelectUserIDByResetToken := password.MockSelectUserIDByResetToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockValidatePassword := password.MockValidate(nil)
+	mockResetWithToken := password.MockResetWithToken(1, nil)
+	mockDeleteAllSessions := session.MockDeleteAll(0, errors.New("failed to delete all user sessions"))
+
+	resetPassword := auth.MakeResetPassword(mockSelectUserIDByResetToken, mockSelectUserByID, mockValidatePassword, mockResetWithToken, mockDeleteAllSessions)
+
+	want := auth.FailedToDeleteAllUserSessions
+	got := resetPassword(context.Background(), auth.MockResetPasswordDTO())
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ahbcc/cmd/api/middleware"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/log"
+)
+
+type (
+	// ListSessions returns the active sessions of the user that owns the given token, marking the one of the token as
+	// the current one
+	ListSessions func(ctx context.Context, token string) ([]SessionDTO, error)
+
+	// RevokeSession deletes a session, seeking it by its ID, of the user that owns the given token
+	RevokeSession func(ctx context.Context, token string, sessionID int) error
+
+	// RevokeOtherSessions deletes all the sessions of the user that owns the given token, except the one of the token.
+	// It returns the amount of sessions revoked
+	RevokeOtherSessions func(ctx context.Context, token string) (int, error)
+
+	// RefreshSession extends the session of the given token, replacing the token by a new one. It returns the new token
+	// with its expiration time. The user of the session is set as the actor of the request in the audit log
+	RefreshSession func(ctx context.Context, token string) (string, time.Time, error)
+)
+
+// MakeListSessions creates a new ListSessions
+func MakeListSessions(selectUserIDByToken session.SelectUserIDByToken, selectActiveSessions session.SelectActiveByUserID) ListSessions {
+	return func(ctx context.Context, token string) ([]SessionDTO, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, sessionTokenError(err)
+		}
+
+		activeSessions, err := selectActiveSessions(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveActiveSessions
+		}
+
+		tokenHash := session.HashToken(token)
+		sessions := make([]SessionDTO, 0, len(activeSessions))
+		for _, activeSession := range activeSessions {
+			sessions = append(sessions, SessionDTO{
+				ID:        activeSession.ID,
+				CreatedAt: activeSession.CreatedAt,
+				ExpiresAt: activeSession.ExpiresAt,
+				Current:   activeSession.TokenHash == tokenHash,
+			})
+		}
+
+		return sessions, nil
+	}
+}
+
+// MakeRevokeSession creates a new RevokeSession
+func MakeRevokeSession(selectUserIDByToken session.SelectUserIDByToken, deleteSessionByID session.DeleteByID) RevokeSession {
+	return func(ctx context.Context, token string, sessionID int) error {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return sessionTokenError(err)
+		}
+
+		err = deleteSessionByID(ctx, userID, sessionID)
+		if errors.Is(err, session.NoSessionFoundForTheGivenID) {
+			log.Error(ctx, err.Error())
+			return NoSessionFoundForTheGivenID
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDeleteUserSession
+		}
+
+		return nil
+	}
+}
+
+// MakeRevokeOtherSessions creates a new RevokeOtherSessions
+func MakeRevokeOtherSessions(selectUserIDByToken session.SelectUserIDByToken, deleteOtherSessions session.DeleteOthers) RevokeOtherSessions {
+	return func(ctx context.Context, token string) (int, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, sessionTokenError(err)
+		}
+
+		revoked, err := deleteOtherSessions(ctx, userID, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToDeleteOtherUserSessions
+		}
+
+		return revoked, nil
+	}
+}
+
+// MakeRefreshSession creates a new RefreshSession
+func MakeRefreshSession(refreshSessionToken session.RefreshToken) RefreshSession {
+	return func(ctx context.Context, token string) (string, time.Time, error) {
+		refreshed, err := refreshSessionToken(ctx, token)
+		if errors.Is(err, session.FailedToRefreshUserSessionToken) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToRefreshUserSession
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, InvalidSessionToken
+		}
+		middleware.SetActor(ctx, refreshed.UserID)
+
+		return refreshed.Token, refreshed.ExpiresAt, nil
+	}
+}
+
+// sessionTokenError returns InvalidSessionToken when the session of the token couldn't be retrieved because the token
+// is invalid, expired or revoked, so the handlers can distinguish it from a failure while retrieving it
+func sessionTokenError(err error) error {
+	if errors.Is(err, session.FailedToExecuteQueryToRetrieveUserID) {
+		return FailedToRetrieveUserID
+	}
+
+	return InvalidSessionToken
+}
@@ -0,0 +1,178 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user/session"
+)
+
+func TestListSessions_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectActiveSessions := session.MockSelectActiveByUserID(session.MockActiveDAOs(), nil)
+
+	listSessions := auth.MakeListSessions(mockSelectUserIDByToken, mockSelectActiveSessions)
+
+	want := auth.MockSessionDTOs()
+	got, err := listSessions(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestListSessions_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.ExpiredSessionToken, expected: auth.InvalidSessionToken},
+		{err: session.NoUserIDFoundForTheGivenToken, expected: auth.InvalidSessionToken},
+		{err: session.FailedToExecuteQueryToRetrieveUserID, expected: auth.FailedToRetrieveUserID},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(0, tt.err)
+		mockSelectActiveSessions := session.MockSelectActiveByUserID(session.MockActiveDAOs(), nil)
+
+		listSessions := auth.MakeListSessions(mockSelectUserIDByToken, mockSelectActiveSessions)
+
+		want := tt.expected
+		_, got := listSessions(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestListSessions_failsWhenSelectActiveSessionsThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectActiveSessions := session.MockSelectActiveByUserID(nil, errors.New("failed to select active sessions"))
+
+	listSessions := auth.MakeListSessions(mockSelectUserIDByToken, mockSelectActiveSessions)
+
+	want := auth.FailedToRetrieveActiveSessions
+	_, got := listSessions(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeSession_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockDeleteSessionByID := session.MockDeleteByID(nil)
+
+	revokeSession := auth.MakeRevokeSession(mockSelectUserIDByToken, mockDeleteSessionByID)
+
+	got := revokeSession(context.Background(), "token", 2)
+
+	assert.Nil(t, got)
+}
+
+func TestRevokeSession_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(0, session.InvalidSessionTokenSignature)
+	mockDeleteSessionByID := session.MockDeleteByID(nil)
+
+	revokeSession := auth.MakeRevokeSession(mockSelectUserIDByToken, mockDeleteSessionByID)
+
+	want := auth.InvalidSessionToken
+	got := revokeSession(context.Background(), "token", 2)
+
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeSession_failsWhenDeleteSessionByIDThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.NoSessionFoundForTheGivenID, expected: auth.NoSessionFoundForTheGivenID},
+		{err: session.FailedToDeleteUserSession, expected: auth.FailedToDeleteUserSession},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+		mockDeleteSessionByID := session.MockDeleteByID(tt.err)
+
+		revokeSession := auth.MakeRevokeSession(mockSelectUserIDByToken, mockDeleteSessionByID)
+
+		want := tt.expected
+		got := revokeSession(context.Background(), "token", 2)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRevokeOtherSessions_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(3, nil)
+
+	revokeOtherSessions := auth.MakeRevokeOtherSessions(mockSelectUserIDByToken, mockDeleteOtherSessions)
+
+	want := 3
+	got, err := revokeOtherSessions(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeOtherSessions_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(0, session.NoUserIDFoundForTheGivenToken)
+	mockDeleteOtherSessions := session.MockDeleteOthers(3, nil)
+
+	revokeOtherSessions := auth.MakeRevokeOtherSessions(mockSelectUserIDByToken, mockDeleteOtherSessions)
+
+	want := auth.InvalidSessionToken
+	_, got := revokeOtherSessions(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestRevokeOtherSessions_failsWhenDeleteOtherSessionsThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(0, errors.New("failed to delete other sessions"))
+
+	revokeOtherSessions := auth.MakeRevokeOtherSessions(mockSelectUserIDByToken, mockDeleteOtherSessions)
+
+	want := auth.FailedToDeleteOtherUserSessions
+	_, got := revokeOtherSessions(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestRefreshSession_success(t *testing.T) {
+	mockExpiresAt := time.Date(2006, time.January, 1, 0, 0, 0, 0, time.UTC)
+	mockRefreshToken := session.MockRefreshToken(session.DAO{UserID: 1, Token: "new token", ExpiresAt: mockExpiresAt}, nil)
+
+	refreshSession := auth.MakeRefreshSession(mockRefreshToken)
+
+	token, expiresAt, err := refreshSession(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "new token", token)
+	assert.Equal(t, mockExpiresAt, expiresAt)
+}
+
+func TestRefreshSession_failsWhenRefreshTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.ExpiredSessionToken, expected: auth.InvalidSessionToken},
+		{err: session.NoSessionFoundForTheGivenToken, expected: auth.InvalidSessionToken},
+		{err: session.FailedToRefreshUserSessionToken, expected: auth.FailedToRefreshUserSession},
+	}
+
+	for _, tt := range tests {
+		mockRefreshToken := session.MockRefreshToken(session.DAO{}, tt.err)
+
+		refreshSession := auth.MakeRefreshSession(mockRefreshToken)
+
+		want := tt.expected
+		_, _, got := refreshSession(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
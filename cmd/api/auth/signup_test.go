@@ -8,17 +8,21 @@ import (
 	"github.com/stretchr/testify/assert"
 
 	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/invites"
 	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/password"
 )
 
 func TestSignUp_success(t *testing.T) {
 	mockUserExists := user.MockExists(false, nil)
 	mockInsertUser := user.MockInsert(nil)
+	mockRedeemInvite := invites.MockRedeem(nil)
+	mockValidatePassword := password.MockValidate(nil)
 	mockUserDTO := user.MockDTO()
 
-	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser)
+	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, false)
 
-	got := signUp(context.Background(), mockUserDTO)
+	got := signUp(context.Background(), mockUserDTO, "")
 
 	assert.Nil(t, got)
 }
@@ -26,12 +30,14 @@ func TestSignUp_success(t *testing.T) {
 func TestSignUp_failsWhenUserExistsThrowsError(t *testing.T) {
 	mockUserExists := user.MockExists(false, errors.New("failed to execute Exists"))
 	mockInsertUser := user.MockInsert(nil)
+	mockRedeemInvite := invites.MockRedeem(nil)
+	mockValidatePassword := password.MockValidate(nil)
 	mockUserDTO := user.MockDTO()
 
-	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser)
+	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, false)
 
 	want := auth.FailedToRetrieveIfTheUserExists
-	got := signUp(context.Background(), mockUserDTO)
+	got := signUp(context.Background(), mockUserDTO, "")
 
 	assert.Equal(t, want, got)
 }
@@ -39,12 +45,14 @@ func TestSignUp_failsWhenUserExistsThrowsError(t *testing.T) {
 func TestSignUp_failsWhenUserAlreadyExists(t *testing.T) {
 	mockUserExists := user.MockExists(true, nil)
 	mockInsertUser := user.MockInsert(nil)
+	mockRedeemInvite := invites.MockRedeem(nil)
+	mockValidatePassword := password.MockValidate(nil)
 	mockUserDTO := user.MockDTO()
 
-	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser)
+	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, false)
 
 	want := auth.FailedToSignUpBecauseTheUserAlreadyExists
-	got := signUp(context.Background(), mockUserDTO)
+	got := signUp(context.Background(), mockUserDTO, "")
 
 	assert.Equal(t, want, got)
 }
@@ -52,13 +60,15 @@ func TestSignUp_failsWhenUserAlreadyExists(t *testing.T) {
 func TestSignUp_failsWhenGenerateFromPasswordThrowsError(t *testing.T) {
 	mockUserExists := user.MockExists(false, nil)
 	mockInsertUser := user.MockInsert(nil)
+	mockRedeemInvite := invites.MockRedeem(nil)
+	mockValidatePassword := password.MockValidate(nil)
 	mockUserDTO := user.MockDTO()
 	mockUserDTO.Password = "verylongpassword1234567890123456789012345678901234567890123456789012345678901234567890123456789012345678901234567890"
 
-	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser)
+	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, false)
 
 	want := auth.FailedToGenerateHashFromPassword
-	got := signUp(context.Background(), mockUserDTO)
+	got := signUp(context.Background(), mockUserDTO, "")
 
 	assert.Equal(t, want, got)
 }
@@ -66,12 +76,97 @@ func TestSignUp_failsWhenGenerateFromPasswordThrowsError(t *testing.T) {
 func TestSignUp_failsWhenInsertThrowsError(t *testing.T) {
 	mockUserExists := user.MockExists(false, nil)
 	mockInsertUser := user.MockInsert(errors.New("failed to insert user"))
+	mockRedeemInvite := invites.MockRedeem(nil)
+	mockValidatePassword := password.MockValidate(nil)
 	mockUserDTO := user.MockDTO()
 
-	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser)
+	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, false)
 
 	want := auth.FailedToInsertUserIntoDatabase
-	got := signUp(context.Background(), mockUserDTO)
+	got := signUp(context.Background(), mockUserDTO, "")
 
 	assert.Equal(t, want, got)
 }
+
+func TestSignUp_failsWhenThePasswordDoesNotMeetThePolicy(t *testing.T) {
+	mockUserExists := user.MockExists(false, nil)
+	mockInsertUser := user.MockInsert(nil)
+	mockRedeemInvite := invites.MockRedeem(nil)
+	mockValidatePassword := password.MockValidate(password.PasswordTooShort)
+	mockUserDTO := user.MockDTO()
+
+	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, false)
+
+	want := password.PasswordTooShort
+	got := signUp(context.Background(), mockUserDTO, "")
+
+	assert.Equal(t, want, got)
+}
+
+func TestSignUp_successWithInviteCode(t *testing.T) {
+	tests := []struct {
+		inviteOnly bool
+	}{
+		{inviteOnly: true},
+		{inviteOnly: false},
+	}
+
+	for _, tt := range tests {
+		var redeemedCode string
+		mockUserExists := user.MockExists(false, nil)
+		mockInsertUser := user.MockInsert(errors.New("the user must be inserted by the invite redemption"))
+		mockRedeemInvite := func(ctx context.Context, code string, user user.DTO) error {
+			redeemedCode = code
+			return nil
+		}
+		mockValidatePassword := password.MockValidate(nil)
+		mockUserDTO := user.MockDTO()
+
+		signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, tt.inviteOnly)
+
+		got := signUp(context.Background(), mockUserDTO, "invite code")
+
+		assert.Nil(t, got)
+		assert.Equal(t, "invite code", redeemedCode)
+	}
+}
+
+func TestSignUp_failsWhenTheInviteCodeIsMissingAndTheSignUpIsInviteOnly(t *testing.T) {
+	mockUserExists := user.MockExists(false, nil)
+	mockInsertUser := user.MockInsert(nil)
+	mockRedeemInvite := invites.MockRedeem(nil)
+	mockValidatePassword := password.MockValidate(nil)
+	mockUserDTO := user.MockDTO()
+
+	signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, true)
+
+	want := auth.InviteCodeIsRequired
+	got := signUp(context.Background(), mockUserDTO, "")
+
+	assert.Equal(t, want, got)
+}
+
+func TestSignUp_failsWhenRedeemInviteThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: invites.NoValidInviteFound, expected: auth.InvalidInviteCode},
+		{err: invites.FailedToRedeemInvite, expected: auth.FailedToRedeemInviteCode},
+	}
+
+	for _, tt := range tests {
+		mockUserExists := user.MockExists(false, nil)
+		mockInsertUser := user.MockInsert(nil)
+		mockRedeemInvite := invites.MockRedeem(tt.err)
+		mockValidatePassword := password.MockValidate(nil)
+		mockUserDTO := user.MockDTO()
+
+		signUp := auth.MakeSignUp(mockUserExists, mockInsertUser, mockValidatePassword, mockRedeemInvite, true)
+
+		want := tt.expected
+		got := signUp(context.Background(), mockUserDTO, "invite code")
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -2,19 +2,35 @@ package auth
 
 import (
 	"context"
+	"errors"
 	
 	"golang.org/x/crypto/bcrypt"
 
+	"ahbcc/cmd/api/auth/invites"
 	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/password"
 	"ahbcc/internal/log"
 )
 
-// SignUp registers a new user in the system
-type SignUp func(ctx context.Context, user user.DTO) error
+// SignUp registers a new user in the system. The password must meet the password policy.
+// If an invite code is given, the user gets the role of its invite, and a use of the invite is consumed. When the sign
+// up is invite-only, the invite code is required
+type SignUp func(ctx context.Context, user user.DTO, inviteCode string) error
 
 // MakeSignUp creates a new SignUp
-func MakeSignUp(userExists user.Exists, insertUser user.Insert) SignUp {
-	return func(ctx context.Context, user user.DTO) error {
+func MakeSignUp(userExists user.Exists, insertUser user.Insert, validatePassword password.Validate, redeemInvite invites.Redeem, inviteOnly bool) SignUp {
+	return func(ctx context.Context, user user.DTO, inviteCode string) error {
+		if inviteOnly && inviteCode == "" {
+			log.Error(ctx, InviteCodeIsRequired.Error())
+			return InviteCodeIsRequired
+		}
+
+		err := validatePassword(user.Username, user.Password)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return err
+		}
+
 		exists, err := userExists(ctx, user.Username)
 		if err != nil {
 			log.Error(ctx, err.Error())
@@ -34,6 +50,19 @@ func MakeSignUp(userExists user.Exists, insertUser user.Insert) SignUp {
 
 		user.Password = string(hash)
 
+		if inviteCode != "" {
+			err = redeemInvite(ctx, inviteCode, user)
+			if errors.Is(err, invites.NoValidInviteFound) {
+				log.Error(ctx, err.Error())
+				return InvalidInviteCode
+			} else if err != nil {
+				log.Error(ctx, err.Error())
+				return FailedToRedeemInviteCode
+			}
+
+			return nil
+		}
+
 		err = insertUser(ctx, user)
 		if err != nil {
 			log.Error(ctx, err.Error())
@@ -0,0 +1,76 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/cmd/api/middleware"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/internal/log"
+)
+
+// OIDCLogIn logs in the user authenticated by the OpenID Connect provider. It finishes the login against the provider
+// with the authorization code and the state it redirected the user back with, resolves the user of the identity,
+// linking or creating it when the configuration allows it, and creates the user session, returning it with its
+// expiration time. As in LogIn, the user is identified as the actor of the request in the audit log, and when the user
+// has the two-factor authentication enabled SecondFactorRequired is returned with a login challenge instead
+type OIDCLogIn func(ctx context.Context, code string, state string) (string, time.Time, error)
+
+// MakeOIDCLogIn creates a new OIDCLogIn
+func MakeOIDCLogIn(authenticate oidc.Authenticate, resolveUser oidc.ResolveUser, deleteExpiredUserSessions session.DeleteExpiredSessions, createSessionToken session.CreateToken, isTwoFactorEnabled totp.IsEnabled, createLoginChallenge totp.CreateChallenge) OIDCLogIn {
+	return func(ctx context.Context, code string, state string) (string, time.Time, error) {
+		identity, err := authenticate(ctx, code, state)
+		if errors.Is(err, oidc.NoValidStateFound) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, InvalidOIDCState
+		} else if oidc.IsAuthenticationFailure(err) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, OIDCAuthenticationFailed
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToAuthenticateWithOIDCProvider
+		}
+		ctx = log.With(ctx, log.Param("username", identity.Username))
+
+		userID, err := resolveUser(ctx, identity)
+		if errors.Is(err, oidc.UserNotProvisioned) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, OIDCUserNotProvisioned
+		} else if errors.Is(err, oidc.UsernameTaken) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, OIDCUsernameTaken
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToResolveOIDCUser
+		}
+
+		challenge, challengeExpiresAt, err := secondFactorChallenge(ctx, isTwoFactorEnabled, createLoginChallenge, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, err
+		} else if challenge != "" {
+			return challenge, challengeExpiresAt, SecondFactorRequired
+		}
+
+		err = deleteExpiredUserSessions(ctx, userID)
+		if err != nil {
+			log.Warn(ctx, err.Error())
+			// We don't want abort login due this cleanup
+		}
+
+		token, expiresAt, err := createSessionToken(ctx, userID)
+		if errors.Is(err, session.UserDisabled) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, AccountDisabled
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCreateUserSession
+		}
+		middleware.Identify(ctx, userID)
+
+		return token, expiresAt, nil
+	}
+}
@@ -0,0 +1,150 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestOIDCLogIn_success(t *testing.T) {
+	mockAuthenticate := oidc.MockAuthenticate(oidc.MockIdentityDTO(), nil)
+	mockResolveUser := oidc.MockResolveUser(5, nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(errors.New("failed to delete expired sessions"))
+	mockExpiresAt := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+	mockCreateToken := session.MockCreateToken("token", mockExpiresAt, nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+	oidcLogIn := auth.MakeOIDCLogIn(mockAuthenticate, mockResolveUser, mockDeleteExpiredSessions, mockCreateToken, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+	token, expiresAt, err := oidcLogIn(context.Background(), "code", "state")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, mockExpiresAt, expiresAt)
+}
+
+func TestOIDCLogIn_failsWhenAuthenticateThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: oidc.NoValidStateFound, expected: auth.InvalidOIDCState},
+		{err: oidc.InvalidIDTokenSignature, expected: auth.OIDCAuthenticationFailed},
+		{err: oidc.FailedToExchangeCode, expected: auth.OIDCAuthenticationFailed},
+		{err: oidc.EmailNotVerified, expected: auth.OIDCAuthenticationFailed},
+		{err: oidc.FailedToRetrieveSigningKeys, expected: auth.FailedToAuthenticateWithOIDCProvider},
+		{err: oidc.FailedToConsumeState, expected: auth.FailedToAuthenticateWithOIDCProvider},
+	}
+
+	for _, tt := range tests {
+		mockAuthenticate := oidc.MockAuthenticate(oidc.IdentityDTO{}, tt.err)
+		mockResolveUser := oidc.MockResolveUser(5, nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateToken := session.MockCreateToken("token", time.Now(), nil)
+		mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+		mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+		oidcLogIn := auth.MakeOIDCLogIn(mockAuthenticate, mockResolveUser, mockDeleteExpiredSessions, mockCreateToken, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+		want := tt.expected
+		_, _, got := oidcLogIn(context.Background(), "code", "state")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestOIDCLogIn_failsWhenResolveUserThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: oidc.UserNotProvisioned, expected: auth.OIDCUserNotProvisioned},
+		{err: oidc.UsernameTaken, expected: auth.OIDCUsernameTaken},
+		{err: oidc.FailedToResolveUser, expected: auth.FailedToResolveOIDCUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthenticate := oidc.MockAuthenticate(oidc.MockIdentityDTO(), nil)
+		mockResolveUser := oidc.MockResolveUser(0, tt.err)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateToken := session.MockCreateToken("token", time.Now(), nil)
+		mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+		mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+		oidcLogIn := auth.MakeOIDCLogIn(mockAuthenticate, mockResolveUser, mockDeleteExpiredSessions, mockCreateToken, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+		want := tt.expected
+		_, _, got := oidcLogIn(context.Background(), "code", "state")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestOIDCLogIn_failsWhenCreateTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.UserDisabled, expected: auth.AccountDisabled},
+		{err: session.FailedToCreatUserSessionToken, expected: auth.FailedToCreateUserSession},
+	}
+
+	for _, tt := range tests {
+		mockAuthenticate := oidc.MockAuthenticate(oidc.MockIdentityDTO(), nil)
+		mockResolveUser := oidc.MockResolveUser(5, nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateToken := session.MockCreateToken("", time.Time{}, tt.err)
+		mockIsTwoFactorEnabled := totp.MockIsEnabled(false, nil)
+		mockCreateLoginChallenge := totp.MockCreateChallenge("", time.Time{}, nil)
+
+		oidcLogIn := auth.MakeOIDCLogIn(mockAuthenticate, mockResolveUser, mockDeleteExpiredSessions, mockCreateToken, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+		want := tt.expected
+		_, _, got := oidcLogIn(context.Background(), "code", "state")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestOIDCLogIn_returnsALoginChallengeWhenTheUserHasTwoFactorEnabled(t *testing.T) {
+	mockAuthenticate := oidc.MockAuthenticate(oidc.MockIdentityDTO(), nil)
+	mockResolveUser := oidc.MockResolveUser(5, nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+	mockCreateToken := session.MockCreateToken("", time.Time{}, errors.New("should not be called"))
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(true, nil)
+	mockChallengeExpiresAt := time.Date(2025, time.March, 8, 0, 5, 0, 0, time.UTC)
+	mockCreateLoginChallenge := totp.MockCreateChallenge("challenge", mockChallengeExpiresAt, nil)
+
+	oidcLogIn := auth.MakeOIDCLogIn(mockAuthenticate, mockResolveUser, mockDeleteExpiredSessions, mockCreateToken, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+	challenge, expiresAt, err := oidcLogIn(context.Background(), "code", "state")
+
+	assert.Equal(t, auth.SecondFactorRequired, err)
+	assert.Equal(t, "challenge", challenge)
+	assert.Equal(t, mockChallengeExpiresAt, expiresAt)
+}
+
+func TestOIDCLogIn_failsWhenIsTwoFactorEnabledThrowsError(t *testing.T) {
+	mockAuthenticate := oidc.MockAuthenticate(oidc.MockIdentityDTO(), nil)
+	mockResolveUser := oidc.MockResolveUser(5, nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+	mockCreateToken := session.MockCreateToken("token", time.Now(), nil)
+	mockIsTwoFactorEnabled := totp.MockIsEnabled(false, errors.New("failed to check if two-factor is enabled"))
+	mockCreateLoginChallenge := totp.MockCreateChallenge("challenge", time.Now(), nil)
+
+	oidcLogIn := auth.MakeOIDCLogIn(mockAuthenticate, mockResolveUser, mockDeleteExpiredSessions, mockCreateToken, mockIsTwoFactorEnabled, mockCreateLoginChallenge)
+
+	want := auth.FailedToCheckTwoFactor
+	_, _, got := oidcLogIn(context.Background(), "code", "state")
+
+	assert.Equal(t, want, got)
+}
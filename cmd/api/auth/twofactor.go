@@ -0,0 +1,232 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"time"
+
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/cmd/api/middleware"
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/internal/log"
+)
+
+type (
+	// CompleteLogIn completes the second step of a login, verifying the given code of the second factor of the user of
+	// the login challenge, either a TOTP code or a recovery code. Each challenge accepts a limited amount of attempts,
+	// and each one is also subject to the login attempts limits of the username and of the IP it is made from: a wrong
+	// code is recorded as a failed login attempt, and TooManyLoginAttempts or AccountLocked is returned with the time
+	// from which the login can be retried when it isn't allowed.
+	// It creates the user session and returns it with its expiration time, recording the login attempt as succeeded and
+	// identifying the user as the actor of the request in the audit log
+	CompleteLogIn func(ctx context.Context, challenge string, code string, ip string) (string, time.Time, error)
+
+	// EnrollTwoFactor creates a new TOTP secret for the user of the given session token, returning it with its
+	// provisioning URI. It isn't enabled until it is confirmed
+	EnrollTwoFactor func(ctx context.Context, token string) (totp.EnrollmentDTO, error)
+
+	// ConfirmTwoFactor enables the enrolled second factor of the user of the given session token with a code of it,
+	// revoking the other sessions of the user, and returns the recovery codes of the user
+	ConfirmTwoFactor func(ctx context.Context, token string, code string) ([]string, error)
+
+	// DisableTwoFactor disables the second factor of the user of the given session token, which must confirm it with a
+	// code of it. The users whose role requires the two-factor authentication can't disable it
+	DisableTwoFactor func(ctx context.Context, token string, code string) error
+)
+
+// MakeCompleteLogIn creates a new CompleteLogIn
+func MakeCompleteLogIn(useLoginChallenge totp.UseChallenge, selectUserByID user.SelectByID, checkLoginAttempts attempts.Check, recordLoginAttempt attempts.Record, verifySecondFactor totp.Verify, deleteLoginChallenge totp.DeleteChallenge, deleteExpiredUserSessions session.DeleteExpiredSessions, createSessionToken session.CreateToken, maxAttempts int) CompleteLogIn {
+	return func(ctx context.Context, challenge string, code string, ip string) (string, time.Time, error) {
+		userID, err := useLoginChallenge(ctx, challenge, maxAttempts)
+		if errors.Is(err, totp.NoValidChallengeFound) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, InvalidLoginChallenge
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCompleteLogIn
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		userDAO, err := selectUserByID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToSelectUserByID
+		}
+
+		retryAt, err := checkAttempts(ctx, checkLoginAttempts, recordLoginAttempt, userDAO.Username, ip)
+		if err != nil {
+			return "", retryAt, err
+		}
+
+		err = verifySecondFactor(ctx, userID, code)
+		if errors.Is(err, totp.WrongCode) {
+			log.Error(ctx, err.Error())
+			recordAttempt(ctx, recordLoginAttempt, userDAO.Username, ip, attempts.OutcomeFailed)
+			return "", time.Time{}, WrongSecondFactorCode
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCompleteLogIn
+		}
+
+		err = deleteLoginChallenge(ctx, challenge)
+		if err != nil {
+			log.Warn(ctx, err.Error())
+			// The challenge expires anyway, we don't want abort login due this cleanup
+		}
+
+		err = deleteExpiredUserSessions(ctx, userID)
+		if err != nil {
+			log.Warn(ctx, err.Error())
+			// We don't want abort login due this cleanup
+		}
+
+		token, expiresAt, err := createSessionToken(ctx, userID)
+		if errors.Is(err, session.UserDisabled) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, AccountDisabled
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCreateUserSession
+		}
+
+		recordAttempt(ctx, recordLoginAttempt, userDAO.Username, ip, attempts.OutcomeSucceeded)
+		middleware.Identify(ctx, userID)
+
+		return token, expiresAt, nil
+	}
+}
+
+// MakeEnrollTwoFactor creates a new EnrollTwoFactor
+func MakeEnrollTwoFactor(selectUserIDByToken session.SelectUserIDByToken, selectUserByID user.SelectByID, enroll totp.Enroll) EnrollTwoFactor {
+	return func(ctx context.Context, token string) (totp.EnrollmentDTO, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return totp.EnrollmentDTO{}, sessionTokenError(err)
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		userDAO, err := selectUserByID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return totp.EnrollmentDTO{}, FailedToSelectUserByID
+		}
+
+		enrollment, err := enroll(ctx, userID, userDAO.Username)
+		if errors.Is(err, totp.TwoFactorAlreadyEnabled) {
+			log.Error(ctx, err.Error())
+			return totp.EnrollmentDTO{}, TwoFactorAlreadyEnabled
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return totp.EnrollmentDTO{}, FailedToEnrollTwoFactor
+		}
+
+		return enrollment, nil
+	}
+}
+
+// MakeConfirmTwoFactor creates a new ConfirmTwoFactor
+func MakeConfirmTwoFactor(selectUserIDByToken session.SelectUserIDByToken, confirm totp.Confirm, deleteOtherSessions session.DeleteOthers) ConfirmTwoFactor {
+	return func(ctx context.Context, token string, code string) ([]string, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, sessionTokenError(err)
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		recoveryCodes, err := confirm(ctx, userID, code)
+		if errors.Is(err, totp.TwoFactorNotEnrolled) {
+			log.Error(ctx, err.Error())
+			return nil, TwoFactorNotEnrolled
+		} else if errors.Is(err, totp.TwoFactorAlreadyEnabled) {
+			log.Error(ctx, err.Error())
+			return nil, TwoFactorAlreadyEnabled
+		} else if errors.Is(err, totp.WrongCode) {
+			log.Error(ctx, err.Error())
+			return nil, WrongSecondFactorCode
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToConfirmTwoFactor
+		}
+
+		_, err = deleteOtherSessions(ctx, userID, token)
+		if err != nil {
+			log.Warn(ctx, err.Error())
+			// The second factor is already enabled, so its recovery codes must be returned anyway
+		}
+
+		return recoveryCodes, nil
+	}
+}
+
+// MakeDisableTwoFactor creates a new DisableTwoFactor
+func MakeDisableTwoFactor(selectUserIDByToken session.SelectUserIDByToken, selectUserByID user.SelectByID, verifySecondFactor totp.Verify, deleteSecondFactor totp.Delete, requiredRoles []string) DisableTwoFactor {
+	return func(ctx context.Context, token string, code string) error {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return sessionTokenError(err)
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		userDAO, err := selectUserByID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToSelectUserByID
+		}
+
+		if slices.Contains(requiredRoles, userDAO.Role) {
+			log.Error(ctx, TwoFactorRequiredForRole.Error())
+			return TwoFactorRequiredForRole
+		}
+
+		err = verifySecondFactor(ctx, userID, code)
+		if totp.IsNotEnrolled(err) {
+			log.Error(ctx, err.Error())
+			return TwoFactorNotEnabled
+		} else if errors.Is(err, totp.WrongCode) {
+			log.Error(ctx, err.Error())
+			return WrongSecondFactorCode
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDisableTwoFactor
+		}
+
+		err = deleteSecondFactor(ctx, userID)
+		if errors.Is(err, totp.TwoFactorNotEnabled) {
+			log.Error(ctx, err.Error())
+			return TwoFactorNotEnabled
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDisableTwoFactor
+		}
+
+		return nil
+	}
+}
+
+// secondFactorChallenge creates a login challenge for the user when it has the two-factor authentication enabled. It
+// returns an empty challenge when it hasn't
+func secondFactorChallenge(ctx context.Context, isTwoFactorEnabled totp.IsEnabled, createLoginChallenge totp.CreateChallenge, userID int) (string, time.Time, error) {
+	enabled, err := isTwoFactorEnabled(ctx, userID)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return "", time.Time{}, FailedToCheckTwoFactor
+	}
+
+	if !enabled {
+		return "", time.Time{}, nil
+	}
+
+	challenge, expiresAt, err := createLoginChallenge(ctx, userID)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return "", time.Time{}, FailedToCreateLoginChallenge
+	}
+
+	return challenge, expiresAt, nil
+}
@@ -0,0 +1,422 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestCompleteLogIn_success(t *testing.T) {
+	mockUseLoginChallenge := totp.MockUseChallenge(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	var recorded []attempts.DAO
+	mockRecordLoginAttempt := func(ctx context.Context, attempt attempts.DAO) error {
+		recorded = append(recorded, attempt)
+		return nil
+	}
+	mockVerifySecondFactor := totp.MockVerify(nil)
+	mockDeleteLoginChallenge := totp.MockDeleteChallenge(errors.New("failed to delete login challenge"))
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(errors.New("failed to delete expired sessions"))
+	mockExpiresAt := time.Date(2025, time.March, 8, 0, 0, 0, 0, time.UTC)
+	mockCreateSessionToken := session.MockCreateToken("token", mockExpiresAt, nil)
+
+	completeLogIn := auth.MakeCompleteLogIn(mockUseLoginChallenge, mockSelectUserByID, mockCheckLoginAttempts, mockRecordLoginAttempt, mockVerifySecondFactor, mockDeleteLoginChallenge, mockDeleteExpiredSessions, mockCreateSessionToken, 5)
+
+	token, expiresAt, err := completeLogIn(context.Background(), "challenge", "123456", "127.0.0.1")
+
+	assert.Nil(t, err)
+	assert.Equal(t, "token", token)
+	assert.Equal(t, mockExpiresAt, expiresAt)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, user.MockDAO().Username, recorded[0].Username)
+	assert.Equal(t, "127.0.0.1", recorded[0].IP)
+	assert.Equal(t, attempts.OutcomeSucceeded, recorded[0].Outcome)
+}
+
+func TestCompleteLogIn_failsRecordingAFailedAttemptWhenTheCodeIsWrong(t *testing.T) {
+	mockUseLoginChallenge := totp.MockUseChallenge(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	var recorded []attempts.DAO
+	mockRecordLoginAttempt := func(ctx context.Context, attempt attempts.DAO) error {
+		recorded = append(recorded, attempt)
+		return nil
+	}
+	mockVerifySecondFactor := totp.MockVerify(totp.WrongCode)
+	mockDeleteLoginChallenge := totp.MockDeleteChallenge(nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+	mockCreateSessionToken := session.MockCreateToken("", time.Time{}, errors.New("should not be called"))
+
+	completeLogIn := auth.MakeCompleteLogIn(mockUseLoginChallenge, mockSelectUserByID, mockCheckLoginAttempts, mockRecordLoginAttempt, mockVerifySecondFactor, mockDeleteLoginChallenge, mockDeleteExpiredSessions, mockCreateSessionToken, 5)
+
+	want := auth.WrongSecondFactorCode
+	_, _, got := completeLogIn(context.Background(), "challenge", "000000", "127.0.0.1")
+
+	assert.Equal(t, want, got)
+	assert.Len(t, recorded, 1)
+	assert.Equal(t, user.MockDAO().Username, recorded[0].Username)
+	assert.Equal(t, attempts.OutcomeFailed, recorded[0].Outcome)
+}
+
+func TestCompleteLogIn_failsWhenTheLoginAttemptIsNotAllowed(t *testing.T) {
+	retryAt := time.Date(2025, time.March, 8, 0, 15, 0, 0, time.UTC)
+	tests := []struct {
+		err             error
+		expected        error
+		expectedRetryAt time.Time
+		expectedOutcome string
+	}{
+		{err: attempts.AccountLocked, expected: auth.AccountLocked, expectedRetryAt: retryAt, expectedOutcome: attempts.OutcomeLocked},
+		{err: attempts.TooManyLoginAttempts, expected: auth.TooManyLoginAttempts, expectedRetryAt: retryAt, expectedOutcome: attempts.OutcomeThrottled},
+		{err: attempts.FailedToCheckLoginAttempts, expected: auth.FailedToCheckLoginAttempts},
+	}
+
+	for _, tt := range tests {
+		mockUseLoginChallenge := totp.MockUseChallenge(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockCheckLoginAttempts := attempts.MockCheck(retryAt, tt.err)
+		var recorded []attempts.DAO
+		mockRecordLoginAttempt := func(ctx context.Context, attempt attempts.DAO) error {
+			recorded = append(recorded, attempt)
+			return nil
+		}
+		verified := false
+		mockVerifySecondFactor := func(ctx context.Context, userID int, code string) error {
+			verified = true
+			return nil
+		}
+		mockDeleteLoginChallenge := totp.MockDeleteChallenge(nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateSessionToken := session.MockCreateToken("token", time.Now(), nil)
+
+		completeLogIn := auth.MakeCompleteLogIn(mockUseLoginChallenge, mockSelectUserByID, mockCheckLoginAttempts, mockRecordLoginAttempt, mockVerifySecondFactor, mockDeleteLoginChallenge, mockDeleteExpiredSessions, mockCreateSessionToken, 5)
+
+		_, gotRetryAt, got := completeLogIn(context.Background(), "challenge", "123456", "127.0.0.1")
+
+		assert.Equal(t, tt.expected, got)
+		assert.Equal(t, tt.expectedRetryAt, gotRetryAt)
+		assert.False(t, verified)
+		if tt.expectedOutcome != "" {
+			assert.Len(t, recorded, 1)
+			assert.Equal(t, tt.expectedOutcome, recorded[0].Outcome)
+		} else {
+			assert.Empty(t, recorded)
+		}
+	}
+}
+
+func TestCompleteLogIn_failsWhenSelectUserByIDThrowsError(t *testing.T) {
+	mockUseLoginChallenge := totp.MockUseChallenge(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.DAO{}, errors.New("failed to select user by id"))
+	mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+	mockRecordLoginAttempt := attempts.MockRecord(nil)
+	mockVerifySecondFactor := totp.MockVerify(nil)
+	mockDeleteLoginChallenge := totp.MockDeleteChallenge(nil)
+	mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+	mockCreateSessionToken := session.MockCreateToken("token", time.Now(), nil)
+
+	completeLogIn := auth.MakeCompleteLogIn(mockUseLoginChallenge, mockSelectUserByID, mockCheckLoginAttempts, mockRecordLoginAttempt, mockVerifySecondFactor, mockDeleteLoginChallenge, mockDeleteExpiredSessions, mockCreateSessionToken, 5)
+
+	want := auth.FailedToSelectUserByID
+	_, _, got := completeLogIn(context.Background(), "challenge", "123456", "127.0.0.1")
+
+	assert.Equal(t, want, got)
+}
+
+func TestCompleteLogIn_failsWhenUseLoginChallengeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: totp.NoValidChallengeFound, expected: auth.InvalidLoginChallenge},
+		{err: totp.FailedToUseChallenge, expected: auth.FailedToCompleteLogIn},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+		mockRecordLoginAttempt := attempts.MockRecord(nil)
+		mockUseLoginChallenge := totp.MockUseChallenge(-1, tt.err)
+		mockVerifySecondFactor := totp.MockVerify(nil)
+		mockDeleteLoginChallenge := totp.MockDeleteChallenge(nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateSessionToken := session.MockCreateToken("token", time.Now(), nil)
+
+		completeLogIn := auth.MakeCompleteLogIn(mockUseLoginChallenge, mockSelectUserByID, mockCheckLoginAttempts, mockRecordLoginAttempt, mockVerifySecondFactor, mockDeleteLoginChallenge, mockDeleteExpiredSessions, mockCreateSessionToken, 5)
+
+		want := tt.expected
+		_, _, got := completeLogIn(context.Background(), "challenge", "123456", "127.0.0.1")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCompleteLogIn_failsWhenVerifySecondFactorThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: totp.WrongCode, expected: auth.WrongSecondFactorCode},
+		{err: totp.FailedToExecuteSelectByUserID, expected: auth.FailedToCompleteLogIn},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+		mockRecordLoginAttempt := attempts.MockRecord(nil)
+		mockUseLoginChallenge := totp.MockUseChallenge(1, nil)
+		mockVerifySecondFactor := totp.MockVerify(tt.err)
+		mockDeleteLoginChallenge := totp.MockDeleteChallenge(nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateSessionToken := session.MockCreateToken("token", time.Now(), nil)
+
+		completeLogIn := auth.MakeCompleteLogIn(mockUseLoginChallenge, mockSelectUserByID, mockCheckLoginAttempts, mockRecordLoginAttempt, mockVerifySecondFactor, mockDeleteLoginChallenge, mockDeleteExpiredSessions, mockCreateSessionToken, 5)
+
+		want := tt.expected
+		_, _, got := completeLogIn(context.Background(), "challenge", "123456", "127.0.0.1")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCompleteLogIn_failsWhenCreateSessionTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.UserDisabled, expected: auth.AccountDisabled},
+		{err: session.FailedToCreatUserSessionToken, expected: auth.FailedToCreateUserSession},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockCheckLoginAttempts := attempts.MockCheck(time.Time{}, nil)
+		mockRecordLoginAttempt := attempts.MockRecord(nil)
+		mockUseLoginChallenge := totp.MockUseChallenge(1, nil)
+		mockVerifySecondFactor := totp.MockVerify(nil)
+		mockDeleteLoginChallenge := totp.MockDeleteChallenge(nil)
+		mockDeleteExpiredSessions := session.MockDeleteExpiredSessions(nil)
+		mockCreateSessionToken := session.MockCreateToken("", time.Time{}, tt.err)
+
+		completeLogIn := auth.MakeCompleteLogIn(mockUseLoginChallenge, mockSelectUserByID, mockCheckLoginAttempts, mockRecordLoginAttempt, mockVerifySecondFactor, mockDeleteLoginChallenge, mockDeleteExpiredSessions, mockCreateSessionToken, 5)
+
+		want := tt.expected
+		_, _, got := completeLogIn(context.Background(), "challenge", "123456", "127.0.0.1")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestEnrollTwoFactor_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockEnroll := totp.MockEnroll(totp.MockEnrollmentDTO(), nil)
+
+	enrollTwoFactor := auth.MakeEnrollTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockEnroll)
+
+	want := totp.MockEnrollmentDTO()
+	got, err := enrollTwoFactor(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestEnrollTwoFactor_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.NoUserIDFoundForTheGivenToken, expected: auth.InvalidSessionToken},
+		{err: session.FailedToExecuteQueryToRetrieveUserID, expected: auth.FailedToRetrieveUserID},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(-1, tt.err)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockEnroll := totp.MockEnroll(totp.MockEnrollmentDTO(), nil)
+
+		enrollTwoFactor := auth.MakeEnrollTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockEnroll)
+
+		want := tt.expected
+		_, got := enrollTwoFactor(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestEnrollTwoFactor_failsWhenSelectUserByIDThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.DAO{}, errors.New("failed to select user by id"))
+	mockEnroll := totp.MockEnroll(totp.MockEnrollmentDTO(), nil)
+
+	enrollTwoFactor := auth.MakeEnrollTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockEnroll)
+
+	want := auth.FailedToSelectUserByID
+	_, got := enrollTwoFactor(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnrollTwoFactor_failsWhenEnrollThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: totp.TwoFactorAlreadyEnabled, expected: auth.TwoFactorAlreadyEnabled},
+		{err: totp.FailedToUpsertSecret, expected: auth.FailedToEnrollTwoFactor},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockEnroll := totp.MockEnroll(totp.EnrollmentDTO{}, tt.err)
+
+		enrollTwoFactor := auth.MakeEnrollTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockEnroll)
+
+		want := tt.expected
+		_, got := enrollTwoFactor(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestConfirmTwoFactor_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockConfirm := totp.MockConfirm(totp.MockRecoveryCodes(), nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(0, errors.New("failed to delete other sessions"))
+
+	confirmTwoFactor := auth.MakeConfirmTwoFactor(mockSelectUserIDByToken, mockConfirm, mockDeleteOtherSessions)
+
+	want := totp.MockRecoveryCodes()
+	got, err := confirmTwoFactor(context.Background(), "token", "123456")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestConfirmTwoFactor_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(-1, session.NoUserIDFoundForTheGivenToken)
+	mockConfirm := totp.MockConfirm(totp.MockRecoveryCodes(), nil)
+	mockDeleteOtherSessions := session.MockDeleteOthers(0, nil)
+
+	confirmTwoFactor := auth.MakeConfirmTwoFactor(mockSelectUserIDByToken, mockConfirm, mockDeleteOtherSessions)
+
+	want := auth.InvalidSessionToken
+	_, got := confirmTwoFactor(context.Background(), "token", "123456")
+
+	assert.Equal(t, want, got)
+}
+
+func TestConfirmTwoFactor_failsWhenConfirmThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: totp.TwoFactorNotEnrolled, expected: auth.TwoFactorNotEnrolled},
+		{err: totp.TwoFactorAlreadyEnabled, expected: auth.TwoFactorAlreadyEnabled},
+		{err: totp.WrongCode, expected: auth.WrongSecondFactorCode},
+		{err: totp.FailedToEnableTwoFactor, expected: auth.FailedToConfirmTwoFactor},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+		mockConfirm := totp.MockConfirm(nil, tt.err)
+		mockDeleteOtherSessions := session.MockDeleteOthers(0, nil)
+
+		confirmTwoFactor := auth.MakeConfirmTwoFactor(mockSelectUserIDByToken, mockConfirm, mockDeleteOtherSessions)
+
+		want := tt.expected
+		_, got := confirmTwoFactor(context.Background(), "token", "123456")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestDisableTwoFactor_success(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockVerifySecondFactor := totp.MockVerify(nil)
+	mockDeleteSecondFactor := totp.MockDelete(nil)
+
+	disableTwoFactor := auth.MakeDisableTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockVerifySecondFactor, mockDeleteSecondFactor, []string{user.AdminRole})
+
+	got := disableTwoFactor(context.Background(), "token", "123456")
+
+	assert.Nil(t, got)
+}
+
+func TestDisableTwoFactor_failsWhenTheRoleOfTheUserRequiresTwoFactor(t *testing.T) {
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockUserDAO := user.MockDAO()
+	mockUserDAO.Role = user.AdminRole
+	mockSelectUserByID := user.MockSelectByID(mockUserDAO, nil)
+	mockVerifySecondFactor := totp.MockVerify(nil)
+	mockDeleteSecondFactor := totp.MockDelete(nil)
+
+	disableTwoFactor := auth.MakeDisableTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockVerifySecondFactor, mockDeleteSecondFactor, []string{user.AdminRole})
+
+	want := auth.TwoFactorRequiredForRole
+	got := disableTwoFactor(context.Background(), "token", "123456")
+
+	assert.Equal(t, want, got)
+}
+
+func TestDisableTwoFactor_failsWhenVerifySecondFactorThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: totp.TwoFactorNotEnrolled, expected: auth.TwoFactorNotEnabled},
+		{err: totp.TwoFactorNotEnabled, expected: auth.TwoFactorNotEnabled},
+		{err: totp.WrongCode, expected: auth.WrongSecondFactorCode},
+		{err: totp.FailedToExecuteSelectByUserID, expected: auth.FailedToDisableTwoFactor},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockVerifySecondFactor := totp.MockVerify(tt.err)
+		mockDeleteSecondFactor := totp.MockDelete(nil)
+
+		disableTwoFactor := auth.MakeDisableTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockVerifySecondFactor, mockDeleteSecondFactor, nil)
+
+		want := tt.expected
+		got := disableTwoFactor(context.Background(), "token", "123456")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestDisableTwoFactor_failsWhenDeleteSecondFactorThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: totp.TwoFactorNotEnabled, expected: auth.TwoFactorNotEnabled},
+		{err: totp.FailedToDeleteTwoFactor, expected: auth.FailedToDisableTwoFactor},
+	}
+
+	for _, tt := range tests {
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockVerifySecondFactor := totp.MockVerify(nil)
+		mockDeleteSecondFactor := totp.MockDelete(tt.err)
+
+		disableTwoFactor := auth.MakeDisableTwoFactor(mockSelectUserIDByToken, mockSelectUserByID, mockVerifySecondFactor, mockDeleteSecondFactor, nil)
+
+		want := tt.expected
+		got := disableTwoFactor(context.Background(), "token", "123456")
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,42 @@
+package authors
+
+import "time"
+
+type (
+	// DAO represents an author of the 'authors' table
+	DAO struct {
+		ID          int       `json:"id"`
+		Handle      string    `json:"handle"`
+		FirstSeenAt time.Time `json:"first_seen_at"`
+		LastSeenAt  time.Time `json:"last_seen_at"`
+	}
+
+	// AvatarDAO represents a profile image of the 'authors_avatars' table
+	AvatarDAO struct {
+		Avatar      string    `json:"avatar"`
+		FirstSeenAt time.Time `json:"first_seen_at"`
+		LastSeenAt  time.Time `json:"last_seen_at"`
+	}
+
+	// CriteriaTweetsDAO represents the amount of tweets of an author retrieved by a search criteria
+	CriteriaTweetsDAO struct {
+		SearchCriteriaID int `json:"search_criteria_id"`
+		Tweets           int `json:"tweets"`
+	}
+
+	// VerdictsDAO represents the amount of times each verdict was given to the tweets of an author
+	VerdictsDAO struct {
+		Positive      int `json:"positive"`
+		Indeterminate int `json:"indeterminate"`
+		Negative      int `json:"negative"`
+	}
+
+	// RankedAuthorDAO represents an author with the amount of tweets it wrote, and the amount of each verdict they got
+	RankedAuthorDAO struct {
+		Handle        string `json:"handle"`
+		Tweets        int    `json:"tweets"`
+		Positive      int    `json:"positive"`
+		Indeterminate int    `json:"indeterminate"`
+		Negative      int    `json:"negative"`
+	}
+)
@@ -0,0 +1,57 @@
+package authors
+
+import "time"
+
+type (
+	// SightingDTO represents an appearance of an author, in a tweet or in a quote, with the avatar it had at that moment
+	SightingDTO struct {
+		Handle string
+		Avatar *string
+		SeenAt time.Time
+	}
+
+	// ProfileDTO is the response of the /authors/{handle}/v1 endpoint
+	ProfileDTO struct {
+		Handle            string              `json:"handle"`
+		FirstSeenAt       time.Time           `json:"first_seen_at"`
+		LastSeenAt        time.Time           `json:"last_seen_at"`
+		Avatars           []AvatarDTO         `json:"avatars"`
+		TweetsPerCriteria []CriteriaTweetsDTO `json:"tweets_per_criteria"`
+		Distribution      DistributionDTO     `json:"distribution"`
+	}
+
+	// AvatarDTO represents a profile image used by an author, and when it was used
+	AvatarDTO struct {
+		Avatar      string    `json:"avatar"`
+		FirstSeenAt time.Time `json:"first_seen_at"`
+		LastSeenAt  time.Time `json:"last_seen_at"`
+	}
+
+	// CriteriaTweetsDTO represents the amount of tweets of an author retrieved by a search criteria
+	CriteriaTweetsDTO struct {
+		SearchCriteriaID int `json:"search_criteria_id"`
+		Tweets           int `json:"tweets"`
+	}
+
+	// DistributionDTO represents how the verdicts given to the tweets of an author are distributed among the possible
+	// categorizations
+	DistributionDTO struct {
+		Positive      int     `json:"positive"`
+		Indeterminate int     `json:"indeterminate"`
+		Negative      int     `json:"negative"`
+		Total         int     `json:"total"`
+		PositiveRatio float64 `json:"positive_ratio"`
+	}
+
+	// RankingDTO is the response of the /authors/v1 endpoint
+	RankingDTO struct {
+		Authors []RankedAuthorDTO `json:"authors"`
+	}
+
+	// RankedAuthorDTO represents an author of the ranking, with the amount of tweets it wrote and the verdicts they got
+	RankedAuthorDTO struct {
+		Handle       string          `json:"handle"`
+		Tweets       int             `json:"tweets"`
+		Distribution DistributionDTO `json:"distribution"`
+	}
+)
@@ -0,0 +1,35 @@
+package authors
+
+import "errors"
+
+var (
+	NothingToUpsertWhenSightingsAreEmpty                = errors.New("nothing to upsert when sightings are empty")
+	FailedToUpsertSightings                             = errors.New("failed to upsert sightings")
+	NoAuthorFoundForTheGivenHandle                      = errors.New("no author found for the given handle")
+	FailedToExecuteSelectAuthorByHandle                 = errors.New("failed to execute select author by handle")
+	FailedToExecuteSelectAvatars                        = errors.New("failed to execute select avatars")
+	FailedToExecuteCollectRowsInSelectAvatars           = errors.New("failed to execute collect rows in select avatars")
+	FailedToExecuteSelectTweetsPerCriteria              = errors.New("failed to execute select tweets per criteria")
+	FailedToExecuteCollectRowsInSelectTweetsPerCriteria = errors.New("failed to execute collect rows in select tweets per criteria")
+	FailedToExecuteSelectVerdicts                       = errors.New("failed to execute select verdicts")
+	FailedToExecuteSelectRanking                        = errors.New("failed to execute select ranking")
+	FailedToExecuteCollectRowsInSelectRanking           = errors.New("failed to execute collect rows in select ranking")
+	FailedToAuthorizeUser                               = errors.New("failed to authorize user")
+	FailedToRetrieveAuthor                              = errors.New("failed to retrieve author")
+	FailedToRetrieveAvatars                             = errors.New("failed to retrieve avatars")
+	FailedToRetrieveTweetsPerCriteria                   = errors.New("failed to retrieve tweets per criteria")
+	FailedToRetrieveVerdicts                            = errors.New("failed to retrieve verdicts")
+	FailedToRetrieveRanking                             = errors.New("failed to retrieve ranking")
+	AuthorizationTokenIsRequired                        = errors.New("authorization token is required")
+	InvalidLimit                                        = errors.New("invalid limit")
+)
+
+const (
+	AuthorizationTokenRequired     string = "Authorization token is required"
+	InvalidQueryParameterFormat    string = "Invalid query parameter format"
+	UserNotAuthorized              string = "User not authorized"
+	InvalidOrExpiredToken          string = "Invalid or expired session token"
+	AuthorNotFound                 string = "Author not found"
+	FailedToRetrieveAuthorProfile  string = "Failed to retrieve author profile"
+	FailedToRetrieveAuthorsRanking string = "Failed to retrieve authors ranking"
+)
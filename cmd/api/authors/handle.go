@@ -0,0 +1,10 @@
+package authors
+
+import "strings"
+
+// NormalizeHandle returns the handle used to identify an author: the given author in lowercase and without the leading @,
+// so the same account is recognized no matter how it was written. It must match the LOWER(LTRIM(author, '@')) used in
+// the queries
+func NormalizeHandle(author string) string {
+	return strings.ToLower(strings.TrimLeft(author, "@"))
+}
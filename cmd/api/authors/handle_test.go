@@ -0,0 +1,29 @@
+package authors_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/authors"
+)
+
+func TestNormalizeHandle_success(t *testing.T) {
+	tests := []struct {
+		author   string
+		expected string
+	}{
+		{author: "TestAuthor", expected: "testauthor"},
+		{author: "@TestAuthor", expected: "testauthor"},
+		{author: "@@testauthor", expected: "testauthor"},
+		{author: "@", expected: ""},
+		{author: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got := authors.NormalizeHandle(tt.author)
+
+		assert.Equal(t, want, got)
+	}
+}
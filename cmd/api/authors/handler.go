@@ -0,0 +1,91 @@
+package authors
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// ProfileHandlerV1 HTTP Handler of the endpoint /authors/{handle}/v1
+func ProfileHandlerV1(profile Profile) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		handle := r.PathValue("handle")
+		ctx = log.With(ctx, log.Param("handle", handle))
+
+		authorProfile, err := profile(ctx, token, handle)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			case errors.Is(err, NoAuthorFoundForTheGivenHandle):
+				response.Send(ctx, w, http.StatusNotFound, AuthorNotFound, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveAuthorProfile, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Author profile successfully retrieved", authorProfile, nil)
+	}
+}
+
+// RankingHandlerV1 HTTP Handler of the endpoint /authors/v1
+func RankingHandlerV1(ranking Ranking) http.HandlerFunc {
+	const (
+		defaultLimit int = 50
+		maxLimit     int = 500
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		limit := defaultLimit
+		limitQueryParamStr := r.URL.Query().Get("limit")
+		if limitQueryParamStr != "" {
+			var err error
+			limit, err = strconv.Atoi(limitQueryParamStr)
+			if err != nil || limit <= 0 || limit > maxLimit {
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, InvalidLimit)
+				return
+			}
+		}
+		ctx = log.With(ctx, log.Param("limit", limit))
+
+		authorsRanking, err := ranking(ctx, token, limit)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveAuthorsRanking, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Authors ranking successfully retrieved", authorsRanking, nil)
+	}
+}
@@ -0,0 +1,169 @@
+package authors_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/authors"
+)
+
+func TestProfileHandlerV1_success(t *testing.T) {
+	mockProfile := authors.MockProfile(authors.MockProfileDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/authors/testauthor/v1", nil)
+	mockRequest.SetPathValue("handle", "testauthor")
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	profileHandlerV1 := authors.ProfileHandlerV1(mockProfile)
+
+	profileHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+
+	var response struct {
+		Data authors.ProfileDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, authors.MockProfileDTO(), response.Data)
+}
+
+func TestProfileHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockProfile := authors.MockProfile(authors.ProfileDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/authors/testauthor/v1", nil)
+	mockRequest.SetPathValue("handle", "testauthor")
+
+	profileHandlerV1 := authors.ProfileHandlerV1(mockProfile)
+
+	profileHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestProfileHandlerV1_failsWhenProfileThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: authors.NoAuthorFoundForTheGivenHandle, expected: http.StatusNotFound},
+		{err: errors.New("failed to retrieve profile"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockProfile := authors.MockProfile(authors.ProfileDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/authors/testauthor/v1", nil)
+		mockRequest.SetPathValue("handle", "testauthor")
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		profileHandlerV1 := authors.ProfileHandlerV1(mockProfile)
+
+		profileHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRankingHandlerV1_success(t *testing.T) {
+	mockRanking := authors.MockRanking(authors.MockRankingDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/authors/v1?limit=10", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	rankingHandlerV1 := authors.RankingHandlerV1(mockRanking)
+
+	rankingHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+
+	var response struct {
+		Data authors.RankingDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, authors.MockRankingDTO(), response.Data)
+}
+
+func TestRankingHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockRanking := authors.MockRanking(authors.RankingDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/authors/v1", nil)
+
+	rankingHandlerV1 := authors.RankingHandlerV1(mockRanking)
+
+	rankingHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRankingHandlerV1_failsWhenLimitIsInvalid(t *testing.T) {
+	for _, limit := range []string{"abc", "0", "-1", "501"} {
+		mockRanking := authors.MockRanking(authors.RankingDTO{}, nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/authors/v1?limit="+limit, nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		rankingHandlerV1 := authors.RankingHandlerV1(mockRanking)
+
+		rankingHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRankingHandlerV1_failsWhenRankingThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to retrieve ranking"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockRanking := authors.MockRanking(authors.RankingDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/authors/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		rankingHandlerV1 := authors.RankingHandlerV1(mockRanking)
+
+		rankingHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
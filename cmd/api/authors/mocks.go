@@ -0,0 +1,139 @@
+package authors
+
+import (
+	"context"
+	"time"
+)
+
+// MockUpsertSightings mocks an UpsertSightings function
+func MockUpsertSightings(err error) UpsertSightings {
+	return func(ctx context.Context, sightings []SightingDTO) error {
+		return err
+	}
+}
+
+// MockSelectByHandle mocks a SelectByHandle function
+func MockSelectByHandle(dao DAO, err error) SelectByHandle {
+	return func(ctx context.Context, handle string) (DAO, error) {
+		return dao, err
+	}
+}
+
+// MockSelectAvatars mocks a SelectAvatars function
+func MockSelectAvatars(daos []AvatarDAO, err error) SelectAvatars {
+	return func(ctx context.Context, authorID int) ([]AvatarDAO, error) {
+		return daos, err
+	}
+}
+
+// MockSelectTweetsPerCriteria mocks a SelectTweetsPerCriteria function
+func MockSelectTweetsPerCriteria(daos []CriteriaTweetsDAO, err error) SelectTweetsPerCriteria {
+	return func(ctx context.Context, handle string) ([]CriteriaTweetsDAO, error) {
+		return daos, err
+	}
+}
+
+// MockSelectVerdicts mocks a SelectVerdicts function
+func MockSelectVerdicts(dao VerdictsDAO, err error) SelectVerdicts {
+	return func(ctx context.Context, handle string) (VerdictsDAO, error) {
+		return dao, err
+	}
+}
+
+// MockSelectRanking mocks a SelectRanking function
+func MockSelectRanking(daos []RankedAuthorDAO, err error) SelectRanking {
+	return func(ctx context.Context, limit int) ([]RankedAuthorDAO, error) {
+		return daos, err
+	}
+}
+
+// MockProfile mocks a Profile function
+func MockProfile(profile ProfileDTO, err error) Profile {
+	return func(ctx context.Context, token string, handle string) (ProfileDTO, error) {
+		return profile, err
+	}
+}
+
+// MockRanking mocks a Ranking function
+func MockRanking(ranking RankingDTO, err error) Ranking {
+	return func(ctx context.Context, token string, limit int) (RankingDTO, error) {
+		return ranking, err
+	}
+}
+
+// MockSightingDTOs mocks a []SightingDTO
+func MockSightingDTOs() []SightingDTO {
+	avatar := "https://testuseravatar.com"
+
+	return []SightingDTO{
+		{Handle: "@TestAuthor", Avatar: &avatar, SeenAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{Handle: "TestQuoteAuthor", SeenAt: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+// MockDAO mocks a DAO
+func MockDAO() DAO {
+	return DAO{
+		ID:          1,
+		Handle:      "testauthor",
+		FirstSeenAt: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LastSeenAt:  time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+	}
+}
+
+// MockAvatarDAOs mocks a []AvatarDAO
+func MockAvatarDAOs() []AvatarDAO {
+	return []AvatarDAO{
+		{Avatar: "https://newavatar.com", FirstSeenAt: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), LastSeenAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+		{Avatar: "https://oldavatar.com", FirstSeenAt: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), LastSeenAt: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
+// MockCriteriaTweetsDAOs mocks a []CriteriaTweetsDAO
+func MockCriteriaTweetsDAOs() []CriteriaTweetsDAO {
+	return []CriteriaTweetsDAO{
+		{SearchCriteriaID: 1, Tweets: 7},
+		{SearchCriteriaID: 2, Tweets: 3},
+	}
+}
+
+// MockVerdictsDAO mocks a VerdictsDAO
+func MockVerdictsDAO() VerdictsDAO {
+	return VerdictsDAO{Positive: 6, Indeterminate: 2, Negative: 2}
+}
+
+// MockRankedAuthorDAOs mocks a []RankedAuthorDAO
+func MockRankedAuthorDAOs() []RankedAuthorDAO {
+	return []RankedAuthorDAO{
+		{Handle: "testauthor", Tweets: 10, Positive: 6, Indeterminate: 2, Negative: 2},
+		{Handle: "anotherauthor", Tweets: 4, Positive: 0, Indeterminate: 0, Negative: 0},
+	}
+}
+
+// MockProfileDTO mocks the ProfileDTO built from MockDAO, MockAvatarDAOs, MockCriteriaTweetsDAOs and MockVerdictsDAO
+func MockProfileDTO() ProfileDTO {
+	return ProfileDTO{
+		Handle:      "testauthor",
+		FirstSeenAt: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC),
+		LastSeenAt:  time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC),
+		Avatars: []AvatarDTO{
+			{Avatar: "https://newavatar.com", FirstSeenAt: time.Date(2025, time.February, 1, 0, 0, 0, 0, time.UTC), LastSeenAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)},
+			{Avatar: "https://oldavatar.com", FirstSeenAt: time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC), LastSeenAt: time.Date(2025, time.January, 31, 0, 0, 0, 0, time.UTC)},
+		},
+		TweetsPerCriteria: []CriteriaTweetsDTO{
+			{SearchCriteriaID: 1, Tweets: 7},
+			{SearchCriteriaID: 2, Tweets: 3},
+		},
+		Distribution: DistributionDTO{Positive: 6, Indeterminate: 2, Negative: 2, Total: 10, PositiveRatio: 0.6},
+	}
+}
+
+// MockRankingDTO mocks the RankingDTO built from MockRankedAuthorDAOs
+func MockRankingDTO() RankingDTO {
+	return RankingDTO{
+		Authors: []RankedAuthorDTO{
+			{Handle: "testauthor", Tweets: 10, Distribution: DistributionDTO{Positive: 6, Indeterminate: 2, Negative: 2, Total: 10, PositiveRatio: 0.6}},
+			{Handle: "anotherauthor", Tweets: 4, Distribution: DistributionDTO{}},
+		},
+	}
+}
@@ -0,0 +1,89 @@
+package authors
+
+import (
+	"context"
+	"errors"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// Profile returns the profile of the author with the given handle: when it was first and last seen, the avatars it
+// used, the amount of its tweets retrieved by each criteria and the distribution of the verdicts given to them.
+// Only admins are allowed to retrieve it
+type Profile func(ctx context.Context, token string, handle string) (ProfileDTO, error)
+
+// MakeProfile creates a new Profile
+func MakeProfile(authorize auth.Authorize, selectByHandle SelectByHandle, selectAvatars SelectAvatars, selectTweetsPerCriteria SelectTweetsPerCriteria, selectVerdicts SelectVerdicts) Profile {
+	return func(ctx context.Context, token string, handle string) (ProfileDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ProfileDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		author, err := selectByHandle(ctx, NormalizeHandle(handle))
+		if errors.Is(err, NoAuthorFoundForTheGivenHandle) {
+			log.Error(ctx, err.Error())
+			return ProfileDTO{}, NoAuthorFoundForTheGivenHandle
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return ProfileDTO{}, FailedToRetrieveAuthor
+		}
+
+		avatarDAOs, err := selectAvatars(ctx, author.ID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ProfileDTO{}, FailedToRetrieveAvatars
+		}
+
+		tweetsPerCriteriaDAOs, err := selectTweetsPerCriteria(ctx, author.Handle)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ProfileDTO{}, FailedToRetrieveTweetsPerCriteria
+		}
+
+		verdicts, err := selectVerdicts(ctx, author.Handle)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ProfileDTO{}, FailedToRetrieveVerdicts
+		}
+
+		profile := ProfileDTO{
+			Handle:            author.Handle,
+			FirstSeenAt:       author.FirstSeenAt,
+			LastSeenAt:        author.LastSeenAt,
+			Avatars:           make([]AvatarDTO, 0, len(avatarDAOs)),
+			TweetsPerCriteria: make([]CriteriaTweetsDTO, 0, len(tweetsPerCriteriaDAOs)),
+		}
+
+		for _, dao := range avatarDAOs {
+			profile.Avatars = append(profile.Avatars, AvatarDTO{Avatar: dao.Avatar, FirstSeenAt: dao.FirstSeenAt, LastSeenAt: dao.LastSeenAt})
+		}
+
+		for _, dao := range tweetsPerCriteriaDAOs {
+			profile.TweetsPerCriteria = append(profile.TweetsPerCriteria, CriteriaTweetsDTO{SearchCriteriaID: dao.SearchCriteriaID, Tweets: dao.Tweets})
+		}
+
+		profile.Distribution = newDistribution(verdicts.Positive, verdicts.Indeterminate, verdicts.Negative)
+
+		return profile, nil
+	}
+}
+
+// newDistribution creates a DistributionDTO with the given verdicts, calculating its total and positive ratio
+func newDistribution(positive, indeterminate, negative int) DistributionDTO {
+	distribution := DistributionDTO{
+		Positive:      positive,
+		Indeterminate: indeterminate,
+		Negative:      negative,
+		Total:         positive + indeterminate + negative,
+	}
+
+	if distribution.Total > 0 {
+		distribution.PositiveRatio = float64(distribution.Positive) / float64(distribution.Total)
+	}
+
+	return distribution
+}
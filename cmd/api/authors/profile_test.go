@@ -0,0 +1,104 @@
+package authors_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/authors"
+)
+
+func TestProfile_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectByHandle := authors.MockSelectByHandle(authors.MockDAO(), nil)
+	mockSelectAvatars := authors.MockSelectAvatars(authors.MockAvatarDAOs(), nil)
+	mockSelectTweetsPerCriteria := authors.MockSelectTweetsPerCriteria(authors.MockCriteriaTweetsDAOs(), nil)
+	mockSelectVerdicts := authors.MockSelectVerdicts(authors.MockVerdictsDAO(), nil)
+
+	profile := authors.MakeProfile(mockAuthorize, mockSelectByHandle, mockSelectAvatars, mockSelectTweetsPerCriteria, mockSelectVerdicts)
+
+	want := authors.MockProfileDTO()
+	got, err := profile(context.Background(), "token", "@TestAuthor")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestProfile_successWithoutAvatarsTweetsAndVerdicts(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectByHandle := authors.MockSelectByHandle(authors.MockDAO(), nil)
+	mockSelectAvatars := authors.MockSelectAvatars(nil, nil)
+	mockSelectTweetsPerCriteria := authors.MockSelectTweetsPerCriteria(nil, nil)
+	mockSelectVerdicts := authors.MockSelectVerdicts(authors.VerdictsDAO{}, nil)
+
+	profile := authors.MakeProfile(mockAuthorize, mockSelectByHandle, mockSelectAvatars, mockSelectTweetsPerCriteria, mockSelectVerdicts)
+
+	want := authors.MockProfileDTO()
+	want.Avatars = []authors.AvatarDTO{}
+	want.TweetsPerCriteria = []authors.CriteriaTweetsDTO{}
+	want.Distribution = authors.DistributionDTO{}
+	got, err := profile(context.Background(), "token", "testauthor")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestProfile_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: authors.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectByHandle := authors.MockSelectByHandle(authors.MockDAO(), nil)
+		mockSelectAvatars := authors.MockSelectAvatars(authors.MockAvatarDAOs(), nil)
+		mockSelectTweetsPerCriteria := authors.MockSelectTweetsPerCriteria(authors.MockCriteriaTweetsDAOs(), nil)
+		mockSelectVerdicts := authors.MockSelectVerdicts(authors.MockVerdictsDAO(), nil)
+
+		profile := authors.MakeProfile(mockAuthorize, mockSelectByHandle, mockSelectAvatars, mockSelectTweetsPerCriteria, mockSelectVerdicts)
+
+		want := tt.expected
+		_, got := profile(context.Background(), "token", "testauthor")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestProfile_failsWhenAnySelectThrowsError(t *testing.T) {
+	err := errors.New("failed to select")
+	tests := []struct {
+		authorErr            error
+		avatarsErr           error
+		tweetsPerCriteriaErr error
+		verdictsErr          error
+		expected             error
+	}{
+		{authorErr: authors.NoAuthorFoundForTheGivenHandle, expected: authors.NoAuthorFoundForTheGivenHandle},
+		{authorErr: err, expected: authors.FailedToRetrieveAuthor},
+		{avatarsErr: err, expected: authors.FailedToRetrieveAvatars},
+		{tweetsPerCriteriaErr: err, expected: authors.FailedToRetrieveTweetsPerCriteria},
+		{verdictsErr: err, expected: authors.FailedToRetrieveVerdicts},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockSelectByHandle := authors.MockSelectByHandle(authors.MockDAO(), tt.authorErr)
+		mockSelectAvatars := authors.MockSelectAvatars(nil, tt.avatarsErr)
+		mockSelectTweetsPerCriteria := authors.MockSelectTweetsPerCriteria(nil, tt.tweetsPerCriteriaErr)
+		mockSelectVerdicts := authors.MockSelectVerdicts(authors.VerdictsDAO{}, tt.verdictsErr)
+
+		profile := authors.MakeProfile(mockAuthorize, mockSelectByHandle, mockSelectAvatars, mockSelectTweetsPerCriteria, mockSelectVerdicts)
+
+		want := tt.expected
+		_, got := profile(context.Background(), "token", "testauthor")
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,41 @@
+package authors
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// Ranking returns the authors with the most positive verdicts given to their tweets, up to the given limit, so the
+// accounts with a repeated adverse behavior can be found. Only admins are allowed to retrieve it
+type Ranking func(ctx context.Context, token string, limit int) (RankingDTO, error)
+
+// MakeRanking creates a new Ranking
+func MakeRanking(authorize auth.Authorize, selectRanking SelectRanking) Ranking {
+	return func(ctx context.Context, token string, limit int) (RankingDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return RankingDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		rankedAuthorDAOs, err := selectRanking(ctx, limit)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return RankingDTO{}, FailedToRetrieveRanking
+		}
+
+		ranking := RankingDTO{Authors: make([]RankedAuthorDTO, 0, len(rankedAuthorDAOs))}
+		for _, dao := range rankedAuthorDAOs {
+			ranking.Authors = append(ranking.Authors, RankedAuthorDTO{
+				Handle:       dao.Handle,
+				Tweets:       dao.Tweets,
+				Distribution: newDistribution(dao.Positive, dao.Indeterminate, dao.Negative),
+			})
+		}
+
+		return ranking, nil
+	}
+}
@@ -0,0 +1,59 @@
+package authors_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/authors"
+)
+
+func TestRanking_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectRanking := authors.MockSelectRanking(authors.MockRankedAuthorDAOs(), nil)
+
+	ranking := authors.MakeRanking(mockAuthorize, mockSelectRanking)
+
+	want := authors.MockRankingDTO()
+	got, err := ranking(context.Background(), "token", 50)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRanking_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: authors.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectRanking := authors.MockSelectRanking(authors.MockRankedAuthorDAOs(), nil)
+
+		ranking := authors.MakeRanking(mockAuthorize, mockSelectRanking)
+
+		want := tt.expected
+		_, got := ranking(context.Background(), "token", 50)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRanking_failsWhenSelectRankingThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectRanking := authors.MockSelectRanking(nil, errors.New("failed to select ranking"))
+
+	ranking := authors.MakeRanking(mockAuthorize, mockSelectRanking)
+
+	want := authors.FailedToRetrieveRanking
+	_, got := ranking(context.Background(), "token", 50)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,179 @@
+package authors
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// SelectByHandle retrieves an author by its normalized handle
+	SelectByHandle func(ctx context.Context, handle string) (DAO, error)
+
+	// SelectAvatars retrieves the profile images used by an author, from the newest to the oldest
+	SelectAvatars func(ctx context.Context, authorID int) ([]AvatarDAO, error)
+
+	// SelectTweetsPerCriteria returns the amount of tweets of an author retrieved by each search criteria
+	SelectTweetsPerCriteria func(ctx context.Context, handle string) ([]CriteriaTweetsDAO, error)
+
+	// SelectVerdicts returns the amount of times each verdict was given to the tweets of an author
+	SelectVerdicts func(ctx context.Context, handle string) (VerdictsDAO, error)
+
+	// SelectRanking returns the authors with the most positive verdicts given to their tweets, alongside the amount of
+	// tweets they wrote and the rest of their verdicts. The ties are broken by the amount of tweets
+	SelectRanking func(ctx context.Context, limit int) ([]RankedAuthorDAO, error)
+)
+
+// MakeSelectByHandle creates a new SelectByHandle
+func MakeSelectByHandle(db database.Connection) SelectByHandle {
+	const query string = `
+		SELECT a.id, a.handle, a.first_seen_at, a.last_seen_at
+		FROM authors AS a
+		WHERE a.handle = $1;
+	`
+
+	return func(ctx context.Context, handle string) (DAO, error) {
+		var author DAO
+		err := db.QueryRow(ctx, query, handle).Scan(
+			&author.ID,
+			&author.Handle,
+			&author.FirstSeenAt,
+			&author.LastSeenAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return DAO{}, NoAuthorFoundForTheGivenHandle
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return DAO{}, FailedToExecuteSelectAuthorByHandle
+		}
+
+		return author, nil
+	}
+}
+
+// MakeSelectAvatars creates a new SelectAvatars
+func MakeSelectAvatars(db database.Connection, collectRows database.CollectRows[AvatarDAO]) SelectAvatars {
+	const query string = `
+		SELECT aa.avatar, aa.first_seen_at, aa.last_seen_at
+		FROM authors_avatars AS aa
+		WHERE aa.author_id = $1
+		ORDER BY aa.last_seen_at DESC, aa.id DESC;
+	`
+
+	return func(ctx context.Context, authorID int) ([]AvatarDAO, error) {
+		rows, err := db.Query(ctx, query, authorID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectAvatars
+		}
+
+		avatars, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectAvatars
+		}
+
+		return avatars, nil
+	}
+}
+
+// MakeSelectTweetsPerCriteria creates a new SelectTweetsPerCriteria
+func MakeSelectTweetsPerCriteria(db database.Connection, collectRows database.CollectRows[CriteriaTweetsDAO]) SelectTweetsPerCriteria {
+	const query string = `
+		SELECT tc.search_criteria_id, COUNT(DISTINCT t.id)
+		FROM tweets AS t
+		INNER JOIN tweets_search_criteria AS tc ON tc.tweet_id = t.id
+		WHERE LOWER(LTRIM(t.author, '@')) = $1
+		GROUP BY tc.search_criteria_id
+		ORDER BY tc.search_criteria_id;
+	`
+
+	return func(ctx context.Context, handle string) ([]CriteriaTweetsDAO, error) {
+		rows, err := db.Query(ctx, query, handle)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectTweetsPerCriteria
+		}
+
+		tweetsPerCriteria, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectTweetsPerCriteria
+		}
+
+		return tweetsPerCriteria, nil
+	}
+}
+
+// MakeSelectVerdicts creates a new SelectVerdicts
+func MakeSelectVerdicts(db database.Connection) SelectVerdicts {
+	const query string = `
+		SELECT COUNT(c.id) FILTER (WHERE c.categorization = 'POSITIVE'),
+			   COUNT(c.id) FILTER (WHERE c.categorization = 'INDETERMINATE'),
+			   COUNT(c.id) FILTER (WHERE c.categorization = 'NEGATIVE')
+		FROM categorized_tweets AS c
+		INNER JOIN tweets AS t ON t.id = c.tweet_id
+		WHERE LOWER(LTRIM(t.author, '@')) = $1;
+	`
+
+	return func(ctx context.Context, handle string) (VerdictsDAO, error) {
+		var verdicts VerdictsDAO
+		err := db.QueryRow(ctx, query, handle).Scan(
+			&verdicts.Positive,
+			&verdicts.Indeterminate,
+			&verdicts.Negative,
+		)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return VerdictsDAO{}, FailedToExecuteSelectVerdicts
+		}
+
+		return verdicts, nil
+	}
+}
+
+// MakeSelectRanking creates a new SelectRanking
+func MakeSelectRanking(db database.Connection, collectRows database.CollectRows[RankedAuthorDAO]) SelectRanking {
+	const query string = `
+		WITH tweets_per_author AS (
+			SELECT LOWER(LTRIM(t.author, '@')) AS handle, COUNT(t.id) AS tweets
+			FROM tweets AS t
+			GROUP BY LOWER(LTRIM(t.author, '@'))
+		),
+		verdicts_per_author AS (
+			SELECT LOWER(LTRIM(t.author, '@')) AS handle,
+				   COUNT(c.id) FILTER (WHERE c.categorization = 'POSITIVE') AS positive,
+				   COUNT(c.id) FILTER (WHERE c.categorization = 'INDETERMINATE') AS indeterminate,
+				   COUNT(c.id) FILTER (WHERE c.categorization = 'NEGATIVE') AS negative
+			FROM categorized_tweets AS c
+			INNER JOIN tweets AS t ON t.id = c.tweet_id
+			GROUP BY LOWER(LTRIM(t.author, '@'))
+		)
+		SELECT ta.handle, ta.tweets, COALESCE(va.positive, 0), COALESCE(va.indeterminate, 0), COALESCE(va.negative, 0)
+		FROM tweets_per_author AS ta
+		LEFT JOIN verdicts_per_author AS va ON va.handle = ta.handle
+		ORDER BY COALESCE(va.positive, 0) DESC, ta.tweets DESC, ta.handle
+		LIMIT $1;
+	`
+
+	return func(ctx context.Context, limit int) ([]RankedAuthorDAO, error) {
+		rows, err := db.Query(ctx, query, limit)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectRanking
+		}
+
+		ranking, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectRanking
+		}
+
+		return ranking, nil
+	}
+}
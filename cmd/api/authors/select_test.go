@@ -0,0 +1,230 @@
+package authors_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/authors"
+	"ahbcc/internal/database"
+)
+
+func TestSelectByHandle_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockAuthor := authors.MockDAO()
+	database.MockScan(mockPgxRow, []any{mockAuthor.ID, mockAuthor.Handle, mockAuthor.FirstSeenAt, mockAuthor.LastSeenAt}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectByHandle := authors.MakeSelectByHandle(mockPostgresConnection)
+
+	want := mockAuthor
+	got, err := selectByHandle(context.Background(), mockAuthor.Handle)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectByHandle_failsWhenSelectOperationFails(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: authors.NoAuthorFoundForTheGivenHandle},
+		{err: errors.New("failed to execute select operation"), expected: authors.FailedToExecuteSelectAuthorByHandle},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		selectByHandle := authors.MakeSelectByHandle(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := selectByHandle(context.Background(), "testauthor")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSelectAvatars_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := authors.MockAvatarDAOs()
+	mockCollectRows := database.MockCollectRows[authors.AvatarDAO](mockDAOs, nil)
+
+	selectAvatars := authors.MakeSelectAvatars(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectAvatars(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectAvatars_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[authors.AvatarDAO](nil, nil)
+
+	selectAvatars := authors.MakeSelectAvatars(mockPostgresConnection, mockCollectRows)
+
+	want := authors.FailedToExecuteSelectAvatars
+	_, got := selectAvatars(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectAvatars_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[authors.AvatarDAO](nil, errors.New("failed to collect rows"))
+
+	selectAvatars := authors.MakeSelectAvatars(mockPostgresConnection, mockCollectRows)
+
+	want := authors.FailedToExecuteCollectRowsInSelectAvatars
+	_, got := selectAvatars(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectTweetsPerCriteria_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := authors.MockCriteriaTweetsDAOs()
+	mockCollectRows := database.MockCollectRows[authors.CriteriaTweetsDAO](mockDAOs, nil)
+
+	selectTweetsPerCriteria := authors.MakeSelectTweetsPerCriteria(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectTweetsPerCriteria(context.Background(), "testauthor")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectTweetsPerCriteria_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[authors.CriteriaTweetsDAO](nil, nil)
+
+	selectTweetsPerCriteria := authors.MakeSelectTweetsPerCriteria(mockPostgresConnection, mockCollectRows)
+
+	want := authors.FailedToExecuteSelectTweetsPerCriteria
+	_, got := selectTweetsPerCriteria(context.Background(), "testauthor")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectTweetsPerCriteria_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[authors.CriteriaTweetsDAO](nil, errors.New("failed to collect rows"))
+
+	selectTweetsPerCriteria := authors.MakeSelectTweetsPerCriteria(mockPostgresConnection, mockCollectRows)
+
+	want := authors.FailedToExecuteCollectRowsInSelectTweetsPerCriteria
+	_, got := selectTweetsPerCriteria(context.Background(), "testauthor")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectVerdicts_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockVerdicts := authors.MockVerdictsDAO()
+	database.MockScan(mockPgxRow, []any{mockVerdicts.Positive, mockVerdicts.Indeterminate, mockVerdicts.Negative}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectVerdicts := authors.MakeSelectVerdicts(mockPostgresConnection)
+
+	want := mockVerdicts
+	got, err := selectVerdicts(context.Background(), "testauthor")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectVerdicts_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(errors.New("failed to execute select operation"))
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectVerdicts := authors.MakeSelectVerdicts(mockPostgresConnection)
+
+	want := authors.FailedToExecuteSelectVerdicts
+	_, got := selectVerdicts(context.Background(), "testauthor")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectRanking_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := authors.MockRankedAuthorDAOs()
+	mockCollectRows := database.MockCollectRows[authors.RankedAuthorDAO](mockDAOs, nil)
+
+	selectRanking := authors.MakeSelectRanking(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectRanking(context.Background(), 50)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectRanking_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[authors.RankedAuthorDAO](nil, nil)
+
+	selectRanking := authors.MakeSelectRanking(mockPostgresConnection, mockCollectRows)
+
+	want := authors.FailedToExecuteSelectRanking
+	_, got := selectRanking(context.Background(), 50)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectRanking_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[authors.RankedAuthorDAO](nil, errors.New("failed to collect rows"))
+
+	selectRanking := authors.MakeSelectRanking(mockPostgresConnection, mockCollectRows)
+
+	want := authors.FailedToExecuteCollectRowsInSelectRanking
+	_, got := selectRanking(context.Background(), 50)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,90 @@
+package authors
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// UpsertSightings inserts the authors of the given sightings into the 'authors' table, and their avatars into the
+// 'authors_avatars' table. An author, or avatar, that was already inserted only widens its first and last seen dates.
+// The sightings without a handle are ignored.
+// The sightings are upserted in chunks, each one on its own, since upserting a chunk again doesn't change the result
+type UpsertSightings func(ctx context.Context, sightings []SightingDTO) error
+
+// MakeUpsertSightings creates a new UpsertSightings
+func MakeUpsertSightings(db database.Connection) UpsertSightings {
+	const (
+		query string = `
+			WITH input(handle, avatar, seen_at) AS (
+				VALUES %s
+			),
+			upserted AS (
+				INSERT INTO authors(handle, first_seen_at, last_seen_at)
+				SELECT i.handle, MIN(i.seen_at), MAX(i.seen_at)
+				FROM input AS i
+				GROUP BY i.handle
+				ON CONFLICT (handle) DO UPDATE SET
+					first_seen_at = LEAST(authors.first_seen_at, EXCLUDED.first_seen_at),
+					last_seen_at = GREATEST(authors.last_seen_at, EXCLUDED.last_seen_at)
+				RETURNING id, handle
+			)
+			INSERT INTO authors_avatars(author_id, avatar, first_seen_at, last_seen_at)
+			SELECT u.id, i.avatar, MIN(i.seen_at), MAX(i.seen_at)
+			FROM input AS i
+			INNER JOIN upserted AS u ON u.handle = i.handle
+			WHERE i.avatar IS NOT NULL
+			GROUP BY u.id, i.avatar
+			ON CONFLICT (author_id, avatar) DO UPDATE SET
+				first_seen_at = LEAST(authors_avatars.first_seen_at, EXCLUDED.first_seen_at),
+				last_seen_at = GREATEST(authors_avatars.last_seen_at, EXCLUDED.last_seen_at);
+		`
+		parameters = 3
+
+		// chunkSize keeps each query far below the limit of 65535 parameters of Postgres
+		chunkSize = 1000
+	)
+
+	return func(ctx context.Context, sightings []SightingDTO) error {
+		values := make([]any, 0, len(sightings)*parameters)
+		for _, sighting := range sightings {
+			handle := NormalizeHandle(sighting.Handle)
+			if handle == "" {
+				continue
+			}
+
+			var avatar *string
+			if sighting.Avatar != nil && *sighting.Avatar != "" {
+				avatar = sighting.Avatar
+			}
+
+			values = append(values, handle, avatar, sighting.SeenAt)
+		}
+
+		total := len(values) / parameters
+		if total == 0 {
+			return NothingToUpsertWhenSightingsAreEmpty
+		}
+
+		for start := 0; start < total; start += chunkSize {
+			end := min(start+chunkSize, total)
+
+			placeholders := make([]string, 0, end-start)
+			for i := range end - start {
+				idx := i * parameters
+				placeholders = append(placeholders, fmt.Sprintf("($%d::TEXT, $%d::TEXT, $%d::TIMESTAMPTZ)", idx+1, idx+2, idx+3))
+			}
+
+			_, err := db.Exec(ctx, fmt.Sprintf(query, strings.Join(placeholders, ",")), values[start*parameters:end*parameters]...)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return FailedToUpsertSightings
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,70 @@
+package authors_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/authors"
+	"ahbcc/internal/database"
+)
+
+func TestUpsertSightings_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockSightings := authors.MockSightingDTOs()
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{"testauthor", mockSightings[0].Avatar, mockSightings[0].SeenAt, "testquoteauthor", (*string)(nil), mockSightings[1].SeenAt}).Return(pgconn.CommandTag{}, nil)
+
+	upsertSightings := authors.MakeUpsertSightings(mockPostgresConnection)
+
+	got := upsertSightings(context.Background(), mockSightings)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpsertSightings_successIgnoringTheSightingsWithoutHandleAndTheEmptyAvatars(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	emptyAvatar := ""
+	mockSightings := authors.MockSightingDTOs()
+	mockSightings[0].Handle = "@"
+	mockSightings[1].Avatar = &emptyAvatar
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{"testquoteauthor", (*string)(nil), mockSightings[1].SeenAt}).Return(pgconn.CommandTag{}, nil)
+
+	upsertSightings := authors.MakeUpsertSightings(mockPostgresConnection)
+
+	got := upsertSightings(context.Background(), mockSightings)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpsertSightings_failsWhenThereIsNothingToUpsert(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+
+	upsertSightings := authors.MakeUpsertSightings(mockPostgresConnection)
+
+	for _, sightings := range [][]authors.SightingDTO{nil, {{Handle: ""}}} {
+		want := authors.NothingToUpsertWhenSightingsAreEmpty
+		got := upsertSightings(context.Background(), sightings)
+
+		assert.Equal(t, want, got)
+	}
+	mockPostgresConnection.AssertNotCalled(t, "Exec")
+}
+
+func TestUpsertSightings_failsWhenUpsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to upsert sightings"))
+
+	upsertSightings := authors.MakeUpsertSightings(mockPostgresConnection)
+
+	want := authors.FailedToUpsertSightings
+	got := upsertSightings(context.Background(), authors.MockSightingDTOs())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
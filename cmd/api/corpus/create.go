@@ -6,44 +6,148 @@ import (
 
 	"ahbcc/cmd/api/tweets"
 	"ahbcc/cmd/api/tweets/categorized"
+	"ahbcc/cmd/api/tweets/duplicates"
+	"ahbcc/cmd/api/tweets/gold"
+	"ahbcc/cmd/api/tweets/language"
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/tweets/quotes"
 	"ahbcc/internal/log"
 )
 
 // Create retrieves the information from the categorized_tweets table and inserts the tweets with all their information
 // into the corpus table. It only considers the 'POSITIVE' and 'NEGATIVE' categorizations.
-type Create func(ctx context.Context) error
+// If options.ExcludeFlaggedAnnotators is true, the verdicts of the annotators flagged by the gold tweets quality control
+// are not considered.
+// If options.ExcludeLanguageMismatches is true, the tweets whose detected language is not the one of any of the search
+// criteria they are related to are not considered, the same as in the tweets feed.
+// The options.Duplicates mode defines how the verdicts of the near-duplicate tweets are handled: they are kept as they
+// were given (KeepDuplicates, the default), only the ones of a single tweet of each group are considered
+// (DeduplicateDuplicates), or they are also copied to the uncategorized tweets of the group (PropagateDuplicates).
+// If options.IncludeContext is true, the text of the tweet each reply replies to is stored along with it.
+type Create func(ctx context.Context, options CreateOptions) error
 
 // MakeCreate creates a new Create function
-func MakeCreate(selectByCategorizations categorized.SelectByCategorizations, selectTweetByID tweets.SelectByID, selectTweetQuoteByID quotes.SelectByID, deleteAllCorpusRows DeleteAll, insertCorpusRow Insert) Create {
+func MakeCreate(selectByCategorizations categorized.SelectByCategorizations, selectTweetByID tweets.SelectByID, selectTweetQuoteByID quotes.SelectByID, selectCriteriaLanguages language.SelectCriteriaLanguages, selectDuplicateMembers duplicates.SelectMembers, selectConversations parents.SelectConversations, deleteAllCorpusRows DeleteAll, insertCorpusRow Insert, flaggedAnnotators gold.FlaggedAnnotators) Create {
 	var categorizations = []string{categorized.VerdictPositive, categorized.VerdictNegative}
 
-	return func(ctx context.Context) error {
+	return func(ctx context.Context, options CreateOptions) error {
 		categorizedTweets, err := selectByCategorizations(ctx, categorizations)
 		if err != nil {
 			log.Error(ctx, err.Error())
 			return FailedToRetrieveCategorizedTweets
 		}
 
-		rows := make([]DTO, 0, len(categorizedTweets))
+		excludedAnnotators := make(map[int]bool)
+		if options.ExcludeFlaggedAnnotators {
+			flaggedAnnotatorsIDs, err := flaggedAnnotators(ctx)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return FailedToRetrieveFlaggedAnnotators
+			}
+
+			for _, userID := range flaggedAnnotatorsIDs {
+				excludedAnnotators[userID] = true
+			}
+			log.Info(ctx, fmt.Sprintf("Excluding the verdicts of %d flagged annotators\n", len(excludedAnnotators)))
+		}
+
+		criteriaLanguages := make(map[int][]string)
+		var mismatches int
+
+		candidates := make([]candidate, 0, len(categorizedTweets))
 		for _, categorizedTweet := range categorizedTweets {
+			if categorizedTweet.UserID != nil && excludedAnnotators[*categorizedTweet.UserID] {
+				continue
+			}
+
 			tweetData, err := selectTweetByID(ctx, categorizedTweet.TweetID)
 			if err != nil {
 				log.Error(ctx, err.Error())
 				continue
 			}
 
+			// A tweet matches if its language is the one of any of the search criteria it is related to, as in the feed
+			if options.ExcludeLanguageMismatches {
+				tweetCriteriaLanguages, ok := criteriaLanguages[categorizedTweet.TweetID]
+				if !ok {
+					tweetCriteriaLanguages, err = selectCriteriaLanguages(ctx, categorizedTweet.TweetID)
+					if err != nil {
+						log.Error(ctx, err.Error())
+						return FailedToRetrieveCriteriaLanguages
+					}
+
+					criteriaLanguages[categorizedTweet.TweetID] = tweetCriteriaLanguages
+				}
+
+				if language.IsMismatch(tweetData.Language, tweetData.LanguageConfidence, tweetCriteriaLanguages...) {
+					mismatches++
+					continue
+				}
+			}
+
+			candidates = append(candidates, candidate{categorized: categorizedTweet, tweet: tweetData})
+		}
+
+		if options.ExcludeLanguageMismatches {
+			log.Info(ctx, fmt.Sprintf("Excluded %d categorized tweets whose language doesn't match the one of any of their search criteria\n", mismatches))
+		}
+
+		switch options.Duplicates {
+		case DeduplicateDuplicates:
+			deduplicated := deduplicate(candidates)
+			log.Info(ctx, fmt.Sprintf("Excluded %d categorized tweets that are near-duplicates of other categorized tweets\n", len(candidates)-len(deduplicated)))
+			candidates = deduplicated
+		case PropagateDuplicates:
+			candidates, err = propagate(ctx, candidates, selectDuplicateMembers, selectTweetByID)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return err
+			}
+		}
+
+		parentTexts := make(map[string]*string)
+		if options.IncludeContext {
+			inReplyToStatusIDs := make([]string, 0, len(candidates))
+			for _, c := range candidates {
+				if c.tweet.InReplyToStatusID != nil {
+					inReplyToStatusIDs = append(inReplyToStatusIDs, *c.tweet.InReplyToStatusID)
+				}
+			}
+
+			conversations, err := selectConversations(ctx, inReplyToStatusIDs)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return FailedToRetrieveConversations
+			}
+
+			// Each conversation ends with the tweet the reply replies to
+			for statusID, conversation := range conversations {
+				parentTexts[statusID] = conversation[len(conversation)-1].TextContent
+			}
+		}
+
+		rows := make([]DTO, 0, len(candidates))
+		for _, c := range candidates {
 			row := DTO{
-				TweetAuthor:    tweetData.Author,
-				TweetAvatar:    tweetData.Avatar,
-				TweetText:      tweetData.TextContent,
-				TweetImages:    tweetData.Images,
-				IsTweetAReply:  tweetData.IsAReply,
-				Categorization: categorizedTweet.Categorization,
+				TweetAuthor:    c.tweet.Author,
+				TweetAvatar:    c.tweet.Avatar,
+				TweetText:      c.tweet.TextContent,
+				TweetImages:    c.tweet.Images,
+				IsTweetAReply:  c.tweet.IsAReply,
+				TweetHashtags:  c.tweet.Hashtags,
+				TweetMentions:  c.tweet.Mentions,
+				TweetURLs:      c.tweet.URLs,
+				TweetEmojis:    c.tweet.Emojis,
+				TweetCashtags:  c.tweet.Cashtags,
+				Categorization: c.categorized.Categorization,
+			}
+
+			if c.tweet.InReplyToStatusID != nil {
+				row.TweetParentText = parentTexts[*c.tweet.InReplyToStatusID]
 			}
 
-			if tweetData.QuoteID != nil {
-				tweetQuoteData, err := selectTweetQuoteByID(ctx, *tweetData.QuoteID)
+			if c.tweet.QuoteID != nil {
+				tweetQuoteData, err := selectTweetQuoteByID(ctx, *c.tweet.QuoteID)
 				if err != nil {
 					log.Error(ctx, err.Error())
 				} else {
@@ -52,6 +156,11 @@ func MakeCreate(selectByCategorizations categorized.SelectByCategorizations, sel
 					row.QuoteText = tweetQuoteData.TextContent
 					row.QuoteImages = tweetQuoteData.Images
 					row.IsQuoteAReply = &tweetQuoteData.IsAReply
+					row.QuoteHashtags = tweetQuoteData.Hashtags
+					row.QuoteMentions = tweetQuoteData.Mentions
+					row.QuoteURLs = tweetQuoteData.URLs
+					row.QuoteEmojis = tweetQuoteData.Emojis
+					row.QuoteCashtags = tweetQuoteData.Cashtags
 				}
 			}
 
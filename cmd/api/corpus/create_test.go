@@ -3,6 +3,8 @@ package corpus_test
 import (
 	"context"
 	"errors"
+	"fmt"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -10,6 +12,10 @@ import (
 	"ahbcc/cmd/api/corpus"
 	"ahbcc/cmd/api/tweets"
 	"ahbcc/cmd/api/tweets/categorized"
+	"ahbcc/cmd/api/tweets/duplicates"
+	"ahbcc/cmd/api/tweets/gold"
+	"ahbcc/cmd/api/tweets/language"
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/tweets/quotes"
 )
 
@@ -17,12 +23,16 @@ func TestCreate_success(t *testing.T) {
 	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
 	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
 	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
 	mockInsert := corpus.MockInsert(nil)
 
-	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockDeleteAll, mockInsert)
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
 
-	got := create(context.Background())
+	got := create(context.Background(), corpus.CreateOptions{})
 
 	assert.Nil(t, got)
 }
@@ -31,12 +41,16 @@ func TestCreate_successEvenWhenSelectTweetByIDThrowsError(t *testing.T) {
 	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
 	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), errors.New("failed to select tweet by id"))
 	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
 	mockInsert := corpus.MockInsert(nil)
 
-	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockDeleteAll, mockInsert)
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
 
-	got := create(context.Background())
+	got := create(context.Background(), corpus.CreateOptions{})
 
 	assert.Nil(t, got)
 }
@@ -45,12 +59,16 @@ func TestCreate_successEvenWhenSelectTweetQuoteByIDThrowsError(t *testing.T) {
 	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
 	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
 	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), errors.New("failed to select quote by id"))
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
 	mockInsert := corpus.MockInsert(nil)
 
-	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockDeleteAll, mockInsert)
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
 
-	got := create(context.Background())
+	got := create(context.Background(), corpus.CreateOptions{})
 
 	assert.Nil(t, got)
 }
@@ -59,12 +77,16 @@ func TestCreate_successEvenWhenInsertThrowsError(t *testing.T) {
 	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
 	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
 	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
 	mockInsert := corpus.MockInsert(errors.New("failed to insert"))
 
-	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockDeleteAll, mockInsert)
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
 
-	got := create(context.Background())
+	got := create(context.Background(), corpus.CreateOptions{})
 
 	assert.Nil(t, got)
 }
@@ -73,13 +95,17 @@ func TestCreate_failsWhenSelectByCategorizationsThrowsError(t *testing.T) {
 	mockSelectByCategorizations := categorized.MockSelectByCategorizations(nil, errors.New("failed to select by categorizations"))
 	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
 	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
 	mockInsert := corpus.MockInsert(nil)
 
-	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockDeleteAll, mockInsert)
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
 
 	want := corpus.FailedToRetrieveCategorizedTweets
-	got := create(context.Background())
+	got := create(context.Background(), corpus.CreateOptions{})
 
 	assert.Equal(t, want, got)
 }
@@ -88,13 +114,334 @@ func TestCreate_failsWhenDeleteAllThrowsError(t *testing.T) {
 	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
 	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
 	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 	mockDeleteAll := corpus.MockDeleteAll(errors.New("failed to delete all"))
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
 	mockInsert := corpus.MockInsert(nil)
 
-	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockDeleteAll, mockInsert)
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
 
 	want := corpus.FailedToCleanUpCorpusTable
-	got := create(context.Background())
+	got := create(context.Background(), corpus.CreateOptions{})
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreate_successExcludingFlaggedAnnotators(t *testing.T) {
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{*categorized.MockCategorizedTweetDAO().UserID}, nil)
+	var inserted int
+	mockInsert := func(ctx context.Context, entry corpus.DTO) (int, error) {
+		inserted++
+		return inserted, nil
+	}
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	got := create(context.Background(), corpus.CreateOptions{ExcludeFlaggedAnnotators: true})
+
+	assert.Nil(t, got)
+	assert.Equal(t, 0, inserted)
+}
+
+func TestCreate_successIncludingTheCategorizationsOfTheDeletedUsers(t *testing.T) {
+	anonymizedCategorizedTweet := categorized.MockCategorizedTweetDAO()
+	anonymizedCategorizedTweet.UserID = nil
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{anonymizedCategorizedTweet}, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{*categorized.MockCategorizedTweetDAO().UserID}, nil)
+	var inserted int
+	mockInsert := func(ctx context.Context, entry corpus.DTO) (int, error) {
+		inserted++
+		return inserted, nil
+	}
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	got := create(context.Background(), corpus.CreateOptions{ExcludeFlaggedAnnotators: true})
+
+	assert.Nil(t, got)
+	assert.Equal(t, 1, inserted)
+}
+
+func TestCreate_failsWhenFlaggedAnnotatorsThrowsError(t *testing.T) {
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators(nil, errors.New("failed to retrieve flagged annotators"))
+	mockInsert := corpus.MockInsert(nil)
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	want := corpus.FailedToRetrieveFlaggedAnnotators
+	got := create(context.Background(), corpus.CreateOptions{ExcludeFlaggedAnnotators: true})
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreate_successExcludingLanguageMismatches(t *testing.T) {
+	tests := []struct {
+		language string
+		expected int
+	}{
+		{language: "es", expected: 1},
+		{language: "pt", expected: 0},
+	}
+
+	for _, tt := range tests {
+		mockTweetDAO := tweets.MockTweetDAO()
+		mockTweetDAO.Language = &tt.language
+		mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+		mockSelectTweetByID := tweets.MockSelectByID(mockTweetDAO, nil)
+		mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+		mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+		mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+		mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+		mockDeleteAll := corpus.MockDeleteAll(nil)
+		mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+		var inserted int
+		mockInsert := func(ctx context.Context, entry corpus.DTO) (int, error) {
+			inserted++
+			return inserted, nil
+		}
+
+		create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+		got := create(context.Background(), corpus.CreateOptions{ExcludeLanguageMismatches: true})
+
+		assert.Nil(t, got)
+		assert.Equal(t, tt.expected, inserted)
+	}
+}
+
+func TestCreate_successKeepingTheTweetsWhoseLanguageMatchesAnyOfTheirSearchCriteria(t *testing.T) {
+	tests := []struct {
+		criteriaLanguages []string
+		expected          int
+	}{
+		{criteriaLanguages: []string{"pt", "ES"}, expected: 1},
+		{criteriaLanguages: []string{"pt", "en"}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		mockTweetLanguage := "es"
+		mockTweetDAO := tweets.MockTweetDAO()
+		mockTweetDAO.Language = &mockTweetLanguage
+		mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+		mockSelectTweetByID := tweets.MockSelectByID(mockTweetDAO, nil)
+		mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+		mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages(tt.criteriaLanguages, nil)
+		mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+		mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+		mockDeleteAll := corpus.MockDeleteAll(nil)
+		mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+		var inserted int
+		mockInsert := func(ctx context.Context, entry corpus.DTO) (int, error) {
+			inserted++
+			return inserted, nil
+		}
+
+		create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+		got := create(context.Background(), corpus.CreateOptions{ExcludeLanguageMismatches: true})
+
+		assert.Nil(t, got)
+		assert.Equal(t, tt.expected, inserted)
+	}
+}
+
+func TestCreate_failsWhenSelectCriteriaLanguagesThrowsError(t *testing.T) {
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages(nil, errors.New("failed to select criteria languages"))
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+	mockInsert := corpus.MockInsert(nil)
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	want := corpus.FailedToRetrieveCriteriaLanguages
+	got := create(context.Background(), corpus.CreateOptions{ExcludeLanguageMismatches: true})
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreate_successHandlingTheNearDuplicates(t *testing.T) {
+	representativeID := 1
+	firstUserID, secondUserID, thirdUserID := 10, 11, 12
+	categorizedTweets := []categorized.DAO{
+		{ID: 1, TweetID: 2, UserID: &firstUserID, Categorization: categorized.VerdictPositive},
+		{ID: 2, TweetID: 3, UserID: &secondUserID, Categorization: categorized.VerdictNegative},
+		{ID: 3, TweetID: 3, UserID: &thirdUserID, Categorization: categorized.VerdictPositive},
+		{ID: 4, TweetID: 5, UserID: &firstUserID, Categorization: categorized.VerdictNegative},
+	}
+	mockSelectTweetByID := func(ctx context.Context, id int) (tweets.DAO, error) {
+		tweet := tweets.MockTweetDAO()
+		tweet.ID = id
+		tweet.QuoteID = nil
+		text := strconv.Itoa(id)
+		tweet.TextContent = &text
+		if id < 5 {
+			tweet.RepresentativeID = &representativeID
+		}
+		return tweet, nil
+	}
+
+	tests := []struct {
+		duplicates string
+		expected   []string
+	}{
+		{duplicates: "", expected: []string{"2:POSITIVE", "3:NEGATIVE", "3:POSITIVE", "5:NEGATIVE"}},
+		{duplicates: corpus.KeepDuplicates, expected: []string{"2:POSITIVE", "3:NEGATIVE", "3:POSITIVE", "5:NEGATIVE"}},
+		{duplicates: corpus.DeduplicateDuplicates, expected: []string{"2:POSITIVE", "5:NEGATIVE"}},
+		{duplicates: corpus.PropagateDuplicates, expected: []string{"2:POSITIVE", "3:NEGATIVE", "3:POSITIVE", "5:NEGATIVE", "1:POSITIVE", "4:POSITIVE"}},
+	}
+
+	for _, tt := range tests {
+		mockSelectByCategorizations := categorized.MockSelectByCategorizations(categorizedTweets, nil)
+		mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+		mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+		mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{2, 3, 4}, nil)
+		mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+		mockDeleteAll := corpus.MockDeleteAll(nil)
+		mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+		var inserted []string
+		mockInsert := func(ctx context.Context, entry corpus.DTO) (int, error) {
+			inserted = append(inserted, fmt.Sprintf("%s:%s", *entry.TweetText, entry.Categorization))
+			return len(inserted), nil
+		}
+
+		create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+		got := create(context.Background(), corpus.CreateOptions{Duplicates: tt.duplicates})
+
+		assert.Nil(t, got)
+		assert.Equal(t, tt.expected, inserted)
+	}
+}
+
+func TestCreate_successSkippingTheNearDuplicatesThatCannotBeRetrieved(t *testing.T) {
+	representativeID := 1
+	categorizedTweets := []categorized.DAO{
+		{ID: 1, TweetID: 2, Categorization: categorized.VerdictPositive},
+	}
+	mockSelectTweetByID := func(ctx context.Context, id int) (tweets.DAO, error) {
+		if id == 3 {
+			return tweets.DAO{}, errors.New("failed to select tweet by id")
+		}
+		tweet := tweets.MockTweetDAO()
+		tweet.ID = id
+		tweet.QuoteID = nil
+		text := strconv.Itoa(id)
+		tweet.TextContent = &text
+		tweet.RepresentativeID = &representativeID
+		return tweet, nil
+	}
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations(categorizedTweets, nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{2, 3, 4}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+	var inserted []string
+	mockInsert := func(ctx context.Context, entry corpus.DTO) (int, error) {
+		inserted = append(inserted, fmt.Sprintf("%s:%s", *entry.TweetText, entry.Categorization))
+		return len(inserted), nil
+	}
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	got := create(context.Background(), corpus.CreateOptions{Duplicates: corpus.PropagateDuplicates})
+
+	assert.Nil(t, got)
+	assert.Equal(t, []string{"2:POSITIVE", "1:POSITIVE", "4:POSITIVE"}, inserted)
+}
+
+func TestCreate_failsWhenSelectDuplicateMembersThrowsError(t *testing.T) {
+	representativeID := 123
+	tweet := tweets.MockTweetDAO()
+	tweet.RepresentativeID = &representativeID
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweet, nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers(nil, errors.New("failed to select members"))
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+	mockInsert := corpus.MockInsert(nil)
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	want := corpus.FailedToRetrieveDuplicateMembers
+	got := create(context.Background(), corpus.CreateOptions{Duplicates: corpus.PropagateDuplicates})
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreate_successIncludingTheContextOfTheReplies(t *testing.T) {
+	inReplyToStatusID := "1111111111111111111"
+	tweet := tweets.MockTweetDAO()
+	tweet.InReplyToStatusID = &inReplyToStatusID
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweet, nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{inReplyToStatusID: parents.MockConversation()}, nil)
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+	var inserted []corpus.DTO
+	mockInsert := func(ctx context.Context, entry corpus.DTO) (int, error) {
+		inserted = append(inserted, entry)
+		return len(inserted), nil
+	}
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	got := create(context.Background(), corpus.CreateOptions{IncludeContext: true})
+
+	assert.Nil(t, got)
+	assert.Len(t, inserted, 1)
+	assert.Equal(t, "test parent", *inserted[0].TweetParentText)
+}
+
+func TestCreate_failsWhenSelectConversationsThrowsError(t *testing.T) {
+	mockSelectByCategorizations := categorized.MockSelectByCategorizations([]categorized.DAO{categorized.MockCategorizedTweetDAO()}, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+	mockSelectQuoteByID := quotes.MockSelectByID(quotes.MockTweetQuoteDAO(), nil)
+	mockSelectCriteriaLanguages := language.MockSelectCriteriaLanguages([]string{"es"}, nil)
+	mockSelectDuplicateMembers := duplicates.MockSelectMembers([]int{}, nil)
+	mockSelectConversations := parents.MockSelectConversations(nil, errors.New("failed to select conversations"))
+	mockDeleteAll := corpus.MockDeleteAll(nil)
+	mockFlaggedAnnotators := gold.MockFlaggedAnnotators([]int{}, nil)
+	mockInsert := corpus.MockInsert(nil)
+
+	create := corpus.MakeCreate(mockSelectByCategorizations, mockSelectTweetByID, mockSelectQuoteByID, mockSelectCriteriaLanguages, mockSelectDuplicateMembers, mockSelectConversations, mockDeleteAll, mockInsert, mockFlaggedAnnotators)
+
+	want := corpus.FailedToRetrieveConversations
+	got := create(context.Background(), corpus.CreateOptions{IncludeContext: true})
 
 	assert.Equal(t, want, got)
 }
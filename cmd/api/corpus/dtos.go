@@ -2,15 +2,26 @@ package corpus
 
 // DTO represents a corpus entry to be inserted into the 'corpus' table
 type DTO struct {
-	TweetAuthor    string   `json:"tweet_author"`
-	TweetAvatar    *string  `json:"tweet_avatar,omitempty"`
-	TweetText      *string  `json:"tweet_text,omitempty"`
-	TweetImages    []string `json:"tweet_images,omitempty"`
-	IsTweetAReply  bool     `json:"is_tweet_a_reply"`
-	QuoteAuthor    *string  `json:"quote_author"`
-	QuoteAvatar    *string  `json:"quote_avatar,omitempty"`
-	QuoteText      *string  `json:"quote_text,omitempty"`
-	QuoteImages    []string `json:"quote_images,omitempty"`
-	IsQuoteAReply  *bool    `json:"is_quote_a_reply,omitempty"`
-	Categorization string   `json:"categorization"`
+	TweetAuthor     string   `json:"tweet_author"`
+	TweetAvatar     *string  `json:"tweet_avatar,omitempty"`
+	TweetText       *string  `json:"tweet_text,omitempty"`
+	TweetImages     []string `json:"tweet_images,omitempty"`
+	IsTweetAReply   bool     `json:"is_tweet_a_reply"`
+	TweetHashtags   []string `json:"tweet_hashtags,omitempty"`
+	TweetMentions   []string `json:"tweet_mentions,omitempty"`
+	TweetURLs       []string `json:"tweet_urls,omitempty"`
+	TweetEmojis     []string `json:"tweet_emojis,omitempty"`
+	TweetCashtags   []string `json:"tweet_cashtags,omitempty"`
+	TweetParentText *string  `json:"tweet_parent_text,omitempty"`
+	QuoteAuthor     *string  `json:"quote_author"`
+	QuoteAvatar     *string  `json:"quote_avatar,omitempty"`
+	QuoteText       *string  `json:"quote_text,omitempty"`
+	QuoteImages     []string `json:"quote_images,omitempty"`
+	IsQuoteAReply   *bool    `json:"is_quote_a_reply,omitempty"`
+	QuoteHashtags   []string `json:"quote_hashtags,omitempty"`
+	QuoteMentions   []string `json:"quote_mentions,omitempty"`
+	QuoteURLs       []string `json:"quote_urls,omitempty"`
+	QuoteEmojis     []string `json:"quote_emojis,omitempty"`
+	QuoteCashtags   []string `json:"quote_cashtags,omitempty"`
+	Categorization  string   `json:"categorization"`
 }
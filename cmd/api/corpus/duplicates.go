@@ -0,0 +1,129 @@
+package corpus
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"ahbcc/cmd/api/tweets"
+	"ahbcc/cmd/api/tweets/categorized"
+	"ahbcc/cmd/api/tweets/duplicates"
+	"ahbcc/internal/log"
+)
+
+const (
+	// KeepDuplicates keeps the verdicts of all the near-duplicate tweets as they were given. It is the default
+	KeepDuplicates string = "keep"
+
+	// DeduplicateDuplicates keeps only one tweet of each group of near-duplicate tweets: its representative, if it was
+	// categorized, or the categorized tweet with the lowest ID otherwise
+	DeduplicateDuplicates string = "deduplicate"
+
+	// PropagateDuplicates copies the verdicts of the tweet chosen as in DeduplicateDuplicates to the rest of the tweets
+	// of its group that weren't categorized
+	PropagateDuplicates string = "propagate"
+)
+
+// candidate represents a categorized tweet that may become a row of the corpus
+type candidate struct {
+	categorized categorized.DAO
+	tweet       tweets.DAO
+}
+
+// IsValidDuplicatesMode validates that the mode is one of the supported ways of handling the near-duplicate tweets
+func IsValidDuplicatesMode(mode string) bool {
+	return mode == KeepDuplicates || mode == DeduplicateDuplicates || mode == PropagateDuplicates
+}
+
+// groupKey returns the key of the near-duplicate group of the tweet. The tweets that aren't near-duplicates of any
+// other one are a group by themselves
+func groupKey(tweet tweets.DAO) int {
+	if tweet.RepresentativeID != nil {
+		return *tweet.RepresentativeID
+	}
+
+	return tweet.ID
+}
+
+// chosenTweets returns the ID of the categorized tweet chosen to stand for each group of near-duplicate tweets: the
+// representative of the group, if it was categorized, or the categorized tweet with the lowest ID otherwise
+func chosenTweets(candidates []candidate) map[int]int {
+	chosen := make(map[int]int)
+	for _, c := range candidates {
+		key := groupKey(c.tweet)
+		current, ok := chosen[key]
+		if !ok || (current != key && (c.tweet.ID == key || c.tweet.ID < current)) {
+			chosen[key] = c.tweet.ID
+		}
+	}
+
+	return chosen
+}
+
+// deduplicate keeps only the verdicts of the tweet chosen to stand for each group of near-duplicate tweets
+func deduplicate(candidates []candidate) []candidate {
+	chosen := chosenTweets(candidates)
+
+	return slices.DeleteFunc(slices.Clone(candidates), func(c candidate) bool {
+		return chosen[groupKey(c.tweet)] != c.tweet.ID
+	})
+}
+
+// propagate appends, for each group of near-duplicate tweets, a copy of the verdicts of the tweet chosen to stand for
+// the group to each of the tweets of the group that weren't categorized. The tweets that can't be retrieved are logged
+// and skipped, so the rest of the corpus is still created
+func propagate(ctx context.Context, candidates []candidate, selectDuplicateMembers duplicates.SelectMembers, selectTweetByID tweets.SelectByID) ([]candidate, error) {
+	chosen := chosenTweets(candidates)
+
+	categorizedTweets := make(map[int]bool)
+	verdicts := make(map[int][]categorized.DAO)
+	for _, c := range candidates {
+		categorizedTweets[c.tweet.ID] = true
+		if c.tweet.RepresentativeID != nil && chosen[groupKey(c.tweet)] == c.tweet.ID {
+			verdicts[groupKey(c.tweet)] = append(verdicts[groupKey(c.tweet)], c.categorized)
+		}
+	}
+
+	representatives := make([]int, 0, len(verdicts))
+	for representativeID := range verdicts {
+		representatives = append(representatives, representativeID)
+	}
+	slices.Sort(representatives)
+
+	propagated := slices.Clone(candidates)
+	var copies int
+	var skipped []int
+	for _, representativeID := range representatives {
+		members, err := selectDuplicateMembers(ctx, representativeID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveDuplicateMembers
+		}
+
+		for _, tweetID := range append([]int{representativeID}, members...) {
+			if categorizedTweets[tweetID] {
+				continue
+			}
+
+			tweetData, err := selectTweetByID(ctx, tweetID)
+			if err != nil {
+				log.Warn(ctx, fmt.Sprintf("The verdicts of the group %d couldn't be propagated to the tweet %d: %s", representativeID, tweetID, err.Error()))
+				skipped = append(skipped, tweetID)
+				continue
+			}
+
+			for _, verdict := range verdicts[representativeID] {
+				verdict.TweetID = tweetID
+				propagated = append(propagated, candidate{categorized: verdict, tweet: tweetData})
+				copies++
+			}
+		}
+	}
+
+	log.Info(ctx, fmt.Sprintf("Propagated %d verdicts to the uncategorized near-duplicates of %d groups\n", copies, len(representatives)))
+	if len(skipped) > 0 {
+		log.Warn(ctx, fmt.Sprintf("Skipped %d uncategorized near-duplicates that couldn't be retrieved: %v", len(skipped), skipped))
+	}
+
+	return propagated, nil
+}
@@ -11,9 +11,22 @@ var (
 	FailedToCleanUpCorpusTable                         = errors.New("failed to clean up corpus table")
 	FailedToExecuteSelectAll                           = errors.New("failed to execute select all")
 	InvalidExportFormat                                = errors.New("invalid export format")
+	FailedToRetrieveFlaggedAnnotators                  = errors.New("failed to retrieve flagged annotators")
+	FailedToRetrieveCriteriaLanguages                  = errors.New("failed to retrieve criteria languages")
+	InvalidPrivacyProfile                              = errors.New("privacy profile must be raw, pseudonymized or strict")
+	MissingPseudonymizationKey                         = errors.New("the pseudonymization key is not configured")
+	FailedToAnonymizeCorpus                            = errors.New("failed to anonymize corpus")
+	InvalidDuplicatesMode                              = errors.New("duplicates mode must be keep, deduplicate or propagate")
+	FailedToRetrieveDuplicateMembers                   = errors.New("failed to retrieve duplicate members")
+	FailedToRetrieveConversations                      = errors.New("failed to retrieve conversations")
+	AuthorizationTokenIsRequired                       = errors.New("authorization token is required")
 )
 
 const (
-	FailedToCreateCorpus string = "Failed to create corpus"
-	FailedToExportCorpus string = "Failed to export corpus"
+	FailedToCreateCorpus       string = "Failed to create corpus"
+	FailedToExportCorpus       string = "Failed to export corpus"
+	InvalidQueryParameter      string = "Invalid query parameter"
+	AuthorizationTokenRequired string = "Authorization token is required"
+	UserNotAuthorized          string = "User not authorized"
+	InvalidOrExpiredToken      string = "Invalid or expired session token"
 )
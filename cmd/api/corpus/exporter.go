@@ -18,8 +18,9 @@ const (
 )
 
 type (
-	// ExportCorpus exports the corpus in a given format
-	ExportCorpus func(ctx context.Context, format string) (*ExportResult, error)
+	// ExportCorpus exports the corpus in a given format, applying the given privacy profile. The profile is recorded in
+	// the metadata of the result and in the name of the file
+	ExportCorpus func(ctx context.Context, format string, profile string) (*ExportResult, error)
 
 	// ExportDataToJSON exports the corpus in a JSON format
 	ExportDataToJSON func(ctx context.Context, corpusData []DAO) (*ExportResult, error)
@@ -29,19 +30,30 @@ type (
 )
 
 // MakeExportCorpus creates a new ExportCorpus function
-func MakeExportCorpus(selectAll SelectAll, toJSON ExportDataToJSON, toCSV ExportDataToCSV) ExportCorpus {
-	return func(ctx context.Context, format string) (*ExportResult, error) {
+func MakeExportCorpus(selectAll SelectAll, anonymize Anonymize, toJSON ExportDataToJSON, toCSV ExportDataToCSV) ExportCorpus {
+	return func(ctx context.Context, format string, profile string) (*ExportResult, error) {
 		if format != JSONFormat && format != CSVFormat {
 			log.Error(ctx, fmt.Sprintf("Invalid export format: %s", format))
 			return nil, InvalidExportFormat
 		}
 
+		if !IsValidPrivacyProfile(profile) {
+			log.Error(ctx, fmt.Sprintf("Invalid privacy profile: %s", profile))
+			return nil, InvalidPrivacyProfile
+		}
+
 		corpusData, err := selectAll(ctx)
 		if err != nil {
 			log.Error(ctx, err.Error())
 			return nil, FailedToExecuteSelectAll
 		}
 
+		corpusData, err = anonymize(ctx, corpusData, profile)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToAnonymizeCorpus
+		}
+
 		var exportResult *ExportResult
 		switch format {
 		case JSONFormat:
@@ -49,8 +61,16 @@ func MakeExportCorpus(selectAll SelectAll, toJSON ExportDataToJSON, toCSV Export
 		case CSVFormat:
 			exportResult, err = toCSV(ctx, corpusData)
 		}
+		if err != nil {
+			return nil, err
+		}
+
+		exportResult.Metadata = NewExportMetadata(profile)
+		if profile != RawProfile {
+			exportResult.Filename = fmt.Sprintf("corpus_%s.%s", profile, format)
+		}
 
-		return exportResult, err
+		return exportResult, nil
 	}
 }
 
@@ -86,7 +106,9 @@ func MakeExportDataToCSV() ExportDataToCSV {
 
 		header := []string{
 			"ID", "TweetAuthor", "TweetAvatar", "TweetText", "TweetImages", "IsTweetAReply",
-			"QuoteAuthor", "QuoteAvatar", "QuoteText", "QuoteImages", "IsQuoteAReply", "Categorization",
+			"TweetHashtags", "TweetMentions", "TweetURLs", "TweetEmojis", "TweetCashtags", "TweetParentText",
+			"QuoteAuthor", "QuoteAvatar", "QuoteText", "QuoteImages", "IsQuoteAReply",
+			"QuoteHashtags", "QuoteMentions", "QuoteURLs", "QuoteEmojis", "QuoteCashtags", "Categorization",
 		}
 
 		err := writer.Write(header)
@@ -111,6 +133,11 @@ func MakeExportDataToCSV() ExportDataToCSV {
 				tweetImages = strings.Join(entry.TweetImages, ",")
 			}
 
+			tweetParentText := ""
+			if entry.TweetParentText != nil {
+				tweetParentText = *entry.TweetParentText
+			}
+
 			quoteAuthor := ""
 			if entry.QuoteAuthor != nil {
 				quoteAuthor = *entry.QuoteAuthor
@@ -143,11 +170,22 @@ func MakeExportDataToCSV() ExportDataToCSV {
 				tweetText,
 				tweetImages,
 				fmt.Sprintf("%v", entry.IsTweetAReply),
+				strings.Join(entry.TweetHashtags, ","),
+				strings.Join(entry.TweetMentions, ","),
+				strings.Join(entry.TweetURLs, ","),
+				strings.Join(entry.TweetEmojis, ","),
+				strings.Join(entry.TweetCashtags, ","),
+				tweetParentText,
 				quoteAuthor,
 				quoteAvatar,
 				quoteText,
 				quoteImages,
 				isQuoteAReply,
+				strings.Join(entry.QuoteHashtags, ","),
+				strings.Join(entry.QuoteMentions, ","),
+				strings.Join(entry.QuoteURLs, ","),
+				strings.Join(entry.QuoteEmojis, ","),
+				strings.Join(entry.QuoteCashtags, ","),
 				entry.Categorization,
 			}
 
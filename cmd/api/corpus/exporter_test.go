@@ -14,12 +14,13 @@ import (
 func TestMakeExportCorpus_successWithJSON(t *testing.T) {
 	corpusData := []corpus.DAO{{ID: 1, TweetAuthor: "author1"}}
 	mockSelectAll := corpus.MockSelectAll(corpusData, nil)
+	mockAnonymize := corpus.MockAnonymize(corpusData, nil)
 	mockExportToJSON := corpus.MockExportDataToJSON(corpus.MockJSONExportResult(), nil)
 	mockExportToCSV := corpus.MockExportDataToCSV(nil, nil)
 
-	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockExportToJSON, mockExportToCSV)
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
 
-	got, err := exportCorpus(context.Background(), corpus.JSONFormat)
+	got, err := exportCorpus(context.Background(), corpus.JSONFormat, corpus.RawProfile)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "application/json", got.ContentType)
@@ -29,12 +30,13 @@ func TestMakeExportCorpus_successWithJSON(t *testing.T) {
 func TestMakeExportCorpus_successWithCSV(t *testing.T) {
 	corpusData := []corpus.DAO{{ID: 1, TweetAuthor: "author1"}}
 	mockSelectAll := corpus.MockSelectAll(corpusData, nil)
+	mockAnonymize := corpus.MockAnonymize(corpusData, nil)
 	mockExportToJSON := corpus.MockExportDataToJSON(nil, nil)
 	mockExportToCSV := corpus.MockExportDataToCSV(corpus.MockCSVExportResult(), nil)
 
-	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockExportToJSON, mockExportToCSV)
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
 
-	got, err := exportCorpus(context.Background(), corpus.CSVFormat)
+	got, err := exportCorpus(context.Background(), corpus.CSVFormat, corpus.RawProfile)
 
 	assert.Nil(t, err)
 	assert.Equal(t, "text/csv", got.ContentType)
@@ -43,26 +45,28 @@ func TestMakeExportCorpus_successWithCSV(t *testing.T) {
 
 func TestMakeExportCorpus_failsWhenTheFormatRequestedIsInvalid(t *testing.T) {
 	mockSelectAll := corpus.MockSelectAll(nil, nil)
+	mockAnonymize := corpus.MockAnonymize(nil, nil)
 	mockExportToJSON := corpus.MockExportDataToJSON(nil, nil)
 	mockExportToCSV := corpus.MockExportDataToCSV(nil, nil)
 
-	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockExportToJSON, mockExportToCSV)
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
 
 	want := corpus.InvalidExportFormat
-	_, got := exportCorpus(context.Background(), "invalid-format")
+	_, got := exportCorpus(context.Background(), "invalid-format", corpus.RawProfile)
 
 	assert.Equal(t, want, got)
 }
 
 func TestMakeExportCorpus_failsWhenSelectAllThrowsError(t *testing.T) {
 	mockSelectAll := corpus.MockSelectAll(nil, errors.New("failed to select all"))
+	mockAnonymize := corpus.MockAnonymize(nil, nil)
 	mockExportToJSON := corpus.MockExportDataToJSON(nil, nil)
 	mockExportToCSV := corpus.MockExportDataToCSV(nil, nil)
 
-	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockExportToJSON, mockExportToCSV)
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
 
 	want := corpus.FailedToExecuteSelectAll
-	_, got := exportCorpus(context.Background(), corpus.JSONFormat)
+	_, got := exportCorpus(context.Background(), corpus.JSONFormat, corpus.RawProfile)
 
 	assert.Equal(t, want, got)
 }
@@ -71,12 +75,58 @@ func TestMakeExportCorpus_failsWhenJSONExportThrowsError(t *testing.T) {
 	corpusData := []corpus.DAO{{ID: 1, TweetAuthor: "author1"}}
 	want := errors.New("JSON encoding error")
 	mockSelectAll := corpus.MockSelectAll(corpusData, nil)
+	mockAnonymize := corpus.MockAnonymize(corpusData, nil)
 	mockExportToJSON := corpus.MockExportDataToJSON(nil, want)
 	mockExportToCSV := corpus.MockExportDataToCSV(nil, nil)
 
-	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockExportToJSON, mockExportToCSV)
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
 
-	_, got := exportCorpus(context.Background(), corpus.JSONFormat)
+	_, got := exportCorpus(context.Background(), corpus.JSONFormat, corpus.RawProfile)
+
+	assert.Equal(t, want, got)
+}
+
+func TestMakeExportCorpus_successRecordingThePrivacyProfile(t *testing.T) {
+	corpusData := []corpus.DAO{{ID: 1, TweetAuthor: "author1"}}
+	mockSelectAll := corpus.MockSelectAll(corpusData, nil)
+	mockAnonymize := corpus.MockAnonymize(corpusData, nil)
+	mockExportToJSON := corpus.MockExportDataToJSON(corpus.MockJSONExportResult(), nil)
+	mockExportToCSV := corpus.MockExportDataToCSV(nil, nil)
+
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
+
+	got, err := exportCorpus(context.Background(), corpus.JSONFormat, corpus.StrictProfile)
+
+	assert.Nil(t, err)
+	assert.Equal(t, "corpus_strict.json", got.Filename)
+	assert.Equal(t, corpus.ExportMetadata{PrivacyProfile: corpus.StrictProfile, PseudonymizedAuthors: true, RedactedTexts: true, DroppedMedia: true}, got.Metadata)
+}
+
+func TestMakeExportCorpus_failsWhenThePrivacyProfileRequestedIsInvalid(t *testing.T) {
+	mockSelectAll := corpus.MockSelectAll(nil, nil)
+	mockAnonymize := corpus.MockAnonymize(nil, nil)
+	mockExportToJSON := corpus.MockExportDataToJSON(nil, nil)
+	mockExportToCSV := corpus.MockExportDataToCSV(nil, nil)
+
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
+
+	want := corpus.InvalidPrivacyProfile
+	_, got := exportCorpus(context.Background(), corpus.JSONFormat, "invalid-profile")
+
+	assert.Equal(t, want, got)
+}
+
+func TestMakeExportCorpus_failsWhenAnonymizeThrowsError(t *testing.T) {
+	corpusData := []corpus.DAO{{ID: 1, TweetAuthor: "author1"}}
+	mockSelectAll := corpus.MockSelectAll(corpusData, nil)
+	mockAnonymize := corpus.MockAnonymize(nil, corpus.MissingPseudonymizationKey)
+	mockExportToJSON := corpus.MockExportDataToJSON(corpus.MockJSONExportResult(), nil)
+	mockExportToCSV := corpus.MockExportDataToCSV(nil, nil)
+
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
+
+	want := corpus.FailedToAnonymizeCorpus
+	_, got := exportCorpus(context.Background(), corpus.JSONFormat, corpus.PseudonymizedProfile)
 
 	assert.Equal(t, want, got)
 }
@@ -144,12 +194,13 @@ func TestMakeExportCorpus_CSVExportError(t *testing.T) {
 	corpusData := []corpus.DAO{{ID: 1, TweetAuthor: "author1"}}
 	want := errors.New("CSV encoding error")
 	mockSelectAll := corpus.MockSelectAll(corpusData, nil)
+	mockAnonymize := corpus.MockAnonymize(corpusData, nil)
 	mockExportToJSON := corpus.MockExportDataToJSON(nil, nil)
 	mockExportToCSV := corpus.MockExportDataToCSV(nil, want)
 
-	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockExportToJSON, mockExportToCSV)
+	exportCorpus := corpus.MakeExportCorpus(mockSelectAll, mockAnonymize, mockExportToJSON, mockExportToCSV)
 
-	_, got := exportCorpus(context.Background(), corpus.CSVFormat)
+	_, got := exportCorpus(context.Background(), corpus.CSVFormat, corpus.RawProfile)
 
 	assert.Equal(t, want, got)
 }
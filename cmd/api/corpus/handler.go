@@ -1,17 +1,85 @@
 package corpus
 
 import (
+	"encoding/json"
+	"errors"
 	"net/http"
+	"strconv"
 
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
 	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
 )
 
-// CreateCorpusHandlerV1 HTTP Handler of the endpoint /corpus/v1
-func CreateCorpusHandlerV1(createCorpus Create) http.HandlerFunc {
+// CreateCorpusHandlerV1 HTTP Handler of the endpoint /corpus/v1. Only the admins are allowed to create the corpus
+func CreateCorpusHandlerV1(authorize auth.Authorize, createCorpus Create) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		err := createCorpus(ctx)
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToCreateCorpus, nil, err)
+			}
+			return
+		}
+
+		var options CreateOptions
+		excludeFlaggedAnnotatorsParam := r.URL.Query().Get("exclude_flagged_annotators")
+		if excludeFlaggedAnnotatorsParam != "" {
+			excludeFlaggedAnnotators, err := strconv.ParseBool(excludeFlaggedAnnotatorsParam)
+			if err != nil {
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameter, nil, err)
+				return
+			}
+			options.ExcludeFlaggedAnnotators = excludeFlaggedAnnotators
+			ctx = log.With(ctx, log.Param("exclude_flagged_annotators", excludeFlaggedAnnotators))
+		}
+
+		excludeLanguageMismatchesParam := r.URL.Query().Get("exclude_language_mismatches")
+		if excludeLanguageMismatchesParam != "" {
+			excludeLanguageMismatches, err := strconv.ParseBool(excludeLanguageMismatchesParam)
+			if err != nil {
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameter, nil, err)
+				return
+			}
+			options.ExcludeLanguageMismatches = excludeLanguageMismatches
+			ctx = log.With(ctx, log.Param("exclude_language_mismatches", excludeLanguageMismatches))
+		}
+
+		includeContextParam := r.URL.Query().Get("include_context")
+		if includeContextParam != "" {
+			includeContext, err := strconv.ParseBool(includeContextParam)
+			if err != nil {
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameter, nil, err)
+				return
+			}
+			options.IncludeContext = includeContext
+			ctx = log.With(ctx, log.Param("include_context", includeContext))
+		}
+
+		options.Duplicates = r.URL.Query().Get("duplicates")
+		if options.Duplicates != "" {
+			if !IsValidDuplicatesMode(options.Duplicates) {
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameter, nil, InvalidDuplicatesMode)
+				return
+			}
+			ctx = log.With(ctx, log.Param("duplicates", options.Duplicates))
+		}
+
+		err = createCorpus(ctx, options)
 		if err != nil {
 			response.Send(ctx, w, http.StatusInternalServerError, FailedToCreateCorpus, nil, err)
 			return
@@ -31,12 +99,23 @@ func ExportCorpusHandlerV1(exportCorpus ExportCorpus) http.HandlerFunc {
 			format = JSONFormat
 		}
 
-		result, err := exportCorpus(ctx, format)
-		if err != nil {
+		profile := r.URL.Query().Get("privacy_profile")
+		if profile == "" {
+			profile = PseudonymizedProfile
+		}
+		ctx = log.With(ctx, log.Param("format", format), log.Param("privacy_profile", profile))
+
+		result, err := exportCorpus(ctx, format, profile)
+		if errors.Is(err, InvalidPrivacyProfile) {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameter, nil, err)
+			return
+		} else if err != nil {
 			response.Send(ctx, w, http.StatusInternalServerError, FailedToExportCorpus, nil, err)
 			return
 		}
 
+		metadata, _ := json.Marshal(result.Metadata)
+		w.Header().Set("X-Export-Metadata", string(metadata))
 		w.Header().Set("Content-Type", result.ContentType)
 		w.Header().Set("Content-Disposition", "attachment; filename="+result.Filename)
 		w.Write(result.Data)
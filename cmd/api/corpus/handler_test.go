@@ -9,6 +9,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"ahbcc/cmd/api/auth"
 	"ahbcc/cmd/api/corpus"
 )
 
@@ -16,8 +17,9 @@ func TestCreateCorpusHandlerV1_success(t *testing.T) {
 	mockCreateCorpus := corpus.MockCreate(nil)
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
 
-	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(mockCreateCorpus)
+	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(1, nil), mockCreateCorpus)
 
 	createCorpusHandlerV1(mockResponseWriter, mockRequest)
 
@@ -27,12 +29,55 @@ func TestCreateCorpusHandlerV1_success(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestCreateCorpusHandlerV1_failsWhenTheTokenIsMissing(t *testing.T) {
+	mockCreateCorpus := corpus.MockCreate(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1", nil)
+
+	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(1, nil), mockCreateCorpus)
+
+	createCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreateCorpusHandlerV1_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.FailedToSelectUserByID, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockCreateCorpus := corpus.MockCreate(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(-1, tt.err), mockCreateCorpus)
+
+		createCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
 func TestCreateCorpusHandlerV1_failsWhenCreateCorpusThrowsError(t *testing.T) {
 	mockCreateCorpus := corpus.MockCreate(errors.New("failed to create corpus"))
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
 
-	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(mockCreateCorpus)
+	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(1, nil), mockCreateCorpus)
 
 	createCorpusHandlerV1(mockResponseWriter, mockRequest)
 
@@ -42,6 +87,70 @@ func TestCreateCorpusHandlerV1_failsWhenCreateCorpusThrowsError(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestCreateCorpusHandlerV1_failsWhenExcludeFlaggedAnnotatorsIsNotABoolean(t *testing.T) {
+	mockCreateCorpus := corpus.MockCreate(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1?exclude_flagged_annotators=maybe", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(1, nil), mockCreateCorpus)
+
+	createCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreateCorpusHandlerV1_failsWhenExcludeLanguageMismatchesIsNotABoolean(t *testing.T) {
+	mockCreateCorpus := corpus.MockCreate(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1?exclude_language_mismatches=maybe", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(1, nil), mockCreateCorpus)
+
+	createCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreateCorpusHandlerV1_failsWhenIncludeContextIsNotABoolean(t *testing.T) {
+	mockCreateCorpus := corpus.MockCreate(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1?include_context=maybe", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(1, nil), mockCreateCorpus)
+
+	createCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCreateCorpusHandlerV1_failsWhenTheDuplicatesModeIsInvalid(t *testing.T) {
+	mockCreateCorpus := corpus.MockCreate(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/corpus/v1?duplicates=remove", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	createCorpusHandlerV1 := corpus.CreateCorpusHandlerV1(auth.MockAuthorize(1, nil), mockCreateCorpus)
+
+	createCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
 func TestExportCorpusHandlerV1_successWithJSONExport(t *testing.T) {
 	mockJSONExportResult := corpus.MockJSONExportResult()
 	mockExportCorpus := corpus.MockExportCorpus(mockJSONExportResult, nil)
@@ -58,6 +167,23 @@ func TestExportCorpusHandlerV1_successWithJSONExport(t *testing.T) {
 	assert.Equal(t, mockResponseWriter.Body.String(), string(mockJSONExportResult.Data))
 }
 
+func TestExportCorpusHandlerV1_successPseudonymizingTheCorpusByDefault(t *testing.T) {
+	var got string
+	mockExportCorpus := func(ctx context.Context, format string, profile string) (*corpus.ExportResult, error) {
+		got = profile
+		return corpus.MockJSONExportResult(), nil
+	}
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/corpus/v1", nil)
+
+	exportCorpusHandlerV1 := corpus.ExportCorpusHandlerV1(mockExportCorpus)
+
+	exportCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Code)
+	assert.Equal(t, corpus.PseudonymizedProfile, got)
+}
+
 func TestExportCorpusHandlerV1_successWithCSVExport(t *testing.T) {
 	mockCSVExportResult := corpus.MockCSVExportResult()
 	mockExportCorpus := corpus.MockExportCorpus(mockCSVExportResult, nil)
@@ -74,6 +200,36 @@ func TestExportCorpusHandlerV1_successWithCSVExport(t *testing.T) {
 	assert.Equal(t, mockResponseWriter.Body.String(), string(mockCSVExportResult.Data))
 }
 
+func TestExportCorpusHandlerV1_successRecordingTheExportMetadata(t *testing.T) {
+	mockJSONExportResult := corpus.MockJSONExportResult()
+	mockJSONExportResult.Metadata = corpus.NewExportMetadata(corpus.PseudonymizedProfile)
+	mockExportCorpus := corpus.MockExportCorpus(mockJSONExportResult, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/corpus/v1?format=json&privacy_profile=pseudonymized", nil)
+
+	exportCorpusHandlerV1 := corpus.ExportCorpusHandlerV1(mockExportCorpus)
+
+	exportCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	assert.Equal(t, http.StatusOK, mockResponseWriter.Code)
+	assert.JSONEq(t, `{"privacy_profile":"pseudonymized","pseudonymized_authors":true,"redacted_texts":true,"dropped_media":false}`, mockResponseWriter.Header().Get("X-Export-Metadata"))
+}
+
+func TestExportCorpusHandlerV1_failsWhenThePrivacyProfileIsInvalid(t *testing.T) {
+	mockExportCorpus := corpus.MockExportCorpus(nil, corpus.InvalidPrivacyProfile)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/corpus/v1?privacy_profile=invalid", nil)
+
+	exportCorpusHandlerV1 := corpus.ExportCorpusHandlerV1(mockExportCorpus)
+
+	exportCorpusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
 func TestExportCorpusHandlerV1_Error(t *testing.T) {
 	mockExportCorpus := corpus.MockExportCorpus(nil, errors.New("failed to export corpus"))
 	mockResponseWriter := httptest.NewRecorder()
@@ -12,8 +12,9 @@ type Insert func(ctx context.Context, entry DTO) (int, error)
 
 // MakeInsert creates a new Insert function
 func MakeInsert(db database.Connection) Insert {
-	const query string = `INSERT INTO corpus(tweet_author, tweet_avatar, tweet_text, tweet_images, is_tweet_a_reply, quote_author, quote_avatar, quote_text, quote_images, is_quote_a_reply, categorization) 
-						  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+	const query string = `INSERT INTO corpus(tweet_author, tweet_avatar, tweet_text, tweet_images, is_tweet_a_reply, tweet_hashtags, tweet_mentions, tweet_urls, tweet_emojis, tweet_cashtags, tweet_parent_text,
+						  					 quote_author, quote_avatar, quote_text, quote_images, is_quote_a_reply, quote_hashtags, quote_mentions, quote_urls, quote_emojis, quote_cashtags, categorization) 
+						  VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20, $21, $22)
 						  RETURNING id;`
 
 	return func(ctx context.Context, entry DTO) (int, error) {
@@ -27,11 +28,22 @@ func MakeInsert(db database.Connection) Insert {
 			entry.TweetText,
 			entry.TweetImages,
 			entry.IsTweetAReply,
+			entry.TweetHashtags,
+			entry.TweetMentions,
+			entry.TweetURLs,
+			entry.TweetEmojis,
+			entry.TweetCashtags,
+			entry.TweetParentText,
 			entry.QuoteAuthor,
 			entry.QuoteAvatar,
 			entry.QuoteText,
 			entry.QuoteImages,
 			entry.IsQuoteAReply,
+			entry.QuoteHashtags,
+			entry.QuoteMentions,
+			entry.QuoteURLs,
+			entry.QuoteEmojis,
+			entry.QuoteCashtags,
 			entry.Categorization,
 		).Scan(&rowID)
 		if err != nil {
@@ -25,18 +25,25 @@ func MockDeleteAll(err error) DeleteAll {
 
 // MockCreate mocks Create function
 func MockCreate(err error) Create {
-	return func(ctx context.Context) error {
+	return func(ctx context.Context, options CreateOptions) error {
 		return err
 	}
 }
 
 // MockExportCorpus mocks ExportCorpus function
 func MockExportCorpus(result *ExportResult, err error) ExportCorpus {
-	return func(ctx context.Context, format string) (*ExportResult, error) {
+	return func(ctx context.Context, format string, profile string) (*ExportResult, error) {
 		return result, err
 	}
 }
 
+// MockAnonymize mocks Anonymize function
+func MockAnonymize(entries []DAO, err error) Anonymize {
+	return func(ctx context.Context, corpusData []DAO, profile string) ([]DAO, error) {
+		return entries, err
+	}
+}
+
 // MockExportDataToJSON mocks ExportDataToJSON function
 func MockExportDataToJSON(result *ExportResult, err error) ExportDataToJSON {
 	return func(ctx context.Context, corpusData []DAO) (*ExportResult, error) {
@@ -84,11 +91,15 @@ func MockDTO() DTO {
 		TweetText:      &tweetText,
 		TweetImages:    []string{"image1.jpg", "image2.jpg"},
 		IsTweetAReply:  false,
+		TweetHashtags:  []string{"hashtag1", "hashtag2"},
+		TweetMentions:  []string{"mention1"},
+		TweetEmojis:    []string{"😭"},
 		QuoteAuthor:    &quoteAuthor,
 		QuoteAvatar:    &quoteAvatar,
 		QuoteText:      &quoteText,
 		QuoteImages:    []string{"quote_image1.jpg"},
 		IsQuoteAReply:  &isQuoteAReply,
+		QuoteHashtags:  []string{"hashtag1"},
 		Categorization: "POSITIVE",
 	}
 }
@@ -109,19 +120,25 @@ func MockDAO() DAO {
 		TweetText:      &tweetText,
 		TweetImages:    []string{"image1.jpg"},
 		IsTweetAReply:  false,
+		TweetHashtags:  []string{"hashtag1", "hashtag2"},
+		TweetMentions:  []string{"mention1"},
+		TweetEmojis:    []string{"😭"},
 		QuoteAuthor:    &quoteAuthor,
 		QuoteAvatar:    &quoteAvatar,
 		QuoteText:      &quoteText,
 		QuoteImages:    []string{"quote_image1.jpg"},
 		IsQuoteAReply:  &isQuoteAReply,
+		QuoteHashtags:  []string{"hashtag1"},
 		Categorization: "POSITIVE",
 	}
 }
 
 // MockCSVData mocks the string result of a CSV file
 func MockCSVData() string {
-	return "ID,TweetAuthor,TweetAvatar,TweetText,TweetImages,IsTweetAReply,QuoteAuthor,QuoteAvatar,QuoteText,QuoteImages,IsQuoteAReply,Categorization\n" +
-		"1,test_author,test_avatar,test_text,image1.jpg,false,quote_author,quote_avatar,quote_text,quote_image1.jpg,true,POSITIVE\n"
+	return "ID,TweetAuthor,TweetAvatar,TweetText,TweetImages,IsTweetAReply,TweetHashtags,TweetMentions,TweetURLs,TweetEmojis,TweetCashtags,TweetParentText," +
+		"QuoteAuthor,QuoteAvatar,QuoteText,QuoteImages,IsQuoteAReply,QuoteHashtags,QuoteMentions,QuoteURLs,QuoteEmojis,QuoteCashtags,Categorization\n" +
+		"1,test_author,test_avatar,test_text,image1.jpg,false,\"hashtag1,hashtag2\",mention1,,😭,,," +
+		"quote_author,quote_avatar,quote_text,quote_image1.jpg,true,hashtag1,,,,,POSITIVE\n"
 }
 
 // MockJSONData mocks the string result of a JSON file
@@ -134,11 +151,15 @@ func MockJSONData() string {
 		"TweetText": "test_text",
 		"TweetImages": ["image1.jpg"],
 		"IsTweetAReply": false,
+		"TweetHashtags": ["hashtag1", "hashtag2"],
+		"TweetMentions": ["mention1"],
+		"TweetEmojis": ["😭"],
 		"QuoteAuthor": "quote_author",
 		"QuoteAvatar": "quote_avatar",
 		"QuoteText": "quote_text",
 		"QuoteImages": ["quote_image1.jpg"],
 		"IsQuoteAReply": true,
+		"QuoteHashtags": ["hashtag1"],
 		"Categorization": "POSITIVE"
 	  }
 	]`
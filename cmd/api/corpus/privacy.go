@@ -0,0 +1,146 @@
+package corpus
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+
+	"ahbcc/internal/log"
+)
+
+const (
+	// RawProfile exports the corpus as it is stored
+	RawProfile string = "raw"
+
+	// PseudonymizedProfile is the default profile. It replaces the authors with keyed-hash pseudonyms, and the @mentions, URLs, emails and phone
+	// numbers of the texts with placeholders. The mentioned users are pseudonymized, and the URLs dropped, from the
+	// entities too
+	PseudonymizedProfile string = "pseudonymized"
+
+	// StrictProfile applies the PseudonymizedProfile and also drops the avatars and the images
+	StrictProfile string = "strict"
+
+	URLPlaceholder   string = "[URL]"
+	EmailPlaceholder string = "[EMAIL]"
+	PhonePlaceholder string = "[PHONE]"
+)
+
+var (
+	urlRegexp     = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+`)
+	emailRegexp   = regexp.MustCompile(`[A-Za-z0-9._%+-]+@[A-Za-z0-9.-]+\.[A-Za-z]{2,}`)
+	mentionRegexp = regexp.MustCompile(`@([A-Za-z0-9_]{1,15})`)
+	phoneRegexp   = regexp.MustCompile(`(^|[^\w@])((?:\+\d{1,3}[\s.-]?)?(?:\(\d{2,4}\)[\s.-]?|\d{2,4}[\s.-]?)\d{3,4}[\s.-]?\d{4})\b`)
+)
+
+// Anonymize applies the given privacy profile to the corpus entries, returning a copy of them
+type Anonymize func(ctx context.Context, corpusData []DAO, profile string) ([]DAO, error)
+
+// MakeAnonymize creates a new Anonymize. The key is used to compute the pseudonyms, so the same author gets the same
+// pseudonym in the whole export, and in every export made with the same key, but it can't be reverted without the key
+func MakeAnonymize(key string) Anonymize {
+	return func(ctx context.Context, corpusData []DAO, profile string) ([]DAO, error) {
+		if !IsValidPrivacyProfile(profile) {
+			log.Error(ctx, InvalidPrivacyProfile.Error())
+			return nil, InvalidPrivacyProfile
+		}
+
+		if profile == RawProfile {
+			return corpusData, nil
+		}
+
+		if key == "" {
+			log.Error(ctx, MissingPseudonymizationKey.Error())
+			return nil, MissingPseudonymizationKey
+		}
+
+		anonymized := make([]DAO, 0, len(corpusData))
+		for _, entry := range corpusData {
+			entry.TweetAuthor = pseudonym(key, entry.TweetAuthor)
+			entry.TweetText = redact(key, entry.TweetText)
+			entry.TweetParentText = redact(key, entry.TweetParentText)
+			if entry.QuoteAuthor != nil {
+				quoteAuthor := pseudonym(key, *entry.QuoteAuthor)
+				entry.QuoteAuthor = &quoteAuthor
+			}
+			entry.QuoteText = redact(key, entry.QuoteText)
+			entry.TweetMentions = pseudonyms(key, entry.TweetMentions)
+			entry.QuoteMentions = pseudonyms(key, entry.QuoteMentions)
+			entry.TweetURLs = nil
+			entry.QuoteURLs = nil
+
+			if profile == StrictProfile {
+				entry.TweetAvatar = nil
+				entry.TweetImages = nil
+				entry.QuoteAvatar = nil
+				entry.QuoteImages = nil
+			}
+
+			anonymized = append(anonymized, entry)
+		}
+
+		return anonymized, nil
+	}
+}
+
+// IsValidPrivacyProfile validates that the profile is one of the supported privacy profiles
+func IsValidPrivacyProfile(profile string) bool {
+	return profile == RawProfile || profile == PseudonymizedProfile || profile == StrictProfile
+}
+
+// NewExportMetadata describes what the given privacy profile did to the exported corpus
+func NewExportMetadata(profile string) ExportMetadata {
+	anonymized := profile == PseudonymizedProfile || profile == StrictProfile
+
+	return ExportMetadata{
+		PrivacyProfile:       profile,
+		PseudonymizedAuthors: anonymized,
+		RedactedTexts:        anonymized,
+		DroppedMedia:         profile == StrictProfile,
+	}
+}
+
+// pseudonym returns the keyed-hash pseudonym of an author. The leading @ and the case are ignored, so an author and the
+// @mentions to it get the same pseudonym
+func pseudonym(key, author string) string {
+	mac := hmac.New(sha256.New, []byte(key))
+	mac.Write([]byte(strings.ToLower(strings.TrimPrefix(author, "@"))))
+
+	return "user_" + hex.EncodeToString(mac.Sum(nil))[:12]
+}
+
+// pseudonyms returns the pseudonyms of the mentioned users
+func pseudonyms(key string, mentions []string) []string {
+	if mentions == nil {
+		return nil
+	}
+
+	pseudonymized := make([]string, 0, len(mentions))
+	for _, mention := range mentions {
+		pseudonymized = append(pseudonymized, pseudonym(key, mention))
+	}
+
+	return pseudonymized
+}
+
+// redact replaces the URLs, emails and phone numbers of the text with placeholders, and the @mentions with the
+// pseudonyms of the mentioned users. The URLs and the emails are replaced first, because they can contain an @, and the
+// phone numbers before the @mentions, so the digits of the pseudonyms are never taken as one. A phone number must start
+// a word, other than an @mention, and have at least 9 digits, so the numbers inside the words and the shorter ones,
+// such as the dates or the amounts, are kept
+func redact(key string, text *string) *string {
+	if text == nil {
+		return nil
+	}
+
+	redacted := urlRegexp.ReplaceAllString(*text, URLPlaceholder)
+	redacted = emailRegexp.ReplaceAllString(redacted, EmailPlaceholder)
+	redacted = phoneRegexp.ReplaceAllString(redacted, "${1}"+PhonePlaceholder)
+	redacted = mentionRegexp.ReplaceAllStringFunc(redacted, func(mention string) string {
+		return "@" + pseudonym(key, mention)
+	})
+
+	return &redacted
+}
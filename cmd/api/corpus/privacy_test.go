@@ -0,0 +1,164 @@
+package corpus_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/corpus"
+)
+
+func TestAnonymize_successWithRawProfile(t *testing.T) {
+	corpusData := []corpus.DAO{corpus.MockDAO()}
+
+	anonymize := corpus.MakeAnonymize("")
+
+	want := corpusData
+	got, err := anonymize(context.Background(), corpusData, corpus.RawProfile)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestAnonymize_successWithPseudonymizedProfile(t *testing.T) {
+	entry := corpus.MockDAO()
+	tweetText := "Hey @Quote_Author, write me to john.doe@mail.com or call +54 11 5555-1234, see https://t.co/abc123 or www.test.com"
+	entry.TweetText = &tweetText
+	corpusData := []corpus.DAO{entry}
+
+	anonymize := corpus.MakeAnonymize("secret")
+
+	got, err := anonymize(context.Background(), corpusData, corpus.PseudonymizedProfile)
+
+	assert.Nil(t, err)
+	assert.Regexp(t, `^user_[0-9a-f]{12}$`, got[0].TweetAuthor)
+	assert.Regexp(t, `^user_[0-9a-f]{12}$`, *got[0].QuoteAuthor)
+	assert.Equal(t, "Hey @"+*got[0].QuoteAuthor+", write me to [EMAIL] or call [PHONE], see [URL] or [URL]", *got[0].TweetText)
+	assert.Equal(t, entry.TweetAvatar, got[0].TweetAvatar)
+	assert.Equal(t, entry.TweetImages, got[0].TweetImages)
+	assert.Equal(t, "test_author", corpusData[0].TweetAuthor)
+}
+
+func TestAnonymize_successKeepingTheNumbersThatAreNotPhoneNumbers(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "Order 12345678 arrived", expected: "Order 12345678 arrived"},
+		{text: "Paid 2024-10-01 with code ab1234567890", expected: "Paid 2024-10-01 with code ab1234567890"},
+		{text: "Signed by user_123456789012", expected: "Signed by user_123456789012"},
+		{text: "Call 1155551234 or (011) 5555-1234", expected: "Call [PHONE] or [PHONE]"},
+	}
+
+	for _, tt := range tests {
+		entry := corpus.MockDAO()
+		entry.TweetText = &tt.text
+
+		anonymize := corpus.MakeAnonymize("secret")
+
+		got, err := anonymize(context.Background(), []corpus.DAO{entry}, corpus.PseudonymizedProfile)
+
+		assert.Nil(t, err)
+		assert.Equal(t, tt.expected, *got[0].TweetText)
+	}
+}
+
+func TestAnonymize_successWithoutRedactingTheDigitsOfThePseudonymsAsPhoneNumbers(t *testing.T) {
+	tests := []struct {
+		mention string
+	}{
+		{mention: "@user_123456789"},
+		{mention: "@123456789012"},
+	}
+
+	for _, tt := range tests {
+		entry := corpus.MockDAO()
+		tweetText := "Hey " + tt.mention + ", call 1155551234"
+		entry.TweetText = &tweetText
+
+		anonymize := corpus.MakeAnonymize("secret")
+
+		got, err := anonymize(context.Background(), []corpus.DAO{entry}, corpus.PseudonymizedProfile)
+
+		assert.Nil(t, err)
+		assert.Regexp(t, `^Hey @user_[0-9a-f]{12}, call \[PHONE\]$`, *got[0].TweetText)
+	}
+}
+
+func TestAnonymize_successPseudonymizingTheMentionsAndDroppingTheURLsOfTheEntities(t *testing.T) {
+	entry := corpus.MockDAO()
+	entry.TweetMentions = []string{"quote_author"}
+	entry.TweetURLs = []string{"https://t.co/abc123"}
+	entry.QuoteURLs = []string{"www.test.com"}
+	corpusData := []corpus.DAO{entry}
+
+	anonymize := corpus.MakeAnonymize("secret")
+
+	got, err := anonymize(context.Background(), corpusData, corpus.PseudonymizedProfile)
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{*got[0].QuoteAuthor}, got[0].TweetMentions)
+	assert.Nil(t, got[0].TweetURLs)
+	assert.Nil(t, got[0].QuoteURLs)
+	assert.Equal(t, entry.TweetHashtags, got[0].TweetHashtags)
+	assert.Equal(t, []string{"quote_author"}, corpusData[0].TweetMentions)
+}
+
+func TestAnonymize_successWithConsistentPseudonymsAcrossTheExport(t *testing.T) {
+	first := corpus.MockDAO()
+	second := corpus.MockDAO()
+	second.TweetAuthor = "@TEST_AUTHOR"
+	other := corpus.MockDAO()
+	other.TweetAuthor = "other_author"
+
+	anonymize := corpus.MakeAnonymize("secret")
+
+	got, err := anonymize(context.Background(), []corpus.DAO{first, second, other}, corpus.PseudonymizedProfile)
+
+	assert.Nil(t, err)
+	assert.Equal(t, got[0].TweetAuthor, got[1].TweetAuthor)
+	assert.NotEqual(t, got[0].TweetAuthor, got[2].TweetAuthor)
+}
+
+func TestAnonymize_successWithPseudonymsThatDependOnTheKey(t *testing.T) {
+	corpusData := []corpus.DAO{corpus.MockDAO()}
+
+	first, _ := corpus.MakeAnonymize("secret")(context.Background(), corpusData, corpus.PseudonymizedProfile)
+	second, _ := corpus.MakeAnonymize("another secret")(context.Background(), corpusData, corpus.PseudonymizedProfile)
+
+	assert.NotEqual(t, first[0].TweetAuthor, second[0].TweetAuthor)
+}
+
+func TestAnonymize_successWithStrictProfile(t *testing.T) {
+	corpusData := []corpus.DAO{corpus.MockDAO()}
+
+	anonymize := corpus.MakeAnonymize("secret")
+
+	got, err := anonymize(context.Background(), corpusData, corpus.StrictProfile)
+
+	assert.Nil(t, err)
+	assert.Nil(t, got[0].TweetAvatar)
+	assert.Nil(t, got[0].TweetImages)
+	assert.Nil(t, got[0].QuoteAvatar)
+	assert.Nil(t, got[0].QuoteImages)
+	assert.Regexp(t, `^user_[0-9a-f]{12}$`, got[0].TweetAuthor)
+}
+
+func TestAnonymize_failsWhenThePrivacyProfileIsInvalid(t *testing.T) {
+	anonymize := corpus.MakeAnonymize("secret")
+
+	want := corpus.InvalidPrivacyProfile
+	_, got := anonymize(context.Background(), []corpus.DAO{corpus.MockDAO()}, "invalid-profile")
+
+	assert.Equal(t, want, got)
+}
+
+func TestAnonymize_failsWhenTheKeyIsNotConfigured(t *testing.T) {
+	anonymize := corpus.MakeAnonymize("")
+
+	want := corpus.MissingPseudonymizationKey
+	_, got := anonymize(context.Background(), []corpus.DAO{corpus.MockDAO()}, corpus.PseudonymizedProfile)
+
+	assert.Equal(t, want, got)
+}
@@ -13,7 +13,9 @@ type SelectAll func(ctx context.Context) ([]DAO, error)
 // MakeSelectAll creates a new SelectAll function
 func MakeSelectAll(db database.Connection, collectRows database.CollectRows[DAO]) SelectAll {
 	const query string = `SELECT id, tweet_author, tweet_avatar, tweet_text, tweet_images, is_tweet_a_reply,
-						  quote_author, quote_avatar, quote_text, quote_images, is_quote_a_reply, categorization
+						  tweet_hashtags, tweet_mentions, tweet_urls, tweet_emojis, tweet_cashtags, tweet_parent_text,
+						  quote_author, quote_avatar, quote_text, quote_images, is_quote_a_reply,
+						  quote_hashtags, quote_mentions, quote_urls, quote_emojis, quote_cashtags, categorization
 				  		  FROM corpus`
 
 	return func(ctx context.Context) ([]DAO, error) {
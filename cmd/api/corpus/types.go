@@ -5,4 +5,21 @@ type ExportResult struct {
 	Data        []byte
 	ContentType string
 	Filename    string
+	Metadata    ExportMetadata
+}
+
+// ExportMetadata describes how the exported corpus was transformed to protect the privacy of the authors
+type ExportMetadata struct {
+	PrivacyProfile       string `json:"privacy_profile"`
+	PseudonymizedAuthors bool   `json:"pseudonymized_authors"`
+	RedactedTexts        bool   `json:"redacted_texts"`
+	DroppedMedia         bool   `json:"dropped_media"`
+}
+
+// CreateOptions represents the options that can be used to customize the creation of the corpus
+type CreateOptions struct {
+	ExcludeFlaggedAnnotators  bool
+	ExcludeLanguageMismatches bool
+	Duplicates                string
+	IncludeContext            bool
 }
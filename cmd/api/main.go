@@ -6,11 +6,20 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"time"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/rs/zerolog"
 
+	"ahbcc/cmd/api/annotators"
+	"ahbcc/cmd/api/audit"
 	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/auth/attempts"
+	"ahbcc/cmd/api/auth/invites"
+	"ahbcc/cmd/api/auth/oidc"
+	"ahbcc/cmd/api/authors"
 	"ahbcc/cmd/api/corpus"
+	"ahbcc/cmd/api/media"
 	"ahbcc/cmd/api/middleware"
 	"ahbcc/cmd/api/migrations"
 	"ahbcc/cmd/api/ping"
@@ -19,9 +28,17 @@ import (
 	"ahbcc/cmd/api/search/criteria/executions/summary"
 	"ahbcc/cmd/api/tweets"
 	"ahbcc/cmd/api/tweets/categorized"
+	"ahbcc/cmd/api/tweets/duplicates"
+	"ahbcc/cmd/api/tweets/entities"
+	"ahbcc/cmd/api/tweets/gold"
+	"ahbcc/cmd/api/tweets/language"
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/tweets/quotes"
 	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/password"
 	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/cmd/api/users"
 	"ahbcc/internal/database"
 	_http "ahbcc/internal/http"
 	"ahbcc/internal/log"
@@ -47,13 +64,65 @@ func main() {
 
 	log.NewCustomLogger(os.Stdout, logLevel)
 
-	httpClient := _http.NewClient()
+	httpClient := _http.NewClient(_http.Config{
+		Timeout:          time.Duration(setup.EnvInt("HTTP_CLIENT_TIMEOUT_MS", 3000)) * time.Millisecond,
+		MaxRetries:       setup.EnvInt("HTTP_CLIENT_MAX_RETRIES", 2),
+		BackoffBase:      time.Duration(setup.EnvInt("HTTP_CLIENT_BACKOFF_BASE_MS", 100)) * time.Millisecond,
+		BackoffMax:       time.Duration(setup.EnvInt("HTTP_CLIENT_BACKOFF_MAX_MS", 2000)) * time.Millisecond,
+		BreakerThreshold: setup.EnvInt("HTTP_CLIENT_BREAKER_THRESHOLD", 5),
+		BreakerCooldown:  time.Duration(setup.EnvInt("HTTP_CLIENT_BREAKER_COOLDOWN_SECONDS", 30)) * time.Second,
+		RequestID:        middleware.RequestIDFrom,
+	})
 
 	// Database
 	pg := setup.Init(database.InitPostgres())
 	defer pg.Close()
 	db := pg.Database()
 
+	// Settings
+	goldQuestionsRate := setup.EnvFloat("GOLD_QUESTIONS_RATE", 0.1)
+	goldAccuracyThreshold := setup.EnvFloat("GOLD_ACCURACY_THRESHOLD", 0.7)
+	goldMinimumAnswers := setup.EnvInt("GOLD_MINIMUM_ANSWERS", 10)
+	mediaArchivePath := setup.EnvString("MEDIA_ARCHIVE_PATH", "media_archive")
+	mediaArchiveBatchSize := setup.EnvInt("MEDIA_ARCHIVE_BATCH_SIZE", 100)
+	mediaMaxAttempts := setup.EnvInt("MEDIA_MAX_ATTEMPTS", 5)
+	mediaMaxSize := setup.EnvInt("MEDIA_MAX_SIZE", 10<<20)
+	auditPayloadKey := setup.EnvString("AUDIT_PAYLOAD_KEY", "")
+	entitiesBackfillBatchSize := setup.EnvInt("ENTITIES_BACKFILL_BATCH_SIZE", 1000)
+	languageBackfillBatchSize := setup.EnvInt("LANGUAGE_BACKFILL_BATCH_SIZE", 1000)
+	sessionKeyring := setup.Init(session.ParseKeyring(setup.EnvString("SESSION_SIGNING_KEYS", ""), setup.EnvString("SESSION_ACTIVE_KEY_ID", ""), setup.EnvString("SESSION_SECRET_KEY", "")))
+	sessionDuration := time.Duration(setup.EnvInt("SESSION_DURATION_HOURS", 720)) * time.Hour
+	loginAttemptsStoreKind := setup.EnvString("LOGIN_ATTEMPTS_STORE", "memory")
+	loginAttemptsLimits := attempts.Limits{
+		Window:                 time.Duration(setup.EnvInt("LOGIN_ATTEMPTS_WINDOW_MINUTES", 15)) * time.Minute,
+		MaxFailuresPerUsername: setup.EnvInt("LOGIN_MAX_FAILURES_PER_USERNAME", 5),
+		MaxFailuresPerIP:       setup.EnvInt("LOGIN_MAX_FAILURES_PER_IP", 20),
+		LockoutThreshold:       setup.EnvInt("LOGIN_LOCKOUT_THRESHOLD", 10),
+		LockoutDuration:        time.Duration(setup.EnvInt("LOGIN_LOCKOUT_MINUTES", 30)) * time.Minute,
+	}
+	passwordPolicy := password.Policy{
+		MinLength:           setup.EnvInt("PASSWORD_MIN_LENGTH", 8),
+		MinCharacterClasses: setup.EnvInt("PASSWORD_MIN_CHARACTER_CLASSES", 2),
+	}
+	passwordResetTokenDuration := time.Duration(setup.EnvInt("PASSWORD_RESET_TOKEN_DURATION_HOURS", 24)) * time.Hour
+	signUpInviteOnly := setup.EnvBool("SIGNUP_INVITE_ONLY", false)
+	inviteDuration := time.Duration(setup.EnvInt("INVITE_DURATION_HOURS", 168)) * time.Hour
+	oidcConfig := oidc.Config{
+		IssuerURL:         setup.EnvString("OIDC_ISSUER_URL", ""),
+		ClientID:          setup.EnvString("OIDC_CLIENT_ID", ""),
+		ClientSecret:      setup.EnvString("OIDC_CLIENT_SECRET", ""),
+		RedirectURL:       setup.EnvString("OIDC_REDIRECT_URL", ""),
+		Scopes:            oidc.ParseScopes(setup.EnvString("OIDC_SCOPES", "openid profile email")),
+		UsernameClaim:     setup.EnvString("OIDC_USERNAME_CLAIM", "preferred_username"),
+		AutoProvision:     setup.EnvBool("OIDC_AUTO_PROVISION", false),
+		LinkExistingUsers: setup.EnvBool("OIDC_LINK_EXISTING_USERS", false),
+	}
+	oidcPostLoginRedirectURL := setup.EnvString("OIDC_POST_LOGIN_REDIRECT_URL", "")
+	twoFactorIssuer := setup.EnvString("TWO_FACTOR_ISSUER", "AHBCC")
+	twoFactorRequiredRoles := user.ParseRoles(setup.EnvString("TWO_FACTOR_REQUIRED_ROLES", ""))
+	loginChallengeDuration := time.Duration(setup.EnvInt("LOGIN_CHALLENGE_DURATION_MINUTES", 5)) * time.Minute
+	loginChallengeMaxAttempts := setup.EnvInt("LOGIN_CHALLENGE_MAX_ATTEMPTS", 5)
+
 	// Services
 
 	// POST /migrations/run/v1 dependencies
@@ -61,35 +130,227 @@ func main() {
 	isMigrationApplied := migrations.MakeIsMigrationApplied(db)
 	insertAppliedMigration := migrations.MakeInsertAppliedMigration(db)
 	runMigrations := migrations.MakeRun(db, createMigrationsTable, isMigrationApplied, insertAppliedMigration)
+	isDatabaseBootstrapped := migrations.MakeIsBootstrapped(db)
 
 	// POST /auth/signup/v1 dependencies
 	userExists := user.MakeExists(db)
 	insertUser := user.MakeInsert(db)
-	signUp := auth.MakeSignUp(userExists, insertUser)
+	validatePassword := password.MakeValidate(passwordPolicy)
+	redeemInvite := invites.MakeRedeem(db)
+	signUp := auth.MakeSignUp(userExists, insertUser, validatePassword, redeemInvite, signUpInviteOnly)
 
 	// POST /auth/login/v1 dependencies
 	selectUserByUsername := user.MakeSelectByUsername(db)
 	deleteExpiredUserSessions := session.MakeDeleteExpiredSessions(db)
 	insertUserSession := session.MakeInsert(db)
-	createSessionToken := session.MakeCreateToken(insertUserSession)
-	logIn := auth.MakeLogIn(selectUserByUsername, deleteExpiredUserSessions, createSessionToken)
+	createSessionToken := session.MakeCreateToken(insertUserSession, sessionKeyring, sessionDuration)
+	loginAttemptsStore := attempts.MakeMemoryStore()
+	if loginAttemptsStoreKind == "postgres" {
+		collectFailureTimes := database.MakeCollectRows[time.Time](pgx.RowTo[time.Time])
+		loginAttemptsStore = attempts.MakePostgresStore(db, collectFailureTimes)
+	}
+	checkLoginAttempts := attempts.MakeCheck(loginAttemptsStore, loginAttemptsLimits)
+	insertLoginAttempt := attempts.MakeInsert(db)
+	recordLoginAttempt := attempts.MakeRecord(insertLoginAttempt, loginAttemptsStore, loginAttemptsLimits)
+	isTwoFactorEnabled := totp.MakeIsEnabled(db)
+	insertLoginChallenge := totp.MakeInsertChallenge(db)
+	createLoginChallenge := totp.MakeCreateChallenge(insertLoginChallenge, loginChallengeDuration)
+	logIn := auth.MakeLogIn(selectUserByUsername, deleteExpiredUserSessions, createSessionToken, checkLoginAttempts, recordLoginAttempt, isTwoFactorEnabled, createLoginChallenge)
+
+	// POST /auth/login/2fa/v1 dependencies
+	useLoginChallenge := totp.MakeUseChallenge(db)
+	selectSecondFactorByUserID := totp.MakeSelectByUserID(db)
+	updateSecondFactorLastUsedStep := totp.MakeUpdateLastUsedStep(db)
+	useRecoveryCode := totp.MakeUseRecoveryCode(db)
+	verifySecondFactor := totp.MakeVerify(selectSecondFactorByUserID, updateSecondFactorLastUsedStep, useRecoveryCode)
+	deleteLoginChallenge := totp.MakeDeleteChallenge(db)
+	selectUserByID := user.MakeSelectByID(db)
+	completeLogIn := auth.MakeCompleteLogIn(useLoginChallenge, selectUserByID, checkLoginAttempts, recordLoginAttempt, verifySecondFactor, deleteLoginChallenge, deleteExpiredUserSessions, createSessionToken, loginChallengeMaxAttempts)
 
 	// POST /auth/logout/v1 dependencies
 	deleteUserSession := session.MakeDelete(db)
 	logOut := auth.MakeLogOut(deleteUserSession)
 
+	// POST /auth/sessions/refresh/v1 dependencies
+	verifySessionToken := session.MakeVerifyToken(sessionKeyring)
+	updateSessionToken := session.MakeUpdateToken(db)
+	refreshSessionToken := session.MakeRefreshToken(verifySessionToken, updateSessionToken, sessionKeyring, sessionDuration)
+	refreshSession := auth.MakeRefreshSession(refreshSessionToken)
+
 	// POST /tweets/v1 dependencies
 	insertSingleQuote := quotes.MakeInsertSingle(db)
-	deleteOrphanQuotes := quotes.MakeDeleteOrphans(db)
-	insertTweets := tweets.MakeInsert(db, insertSingleQuote, deleteOrphanQuotes)
+	collectRelatedTweets := database.MakeCollectRows[tweets.RelatedDAO](nil)
+	insertParents := parents.MakeInsertChains(db)
+	upsertAuthors := authors.MakeUpsertSightings(db)
+	insertTweets := tweets.MakeInsert(db, collectRelatedTweets, insertSingleQuote, insertParents, upsertAuthors)
 
 	// POST /tweets/categorized/v1 dependencies
-	selectUserIDByToken := session.MakeSelectUserIDByToken(db)
+	selectUserIDByToken := auth.IdentifyActor(session.MakeSelectUserIDByToken(db, verifySessionToken))
 	selectTweetByID := tweets.MakeSelectByID(db)
 	selectByUserIDTweetIDAndSearchCriteriaID := categorized.MakeSelectByUserIDTweetIDAndSearchCriteriaID(db)
 	insertSingle := categorized.MakeInsertSingle(db)
 	insertCategorizedTweet := categorized.MakeInsertCategorizedTweet(selectUserIDByToken, selectTweetByID, selectByUserIDTweetIDAndSearchCriteriaID, insertSingle)
 
+	// GET /auth/sessions/v1 dependencies
+	collectActiveSessionDAORows := database.MakeCollectRows[session.ActiveDAO](nil)
+	selectActiveUserSessions := session.MakeSelectActiveByUserID(db, collectActiveSessionDAORows)
+	listSessions := auth.MakeListSessions(selectUserIDByToken, selectActiveUserSessions)
+
+	// DELETE /auth/sessions/{session_id}/v1 dependencies
+	deleteUserSessionByID := session.MakeDeleteByID(db)
+	revokeSession := auth.MakeRevokeSession(selectUserIDByToken, deleteUserSessionByID)
+
+	// DELETE /auth/sessions/v1 dependencies
+	deleteOtherUserSessions := session.MakeDeleteOthers(db)
+	revokeOtherSessions := auth.MakeRevokeOtherSessions(selectUserIDByToken, deleteOtherUserSessions)
+
+	// Role based authorization dependencies
+	authorize := auth.MakeAuthorize(selectUserIDByToken, selectUserByID, isTwoFactorEnabled, twoFactorRequiredRoles)
+
+	// POST /auth/2fa/v1 dependencies
+	upsertSecondFactorSecret := totp.MakeUpsertSecret(db)
+	enrollSecondFactor := totp.MakeEnroll(upsertSecondFactorSecret, twoFactorIssuer)
+	enrollTwoFactor := auth.MakeEnrollTwoFactor(selectUserIDByToken, selectUserByID, enrollSecondFactor)
+
+	// POST /auth/2fa/confirm/v1 dependencies
+	enableSecondFactor := totp.MakeEnable(db)
+	insertRecoveryCodes := totp.MakeInsertRecoveryCodes(db)
+	createRecoveryCodes := totp.MakeCreateRecoveryCodes(insertRecoveryCodes)
+	confirmSecondFactor := totp.MakeConfirm(selectSecondFactorByUserID, enableSecondFactor, createRecoveryCodes)
+	confirmTwoFactor := auth.MakeConfirmTwoFactor(selectUserIDByToken, confirmSecondFactor, deleteOtherUserSessions)
+
+	// DELETE /auth/2fa/v1 dependencies
+	deleteSecondFactor := totp.MakeDelete(db)
+	disableTwoFactor := auth.MakeDisableTwoFactor(selectUserIDByToken, selectUserByID, verifySecondFactor, deleteSecondFactor, twoFactorRequiredRoles)
+
+	// GET /auth/sessions/keys/v1 dependencies
+	collectKeyUsageDAORows := database.MakeCollectRows[session.KeyUsageDAO](nil)
+	selectSessionKeysUsage := session.MakeSelectKeysUsage(db, collectKeyUsageDAORows)
+	signingKeys := auth.MakeSigningKeys(authorize, sessionKeyring, selectSessionKeysUsage)
+
+	// DELETE /auth/lockouts/{username}/v1 dependencies
+	unlockLoginAttempts := attempts.MakeUnlock(loginAttemptsStore)
+	unlockAccount := auth.MakeUnlockAccount(authorize, unlockLoginAttempts)
+
+	// PUT /auth/password/v1 dependencies
+	updateUserPassword := user.MakeUpdatePassword(db)
+	changePassword := auth.MakeChangePassword(selectUserIDByToken, selectUserByID, validatePassword, updateUserPassword, deleteOtherUserSessions)
+
+	// POST /auth/password/reset/tokens/v1 dependencies
+	insertPasswordResetToken := password.MakeInsertResetToken(db)
+	createPasswordResetToken := password.MakeCreateResetToken(insertPasswordResetToken, passwordResetTokenDuration)
+	issuePasswordResetToken := auth.MakeIssuePasswordResetToken(authorize, selectUserByUsername, createPasswordResetToken)
+
+	// POST /auth/password/reset/v1 dependencies
+	selectUserIDByPasswordResetToken := password.MakeSelectUserIDByResetToken(db)
+	resetPasswordWithToken := password.MakeResetWithToken(db)
+	deleteAllUserSessions := session.MakeDeleteAll(db)
+	resetPassword := auth.MakeResetPassword(selectUserIDByPasswordResetToken, selectUserByID, validatePassword, resetPasswordWithToken, deleteAllUserSessions)
+
+	// POST /auth/invites/v1 dependencies
+	insertInvite := invites.MakeInsert(db)
+	createUserInvite := invites.MakeCreate(insertInvite)
+	createInvite := auth.MakeCreateInvite(authorize, createUserInvite, inviteDuration)
+
+	// GET /auth/invites/v1 dependencies
+	collectOutstandingInviteDAORows := database.MakeCollectRows[invites.OutstandingDAO](nil)
+	selectOutstandingInvites := invites.MakeSelectOutstanding(db, collectOutstandingInviteDAORows)
+	collectRedemptionDAORows := database.MakeCollectRows[invites.RedemptionDAO](nil)
+	selectInvitesRedemptions := invites.MakeSelectRedemptions(db, collectRedemptionDAORows)
+	listInvites := auth.MakeListInvites(authorize, selectOutstandingInvites, selectInvitesRedemptions)
+
+	// GET /auth/oidc/login/v1 dependencies
+	oidcHTTPClient := &http.Client{Timeout: 10 * time.Second}
+	discoverOIDCProvider := oidc.MakeDiscover(oidcHTTPClient, oidcConfig.IssuerURL)
+	insertOIDCState := oidc.MakeInsertState(db)
+	oidcAuthorizationURL := oidc.MakeAuthorizationURL(discoverOIDCProvider, insertOIDCState, oidcConfig)
+
+	// GET /auth/oidc/callback/v1 dependencies
+	consumeOIDCState := oidc.MakeConsumeState(db)
+	exchangeOIDCCode := oidc.MakeExchange(oidcHTTPClient, discoverOIDCProvider, oidcConfig)
+	selectOIDCKey := oidc.MakeSelectKey(oidcHTTPClient, discoverOIDCProvider, time.Minute)
+	verifyOIDCIDToken := oidc.MakeVerifyIDToken(selectOIDCKey, oidcConfig.IssuerURL, oidcConfig.ClientID)
+	authenticateOIDCUser := oidc.MakeAuthenticate(consumeOIDCState, exchangeOIDCCode, verifyOIDCIDToken, oidcConfig)
+	selectUserIDByOIDCIdentity := oidc.MakeSelectUserIDByIdentity(db)
+	insertOIDCIdentity := oidc.MakeInsertIdentity(db)
+	provisionOIDCUser := oidc.MakeProvisionUser(db)
+	resolveOIDCUser := oidc.MakeResolveUser(selectUserIDByOIDCIdentity, selectUserByUsername, insertOIDCIdentity, provisionOIDCUser, oidcConfig)
+	oidcLogIn := auth.MakeOIDCLogIn(authenticateOIDCUser, resolveOIDCUser, deleteExpiredUserSessions, createSessionToken, isTwoFactorEnabled, createLoginChallenge)
+
+	// PUT /tweets/{tweet_id}/gold/v1 dependencies
+	insertGoldTweet := gold.MakeInsert(db)
+	markGoldTweet := gold.MakeMark(authorize, selectTweetByID, insertGoldTweet)
+
+	// DELETE /tweets/{tweet_id}/gold/v1 dependencies
+	deleteGoldTweet := gold.MakeDelete(db)
+	unmarkGoldTweet := gold.MakeUnmark(authorize, deleteGoldTweet)
+
+	// GET /annotators/quality/v1 dependencies
+	collectAccuracyDAORows := database.MakeCollectRows[gold.AccuracyDAO](nil)
+	selectAccuracyByAnnotator := gold.MakeSelectAccuracyByAnnotator(db, collectAccuracyDAORows)
+	goldQuality := gold.MakeQuality(authorize, selectAccuracyByAnnotator, goldAccuracyThreshold, goldMinimumAnswers)
+
+	// GET /annotators/activity/v1 dependencies
+	collectDailyVerdictsDAORows := database.MakeCollectRows[annotators.DailyVerdictsDAO](nil)
+	selectDailyVerdicts := annotators.MakeSelectDailyVerdicts(db, collectDailyVerdictsDAORows)
+	collectVerdictsDistributionDAORows := database.MakeCollectRows[annotators.VerdictsDistributionDAO](nil)
+	selectVerdictsDistribution := annotators.MakeSelectVerdictsDistribution(db, collectVerdictsDistributionDAORows)
+	collectMedianIntervalDAORows := database.MakeCollectRows[annotators.MedianIntervalDAO](nil)
+	selectMedianIntervals := annotators.MakeSelectMedianIntervals(db, collectMedianIntervalDAORows)
+	collectProgressDAORows := database.MakeCollectRows[annotators.ProgressDAO](nil)
+	selectAnnotatorsProgress := annotators.MakeSelectProgress(db, collectProgressDAORows)
+	annotatorsActivity := annotators.MakeActivity(authorize, selectDailyVerdicts, selectVerdictsDistribution, selectMedianIntervals, selectAnnotatorsProgress)
+
+	// GET /users/v1 dependencies
+	collectUserDAORows := database.MakeCollectRows[users.DAO](nil)
+	selectAllUsers := users.MakeSelectAll(db, collectUserDAORows)
+	listUsers := users.MakeList(authorize, selectAllUsers)
+
+	// PUT /users/{user_id}/status/v1 dependencies
+	updateUserDisabled := user.MakeUpdateDisabled(db)
+	setUserStatus := users.MakeSetStatus(authorize, updateUserDisabled, deleteAllUserSessions)
+
+	// PUT /users/{user_id}/username/v1 dependencies
+	updateUsername := user.MakeUpdateUsername(db)
+	renameUser := users.MakeRename(authorize, userExists, updateUsername)
+
+	// DELETE /users/{user_id}/v1 dependencies
+	deleteUser := users.MakeDelete(db)
+	removeUser := users.MakeRemove(authorize, selectUserByID, deleteUser)
+
+	// GET /audit/v1 dependencies
+	collectAuditDAORows := database.MakeCollectRows[audit.DAO](nil)
+	selectAuditEntries := audit.MakeSelect(db, collectAuditDAORows)
+	queryAuditLog := audit.MakeQuery(authorize, selectAuditEntries)
+
+	// GET /audit/export/v1 dependencies
+	exportAuditLog := audit.MakeExport(authorize, selectAuditEntries)
+
+	// Audit middleware dependencies
+	insertAuditEntry := audit.MakeInsert(db)
+	auditMiddleware := audit.MakeMiddleware(insertAuditEntry, []byte(auditPayloadKey))
+
+	// GET /authors/{handle}/v1 dependencies
+	selectAuthorByHandle := authors.MakeSelectByHandle(db)
+	collectAvatarDAORows := database.MakeCollectRows[authors.AvatarDAO](nil)
+	selectAuthorAvatars := authors.MakeSelectAvatars(db, collectAvatarDAORows)
+	collectCriteriaTweetsDAORows := database.MakeCollectRows[authors.CriteriaTweetsDAO](nil)
+	selectAuthorTweetsPerCriteria := authors.MakeSelectTweetsPerCriteria(db, collectCriteriaTweetsDAORows)
+	selectAuthorVerdicts := authors.MakeSelectVerdicts(db)
+	authorProfile := authors.MakeProfile(authorize, selectAuthorByHandle, selectAuthorAvatars, selectAuthorTweetsPerCriteria, selectAuthorVerdicts)
+
+	// GET /authors/v1 dependencies
+	collectRankedAuthorDAORows := database.MakeCollectRows[authors.RankedAuthorDAO](nil)
+	selectAuthorsRanking := authors.MakeSelectRanking(db, collectRankedAuthorDAORows)
+	authorsRanking := authors.MakeRanking(authorize, selectAuthorsRanking)
+
+	// POST /tweets/categorize/batch/v1 dependencies
+	collectTweetsDAORows := database.MakeCollectRows[tweets.DAO](nil)
+	selectTweetsByIDs := tweets.MakeSelectByIDs(db, collectTweetsDAORows)
+	collectInsertedCategorizedTweetsRows := database.MakeCollectRows[categorized.InsertedDAO](nil)
+	insertMultipleCategorizedTweets := categorized.MakeInsertMultiple(db, collectInsertedCategorizedTweetsRows)
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(db, selectUserIDByToken, selectTweetsByIDs, insertMultipleCategorizedTweets)
+
 	// GET /criteria/v1
 	collectSummaryDAORows := database.MakeCollectRows[summary.DAO](nil)
 	selectAllCriteriaExecutionsSummaries := summary.MakeSelectAll(db, collectSummaryDAORows)
@@ -111,10 +372,17 @@ func main() {
 	resumeCriteria := criteria.MakeResume(selectCriteriaByID, selectLastDayExecutedByCriteriaID, selectExecutionsByStatuses, scrapperEnqueueCriteria)
 	initCriteria := criteria.MakeInit(selectExecutionsByStatuses, resumeCriteria)
 
-	// GET /criteria/{criteria_id}/tweets/v1 dependencies
+	// GET /criteria/{criteria_id}/tweets/v1 and GET /criteria/{criteria_id}/tweets/v2 dependencies
 	tweetsCustomScanner := tweets.CustomScanner()
 	collectTweetsDTORows := database.MakeCollectRows[tweets.CustomTweetDTO](tweetsCustomScanner)
-	selectBySearchCriteriaIDYearAndMonth := tweets.MakeSelectBySearchCriteriaIDYearAndMonth(db, collectTweetsDTORows, selectUserIDByToken)
+	collectConversationsLinkDAORows := database.MakeCollectRows[parents.LinkDAO](nil)
+	selectConversations := parents.MakeSelectConversations(db, collectConversationsLinkDAORows)
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(db, collectTweetsDTORows, selectUserIDByToken, selectConversations, goldQuestionsRate)
+
+	// GET /tweets/search/v1 dependencies
+	searchResultScanner := tweets.SearchResultScanner()
+	collectSearchResultRows := database.MakeCollectRows[tweets.SearchResultDTO](searchResultScanner)
+	searchTweets := tweets.MakeSearch(db, collectSearchResultRows, selectUserIDByToken)
 
 	// POST /criteria/{criteria_id}/enqueue/v1 dependencies
 	insertCriteriaExecution := executions.MakeInsertExecution(db)
@@ -141,40 +409,118 @@ func main() {
 	selectTweetQuoteByID := quotes.MakeSelectByID(db)
 	deleteAllCorpusRows := corpus.MakeDeleteAll(db)
 	insertCorpusRow := corpus.MakeInsert(db)
-	createCorpus := corpus.MakeCreate(selectCategorizedTweetsByCategorizations, selectTweetByID, selectTweetQuoteByID, deleteAllCorpusRows, insertCorpusRow)
+	flaggedAnnotators := gold.MakeFlaggedAnnotators(selectAccuracyByAnnotator, goldAccuracyThreshold, goldMinimumAnswers)
+	collectDuplicateMembersRows := database.MakeCollectRows[int](pgx.RowTo[int])
+	selectDuplicateMembers := duplicates.MakeSelectMembers(db, collectDuplicateMembersRows)
+	collectCriteriaLanguagesRows := database.MakeCollectRows[string](pgx.RowTo[string])
+	selectCriteriaLanguages := language.MakeSelectCriteriaLanguages(db, collectCriteriaLanguagesRows)
+	createCorpus := corpus.MakeCreate(selectCategorizedTweetsByCategorizations, selectTweetByID, selectTweetQuoteByID, selectCriteriaLanguages, selectDuplicateMembers, selectConversations, deleteAllCorpusRows, insertCorpusRow, flaggedAnnotators)
 
 	// GET /corpus/v1 dependencies
 	collectCorpusDAORows := database.MakeCollectRows[corpus.DAO](nil)
 	selectAllCorpusRows := corpus.MakeSelectAll(db, collectCorpusDAORows)
 	exportDataToJSON := corpus.MakeExportDataToJSON()
 	exportDataToCSV := corpus.MakeExportDataToCSV()
-	exportCorpus := corpus.MakeExportCorpus(selectAllCorpusRows, exportDataToJSON, exportDataToCSV)
+	anonymizeCorpus := corpus.MakeAnonymize(os.Getenv("CORPUS_PSEUDONYMIZATION_KEY"))
+	exportCorpus := corpus.MakeExportCorpus(selectAllCorpusRows, anonymizeCorpus, exportDataToJSON, exportDataToCSV)
+
+	// POST /media/archive/v1 dependencies
+	insertPendingMedia := media.MakeInsertPending(db)
+	collectMediaDAORows := database.MakeCollectRows[media.DAO](nil)
+	selectPendingMedia := media.MakeSelectPending(db, collectMediaDAORows, mediaMaxAttempts)
+	fetchMedia := media.MakeFetch(media.NewFetchClient(30*time.Second, media.Hosts), media.Hosts, int64(mediaMaxSize))
+	saveMedia := media.MakeSave(mediaArchivePath)
+	updateArchivedMedia := media.MakeUpdateArchived(db)
+	updateFailedMedia := media.MakeUpdateFailed(db)
+	archiveMedia := media.MakeArchive(authorize, insertPendingMedia, selectPendingMedia, fetchMedia, saveMedia, updateArchivedMedia, updateFailedMedia, mediaArchiveBatchSize)
+
+	// GET /media/v1 dependencies
+	selectArchivedMediaByURL := media.MakeSelectArchivedByURL(db)
+	readMedia := media.MakeRead(mediaArchivePath)
+	retrieveMedia := media.MakeRetrieve(authorize, selectArchivedMediaByURL, readMedia)
+
+	// POST /tweets/entities/backfill/v1 dependencies
+	collectPendingEntitiesDAORows := database.MakeCollectRows[entities.PendingDAO](nil)
+	selectPendingEntities := entities.MakeSelectPending(db, collectPendingEntitiesDAORows)
+	updateExtractedEntities := entities.MakeUpdateExtracted(db)
+	backfillEntities := entities.MakeBackfill(authorize, selectPendingEntities, updateExtractedEntities, entitiesBackfillBatchSize)
+
+	// POST /tweets/language/backfill/v1 dependencies
+	collectPendingLanguageDAORows := database.MakeCollectRows[language.PendingDAO](nil)
+	selectPendingLanguages := language.MakeSelectPending(db, collectPendingLanguageDAORows)
+	updateDetectedLanguages := language.MakeUpdateDetected(db)
+	backfillLanguages := language.MakeBackfill(authorize, selectPendingLanguages, updateDetectedLanguages, languageBackfillBatchSize)
+
+	// POST /tweets/duplicates/cluster/v1 dependencies
+	collectDuplicatesTextDAORows := database.MakeCollectRows[duplicates.TextDAO](nil)
+	selectDuplicatesTexts := duplicates.MakeSelectTexts(db, collectDuplicatesTextDAORows)
+	updateDuplicatesRepresentatives := duplicates.MakeUpdateRepresentatives(db)
+	clusterDuplicates := duplicates.MakeCluster(authorize, selectDuplicatesTexts, updateDuplicatesRepresentatives)
 
 	/* --- Router --- */
 	log.Info(ctx, "Initializing router...")
 	router := http.NewServeMux()
 	router.HandleFunc("GET /ping/v1", ping.HandlerV1())
-	router.HandleFunc("POST /migrations/run/v1", migrations.RunHandlerV1(runMigrations))
+	router.HandleFunc("POST /migrations/run/v1", migrations.RunHandlerV1(isDatabaseBootstrapped, authorize, runMigrations))
 	router.HandleFunc("POST /auth/signup/v1", auth.SignUpHandlerV1(signUp))
 	router.HandleFunc("POST /auth/login/v1", auth.LogInHandlerV1(logIn))
 	router.HandleFunc("POST /auth/logout/v1", auth.LogOutHandlerV1(logOut))
+	router.HandleFunc("POST /auth/sessions/refresh/v1", auth.RefreshSessionHandlerV1(refreshSession))
+	router.HandleFunc("GET /auth/sessions/v1", auth.ListSessionsHandlerV1(listSessions))
+	router.HandleFunc("GET /auth/sessions/keys/v1", auth.SigningKeysHandlerV1(signingKeys))
+	router.HandleFunc("DELETE /auth/lockouts/{username}/v1", auth.UnlockAccountHandlerV1(unlockAccount))
+	router.HandleFunc("PUT /auth/password/v1", auth.ChangePasswordHandlerV1(changePassword))
+	router.HandleFunc("POST /auth/password/reset/tokens/v1", auth.IssuePasswordResetTokenHandlerV1(issuePasswordResetToken))
+	router.HandleFunc("POST /auth/password/reset/v1", auth.ResetPasswordHandlerV1(resetPassword))
+	router.HandleFunc("POST /auth/invites/v1", auth.CreateInviteHandlerV1(createInvite))
+	router.HandleFunc("GET /auth/invites/v1", auth.ListInvitesHandlerV1(listInvites))
+	router.HandleFunc("POST /auth/login/2fa/v1", auth.CompleteLogInHandlerV1(completeLogIn))
+	router.HandleFunc("POST /auth/2fa/v1", auth.EnrollTwoFactorHandlerV1(enrollTwoFactor))
+	router.HandleFunc("POST /auth/2fa/confirm/v1", auth.ConfirmTwoFactorHandlerV1(confirmTwoFactor))
+	router.HandleFunc("DELETE /auth/2fa/v1", auth.DisableTwoFactorHandlerV1(disableTwoFactor))
+	if oidcConfig.Enabled() {
+		router.HandleFunc("GET /auth/oidc/login/v1", auth.OIDCLogInHandlerV1(oidcAuthorizationURL))
+		router.HandleFunc("GET /auth/oidc/callback/v1", auth.OIDCCallbackHandlerV1(oidcLogIn, oidcPostLoginRedirectURL))
+	}
+	router.HandleFunc("DELETE /auth/sessions/v1", auth.RevokeOtherSessionsHandlerV1(revokeOtherSessions))
+	router.HandleFunc("DELETE /auth/sessions/{session_id}/v1", auth.RevokeSessionHandlerV1(revokeSession))
 	router.HandleFunc("POST /tweets/v1", tweets.InsertHandlerV1(insertTweets))
 	router.HandleFunc("POST /tweets/{tweet_id}/categorize/v1", categorized.InsertSingleHandlerV1(insertCategorizedTweet))
+	router.HandleFunc("POST /tweets/categorize/batch/v1", categorized.InsertBatchHandlerV1(insertCategorizedTweetsBatch))
+	router.HandleFunc("PUT /tweets/{tweet_id}/gold/v1", gold.MarkHandlerV1(markGoldTweet))
+	router.HandleFunc("DELETE /tweets/{tweet_id}/gold/v1", gold.UnmarkHandlerV1(unmarkGoldTweet))
+	router.HandleFunc("GET /annotators/quality/v1", gold.QualityHandlerV1(goldQuality))
+	router.HandleFunc("GET /annotators/activity/v1", annotators.ActivityHandlerV1(annotatorsActivity))
+	router.HandleFunc("GET /users/v1", users.ListHandlerV1(listUsers))
+	router.HandleFunc("PUT /users/{user_id}/status/v1", users.SetStatusHandlerV1(setUserStatus))
+	router.HandleFunc("PUT /users/{user_id}/username/v1", users.RenameHandlerV1(renameUser))
+	router.HandleFunc("DELETE /users/{user_id}/v1", users.RemoveHandlerV1(removeUser))
+	router.HandleFunc("GET /audit/v1", audit.QueryHandlerV1(queryAuditLog))
+	router.HandleFunc("GET /audit/export/v1", audit.ExportHandlerV1(exportAuditLog))
+	router.HandleFunc("GET /authors/v1", authors.RankingHandlerV1(authorsRanking))
+	router.HandleFunc("GET /authors/{handle}/v1", authors.ProfileHandlerV1(authorProfile))
 	router.HandleFunc("GET /criteria/v1", criteria.InformationHandlerV1(information))
 	router.HandleFunc("GET /criteria/{criteria_id}/summarize/v1", criteria.SummarizedInformationHandlerV1(summarizedInformation))
 	router.HandleFunc("POST /criteria/init/v1", criteria.InitHandlerV1(initCriteria))
-	router.HandleFunc("GET /criteria/{criteria_id}/tweets/v1", tweets.CriteriaTweetsHandlerV1(selectBySearchCriteriaIDYearAndMonth))
+	router.HandleFunc("GET /criteria/{criteria_id}/tweets/v1", tweets.CriteriaTweetsHandlerV1(selectBySearchCriteriaID))
+	router.HandleFunc("GET /criteria/{criteria_id}/tweets/v2", tweets.CriteriaTweetsHandlerV2(selectBySearchCriteriaID))
+	router.HandleFunc("GET /tweets/search/v1", tweets.SearchHandlerV1(searchTweets))
 	router.HandleFunc("POST /criteria/{criteria_id}/enqueue/v1", criteria.EnqueueHandlerV1(enqueueCriteria))
 	router.HandleFunc("POST /criteria-executions/summarize/v1", executions.SummarizeHandlerV1(summarizeCriteriaExecutions))
 	router.HandleFunc("GET /criteria-executions/{execution_id}/v1", executions.GetExecutionByIDHandlerV1(selectExecutionByID))
 	router.HandleFunc("PUT /criteria-executions/{execution_id}/v1", executions.UpdateExecutionHandlerV1(updateCriteriaExecution))
 	router.HandleFunc("POST /criteria-executions/{execution_id}/day/v1", executions.CreateExecutionDayHandlerV1(insertCriteriaExecutionDay))
-	router.HandleFunc("POST /corpus/v1", corpus.CreateCorpusHandlerV1(createCorpus))
+	router.HandleFunc("POST /corpus/v1", corpus.CreateCorpusHandlerV1(authorize, createCorpus))
 	router.HandleFunc("GET /corpus/v1", corpus.ExportCorpusHandlerV1(exportCorpus))
+	router.HandleFunc("POST /media/archive/v1", media.ArchiveHandlerV1(archiveMedia))
+	router.HandleFunc("GET /media/v1", media.RetrieveHandlerV1(retrieveMedia))
+	router.HandleFunc("POST /tweets/entities/backfill/v1", entities.BackfillHandlerV1(backfillEntities))
+	router.HandleFunc("POST /tweets/language/backfill/v1", language.BackfillHandlerV1(backfillLanguages))
+	router.HandleFunc("POST /tweets/duplicates/cluster/v1", duplicates.ClusterHandlerV1(clusterDuplicates))
 	log.Info(ctx, "Router initialized!")
 
 	/* --- Middlewares --- */
-	handler := middleware.CORS(router)
+	handler := middleware.CORS(middleware.RequestID(auditMiddleware(router)))
 
 	/* --- Server --- */
 	port := fmt.Sprintf(":%s", os.Getenv("API_PORT"))
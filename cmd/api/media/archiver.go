@@ -0,0 +1,115 @@
+package media
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+type (
+	// Archive registers the media referenced by the tweets and the quotes, and downloads the pending ones into the local
+	// archive. The media that can't be fetched are marked as FAILED, and they are retried in the next runs until the
+	// maximum number of attempts is reached. Only admins are allowed to archive media
+	Archive func(ctx context.Context, token string) (ArchiveResultDTO, error)
+
+	// Retrieve retrieves the archived copy of the media of the given remote URL. Any logged-in user is allowed to
+	// retrieve media
+	Retrieve func(ctx context.Context, token string, url string) (FileDTO, error)
+)
+
+// MakeArchive creates a new Archive
+func MakeArchive(authorize auth.Authorize, insertPending InsertPending, selectPending SelectPending, fetch Fetch, save Save, updateArchived UpdateArchived, updateFailed UpdateFailed, batchSize int) Archive {
+	return func(ctx context.Context, token string) (ArchiveResultDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ArchiveResultDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		registered, err := insertPending(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ArchiveResultDTO{}, FailedToRegisterPendingMedia
+		}
+
+		pending, err := selectPending(ctx, batchSize)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ArchiveResultDTO{}, FailedToRetrievePendingMedia
+		}
+
+		result := ArchiveResultDTO{Registered: registered}
+		for _, media := range pending {
+			mediaCtx := log.With(ctx, log.Param("media_id", media.ID), log.Param("url", media.URL))
+
+			archived, err := archiveMedia(mediaCtx, media.URL, fetch, save)
+			if err != nil {
+				result.Failed++
+				err = updateFailed(mediaCtx, media.ID, err.Error())
+				if err != nil {
+					log.Warn(mediaCtx, fmt.Sprintf("The media couldn't be marked as failed, so it will be retried without delay: %s", err.Error()))
+				}
+				continue
+			}
+
+			err = updateArchived(mediaCtx, media.ID, archived)
+			if err != nil {
+				result.Failed++
+				continue
+			}
+
+			result.Archived++
+		}
+
+		return result, nil
+	}
+}
+
+// MakeRetrieve creates a new Retrieve
+func MakeRetrieve(authorize auth.Authorize, selectArchivedByURL SelectArchivedByURL, read Read) Retrieve {
+	return func(ctx context.Context, token string, url string) (FileDTO, error) {
+		_, err := authorize(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FileDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		media, err := selectArchivedByURL(ctx, url)
+		if errors.Is(err, NoArchivedMediaFoundForTheGivenURL) {
+			log.Error(ctx, err.Error())
+			return FileDTO{}, MediaNotFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FileDTO{}, FailedToRetrieveArchivedMedia
+		}
+
+		data, err := read(ctx, *media.Hash)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FileDTO{}, FailedToReadArchivedFile
+		}
+
+		return FileDTO{Data: data, Hash: *media.Hash, MimeType: *media.MimeType}, nil
+	}
+}
+
+// archiveMedia downloads the media and stores it in the archive
+func archiveMedia(ctx context.Context, url string, fetch Fetch, save Save) (ArchivedDTO, error) {
+	fetched, err := fetch(ctx, url)
+	if err != nil {
+		log.Warn(ctx, err.Error())
+		return ArchivedDTO{}, err
+	}
+
+	hash, err := save(ctx, fetched.Data)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return ArchivedDTO{}, err
+	}
+
+	return ArchivedDTO{Hash: hash, Size: int64(len(fetched.Data)), MimeType: fetched.MimeType}, nil
+}
@@ -0,0 +1,210 @@
+package media_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/media"
+)
+
+func TestArchive_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockInsertPending := media.MockInsertPending(2, nil)
+	mockSelectPending := media.MockSelectPending(media.MockDAOs(), nil)
+	mockFetch := media.MockFetch(media.MockFetchedDTO(), nil)
+	mockSave := media.MockSave(testHash, nil)
+	mockUpdateArchived := media.MockUpdateArchived(nil)
+	mockUpdateFailed := media.MockUpdateFailed(nil)
+
+	archive := media.MakeArchive(mockAuthorize, mockInsertPending, mockSelectPending, mockFetch, mockSave, mockUpdateArchived, mockUpdateFailed, 100)
+
+	want := media.ArchiveResultDTO{Registered: 2, Archived: 2, Failed: 0}
+	got, err := archive(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestArchive_successMarkingTheMediaThatCannotBeArchivedAsFailed(t *testing.T) {
+	tests := []struct {
+		fetchErr          error
+		saveErr           error
+		updateArchivedErr error
+		expectedReason    string
+	}{
+		{fetchErr: media.UnexpectedResponseStatus, expectedReason: media.UnexpectedResponseStatus.Error()},
+		{saveErr: media.FailedToWriteArchivedFile, expectedReason: media.FailedToWriteArchivedFile.Error()},
+		{updateArchivedErr: errors.New("failed to update archived media")},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockInsertPending := media.MockInsertPending(0, nil)
+		mockSelectPending := media.MockSelectPending(media.MockDAOs(), nil)
+		mockFetch := media.MockFetch(media.MockFetchedDTO(), tt.fetchErr)
+		mockSave := media.MockSave(testHash, tt.saveErr)
+		mockUpdateArchived := media.MockUpdateArchived(tt.updateArchivedErr)
+		reasons := make([]string, 0)
+		mockUpdateFailed := func(ctx context.Context, id int, reason string) error {
+			reasons = append(reasons, reason)
+			return nil
+		}
+
+		archive := media.MakeArchive(mockAuthorize, mockInsertPending, mockSelectPending, mockFetch, mockSave, mockUpdateArchived, mockUpdateFailed, 100)
+
+		want := media.ArchiveResultDTO{Registered: 0, Archived: 0, Failed: 2}
+		got, err := archive(context.Background(), "token")
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+		if tt.expectedReason != "" {
+			assert.Equal(t, []string{tt.expectedReason, tt.expectedReason}, reasons)
+		}
+	}
+}
+
+func TestArchive_successEvenWhenTheMediaCannotBeMarkedAsFailed(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockInsertPending := media.MockInsertPending(0, nil)
+	mockSelectPending := media.MockSelectPending(media.MockDAOs(), nil)
+	mockFetch := media.MockFetch(media.FetchedDTO{}, media.UnexpectedResponseStatus)
+	mockSave := media.MockSave(testHash, nil)
+	mockUpdateArchived := media.MockUpdateArchived(nil)
+	mockUpdateFailed := media.MockUpdateFailed(media.FailedToExecuteUpdateFailedMedia)
+
+	archive := media.MakeArchive(mockAuthorize, mockInsertPending, mockSelectPending, mockFetch, mockSave, mockUpdateArchived, mockUpdateFailed, 100)
+
+	want := media.ArchiveResultDTO{Registered: 0, Archived: 0, Failed: 2}
+	got, err := archive(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestArchive_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: media.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockInsertPending := media.MockInsertPending(2, nil)
+		mockSelectPending := media.MockSelectPending(media.MockDAOs(), nil)
+		mockFetch := media.MockFetch(media.MockFetchedDTO(), nil)
+		mockSave := media.MockSave(testHash, nil)
+		mockUpdateArchived := media.MockUpdateArchived(nil)
+		mockUpdateFailed := media.MockUpdateFailed(nil)
+
+		archive := media.MakeArchive(mockAuthorize, mockInsertPending, mockSelectPending, mockFetch, mockSave, mockUpdateArchived, mockUpdateFailed, 100)
+
+		want := tt.expected
+		_, got := archive(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestArchive_failsWhenInsertPendingThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockInsertPending := media.MockInsertPending(0, errors.New("failed to insert pending media"))
+	mockSelectPending := media.MockSelectPending(media.MockDAOs(), nil)
+	mockFetch := media.MockFetch(media.MockFetchedDTO(), nil)
+	mockSave := media.MockSave(testHash, nil)
+	mockUpdateArchived := media.MockUpdateArchived(nil)
+	mockUpdateFailed := media.MockUpdateFailed(nil)
+
+	archive := media.MakeArchive(mockAuthorize, mockInsertPending, mockSelectPending, mockFetch, mockSave, mockUpdateArchived, mockUpdateFailed, 100)
+
+	want := media.FailedToRegisterPendingMedia
+	_, got := archive(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestArchive_failsWhenSelectPendingThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockInsertPending := media.MockInsertPending(2, nil)
+	mockSelectPending := media.MockSelectPending(nil, errors.New("failed to select pending media"))
+	mockFetch := media.MockFetch(media.MockFetchedDTO(), nil)
+	mockSave := media.MockSave(testHash, nil)
+	mockUpdateArchived := media.MockUpdateArchived(nil)
+	mockUpdateFailed := media.MockUpdateFailed(nil)
+
+	archive := media.MakeArchive(mockAuthorize, mockInsertPending, mockSelectPending, mockFetch, mockSave, mockUpdateArchived, mockUpdateFailed, 100)
+
+	want := media.FailedToRetrievePendingMedia
+	_, got := archive(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestRetrieve_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectArchivedByURL := media.MockSelectArchivedByURL(media.MockDAO(), nil)
+	mockRead := media.MockRead([]byte("test"), nil)
+
+	retrieve := media.MakeRetrieve(mockAuthorize, mockSelectArchivedByURL, mockRead)
+
+	want := media.MockFileDTO()
+	got, err := retrieve(context.Background(), "token", "https://pbs.twimg.com/media/test.png")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRetrieve_failsWhenAuthorizeThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(-1, errors.New("failed to authorize"))
+	mockSelectArchivedByURL := media.MockSelectArchivedByURL(media.MockDAO(), nil)
+	mockRead := media.MockRead([]byte("test"), nil)
+
+	retrieve := media.MakeRetrieve(mockAuthorize, mockSelectArchivedByURL, mockRead)
+
+	want := media.FailedToAuthorizeUser
+	_, got := retrieve(context.Background(), "token", "https://pbs.twimg.com/media/test.png")
+
+	assert.Equal(t, want, got)
+}
+
+func TestRetrieve_failsWhenSelectArchivedByURLThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: media.NoArchivedMediaFoundForTheGivenURL, expected: media.MediaNotFound},
+		{err: errors.New("failed to select archived media"), expected: media.FailedToRetrieveArchivedMedia},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockSelectArchivedByURL := media.MockSelectArchivedByURL(media.DAO{}, tt.err)
+		mockRead := media.MockRead([]byte("test"), nil)
+
+		retrieve := media.MakeRetrieve(mockAuthorize, mockSelectArchivedByURL, mockRead)
+
+		want := tt.expected
+		_, got := retrieve(context.Background(), "token", "https://pbs.twimg.com/media/test.png")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRetrieve_failsWhenReadThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectArchivedByURL := media.MockSelectArchivedByURL(media.MockDAO(), nil)
+	mockRead := media.MockRead(nil, media.MediaNotFound)
+
+	retrieve := media.MakeRetrieve(mockAuthorize, mockSelectArchivedByURL, mockRead)
+
+	want := media.FailedToReadArchivedFile
+	_, got := retrieve(context.Background(), "token", "https://pbs.twimg.com/media/test.png")
+
+	assert.Equal(t, want, got)
+}
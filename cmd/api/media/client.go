@@ -0,0 +1,80 @@
+package media
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Hosts are the hosts the media is fetched from. Their subdomains are accepted too
+var Hosts = []string{"twimg.com"}
+
+// maxRedirects is the maximum number of redirects followed while fetching a media
+const maxRedirects int = 5
+
+// sharedAddressSpace is the carrier-grade NAT range, where some cloud providers expose their metadata services
+var sharedAddressSpace = netip.MustParsePrefix("100.64.0.0/10")
+
+// NewFetchClient creates the http.Client used by Fetch. It refuses to connect to loopback, private, link-local
+// (including the cloud metadata services) and unspecified addresses, whatever the host resolves to, and only follows
+// redirects to https URLs of the given hosts
+func NewFetchClient(timeout time.Duration, hosts []string) *http.Client {
+	dialer := &net.Dialer{
+		Timeout: 10 * time.Second,
+		Control: rejectInternalAddresses,
+	}
+
+	return &http.Client{
+		Timeout: timeout,
+		Transport: &http.Transport{
+			DialContext:         dialer.DialContext,
+			TLSHandshakeTimeout: 10 * time.Second,
+			MaxIdleConns:        10,
+			IdleConnTimeout:     90 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return TooManyMediaRedirects
+			}
+
+			return validateURL(req.URL, hosts)
+		},
+	}
+}
+
+// rejectInternalAddresses is a net.Dialer Control function that fails when the resolved address is not a public one
+func rejectInternalAddresses(_, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return err
+	}
+
+	addr := addrPort.Addr().Unmap()
+	if addr.IsLoopback() || addr.IsPrivate() || addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() || addr.IsUnspecified() || sharedAddressSpace.Contains(addr) {
+		return fmt.Errorf("%w: %s", MediaAddressNotAllowed, addr)
+	}
+
+	return nil
+}
+
+// validateURL validates that the URL uses https and points to one of the given hosts, or to one of their subdomains
+func validateURL(u *url.URL, hosts []string) error {
+	if u.Scheme != "https" {
+		return fmt.Errorf("%w: %s", MediaURLNotAllowed, u.Redacted())
+	}
+
+	hostname := strings.ToLower(u.Hostname())
+	for _, host := range hosts {
+		if hostname == host || strings.HasSuffix(hostname, "."+host) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("%w: %s", MediaURLNotAllowed, u.Redacted())
+}
@@ -0,0 +1,40 @@
+package media
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRejectInternalAddresses_success(t *testing.T) {
+	for _, address := range []string{"151.101.0.0:443", "[2a04:4e42::0]:443"} {
+		got := rejectInternalAddresses("tcp", address, nil)
+
+		assert.Nil(t, got)
+	}
+}
+
+func TestRejectInternalAddresses_failsWhenTheAddressIsNotPublic(t *testing.T) {
+	tests := []struct {
+		address string
+	}{
+		{address: "127.0.0.1:443"},
+		{address: "[::1]:443"},
+		{address: "10.0.0.1:443"},
+		{address: "172.16.0.1:443"},
+		{address: "192.168.1.1:443"},
+		{address: "169.254.169.254:80"},
+		{address: "100.100.100.200:80"},
+		{address: "[fd00:ec2::254]:80"},
+		{address: "[fe80::1]:443"},
+		{address: "[::ffff:127.0.0.1]:443"},
+		{address: "0.0.0.0:443"},
+	}
+
+	for _, tt := range tests {
+		got := rejectInternalAddresses("tcp", tt.address, nil)
+
+		assert.True(t, errors.Is(got, MediaAddressNotAllowed), tt.address)
+	}
+}
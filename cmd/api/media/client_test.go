@@ -0,0 +1,58 @@
+package media_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/media"
+)
+
+func TestNewFetchClient_successFollowingTheRedirectsToTheAllowedHosts(t *testing.T) {
+	client := media.NewFetchClient(time.Second, []string{"twimg.com"})
+
+	for _, url := range []string{"https://twimg.com/media/test.jpg", "https://pbs.twimg.com/media/test.jpg"} {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+
+		got := client.CheckRedirect(req, []*http.Request{req})
+
+		assert.Nil(t, got)
+	}
+}
+
+func TestNewFetchClient_failsWhenTheRedirectIsNotAllowed(t *testing.T) {
+	client := media.NewFetchClient(time.Second, []string{"twimg.com"})
+
+	for _, url := range []string{"http://pbs.twimg.com/media/test.jpg", "https://169.254.169.254/latest/meta-data", "https://nottwimg.com/media/test.jpg"} {
+		req, _ := http.NewRequest(http.MethodGet, url, nil)
+
+		got := client.CheckRedirect(req, []*http.Request{req})
+
+		assert.True(t, errors.Is(got, media.MediaURLNotAllowed))
+	}
+}
+
+func TestNewFetchClient_failsWhenThereAreTooManyRedirects(t *testing.T) {
+	client := media.NewFetchClient(time.Second, []string{"twimg.com"})
+	req, _ := http.NewRequest(http.MethodGet, "https://pbs.twimg.com/media/test.jpg", nil)
+
+	want := media.TooManyMediaRedirects
+	got := client.CheckRedirect(req, []*http.Request{req, req, req, req, req})
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewFetchClient_failsWhenTheAddressIsNotPublic(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	client := media.NewFetchClient(time.Second, []string{"127.0.0.1"})
+
+	_, got := client.Get(server.URL)
+
+	assert.True(t, errors.Is(got, media.MediaAddressNotAllowed))
+}
@@ -0,0 +1,23 @@
+package media
+
+import "time"
+
+const (
+	PendingStatus  string = "PENDING"
+	ArchivedStatus string = "ARCHIVED"
+	FailedStatus   string = "FAILED"
+)
+
+// DAO represents a media from the 'media' table
+type DAO struct {
+	ID            int        `json:"id"`
+	URL           string     `json:"url"`
+	Hash          *string    `json:"hash,omitempty"`
+	Size          *int64     `json:"size,omitempty"`
+	MimeType      *string    `json:"mime_type,omitempty"`
+	Status        string     `json:"status"`
+	Attempts      int        `json:"attempts"`
+	LastError     *string    `json:"last_error,omitempty"`
+	NextAttemptAt *time.Time `json:"next_attempt_at,omitempty"`
+	ArchivedAt    *time.Time `json:"archived_at,omitempty"`
+}
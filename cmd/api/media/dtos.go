@@ -0,0 +1,30 @@
+package media
+
+type (
+	// FetchedDTO represents the content of a media downloaded from its remote URL
+	FetchedDTO struct {
+		Data     []byte
+		MimeType string
+	}
+
+	// ArchivedDTO represents the data of a media stored in the local archive
+	ArchivedDTO struct {
+		Hash     string `json:"hash"`
+		Size     int64  `json:"size"`
+		MimeType string `json:"mime_type"`
+	}
+
+	// FileDTO represents an archived media ready to be served
+	FileDTO struct {
+		Data     []byte
+		Hash     string
+		MimeType string
+	}
+
+	// ArchiveResultDTO represents the outcome of an archive run
+	ArchiveResultDTO struct {
+		Registered int `json:"registered"`
+		Archived   int `json:"archived"`
+		Failed     int `json:"failed"`
+	}
+)
@@ -0,0 +1,44 @@
+package media
+
+import "errors"
+
+var (
+	FailedToExecuteInsertPendingMedia              = errors.New("failed to execute insert pending media")
+	FailedToExecuteSelectPendingMedia              = errors.New("failed to execute select pending media")
+	FailedToExecuteCollectRowsInSelectPendingMedia = errors.New("failed to execute collect rows in select pending media")
+	NoArchivedMediaFoundForTheGivenURL             = errors.New("no archived media found for the given url")
+	FailedToExecuteSelectArchivedMediaByURL        = errors.New("failed to execute select archived media by url")
+	FailedToExecuteUpdateArchivedMedia             = errors.New("failed to execute update archived media")
+	FailedToExecuteUpdateFailedMedia               = errors.New("failed to execute update failed media")
+	FailedToCreateRequest                          = errors.New("failed to create request")
+	FailedToExecuteRequest                         = errors.New("failed to execute request")
+	UnexpectedResponseStatus                       = errors.New("unexpected response status")
+	FailedToReadResponse                           = errors.New("failed to read response")
+	MediaTooLarge                                  = errors.New("media exceeds the maximum allowed size")
+	MediaIsNotAnImage                              = errors.New("media is not an image")
+	MediaIsAnSVGImage                              = errors.New("media is an svg image, which can carry scripts")
+	InvalidMediaHash                               = errors.New("invalid media hash")
+	FailedToCreateArchiveDirectory                 = errors.New("failed to create archive directory")
+	FailedToWriteArchivedFile                      = errors.New("failed to write archived file")
+	FailedToReadArchivedFile                       = errors.New("failed to read archived file")
+	FailedToAuthorizeUser                          = errors.New("failed to authorize user")
+	FailedToRegisterPendingMedia                   = errors.New("failed to register pending media")
+	FailedToRetrievePendingMedia                   = errors.New("failed to retrieve pending media")
+	FailedToRetrieveArchivedMedia                  = errors.New("failed to retrieve archived media")
+	MediaNotFound                                  = errors.New("media not found")
+	AuthorizationTokenIsRequired                   = errors.New("authorization token is required")
+	MissingMediaURL                                = errors.New("missing media url")
+	MediaURLNotAllowed                             = errors.New("media url not allowed")
+	MediaAddressNotAllowed                         = errors.New("media address not allowed")
+	TooManyMediaRedirects                          = errors.New("too many media redirects")
+)
+
+const (
+	AuthorizationTokenRequired string = "Authorization token is required"
+	InvalidQueryParameter      string = "Invalid query parameter"
+	UserNotAuthorized          string = "User not authorized"
+	InvalidOrExpiredToken      string = "Invalid or expired session token"
+	FailedToArchiveMedia       string = "Failed to archive media"
+	FailedToRetrieveMedia      string = "Failed to retrieve media"
+	MediaNotArchived           string = "Media not archived"
+)
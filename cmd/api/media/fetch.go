@@ -0,0 +1,94 @@
+package media
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strings"
+
+	"ahbcc/internal/log"
+)
+
+// Fetch downloads the media of the given URL. Only https URLs of the given hosts are fetched, and only images smaller
+// than the maximum size of the archive are accepted. The SVG images are rejected, as they can carry scripts
+type Fetch func(ctx context.Context, url string) (FetchedDTO, error)
+
+// svgMimeType is the MIME type of the SVG images
+const svgMimeType string = "image/svg+xml"
+
+// MakeFetch creates a new Fetch
+func MakeFetch(httpClient *http.Client, hosts []string, maxSize int64) Fetch {
+	return func(ctx context.Context, url string) (FetchedDTO, error) {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FetchedDTO{}, FailedToCreateRequest
+		}
+
+		err = validateURL(req.URL, hosts)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FetchedDTO{}, MediaURLNotAllowed
+		}
+
+		resp, err := httpClient.Do(req)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FetchedDTO{}, FailedToExecuteRequest
+		}
+		defer func(body io.ReadCloser) {
+			err = body.Close()
+			if err != nil {
+				log.Error(ctx, err.Error())
+			}
+		}(resp.Body)
+
+		if resp.StatusCode != http.StatusOK {
+			log.Error(ctx, fmt.Sprintf("Media request responded with status: %s", resp.Status))
+			return FetchedDTO{}, UnexpectedResponseStatus
+		}
+
+		if resp.ContentLength > maxSize {
+			log.Error(ctx, MediaTooLarge.Error())
+			return FetchedDTO{}, MediaTooLarge
+		}
+
+		// One extra byte is read to know if the media exceeds the maximum size
+		data, err := io.ReadAll(io.LimitReader(resp.Body, maxSize+1))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FetchedDTO{}, FailedToReadResponse
+		}
+
+		if int64(len(data)) > maxSize {
+			log.Error(ctx, MediaTooLarge.Error())
+			return FetchedDTO{}, MediaTooLarge
+		}
+
+		mimeType := mimeTypeOf(resp.Header.Get("Content-Type"), data)
+		if !strings.HasPrefix(mimeType, "image/") {
+			log.Error(ctx, fmt.Sprintf("Media MIME type: %s", mimeType))
+			return FetchedDTO{}, MediaIsNotAnImage
+		}
+
+		if mimeType == svgMimeType {
+			log.Error(ctx, MediaIsAnSVGImage.Error())
+			return FetchedDTO{}, MediaIsAnSVGImage
+		}
+
+		return FetchedDTO{Data: data, MimeType: mimeType}, nil
+	}
+}
+
+// mimeTypeOf returns the MIME type declared by the Content-Type header, or the one detected from the data when the
+// header is missing or too generic
+func mimeTypeOf(contentType string, data []byte) string {
+	mimeType, _, err := mime.ParseMediaType(contentType)
+	if err != nil || mimeType == "application/octet-stream" {
+		mimeType, _, _ = mime.ParseMediaType(http.DetectContentType(data))
+	}
+
+	return mimeType
+}
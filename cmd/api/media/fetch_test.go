@@ -0,0 +1,173 @@
+package media_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/media"
+)
+
+var (
+	pngHeader = []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR")
+	testHosts = []string{"127.0.0.1"}
+)
+
+func TestFetch_success(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/jpeg")
+		_, _ = w.Write([]byte("test"))
+	}))
+	defer server.Close()
+
+	fetch := media.MakeFetch(server.Client(), testHosts, 1024)
+
+	want := media.FetchedDTO{Data: []byte("test"), MimeType: "image/jpeg"}
+	got, err := fetch(context.Background(), server.URL)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestFetch_successDetectingTheMIMETypeWhenTheContentTypeIsGeneric(t *testing.T) {
+	tests := []struct {
+		contentType string
+	}{
+		{contentType: ""},
+		{contentType: "application/octet-stream"},
+	}
+
+	for _, tt := range tests {
+		server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header()["Content-Type"] = []string{tt.contentType}
+			_, _ = w.Write(pngHeader)
+		}))
+
+		fetch := media.MakeFetch(server.Client(), testHosts, 1024)
+
+		got, err := fetch(context.Background(), server.URL)
+
+		assert.Nil(t, err)
+		assert.Equal(t, "image/png", got.MimeType)
+		server.Close()
+	}
+}
+
+func TestFetch_failsWhenTheRequestCannotBeCreated(t *testing.T) {
+	fetch := media.MakeFetch(http.DefaultClient, testHosts, 1024)
+
+	want := media.FailedToCreateRequest
+	_, got := fetch(context.Background(), "://wrong")
+
+	assert.Equal(t, want, got)
+}
+
+func TestFetch_failsWhenTheURLIsNotAllowed(t *testing.T) {
+	tests := []struct {
+		url string
+	}{
+		{url: "http://127.0.0.1/media/test.jpg"},
+		{url: "https://169.254.169.254/latest/meta-data"},
+		{url: "https://127.0.0.1.evil.com/media/test.jpg"},
+		{url: "file:///etc/passwd"},
+	}
+
+	fetch := media.MakeFetch(http.DefaultClient, testHosts, 1024)
+
+	for _, tt := range tests {
+		want := media.MediaURLNotAllowed
+		_, got := fetch(context.Background(), tt.url)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestFetch_failsWhenTheRequestCannotBeExecuted(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	server.Close()
+
+	fetch := media.MakeFetch(server.Client(), testHosts, 1024)
+
+	want := media.FailedToExecuteRequest
+	_, got := fetch(context.Background(), server.URL)
+
+	assert.Equal(t, want, got)
+}
+
+func TestFetch_failsWhenTheResponseStatusIsNotOK(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	fetch := media.MakeFetch(server.Client(), testHosts, 1024)
+
+	want := media.UnexpectedResponseStatus
+	_, got := fetch(context.Background(), server.URL)
+
+	assert.Equal(t, want, got)
+}
+
+func TestFetch_failsWhenTheMediaExceedsTheMaximumSize(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		_, _ = w.Write(pngHeader)
+	}))
+	defer server.Close()
+
+	fetch := media.MakeFetch(server.Client(), testHosts, 4)
+
+	want := media.MediaTooLarge
+	_, got := fetch(context.Background(), server.URL)
+
+	assert.Equal(t, want, got)
+}
+
+func TestFetch_failsWhenTheDeclaredContentLengthExceedsTheMaximumSize(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/png")
+		w.Header().Set("Content-Length", "2048")
+		_, _ = w.Write(make([]byte, 2048))
+	}))
+	defer server.Close()
+
+	fetch := media.MakeFetch(server.Client(), testHosts, 1024)
+
+	want := media.MediaTooLarge
+	_, got := fetch(context.Background(), server.URL)
+
+	assert.Equal(t, want, got)
+}
+
+func TestFetch_failsWhenTheMediaIsNotAnImage(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		_, _ = w.Write([]byte("<html></html>"))
+	}))
+	defer server.Close()
+
+	fetch := media.MakeFetch(server.Client(), testHosts, 1024)
+
+	want := media.MediaIsNotAnImage
+	_, got := fetch(context.Background(), server.URL)
+
+	assert.Equal(t, want, got)
+}
+
+func TestFetch_failsWhenTheMediaIsAnSVGImage(t *testing.T) {
+	server := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "image/svg+xml")
+		_, _ = w.Write([]byte(`<svg xmlns="http://www.w3.org/2000/svg"><script>alert(1)</script></svg>`))
+	}))
+	defer server.Close()
+
+	fetch := media.MakeFetch(server.Client(), testHosts, 1024)
+
+	want := media.MediaIsAnSVGImage
+	_, got := fetch(context.Background(), server.URL)
+
+	assert.Equal(t, want, got)
+}
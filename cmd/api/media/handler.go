@@ -0,0 +1,91 @@
+package media
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// ArchiveHandlerV1 HTTP Handler of the endpoint POST /media/archive/v1
+func ArchiveHandlerV1(archive Archive) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		result, err := archive(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToArchiveMedia, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Media successfully archived", result, nil)
+	}
+}
+
+// RetrieveHandlerV1 HTTP Handler of the endpoint GET /media/v1
+// It responds with the archived file of the media of the given url
+func RetrieveHandlerV1(retrieve Retrieve) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		url := r.URL.Query().Get("url")
+		if url == "" {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameter, nil, MissingMediaURL)
+			return
+		}
+		ctx = log.With(ctx, log.Param("url", url))
+
+		file, err := retrieve(ctx, token, url)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			case errors.Is(err, MediaNotFound):
+				response.Send(ctx, w, http.StatusNotFound, MediaNotArchived, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveMedia, nil, err)
+			}
+			return
+		}
+
+		// The browsers must not guess another type for the files, and the SVG images archived before they were rejected
+		// are downloaded instead of rendered, as they can carry scripts
+		w.Header().Set("X-Content-Type-Options", "nosniff")
+		if file.MimeType == svgMimeType {
+			w.Header().Set("Content-Disposition", "attachment")
+		}
+
+		// The archived files never change, so they can be cached by their hash
+		w.Header().Set("Content-Type", file.MimeType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(file.Data)))
+		w.Header().Set("ETag", `"`+file.Hash+`"`)
+		w.Header().Set("Cache-Control", "private, max-age=31536000, immutable")
+		w.Write(file.Data)
+	}
+}
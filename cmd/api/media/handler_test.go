@@ -0,0 +1,169 @@
+package media_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/media"
+)
+
+func TestArchiveHandlerV1_success(t *testing.T) {
+	mockArchive := media.MockArchive(media.ArchiveResultDTO{Registered: 2, Archived: 1, Failed: 1}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/media/archive/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	archiveHandlerV1 := media.ArchiveHandlerV1(mockArchive)
+
+	archiveHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestArchiveHandlerV1_failsWhenTheTokenIsNotPresentInTheHeaders(t *testing.T) {
+	mockArchive := media.MockArchive(media.ArchiveResultDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/media/archive/v1", nil)
+
+	archiveHandlerV1 := media.ArchiveHandlerV1(mockArchive)
+
+	archiveHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestArchiveHandlerV1_failsWhenArchiveThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to archive"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockArchive := media.MockArchive(media.ArchiveResultDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/media/archive/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		archiveHandlerV1 := media.ArchiveHandlerV1(mockArchive)
+
+		archiveHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRetrieveHandlerV1_success(t *testing.T) {
+	mockFile := media.MockFileDTO()
+	mockRetrieve := media.MockRetrieve(mockFile, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/media/v1?url=https://pbs.twimg.com/media/test.png", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	retrieveHandlerV1 := media.RetrieveHandlerV1(mockRetrieve)
+
+	retrieveHandlerV1(mockResponseWriter, mockRequest)
+
+	result := mockResponseWriter.Result()
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, mockFile.MimeType, result.Header.Get("Content-Type"))
+	assert.Equal(t, `"`+mockFile.Hash+`"`, result.Header.Get("ETag"))
+	assert.Equal(t, "nosniff", result.Header.Get("X-Content-Type-Options"))
+	assert.Empty(t, result.Header.Get("Content-Disposition"))
+	assert.Equal(t, mockFile.Data, mockResponseWriter.Body.Bytes())
+}
+
+func TestRetrieveHandlerV1_successDownloadingTheSVGImages(t *testing.T) {
+	mockFile := media.MockFileDTO()
+	mockFile.MimeType = "image/svg+xml"
+	mockRetrieve := media.MockRetrieve(mockFile, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/media/v1?url=https://pbs.twimg.com/media/test.svg", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	retrieveHandlerV1 := media.RetrieveHandlerV1(mockRetrieve)
+
+	retrieveHandlerV1(mockResponseWriter, mockRequest)
+
+	result := mockResponseWriter.Result()
+	assert.Equal(t, http.StatusOK, result.StatusCode)
+	assert.Equal(t, "nosniff", result.Header.Get("X-Content-Type-Options"))
+	assert.Equal(t, "attachment", result.Header.Get("Content-Disposition"))
+}
+
+func TestRetrieveHandlerV1_failsWhenTheTokenIsNotPresentInTheHeaders(t *testing.T) {
+	mockRetrieve := media.MockRetrieve(media.MockFileDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/media/v1?url=https://pbs.twimg.com/media/test.png", nil)
+
+	retrieveHandlerV1 := media.RetrieveHandlerV1(mockRetrieve)
+
+	retrieveHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRetrieveHandlerV1_failsWhenTheURLIsNotPresentInTheQuery(t *testing.T) {
+	mockRetrieve := media.MockRetrieve(media.MockFileDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/media/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	retrieveHandlerV1 := media.RetrieveHandlerV1(mockRetrieve)
+
+	retrieveHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRetrieveHandlerV1_failsWhenRetrieveThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: media.MediaNotFound, expected: http.StatusNotFound},
+		{err: errors.New("failed to retrieve"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockRetrieve := media.MockRetrieve(media.FileDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/media/v1?url=https://pbs.twimg.com/media/test.png", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		retrieveHandlerV1 := media.RetrieveHandlerV1(mockRetrieve)
+
+		retrieveHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,41 @@
+package media
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// InsertPending registers, as PENDING, the images and avatars of the tweets and the quotes that are not in the 'media'
+// table yet. It returns the number of registered media
+type InsertPending func(ctx context.Context) (int, error)
+
+// MakeInsertPending creates a new InsertPending
+func MakeInsertPending(db database.Connection) InsertPending {
+	const query string = `
+		INSERT INTO media(url)
+		SELECT r.url
+		FROM (
+			SELECT UNNEST(t.images) AS url FROM tweets AS t
+			UNION
+			SELECT t.avatar FROM tweets AS t
+			UNION
+			SELECT UNNEST(q.images) FROM tweets_quotes AS q
+			UNION
+			SELECT q.avatar FROM tweets_quotes AS q
+		) AS r
+		WHERE r.url IS NOT NULL AND r.url <> ''
+		ON CONFLICT (url) DO NOTHING;
+	`
+
+	return func(ctx context.Context) (int, error) {
+		commandTag, err := db.Exec(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToExecuteInsertPendingMedia
+		}
+
+		return int(commandTag.RowsAffected()), nil
+	}
+}
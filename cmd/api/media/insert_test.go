@@ -0,0 +1,41 @@
+package media_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/media"
+	"ahbcc/internal/database"
+)
+
+func TestInsertPending_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 3"), nil)
+
+	insertPending := media.MakeInsertPending(mockPostgresConnection)
+
+	want := 3
+	got, err := insertPending(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertPending_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert pending media"))
+
+	insertPending := media.MakeInsertPending(mockPostgresConnection)
+
+	want := media.FailedToExecuteInsertPendingMedia
+	_, got := insertPending(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,124 @@
+package media
+
+import "context"
+
+// MockInsertPending mocks an InsertPending function
+func MockInsertPending(registered int, err error) InsertPending {
+	return func(ctx context.Context) (int, error) {
+		return registered, err
+	}
+}
+
+// MockSelectPending mocks a SelectPending function
+func MockSelectPending(media []DAO, err error) SelectPending {
+	return func(ctx context.Context, limit int) ([]DAO, error) {
+		return media, err
+	}
+}
+
+// MockSelectArchivedByURL mocks a SelectArchivedByURL function
+func MockSelectArchivedByURL(media DAO, err error) SelectArchivedByURL {
+	return func(ctx context.Context, url string) (DAO, error) {
+		return media, err
+	}
+}
+
+// MockUpdateArchived mocks an UpdateArchived function
+func MockUpdateArchived(err error) UpdateArchived {
+	return func(ctx context.Context, id int, dto ArchivedDTO) error {
+		return err
+	}
+}
+
+// MockUpdateFailed mocks an UpdateFailed function
+func MockUpdateFailed(err error) UpdateFailed {
+	return func(ctx context.Context, id int, reason string) error {
+		return err
+	}
+}
+
+// MockFetch mocks a Fetch function
+func MockFetch(fetched FetchedDTO, err error) Fetch {
+	return func(ctx context.Context, url string) (FetchedDTO, error) {
+		return fetched, err
+	}
+}
+
+// MockSave mocks a Save function
+func MockSave(hash string, err error) Save {
+	return func(ctx context.Context, data []byte) (string, error) {
+		return hash, err
+	}
+}
+
+// MockRead mocks a Read function
+func MockRead(data []byte, err error) Read {
+	return func(ctx context.Context, hash string) ([]byte, error) {
+		return data, err
+	}
+}
+
+// MockArchive mocks an Archive function
+func MockArchive(result ArchiveResultDTO, err error) Archive {
+	return func(ctx context.Context, token string) (ArchiveResultDTO, error) {
+		return result, err
+	}
+}
+
+// MockRetrieve mocks a Retrieve function
+func MockRetrieve(file FileDTO, err error) Retrieve {
+	return func(ctx context.Context, token string, url string) (FileDTO, error) {
+		return file, err
+	}
+}
+
+// MockDAO mocks an archived media DAO
+func MockDAO() DAO {
+	hash := "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+	size := int64(4)
+	mimeType := "image/png"
+
+	return DAO{
+		ID:       1,
+		URL:      "https://pbs.twimg.com/media/test.png",
+		Hash:     &hash,
+		Size:     &size,
+		MimeType: &mimeType,
+		Status:   ArchivedStatus,
+		Attempts: 1,
+	}
+}
+
+// MockDAOs mocks a slice of pending media DAO
+func MockDAOs() []DAO {
+	return []DAO{
+		{ID: 1, URL: "https://pbs.twimg.com/media/test1.png", Status: PendingStatus},
+		{ID: 2, URL: "https://pbs.twimg.com/media/test2.png", Status: FailedStatus, Attempts: 1},
+	}
+}
+
+// MockFetchedDTO mocks a FetchedDTO
+func MockFetchedDTO() FetchedDTO {
+	return FetchedDTO{Data: []byte("test"), MimeType: "image/png"}
+}
+
+// MockFileDTO mocks a FileDTO
+func MockFileDTO() FileDTO {
+	return FileDTO{Data: []byte("test"), Hash: "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08", MimeType: "image/png"}
+}
+
+// MockScanDAOValues mocks the values of a media DAO scanned from a row
+func MockScanDAOValues(dao DAO) []any {
+	return []any{
+		dao.ID,
+		dao.URL,
+		dao.Hash,
+		dao.Size,
+		dao.MimeType,
+		dao.Status,
+		dao.Attempts,
+		dao.LastError,
+		dao.NextAttemptAt,
+		dao.ArchivedAt,
+	}
+}
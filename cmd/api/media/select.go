@@ -0,0 +1,82 @@
+package media
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// SelectPending retrieves the media that must be fetched: the PENDING ones, and the FAILED ones that didn't reach the
+	// maximum number of attempts and whose next attempt is due
+	SelectPending func(ctx context.Context, limit int) ([]DAO, error)
+
+	// SelectArchivedByURL retrieves an ARCHIVED media by its remote URL
+	SelectArchivedByURL func(ctx context.Context, url string) (DAO, error)
+)
+
+// MakeSelectPending creates a new SelectPending
+func MakeSelectPending(db database.Connection, collectRows database.CollectRows[DAO], maxAttempts int) SelectPending {
+	const query string = `
+		SELECT m.id, m.url, m.hash, m.size, m.mime_type, m.status, m.attempts, m.last_error, m.next_attempt_at, m.archived_at
+		FROM media AS m
+		WHERE m.status = 'PENDING'
+		   OR (m.status = 'FAILED' AND m.attempts < $1 AND m.next_attempt_at <= NOW())
+		ORDER BY m.id
+		LIMIT $2;
+	`
+
+	return func(ctx context.Context, limit int) ([]DAO, error) {
+		rows, err := db.Query(ctx, query, maxAttempts, limit)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectPendingMedia
+		}
+
+		media, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectPendingMedia
+		}
+
+		return media, nil
+	}
+}
+
+// MakeSelectArchivedByURL creates a new SelectArchivedByURL
+func MakeSelectArchivedByURL(db database.Connection) SelectArchivedByURL {
+	const query string = `
+		SELECT m.id, m.url, m.hash, m.size, m.mime_type, m.status, m.attempts, m.last_error, m.next_attempt_at, m.archived_at
+		FROM media AS m
+		WHERE m.url = $1 AND m.status = 'ARCHIVED';
+	`
+
+	return func(ctx context.Context, url string) (DAO, error) {
+		var media DAO
+		err := db.QueryRow(ctx, query, url).Scan(
+			&media.ID,
+			&media.URL,
+			&media.Hash,
+			&media.Size,
+			&media.MimeType,
+			&media.Status,
+			&media.Attempts,
+			&media.LastError,
+			&media.NextAttemptAt,
+			&media.ArchivedAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return DAO{}, NoArchivedMediaFoundForTheGivenURL
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return DAO{}, FailedToExecuteSelectArchivedMediaByURL
+		}
+
+		return media, nil
+	}
+}
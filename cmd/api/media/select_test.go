@@ -0,0 +1,103 @@
+package media_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/media"
+	"ahbcc/internal/database"
+)
+
+func TestSelectPending_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockMedia := media.MockDAOs()
+	mockCollectRows := database.MockCollectRows[media.DAO](mockMedia, nil)
+
+	selectPending := media.MakeSelectPending(mockPostgresConnection, mockCollectRows, 5)
+
+	want := mockMedia
+	got, err := selectPending(context.Background(), 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectPending_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select pending media"))
+	mockCollectRows := database.MockCollectRows[media.DAO](nil, nil)
+
+	selectPending := media.MakeSelectPending(mockPostgresConnection, mockCollectRows, 5)
+
+	want := media.FailedToExecuteSelectPendingMedia
+	_, got := selectPending(context.Background(), 100)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectPending_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[media.DAO](nil, errors.New("failed to collect rows"))
+
+	selectPending := media.MakeSelectPending(mockPostgresConnection, mockCollectRows, 5)
+
+	want := media.FailedToExecuteCollectRowsInSelectPendingMedia
+	_, got := selectPending(context.Background(), 100)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectArchivedByURL_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockMedia := media.MockDAO()
+	database.MockScan(mockPgxRow, media.MockScanDAOValues(mockMedia), t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectArchivedByURL := media.MakeSelectArchivedByURL(mockPostgresConnection)
+
+	want := mockMedia
+	got, err := selectArchivedByURL(context.Background(), mockMedia.URL)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectArchivedByURL_failsWhenSelectOperationFails(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: media.NoArchivedMediaFoundForTheGivenURL},
+		{err: errors.New("failed to execute select operation"), expected: media.FailedToExecuteSelectArchivedMediaByURL},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		selectArchivedByURL := media.MakeSelectArchivedByURL(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := selectArchivedByURL(context.Background(), "https://pbs.twimg.com/media/test.png")
+
+		assert.Equal(t, want, got)
+	}
+}
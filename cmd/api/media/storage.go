@@ -0,0 +1,104 @@
+package media
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"ahbcc/internal/log"
+)
+
+type (
+	// Save stores the data in the archive and returns its SHA-256 hash. The archive is content-addressed: the files are
+	// named after their hash, so the same content referenced by different URLs is stored only once
+	Save func(ctx context.Context, data []byte) (string, error)
+
+	// Read retrieves the data of an archived file by its hash
+	Read func(ctx context.Context, hash string) ([]byte, error)
+)
+
+// MakeSave creates a new Save
+func MakeSave(root string) Save {
+	return func(ctx context.Context, data []byte) (string, error) {
+		sum := sha256.Sum256(data)
+		hash := hex.EncodeToString(sum[:])
+
+		path := archivePath(root, hash)
+		_, err := os.Stat(path)
+		if err == nil {
+			return hash, nil
+		}
+
+		err = os.MkdirAll(filepath.Dir(path), 0o755)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToCreateArchiveDirectory
+		}
+
+		// The file is written with a temporary name and then renamed, so a partially written file is never served
+		tmp, err := os.CreateTemp(filepath.Dir(path), hash+".*.tmp")
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToWriteArchivedFile
+		}
+		defer os.Remove(tmp.Name())
+
+		_, err = tmp.Write(data)
+		if closeErr := tmp.Close(); err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToWriteArchivedFile
+		}
+
+		err = os.Rename(tmp.Name(), path)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", FailedToWriteArchivedFile
+		}
+
+		return hash, nil
+	}
+}
+
+// MakeRead creates a new Read
+func MakeRead(root string) Read {
+	return func(ctx context.Context, hash string) ([]byte, error) {
+		if !isValidHash(hash) {
+			log.Error(ctx, InvalidMediaHash.Error())
+			return nil, InvalidMediaHash
+		}
+
+		data, err := os.ReadFile(archivePath(root, hash))
+		if errors.Is(err, fs.ErrNotExist) {
+			log.Error(ctx, err.Error())
+			return nil, MediaNotFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToReadArchivedFile
+		}
+
+		return data, nil
+	}
+}
+
+// archivePath returns the path of the file of the given hash. The files are spread in subdirectories named after the
+// first two characters of the hash, to keep the directories small
+func archivePath(root, hash string) string {
+	return filepath.Join(root, hash[:2], hash)
+}
+
+// isValidHash validates that the hash is a hex encoded SHA-256, so it can't be used to read files outside the archive
+func isValidHash(hash string) bool {
+	if len(hash) != sha256.Size*2 {
+		return false
+	}
+
+	_, err := hex.DecodeString(hash)
+	return err == nil
+}
@@ -0,0 +1,94 @@
+package media_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/media"
+)
+
+const testHash string = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
+
+func TestSave_success(t *testing.T) {
+	root := t.TempDir()
+
+	save := media.MakeSave(root)
+
+	got, err := save(context.Background(), []byte("test"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, testHash, got)
+	data, _ := os.ReadFile(filepath.Join(root, testHash[:2], testHash))
+	assert.Equal(t, []byte("test"), data)
+}
+
+func TestSave_successWhenTheContentWasAlreadyArchived(t *testing.T) {
+	root := t.TempDir()
+
+	save := media.MakeSave(root)
+
+	first, _ := save(context.Background(), []byte("test"))
+	got, err := save(context.Background(), []byte("test"))
+
+	assert.Nil(t, err)
+	assert.Equal(t, first, got)
+	entries, _ := os.ReadDir(filepath.Join(root, testHash[:2]))
+	assert.Len(t, entries, 1)
+}
+
+func TestSave_failsWhenTheArchiveDirectoryCannotBeCreated(t *testing.T) {
+	root := filepath.Join(t.TempDir(), "file")
+	_ = os.WriteFile(root, []byte("not a directory"), 0o644)
+
+	save := media.MakeSave(root)
+
+	want := media.FailedToCreateArchiveDirectory
+	_, got := save(context.Background(), []byte("test"))
+
+	assert.Equal(t, want, got)
+}
+
+func TestRead_success(t *testing.T) {
+	root := t.TempDir()
+	_, _ = media.MakeSave(root)(context.Background(), []byte("test"))
+
+	read := media.MakeRead(root)
+
+	want := []byte("test")
+	got, err := read(context.Background(), testHash)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestRead_failsWhenTheHashIsInvalid(t *testing.T) {
+	tests := []struct {
+		hash string
+	}{
+		{hash: ""},
+		{hash: "../../etc/passwd"},
+		{hash: "zz86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"},
+	}
+
+	for _, tt := range tests {
+		read := media.MakeRead(t.TempDir())
+
+		want := media.InvalidMediaHash
+		_, got := read(context.Background(), tt.hash)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRead_failsWhenTheFileIsNotArchived(t *testing.T) {
+	read := media.MakeRead(t.TempDir())
+
+	want := media.MediaNotFound
+	_, got := read(context.Background(), testHash)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,57 @@
+package media
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// UpdateArchived marks a media as ARCHIVED, storing the hash, size and MIME type of its archived file
+	UpdateArchived func(ctx context.Context, id int, dto ArchivedDTO) error
+
+	// UpdateFailed marks a media as FAILED, storing the reason. The next attempt is delayed exponentially, starting at
+	// one minute, so the unavailable URLs aren't requested on every run
+	UpdateFailed func(ctx context.Context, id int, reason string) error
+)
+
+// MakeUpdateArchived creates a new UpdateArchived
+func MakeUpdateArchived(db database.Connection) UpdateArchived {
+	const query string = `
+		UPDATE media
+		SET hash = $2, size = $3, mime_type = $4, status = 'ARCHIVED', attempts = attempts + 1, last_error = NULL,
+		    next_attempt_at = NULL, archived_at = NOW()
+		WHERE id = $1;
+	`
+
+	return func(ctx context.Context, id int, dto ArchivedDTO) error {
+		_, err := db.Exec(ctx, query, id, dto.Hash, dto.Size, dto.MimeType)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteUpdateArchivedMedia
+		}
+
+		return nil
+	}
+}
+
+// MakeUpdateFailed creates a new UpdateFailed
+func MakeUpdateFailed(db database.Connection) UpdateFailed {
+	const query string = `
+		UPDATE media
+		SET status = 'FAILED', attempts = attempts + 1, last_error = $2,
+		    next_attempt_at = NOW() + INTERVAL '1 minute' * POWER(2, attempts)
+		WHERE id = $1;
+	`
+
+	return func(ctx context.Context, id int, reason string) error {
+		_, err := db.Exec(ctx, query, id, reason)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteUpdateFailedMedia
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package media_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/media"
+	"ahbcc/internal/database"
+)
+
+func TestUpdateArchived_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+
+	updateArchived := media.MakeUpdateArchived(mockPostgresConnection)
+
+	got := updateArchived(context.Background(), 1, media.ArchivedDTO{Hash: "hash", Size: 4, MimeType: "image/png"})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateArchived_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update media"))
+
+	updateArchived := media.MakeUpdateArchived(mockPostgresConnection)
+
+	want := media.FailedToExecuteUpdateArchivedMedia
+	got := updateArchived(context.Background(), 1, media.ArchivedDTO{Hash: "hash", Size: 4, MimeType: "image/png"})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateFailed_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+
+	updateFailed := media.MakeUpdateFailed(mockPostgresConnection)
+
+	got := updateFailed(context.Background(), 1, media.UnexpectedResponseStatus.Error())
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateFailed_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update media"))
+
+	updateFailed := media.MakeUpdateFailed(mockPostgresConnection)
+
+	want := media.FailedToExecuteUpdateFailedMedia
+	got := updateFailed(context.Background(), 1, media.UnexpectedResponseStatus.Error())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,121 @@
+package middleware
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"net"
+	"net/http"
+
+	"ahbcc/internal/log"
+)
+
+// maxRequestIDLength is the length from which the X-Request-ID header sent by the client is ignored
+const maxRequestIDLength int = 128
+
+type (
+	// Request holds the details of the request being served that are recorded in the audit log
+	Request struct {
+		ID string
+		IP string
+
+		// ActorID is the user that made the request, set by the auth layer once it resolves the session token of the
+		// request, or by a service that identifies the user, as the one that logs in. It is nil when none of them did
+		ActorID *int
+
+		// Identified is true when a service identified the actor with Identify, so the request is recorded in the audit
+		// log even when its method doesn't change the state
+		Identified bool
+	}
+
+	requestCtxKey struct{}
+)
+
+// RequestID is an HTTP middleware that identifies each request with the X-Request-ID header sent by the client, or with
+// a random ID when it isn't sent or it isn't valid. The ID is responded in the same header and added to the log
+// parameters, and it is kept in the context, alongside the IP address of the client, so the audit log can record them
+func RequestID(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		id := r.Header.Get("X-Request-ID")
+		if !isValidRequestID(id) {
+			id = newRequestID()
+		}
+		w.Header().Set("X-Request-ID", id)
+
+		ctx := log.With(r.Context(), log.Param("request_id", id))
+		ctx = context.WithValue(ctx, requestCtxKey{}, &Request{ID: id, IP: ClientIP(r)})
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// RequestFrom returns the details of the request of the given context, or nil when it wasn't served through RequestID
+func RequestFrom(ctx context.Context) *Request {
+	request, _ := ctx.Value(requestCtxKey{}).(*Request)
+	return request
+}
+
+// RequestIDFrom returns the ID of the request of the given context, or an empty string when it wasn't served through
+// RequestID
+func RequestIDFrom(ctx context.Context) string {
+	request := RequestFrom(ctx)
+	if request == nil {
+		return ""
+	}
+
+	return request.ID
+}
+
+// Identify sets the given user as the actor of the request of the given context. The request is recorded in the audit
+// log even when its method doesn't change the state, as the single sign-on callback. It does nothing when the context
+// doesn't belong to a request
+func Identify(ctx context.Context, userID int) {
+	request := RequestFrom(ctx)
+	if request != nil {
+		request.ActorID = &userID
+		request.Identified = true
+	}
+}
+
+// SetActor sets the given user as the actor of the request of the given context, without recording in the audit log
+// the requests that don't change the state. It is called by the auth layer once it resolves the session token of the
+// request, so the actor isn't looked up again. It does nothing when the context doesn't belong to a request
+func SetActor(ctx context.Context, userID int) {
+	request := RequestFrom(ctx)
+	if request != nil {
+		request.ActorID = &userID
+	}
+}
+
+// isValidRequestID returns true when the given request ID isn't empty, isn't too long and only has printable ASCII
+// characters, so it can be safely logged
+func isValidRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+
+	for _, c := range id {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// newRequestID returns a random request ID
+func newRequestID() string {
+	random := make([]byte, 16)
+	_, _ = rand.Read(random)
+	return hex.EncodeToString(random)
+}
+
+// ClientIP returns the IP address of the client that made the request
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+
+	return host
+}
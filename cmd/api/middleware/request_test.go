@@ -0,0 +1,134 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/middleware"
+)
+
+func TestRequestID_successGeneratingTheID(t *testing.T) {
+	for _, requestID := range []string{"", strings.Repeat("a", 129), "invalid id"} {
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		req.Header.Set("X-Request-ID", requestID)
+		req.RemoteAddr = "10.0.0.1:54321"
+
+		rr := httptest.NewRecorder()
+
+		var got *middleware.Request
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got = middleware.RequestFrom(r.Context())
+		})
+
+		handler := middleware.RequestID(next)
+		handler.ServeHTTP(rr, req)
+
+		assert.NotNil(t, got)
+		assert.Len(t, got.ID, 32)
+		assert.Equal(t, "10.0.0.1", got.IP)
+		assert.Nil(t, got.ActorID)
+		assert.Equal(t, got.ID, rr.Header().Get("X-Request-ID"))
+	}
+}
+
+func TestRequestID_successKeepingTheIDOfTheClient(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/", nil)
+	req.Header.Set("X-Request-ID", "client-request-id")
+
+	rr := httptest.NewRecorder()
+
+	var got *middleware.Request
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = middleware.RequestFrom(r.Context())
+	})
+
+	handler := middleware.RequestID(next)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, "client-request-id", got.ID)
+	assert.Equal(t, "client-request-id", rr.Header().Get("X-Request-ID"))
+}
+
+func TestRequestFrom_successReturningNilWhenTheContextHasNoRequest(t *testing.T) {
+	got := middleware.RequestFrom(context.Background())
+
+	assert.Nil(t, got)
+}
+
+func TestRequestIDFrom_success(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("X-Request-ID", "request-1")
+
+	rr := httptest.NewRecorder()
+
+	var got string
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = middleware.RequestIDFrom(r.Context())
+	})
+
+	handler := middleware.RequestID(next)
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, "request-1", got)
+}
+
+func TestRequestIDFrom_successReturningEmptyWhenTheContextHasNoRequest(t *testing.T) {
+	got := middleware.RequestIDFrom(context.Background())
+
+	assert.Empty(t, got)
+}
+
+func TestIdentify_success(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	rr := httptest.NewRecorder()
+
+	var got *middleware.Request
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middleware.Identify(r.Context(), 5)
+		got = middleware.RequestFrom(r.Context())
+	})
+
+	handler := middleware.RequestID(next)
+	handler.ServeHTTP(rr, req)
+
+	assert.NotNil(t, got.ActorID)
+	assert.Equal(t, 5, *got.ActorID)
+	assert.True(t, got.Identified)
+}
+
+func TestIdentify_successDoingNothingWhenTheContextHasNoRequest(t *testing.T) {
+	assert.NotPanics(t, func() {
+		middleware.Identify(context.Background(), 5)
+	})
+}
+
+func TestSetActor_success(t *testing.T) {
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+
+	rr := httptest.NewRecorder()
+
+	var got *middleware.Request
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		middleware.SetActor(r.Context(), 5)
+		got = middleware.RequestFrom(r.Context())
+	})
+
+	handler := middleware.RequestID(next)
+	handler.ServeHTTP(rr, req)
+
+	assert.NotNil(t, got.ActorID)
+	assert.Equal(t, 5, *got.ActorID)
+	assert.False(t, got.Identified)
+}
+
+func TestSetActor_successDoingNothingWhenTheContextHasNoRequest(t *testing.T) {
+	assert.NotPanics(t, func() {
+		middleware.SetActor(context.Background(), 5)
+	})
+}
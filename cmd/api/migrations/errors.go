@@ -10,6 +10,14 @@ var (
 	FailedToCreateMigrationsTable         = errors.New("failed to create migrations table")
 	FailedToRetrieveIfMigrationWasApplied = errors.New("failed to retrieve the applied migration")
 	FailedToInsertAppliedMigration        = errors.New("failed to insert applied migration")
+
+	FailedToRetrieveIfTheDatabaseIsBootstrapped = errors.New("failed to retrieve if the database is bootstrapped")
+	AuthorizationTokenIsRequired                = errors.New("authorization token is required")
 )
 
-const FailedToRunMigrations string = "Failed to run migrations"
+const (
+	FailedToRunMigrations      string = "Failed to run migrations"
+	AuthorizationTokenRequired string = "Authorization token is required"
+	UserNotAuthorized          string = "User not authorized"
+	InvalidOrExpiredToken      string = "Invalid or expired session token"
+)
@@ -1,19 +1,50 @@
 package migrations
 
 import (
+	"errors"
 	"net/http"
-	
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
 	"ahbcc/internal/http/response"
 )
 
 const migrationsDir string = "./migrations"
 
-// RunHandlerV1 HTTP Handler of the endpoint /migrations/run/v1
-func RunHandlerV1(runMigrations Run) http.HandlerFunc {
+// RunHandlerV1 HTTP Handler of the endpoint /migrations/run/v1. Only the admins are allowed to run the migrations, except
+// the first time, when the database isn't bootstrapped yet and there are no users
+func RunHandlerV1(isBootstrapped IsBootstrapped, authorize auth.Authorize, runMigrations Run) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
 
-		err := runMigrations(ctx, migrationsDir)
+		bootstrapped, err := isBootstrapped(ctx)
+		if err != nil {
+			response.Send(ctx, w, http.StatusInternalServerError, FailedToRunMigrations, nil, err)
+			return
+		}
+
+		if bootstrapped {
+			token := r.Header.Get("X-Session-Token")
+			if token == "" {
+				response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+				return
+			}
+
+			_, err = authorize(ctx, token, user.AdminRole)
+			if err != nil {
+				switch {
+				case errors.Is(err, auth.InvalidSessionToken):
+					response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+				case errors.Is(err, auth.UserNotAuthorized):
+					response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+				default:
+					response.Send(ctx, w, http.StatusInternalServerError, FailedToRunMigrations, nil, err)
+				}
+				return
+			}
+		}
+
+		err = runMigrations(ctx, migrationsDir)
 		if err != nil {
 			response.Send(ctx, w, http.StatusInternalServerError, FailedToRunMigrations, nil, err)
 			return
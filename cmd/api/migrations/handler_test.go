@@ -10,15 +10,19 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"ahbcc/cmd/api/auth"
 	"ahbcc/cmd/api/migrations"
 )
 
 func TestRunHandlerV1_success(t *testing.T) {
+	mockIsBootstrapped := migrations.MockIsBootstrapped(true, nil)
+	mockAuthorize := auth.MockAuthorize(1, nil)
 	mockRun := migrations.MockRun(nil)
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/migrations/run/v1", strings.NewReader(""))
+	mockRequest.Header.Set("X-Session-Token", "token")
 
-	runHandlerV1 := migrations.RunHandlerV1(mockRun)
+	runHandlerV1 := migrations.RunHandlerV1(mockIsBootstrapped, mockAuthorize, mockRun)
 
 	runHandlerV1(mockResponseWriter, mockRequest)
 
@@ -28,12 +32,95 @@ func TestRunHandlerV1_success(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
+func TestRunHandlerV1_successWithoutTokenWhenTheDatabaseIsNotBootstrapped(t *testing.T) {
+	mockIsBootstrapped := migrations.MockIsBootstrapped(false, nil)
+	mockAuthorize := auth.MockAuthorize(-1, auth.UserNotAuthorized)
+	mockRun := migrations.MockRun(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/migrations/run/v1", strings.NewReader(""))
+
+	runHandlerV1 := migrations.RunHandlerV1(mockIsBootstrapped, mockAuthorize, mockRun)
+
+	runHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRunHandlerV1_failsWhenTheTokenIsMissing(t *testing.T) {
+	mockIsBootstrapped := migrations.MockIsBootstrapped(true, nil)
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockRun := migrations.MockRun(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/migrations/run/v1", strings.NewReader(""))
+
+	runHandlerV1 := migrations.RunHandlerV1(mockIsBootstrapped, mockAuthorize, mockRun)
+
+	runHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRunHandlerV1_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: auth.FailedToSelectUserByID, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockIsBootstrapped := migrations.MockIsBootstrapped(true, nil)
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockRun := migrations.MockRun(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/migrations/run/v1", strings.NewReader(""))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		runHandlerV1 := migrations.RunHandlerV1(mockIsBootstrapped, mockAuthorize, mockRun)
+
+		runHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRunHandlerV1_failsWhenIsBootstrappedThrowsError(t *testing.T) {
+	mockIsBootstrapped := migrations.MockIsBootstrapped(false, errors.New("failed to retrieve if the database is bootstrapped"))
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockRun := migrations.MockRun(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/migrations/run/v1", strings.NewReader(""))
+
+	runHandlerV1 := migrations.RunHandlerV1(mockIsBootstrapped, mockAuthorize, mockRun)
+
+	runHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusInternalServerError
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
 func TestRunHandlerV1_failsWhenMigrationsRunThrowsError(t *testing.T) {
+	mockIsBootstrapped := migrations.MockIsBootstrapped(true, nil)
+	mockAuthorize := auth.MockAuthorize(1, nil)
 	mockRun := migrations.MockRun(errors.New("migrations run failed"))
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/migrations/run/v1", strings.NewReader(""))
+	mockRequest.Header.Set("X-Session-Token", "token")
 
-	runHandlerV1 := migrations.RunHandlerV1(mockRun)
+	runHandlerV1 := migrations.RunHandlerV1(mockIsBootstrapped, mockAuthorize, mockRun)
 
 	runHandlerV1(mockResponseWriter, mockRequest)
 
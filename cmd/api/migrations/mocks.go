@@ -23,6 +23,13 @@ func MockInsertAppliedMigration(err error) InsertAppliedMigration {
 	}
 }
 
+// MockIsBootstrapped mocks IsBootstrapped function
+func MockIsBootstrapped(bootstrapped bool, err error) IsBootstrapped {
+	return func(ctx context.Context) (bool, error) {
+		return bootstrapped, err
+	}
+}
+
 // MockRun mocks Run function
 func MockRun(err error) Run {
 	return func(ctx context.Context, migrationsDir string) error {
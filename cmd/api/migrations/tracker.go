@@ -19,6 +19,9 @@ type (
 
 	// InsertAppliedMigration inserts a new migration into the migrations table to track that it was already applied
 	InsertAppliedMigration func(ctx context.Context, migrationName string) error
+
+	// IsBootstrapped checks if the migrations were already run once, which is when the migrations table exists
+	IsBootstrapped func(ctx context.Context) (bool, error)
 )
 
 // MakeCreateMigrationsTable creates a new CreateMigrationsTable
@@ -80,3 +83,20 @@ func MakeInsertAppliedMigration(db database.Connection) InsertAppliedMigration {
 		return nil
 	}
 }
+
+// MakeIsBootstrapped creates a new IsBootstrapped
+func MakeIsBootstrapped(db database.Connection) IsBootstrapped {
+	const query string = `SELECT to_regclass('migrations') IS NOT NULL`
+
+	return func(ctx context.Context) (bool, error) {
+		var bootstrapped bool
+
+		err := db.QueryRow(ctx, query).Scan(&bootstrapped)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return false, FailedToRetrieveIfTheDatabaseIsBootstrapped
+		}
+
+		return bootstrapped, nil
+	}
+}
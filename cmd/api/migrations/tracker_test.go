@@ -95,3 +95,35 @@ func TestInsertAppliedMigration_failsWhenInsertOperationThrowsError(t *testing.T
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
 }
+
+func TestIsBootstrapped_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{true}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	isBootstrapped := migrations.MakeIsBootstrapped(mockPostgresConnection)
+
+	got, err := isBootstrapped(context.Background())
+
+	assert.Nil(t, err)
+	assert.True(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestIsBootstrapped_failsWhenSelectOperationFails(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(errors.New("failed to select"))
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	isBootstrapped := migrations.MakeIsBootstrapped(mockPostgresConnection)
+
+	want := migrations.FailedToRetrieveIfTheDatabaseIsBootstrapped
+	_, got := isBootstrapped(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
@@ -8,7 +8,7 @@ import (
 )
 
 type (
-	// SelectMonthlyTweetsCountsByYearByCriteriaID returns the count of all the tweets (using the `tweets` table) for each year and month, seeking by search criteria ID
+	// SelectMonthlyTweetsCountsByYearByCriteriaID returns the count of all the tweets (using the `tweets` and `tweets_search_criteria` tables) for each year and month, seeking by search criteria ID
 	SelectMonthlyTweetsCountsByYearByCriteriaID func(ctx context.Context, criteriaID int) ([]DAO, error)
 
 	// SelectAll returns the summarization of the tweets retrieved for each month and year, for all the criteria
@@ -19,16 +19,17 @@ type (
 func MakeSelectMonthlyTweetsCountsByYearByCriteriaID(db database.Connection, collectRows database.CollectRows[DAO]) SelectMonthlyTweetsCountsByYearByCriteriaID {
 	const query string = `
 		SELECT 
-		    search_criteria_id,
-			EXTRACT(YEAR FROM posted_at) AS tweets_year,
-			LPAD(EXTRACT(MONTH FROM posted_at)::text, 2, '0')::int AS tweets_month,
+		    tc.search_criteria_id,
+			EXTRACT(YEAR FROM t.posted_at) AS tweets_year,
+			LPAD(EXTRACT(MONTH FROM t.posted_at)::text, 2, '0')::int AS tweets_month,
 			COUNT(*) AS total
 		FROM 
-			tweets
+			tweets AS t
+			INNER JOIN tweets_search_criteria AS tc ON tc.tweet_id = t.id
 		WHERE 
-			search_criteria_id = $1
+			tc.search_criteria_id = $1
 		GROUP BY
-		    search_criteria_id,
+		    tc.search_criteria_id,
 			tweets_year,
 			tweets_month
 		ORDER BY 
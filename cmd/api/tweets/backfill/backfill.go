@@ -0,0 +1,35 @@
+package backfill
+
+import (
+	"context"
+
+	"ahbcc/internal/log"
+)
+
+// Batch computes and stores the values of a batch of the rows that are still pending. The selectPending retrieves up to
+// the batch size of them, the compute calculates the value of each one and the update stores all of them at once.
+// It returns how many rows were updated, so the backfill must be called until it is zero
+func Batch[P any, V any](ctx context.Context, selectPending func(ctx context.Context) ([]P, error), compute func(pending P) V, update func(ctx context.Context, values []V) error) (int, error) {
+	pending, err := selectPending(ctx)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return 0, FailedToRetrievePendingRows
+	}
+
+	if len(pending) == 0 {
+		return 0, nil
+	}
+
+	values := make([]V, 0, len(pending))
+	for _, row := range pending {
+		values = append(values, compute(row))
+	}
+
+	err = update(ctx, values)
+	if err != nil {
+		log.Error(ctx, err.Error())
+		return 0, FailedToUpdatePendingRows
+	}
+
+	return len(values), nil
+}
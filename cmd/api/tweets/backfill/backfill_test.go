@@ -0,0 +1,73 @@
+package backfill_test
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/tweets/backfill"
+)
+
+func TestBatch_success(t *testing.T) {
+	mockSelectPending := func(ctx context.Context) ([]int, error) {
+		return []int{1, 2, 3}, nil
+	}
+	var updated []string
+	mockUpdate := func(ctx context.Context, values []string) error {
+		updated = values
+		return nil
+	}
+
+	want := 3
+	got, err := backfill.Batch(context.Background(), mockSelectPending, strconv.Itoa, mockUpdate)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, []string{"1", "2", "3"}, updated)
+}
+
+func TestBatch_successWhenThereIsNothingPending(t *testing.T) {
+	mockSelectPending := func(ctx context.Context) ([]int, error) {
+		return []int{}, nil
+	}
+	mockUpdate := func(ctx context.Context, values []string) error {
+		return errors.New("update must not be called")
+	}
+
+	want := 0
+	got, err := backfill.Batch(context.Background(), mockSelectPending, strconv.Itoa, mockUpdate)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestBatch_failsWhenSelectPendingThrowsError(t *testing.T) {
+	mockSelectPending := func(ctx context.Context) ([]int, error) {
+		return nil, errors.New("failed to select pending")
+	}
+	mockUpdate := func(ctx context.Context, values []string) error {
+		return nil
+	}
+
+	want := backfill.FailedToRetrievePendingRows
+	_, got := backfill.Batch(context.Background(), mockSelectPending, strconv.Itoa, mockUpdate)
+
+	assert.Equal(t, want, got)
+}
+
+func TestBatch_failsWhenUpdateThrowsError(t *testing.T) {
+	mockSelectPending := func(ctx context.Context) ([]int, error) {
+		return []int{1, 2, 3}, nil
+	}
+	mockUpdate := func(ctx context.Context, values []string) error {
+		return errors.New("failed to update")
+	}
+
+	want := backfill.FailedToUpdatePendingRows
+	_, got := backfill.Batch(context.Background(), mockSelectPending, strconv.Itoa, mockUpdate)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,15 @@
+package backfill
+
+import "errors"
+
+var (
+	FailedToRetrievePendingRows  = errors.New("failed to retrieve pending rows")
+	FailedToUpdatePendingRows    = errors.New("failed to update pending rows")
+	AuthorizationTokenIsRequired = errors.New("authorization token is required")
+)
+
+const (
+	AuthorizationTokenRequired string = "Authorization token is required"
+	UserNotAuthorized          string = "User not authorized"
+	InvalidOrExpiredToken      string = "Invalid or expired session token"
+)
@@ -0,0 +1,41 @@
+package backfill
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// HandlerV1 HTTP Handler of the backfill endpoints. The run executes the backfill with the session token of the request,
+// and the failed and succeeded messages are sent in the responses
+func HandlerV1[R any](run func(ctx context.Context, token string) (R, error), failed, succeeded string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		result, err := run(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, failed, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, succeeded, result, nil)
+	}
+}
@@ -0,0 +1,69 @@
+package backfill_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/backfill"
+)
+
+func TestHandlerV1_success(t *testing.T) {
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/entities/backfill/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	handlerV1 := backfill.HandlerV1(backfill.MockRun(2, nil), "Failed to backfill", "Successfully backfilled")
+
+	handlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestHandlerV1_failsWhenTheTokenIsNotPresentInTheHeaders(t *testing.T) {
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/entities/backfill/v1", nil)
+
+	handlerV1 := backfill.HandlerV1(backfill.MockRun(2, nil), "Failed to backfill", "Successfully backfilled")
+
+	handlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestHandlerV1_failsWhenTheBackfillThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to backfill"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/entities/backfill/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		handlerV1 := backfill.HandlerV1(backfill.MockRun(0, tt.err), "Failed to backfill", "Successfully backfilled")
+
+		handlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,10 @@
+package backfill
+
+import "context"
+
+// MockRun mocks the backfill executed by HandlerV1
+func MockRun[R any](result R, err error) func(ctx context.Context, token string) (R, error) {
+	return func(ctx context.Context, token string) (R, error) {
+		return result, err
+	}
+}
@@ -7,6 +7,12 @@ type DAO struct {
 	TweetID          int    `json:"tweet_id"`
 	TweetYear        int    `json:"tweet_year"`
 	TweetMonth       int    `json:"tweet_month"`
-	UserID           int    `json:"user_id"`
+	UserID           *int   `json:"user_id"` // UserID is nil when the categorization was anonymized after deleting the user
 	Categorization   string `json:"categorization"`
 }
+
+// InsertedDAO represents the ID of a row inserted into the 'categorized_tweets' table and the tweet it references
+type InsertedDAO struct {
+	ID      int `json:"id"`
+	TweetID int `json:"tweet_id"`
+}
@@ -28,6 +28,30 @@ type (
 	InsertSingleResponseDTO struct {
 		ID int `json:"id"`
 	}
+
+	// InsertBatchBodyDTO is the body of the /tweets/categorize/batch/v1 endpoint
+	InsertBatchBodyDTO struct {
+		Categorizations []BatchItemDTO `json:"categorizations"`
+	}
+
+	// BatchItemDTO represents a tweet and the verdict given to it, inside the /tweets/categorize/batch/v1 body
+	BatchItemDTO struct {
+		TweetID        int    `json:"tweet_id"`
+		Categorization string `json:"categorization"`
+	}
+
+	// BatchItemResultDTO represents the outcome of the categorization of a single tweet of the /tweets/categorize/batch/v1 body.
+	// The ID is only present when the categorized tweet was inserted
+	BatchItemResultDTO struct {
+		TweetID int    `json:"tweet_id"`
+		Result  string `json:"result"`
+		ID      *int   `json:"id,omitempty"`
+	}
+
+	// InsertBatchResponseDTO is the response of the /tweets/categorize/batch/v1 endpoint
+	InsertBatchResponseDTO struct {
+		Results []BatchItemResultDTO `json:"results"`
+	}
 )
 
 const (
@@ -35,3 +59,15 @@ const (
 	VerdictIndeterminate string = "INDETERMINATE"
 	VerdictNegative      string = "NEGATIVE"
 )
+
+const (
+	ResultInserted              string = "INSERTED"
+	ResultAlreadyCategorized    string = "ALREADY_CATEGORIZED"
+	ResultUnknownTweet          string = "UNKNOWN_TWEET"
+	ResultInvalidCategorization string = "INVALID_CATEGORIZATION"
+)
+
+// IsValidVerdict returns true if the given categorization is one of the allowed verdicts
+func IsValidVerdict(categorization string) bool {
+	return categorization == VerdictPositive || categorization == VerdictIndeterminate || categorization == VerdictNegative
+}
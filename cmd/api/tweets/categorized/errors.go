@@ -18,6 +18,14 @@ var (
 	TweetAlreadyCategorized                                        = errors.New("tweet already categorized")
 	FailedToExecuteSelectByCategorizations                         = errors.New("failed to execute select by categorizations")
 	FailedToExecuteCollectRowsInSelectByCategorizations            = errors.New("failed to execute collect rows in select by categorizations")
+	FailedToExecuteInsertMultipleCategorizedTweets                 = errors.New("failed to execute insert multiple categorized tweets")
+	FailedToExecuteCollectRowsInInsertMultipleCategorizedTweets    = errors.New("failed to execute collect rows in insert multiple categorized tweets")
+	FailedToRetrieveTweetsByIDs                                    = errors.New("failed to retrieve tweets by ids")
+	FailedToInsertMultipleCategorizedTweets                        = errors.New("failed to insert multiple categorized tweets")
+	FailedToBeginTransaction                                       = errors.New("failed to begin transaction")
+	FailedToCommitTransaction                                      = errors.New("failed to commit transaction")
+	MissingCategorizations                                         = errors.New("missing categorizations")
+	TooManyCategorizations                                         = errors.New("too many categorizations")
 )
 
 const (
@@ -26,4 +34,5 @@ const (
 	InvalidRequestBody                          string = "Invalid request body"
 	FailedToInsertCategorizedTweet              string = "Failed to insert categorized tweet"
 	FailedToCategorizeAnAlreadyCategorizedTweet string = "Failed to categorize an already categorized tweet"
+	FailedToInsertCategorizedTweetsBatch        string = "Failed to insert categorized tweets batch"
 )
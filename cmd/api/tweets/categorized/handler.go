@@ -39,9 +39,7 @@ func InsertSingleHandlerV1(insertCategorizedTweet InsertCategorizedTweet) http.H
 		}
 		ctx = log.With(ctx, log.Param("body", body))
 
-		if body.Categorization != VerdictPositive &&
-			body.Categorization != VerdictIndeterminate &&
-			body.Categorization != VerdictNegative {
+		if !IsValidVerdict(body.Categorization) {
 			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, InvalidCategorization)
 			return
 		}
@@ -60,3 +58,43 @@ func InsertSingleHandlerV1(insertCategorizedTweet InsertCategorizedTweet) http.H
 		response.Send(ctx, w, http.StatusOK, "Tweet successfully categorized", InsertSingleResponseDTO{ID: categorizedTweetID}, nil)
 	}
 }
+
+// InsertBatchHandlerV1 HTTP Handler of the endpoint /tweets/categorize/batch/v1
+func InsertBatchHandlerV1(insertCategorizedTweetsBatch InsertCategorizedTweetsBatch) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		var body InsertBatchBodyDTO
+		err := json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+		ctx = log.With(ctx, log.Param("categorizations", len(body.Categorizations)))
+
+		if len(body.Categorizations) == 0 {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, MissingCategorizations)
+			return
+		}
+
+		if len(body.Categorizations) > MaxBatchSize {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, TooManyCategorizations)
+			return
+		}
+
+		results, err := insertCategorizedTweetsBatch(ctx, token, body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusInternalServerError, FailedToInsertCategorizedTweetsBatch, nil, err)
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Tweets batch successfully categorized", InsertBatchResponseDTO{Results: results}, nil)
+	}
+}
@@ -152,3 +152,108 @@ func TestInsertSingleHandlerV1_failsWhenInsertThrowsError(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func TestInsertBatchHandlerV1_success(t *testing.T) {
+	mockResults := []categorized.BatchItemResultDTO{{TweetID: 1, Result: categorized.ResultInserted}}
+	mockInsertCategorizedTweetsBatch := categorized.MockInsertCategorizedTweetsBatch(mockResults, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	bodyBytes, _ := json.Marshal(categorized.MockInsertBatchBodyDTO())
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/categorize/batch/v1", bytes.NewReader(bodyBytes))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	insertBatchHandlerV1 := categorized.InsertBatchHandlerV1(mockInsertCategorizedTweetsBatch)
+
+	insertBatchHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+
+	var response struct {
+		Data categorized.InsertBatchResponseDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, mockResults, response.Data.Results)
+}
+
+func TestInsertBatchHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockInsertCategorizedTweetsBatch := categorized.MockInsertCategorizedTweetsBatch(nil, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	bodyBytes, _ := json.Marshal(categorized.MockInsertBatchBodyDTO())
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/categorize/batch/v1", bytes.NewReader(bodyBytes))
+
+	insertBatchHandlerV1 := categorized.InsertBatchHandlerV1(mockInsertCategorizedTweetsBatch)
+
+	insertBatchHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestInsertBatchHandlerV1_failsWhenTheBodyCannotBeParsed(t *testing.T) {
+	mockInsertCategorizedTweetsBatch := categorized.MockInsertCategorizedTweetsBatch(nil, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockBody, _ := json.Marshal(`{"wrong": "body"}`)
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/categorize/batch/v1", bytes.NewReader(mockBody))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	insertBatchHandlerV1 := categorized.InsertBatchHandlerV1(mockInsertCategorizedTweetsBatch)
+
+	insertBatchHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusBadRequest
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestInsertBatchHandlerV1_failsWhenTheBatchIsEmptyOrTooBig(t *testing.T) {
+	tooBig := make([]categorized.BatchItemDTO, categorized.MaxBatchSize+1)
+	for i := range tooBig {
+		tooBig[i] = categorized.BatchItemDTO{TweetID: i, Categorization: categorized.VerdictPositive}
+	}
+
+	for _, tt := range []struct {
+		body categorized.InsertBatchBodyDTO
+	}{
+		{body: categorized.InsertBatchBodyDTO{}},
+		{body: categorized.InsertBatchBodyDTO{Categorizations: tooBig}},
+	} {
+		mockInsertCategorizedTweetsBatch := categorized.MockInsertCategorizedTweetsBatch(nil, nil)
+		mockResponseWriter := httptest.NewRecorder()
+		bodyBytes, _ := json.Marshal(tt.body)
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/categorize/batch/v1", bytes.NewReader(bodyBytes))
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		insertBatchHandlerV1 := categorized.InsertBatchHandlerV1(mockInsertCategorizedTweetsBatch)
+
+		insertBatchHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestInsertBatchHandlerV1_failsWhenInsertCategorizedTweetsBatchThrowsError(t *testing.T) {
+	mockInsertCategorizedTweetsBatch := categorized.MockInsertCategorizedTweetsBatch(nil, errors.New("failed to insert batch"))
+	mockResponseWriter := httptest.NewRecorder()
+	bodyBytes, _ := json.Marshal(categorized.MockInsertBatchBodyDTO())
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/categorize/batch/v1", bytes.NewReader(bodyBytes))
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	insertBatchHandlerV1 := categorized.InsertBatchHandlerV1(mockInsertCategorizedTweetsBatch)
+
+	insertBatchHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusInternalServerError
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
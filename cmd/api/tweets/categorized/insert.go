@@ -2,13 +2,24 @@ package categorized
 
 import (
 	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
 
 	"ahbcc/internal/database"
 	"ahbcc/internal/log"
 )
 
-// InsertSingle inserts a new categorized tweet DTO into 'categorized_tweets' table and returns the ID
-type InsertSingle func(ctx context.Context, dto DTO) (int, error)
+type (
+	// InsertSingle inserts a new categorized tweet DTO into 'categorized_tweets' table and returns the ID
+	InsertSingle func(ctx context.Context, dto DTO) (int, error)
+
+	// InsertMultiple inserts many categorized tweets DTOs into 'categorized_tweets' table, using the given transaction
+	// if it is not nil, and returns the ID of each inserted row alongside the ID of the tweet it references. The tweets
+	// already categorized by the user are skipped, so they aren't returned
+	InsertMultiple func(tx pgx.Tx, ctx context.Context, dtos []DTO) ([]InsertedDAO, error)
+)
 
 // MakeInsertSingle creates a new InsertSingle
 func MakeInsertSingle(db database.Connection) InsertSingle {
@@ -39,3 +50,47 @@ func MakeInsertSingle(db database.Connection) InsertSingle {
 		return categorizedTweetID, nil
 	}
 }
+
+// MakeInsertMultiple creates a new InsertMultiple
+func MakeInsertMultiple(db database.Connection, collectRows database.CollectRows[InsertedDAO]) InsertMultiple {
+	const (
+		query string = `
+			INSERT INTO categorized_tweets(search_criteria_id, tweet_id, tweet_year, tweet_month, user_id, categorization) 
+			VALUES %s
+			ON CONFLICT (user_id, tweet_id) DO NOTHING
+			RETURNING id, tweet_id;
+		`
+		parameters = 6
+	)
+
+	return func(tx pgx.Tx, ctx context.Context, dtos []DTO) ([]InsertedDAO, error) {
+		var conn database.Connection = db
+		if tx != nil {
+			conn = tx
+		}
+
+		placeholders := make([]string, 0, len(dtos))
+		values := make([]any, 0, len(dtos)*parameters)
+		for i, dto := range dtos {
+			idx := i * parameters
+			placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d)", idx+1, idx+2, idx+3, idx+4, idx+5, idx+6))
+			values = append(values, dto.SearchCriteriaID, dto.TweetID, dto.TweetYear, dto.TweetMonth, dto.UserID, dto.Categorization)
+		}
+
+		queryToExecute := fmt.Sprintf(query, strings.Join(placeholders, ","))
+
+		rows, err := conn.Query(ctx, queryToExecute, values...)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteInsertMultipleCategorizedTweets
+		}
+
+		inserted, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInInsertMultipleCategorizedTweets
+		}
+
+		return inserted, nil
+	}
+}
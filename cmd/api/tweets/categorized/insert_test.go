@@ -46,3 +46,53 @@ func TestInsertSingle_failsWhenScanThrowsError(t *testing.T) {
 	mockPostgresConnection.AssertExpectations(t)
 	mockPgxRow.AssertExpectations(t)
 }
+
+func TestInsertMultiple_success(t *testing.T) {
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresTx.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockInserted := []categorized.InsertedDAO{{ID: 1, TweetID: 123456}}
+	mockCollectRows := database.MockCollectRows[categorized.InsertedDAO](mockInserted, nil)
+	mockDTOs := []categorized.DTO{categorized.MockDTO()}
+
+	insertMultiple := categorized.MakeInsertMultiple(new(database.MockPostgresConnection), mockCollectRows)
+
+	want := mockInserted
+	got, err := insertMultiple(mockPostgresTx, context.Background(), mockDTOs)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsertMultiple_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to insert multiple"))
+	mockCollectRows := database.MockCollectRows[categorized.InsertedDAO](nil, nil)
+	mockDTOs := []categorized.DTO{categorized.MockDTO()}
+
+	insertMultiple := categorized.MakeInsertMultiple(mockPostgresConnection, mockCollectRows)
+
+	want := categorized.FailedToExecuteInsertMultipleCategorizedTweets
+	_, got := insertMultiple(nil, context.Background(), mockDTOs)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertMultiple_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[categorized.InsertedDAO](nil, errors.New("failed to collect rows"))
+	mockDTOs := []categorized.DTO{categorized.MockDTO()}
+
+	insertMultiple := categorized.MakeInsertMultiple(mockPostgresConnection, mockCollectRows)
+
+	want := categorized.FailedToExecuteCollectRowsInInsertMultipleCategorizedTweets
+	_, got := insertMultiple(nil, context.Background(), mockDTOs)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -6,11 +6,21 @@ import (
 
 	"ahbcc/cmd/api/tweets"
 	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
 	"ahbcc/internal/log"
 )
 
-// InsertCategorizedTweet inserts a categorized tweet
-type InsertCategorizedTweet func(ctx context.Context, token string, tweetID int, body InsertSingleBodyDTO) (int, error)
+// MaxBatchSize is the maximum amount of categorizations accepted by a single InsertCategorizedTweetsBatch call
+const MaxBatchSize int = 500
+
+type (
+	// InsertCategorizedTweet inserts a categorized tweet
+	InsertCategorizedTweet func(ctx context.Context, token string, tweetID int, body InsertSingleBodyDTO) (int, error)
+
+	// InsertCategorizedTweetsBatch inserts all the categorizations of the body inside a single transaction and returns
+	// the outcome for each one of them, in the same order they were received
+	InsertCategorizedTweetsBatch func(ctx context.Context, token string, body InsertBatchBodyDTO) ([]BatchItemResultDTO, error)
+)
 
 // MakeInsertCategorizedTweet creates a new InsertCategorizedTweet service
 func MakeInsertCategorizedTweet(selectUserIDByToken session.SelectUserIDByToken, selectTweetByID tweets.SelectByID, selectByUserIDTweetIDAndSearchCriteriaID SelectByUserIDTweetIDAndSearchCriteriaID, insertSingle InsertSingle) InsertCategorizedTweet {
@@ -56,3 +66,106 @@ func MakeInsertCategorizedTweet(selectUserIDByToken session.SelectUserIDByToken,
 		return categorizedTweetID, nil
 	}
 }
+
+// MakeInsertCategorizedTweetsBatch creates a new InsertCategorizedTweetsBatch service
+func MakeInsertCategorizedTweetsBatch(db database.Connection, selectUserIDByToken session.SelectUserIDByToken, selectTweetsByIDs tweets.SelectByIDs, insertMultiple InsertMultiple) InsertCategorizedTweetsBatch {
+	return func(ctx context.Context, token string, body InsertBatchBodyDTO) ([]BatchItemResultDTO, error) {
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveUserID
+		}
+
+		tweetIDs := make([]int, 0, len(body.Categorizations))
+		for _, item := range body.Categorizations {
+			tweetIDs = append(tweetIDs, item.TweetID)
+		}
+
+		tweetsDAO, err := selectTweetsByIDs(ctx, tweetIDs)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveTweetsByIDs
+		}
+
+		existingTweets := make(map[int]tweets.DAO, len(tweetsDAO))
+		for _, tweetDAO := range tweetsDAO {
+			existingTweets[tweetDAO.ID] = tweetDAO
+		}
+
+		alreadyCategorized := make(map[int]bool, len(body.Categorizations))
+		results := make([]BatchItemResultDTO, len(body.Categorizations))
+		toInsert := make([]DTO, 0, len(body.Categorizations))
+		for i, item := range body.Categorizations {
+			results[i] = BatchItemResultDTO{TweetID: item.TweetID}
+
+			tweetDAO, ok := existingTweets[item.TweetID]
+			switch {
+			case !IsValidVerdict(item.Categorization):
+				results[i].Result = ResultInvalidCategorization
+			case !ok:
+				results[i].Result = ResultUnknownTweet
+			case alreadyCategorized[item.TweetID]:
+				results[i].Result = ResultAlreadyCategorized
+			default:
+				// A tweet repeated inside the same batch is only categorized once
+				alreadyCategorized[item.TweetID] = true
+				results[i].Result = ResultInserted
+				toInsert = append(toInsert, DTO{
+					SearchCriteriaID: tweetDAO.SearchCriteriaID,
+					TweetID:          tweetDAO.ID,
+					TweetYear:        tweetDAO.PostedAt.Year(),
+					TweetMonth:       int(tweetDAO.PostedAt.Month()),
+					UserID:           userID,
+					Categorization:   item.Categorization,
+				})
+			}
+		}
+
+		if len(toInsert) == 0 {
+			return results, nil
+		}
+
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToBeginTransaction
+		}
+
+		defer tx.Rollback(ctx)
+
+		inserted, err := insertMultiple(tx, ctx, toInsert)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToInsertMultipleCategorizedTweets
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToCommitTransaction
+		}
+
+		insertedIDs := make(map[int]int, len(inserted))
+		for _, insertedDAO := range inserted {
+			insertedIDs[insertedDAO.TweetID] = insertedDAO.ID
+		}
+
+		// The tweets the user had already categorized, even by a concurrent request, are skipped by the insertion
+		for i := range results {
+			if results[i].Result != ResultInserted {
+				continue
+			}
+
+			id, ok := insertedIDs[results[i].TweetID]
+			if !ok {
+				results[i].Result = ResultAlreadyCategorized
+				continue
+			}
+
+			results[i].ID = &id
+			delete(insertedIDs, results[i].TweetID)
+		}
+
+		return results, nil
+	}
+}
@@ -6,10 +6,12 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 
 	"ahbcc/cmd/api/tweets"
 	"ahbcc/cmd/api/tweets/categorized"
 	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
 )
 
 func TestInsertCategorizedTweet_success(t *testing.T) {
@@ -114,3 +116,144 @@ func TestInsertCategorizedTweet_failsWhenInsertSingleThrowsError(t *testing.T) {
 
 	assert.Equal(t, want, got)
 }
+
+func mockBatchTweetsDAO() []tweets.DAO {
+	first := tweets.MockTweetDAO()
+	first.ID = 1
+	second := tweets.MockTweetDAO()
+	second.ID = 2
+	fourth := tweets.MockTweetDAO()
+	fourth.ID = 4
+
+	return []tweets.DAO{first, second, fourth}
+}
+
+func TestInsertCategorizedTweetsBatch_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresTx.On("Commit", mock.Anything).Return(nil)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(789, nil)
+	mockSelectTweetsByIDs := tweets.MockSelectByIDs(mockBatchTweetsDAO(), nil)
+	mockInsertMultiple := categorized.MockInsertMultiple([]categorized.InsertedDAO{{ID: 10, TweetID: 1}}, nil)
+	mockBody := categorized.MockInsertBatchBodyDTO()
+
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(mockPostgresConnection, mockSelectUserIDByToken, mockSelectTweetsByIDs, mockInsertMultiple)
+
+	insertedID := 10
+	want := []categorized.BatchItemResultDTO{
+		{TweetID: 1, Result: categorized.ResultInserted, ID: &insertedID},
+		{TweetID: 2, Result: categorized.ResultAlreadyCategorized},
+		{TweetID: 3, Result: categorized.ResultUnknownTweet},
+		{TweetID: 4, Result: categorized.ResultInvalidCategorization},
+		{TweetID: 1, Result: categorized.ResultAlreadyCategorized},
+	}
+	got, err := insertCategorizedTweetsBatch(context.Background(), "token", mockBody)
+
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsertCategorizedTweetsBatch_successWithoutOpeningATransactionWhenThereIsNothingToInsert(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(789, nil)
+	mockSelectTweetsByIDs := tweets.MockSelectByIDs([]tweets.DAO{}, nil)
+	mockInsertMultiple := categorized.MockInsertMultiple(nil, errors.New("should not be called"))
+	mockBody := categorized.InsertBatchBodyDTO{Categorizations: []categorized.BatchItemDTO{{TweetID: 3, Categorization: categorized.VerdictPositive}}}
+
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(mockPostgresConnection, mockSelectUserIDByToken, mockSelectTweetsByIDs, mockInsertMultiple)
+
+	want := []categorized.BatchItemResultDTO{{TweetID: 3, Result: categorized.ResultUnknownTweet}}
+	got, err := insertCategorizedTweetsBatch(context.Background(), "token", mockBody)
+
+	assert.NoError(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertCategorizedTweetsBatch_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(-1, errors.New("failed to select user id by token"))
+	mockSelectTweetsByIDs := tweets.MockSelectByIDs(mockBatchTweetsDAO(), nil)
+	mockInsertMultiple := categorized.MockInsertMultiple(nil, nil)
+
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(mockPostgresConnection, mockSelectUserIDByToken, mockSelectTweetsByIDs, mockInsertMultiple)
+
+	want := categorized.FailedToRetrieveUserID
+	_, got := insertCategorizedTweetsBatch(context.Background(), "token", categorized.MockInsertBatchBodyDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestInsertCategorizedTweetsBatch_failsWhenSelectTweetsByIDsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(789, nil)
+	mockSelectTweetsByIDs := tweets.MockSelectByIDs(nil, errors.New("failed to select tweets by ids"))
+	mockInsertMultiple := categorized.MockInsertMultiple(nil, nil)
+
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(mockPostgresConnection, mockSelectUserIDByToken, mockSelectTweetsByIDs, mockInsertMultiple)
+
+	want := categorized.FailedToRetrieveTweetsByIDs
+	_, got := insertCategorizedTweetsBatch(context.Background(), "token", categorized.MockInsertBatchBodyDTO())
+
+	assert.Equal(t, want, got)
+}
+
+func TestInsertCategorizedTweetsBatch_failsWhenBeginTransactionThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, errors.New("failed to begin transaction"))
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(789, nil)
+	mockSelectTweetsByIDs := tweets.MockSelectByIDs(mockBatchTweetsDAO(), nil)
+	mockInsertMultiple := categorized.MockInsertMultiple(nil, nil)
+
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(mockPostgresConnection, mockSelectUserIDByToken, mockSelectTweetsByIDs, mockInsertMultiple)
+
+	want := categorized.FailedToBeginTransaction
+	_, got := insertCategorizedTweetsBatch(context.Background(), "token", categorized.MockInsertBatchBodyDTO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertCategorizedTweetsBatch_failsWhenInsertMultipleThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(789, nil)
+	mockSelectTweetsByIDs := tweets.MockSelectByIDs(mockBatchTweetsDAO(), nil)
+	mockInsertMultiple := categorized.MockInsertMultiple(nil, errors.New("failed to insert multiple"))
+
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(mockPostgresConnection, mockSelectUserIDByToken, mockSelectTweetsByIDs, mockInsertMultiple)
+
+	want := categorized.FailedToInsertMultipleCategorizedTweets
+	_, got := insertCategorizedTweetsBatch(context.Background(), "token", categorized.MockInsertBatchBodyDTO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsertCategorizedTweetsBatch_failsWhenCommitThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresTx.On("Commit", mock.Anything).Return(errors.New("failed to commit"))
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(789, nil)
+	mockSelectTweetsByIDs := tweets.MockSelectByIDs(mockBatchTweetsDAO(), nil)
+	mockInsertMultiple := categorized.MockInsertMultiple([]categorized.InsertedDAO{{ID: 10, TweetID: 1}}, nil)
+
+	insertCategorizedTweetsBatch := categorized.MakeInsertCategorizedTweetsBatch(mockPostgresConnection, mockSelectUserIDByToken, mockSelectTweetsByIDs, mockInsertMultiple)
+
+	want := categorized.FailedToCommitTransaction
+	_, got := insertCategorizedTweetsBatch(context.Background(), "token", categorized.MockInsertBatchBodyDTO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
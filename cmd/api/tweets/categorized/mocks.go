@@ -1,6 +1,10 @@
 package categorized
 
-import "context"
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
 
 // MockSelectAllByUserID mocks a SelectAllByUserID function
 func MockSelectAllByUserID(dtos []AnalyzedTweetsDTO, err error) SelectAllByUserID {
@@ -37,6 +41,33 @@ func MockInsertCategorizedTweet(id int, err error) InsertCategorizedTweet {
 	}
 }
 
+// MockInsertMultiple mocks an InsertMultiple function
+func MockInsertMultiple(inserted []InsertedDAO, err error) InsertMultiple {
+	return func(tx pgx.Tx, ctx context.Context, dtos []DTO) ([]InsertedDAO, error) {
+		return inserted, err
+	}
+}
+
+// MockInsertCategorizedTweetsBatch mocks an InsertCategorizedTweetsBatch function
+func MockInsertCategorizedTweetsBatch(results []BatchItemResultDTO, err error) InsertCategorizedTweetsBatch {
+	return func(ctx context.Context, token string, body InsertBatchBodyDTO) ([]BatchItemResultDTO, error) {
+		return results, err
+	}
+}
+
+// MockInsertBatchBodyDTO mocks an InsertBatchBodyDTO
+func MockInsertBatchBodyDTO() InsertBatchBodyDTO {
+	return InsertBatchBodyDTO{
+		Categorizations: []BatchItemDTO{
+			{TweetID: 1, Categorization: VerdictPositive},
+			{TweetID: 2, Categorization: VerdictNegative},
+			{TweetID: 3, Categorization: VerdictIndeterminate},
+			{TweetID: 4, Categorization: "WRONG"},
+			{TweetID: 1, Categorization: VerdictNegative},
+		},
+	}
+}
+
 // MockCategorizedTweetsDAO mocks an AnalyzedTweetsDTO
 func MockCategorizedTweetsDAO(searchCriteriaID, year, month, analyzed int) AnalyzedTweetsDTO {
 	return AnalyzedTweetsDTO{
@@ -77,13 +108,15 @@ func MockInsertSingleBodyDTO(verdict string) InsertSingleBodyDTO {
 
 // MockCategorizedTweetDAO mocks a DAO
 func MockCategorizedTweetDAO() DAO {
+	userID := 456
+
 	return DAO{
 		ID:               1,
 		SearchCriteriaID: 2,
 		TweetID:          123,
 		TweetYear:        2024,
 		TweetMonth:       5,
-		UserID:           456,
+		UserID:           &userID,
 		Categorization:   VerdictPositive,
 	}
 }
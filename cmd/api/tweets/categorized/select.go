@@ -26,11 +26,12 @@ type (
 // MakeSelectAllByUserID creates a new SelectAllByUserID
 func MakeSelectAllByUserID(db database.Connection, collectRows database.CollectRows[AnalyzedTweetsDTO]) SelectAllByUserID {
 	const query string = `
-		SELECT search_criteria_id, tweet_year, tweet_month, COUNT(*) AS analyzed_tweets
-		FROM categorized_tweets
-		WHERE user_id = $1
-		GROUP BY search_criteria_id, tweet_year, tweet_month
-		ORDER BY search_criteria_id, tweet_year, tweet_month;
+		SELECT tc.search_criteria_id, c.tweet_year, c.tweet_month, COUNT(*) AS analyzed_tweets
+		FROM categorized_tweets AS c
+		INNER JOIN tweets_search_criteria AS tc ON tc.tweet_id = c.tweet_id
+		WHERE c.user_id = $1
+		GROUP BY tc.search_criteria_id, c.tweet_year, c.tweet_month
+		ORDER BY tc.search_criteria_id, c.tweet_year, c.tweet_month;
 	`
 
 	return func(ctx context.Context, userID int) ([]AnalyzedTweetsDTO, error) {
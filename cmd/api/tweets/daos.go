@@ -1,17 +1,33 @@
 package tweets
 
-import "time"
+import (
+	"time"
 
-// DAO represents a tweet from the 'tweets' table
+	"ahbcc/cmd/api/tweets/entities"
+)
+
+// DAO represents a tweet from the 'tweets' table. The entities and the language are nil when they weren't extracted, or
+// detected, yet. The RepresentativeID is nil when the tweet isn't a near-duplicate of any other one
 type DAO struct {
-	ID               int       `json:"id"`
-	StatusID         string    `json:"status_id"`
-	Author           string    `json:"author_id"`
-	Avatar           *string   `json:"avatar,omitempty"`
-	PostedAt         time.Time `json:"posted_at"`
-	IsAReply         bool      `json:"is_a_reply"`
-	TextContent      *string   `json:"text_content,omitempty"`
-	Images           []string  `json:"images"`
-	QuoteID          *int      `json:"quote_id,omitempty"`
-	SearchCriteriaID int       `json:"search_criteria_id"`
+	ID          int       `json:"id"`
+	StatusID    string    `json:"status_id"`
+	Author      string    `json:"author_id"`
+	Avatar      *string   `json:"avatar,omitempty"`
+	PostedAt    time.Time `json:"posted_at"`
+	IsAReply    bool      `json:"is_a_reply"`
+	TextContent *string   `json:"text_content,omitempty"`
+	Images      []string  `json:"images"`
+	entities.Entities
+	Language           *string  `json:"language,omitempty"`
+	LanguageConfidence *float64 `json:"language_confidence,omitempty"`
+	RepresentativeID   *int     `json:"representative_id,omitempty"`
+	InReplyToStatusID  *string  `json:"in_reply_to_status_id,omitempty"`
+	QuoteID            *int     `json:"quote_id,omitempty"`
+	SearchCriteriaID   int      `json:"search_criteria_id"`
+}
+
+// RelatedDAO represents a tweet that was related to a search criteria in the 'tweets_search_criteria' table
+type RelatedDAO struct {
+	StatusID         string `json:"status_id"`
+	SearchCriteriaID int    `json:"search_criteria_id"`
 }
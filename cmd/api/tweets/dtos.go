@@ -3,35 +3,74 @@ package tweets
 import (
 	"time"
 
+	"ahbcc/cmd/api/tweets/entities"
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/tweets/quotes"
 )
 
 type (
-	// TweetDTO represents a tweet to be inserted into the 'tweets' table
+	// TweetDTO represents a tweet to be inserted into the 'tweets' table. When the tweet is a reply, InReplyTo is the
+	// tweet it replies to, which is inserted into the 'tweets_parents' table
 	TweetDTO struct {
-		StatusID         string           `json:"status_id"`
-		Author           string           `json:"author"`
-		Avatar           *string          `json:"avatar,omitempty"`
-		PostedAt         string           `json:"posted_at"`
-		IsAReply         bool             `json:"is_a_reply"`
-		TextContent      *string          `json:"text_content,omitempty"`
-		Images           []string         `json:"images,omitempty"`
-		SearchCriteriaID *int             `json:"search_criteria_id,omitempty"`
-		Quote            *quotes.QuoteDTO `json:"quote,omitempty"`
+		StatusID         string             `json:"status_id"`
+		Author           string             `json:"author"`
+		Avatar           *string            `json:"avatar,omitempty"`
+		PostedAt         string             `json:"posted_at"`
+		IsAReply         bool               `json:"is_a_reply"`
+		TextContent      *string            `json:"text_content,omitempty"`
+		Images           []string           `json:"images,omitempty"`
+		SearchCriteriaID *int               `json:"search_criteria_id,omitempty"`
+		Quote            *quotes.QuoteDTO   `json:"quote,omitempty"`
+		InReplyTo        *parents.ParentDTO `json:"in_reply_to,omitempty"`
 	}
 
-	// CustomTweetDTO represents a tweet obtained from the database with the extra property *quotes.CustomQuoteDTO
+	// InsertResultDTO represents the outcome of the insertion of a TweetDTO. Reason is only present for the INVALID tweets
+	InsertResultDTO struct {
+		StatusID string  `json:"status_id"`
+		Result   string  `json:"result"`
+		Reason   *string `json:"reason,omitempty"`
+	}
+
+	// CustomTweetDTO represents a tweet obtained from the database with the extra property *quotes.CustomQuoteDTO.
+	// When the tweet is a reply, the Conversation contains the tweets that lead to it, from the first one to its parent
 	CustomTweetDTO struct {
-		ID               int                    `json:"id"`
-		StatusID         string                 `json:"status_id"`
-		Author           string                 `json:"author"`
-		Avatar           *string                `json:"avatar,omitempty"`
-		PostedAt         time.Time              `json:"posted_at"`
-		IsAReply         bool                   `json:"is_a_reply"`
-		TextContent      *string                `json:"text_content,omitempty"`
-		Images           []string               `json:"images,omitempty"`
-		QuoteID          *int                   `json:"quote_id,omitempty"`
-		SearchCriteriaID *int                   `json:"search_criteria_id,omitempty"`
-		Quote            *quotes.CustomQuoteDTO `json:"quote,omitempty"`
+		ID                 int                    `json:"id"`
+		StatusID           string                 `json:"status_id"`
+		Author             string                 `json:"author"`
+		Avatar             *string                `json:"avatar,omitempty"`
+		PostedAt           *time.Time             `json:"posted_at,omitempty"`
+		IsAReply           bool                   `json:"is_a_reply"`
+		TextContent        *string                `json:"text_content,omitempty"`
+		Images             []string               `json:"images,omitempty"`
+		Entities           entities.Entities      `json:"entities"`
+		Language           *string                `json:"language,omitempty"`
+		LanguageConfidence *float64               `json:"language_confidence,omitempty"`
+		RepresentativeID   *int                   `json:"representative_id,omitempty"`
+		InReplyToStatusID  *string                `json:"in_reply_to_status_id,omitempty"`
+		QuoteID            *int                   `json:"quote_id,omitempty"`
+		SearchCriteriaID   *int                   `json:"search_criteria_id,omitempty"`
+		Quote              *quotes.CustomQuoteDTO `json:"quote,omitempty"`
+		Conversation       []parents.DAO          `json:"conversation,omitempty"`
+	}
+
+	// PageDTO represents a page of tweets. NextCursor is only present when there are more tweets to retrieve
+	PageDTO struct {
+		Tweets     []CustomTweetDTO `json:"tweets"`
+		NextCursor *string          `json:"next_cursor,omitempty"`
+	}
+
+	// SearchResultDTO represents a tweet retrieved by a full-text search, with its rank and the matched words
+	// highlighted between <mark> and </mark> tags
+	SearchResultDTO struct {
+		CustomTweetDTO
+		Rank           float64 `json:"rank"`
+		Highlight      *string `json:"highlight,omitempty"`
+		QuoteHighlight *string `json:"quote_highlight,omitempty"`
+	}
+
+	// SearchPageDTO represents a page of search results. NextCursor is only present when there are more results to retrieve
+	SearchPageDTO struct {
+		Results    []SearchResultDTO `json:"results"`
+		NextCursor *string           `json:"next_cursor,omitempty"`
 	}
 )
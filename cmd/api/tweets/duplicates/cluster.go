@@ -0,0 +1,60 @@
+package duplicates
+
+import (
+	"context"
+	"fmt"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// Cluster groups the near-duplicate tweets, such as copy-pasted texts and bot spam, by the SimHash of their normalized
+// texts, and stores the representative of each group. The previous groups are replaced, so it must be called again
+// after inserting new tweets. Only admins are allowed to cluster the tweets
+type Cluster func(ctx context.Context, token string) (ClusterResultDTO, error)
+
+// MakeCluster creates a new Cluster
+func MakeCluster(authorize auth.Authorize, selectTexts SelectTexts, updateRepresentatives UpdateRepresentatives) Cluster {
+	return func(ctx context.Context, token string) (ClusterResultDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ClusterResultDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		texts, err := selectTexts(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ClusterResultDTO{}, FailedToRetrieveTweetsTexts
+		}
+
+		hashes := make([]HashedDAO, 0, len(texts))
+		for _, text := range texts {
+			simHash, ok := SimHash(text.TextContent)
+			if ok {
+				hashes = append(hashes, HashedDAO{ID: text.ID, SimHash: simHash})
+			}
+		}
+
+		representatives := group(hashes)
+
+		err = updateRepresentatives(ctx, representatives)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return ClusterResultDTO{}, FailedToUpdateRepresentatives
+		}
+
+		result := ClusterResultDTO{Hashed: len(hashes)}
+		for tweetID, representativeID := range representatives {
+			if tweetID == representativeID {
+				result.Groups++
+			} else {
+				result.Duplicates++
+			}
+		}
+		log.Info(ctx, fmt.Sprintf("Found %d duplicates in %d groups among %d hashed tweets\n", result.Duplicates, result.Groups, result.Hashed))
+
+		return result, nil
+	}
+}
@@ -0,0 +1,99 @@
+package duplicates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/duplicates"
+)
+
+func TestCluster_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectTexts := duplicates.MockSelectTexts(duplicates.MockTextDAOs(), nil)
+	var updated map[int]int
+	mockUpdateRepresentatives := func(ctx context.Context, representatives map[int]int) error {
+		updated = representatives
+		return nil
+	}
+
+	cluster := duplicates.MakeCluster(mockAuthorize, mockSelectTexts, mockUpdateRepresentatives)
+
+	want := duplicates.ClusterResultDTO{Hashed: 4, Groups: 1, Duplicates: 2}
+	got, err := cluster(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, map[int]int{1: 1, 2: 1, 3: 1}, updated)
+}
+
+func TestCluster_successWhenThereAreNoDuplicates(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectTexts := duplicates.MockSelectTexts(duplicates.MockTextDAOs()[3:], nil)
+	var updated map[int]int
+	mockUpdateRepresentatives := func(ctx context.Context, representatives map[int]int) error {
+		updated = representatives
+		return nil
+	}
+
+	cluster := duplicates.MakeCluster(mockAuthorize, mockSelectTexts, mockUpdateRepresentatives)
+
+	want := duplicates.ClusterResultDTO{Hashed: 1}
+	got, err := cluster(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	assert.Empty(t, updated)
+}
+
+func TestCluster_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: duplicates.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectTexts := duplicates.MockSelectTexts(duplicates.MockTextDAOs(), nil)
+		mockUpdateRepresentatives := duplicates.MockUpdateRepresentatives(nil)
+
+		cluster := duplicates.MakeCluster(mockAuthorize, mockSelectTexts, mockUpdateRepresentatives)
+
+		want := tt.expected
+		_, got := cluster(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCluster_failsWhenSelectTextsThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectTexts := duplicates.MockSelectTexts(nil, errors.New("failed to select texts"))
+	mockUpdateRepresentatives := duplicates.MockUpdateRepresentatives(nil)
+
+	cluster := duplicates.MakeCluster(mockAuthorize, mockSelectTexts, mockUpdateRepresentatives)
+
+	want := duplicates.FailedToRetrieveTweetsTexts
+	_, got := cluster(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestCluster_failsWhenUpdateRepresentativesThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectTexts := duplicates.MockSelectTexts(duplicates.MockTextDAOs(), nil)
+	mockUpdateRepresentatives := duplicates.MockUpdateRepresentatives(errors.New("failed to update representatives"))
+
+	cluster := duplicates.MakeCluster(mockAuthorize, mockSelectTexts, mockUpdateRepresentatives)
+
+	want := duplicates.FailedToUpdateRepresentatives
+	_, got := cluster(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,13 @@
+package duplicates
+
+// TextDAO represents the text of a tweet from the 'tweets' table
+type TextDAO struct {
+	ID          int     `json:"id"`
+	TextContent *string `json:"text_content,omitempty"`
+}
+
+// HashedDAO represents the SimHash of the text of a tweet
+type HashedDAO struct {
+	ID      int
+	SimHash uint64
+}
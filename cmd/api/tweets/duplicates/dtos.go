@@ -0,0 +1,9 @@
+package duplicates
+
+// ClusterResultDTO represents the outcome of clustering the tweets: how many were hashed, how many duplicate groups
+// were found, and how many tweets are duplicates of the representative of their group
+type ClusterResultDTO struct {
+	Hashed     int `json:"hashed"`
+	Groups     int `json:"groups"`
+	Duplicates int `json:"duplicates"`
+}
@@ -0,0 +1,25 @@
+package duplicates
+
+import "errors"
+
+var (
+	FailedToExecuteSelectTexts                = errors.New("failed to execute select texts")
+	FailedToExecuteCollectRowsInSelectTexts   = errors.New("failed to execute collect rows in select texts")
+	FailedToExecuteSelectMembers              = errors.New("failed to execute select members")
+	FailedToExecuteCollectRowsInSelectMembers = errors.New("failed to execute collect rows in select members")
+	FailedToBeginTransaction                  = errors.New("failed to begin transaction")
+	FailedToCommitTransaction                 = errors.New("failed to commit transaction")
+	FailedToExecuteResetRepresentatives       = errors.New("failed to execute reset representatives")
+	FailedToExecuteUpdateRepresentatives      = errors.New("failed to execute update representatives")
+	FailedToAuthorizeUser                     = errors.New("failed to authorize user")
+	FailedToRetrieveTweetsTexts               = errors.New("failed to retrieve tweets texts")
+	FailedToUpdateRepresentatives             = errors.New("failed to update representatives")
+	AuthorizationTokenIsRequired              = errors.New("authorization token is required")
+)
+
+const (
+	AuthorizationTokenRequired string = "Authorization token is required"
+	UserNotAuthorized          string = "User not authorized"
+	InvalidOrExpiredToken      string = "Invalid or expired session token"
+	FailedToClusterDuplicates  string = "Failed to cluster duplicates"
+)
@@ -0,0 +1,79 @@
+package duplicates
+
+import "slices"
+
+const (
+	// bands is the number of 16 bits bands the SimHashes are split into to find the candidates. Two SimHashes with at
+	// most MaxDistance different bits share, at least, one of the MaxDistance+1 bands
+	bands     int = MaxDistance + 1
+	bandWidth int = 64 / bands
+)
+
+// group clusters the tweets whose SimHashes are at most MaxDistance bits apart, and returns the representative of each
+// tweet that belongs to a group of two or more tweets. The representative of a group is its tweet with the lowest ID
+func group(hashes []HashedDAO) map[int]int {
+	// The tweets with the same SimHash are grouped first, so the comparisons are only made between different SimHashes
+	tweetsBySimHash := make(map[uint64][]int)
+	for _, hashed := range hashes {
+		tweetsBySimHash[hashed.SimHash] = append(tweetsBySimHash[hashed.SimHash], hashed.ID)
+	}
+
+	simHashes := make([]uint64, 0, len(tweetsBySimHash))
+	for simHash := range tweetsBySimHash {
+		simHashes = append(simHashes, simHash)
+	}
+	slices.Sort(simHashes)
+
+	parents := make([]int, len(simHashes))
+	for i := range parents {
+		parents[i] = i
+	}
+
+	var find func(i int) int
+	find = func(i int) int {
+		if parents[i] != i {
+			parents[i] = find(parents[i])
+		}
+		return parents[i]
+	}
+
+	for band := range bands {
+		shift := band * bandWidth
+		buckets := make(map[uint64][]int)
+		for i, simHash := range simHashes {
+			key := (simHash >> shift) & (1<<bandWidth - 1)
+			buckets[key] = append(buckets[key], i)
+		}
+
+		for _, candidates := range buckets {
+			for i := 0; i < len(candidates); i++ {
+				for j := i + 1; j < len(candidates); j++ {
+					a, b := candidates[i], candidates[j]
+					if Distance(simHashes[a], simHashes[b]) <= MaxDistance {
+						parents[find(a)] = find(b)
+					}
+				}
+			}
+		}
+	}
+
+	members := make(map[int][]int)
+	for i, simHash := range simHashes {
+		root := find(i)
+		members[root] = append(members[root], tweetsBySimHash[simHash]...)
+	}
+
+	representatives := make(map[int]int)
+	for _, tweetIDs := range members {
+		if len(tweetIDs) < 2 {
+			continue
+		}
+
+		representative := slices.Min(tweetIDs)
+		for _, tweetID := range tweetIDs {
+			representatives[tweetID] = representative
+		}
+	}
+
+	return representatives
+}
@@ -0,0 +1,39 @@
+package duplicates
+
+import (
+	"errors"
+	"net/http"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// ClusterHandlerV1 HTTP Handler of the endpoint POST /tweets/duplicates/cluster/v1
+func ClusterHandlerV1(cluster Cluster) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		result, err := cluster(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToClusterDuplicates, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Duplicates successfully clustered", result, nil)
+	}
+}
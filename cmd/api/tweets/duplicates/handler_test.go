@@ -0,0 +1,72 @@
+package duplicates_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/duplicates"
+)
+
+func TestClusterHandlerV1_success(t *testing.T) {
+	mockCluster := duplicates.MockCluster(duplicates.ClusterResultDTO{Hashed: 4, Groups: 1, Duplicates: 2}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/duplicates/cluster/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	clusterHandlerV1 := duplicates.ClusterHandlerV1(mockCluster)
+
+	clusterHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestClusterHandlerV1_failsWhenTheTokenIsNotPresentInTheHeaders(t *testing.T) {
+	mockCluster := duplicates.MockCluster(duplicates.ClusterResultDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/duplicates/cluster/v1", nil)
+
+	clusterHandlerV1 := duplicates.ClusterHandlerV1(mockCluster)
+
+	clusterHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestClusterHandlerV1_failsWhenClusterThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to cluster"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockCluster := duplicates.MockCluster(duplicates.ClusterResultDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/duplicates/cluster/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		clusterHandlerV1 := duplicates.ClusterHandlerV1(mockCluster)
+
+		clusterHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,49 @@
+package duplicates
+
+import "context"
+
+// MockCluster mocks Cluster function
+func MockCluster(result ClusterResultDTO, err error) Cluster {
+	return func(ctx context.Context, token string) (ClusterResultDTO, error) {
+		return result, err
+	}
+}
+
+// MockSelectTexts mocks SelectTexts function
+func MockSelectTexts(texts []TextDAO, err error) SelectTexts {
+	return func(ctx context.Context) ([]TextDAO, error) {
+		return texts, err
+	}
+}
+
+// MockSelectMembers mocks SelectMembers function
+func MockSelectMembers(members []int, err error) SelectMembers {
+	return func(ctx context.Context, representativeID int) ([]int, error) {
+		return members, err
+	}
+}
+
+// MockUpdateRepresentatives mocks UpdateRepresentatives function
+func MockUpdateRepresentatives(err error) UpdateRepresentatives {
+	return func(ctx context.Context, representatives map[int]int) error {
+		return err
+	}
+}
+
+// MockTextDAOs mocks a slice of TextDAO, where the first three tweets are near-duplicates of each other
+func MockTextDAOs() []TextDAO {
+	original := "Ganá dinero desde tu casa sin invertir nada, escribime por privado y te explico cómo https://t.co/abc123"
+	copied := "@usuario Ganá dinero desde tu casa sin invertir nada!! Escribime por privado y te explico cómo 💸"
+	edited := "Ganá dinero desde tu casa sin invertir nada, escribime por privado y te explico cómo hacerlo"
+	unrelated := "Hoy no pude levantarme de la cama, no tengo ganas de nada y no sé a quién contarle"
+	short := "jajaja"
+
+	return []TextDAO{
+		{ID: 1, TextContent: &original},
+		{ID: 2, TextContent: &copied},
+		{ID: 3, TextContent: &edited},
+		{ID: 4, TextContent: &unrelated},
+		{ID: 5, TextContent: &short},
+		{ID: 6, TextContent: nil},
+	}
+}
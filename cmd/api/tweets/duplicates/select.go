@@ -0,0 +1,68 @@
+package duplicates
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// SelectTexts retrieves the ID and the text of all the tweets
+	SelectTexts func(ctx context.Context) ([]TextDAO, error)
+
+	// SelectMembers retrieves the IDs of the tweets of the duplicate group of the given representative, except the
+	// representative itself
+	SelectMembers func(ctx context.Context, representativeID int) ([]int, error)
+)
+
+// MakeSelectTexts creates a new SelectTexts
+func MakeSelectTexts(db database.Connection, collectRows database.CollectRows[TextDAO]) SelectTexts {
+	const query string = `
+		SELECT t.id, t.text_content
+		FROM tweets AS t
+		ORDER BY t.id;
+	`
+
+	return func(ctx context.Context) ([]TextDAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectTexts
+		}
+
+		texts, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectTexts
+		}
+
+		return texts, nil
+	}
+}
+
+// MakeSelectMembers creates a new SelectMembers
+func MakeSelectMembers(db database.Connection, collectRows database.CollectRows[int]) SelectMembers {
+	const query string = `
+		SELECT t.id
+		FROM tweets AS t
+		WHERE t.representative_id = $1 AND t.id <> $1
+		ORDER BY t.id;
+	`
+
+	return func(ctx context.Context, representativeID int) ([]int, error) {
+		rows, err := db.Query(ctx, query, representativeID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectMembers
+		}
+
+		members, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectMembers
+		}
+
+		return members, nil
+	}
+}
@@ -0,0 +1,106 @@
+package duplicates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/duplicates"
+	"ahbcc/internal/database"
+)
+
+func TestSelectTexts_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockTexts := duplicates.MockTextDAOs()
+	mockCollectRows := database.MockCollectRows[duplicates.TextDAO](mockTexts, nil)
+
+	selectTexts := duplicates.MakeSelectTexts(mockPostgresConnection, mockCollectRows)
+
+	want := mockTexts
+	got, err := selectTexts(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectTexts_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select texts"))
+	mockCollectRows := database.MockCollectRows[duplicates.TextDAO](nil, nil)
+
+	selectTexts := duplicates.MakeSelectTexts(mockPostgresConnection, mockCollectRows)
+
+	want := duplicates.FailedToExecuteSelectTexts
+	_, got := selectTexts(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectTexts_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[duplicates.TextDAO](nil, errors.New("failed to collect rows"))
+
+	selectTexts := duplicates.MakeSelectTexts(mockPostgresConnection, mockCollectRows)
+
+	want := duplicates.FailedToExecuteCollectRowsInSelectTexts
+	_, got := selectTexts(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectMembers_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[int]([]int{2, 3}, nil)
+
+	selectMembers := duplicates.MakeSelectMembers(mockPostgresConnection, mockCollectRows)
+
+	want := []int{2, 3}
+	got, err := selectMembers(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectMembers_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select members"))
+	mockCollectRows := database.MockCollectRows[int](nil, nil)
+
+	selectMembers := duplicates.MakeSelectMembers(mockPostgresConnection, mockCollectRows)
+
+	want := duplicates.FailedToExecuteSelectMembers
+	_, got := selectMembers(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectMembers_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[int](nil, errors.New("failed to collect rows"))
+
+	selectMembers := duplicates.MakeSelectMembers(mockPostgresConnection, mockCollectRows)
+
+	want := duplicates.FailedToExecuteCollectRowsInSelectMembers
+	_, got := selectMembers(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,81 @@
+package duplicates
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// MaxDistance is the maximum number of different bits between the SimHashes of two near-duplicate texts
+	MaxDistance int = 3
+
+	// minWords is the minimum number of words a text must have to be hashed. Shorter texts, such as "jajaja" or a lonely
+	// link, would be considered duplicates of each other without being copies
+	minWords int = 5
+
+	// shingleLength is the number of characters of each feature. Character shingles, unlike whole words, keep the
+	// SimHashes of short texts stable when only a word or two change
+	shingleLength int = 4
+)
+
+var ignoredRegexp = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|[@＠]\w+`)
+
+// SimHash computes the 64 bits SimHash of the normalized text, using its overlapping shingles of characters as features.
+// The texts that differ only in their URLs, mentions, punctuation, emojis or case get the same SimHash, and
+// the ones that differ in a few words get SimHashes that differ in a few bits.
+// It returns false when the text is nil or too short to be hashed
+func SimHash(text *string) (uint64, bool) {
+	if text == nil {
+		return 0, false
+	}
+
+	words := normalize(*text)
+	if len(words) < minWords {
+		return 0, false
+	}
+
+	var weights [64]int
+	addFeature := func(feature string) {
+		hasher := fnv.New64a()
+		hasher.Write([]byte(feature))
+		hash := hasher.Sum64()
+		for bit := range 64 {
+			if hash&(1<<bit) != 0 {
+				weights[bit]++
+			} else {
+				weights[bit]--
+			}
+		}
+	}
+
+	normalized := []rune(strings.Join(words, " "))
+	for i := 0; i+shingleLength <= len(normalized); i++ {
+		addFeature(string(normalized[i : i+shingleLength]))
+	}
+
+	var simHash uint64
+	for bit, weight := range weights {
+		if weight > 0 {
+			simHash |= 1 << bit
+		}
+	}
+
+	return simHash, true
+}
+
+// Distance returns the number of different bits between two SimHashes
+func Distance(a, b uint64) int {
+	return bits.OnesCount64(a ^ b)
+}
+
+// normalize returns the lowercase words of the text, ignoring its URLs, mentions, punctuation and emojis
+func normalize(text string) []string {
+	text = ignoredRegexp.ReplaceAllString(text, " ")
+
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
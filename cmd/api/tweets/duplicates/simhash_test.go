@@ -0,0 +1,56 @@
+package duplicates_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/tweets/duplicates"
+)
+
+func TestSimHash_success(t *testing.T) {
+	texts := duplicates.MockTextDAOs()
+
+	original, ok := duplicates.SimHash(texts[0].TextContent)
+	assert.True(t, ok)
+
+	copied, ok := duplicates.SimHash(texts[1].TextContent)
+	assert.True(t, ok)
+	assert.Equal(t, original, copied)
+
+	edited, ok := duplicates.SimHash(texts[2].TextContent)
+	assert.True(t, ok)
+	assert.LessOrEqual(t, duplicates.Distance(original, edited), duplicates.MaxDistance)
+
+	unrelated, ok := duplicates.SimHash(texts[3].TextContent)
+	assert.True(t, ok)
+	assert.Greater(t, duplicates.Distance(original, unrelated), duplicates.MaxDistance)
+}
+
+func TestSimHash_successIgnoringTheTextsThatCannotBeHashed(t *testing.T) {
+	texts := duplicates.MockTextDAOs()
+
+	for _, text := range texts[4:] {
+		_, ok := duplicates.SimHash(text.TextContent)
+
+		assert.False(t, ok)
+	}
+}
+
+func TestDistance_success(t *testing.T) {
+	tests := []struct {
+		a, b     uint64
+		expected int
+	}{
+		{a: 0, b: 0, expected: 0},
+		{a: 0b1011, b: 0b0001, expected: 2},
+		{a: 0, b: ^uint64(0), expected: 64},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got := duplicates.Distance(tt.a, tt.b)
+
+		assert.Equal(t, want, got)
+	}
+}
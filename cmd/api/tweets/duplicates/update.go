@@ -0,0 +1,79 @@
+package duplicates
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// UpdateRepresentatives replaces the duplicate groups of the tweets with the given ones, a map from each tweet ID to the
+// ID of the representative of its group. The tweets that aren't in the map don't belong to any group.
+// The groups are replaced in chunks inside a single transaction, so the tweets are never left half clustered
+type UpdateRepresentatives func(ctx context.Context, representatives map[int]int) error
+
+// MakeUpdateRepresentatives creates a new UpdateRepresentatives
+func MakeUpdateRepresentatives(db database.Connection) UpdateRepresentatives {
+	const (
+		resetQuery string = `UPDATE tweets SET representative_id = NULL WHERE representative_id IS NOT NULL;`
+		query      string = `
+			UPDATE tweets AS t
+			SET representative_id = r.representative_id
+			FROM (VALUES %s) AS r(id, representative_id)
+			WHERE t.id = r.id;
+		`
+		parameters = 2
+
+		// chunkSize keeps each query far below the limit of 65535 parameters of Postgres
+		chunkSize = 10000
+	)
+
+	return func(ctx context.Context, representatives map[int]int) error {
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToBeginTransaction
+		}
+
+		defer tx.Rollback(ctx)
+
+		_, err = tx.Exec(ctx, resetQuery)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteResetRepresentatives
+		}
+
+		values := make([]any, 0, len(representatives)*parameters)
+		for tweetID, representativeID := range representatives {
+			values = append(values, tweetID, representativeID)
+		}
+
+		for start := 0; start < len(representatives); start += chunkSize {
+			end := min(start+chunkSize, len(representatives))
+
+			placeholders := make([]string, 0, end-start)
+			for i := range end - start {
+				idx := i * parameters
+				placeholders = append(placeholders, fmt.Sprintf("($%d::INTEGER, $%d::INTEGER)", idx+1, idx+2))
+			}
+
+			queryToExecute := fmt.Sprintf(query, strings.Join(placeholders, ","))
+
+			_, err = tx.Exec(ctx, queryToExecute, values[start*parameters:end*parameters]...)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return FailedToExecuteUpdateRepresentatives
+			}
+		}
+
+		err = tx.Commit(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToCommitTransaction
+		}
+
+		return nil
+	}
+}
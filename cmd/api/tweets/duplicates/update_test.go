@@ -0,0 +1,99 @@
+package duplicates_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/duplicates"
+	"ahbcc/internal/database"
+)
+
+func TestUpdateRepresentatives_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockPostgresTx.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockPostgresTx.On("Commit", mock.Anything).Return(nil)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+
+	updateRepresentatives := duplicates.MakeUpdateRepresentatives(mockPostgresConnection)
+
+	got := updateRepresentatives(context.Background(), map[int]int{1: 1, 2: 1})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertNumberOfCalls(t, "Exec", 2)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestUpdateRepresentatives_failsWhenBeginTransactionThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, errors.New("failed to begin transaction"))
+
+	updateRepresentatives := duplicates.MakeUpdateRepresentatives(mockPostgresConnection)
+
+	want := duplicates.FailedToBeginTransaction
+	got := updateRepresentatives(context.Background(), map[int]int{1: 1, 2: 1})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateRepresentatives_failsWhenResetOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockPostgresTx.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to reset representatives"))
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+
+	updateRepresentatives := duplicates.MakeUpdateRepresentatives(mockPostgresConnection)
+
+	want := duplicates.FailedToExecuteResetRepresentatives
+	got := updateRepresentatives(context.Background(), map[int]int{1: 1, 2: 1})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestUpdateRepresentatives_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockPostgresTx.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil).Once()
+	mockPostgresTx.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update representatives"))
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+
+	updateRepresentatives := duplicates.MakeUpdateRepresentatives(mockPostgresConnection)
+
+	want := duplicates.FailedToExecuteUpdateRepresentatives
+	got := updateRepresentatives(context.Background(), map[int]int{1: 1, 2: 1})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestUpdateRepresentatives_failsWhenCommitTransactionThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockPostgresTx.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockPostgresTx.On("Commit", mock.Anything).Return(errors.New("failed to commit transaction"))
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+
+	updateRepresentatives := duplicates.MakeUpdateRepresentatives(mockPostgresConnection)
+
+	want := duplicates.FailedToCommitTransaction
+	got := updateRepresentatives(context.Background(), map[int]int{1: 1, 2: 1})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
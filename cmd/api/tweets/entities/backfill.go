@@ -0,0 +1,55 @@
+package entities
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/backfill"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// Backfill extracts the entities of the tweets and the quotes that were inserted before the entities were extracted
+// at ingestion time. Each call processes up to the batch size of tweets and of quotes, so it must be called until both
+// counts of the result are zero. Only admins are allowed to backfill the entities
+type Backfill func(ctx context.Context, token string) (BackfillResultDTO, error)
+
+// MakeBackfill creates a new Backfill
+func MakeBackfill(authorize auth.Authorize, selectPending SelectPending, updateExtracted UpdateExtracted, batchSize int) Backfill {
+	return func(ctx context.Context, token string) (BackfillResultDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return BackfillResultDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		tweets, err := backfillTable(ctx, TweetsTable, selectPending, updateExtracted, batchSize)
+		if err != nil {
+			return BackfillResultDTO{}, err
+		}
+
+		quotes, err := backfillTable(ctx, QuotesTable, selectPending, updateExtracted, batchSize)
+		if err != nil {
+			return BackfillResultDTO{}, err
+		}
+
+		return BackfillResultDTO{Tweets: tweets, Quotes: quotes}, nil
+	}
+}
+
+// backfillTable extracts the entities of a batch of pending rows of the table, and returns how many were updated
+func backfillTable(ctx context.Context, table string, selectPending SelectPending, updateExtracted UpdateExtracted, batchSize int) (int, error) {
+	ctx = log.With(ctx, log.Param("table", table))
+
+	return backfill.Batch(ctx,
+		func(ctx context.Context) ([]PendingDAO, error) {
+			return selectPending(ctx, table, batchSize)
+		},
+		func(pending PendingDAO) ExtractedDAO {
+			return ExtractedDAO{ID: pending.ID, Entities: Extract(pending.TextContent)}
+		},
+		func(ctx context.Context, extracted []ExtractedDAO) error {
+			return updateExtracted(ctx, table, extracted)
+		},
+	)
+}
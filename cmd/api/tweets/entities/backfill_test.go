@@ -0,0 +1,78 @@
+package entities_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/backfill"
+	"ahbcc/cmd/api/tweets/entities"
+)
+
+func TestBackfill_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectPending := entities.MockSelectPending(entities.MockPendingDAOs(), nil)
+	updated := make(map[string][]entities.ExtractedDAO)
+	mockUpdateExtracted := func(ctx context.Context, table string, extracted []entities.ExtractedDAO) error {
+		updated[table] = extracted
+		return nil
+	}
+
+	backfill := entities.MakeBackfill(mockAuthorize, mockSelectPending, mockUpdateExtracted, 100)
+
+	want := entities.BackfillResultDTO{Tweets: 2, Quotes: 2}
+	got, err := backfill(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	wantExtracted := []entities.ExtractedDAO{
+		{ID: 1, Entities: entities.MockEntities()},
+		{ID: 2, Entities: entities.Extract(nil)},
+	}
+	assert.Equal(t, wantExtracted, updated[entities.TweetsTable])
+	assert.Equal(t, wantExtracted, updated[entities.QuotesTable])
+}
+
+func TestBackfill_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: entities.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectPending := entities.MockSelectPending(entities.MockPendingDAOs(), nil)
+		mockUpdateExtracted := entities.MockUpdateExtracted(nil)
+
+		backfill := entities.MakeBackfill(mockAuthorize, mockSelectPending, mockUpdateExtracted, 100)
+
+		want := tt.expected
+		_, got := backfill(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestBackfill_failsWhenTheQuotesCannotBeBackfilled(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectPending := func(ctx context.Context, table string, limit int) ([]entities.PendingDAO, error) {
+		if table == entities.QuotesTable {
+			return nil, errors.New("failed to select pending quotes")
+		}
+		return entities.MockPendingDAOs(), nil
+	}
+	mockUpdateExtracted := entities.MockUpdateExtracted(nil)
+
+	backfillEntities := entities.MakeBackfill(mockAuthorize, mockSelectPending, mockUpdateExtracted, 100)
+
+	want := backfill.FailedToRetrievePendingRows
+	_, got := backfillEntities(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,13 @@
+package entities
+
+// PendingDAO represents a tweet or a quote whose entities weren't extracted yet
+type PendingDAO struct {
+	ID          int     `json:"id"`
+	TextContent *string `json:"text_content,omitempty"`
+}
+
+// ExtractedDAO represents the entities extracted from the text of a tweet or a quote
+type ExtractedDAO struct {
+	ID       int
+	Entities Entities
+}
@@ -0,0 +1,20 @@
+package entities
+
+type (
+	// Entities represents the entities found in the text of a tweet or a quote. Each entity appears once, in the order
+	// of its first occurrence. The hashtags and the mentions are stored in lowercase and the cashtags in uppercase,
+	// without their # @ and $ prefixes, so they can be compared between tweets
+	Entities struct {
+		Hashtags []string `json:"hashtags"`
+		Mentions []string `json:"mentions"`
+		URLs     []string `json:"urls"`
+		Emojis   []string `json:"emojis"`
+		Cashtags []string `json:"cashtags"`
+	}
+
+	// BackfillResultDTO represents the number of tweets and quotes whose entities were extracted by a backfill
+	BackfillResultDTO struct {
+		Tweets int `json:"tweets"`
+		Quotes int `json:"quotes"`
+	}
+)
@@ -0,0 +1,14 @@
+package entities
+
+import "errors"
+
+var (
+	FailedToExecuteSelectPending              = errors.New("failed to execute select pending")
+	FailedToExecuteCollectRowsInSelectPending = errors.New("failed to execute collect rows in select pending")
+	FailedToExecuteUpdateExtracted            = errors.New("failed to execute update extracted")
+	FailedToAuthorizeUser                     = errors.New("failed to authorize user")
+)
+
+const (
+	FailedToBackfillEntities string = "Failed to backfill entities"
+)
@@ -0,0 +1,134 @@
+package entities
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+)
+
+var (
+	hashtagRegexp = regexp.MustCompile(`(?:^|[^\p{L}\p{M}\p{N}_&])[#＃]([\p{L}\p{M}\p{N}_]*[\p{L}\p{M}][\p{L}\p{M}\p{N}_]*)`)
+	mentionRegexp = regexp.MustCompile(`(?:^|[^A-Za-z0-9_!#$%&*@＠])[@＠]([A-Za-z0-9_]{1,15})\b`)
+	cashtagRegexp = regexp.MustCompile(`(?:^|\s)\$([A-Za-z]{1,6}(?:[._][A-Za-z]{1,2})?)\b`)
+	urlRegexp     = regexp.MustCompile(`(?i)\b(?:https?://|www\.)[^\s<>"]+`)
+)
+
+const (
+	zeroWidthJoiner   rune = 0x200D
+	variationSelector rune = 0xFE0F
+	keycap            rune = 0x20E3
+)
+
+// Extract returns the hashtags, mentions, URLs, emojis and cashtags of the text. The slices are never nil, so the
+// entities of a text without them are stored as empty arrays, and can be told apart from the not extracted ones
+func Extract(text *string) Entities {
+	if text == nil {
+		return Entities{Hashtags: []string{}, Mentions: []string{}, URLs: []string{}, Emojis: []string{}, Cashtags: []string{}}
+	}
+
+	urls := unique(urlRegexp.FindAllString(*text, -1), func(url string) string {
+		return strings.TrimRight(url, ".,;:!?)]}'’”")
+	})
+
+	// The URLs are removed before looking for the rest of the entities, so the fragments of a URL aren't taken as
+	// hashtags or mentions
+	withoutURLs := urlRegexp.ReplaceAllString(*text, " ")
+
+	return Entities{
+		Hashtags: unique(submatches(hashtagRegexp, withoutURLs), strings.ToLower),
+		Mentions: unique(submatches(mentionRegexp, withoutURLs), strings.ToLower),
+		URLs:     urls,
+		Emojis:   unique(emojis(withoutURLs), nil),
+		Cashtags: unique(submatches(cashtagRegexp, withoutURLs), strings.ToUpper),
+	}
+}
+
+// submatches returns the first capturing group of each match of the regexp
+func submatches(re *regexp.Regexp, text string) []string {
+	matches := re.FindAllStringSubmatch(text, -1)
+	values := make([]string, 0, len(matches))
+	for _, match := range matches {
+		values = append(values, match[1])
+	}
+
+	return values
+}
+
+// unique normalizes the values and removes the repeated ones, keeping the order of their first occurrence
+func unique(values []string, normalize func(string) string) []string {
+	seen := make(map[string]bool, len(values))
+	result := make([]string, 0, len(values))
+	for _, value := range values {
+		if normalize != nil {
+			value = normalize(value)
+		}
+
+		if value == "" || seen[value] {
+			continue
+		}
+
+		seen[value] = true
+		result = append(result, value)
+	}
+
+	return result
+}
+
+// emojis returns the emojis of the text. The sequences joined by a zero width joiner, the skin tones, the variation
+// selectors and the pairs of regional indicators (flags) are kept together as a single emoji
+func emojis(text string) []string {
+	found := make([]string, 0)
+
+	runes := []rune(text)
+	for i := 0; i < len(runes); i++ {
+		if !isEmoji(runes[i]) {
+			continue
+		}
+
+		end := i + 1
+		if isRegionalIndicator(runes[i]) {
+			if end < len(runes) && isRegionalIndicator(runes[end]) {
+				end++
+			}
+		} else {
+			for end < len(runes) {
+				switch {
+				case runes[end] == variationSelector || runes[end] == keycap || isSkinTone(runes[end]):
+					end++
+				case runes[end] == zeroWidthJoiner && end+1 < len(runes) && isEmoji(runes[end+1]):
+					end += 2
+				default:
+					goto done
+				}
+			}
+		}
+	done:
+		found = append(found, string(runes[i:end]))
+		i = end - 1
+	}
+
+	return found
+}
+
+// isEmoji reports whether the rune belongs to one of the Unicode blocks that contain emojis
+func isEmoji(r rune) bool {
+	if r == utf8.RuneError {
+		return false
+	}
+
+	return (r >= 0x1F000 && r <= 0x1FAFF) ||
+		(r >= 0x2600 && r <= 0x27BF) ||
+		(r >= 0x2300 && r <= 0x23FF) ||
+		(r >= 0x2B00 && r <= 0x2BFF) ||
+		r == 0x203C || r == 0x2049 || r == 0x2122 || r == 0x2139 || r == 0x3030 || r == 0x303D
+}
+
+// isSkinTone reports whether the rune is an emoji skin tone modifier
+func isSkinTone(r rune) bool {
+	return r >= 0x1F3FB && r <= 0x1F3FF
+}
+
+// isRegionalIndicator reports whether the rune is one of the letters used in pairs to represent flags
+func isRegionalIndicator(r rune) bool {
+	return r >= 0x1F1E6 && r <= 0x1F1FF
+}
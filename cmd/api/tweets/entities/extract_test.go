@@ -0,0 +1,47 @@
+package entities_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/tweets/entities"
+)
+
+func TestExtract_success(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected entities.Entities
+	}{
+		{
+			text:     "Hoy no como #Ana #ana #TCA, gracias @User_1 y @user_1",
+			expected: entities.Entities{Hashtags: []string{"ana", "tca"}, Mentions: []string{"user_1"}, URLs: []string{}, Emojis: []string{}, Cashtags: []string{}},
+		},
+		{
+			text:     "Mirá esto https://example.com/a#section, y www.test.com/path.",
+			expected: entities.Entities{Hashtags: []string{}, Mentions: []string{}, URLs: []string{"https://example.com/a#section", "www.test.com/path"}, Emojis: []string{}, Cashtags: []string{}},
+		},
+		{
+			text:     "No me siento bien 😭😭 👍🏽 👨‍👩‍👧 🇦🇷 ❤️",
+			expected: entities.Entities{Hashtags: []string{}, Mentions: []string{}, URLs: []string{}, Emojis: []string{"😭", "👍🏽", "👨‍👩‍👧", "🇦🇷", "❤️"}, Cashtags: []string{}},
+		},
+		{
+			text:     "Compré $btc y $TSLA a $100, email test@mail.com, #123 y C#",
+			expected: entities.Entities{Hashtags: []string{}, Mentions: []string{}, URLs: []string{}, Emojis: []string{}, Cashtags: []string{"BTC", "TSLA"}},
+		},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got := entities.Extract(&tt.text)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestExtract_successReturningEmptyEntitiesWhenTheTextIsNil(t *testing.T) {
+	want := entities.Entities{Hashtags: []string{}, Mentions: []string{}, URLs: []string{}, Emojis: []string{}, Cashtags: []string{}}
+	got := entities.Extract(nil)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,12 @@
+package entities
+
+import (
+	"net/http"
+
+	"ahbcc/cmd/api/tweets/backfill"
+)
+
+// BackfillHandlerV1 HTTP Handler of the endpoint POST /tweets/entities/backfill/v1
+func BackfillHandlerV1(backfillEntities Backfill) http.HandlerFunc {
+	return backfill.HandlerV1(backfillEntities, FailedToBackfillEntities, "Entities successfully backfilled")
+}
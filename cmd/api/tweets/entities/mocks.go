@@ -0,0 +1,38 @@
+package entities
+
+import "context"
+
+// MockEntities mocks the Entities
+func MockEntities() Entities {
+	return Entities{
+		Hashtags: []string{"test"},
+		Mentions: []string{"testuser"},
+		URLs:     []string{"https://test.com"},
+		Emojis:   []string{"😀"},
+		Cashtags: []string{"TEST"},
+	}
+}
+
+// MockSelectPending mocks SelectPending function
+func MockSelectPending(pending []PendingDAO, err error) SelectPending {
+	return func(ctx context.Context, table string, limit int) ([]PendingDAO, error) {
+		return pending, err
+	}
+}
+
+// MockUpdateExtracted mocks UpdateExtracted function
+func MockUpdateExtracted(err error) UpdateExtracted {
+	return func(ctx context.Context, table string, extracted []ExtractedDAO) error {
+		return err
+	}
+}
+
+// MockPendingDAOs mocks a slice of PendingDAO
+func MockPendingDAOs() []PendingDAO {
+	textContent := "test #Test @TestUser https://test.com 😀 $test"
+
+	return []PendingDAO{
+		{ID: 1, TextContent: &textContent},
+		{ID: 2, TextContent: nil},
+	}
+}
@@ -0,0 +1,44 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+const (
+	TweetsTable string = "tweets"
+	QuotesTable string = "tweets_quotes"
+)
+
+// SelectPending retrieves the rows of the given table, TweetsTable or QuotesTable, whose entities weren't extracted yet
+type SelectPending func(ctx context.Context, table string, limit int) ([]PendingDAO, error)
+
+// MakeSelectPending creates a new SelectPending
+func MakeSelectPending(db database.Connection, collectRows database.CollectRows[PendingDAO]) SelectPending {
+	const query string = `
+		SELECT p.id, p.text_content
+		FROM %s AS p
+		WHERE p.hashtags IS NULL
+		ORDER BY p.id
+		LIMIT $1;
+	`
+
+	return func(ctx context.Context, table string, limit int) ([]PendingDAO, error) {
+		rows, err := db.Query(ctx, fmt.Sprintf(query, table), limit)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectPending
+		}
+
+		pending, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectPending
+		}
+
+		return pending, nil
+	}
+}
@@ -0,0 +1,60 @@
+package entities_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/entities"
+	"ahbcc/internal/database"
+)
+
+func TestSelectPending_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockPending := entities.MockPendingDAOs()
+	mockCollectRows := database.MockCollectRows[entities.PendingDAO](mockPending, nil)
+
+	selectPending := entities.MakeSelectPending(mockPostgresConnection, mockCollectRows)
+
+	want := mockPending
+	got, err := selectPending(context.Background(), entities.TweetsTable, 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectPending_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select pending"))
+	mockCollectRows := database.MockCollectRows[entities.PendingDAO](nil, nil)
+
+	selectPending := entities.MakeSelectPending(mockPostgresConnection, mockCollectRows)
+
+	want := entities.FailedToExecuteSelectPending
+	_, got := selectPending(context.Background(), entities.TweetsTable, 100)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectPending_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[entities.PendingDAO](nil, errors.New("failed to collect rows"))
+
+	selectPending := entities.MakeSelectPending(mockPostgresConnection, mockCollectRows)
+
+	want := entities.FailedToExecuteCollectRowsInSelectPending
+	_, got := selectPending(context.Background(), entities.QuotesTable, 100)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// UpdateExtracted stores the extracted entities in the rows of the given table, TweetsTable or QuotesTable
+type UpdateExtracted func(ctx context.Context, table string, extracted []ExtractedDAO) error
+
+// MakeUpdateExtracted creates a new UpdateExtracted
+func MakeUpdateExtracted(db database.Connection) UpdateExtracted {
+	const (
+		query string = `
+			UPDATE %s AS t
+			SET hashtags = e.hashtags, mentions = e.mentions, urls = e.urls, emojis = e.emojis, cashtags = e.cashtags
+			FROM (VALUES %s) AS e(id, hashtags, mentions, urls, emojis, cashtags)
+			WHERE t.id = e.id;
+		`
+		parameters = 6
+	)
+
+	return func(ctx context.Context, table string, extracted []ExtractedDAO) error {
+		placeholders := make([]string, 0, len(extracted))
+		values := make([]any, 0, len(extracted)*parameters)
+		for i, dao := range extracted {
+			idx := i * parameters
+			placeholders = append(placeholders, fmt.Sprintf("($%d::INTEGER, $%d::TEXT[], $%d::TEXT[], $%d::TEXT[], $%d::TEXT[], $%d::TEXT[])", idx+1, idx+2, idx+3, idx+4, idx+5, idx+6))
+			values = append(values, dao.ID, dao.Entities.Hashtags, dao.Entities.Mentions, dao.Entities.URLs, dao.Entities.Emojis, dao.Entities.Cashtags)
+		}
+
+		queryToExecute := fmt.Sprintf(query, table, strings.Join(placeholders, ","))
+
+		_, err := db.Exec(ctx, queryToExecute, values...)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteUpdateExtracted
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package entities_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/entities"
+	"ahbcc/internal/database"
+)
+
+func TestUpdateExtracted_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+
+	updateExtracted := entities.MakeUpdateExtracted(mockPostgresConnection)
+
+	got := updateExtracted(context.Background(), entities.TweetsTable, []entities.ExtractedDAO{{ID: 1, Entities: entities.MockEntities()}})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateExtracted_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update extracted"))
+
+	updateExtracted := entities.MakeUpdateExtracted(mockPostgresConnection)
+
+	want := entities.FailedToExecuteUpdateExtracted
+	got := updateExtracted(context.Background(), entities.QuotesTable, []entities.ExtractedDAO{{ID: 1, Entities: entities.MockEntities()}})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -3,15 +3,34 @@ package tweets
 import "errors"
 
 var (
-	FailedToInsertTweets                                      = errors.New("failed to insert tweets")
-	MissingTweetStatusID                                      = errors.New("missing status tweet ID")
-	MissingTweetSearchCriteriaID                              = errors.New("missing tweet search criteria ID")
-	FailedToRetrieveUserUncategorizedTweets                   = errors.New("failed to retrieve user uncategorized tweets")
-	FailedToExecuteCollectRowsInSelectUserUncategorizedTweets = errors.New("failed to execute collect rows in select user uncategorized tweets")
-	AuthorizationTokenIsRequired                              = errors.New("authorization token is required")
-	FailedToRetrieveUserID                                    = errors.New("failed to retrieve user id")
-	NoTweetFoundForTheGivenTweetID                            = errors.New("no tweet found for the given tweet id")
-	FailedExecuteQueryToRetrieveTweetData                     = errors.New("failed to execute query to retrieve tweet data")
+	FailedToInsertTweets                                          = errors.New("failed to insert tweets")
+	MissingTweetStatusID                                          = errors.New("missing status tweet ID")
+	MissingTweetSearchCriteriaID                                  = errors.New("missing tweet search criteria ID")
+	InvalidTweetPostedAt                                          = errors.New("posted_at must be a RFC3339 timestamp")
+	MissingTweetInReplyToStatusID                                 = errors.New("missing in_reply_to status ID")
+	MissingTweetInReplyToAuthor                                   = errors.New("missing in_reply_to author")
+	FailedToRetrieveTweetsConversations                           = errors.New("failed to retrieve tweets conversations")
+	FailedToExecuteCollectRowsInInsertTweets                      = errors.New("failed to execute collect rows in insert tweets")
+	FailedToInsertTweetQuote                                      = errors.New("failed to insert the quote of the tweet")
+	FailedToBeginTransaction                                      = errors.New("failed to begin transaction")
+	FailedToCommitTransaction                                     = errors.New("failed to commit transaction")
+	FailedToRetrieveCriteriaTweets                                = errors.New("failed to retrieve criteria tweets")
+	FailedToExecuteCollectRowsInSelectCriteriaTweets              = errors.New("failed to execute collect rows in select criteria tweets")
+	FailedToRetrieveUserUncategorizedGoldTweets                   = errors.New("failed to retrieve user uncategorized gold tweets")
+	FailedToExecuteCollectRowsInSelectUserUncategorizedGoldTweets = errors.New("failed to execute collect rows in select user uncategorized gold tweets")
+	AuthorizationTokenIsRequired                                  = errors.New("authorization token is required")
+	FailedToRetrieveUserID                                        = errors.New("failed to retrieve user id")
+	NoTweetFoundForTheGivenTweetID                                = errors.New("no tweet found for the given tweet id")
+	FailedExecuteQueryToRetrieveTweetData                         = errors.New("failed to execute query to retrieve tweet data")
+	FailedToExecuteSelectTweetsByIDs                              = errors.New("failed to execute select tweets by ids")
+	FailedToExecuteCollectRowsInSelectTweetsByIDs                 = errors.New("failed to execute collect rows in select tweets by ids")
+	InvalidCursor                                                 = errors.New("invalid cursor")
+	InvalidMonth                                                  = errors.New("month must be between 1 and 12")
+	InvalidLimit                                                  = errors.New("limit must be a positive number that doesn't exceed the maximum")
+	InvalidSearchQuery                                            = errors.New("the search query must contain at least one word")
+	FailedToExecuteSearchTweets                                   = errors.New("failed to execute search tweets")
+	FailedToExecuteCollectRowsInSearchTweets                      = errors.New("failed to execute collect rows in search tweets")
+	InvalidCategorizationFilter                                   = errors.New("categorization must be UNCATEGORIZED, CATEGORIZED, ALL, POSITIVE, INDETERMINATE or NEGATIVE")
 )
 
 const (
@@ -21,4 +40,6 @@ const (
 	AuthorizationTokenRequired       string = "Authorization token is required"
 	FailedToInsertTweetsIntoDatabase string = "Failed to insert tweets into database"
 	FailedToRetrieveTweets           string = "Failed to retrieve tweets"
+	InvalidSearchQueryParameter      string = "Invalid search query"
+	FailedToSearchTweets             string = "Failed to search tweets"
 )
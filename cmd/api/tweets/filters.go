@@ -0,0 +1,241 @@
+package tweets
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"ahbcc/cmd/api/tweets/language"
+)
+
+const (
+	// CategorizationUncategorized only retrieves the tweets that were not categorized by the user. It is the default
+	CategorizationUncategorized string = "UNCATEGORIZED"
+
+	// CategorizationCategorized only retrieves the tweets that were already categorized by the user
+	CategorizationCategorized string = "CATEGORIZED"
+
+	// CategorizationAll retrieves the tweets regardless of their categorization
+	CategorizationAll string = "ALL"
+)
+
+// postedAtSortKey sorts the tweets by posted_at, placing the ones without it last
+const postedAtSortKey string = "COALESCE(t.posted_at, 'infinity')"
+
+// verdicts mirrors the values of the 'verdict' enum. They can also be used as the categorization filter, to only retrieve
+// the tweets that were categorized by the user with that verdict
+var verdicts = []string{"POSITIVE", "INDETERMINATE", "NEGATIVE"}
+
+type (
+	// Filters represents the filters that can be applied when retrieving the tweets of a criteria
+	Filters struct {
+		Year           int
+		Month          int
+		IsAReply       *bool
+		HasQuote       *bool
+		HasImages      *bool
+		Author         string
+		Categorization string
+		Cursor         *Cursor
+		Limit          int
+
+		// ExcludeLanguageMismatches excludes the tweets whose detected language isn't the one of any of their criteria
+		ExcludeLanguageMismatches bool
+
+		// ExcludeDuplicates only retrieves the representative of each group of near-duplicate tweets
+		ExcludeDuplicates bool
+	}
+
+	// Cursor represents the position of the last tweet of a page. The following page starts right after it.
+	// The Rank is only used to page through the results of a search, which are sorted by it. The PostedAt is nil when
+	// the last tweet of the page has no posted_at. The GoldID is the ID of the last gold tweet mixed into the pages, so
+	// the following pages continue with the next gold tweets instead of repeating them
+	Cursor struct {
+		PostedAt *time.Time `json:"p,omitempty"`
+		Rank     float64    `json:"r,omitempty"`
+		ID       int        `json:"i"`
+		GoldID   int        `json:"g,omitempty"`
+	}
+
+	// arguments holds the arguments of a query that is being built
+	arguments []any
+)
+
+// add appends the value to the arguments and returns its placeholder
+func (a *arguments) add(value any) string {
+	*a = append(*a, value)
+	return fmt.Sprintf("$%d", len(*a))
+}
+
+// predicates returns the SQL predicates needed to apply the filters, except the cursor, to the tweets table aliased as
+// 't'. The categorization predicates are relative to the given user
+func (f Filters) predicates(args *arguments, userID int) []string {
+	predicates := make([]string, 0)
+
+	if f.Year != 0 {
+		from := time.Date(f.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		to := from.AddDate(1, 0, 0)
+		if f.Month != 0 {
+			from = time.Date(f.Year, time.Month(f.Month), 1, 0, 0, 0, 0, time.UTC)
+			to = from.AddDate(0, 1, 0)
+		}
+
+		predicates = append(predicates, fmt.Sprintf("t.posted_at >= %s AND t.posted_at < %s", args.add(from), args.add(to)))
+	}
+
+	if f.IsAReply != nil {
+		predicates = append(predicates, fmt.Sprintf("t.is_a_reply = %s", args.add(*f.IsAReply)))
+	}
+
+	if f.HasQuote != nil {
+		if *f.HasQuote {
+			predicates = append(predicates, "t.quote_id IS NOT NULL")
+		} else {
+			predicates = append(predicates, "t.quote_id IS NULL")
+		}
+	}
+
+	if f.HasImages != nil {
+		if *f.HasImages {
+			predicates = append(predicates, "COALESCE(CARDINALITY(t.images), 0) > 0")
+		} else {
+			predicates = append(predicates, "COALESCE(CARDINALITY(t.images), 0) = 0")
+		}
+	}
+
+	if f.Author != "" {
+		predicates = append(predicates, fmt.Sprintf("t.author = %s", args.add(f.Author)))
+	}
+
+	if f.ExcludeLanguageMismatches {
+		predicates = append(predicates, fmt.Sprintf("(t.language IS NULL OR t.language = %s OR t.language_confidence < %s OR EXISTS (SELECT 1 FROM tweets_search_criteria AS lc INNER JOIN search_criteria AS sc ON sc.id = lc.search_criteria_id WHERE lc.tweet_id = t.id AND lower(sc.language) = t.language))", args.add(language.Undetermined), args.add(language.MinConfidence)))
+	}
+
+	if f.ExcludeDuplicates {
+		predicates = append(predicates, "(t.representative_id IS NULL OR t.representative_id = t.id)")
+	}
+
+	switch f.Categorization {
+	case CategorizationAll:
+	case CategorizationCategorized:
+		predicates = append(predicates, fmt.Sprintf("EXISTS (SELECT 1 FROM categorized_tweets AS c WHERE c.tweet_id = t.id AND c.user_id = %s)", args.add(userID)))
+	case "", CategorizationUncategorized:
+		predicates = append(predicates, fmt.Sprintf("NOT EXISTS (SELECT 1 FROM categorized_tweets AS c WHERE c.tweet_id = t.id AND c.user_id = %s)", args.add(userID)))
+	default:
+		predicates = append(predicates, fmt.Sprintf("EXISTS (SELECT 1 FROM categorized_tweets AS c WHERE c.tweet_id = t.id AND c.user_id = %s AND c.categorization = %s)", args.add(userID), args.add(f.Categorization)))
+	}
+
+	return predicates
+}
+
+// after returns the SQL predicate of the tweets that follow the cursor, sorted by postedAtSortKey and ID. It is a row
+// comparison, so the index on the same expressions is used to skip the previous pages
+func (c Cursor) after(args *arguments) string {
+	return fmt.Sprintf("(%s, t.id) > (COALESCE(%s::timestamptz, 'infinity'), %s)", postedAtSortKey, args.add(c.PostedAt), args.add(c.ID))
+}
+
+// uncategorized returns true when the filters only retrieve the tweets that were not categorized by the user
+func (f Filters) uncategorized() bool {
+	return f.Categorization == "" || f.Categorization == CategorizationUncategorized
+}
+
+// Encode returns the opaque representation of the cursor, to be sent to the clients
+func (c Cursor) Encode() string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses the opaque representation of a cursor
+func DecodeCursor(encoded string) (Cursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return Cursor{}, InvalidCursor
+	}
+
+	var cursor Cursor
+	err = json.Unmarshal(data, &cursor)
+	if err != nil || cursor.ID <= 0 {
+		return Cursor{}, InvalidCursor
+	}
+
+	return cursor, nil
+}
+
+// parseFilters parses the filters from the query params of a request.
+// The month is only considered if the year is present, and the limit falls back to defaultLimit when it is missing. A
+// limit that isn't a number between 1 and maxLimit is invalid
+func parseFilters(query url.Values, defaultLimit, maxLimit int) (Filters, error) {
+	filters := Filters{Limit: defaultLimit}
+
+	var err error
+	if yearQueryParam := query.Get("year"); yearQueryParam != "" {
+		filters.Year, err = strconv.Atoi(yearQueryParam)
+		if err != nil {
+			return Filters{}, err
+		}
+
+		if monthQueryParam := query.Get("month"); monthQueryParam != "" {
+			filters.Month, err = strconv.Atoi(monthQueryParam)
+			if err != nil {
+				return Filters{}, err
+			}
+
+			if filters.Month < 1 || filters.Month > 12 {
+				return Filters{}, InvalidMonth
+			}
+		}
+	}
+
+	for param, filter := range map[string]**bool{"is_a_reply": &filters.IsAReply, "has_quote": &filters.HasQuote, "has_images": &filters.HasImages} {
+		if value := query.Get(param); value != "" {
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return Filters{}, err
+			}
+			*filter = &parsed
+		}
+	}
+
+	for param, filter := range map[string]*bool{"exclude_language_mismatches": &filters.ExcludeLanguageMismatches, "exclude_duplicates": &filters.ExcludeDuplicates} {
+		if value := query.Get(param); value != "" {
+			*filter, err = strconv.ParseBool(value)
+			if err != nil {
+				return Filters{}, err
+			}
+		}
+	}
+
+	filters.Author = query.Get("author")
+
+	filters.Categorization = strings.ToUpper(query.Get("categorization"))
+	if filters.Categorization != "" &&
+		filters.Categorization != CategorizationUncategorized &&
+		filters.Categorization != CategorizationCategorized &&
+		filters.Categorization != CategorizationAll &&
+		!slices.Contains(verdicts, filters.Categorization) {
+		return Filters{}, InvalidCategorizationFilter
+	}
+
+	if cursorQueryParam := query.Get("cursor"); cursorQueryParam != "" {
+		cursor, err := DecodeCursor(cursorQueryParam)
+		if err != nil {
+			return Filters{}, err
+		}
+		filters.Cursor = &cursor
+	}
+
+	if limitQueryParam := query.Get("limit"); limitQueryParam != "" {
+		limit, err := strconv.Atoi(limitQueryParam)
+		if err != nil || limit <= 0 || limit > maxLimit {
+			return Filters{}, InvalidLimit
+		}
+		filters.Limit = limit
+	}
+
+	return filters, nil
+}
@@ -0,0 +1,30 @@
+package tweets
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCursorAfter_success(t *testing.T) {
+	postedAt := time.Date(2025, time.April, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		cursor       Cursor
+		expected     string
+		expectedArgs arguments
+	}{
+		{cursor: Cursor{PostedAt: &postedAt, ID: 15}, expected: "(COALESCE(t.posted_at, 'infinity'), t.id) > (COALESCE($1::timestamptz, 'infinity'), $2)", expectedArgs: arguments{&postedAt, 15}},
+		{cursor: Cursor{ID: 15}, expected: "(COALESCE(t.posted_at, 'infinity'), t.id) > (COALESCE($1::timestamptz, 'infinity'), $2)", expectedArgs: arguments{(*time.Time)(nil), 15}},
+	}
+
+	for _, tt := range tests {
+		var args arguments
+
+		got := tt.cursor.after(&args)
+
+		assert.Equal(t, tt.expected, got)
+		assert.Equal(t, tt.expectedArgs, args)
+	}
+}
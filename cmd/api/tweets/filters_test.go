@@ -0,0 +1,47 @@
+package tweets_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/tweets"
+)
+
+func TestDecodeCursor_success(t *testing.T) {
+	postedAt := time.Date(2025, time.April, 1, 10, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		cursor tweets.Cursor
+	}{
+		{cursor: tweets.Cursor{PostedAt: &postedAt, ID: 15}},
+		{cursor: tweets.Cursor{ID: 15}},
+		{cursor: tweets.Cursor{PostedAt: &postedAt, ID: 15, GoldID: 20}},
+	}
+
+	for _, tt := range tests {
+		want := tt.cursor
+		got, err := tweets.DecodeCursor(tt.cursor.Encode())
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestDecodeCursor_failsWhenTheCursorIsInvalid(t *testing.T) {
+	tests := []struct {
+		cursor string
+	}{
+		{cursor: "not base64!"},
+		{cursor: "bm90IGpzb24"},
+		{cursor: tweets.Cursor{Rank: 0.5}.Encode()},
+	}
+
+	for _, tt := range tests {
+		want := tweets.InvalidCursor
+		_, got := tweets.DecodeCursor(tt.cursor)
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,9 @@
+package gold
+
+// AccuracyDAO represents the amount of gold tweets categorized by an annotator and how many of them matched the gold verdict
+type AccuracyDAO struct {
+	UserID   int    `json:"user_id"`
+	Username string `json:"username"`
+	Answered int    `json:"answered"`
+	Correct  int    `json:"correct"`
+}
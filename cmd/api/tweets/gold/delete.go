@@ -0,0 +1,29 @@
+package gold
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// Delete removes the gold mark of a tweet
+type Delete func(ctx context.Context, tweetID int) error
+
+// MakeDelete creates a new Delete
+func MakeDelete(db database.Connection) Delete {
+	const query string = `
+		DELETE FROM gold_tweets
+		WHERE tweet_id = $1
+	`
+
+	return func(ctx context.Context, tweetID int) error {
+		_, err := db.Exec(ctx, query, tweetID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteDeleteGoldTweet
+		}
+
+		return nil
+	}
+}
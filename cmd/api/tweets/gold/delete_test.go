@@ -1,4 +1,4 @@
-package quotes_test
+package gold_test
 
 import (
 	"context"
@@ -9,32 +9,30 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
-	"ahbcc/cmd/api/tweets/quotes"
+	"ahbcc/cmd/api/tweets/gold"
 	"ahbcc/internal/database"
 )
 
-func TestDeleteOrphans_success(t *testing.T) {
+func TestDelete_success(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
-	mockIDs := []int{1, 2, 3}
 
-	deleteOrphansQuotes := quotes.MakeDeleteOrphans(mockPostgresConnection)
+	deleteGoldTweet := gold.MakeDelete(mockPostgresConnection)
 
-	got := deleteOrphansQuotes(context.Background(), mockIDs)
+	got := deleteGoldTweet(context.Background(), 123)
 
 	assert.Nil(t, got)
 	mockPostgresConnection.AssertExpectations(t)
 }
 
-func TestDeleteOrphans_failsWhenDeleteOperationThrowsError(t *testing.T) {
+func TestDelete_failsWhenDBExecFails(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to delete orphan quotes"))
-	mockIDs := []int{1, 2, 3}
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to delete gold tweet"))
 
-	deleteOrphansQuotes := quotes.MakeDeleteOrphans(mockPostgresConnection)
+	deleteGoldTweet := gold.MakeDelete(mockPostgresConnection)
 
-	want := quotes.FailedToDeleteOrphanQuotes
-	got := deleteOrphansQuotes(context.Background(), mockIDs)
+	want := gold.FailedToExecuteDeleteGoldTweet
+	got := deleteGoldTweet(context.Background(), 123)
 
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
@@ -0,0 +1,32 @@
+package gold
+
+type (
+	// DTO represents a gold tweet to be inserted into the 'gold_tweets' table
+	DTO struct {
+		TweetID        int    `json:"tweet_id"`
+		Categorization string `json:"categorization"`
+		CreatedBy      int    `json:"created_by"`
+	}
+
+	// MarkBodyDTO is the body of the PUT /tweets/{tweet_id}/gold/v1 endpoint
+	MarkBodyDTO struct {
+		Categorization string `json:"categorization"`
+	}
+
+	// AnnotatorQualityDTO represents the accuracy of an annotator over the gold tweets
+	AnnotatorQualityDTO struct {
+		UserID   int     `json:"user_id"`
+		Username string  `json:"username"`
+		Answered int     `json:"answered"`
+		Correct  int     `json:"correct"`
+		Accuracy float64 `json:"accuracy"`
+		Flagged  bool    `json:"flagged"`
+	}
+
+	// QualityDTO is the response of the /annotators/quality/v1 endpoint
+	QualityDTO struct {
+		Threshold      float64               `json:"threshold"`
+		MinimumAnswers int                   `json:"minimum_answers"`
+		Annotators     []AnnotatorQualityDTO `json:"annotators"`
+	}
+)
@@ -0,0 +1,31 @@
+package gold
+
+import "errors"
+
+var (
+	FailedToExecuteInsertGoldTweet                        = errors.New("failed to execute insert gold tweet")
+	FailedToExecuteDeleteGoldTweet                        = errors.New("failed to execute delete gold tweet")
+	FailedToExecuteSelectAccuracyByAnnotator              = errors.New("failed to execute select accuracy by annotator")
+	FailedToExecuteCollectRowsInSelectAccuracyByAnnotator = errors.New("failed to execute collect rows in select accuracy by annotator")
+	FailedToAuthorizeUser                                 = errors.New("failed to authorize user")
+	FailedToRetrieveTweetByID                             = errors.New("failed to retrieve tweet by id")
+	FailedToInsertGoldTweet                               = errors.New("failed to insert gold tweet")
+	FailedToDeleteGoldTweet                               = errors.New("failed to delete gold tweet")
+	FailedToRetrieveAccuracyByAnnotator                   = errors.New("failed to retrieve accuracy by annotator")
+	TweetNotFound                                         = errors.New("tweet not found")
+	AuthorizationTokenIsRequired                          = errors.New("authorization token is required")
+	InvalidTweetID                                        = errors.New("invalid tweet id")
+	InvalidCategorization                                 = errors.New("invalid categorization")
+)
+
+const (
+	AuthorizationTokenRequired    string = "Authorization token is required"
+	InvalidURLParameter           string = "Invalid url parameter"
+	InvalidRequestBody            string = "Invalid request body"
+	UserNotAuthorized             string = "User not authorized"
+	InvalidOrExpiredToken         string = "Invalid or expired session token"
+	FailedToMarkTweetAsGold       string = "Failed to mark tweet as gold"
+	FailedToUnmarkGoldTweet       string = "Failed to unmark gold tweet"
+	FailedToRetrieveQualityReport string = "Failed to retrieve quality report"
+	FailedToFindTheTweetToMark    string = "Failed to find the tweet to mark as gold"
+)
@@ -0,0 +1,131 @@
+package gold
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/categorized"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// MarkHandlerV1 HTTP Handler of the endpoint PUT /tweets/{tweet_id}/gold/v1
+func MarkHandlerV1(mark Mark) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		tweetIDParam := r.PathValue("tweet_id")
+		tweetID, err := strconv.Atoi(tweetIDParam)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidURLParameter, nil, InvalidTweetID)
+			return
+		}
+		ctx = log.With(ctx, log.Param("tweet_id", tweetID))
+
+		var body MarkBodyDTO
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+		ctx = log.With(ctx, log.Param("categorization", body.Categorization))
+
+		if !categorized.IsValidVerdict(body.Categorization) {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, InvalidCategorization)
+			return
+		}
+
+		err = mark(ctx, token, tweetID, body)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			case errors.Is(err, TweetNotFound):
+				response.Send(ctx, w, http.StatusNotFound, FailedToFindTheTweetToMark, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToMarkTweetAsGold, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Tweet successfully marked as gold", nil, nil)
+	}
+}
+
+// UnmarkHandlerV1 HTTP Handler of the endpoint DELETE /tweets/{tweet_id}/gold/v1
+func UnmarkHandlerV1(unmark Unmark) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		tweetIDParam := r.PathValue("tweet_id")
+		tweetID, err := strconv.Atoi(tweetIDParam)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidURLParameter, nil, InvalidTweetID)
+			return
+		}
+		ctx = log.With(ctx, log.Param("tweet_id", tweetID))
+
+		err = unmark(ctx, token, tweetID)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToUnmarkGoldTweet, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Gold tweet successfully unmarked", nil, nil)
+	}
+}
+
+// QualityHandlerV1 HTTP Handler of the endpoint /annotators/quality/v1
+func QualityHandlerV1(quality Quality) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+		ctx = log.With(ctx, log.Param("token", token))
+
+		report, err := quality(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveQualityReport, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Quality report successfully retrieved", report, nil)
+	}
+}
@@ -0,0 +1,209 @@
+package gold_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/gold"
+)
+
+func TestMarkHandlerV1_success(t *testing.T) {
+	mockMark := gold.MockMark(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	bodyBytes, _ := json.Marshal(gold.MarkBodyDTO{Categorization: "POSITIVE"})
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/tweets/{tweet_id}/gold/v1", bytes.NewReader(bodyBytes))
+	mockRequest.Header.Set("X-Session-Token", "token")
+	mockRequest.SetPathValue("tweet_id", "123")
+
+	markHandlerV1 := gold.MarkHandlerV1(mockMark)
+
+	markHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestMarkHandlerV1_failsWhenTheRequestIsInvalid(t *testing.T) {
+	tests := []struct {
+		token    string
+		tweetID  string
+		body     string
+		expected int
+	}{
+		{token: "", tweetID: "123", body: `{"categorization":"POSITIVE"}`, expected: http.StatusUnauthorized},
+		{token: "token", tweetID: "abc", body: `{"categorization":"POSITIVE"}`, expected: http.StatusBadRequest},
+		{token: "token", tweetID: "123", body: `{"categorization":`, expected: http.StatusBadRequest},
+		{token: "token", tweetID: "123", body: `{"categorization":"WRONG"}`, expected: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		mockMark := gold.MockMark(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/tweets/{tweet_id}/gold/v1", bytes.NewReader([]byte(tt.body)))
+		if tt.token != "" {
+			mockRequest.Header.Set("X-Session-Token", tt.token)
+		}
+		mockRequest.SetPathValue("tweet_id", tt.tweetID)
+
+		markHandlerV1 := gold.MarkHandlerV1(mockMark)
+
+		markHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestMarkHandlerV1_failsWhenMarkThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: gold.TweetNotFound, expected: http.StatusNotFound},
+		{err: errors.New("failed to mark"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockMark := gold.MockMark(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		bodyBytes, _ := json.Marshal(gold.MarkBodyDTO{Categorization: "POSITIVE"})
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/tweets/{tweet_id}/gold/v1", bytes.NewReader(bodyBytes))
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("tweet_id", "123")
+
+		markHandlerV1 := gold.MarkHandlerV1(mockMark)
+
+		markHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestUnmarkHandlerV1_success(t *testing.T) {
+	mockUnmark := gold.MockUnmark(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/tweets/{tweet_id}/gold/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+	mockRequest.SetPathValue("tweet_id", "123")
+
+	unmarkHandlerV1 := gold.UnmarkHandlerV1(mockUnmark)
+
+	unmarkHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestUnmarkHandlerV1_failsWhenUnmarkThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to unmark"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockUnmark := gold.MockUnmark(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/tweets/{tweet_id}/gold/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("tweet_id", "123")
+
+		unmarkHandlerV1 := gold.UnmarkHandlerV1(mockUnmark)
+
+		unmarkHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestQualityHandlerV1_success(t *testing.T) {
+	mockReport := gold.QualityDTO{Threshold: 0.8, MinimumAnswers: 5, Annotators: []gold.AnnotatorQualityDTO{{UserID: 1, Username: "good", Answered: 10, Correct: 9, Accuracy: 0.9}}}
+	mockQuality := gold.MockQuality(mockReport, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/quality/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	qualityHandlerV1 := gold.QualityHandlerV1(mockQuality)
+
+	qualityHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+
+	var response struct {
+		Data gold.QualityDTO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&response)
+	assert.NoError(t, err)
+
+	assert.Equal(t, mockReport, response.Data)
+}
+
+func TestQualityHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockQuality := gold.MockQuality(gold.QualityDTO{}, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/quality/v1", nil)
+
+	qualityHandlerV1 := gold.QualityHandlerV1(mockQuality)
+
+	qualityHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestQualityHandlerV1_failsWhenQualityThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to retrieve quality"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockQuality := gold.MockQuality(gold.QualityDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/annotators/quality/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		qualityHandlerV1 := gold.QualityHandlerV1(mockQuality)
+
+		qualityHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,31 @@
+package gold
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// Insert inserts a new gold tweet into the 'gold_tweets' table. If the tweet was already marked as gold, its verdict is
+// replaced by the new one, along with the admin that marked it and when
+type Insert func(ctx context.Context, dto DTO) error
+
+// MakeInsert creates a new Insert
+func MakeInsert(db database.Connection) Insert {
+	const query string = `
+		INSERT INTO gold_tweets(tweet_id, categorization, created_by)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (tweet_id) DO UPDATE SET categorization = EXCLUDED.categorization, created_by = EXCLUDED.created_by, created_at = CURRENT_TIMESTAMP;
+	`
+
+	return func(ctx context.Context, dto DTO) error {
+		_, err := db.Exec(ctx, query, dto.TweetID, dto.Categorization, dto.CreatedBy)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteInsertGoldTweet
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package gold_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/gold"
+	"ahbcc/internal/database"
+)
+
+func TestInsert_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+
+	insert := gold.MakeInsert(mockPostgresConnection)
+
+	got := insert(context.Background(), gold.MockDTO())
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsert_failsWhenDBExecFails(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert gold tweet"))
+
+	insert := gold.MakeInsert(mockPostgresConnection)
+
+	want := gold.FailedToExecuteInsertGoldTweet
+	got := insert(context.Background(), gold.MockDTO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,67 @@
+package gold
+
+import (
+	"context"
+	"errors"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+type (
+	// Mark marks a tweet as gold, storing the verdict that the annotators are expected to give to it.
+	// Only admins are allowed to mark tweets as gold
+	Mark func(ctx context.Context, token string, tweetID int, body MarkBodyDTO) error
+
+	// Unmark removes the gold mark of a tweet. Only admins are allowed to unmark tweets
+	Unmark func(ctx context.Context, token string, tweetID int) error
+)
+
+// MakeMark creates a new Mark
+func MakeMark(authorize auth.Authorize, selectTweetByID tweets.SelectByID, insertGoldTweet Insert) Mark {
+	return func(ctx context.Context, token string, tweetID int, body MarkBodyDTO) error {
+		userID, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		_, err = selectTweetByID(ctx, tweetID)
+		if errors.Is(err, tweets.NoTweetFoundForTheGivenTweetID) {
+			log.Error(ctx, err.Error())
+			return TweetNotFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToRetrieveTweetByID
+		}
+
+		err = insertGoldTweet(ctx, DTO{TweetID: tweetID, Categorization: body.Categorization, CreatedBy: userID})
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertGoldTweet
+		}
+
+		return nil
+	}
+}
+
+// MakeUnmark creates a new Unmark
+func MakeUnmark(authorize auth.Authorize, deleteGoldTweet Delete) Unmark {
+	return func(ctx context.Context, token string, tweetID int) error {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		err = deleteGoldTweet(ctx, tweetID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDeleteGoldTweet
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,119 @@
+package gold_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets"
+	"ahbcc/cmd/api/tweets/gold"
+)
+
+func TestMark_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+	mockInsert := gold.MockInsert(nil)
+
+	mark := gold.MakeMark(mockAuthorize, mockSelectTweetByID, mockInsert)
+
+	got := mark(context.Background(), "token", 123, gold.MarkBodyDTO{Categorization: "POSITIVE"})
+
+	assert.Nil(t, got)
+}
+
+func TestMark_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: gold.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+		mockInsert := gold.MockInsert(nil)
+
+		mark := gold.MakeMark(mockAuthorize, mockSelectTweetByID, mockInsert)
+
+		want := tt.expected
+		got := mark(context.Background(), "token", 123, gold.MarkBodyDTO{Categorization: "POSITIVE"})
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestMark_failsWhenSelectTweetByIDThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: tweets.NoTweetFoundForTheGivenTweetID, expected: gold.TweetNotFound},
+		{err: errors.New("failed to select tweet"), expected: gold.FailedToRetrieveTweetByID},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockSelectTweetByID := tweets.MockSelectByID(tweets.DAO{}, tt.err)
+		mockInsert := gold.MockInsert(nil)
+
+		mark := gold.MakeMark(mockAuthorize, mockSelectTweetByID, mockInsert)
+
+		want := tt.expected
+		got := mark(context.Background(), "token", 123, gold.MarkBodyDTO{Categorization: "POSITIVE"})
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestMark_failsWhenInsertThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectTweetByID := tweets.MockSelectByID(tweets.MockTweetDAO(), nil)
+	mockInsert := gold.MockInsert(errors.New("failed to insert"))
+
+	mark := gold.MakeMark(mockAuthorize, mockSelectTweetByID, mockInsert)
+
+	want := gold.FailedToInsertGoldTweet
+	got := mark(context.Background(), "token", 123, gold.MarkBodyDTO{Categorization: "POSITIVE"})
+
+	assert.Equal(t, want, got)
+}
+
+func TestUnmark_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockDelete := gold.MockDelete(nil)
+
+	unmark := gold.MakeUnmark(mockAuthorize, mockDelete)
+
+	got := unmark(context.Background(), "token", 123)
+
+	assert.Nil(t, got)
+}
+
+func TestUnmark_failsWhenAuthorizeThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(-1, auth.UserNotAuthorized)
+	mockDelete := gold.MockDelete(nil)
+
+	unmark := gold.MakeUnmark(mockAuthorize, mockDelete)
+
+	want := auth.UserNotAuthorized
+	got := unmark(context.Background(), "token", 123)
+
+	assert.Equal(t, want, got)
+}
+
+func TestUnmark_failsWhenDeleteThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockDelete := gold.MockDelete(errors.New("failed to delete"))
+
+	unmark := gold.MakeUnmark(mockAuthorize, mockDelete)
+
+	want := gold.FailedToDeleteGoldTweet
+	got := unmark(context.Background(), "token", 123)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,70 @@
+package gold
+
+import "context"
+
+// MockInsert mocks an Insert function
+func MockInsert(err error) Insert {
+	return func(ctx context.Context, dto DTO) error {
+		return err
+	}
+}
+
+// MockDelete mocks a Delete function
+func MockDelete(err error) Delete {
+	return func(ctx context.Context, tweetID int) error {
+		return err
+	}
+}
+
+// MockSelectAccuracyByAnnotator mocks a SelectAccuracyByAnnotator function
+func MockSelectAccuracyByAnnotator(accuracies []AccuracyDAO, err error) SelectAccuracyByAnnotator {
+	return func(ctx context.Context) ([]AccuracyDAO, error) {
+		return accuracies, err
+	}
+}
+
+// MockMark mocks a Mark function
+func MockMark(err error) Mark {
+	return func(ctx context.Context, token string, tweetID int, body MarkBodyDTO) error {
+		return err
+	}
+}
+
+// MockUnmark mocks an Unmark function
+func MockUnmark(err error) Unmark {
+	return func(ctx context.Context, token string, tweetID int) error {
+		return err
+	}
+}
+
+// MockQuality mocks a Quality function
+func MockQuality(report QualityDTO, err error) Quality {
+	return func(ctx context.Context, token string) (QualityDTO, error) {
+		return report, err
+	}
+}
+
+// MockFlaggedAnnotators mocks a FlaggedAnnotators function
+func MockFlaggedAnnotators(userIDs []int, err error) FlaggedAnnotators {
+	return func(ctx context.Context) ([]int, error) {
+		return userIDs, err
+	}
+}
+
+// MockDTO mocks a DTO
+func MockDTO() DTO {
+	return DTO{
+		TweetID:        123,
+		Categorization: "POSITIVE",
+		CreatedBy:      1,
+	}
+}
+
+// MockAccuracyDAOs mocks a []AccuracyDAO
+func MockAccuracyDAOs() []AccuracyDAO {
+	return []AccuracyDAO{
+		{UserID: 1, Username: "good", Answered: 10, Correct: 9},
+		{UserID: 2, Username: "bad", Answered: 10, Correct: 5},
+		{UserID: 3, Username: "new", Answered: 2, Correct: 0},
+	}
+}
@@ -0,0 +1,84 @@
+package gold
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+type (
+	// Quality returns the accuracy of each annotator over the gold tweets, flagging the ones whose accuracy is below
+	// the threshold. Only admins are allowed to retrieve it
+	Quality func(ctx context.Context, token string) (QualityDTO, error)
+
+	// FlaggedAnnotators returns the IDs of the annotators whose accuracy over the gold tweets is below the threshold
+	FlaggedAnnotators func(ctx context.Context) ([]int, error)
+)
+
+// MakeQuality creates a new Quality.
+// An annotator is only flagged after categorizing at least minimumAnswers gold tweets
+func MakeQuality(authorize auth.Authorize, selectAccuracyByAnnotator SelectAccuracyByAnnotator, threshold float64, minimumAnswers int) Quality {
+	return func(ctx context.Context, token string) (QualityDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return QualityDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		accuracies, err := selectAccuracyByAnnotator(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return QualityDTO{}, FailedToRetrieveAccuracyByAnnotator
+		}
+
+		return QualityDTO{
+			Threshold:      threshold,
+			MinimumAnswers: minimumAnswers,
+			Annotators:     evaluate(accuracies, threshold, minimumAnswers),
+		}, nil
+	}
+}
+
+// MakeFlaggedAnnotators creates a new FlaggedAnnotators
+func MakeFlaggedAnnotators(selectAccuracyByAnnotator SelectAccuracyByAnnotator, threshold float64, minimumAnswers int) FlaggedAnnotators {
+	return func(ctx context.Context) ([]int, error) {
+		accuracies, err := selectAccuracyByAnnotator(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveAccuracyByAnnotator
+		}
+
+		flagged := make([]int, 0)
+		for _, annotator := range evaluate(accuracies, threshold, minimumAnswers) {
+			if annotator.Flagged {
+				flagged = append(flagged, annotator.UserID)
+			}
+		}
+
+		return flagged, nil
+	}
+}
+
+// evaluate calculates the accuracy of each annotator and flags the ones below the threshold
+func evaluate(accuracies []AccuracyDAO, threshold float64, minimumAnswers int) []AnnotatorQualityDTO {
+	annotators := make([]AnnotatorQualityDTO, 0, len(accuracies))
+	for _, accuracy := range accuracies {
+		var ratio float64
+		if accuracy.Answered > 0 {
+			ratio = float64(accuracy.Correct) / float64(accuracy.Answered)
+		}
+
+		annotators = append(annotators, AnnotatorQualityDTO{
+			UserID:   accuracy.UserID,
+			Username: accuracy.Username,
+			Answered: accuracy.Answered,
+			Correct:  accuracy.Correct,
+			Accuracy: ratio,
+			Flagged:  accuracy.Answered >= minimumAnswers && ratio < threshold,
+		})
+	}
+
+	return annotators
+}
@@ -0,0 +1,80 @@
+package gold_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/gold"
+)
+
+func TestQuality_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectAccuracyByAnnotator := gold.MockSelectAccuracyByAnnotator(gold.MockAccuracyDAOs(), nil)
+
+	quality := gold.MakeQuality(mockAuthorize, mockSelectAccuracyByAnnotator, 0.8, 5)
+
+	want := gold.QualityDTO{
+		Threshold:      0.8,
+		MinimumAnswers: 5,
+		Annotators: []gold.AnnotatorQualityDTO{
+			{UserID: 1, Username: "good", Answered: 10, Correct: 9, Accuracy: 0.9, Flagged: false},
+			{UserID: 2, Username: "bad", Answered: 10, Correct: 5, Accuracy: 0.5, Flagged: true},
+			{UserID: 3, Username: "new", Answered: 2, Correct: 0, Accuracy: 0, Flagged: false},
+		},
+	}
+	got, err := quality(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestQuality_failsWhenAuthorizeThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(-1, auth.UserNotAuthorized)
+	mockSelectAccuracyByAnnotator := gold.MockSelectAccuracyByAnnotator(gold.MockAccuracyDAOs(), nil)
+
+	quality := gold.MakeQuality(mockAuthorize, mockSelectAccuracyByAnnotator, 0.8, 5)
+
+	want := auth.UserNotAuthorized
+	_, got := quality(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestQuality_failsWhenSelectAccuracyByAnnotatorThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectAccuracyByAnnotator := gold.MockSelectAccuracyByAnnotator(nil, errors.New("failed to select accuracy"))
+
+	quality := gold.MakeQuality(mockAuthorize, mockSelectAccuracyByAnnotator, 0.8, 5)
+
+	want := gold.FailedToRetrieveAccuracyByAnnotator
+	_, got := quality(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestFlaggedAnnotators_success(t *testing.T) {
+	mockSelectAccuracyByAnnotator := gold.MockSelectAccuracyByAnnotator(gold.MockAccuracyDAOs(), nil)
+
+	flaggedAnnotators := gold.MakeFlaggedAnnotators(mockSelectAccuracyByAnnotator, 0.8, 5)
+
+	want := []int{2}
+	got, err := flaggedAnnotators(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestFlaggedAnnotators_failsWhenSelectAccuracyByAnnotatorThrowsError(t *testing.T) {
+	mockSelectAccuracyByAnnotator := gold.MockSelectAccuracyByAnnotator(nil, errors.New("failed to select accuracy"))
+
+	flaggedAnnotators := gold.MakeFlaggedAnnotators(mockSelectAccuracyByAnnotator, 0.8, 5)
+
+	want := gold.FailedToRetrieveAccuracyByAnnotator
+	_, got := flaggedAnnotators(context.Background())
+
+	assert.Equal(t, want, got)
+}
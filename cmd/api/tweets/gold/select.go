@@ -0,0 +1,40 @@
+package gold
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// SelectAccuracyByAnnotator returns, for each annotator that categorized at least one gold tweet, how many gold tweets
+// were categorized and how many of them matched the gold verdict
+type SelectAccuracyByAnnotator func(ctx context.Context) ([]AccuracyDAO, error)
+
+// MakeSelectAccuracyByAnnotator creates a new SelectAccuracyByAnnotator
+func MakeSelectAccuracyByAnnotator(db database.Connection, collectRows database.CollectRows[AccuracyDAO]) SelectAccuracyByAnnotator {
+	const query string = `
+		SELECT u.id, u.username, COUNT(c.id), COUNT(c.id) FILTER (WHERE c.categorization = g.categorization)
+		FROM categorized_tweets AS c
+		INNER JOIN gold_tweets AS g ON g.tweet_id = c.tweet_id
+		INNER JOIN users AS u ON u.id = c.user_id
+		GROUP BY u.id, u.username
+		ORDER BY u.id;
+	`
+
+	return func(ctx context.Context) ([]AccuracyDAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectAccuracyByAnnotator
+		}
+
+		accuracies, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectAccuracyByAnnotator
+		}
+
+		return accuracies, nil
+	}
+}
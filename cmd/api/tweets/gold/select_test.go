@@ -0,0 +1,60 @@
+package gold_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/gold"
+	"ahbcc/internal/database"
+)
+
+func TestSelectAccuracyByAnnotator_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockAccuracies := gold.MockAccuracyDAOs()
+	mockCollectRows := database.MockCollectRows[gold.AccuracyDAO](mockAccuracies, nil)
+
+	selectAccuracyByAnnotator := gold.MakeSelectAccuracyByAnnotator(mockPostgresConnection, mockCollectRows)
+
+	want := mockAccuracies
+	got, err := selectAccuracyByAnnotator(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectAccuracyByAnnotator_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select accuracy"))
+	mockCollectRows := database.MockCollectRows[gold.AccuracyDAO](nil, nil)
+
+	selectAccuracyByAnnotator := gold.MakeSelectAccuracyByAnnotator(mockPostgresConnection, mockCollectRows)
+
+	want := gold.FailedToExecuteSelectAccuracyByAnnotator
+	_, got := selectAccuracyByAnnotator(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectAccuracyByAnnotator_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[gold.AccuracyDAO](nil, errors.New("failed to collect rows"))
+
+	selectAccuracyByAnnotator := gold.MakeSelectAccuracyByAnnotator(mockPostgresConnection, mockCollectRows)
+
+	want := gold.FailedToExecuteCollectRowsInSelectAccuracyByAnnotator
+	_, got := selectAccuracyByAnnotator(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -2,6 +2,7 @@ package tweets
 
 import (
 	"encoding/json"
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -10,6 +11,7 @@ import (
 )
 
 // InsertHandlerV1 HTTP Handler of the endpoint /tweets/v1
+// It responds with the outcome of each tweet, in the same order they were received
 func InsertHandlerV1(insertTweets Insert) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -20,42 +22,40 @@ func InsertHandlerV1(insertTweets Insert) http.HandlerFunc {
 			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
 			return
 		}
-		ctx = log.With(ctx, log.Param("tweets", tweets))
+		ctx = log.With(ctx, log.Param("tweets_count", len(tweets)))
 
-		err = validateBody(tweets)
-		if err != nil {
-			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
-			return
-		}
-
-		err = insertTweets(ctx, tweets)
+		results, err := insertTweets(ctx, tweets)
 		if err != nil {
 			response.Send(ctx, w, http.StatusInternalServerError, FailedToInsertTweetsIntoDatabase, nil, err)
 			return
 		}
 
-		response.Send(ctx, w, http.StatusOK, "Tweets successfully inserted", nil, nil)
+		response.Send(ctx, w, http.StatusOK, "Tweets successfully processed", results, nil)
 	}
 }
 
-// validateBody validates that mandatory fields are present
-func validateBody(body []TweetDTO) error {
-	for _, tweet := range body {
-		if tweet.StatusID == "" {
-			return MissingTweetStatusID
-		}
-
-		if tweet.SearchCriteriaID == nil {
-			return MissingTweetSearchCriteriaID
-		}
-	}
+// CriteriaTweetsHandlerV1 HTTP Handler of the endpoint /criteria/{criteria_id}/tweets/v1
+// It only responds with the tweets of the page, to keep the response compatible with the existing clients
+func CriteriaTweetsHandlerV1(selectBySearchCriteriaID SelectBySearchCriteriaID) http.HandlerFunc {
+	return criteriaTweetsHandler(selectBySearchCriteriaID, func(page PageDTO) any {
+		return page.Tweets
+	})
+}
 
-	return nil
+// CriteriaTweetsHandlerV2 HTTP Handler of the endpoint /criteria/{criteria_id}/tweets/v2
+// It responds with the tweets of the page and the cursor to retrieve the next one
+func CriteriaTweetsHandlerV2(selectBySearchCriteriaID SelectBySearchCriteriaID) http.HandlerFunc {
+	return criteriaTweetsHandler(selectBySearchCriteriaID, func(page PageDTO) any {
+		return page
+	})
 }
 
-// CriteriaTweetsHandlerV1 HTTP Handler of the endpoint /criteria/{criteria_id}/tweets/v1
-func CriteriaTweetsHandlerV1(selectBySearchCriteriaIDYearAndMonth SelectBySearchCriteriaIDYearAndMonth) http.HandlerFunc {
-	const defaultLimit int = 10
+// criteriaTweetsHandler retrieves a page of tweets of a criteria, and responds with the data built by toResponseData
+func criteriaTweetsHandler(selectBySearchCriteriaID SelectBySearchCriteriaID, toResponseData func(page PageDTO) any) http.HandlerFunc {
+	const (
+		defaultLimit int = 10
+		maxLimit     int = 100
+	)
 
 	return func(w http.ResponseWriter, r *http.Request) {
 		ctx := r.Context()
@@ -74,43 +74,79 @@ func CriteriaTweetsHandlerV1(selectBySearchCriteriaIDYearAndMonth SelectBySearch
 		}
 		ctx = log.With(ctx, log.Param("criteria_id", criteriaIDParam))
 
-		var year, month, limit int
-		yearQueryParamStr := r.URL.Query().Get("year")
-		if yearQueryParamStr != "" {
-			year, err = strconv.Atoi(yearQueryParamStr)
+		filters, err := parseFilters(r.URL.Query(), defaultLimit, maxLimit)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, err)
+			return
+		}
+		ctx = log.With(ctx, log.Param("query", r.URL.RawQuery))
+
+		page, err := selectBySearchCriteriaID(ctx, criteriaID, filters, token)
+		if err != nil {
+			response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveTweets, nil, err)
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Criteria tweets successfully retrieved", toResponseData(page), nil)
+	}
+}
+
+// SearchHandlerV1 HTTP Handler of the endpoint /tweets/search/v1
+func SearchHandlerV1(search Search) http.HandlerFunc {
+	const (
+		defaultLimit int = 10
+		maxLimit     int = 100
+	)
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		query := r.URL.Query()
+		searchQuery := query.Get("q")
+		if searchQuery == "" {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidSearchQueryParameter, nil, InvalidSearchQuery)
+			return
+		}
+		ctx = log.With(ctx, log.Param("q", searchQuery))
+
+		var criteriaID int
+		var err error
+		if criteriaIDQueryParam := query.Get("criteria_id"); criteriaIDQueryParam != "" {
+			criteriaID, err = strconv.Atoi(criteriaIDQueryParam)
 			if err != nil {
 				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, err)
 				return
 			}
-			
-			// Only retrieve the month if the year is present. Otherwise, the default value is 0, which means all months.
-			monthQueryParamStr := r.URL.Query().Get("month")
-			if monthQueryParamStr != "" {
-				month, err = strconv.Atoi(monthQueryParamStr)
-				if err != nil {
-					response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, err)
-					return
-				}
-				ctx = log.With(ctx, log.Param("month", month))
-			}
-			ctx = log.With(ctx, log.Param("year", year))
+			ctx = log.With(ctx, log.Param("criteria_id", criteriaIDQueryParam))
 		}
 
-		limitQueryParamStr := r.URL.Query().Get("limit")
-		if limitQueryParamStr != "" {
-			limit, err = strconv.Atoi(limitQueryParamStr)
-			if err != nil {
-				limit = defaultLimit
-			}
-			ctx = log.With(ctx, log.Param("limit", limitQueryParamStr))
+		filters, err := parseFilters(query, defaultLimit, maxLimit)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, err)
+			return
 		}
 
-		uncategorizedTweets, err := selectBySearchCriteriaIDYearAndMonth(ctx, criteriaID, year, month, limit, token)
-		if err != nil {
-			response.Send(ctx, w, http.StatusInternalServerError, FailedToRetrieveTweets, nil, err)
+		// Unlike the criteria tweets, the search includes all the tweets by default, regardless of their categorization
+		if filters.Categorization == "" {
+			filters.Categorization = CategorizationAll
+		}
+		ctx = log.With(ctx, log.Param("query", r.URL.RawQuery))
+
+		page, err := search(ctx, searchQuery, criteriaID, filters, token)
+		if errors.Is(err, InvalidSearchQuery) {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidSearchQueryParameter, nil, err)
+			return
+		} else if err != nil {
+			response.Send(ctx, w, http.StatusInternalServerError, FailedToSearchTweets, nil, err)
 			return
 		}
 
-		response.Send(ctx, w, http.StatusOK, "Criteria tweets successfully retrieved", uncategorizedTweets, nil)
+		response.Send(ctx, w, http.StatusOK, "Tweets successfully searched", page, nil)
 	}
 }
@@ -15,7 +15,7 @@ import (
 )
 
 func TestInsertHandlerV1_success(t *testing.T) {
-	mockInsert := tweets.MockInsert(nil)
+	mockInsert := tweets.MockInsert(tweets.MockInsertResultDTOs(), nil)
 	mockResponseWriter := httptest.NewRecorder()
 	mockTweets := tweets.MockTweetsDTOs()
 	mockBody, _ := json.Marshal(mockTweets)
@@ -32,7 +32,7 @@ func TestInsertHandlerV1_success(t *testing.T) {
 }
 
 func TestInsertHandlerV1_failsWhenTheBodyCannotBeParsed(t *testing.T) {
-	mockInsert := tweets.MockInsert(nil)
+	mockInsert := tweets.MockInsert(tweets.MockInsertResultDTOs(), nil)
 	mockResponseWriter := httptest.NewRecorder()
 	mockBody, _ := json.Marshal(`{"wrong": "body"}`)
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/v1", bytes.NewReader(mockBody))
@@ -47,44 +47,8 @@ func TestInsertHandlerV1_failsWhenTheBodyCannotBeParsed(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
-func TestInsertHandlerV1_failsWhenTweetIDIsNotPresentInBody(t *testing.T) {
-	mockInsert := tweets.MockInsert(nil)
-	mockResponseWriter := httptest.NewRecorder()
-	mockTweets := tweets.MockTweetsDTOs()
-	mockTweets[0].StatusID = ""
-	mockBody, _ := json.Marshal(mockTweets)
-	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/v1", bytes.NewReader(mockBody))
-
-	insertHandlerV1 := tweets.InsertHandlerV1(mockInsert)
-
-	insertHandlerV1(mockResponseWriter, mockRequest)
-
-	want := http.StatusBadRequest
-	got := mockResponseWriter.Result().StatusCode
-
-	assert.Equal(t, want, got)
-}
-
-func TestInsertHandlerV1_failsWhenTweetSearchCriteriaIDIsNotPresentInBody(t *testing.T) {
-	mockInsert := tweets.MockInsert(nil)
-	mockResponseWriter := httptest.NewRecorder()
-	mockTweets := tweets.MockTweetsDTOs()
-	mockTweets[0].SearchCriteriaID = nil
-	mockBody, _ := json.Marshal(mockTweets)
-	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPost, "/tweets/v1", bytes.NewReader(mockBody))
-
-	insertHandlerV1 := tweets.InsertHandlerV1(mockInsert)
-
-	insertHandlerV1(mockResponseWriter, mockRequest)
-
-	want := http.StatusBadRequest
-	got := mockResponseWriter.Result().StatusCode
-
-	assert.Equal(t, want, got)
-}
-
 func TestInsertHandlerV1_failsWhenInsertTweetsThrowsError(t *testing.T) {
-	mockInsert := tweets.MockInsert(errors.New("failed to insert tweets"))
+	mockInsert := tweets.MockInsert(nil, errors.New("failed to insert tweets"))
 	mockResponseWriter := httptest.NewRecorder()
 	mockTweets := tweets.MockTweetsDTOs()
 	mockBody, _ := json.Marshal(mockTweets)
@@ -101,8 +65,8 @@ func TestInsertHandlerV1_failsWhenInsertTweetsThrowsError(t *testing.T) {
 }
 
 func TestCriteriaTweetsHandlerV1_success(t *testing.T) {
-	mockTweets := tweets.MockCustomTweetDTOs()
-	mockSelectBySearchCriteriaIDYearAndMonth := tweets.MockSelectBySearchCriteriaIDYearAndMonth(mockTweets, nil)
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, nil)
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/criteria/{criteria_id}/tweets/v1", nil)
 	mockRequest.SetPathValue("criteria_id", "1")
@@ -113,7 +77,7 @@ func TestCriteriaTweetsHandlerV1_success(t *testing.T) {
 	mockURLQuery.Add("limit", "2")
 	mockRequest.URL.RawQuery = mockURLQuery.Encode()
 
-	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaIDYearAndMonth)
+	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaID)
 
 	criteriaTweetsV1(mockResponseWriter, mockRequest)
 
@@ -123,16 +87,15 @@ func TestCriteriaTweetsHandlerV1_success(t *testing.T) {
 	assert.Equal(t, want, got)
 }
 
-func TestCriteriaTweetsHandlerV1_successWithoutQueryParamsOrWithWrongLimitQueryParam(t *testing.T) {
+func TestCriteriaTweetsHandlerV1_successWithoutQueryParams(t *testing.T) {
 	tests := []struct {
 		params map[string]string
 	}{
 		{params: map[string]string{}},
-		{params: map[string]string{"limit": "wrong"}},
 	}
 
-	mockTweets := tweets.MockCustomTweetDTOs()
-	mockSelectBySearchCriteriaIDYearAndMonth := tweets.MockSelectBySearchCriteriaIDYearAndMonth(mockTweets, nil)
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, nil)
 	mockResponseWriter := httptest.NewRecorder()
 
 	for _, tt := range tests {
@@ -145,7 +108,7 @@ func TestCriteriaTweetsHandlerV1_successWithoutQueryParamsOrWithWrongLimitQueryP
 		}
 		mockRequest.URL.RawQuery = mockURLQuery.Encode()
 
-		criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaIDYearAndMonth)
+		criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaID)
 
 		criteriaTweetsV1(mockResponseWriter, mockRequest)
 
@@ -157,8 +120,8 @@ func TestCriteriaTweetsHandlerV1_successWithoutQueryParamsOrWithWrongLimitQueryP
 }
 
 func TestCriteriaTweetsHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
-	mockTweets := tweets.MockCustomTweetDTOs()
-	mockSelectBySearchCriteriaIDYearAndMonth := tweets.MockSelectBySearchCriteriaIDYearAndMonth(mockTweets, nil)
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, nil)
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/criteria/{criteria_id}/tweets/v1", nil)
 	mockRequest.SetPathValue("criteria_id", "1")
@@ -168,7 +131,7 @@ func TestCriteriaTweetsHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testi
 	mockURLQuery.Add("limit", "2")
 	mockRequest.URL.RawQuery = mockURLQuery.Encode()
 
-	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaIDYearAndMonth)
+	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaID)
 
 	criteriaTweetsV1(mockResponseWriter, mockRequest)
 
@@ -179,14 +142,14 @@ func TestCriteriaTweetsHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testi
 }
 
 func TestCriteriaTweetsHandlerV1_failsWhenTheURLParamCannotBeParsed(t *testing.T) {
-	mockTweets := tweets.MockCustomTweetDTOs()
-	mockSelectBySearchCriteriaIDYearAndMonth := tweets.MockSelectBySearchCriteriaIDYearAndMonth(mockTweets, nil)
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, nil)
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/criteria/{criteria_id}/tweets/v1", nil)
 	mockRequest.SetPathValue("criteria_id", "wrong")
 	mockRequest.Header.Set("X-Session-Token", "token")
 
-	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaIDYearAndMonth)
+	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaID)
 
 	criteriaTweetsV1(mockResponseWriter, mockRequest)
 
@@ -196,16 +159,27 @@ func TestCriteriaTweetsHandlerV1_failsWhenTheURLParamCannotBeParsed(t *testing.T
 	assert.Equal(t, want, got)
 }
 
-func TestCriteriaTweetsHandlerV1_failsWhenYearOrMonthQueryParamsCannotBeParsed(t *testing.T) {
+func TestCriteriaTweetsHandlerV1_failsWhenQueryParamsCannotBeParsed(t *testing.T) {
 	tests := []struct {
 		params map[string]string
 	}{
 		{params: map[string]string{"year": "wrong", "month": "1"}},
 		{params: map[string]string{"year": "2025", "month": "wrong"}},
+		{params: map[string]string{"year": "2025", "month": "13"}},
+		{params: map[string]string{"is_a_reply": "wrong"}},
+		{params: map[string]string{"has_quote": "wrong"}},
+		{params: map[string]string{"has_images": "wrong"}},
+		{params: map[string]string{"exclude_language_mismatches": "wrong"}},
+		{params: map[string]string{"exclude_duplicates": "wrong"}},
+		{params: map[string]string{"categorization": "wrong"}},
+		{params: map[string]string{"cursor": "wrong"}},
+		{params: map[string]string{"limit": "wrong"}},
+		{params: map[string]string{"limit": "0"}},
+		{params: map[string]string{"limit": "101"}},
 	}
 
-	mockTweets := tweets.MockCustomTweetDTOs()
-	mockSelectBySearchCriteriaIDYearAndMonth := tweets.MockSelectBySearchCriteriaIDYearAndMonth(mockTweets, nil)
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, nil)
 	mockResponseWriter := httptest.NewRecorder()
 
 	for _, tt := range tests {
@@ -218,7 +192,7 @@ func TestCriteriaTweetsHandlerV1_failsWhenYearOrMonthQueryParamsCannotBeParsed(t
 		}
 		mockRequest.URL.RawQuery = mockURLQuery.Encode()
 
-		criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaIDYearAndMonth)
+		criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaID)
 
 		criteriaTweetsV1(mockResponseWriter, mockRequest)
 
@@ -229,9 +203,9 @@ func TestCriteriaTweetsHandlerV1_failsWhenYearOrMonthQueryParamsCannotBeParsed(t
 	}
 }
 
-func TestCriteriaTweetsHandlerV1_failsWhenSelectBySearchCriteriaIDYearAndMonthThrowsError(t *testing.T) {
-	mockTweets := tweets.MockCustomTweetDTOs()
-	mockSelectBySearchCriteriaIDYearAndMonth := tweets.MockSelectBySearchCriteriaIDYearAndMonth(mockTweets, errors.New("failed to retrieve tweets"))
+func TestCriteriaTweetsHandlerV1_failsWhenSelectBySearchCriteriaIDThrowsError(t *testing.T) {
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, errors.New("failed to retrieve tweets"))
 	mockResponseWriter := httptest.NewRecorder()
 	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/criteria/{criteria_id}/tweets/v1", nil)
 	mockRequest.SetPathValue("criteria_id", "1")
@@ -242,7 +216,7 @@ func TestCriteriaTweetsHandlerV1_failsWhenSelectBySearchCriteriaIDYearAndMonthTh
 	mockURLQuery.Add("limit", "2")
 	mockRequest.URL.RawQuery = mockURLQuery.Encode()
 
-	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaIDYearAndMonth)
+	criteriaTweetsV1 := tweets.CriteriaTweetsHandlerV1(mockSelectBySearchCriteriaID)
 
 	criteriaTweetsV1(mockResponseWriter, mockRequest)
 
@@ -251,3 +225,149 @@ func TestCriteriaTweetsHandlerV1_failsWhenSelectBySearchCriteriaIDYearAndMonthTh
 
 	assert.Equal(t, want, got)
 }
+
+func TestCriteriaTweetsHandlerV2_success(t *testing.T) {
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/criteria/{criteria_id}/tweets/v2", nil)
+	mockRequest.SetPathValue("criteria_id", "1")
+	mockRequest.Header.Set("X-Session-Token", "token")
+	mockURLQuery := mockRequest.URL.Query()
+	mockURLQuery.Add("year", "2025")
+	mockURLQuery.Add("month", "1")
+	mockURLQuery.Add("is_a_reply", "false")
+	mockURLQuery.Add("has_quote", "true")
+	mockURLQuery.Add("has_images", "true")
+	mockURLQuery.Add("exclude_language_mismatches", "true")
+	mockURLQuery.Add("exclude_duplicates", "true")
+	mockURLQuery.Add("author", "TestAuthor")
+	mockURLQuery.Add("categorization", "positive")
+	mockURLQuery.Add("cursor", *mockPage.NextCursor)
+	mockURLQuery.Add("limit", "2")
+	mockRequest.URL.RawQuery = mockURLQuery.Encode()
+
+	criteriaTweetsV2 := tweets.CriteriaTweetsHandlerV2(mockSelectBySearchCriteriaID)
+
+	criteriaTweetsV2(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestCriteriaTweetsHandlerV2_failsWhenSelectBySearchCriteriaIDThrowsError(t *testing.T) {
+	mockPage := tweets.MockPageDTO()
+	mockSelectBySearchCriteriaID := tweets.MockSelectBySearchCriteriaID(mockPage, errors.New("failed to retrieve tweets"))
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/criteria/{criteria_id}/tweets/v2", nil)
+	mockRequest.SetPathValue("criteria_id", "1")
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	criteriaTweetsV2 := tweets.CriteriaTweetsHandlerV2(mockSelectBySearchCriteriaID)
+
+	criteriaTweetsV2(mockResponseWriter, mockRequest)
+
+	want := http.StatusInternalServerError
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestSearchHandlerV1_success(t *testing.T) {
+	mockSearch := tweets.MockSearch(tweets.MockSearchPageDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/tweets/search/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+	mockURLQuery := mockRequest.URL.Query()
+	mockURLQuery.Add("q", `"hate speech" OR violen*`)
+	mockURLQuery.Add("criteria_id", "1")
+	mockURLQuery.Add("year", "2025")
+	mockURLQuery.Add("limit", "20")
+	mockRequest.URL.RawQuery = mockURLQuery.Encode()
+
+	searchHandlerV1 := tweets.SearchHandlerV1(mockSearch)
+
+	searchHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestSearchHandlerV1_failsWhenSessionTokenHeaderWasNotFound(t *testing.T) {
+	mockSearch := tweets.MockSearch(tweets.MockSearchPageDTO(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/tweets/search/v1?q=hate", nil)
+
+	searchHandlerV1 := tweets.SearchHandlerV1(mockSearch)
+
+	searchHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestSearchHandlerV1_failsWhenQueryParamsAreInvalid(t *testing.T) {
+	tests := []struct {
+		params map[string]string
+	}{
+		{params: map[string]string{}},
+		{params: map[string]string{"q": "hate", "criteria_id": "wrong"}},
+		{params: map[string]string{"q": "hate", "has_quote": "wrong"}},
+		{params: map[string]string{"q": "hate", "cursor": "wrong"}},
+		{params: map[string]string{"q": "hate", "limit": "wrong"}},
+	}
+
+	mockSearch := tweets.MockSearch(tweets.MockSearchPageDTO(), nil)
+
+	for _, tt := range tests {
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/tweets/search/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockURLQuery := mockRequest.URL.Query()
+		for k, v := range tt.params {
+			mockURLQuery.Add(k, v)
+		}
+		mockRequest.URL.RawQuery = mockURLQuery.Encode()
+
+		searchHandlerV1 := tweets.SearchHandlerV1(mockSearch)
+
+		searchHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSearchHandlerV1_failsWhenSearchThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: tweets.InvalidSearchQuery, expected: http.StatusBadRequest},
+		{err: tweets.FailedToExecuteSearchTweets, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockSearch := tweets.MockSearch(tweets.SearchPageDTO{}, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/tweets/search/v1?q=hate", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		searchHandlerV1 := tweets.SearchHandlerV1(mockSearch)
+
+		searchHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
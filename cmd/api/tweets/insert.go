@@ -6,69 +6,325 @@ import (
 	"strings"
 	"time"
 
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/cmd/api/authors"
+	"ahbcc/cmd/api/tweets/entities"
+	"ahbcc/cmd/api/tweets/language"
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/tweets/quotes"
 	"ahbcc/internal/database"
 	"ahbcc/internal/log"
 )
 
-// Insert inserts a new TweetDTO into 'tweets' table
-type Insert func(ctx context.Context, tweet []TweetDTO) error
+const (
+	InsertResultInserted  string = "INSERTED"
+	InsertResultDuplicate string = "DUPLICATE"
+	InsertResultInvalid   string = "INVALID"
+)
+
+// Insert inserts the given TweetDTOs into 'tweets' table, and relates them to their search criteria in the
+// 'tweets_search_criteria' table. A tweet that was already retrieved by another criteria is not inserted again, it is
+// only related to the new one.
+// The tweets are inserted in chunks inside a single transaction, so a large batch doesn't exceed the maximum number of
+// parameters of a query, and either all of them or none are stored. Their quotes are upserted into the 'tweets_quotes'
+// table inside the same transaction, each one in its own savepoint: a tweet whose quote fails to be stored is discarded
+// as INVALID, without discarding the rest.
+// The entities of the text (hashtags, mentions, URLs, emojis and cashtags) are extracted, and its language detected,
+// before inserting each tweet. The conversation of the replies is inserted into the 'tweets_parents' table, in a
+// savepoint of the same transaction.
+// Once the tweets are stored, the authors of the tweets and their quotes, and the avatars they had, are upserted into
+// the 'authors' and 'authors_avatars' tables.
+// It returns the outcome of each tweet, in the same order they were received:
+//   - INSERTED: the tweet was stored for its search criteria
+//   - DUPLICATE: the tweet was already stored for its search criteria, or it was repeated in the batch
+//   - INVALID: the tweet was discarded, the reason explains why
+type Insert func(ctx context.Context, tweets []TweetDTO) ([]InsertResultDTO, error)
 
 // MakeInsert creates a new Insert
-func MakeInsert(db database.Connection, insertQuote quotes.InsertSingle, deleteOrphanQuotes quotes.DeleteOrphans) Insert {
+func MakeInsert(db database.Connection, collectRows database.CollectRows[RelatedDAO], insertQuote quotes.InsertSingle, insertParents parents.InsertChains, upsertAuthors authors.UpsertSightings) Insert {
 	const (
 		query string = `
-			INSERT INTO tweets(status_id, author, avatar, posted_at, is_a_reply, text_content, images, quote_id, search_criteria_id) 
-			VALUES %s
-		    ON CONFLICT (status_id, posted_at, search_criteria_id) DO NOTHING;
+			WITH input(status_id, author, avatar, posted_at, is_a_reply, text_content, images, hashtags, mentions, urls, emojis, cashtags, language, language_confidence, in_reply_to_status_id, quote_id, search_criteria_id) AS (
+				VALUES %s
+			),
+			upserted AS (
+				INSERT INTO tweets(status_id, author, avatar, posted_at, is_a_reply, text_content, images, hashtags, mentions, urls, emojis, cashtags, language, language_confidence, in_reply_to_status_id, quote_id, search_criteria_id)
+				SELECT DISTINCT ON (status_id) * FROM input
+				ON CONFLICT (status_id) DO UPDATE SET 
+					quote_id = COALESCE(tweets.quote_id, EXCLUDED.quote_id),
+					hashtags = COALESCE(tweets.hashtags, EXCLUDED.hashtags),
+					mentions = COALESCE(tweets.mentions, EXCLUDED.mentions),
+					urls = COALESCE(tweets.urls, EXCLUDED.urls),
+					emojis = COALESCE(tweets.emojis, EXCLUDED.emojis),
+					cashtags = COALESCE(tweets.cashtags, EXCLUDED.cashtags),
+					language = COALESCE(tweets.language, EXCLUDED.language),
+					language_confidence = COALESCE(tweets.language_confidence, EXCLUDED.language_confidence),
+					in_reply_to_status_id = COALESCE(tweets.in_reply_to_status_id, EXCLUDED.in_reply_to_status_id)
+				RETURNING id, status_id
+			),
+			related AS (
+				INSERT INTO tweets_search_criteria(tweet_id, search_criteria_id)
+				SELECT DISTINCT u.id, i.search_criteria_id
+				FROM upserted AS u
+				INNER JOIN input AS i ON i.status_id = u.status_id
+				ON CONFLICT DO NOTHING
+				RETURNING tweet_id, search_criteria_id
+			)
+			SELECT u.status_id, r.search_criteria_id
+			FROM related AS r
+			INNER JOIN upserted AS u ON u.id = r.tweet_id;
 		`
-		parameters = 9
+		parameters = 17
+
+		// chunkSize keeps each query far below the limit of 65535 parameters of Postgres
+		chunkSize = 1000
 	)
 
-	return func(ctx context.Context, tweets []TweetDTO) error {
-		placeholders := make([]string, 0, len(tweets)*parameters)
-		values := make([]any, 0, len(tweets)*parameters)
-		quoteIDs := make([]int, 0, len(tweets))
+	return func(ctx context.Context, tweets []TweetDTO) ([]InsertResultDTO, error) {
+		results := make([]InsertResultDTO, len(tweets))
+		postedAts := make([]*time.Time, len(tweets))
+		candidates := make([]int, 0, len(tweets))
 		for i, tweet := range tweets {
-			idx := i * parameters
-			placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d, $%d)", idx+1, idx+2, idx+3, idx+4, idx+5, idx+6, idx+7, idx+8, idx+9))
-			values = append(values, tweet.StatusID, tweet.Author, tweet.Avatar)
-
-			var postedAt *time.Time
-			if tweet.PostedAt != "" {
-				parsedDate, err := time.Parse(time.RFC3339, tweet.PostedAt)
-				if err != nil {
-					log.Warn(ctx, err.Error())
-				} else {
-					postedAt = &parsedDate
+			results[i] = InsertResultDTO{StatusID: tweet.StatusID}
+
+			postedAt, err := validateTweet(tweet)
+			if err != nil {
+				setInvalid(&results[i], err)
+				continue
+			}
+			postedAts[i] = postedAt
+			candidates = append(candidates, i)
+		}
+
+		if len(candidates) == 0 {
+			return results, nil
+		}
+
+		tx, err := db.Begin(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToBeginTransaction
+		}
+
+		defer tx.Rollback(ctx)
+
+		// The quotes are upserted in the same transaction as the tweets that reference them, so none is left behind
+		// when the tweets are not stored. Each one is upserted in its own savepoint, so a quote that fails only
+		// discards the tweets that quote it. The same quoted tweet can be quoted by more than one tweet of the same call
+		quoteIDsByStatusID := make(map[string]int)
+		failedQuotes := make(map[string]bool)
+		valid := make([]int, 0, len(candidates))
+		values := make([]any, 0, len(candidates)*parameters)
+		sightings := make([]authors.SightingDTO, 0, len(candidates))
+		conversations := make([]*parents.ParentDTO, 0)
+		for _, i := range candidates {
+			tweet := tweets[i]
+
+			var quoteID any
+			if quote := tweet.Quote; quote != nil {
+				id, alreadyInserted := quoteIDsByStatusID[quote.StatusID]
+				failed := failedQuotes[quote.StatusID]
+				if !alreadyInserted && !failed {
+					id, err = insertQuoteInSavepoint(ctx, tx, insertQuote, quote)
+					if err != nil {
+						log.Error(ctx, err.Error())
+						failed = true
+					}
+
+					// A quote without status ID can't be told apart from the others, so it is always upserted
+					if quote.StatusID != "" {
+						quoteIDsByStatusID[quote.StatusID] = id
+						failedQuotes[quote.StatusID] = failed
+					}
+				}
+
+				if failed {
+					setInvalid(&results[i], FailedToInsertTweetQuote)
+					continue
 				}
+				quoteID = id
 			}
+			valid = append(valid, i)
 
-			values = append(values, postedAt, tweet.IsAReply, tweet.TextContent, tweet.Images)
+			extracted := entities.Extract(tweet.TextContent)
+			detected := language.Detect(tweet.TextContent)
+			values = append(values, tweet.StatusID, tweet.Author, tweet.Avatar, postedAts[i], tweet.IsAReply || tweet.InReplyTo != nil, tweet.TextContent, tweet.Images, extracted.Hashtags, extracted.Mentions, extracted.URLs, extracted.Emojis, extracted.Cashtags, detected.Language, detected.Confidence)
 
-			quoteID, err := insertQuote(ctx, tweet.Quote)
-			if err != nil {
-				values = append(values, nil)
+			if tweet.InReplyTo != nil {
+				conversations = append(conversations, tweet.InReplyTo)
+				values = append(values, tweet.InReplyTo.StatusID)
 			} else {
-				values = append(values, quoteID)
-				quoteIDs = append(quoteIDs, quoteID)
+				values = append(values, nil)
 			}
 
-			values = append(values, tweet.SearchCriteriaID)
+			values = append(values, quoteID, *tweet.SearchCriteriaID)
+			sightings = append(sightings, tweetSightings(tweet, postedAts[i])...)
+		}
+
+		if len(valid) == 0 {
+			return results, nil
 		}
 
-		queryToExecute := fmt.Sprintf(query, strings.Join(placeholders, ","))
+		// The conversations are inserted in a savepoint of the transaction, so failing to store them is logged but
+		// doesn't discard the tweets. They are stored even if the tweets end up being duplicates, since they are shared
+		// by all the replies to the same tweet
+		if len(conversations) > 0 {
+			err = insertConversations(ctx, tx, insertParents, conversations)
+			if err != nil {
+				log.Warn(ctx, fmt.Sprintf("The conversations of the tweets were not stored: %s", err.Error()))
+			}
+		}
 
-		_, err := db.Exec(ctx, queryToExecute, values...)
+		related := make(map[RelatedDAO]bool, len(valid))
+		for start := 0; start < len(valid); start += chunkSize {
+			end := min(start+chunkSize, len(valid))
+
+			placeholders := make([]string, 0, end-start)
+			for i := range end - start {
+				idx := i * parameters
+				placeholders = append(placeholders, fmt.Sprintf("($%d::TEXT, $%d::TEXT, $%d::TEXT, $%d::TIMESTAMPTZ, $%d::BOOLEAN, $%d::TEXT, $%d::TEXT[], $%d::TEXT[], $%d::TEXT[], $%d::TEXT[], $%d::TEXT[], $%d::TEXT[], $%d::TEXT, $%d::DOUBLE PRECISION, $%d::TEXT, $%d::INTEGER, $%d::INTEGER)", idx+1, idx+2, idx+3, idx+4, idx+5, idx+6, idx+7, idx+8, idx+9, idx+10, idx+11, idx+12, idx+13, idx+14, idx+15, idx+16, idx+17))
+			}
+
+			queryToExecute := fmt.Sprintf(query, strings.Join(placeholders, ","))
+
+			rows, err := tx.Query(ctx, queryToExecute, values[start*parameters:end*parameters]...)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return nil, FailedToInsertTweets
+			}
+
+			relatedDAOs, err := collectRows(rows)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return nil, FailedToExecuteCollectRowsInInsertTweets
+			}
+
+			for _, relatedDAO := range relatedDAOs {
+				related[relatedDAO] = true
+			}
+		}
+
+		err = tx.Commit(ctx)
 		if err != nil {
 			log.Error(ctx, err.Error())
-			return FailedToInsertTweets
+			return nil, FailedToCommitTransaction
+		}
+
+		// The authors are only upserted once their tweets were stored, and failing to do it doesn't discard the tweets
+		err = upsertAuthors(ctx, sightings)
+		if err != nil {
+			log.Warn(ctx, fmt.Sprintf("The authors of the tweets were not stored: %s", err.Error()))
 		}
 
-		if len(quoteIDs) > 0 {
-			err = deleteOrphanQuotes(ctx, quoteIDs)
+		// Only the first occurrence of a tweet for a search criteria is the inserted one, the rest are duplicates
+		for _, i := range valid {
+			key := RelatedDAO{StatusID: tweets[i].StatusID, SearchCriteriaID: *tweets[i].SearchCriteriaID}
+			if related[key] {
+				results[i].Result = InsertResultInserted
+				delete(related, key)
+			} else {
+				results[i].Result = InsertResultDuplicate
+			}
 		}
 
-		return nil
+		return results, nil
+	}
+}
+
+// insertQuoteInSavepoint upserts the given quote inside a savepoint of the transaction, which is rolled back if it
+// fails, so the transaction can still be used
+func insertQuoteInSavepoint(ctx context.Context, tx pgx.Tx, insertQuote quotes.InsertSingle, quote *quotes.QuoteDTO) (int, error) {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return -1, err
+	}
+
+	defer savepoint.Rollback(ctx)
+
+	quoteID, err := insertQuote(savepoint, ctx, quote)
+	if err != nil {
+		return -1, err
+	}
+
+	return quoteID, savepoint.Commit(ctx)
+}
+
+// setInvalid marks the result of a tweet as INVALID, with the given error as its reason
+func setInvalid(result *InsertResultDTO, err error) {
+	reason := err.Error()
+	result.Result = InsertResultInvalid
+	result.Reason = &reason
+}
+
+// insertConversations inserts the given conversations inside a savepoint of the transaction, which is rolled back if
+// they fail, so the transaction can still be used
+func insertConversations(ctx context.Context, tx pgx.Tx, insertParents parents.InsertChains, conversations []*parents.ParentDTO) error {
+	savepoint, err := tx.Begin(ctx)
+	if err != nil {
+		return err
 	}
+
+	defer savepoint.Rollback(ctx)
+
+	err = insertParents(savepoint, ctx, conversations)
+	if err != nil {
+		return err
+	}
+
+	return savepoint.Commit(ctx)
+}
+
+// tweetSightings returns the sightings of the author of the tweet and of the author of its quote, if any. The moment
+// they were seen is the moment their tweets were posted, or the current one when it is unknown
+func tweetSightings(tweet TweetDTO, postedAt *time.Time) []authors.SightingDTO {
+	now := time.Now()
+
+	seenAt := now
+	if postedAt != nil {
+		seenAt = *postedAt
+	}
+	sightings := []authors.SightingDTO{{Handle: tweet.Author, Avatar: tweet.Avatar, SeenAt: seenAt}}
+
+	if tweet.Quote != nil && tweet.Quote.Author != "" {
+		quoteSeenAt, err := time.Parse(time.RFC3339, tweet.Quote.PostedAt)
+		if err != nil {
+			quoteSeenAt = now
+		}
+		sightings = append(sightings, authors.SightingDTO{Handle: tweet.Quote.Author, Avatar: tweet.Quote.Avatar, SeenAt: quoteSeenAt})
+	}
+
+	return sightings
+}
+
+// validateTweet validates that the mandatory fields are present, and returns the parsed posted_at, which is optional
+func validateTweet(tweet TweetDTO) (*time.Time, error) {
+	if tweet.StatusID == "" {
+		return nil, MissingTweetStatusID
+	}
+
+	if tweet.SearchCriteriaID == nil {
+		return nil, MissingTweetSearchCriteriaID
+	}
+
+	if tweet.InReplyTo != nil {
+		if tweet.InReplyTo.StatusID == "" {
+			return nil, MissingTweetInReplyToStatusID
+		}
+
+		if tweet.InReplyTo.Author == "" {
+			return nil, MissingTweetInReplyToAuthor
+		}
+	}
+
+	if tweet.PostedAt == "" {
+		return nil, nil
+	}
+
+	postedAt, err := time.Parse(time.RFC3339, tweet.PostedAt)
+	if err != nil {
+		return nil, InvalidTweetPostedAt
+	}
+
+	return &postedAt, nil
 }
@@ -4,36 +4,44 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
-	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
+	"ahbcc/cmd/api/authors"
 	"ahbcc/cmd/api/tweets"
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/tweets/quotes"
 	"ahbcc/internal/database"
 )
 
 func TestInsert_success(t *testing.T) {
-	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
 	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
-	mockDeleteOrphanQuotes := quotes.MockDeleteOrphans(nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
 	mockTweetDTO := tweets.MockTweetsDTOs()
 
-	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockInsertSingleQuote, mockDeleteOrphanQuotes)
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
 
-	got := insertTweet(context.Background(), mockTweetDTO)
+	want := tweets.MockInsertResultDTOs()
+	got, err := insertTweet(context.Background(), mockTweetDTO)
 
-	assert.Nil(t, got)
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
 }
 
 func TestInsert_successWithTextContentImagesAndQuoteNil(t *testing.T) {
-	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
 	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
-	mockDeleteOrphanQuotes := quotes.MockDeleteOrphans(nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
 	mockTweetDTO := tweets.MockTweetsDTOs()
 	mockTweetDTO[0].TextContent = nil
 	mockTweetDTO[0].Images = nil
@@ -42,72 +50,447 @@ func TestInsert_successWithTextContentImagesAndQuoteNil(t *testing.T) {
 	mockTweetDTO[1].Images = nil
 	mockTweetDTO[1].Quote = nil
 
-	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockInsertSingleQuote, mockDeleteOrphanQuotes)
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	want := tweets.MockInsertResultDTOs()
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsert_successReportingTheInvalidTweets(t *testing.T) {
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := []tweets.TweetDTO{tweets.MockTweetDTO(), tweets.MockTweetDTO(), tweets.MockTweetDTO(), tweets.MockTweetDTO(), tweets.MockTweetDTO(), tweets.MockTweetDTO()}
+	mockTweetDTO[1].StatusID = ""
+	mockTweetDTO[2].SearchCriteriaID = nil
+	mockTweetDTO[3].PostedAt = "wrong"
+	mockTweetDTO[4].InReplyTo = &parents.ParentDTO{Author: "TestParentAuthor"}
+	mockTweetDTO[5].InReplyTo = &parents.ParentDTO{StatusID: "1111111111111111111"}
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	missingStatusID := tweets.MissingTweetStatusID.Error()
+	missingSearchCriteriaID := tweets.MissingTweetSearchCriteriaID.Error()
+	invalidPostedAt := tweets.InvalidTweetPostedAt.Error()
+	missingInReplyToStatusID := tweets.MissingTweetInReplyToStatusID.Error()
+	missingInReplyToAuthor := tweets.MissingTweetInReplyToAuthor.Error()
+	want := []tweets.InsertResultDTO{
+		{StatusID: "1234567890987654321", Result: tweets.InsertResultInserted},
+		{StatusID: "", Result: tweets.InsertResultInvalid, Reason: &missingStatusID},
+		{StatusID: "1234567890987654321", Result: tweets.InsertResultInvalid, Reason: &missingSearchCriteriaID},
+		{StatusID: "1234567890987654321", Result: tweets.InsertResultInvalid, Reason: &invalidPostedAt},
+		{StatusID: "1234567890987654321", Result: tweets.InsertResultInvalid, Reason: &missingInReplyToStatusID},
+		{StatusID: "1234567890987654321", Result: tweets.InsertResultInvalid, Reason: &missingInReplyToAuthor},
+	}
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsert_successUpsertingTheAuthorsOfTheTweetsAndTheirQuotes(t *testing.T) {
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	var upsertedSightings []authors.SightingDTO
+	mockUpsertAuthors := func(ctx context.Context, sightings []authors.SightingDTO) error {
+		upsertedSightings = sightings
+		return errors.New("failed to upsert sightings")
+	}
+	mockTweetDTO := tweets.MockTweetsDTOs()[:1]
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	want := tweets.MockInsertResultDTOs()[:1]
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	wantPostedAt, _ := time.Parse(time.RFC3339, mockTweetDTO[0].PostedAt)
+	wantQuotePostedAt, _ := time.Parse(time.RFC3339, mockTweetDTO[0].Quote.PostedAt)
+	wantSightings := []authors.SightingDTO{
+		{Handle: mockTweetDTO[0].Author, Avatar: mockTweetDTO[0].Avatar, SeenAt: wantPostedAt},
+		{Handle: mockTweetDTO[0].Quote.Author, Avatar: mockTweetDTO[0].Quote.Avatar, SeenAt: wantQuotePostedAt},
+	}
+	assert.Equal(t, wantSightings, upsertedSightings)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsert_successStoringTheConversationOfTheRepliesInASavepoint(t *testing.T) {
+	mockSavepoint := new(database.MockPgxTx)
+	mockSavepoint.On("Commit", mock.Anything).Return(nil)
+	mockSavepoint.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresConnection, mockPostgresTx := mockInsertTransactionWithSavepoint(mockSavepoint)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	var parentsTx pgx.Tx
+	var insertedParents []*parents.ParentDTO
+	mockInsertParents := func(tx pgx.Tx, ctx context.Context, conversations []*parents.ParentDTO) error {
+		parentsTx = tx
+		insertedParents = conversations
+		return nil
+	}
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockParentDTO := parents.MockParentDTO()
+	mockTweetDTO := tweets.MockTweetsDTOs()
+	mockTweetDTO[0].InReplyTo = &mockParentDTO
+	mockTweetDTO[1].InReplyTo = &mockParentDTO
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	want := tweets.MockInsertResultDTOs()
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, pgx.Tx(mockSavepoint), parentsTx)
+	assert.Equal(t, []*parents.ParentDTO{&mockParentDTO, &mockParentDTO}, insertedParents)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+	mockSavepoint.AssertExpectations(t)
+}
+
+func TestInsert_successEvenWhenTheConversationOfTheRepliesFailsToBeStored(t *testing.T) {
+	mockSavepoint := new(database.MockPgxTx)
+	mockSavepoint.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresConnection, mockPostgresTx := mockInsertTransactionWithSavepoint(mockSavepoint)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(errors.New("failed to insert parents"))
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockParentDTO := parents.MockParentDTO()
+	mockTweetDTO := tweets.MockTweetsDTOs()
+	mockTweetDTO[0].InReplyTo = &mockParentDTO
+	mockTweetDTO[0].Quote = nil
+	mockTweetDTO[1].Quote = nil
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
 
-	got := insertTweet(context.Background(), mockTweetDTO)
+	want := tweets.MockInsertResultDTOs()
+	got, err := insertTweet(context.Background(), mockTweetDTO)
 
-	assert.Nil(t, got)
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+	mockSavepoint.AssertExpectations(t)
+	mockSavepoint.AssertNotCalled(t, "Commit", mock.Anything)
 }
 
-func TestInsert_successEvenWhenTheTimestampParseFails(t *testing.T) {
+func TestInsert_successWithoutOpeningATransactionWhenAllTheTweetsAreInvalid(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](nil, nil)
 	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
-	mockDeleteOrphanQuotes := quotes.MockDeleteOrphans(nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
 	mockTweetDTO := tweets.MockTweetsDTOs()
-	mockTweetDTO[0].PostedAt = "wrong"
+	mockTweetDTO[0].StatusID = ""
+	mockTweetDTO[1].StatusID = ""
 
-	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockInsertSingleQuote, mockDeleteOrphanQuotes)
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
 
-	got := insertTweet(context.Background(), mockTweetDTO)
+	got, err := insertTweet(context.Background(), mockTweetDTO)
 
-	assert.Nil(t, got)
+	assert.Nil(t, err)
+	assert.Len(t, got, 2)
+	assert.Equal(t, tweets.InsertResultInvalid, got[0].Result)
+	assert.Equal(t, tweets.InsertResultInvalid, got[1].Result)
+	mockPostgresConnection.AssertNotCalled(t, "Begin", mock.Anything)
+}
+
+func TestInsert_successReportingDuplicatesWhenTheTweetsWereAlreadyStored(t *testing.T) {
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO]([]tweets.RelatedDAO{}, nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := tweets.MockTweetsDTOs()
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, tweets.InsertResultDuplicate, got[0].Result)
+	assert.Equal(t, tweets.InsertResultDuplicate, got[1].Result)
 	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
 }
 
-func TestInsert_successEvenWhenTheQuoteInsertFailsInsertingNilQuoteInTweetsTable(t *testing.T) {
+func TestInsert_successInsertingLargeBatchesInChunks(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
-	mockInsertSingleQuote := quotes.MockInsertSingle(-1, errors.New("failed to insert single quote"))
-	mockDeleteOrphanQuotes := quotes.MockDeleteOrphans(nil)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(new(database.MockPgxRows), nil).Times(3)
+	mockPostgresTx.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresTx.On("Commit", mock.Anything).Return(nil)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO]([]tweets.RelatedDAO{}, nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := make([]tweets.TweetDTO, 2500)
+	for i := range mockTweetDTO {
+		mockTweetDTO[i] = tweets.MockTweetDTO()
+	}
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Len(t, got, 2500)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsert_successInsertingTheSameQuotedTweetOnlyOnce(t *testing.T) {
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	quoteInsertions := 0
+	mockInsertSingleQuote := func(tx pgx.Tx, ctx context.Context, quote *quotes.QuoteDTO) (int, error) {
+		quoteInsertions++
+		return 1, nil
+	}
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
 	mockTweetDTO := tweets.MockTweetsDTOs()
 
-	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockInsertSingleQuote, mockDeleteOrphanQuotes)
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
 
-	got := insertTweet(context.Background(), mockTweetDTO)
+	_, err := insertTweet(context.Background(), mockTweetDTO)
 
-	assert.Nil(t, got)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, quoteInsertions)
 	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
 }
 
-func TestInsert_successEvenWhenTheDeleteOrphanQuotesThrowsError(t *testing.T) {
+func TestInsert_failsWhenBeginTransactionThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, errors.New("failed to begin transaction"))
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
 	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
-	mockDeleteOrphanQuotes := quotes.MockDeleteOrphans(errors.New("failed to delete orphan quotes"))
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
 	mockTweetDTO := tweets.MockTweetsDTOs()
 
-	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockInsertSingleQuote, mockDeleteOrphanQuotes)
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
 
-	got := insertTweet(context.Background(), mockTweetDTO)
+	want := tweets.FailedToBeginTransaction
+	_, got := insertTweet(context.Background(), mockTweetDTO)
 
-	assert.Nil(t, got)
+	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
 }
 
+func TestInsert_successInsertingTheQuotesInASavepointOfTheTransaction(t *testing.T) {
+	mockSavepoint := new(database.MockPgxTx)
+	mockSavepoint.On("Commit", mock.Anything).Return(nil)
+	mockSavepoint.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresConnection, mockPostgresTx := mockInsertTransactionWithSavepoint(mockSavepoint)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	var quoteTx pgx.Tx
+	mockInsertSingleQuote := func(tx pgx.Tx, ctx context.Context, quote *quotes.QuoteDTO) (int, error) {
+		quoteTx = tx
+		return 1, nil
+	}
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := tweets.MockTweetsDTOs()
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	_, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, pgx.Tx(mockSavepoint), quoteTx)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+	mockSavepoint.AssertExpectations(t)
+}
+
+func TestInsert_successDiscardingOnlyTheTweetsWhoseQuoteFailsToBeStored(t *testing.T) {
+	mockSavepoint := new(database.MockPgxTx)
+	mockSavepoint.On("Commit", mock.Anything).Return(nil)
+	mockSavepoint.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresConnection, mockPostgresTx := mockInsertTransactionWithSavepoint(mockSavepoint)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := func(tx pgx.Tx, ctx context.Context, quote *quotes.QuoteDTO) (int, error) {
+		if quote.StatusID == "failing" {
+			return -1, errors.New("failed to insert single quote")
+		}
+		return 1, nil
+	}
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := tweets.MockTweetsDTOs()
+	failingQuote := *mockTweetDTO[0].Quote
+	failingQuote.StatusID = "failing"
+	mockTweetDTO[0].Quote = &failingQuote
+	otherQuote := *mockTweetDTO[1].Quote
+	otherQuote.StatusID = "other"
+	mockTweetDTO[1].Quote = &otherQuote
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, tweets.InsertResultInvalid, got[0].Result)
+	assert.Equal(t, tweets.FailedToInsertTweetQuote.Error(), *got[0].Reason)
+	assert.Equal(t, tweets.InsertResultInserted, got[1].Result)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+	mockSavepoint.AssertNumberOfCalls(t, "Commit", 1)
+}
+
+func TestInsert_successWithoutInsertingTheTweetsWhenAllTheirQuotesFailToBeStored(t *testing.T) {
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(-1, errors.New("failed to insert single quote"))
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := tweets.MockTweetsDTOs()
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, tweets.InsertResultInvalid, got[0].Result)
+	assert.Equal(t, tweets.InsertResultInvalid, got[1].Result)
+	mockPostgresTx.AssertNotCalled(t, "Query", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestInsert_successEvenWhenUpsertAuthorsThrowsError(t *testing.T) {
+	mockPostgresConnection, mockPostgresTx := mockInsertTransaction()
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(errors.New("failed to upsert authors"))
+	mockTweetDTO := tweets.MockTweetsDTOs()
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	want := tweets.MockInsertResultDTOs()
+	got, err := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
 func TestInsert_failsWhenInsertOperationThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert tweets"))
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(new(database.MockPgxRows), errors.New("failed to insert tweets"))
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresTx.On("Begin", mock.Anything).Return(mockQuoteSavepoint(), nil)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
 	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
-	mockDeleteOrphanQuotes := quotes.MockDeleteOrphans(nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
 	mockTweetDTO := tweets.MockTweetsDTOs()
 
-	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockInsertSingleQuote, mockDeleteOrphanQuotes)
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
 
 	want := tweets.FailedToInsertTweets
-	got := insertTweet(context.Background(), mockTweetDTO)
+	_, got := insertTweet(context.Background(), mockTweetDTO)
 
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsert_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(new(database.MockPgxRows), nil)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresTx.On("Begin", mock.Anything).Return(mockQuoteSavepoint(), nil)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](nil, errors.New("failed to collect rows"))
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := tweets.MockTweetsDTOs()
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	want := tweets.FailedToExecuteCollectRowsInInsertTweets
+	_, got := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsert_failsWhenCommitThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(new(database.MockPgxRows), nil)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresTx.On("Commit", mock.Anything).Return(errors.New("failed to commit transaction"))
+	mockPostgresTx.On("Begin", mock.Anything).Return(mockQuoteSavepoint(), nil)
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+	mockCollectRows := database.MockCollectRows[tweets.RelatedDAO](tweets.MockRelatedDAOs(), nil)
+	mockInsertSingleQuote := quotes.MockInsertSingle(1, nil)
+	mockInsertParents := parents.MockInsertChains(nil)
+	mockUpsertAuthors := authors.MockUpsertSightings(nil)
+	mockTweetDTO := tweets.MockTweetsDTOs()
+
+	insertTweet := tweets.MakeInsert(mockPostgresConnection, mockCollectRows, mockInsertSingleQuote, mockInsertParents, mockUpsertAuthors)
+
+	want := tweets.FailedToCommitTransaction
+	_, got := insertTweet(context.Background(), mockTweetDTO)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+// mockQuoteSavepoint mocks a savepoint that commits successfully
+func mockQuoteSavepoint() *database.MockPgxTx {
+	mockSavepoint := new(database.MockPgxTx)
+	mockSavepoint.On("Commit", mock.Anything).Return(nil)
+	mockSavepoint.On("Rollback", mock.Anything).Return(nil)
+
+	return mockSavepoint
+}
+
+// mockInsertTransaction mocks a connection whose transaction executes a single chunk successfully
+func mockInsertTransaction() (*database.MockPostgresConnection, *database.MockPgxTx) {
+	return mockInsertTransactionWithSavepoint(nil)
+}
+
+// mockInsertTransactionWithSavepoint mocks the transaction of the Insert, whose savepoints are the given one. When it
+// is nil, the transaction itself is used as its savepoints
+func mockInsertTransactionWithSavepoint(mockSavepoint *database.MockPgxTx) (*database.MockPostgresConnection, *database.MockPgxTx) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(new(database.MockPgxRows), nil)
+	mockPostgresTx.On("Rollback", mock.Anything).Return(nil)
+	mockPostgresTx.On("Commit", mock.Anything).Return(nil)
+	if mockSavepoint == nil {
+		mockSavepoint = mockPostgresTx
+	}
+	mockPostgresTx.On("Begin", mock.Anything).Return(mockSavepoint, nil).Maybe()
+	mockPostgresConnection.On("Begin", mock.Anything).Return(mockPostgresTx, nil)
+
+	return mockPostgresConnection, mockPostgresTx
 }
@@ -0,0 +1,41 @@
+package language
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/backfill"
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/log"
+)
+
+// Backfill detects the language of the tweets that were inserted before the language was detected at ingestion time.
+// Each call processes up to the batch size of tweets, so it must be called until the count of the result is zero.
+// Only admins are allowed to backfill the languages
+type Backfill func(ctx context.Context, token string) (BackfillResultDTO, error)
+
+// MakeBackfill creates a new Backfill
+func MakeBackfill(authorize auth.Authorize, selectPending SelectPending, updateDetected UpdateDetected, batchSize int) Backfill {
+	return func(ctx context.Context, token string) (BackfillResultDTO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return BackfillResultDTO{}, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		tweets, err := backfill.Batch(ctx,
+			func(ctx context.Context) ([]PendingDAO, error) {
+				return selectPending(ctx, batchSize)
+			},
+			func(pending PendingDAO) DetectedDAO {
+				return DetectedDAO{ID: pending.ID, Detection: Detect(pending.TextContent)}
+			},
+			updateDetected,
+		)
+		if err != nil {
+			return BackfillResultDTO{}, err
+		}
+
+		return BackfillResultDTO{Tweets: tweets}, nil
+	}
+}
@@ -0,0 +1,60 @@
+package language_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/tweets/language"
+)
+
+func TestBackfill_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockPending := language.MockPendingDAOs()
+	mockSelectPending := language.MockSelectPending(mockPending, nil)
+	var updated []language.DetectedDAO
+	mockUpdateDetected := func(ctx context.Context, detected []language.DetectedDAO) error {
+		updated = detected
+		return nil
+	}
+
+	backfill := language.MakeBackfill(mockAuthorize, mockSelectPending, mockUpdateDetected, 100)
+
+	want := language.BackfillResultDTO{Tweets: 2}
+	got, err := backfill(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	wantDetected := []language.DetectedDAO{
+		{ID: 1, Detection: language.Detect(mockPending[0].TextContent)},
+		{ID: 2, Detection: language.DetectionDTO{Language: language.Undetermined, Confidence: 0}},
+	}
+	assert.Equal(t, wantDetected, updated)
+	assert.Equal(t, "es", updated[0].Detection.Language)
+}
+
+func TestBackfill_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: language.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectPending := language.MockSelectPending(language.MockPendingDAOs(), nil)
+		mockUpdateDetected := language.MockUpdateDetected(nil)
+
+		backfill := language.MakeBackfill(mockAuthorize, mockSelectPending, mockUpdateDetected, 100)
+
+		want := tt.expected
+		_, got := backfill(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,13 @@
+package language
+
+// PendingDAO represents a tweet whose language wasn't detected yet
+type PendingDAO struct {
+	ID          int     `json:"id"`
+	TextContent *string `json:"text_content,omitempty"`
+}
+
+// DetectedDAO represents the language detected in the text of a tweet
+type DetectedDAO struct {
+	ID        int
+	Detection DetectionDTO
+}
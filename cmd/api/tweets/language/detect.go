@@ -0,0 +1,181 @@
+package language
+
+import (
+	"math"
+	"regexp"
+	"strings"
+	"unicode"
+)
+
+const (
+	// Undetermined is the language of the texts that are too short, or whose script isn't the one of the supported
+	// languages, to be identified
+	Undetermined string = "und"
+
+	// MinConfidence is the minimum confidence a detected language must have to be considered a mismatch with the
+	// language of a search criteria
+	MinConfidence float64 = 0.8
+
+	// minLetters is the minimum number of letters a text must have to try to identify its language
+	minLetters int = 12
+
+	// minCoverage is the minimum fraction of the trigrams of a text that must be present in the profiles
+	minCoverage float64 = 0.4
+)
+
+var (
+	ignoredRegexp = regexp.MustCompile(`(?i)\b(?:https?://|www\.)\S+|[@＠#＃$]\w+`)
+	profiles      = buildProfiles(samples)
+)
+
+type (
+	// profile represents the logarithm of the smoothed probability of each trigram of a language
+	profile struct {
+		logProbabilities map[string]float64
+		unseen           float64
+	}
+)
+
+// Detect identifies the language of the text, among the ones of the samples, using a naive Bayes classifier over its
+// character trigrams. The confidence is the posterior probability of the detected language. The URLs, mentions,
+// hashtags and cashtags are ignored. The texts that can't be identified are Undetermined, with a confidence of zero
+func Detect(text *string) DetectionDTO {
+	undetermined := DetectionDTO{Language: Undetermined, Confidence: 0}
+	if text == nil {
+		return undetermined
+	}
+
+	normalized := normalize(*text)
+	if countLetters(normalized) < minLetters {
+		return undetermined
+	}
+
+	textTrigrams := trigrams(normalized)
+
+	var total, known int
+	for trigram, count := range textTrigrams {
+		total += count
+		for _, p := range profiles {
+			if _, ok := p.logProbabilities[trigram]; ok {
+				known += count
+				break
+			}
+		}
+	}
+
+	if total == 0 || float64(known)/float64(total) < minCoverage {
+		return undetermined
+	}
+
+	scores := make(map[string]float64, len(profiles))
+	for lang, p := range profiles {
+		for trigram, count := range textTrigrams {
+			logProbability, ok := p.logProbabilities[trigram]
+			if !ok {
+				logProbability = p.unseen
+			}
+			scores[lang] += float64(count) * logProbability
+		}
+	}
+
+	best, bestScore := Undetermined, math.Inf(-1)
+	for lang, score := range scores {
+		if score > bestScore || (score == bestScore && lang < best) {
+			best, bestScore = lang, score
+		}
+	}
+
+	// The posterior probability is computed relative to the best score, to avoid the underflow of the exponentials
+	var sum float64
+	for _, score := range scores {
+		sum += math.Exp(score - bestScore)
+	}
+
+	return DetectionDTO{Language: best, Confidence: math.Round(1/sum*1e4) / 1e4}
+}
+
+// IsMismatch reports whether the detected language of a tweet differs from the languages of all its search criteria.
+// The tweets whose language wasn't detected yet, is Undetermined, or was detected with less than MinConfidence, are
+// never considered a mismatch
+func IsMismatch(language *string, confidence *float64, criteriaLanguages ...string) bool {
+	if language == nil || confidence == nil || *language == Undetermined || *confidence < MinConfidence {
+		return false
+	}
+
+	for _, criteriaLanguage := range criteriaLanguages {
+		if *language == strings.ToLower(criteriaLanguage) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// buildProfiles builds the profile of each language from its sample text. All the profiles share the same vocabulary,
+// so the probabilities of the unseen trigrams are comparable between languages
+func buildProfiles(samples map[string]string) map[string]profile {
+	counts := make(map[string]map[string]int, len(samples))
+	vocabulary := make(map[string]bool)
+	for lang, sample := range samples {
+		counts[lang] = trigrams(normalize(sample))
+		for trigram := range counts[lang] {
+			vocabulary[trigram] = true
+		}
+	}
+
+	built := make(map[string]profile, len(samples))
+	for lang, langCounts := range counts {
+		var total int
+		for _, count := range langCounts {
+			total += count
+		}
+
+		denominator := float64(total + len(vocabulary))
+		p := profile{logProbabilities: make(map[string]float64, len(langCounts)), unseen: math.Log(1 / denominator)}
+		for trigram, count := range langCounts {
+			p.logProbabilities[trigram] = math.Log(float64(count+1) / denominator)
+		}
+
+		built[lang] = p
+	}
+
+	return built
+}
+
+// normalize lowercases the text and replaces everything but the letters and the apostrophes with spaces
+func normalize(text string) string {
+	text = ignoredRegexp.ReplaceAllString(text, " ")
+
+	return strings.Map(func(r rune) rune {
+		if unicode.IsLetter(r) || r == '\'' {
+			return unicode.ToLower(r)
+		}
+
+		return ' '
+	}, text)
+}
+
+// countLetters returns the number of letters of the text
+func countLetters(text string) int {
+	var letters int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+		}
+	}
+
+	return letters
+}
+
+// trigrams counts the character trigrams of each word of the text, padded with a space on both sides
+func trigrams(text string) map[string]int {
+	counts := make(map[string]int)
+	for _, word := range strings.Fields(text) {
+		runes := []rune(" " + word + " ")
+		for i := 0; i+3 <= len(runes); i++ {
+			counts[string(runes[i:i+3])]++
+		}
+	}
+
+	return counts
+}
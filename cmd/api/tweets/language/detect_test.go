@@ -0,0 +1,89 @@
+package language_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/tweets/language"
+)
+
+func TestDetect_success(t *testing.T) {
+	tests := []struct {
+		text     string
+		expected string
+	}{
+		{text: "No puedo más con esta ansiedad, hoy tampoco comí nada", expected: "es"},
+		{text: "Não aguento mais essa ansiedade, hoje também não comi nada", expected: "pt"},
+		{text: "I can't take this anxiety anymore, I didn't eat anything today either", expected: "en"},
+		{text: "Je n'en peux plus de cette anxiété", expected: "fr"},
+		{text: "Non ce la faccio più con questa ansia", expected: "it"},
+		{text: "Ich kann diese Angst nicht mehr ertragen", expected: "de"},
+		{text: "@usuario la verdad que no sé qué hacer https://t.co/abc123 #ayuda", expected: "es"},
+		{text: "a verdade é que não sei o que fazer", expected: "pt"},
+	}
+
+	for _, tt := range tests {
+		got := language.Detect(&tt.text)
+
+		assert.Equal(t, tt.expected, got.Language, tt.text)
+		assert.GreaterOrEqual(t, got.Confidence, language.MinConfidence, tt.text)
+		assert.LessOrEqual(t, got.Confidence, 1.0, tt.text)
+	}
+}
+
+func TestDetect_successReturningUndetermined(t *testing.T) {
+	tests := []string{
+		"hola",
+		"@usuario https://t.co/abc123 #ayuda",
+		"مرحبا كيف حالك اليوم يا صديقي العزيز",
+	}
+
+	for _, tt := range tests {
+		want := language.DetectionDTO{Language: language.Undetermined, Confidence: 0}
+		got := language.Detect(&tt)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestDetect_successReturningUndeterminedWhenTheTextIsNil(t *testing.T) {
+	want := language.DetectionDTO{Language: language.Undetermined, Confidence: 0}
+	got := language.Detect(nil)
+
+	assert.Equal(t, want, got)
+}
+
+func TestIsMismatch_success(t *testing.T) {
+	tests := []struct {
+		language         string
+		confidence       float64
+		criteriaLanguage string
+		expected         bool
+	}{
+		{language: "pt", confidence: 0.99, criteriaLanguage: "es", expected: true},
+		{language: "es", confidence: 0.99, criteriaLanguage: "ES", expected: false},
+		{language: "pt", confidence: 0.5, criteriaLanguage: "es", expected: false},
+		{language: language.Undetermined, confidence: 0, criteriaLanguage: "es", expected: false},
+	}
+
+	for _, tt := range tests {
+		got := language.IsMismatch(&tt.language, &tt.confidence, tt.criteriaLanguage)
+
+		assert.Equal(t, tt.expected, got)
+	}
+}
+
+func TestIsMismatch_successComparingWithAllTheCriteriaLanguages(t *testing.T) {
+	tweetLanguage, confidence := "pt", 0.99
+
+	assert.False(t, language.IsMismatch(&tweetLanguage, &confidence, "es", "PT"))
+	assert.True(t, language.IsMismatch(&tweetLanguage, &confidence, "es", "en"))
+	assert.True(t, language.IsMismatch(&tweetLanguage, &confidence))
+}
+
+func TestIsMismatch_successWhenTheLanguageWasNotDetectedYet(t *testing.T) {
+	got := language.IsMismatch(nil, nil, "es")
+
+	assert.False(t, got)
+}
@@ -0,0 +1,15 @@
+package language
+
+type (
+	// DetectionDTO represents the language detected in the text of a tweet, as an ISO 639-1 code, and the confidence of
+	// the detection, between 0 and 1
+	DetectionDTO struct {
+		Language   string  `json:"language"`
+		Confidence float64 `json:"confidence"`
+	}
+
+	// BackfillResultDTO represents the number of tweets whose language was detected by a backfill
+	BackfillResultDTO struct {
+		Tweets int `json:"tweets"`
+	}
+)
@@ -0,0 +1,16 @@
+package language
+
+import "errors"
+
+var (
+	FailedToExecuteSelectPending                        = errors.New("failed to execute select pending")
+	FailedToExecuteCollectRowsInSelectPending           = errors.New("failed to execute collect rows in select pending")
+	FailedToExecuteUpdateDetected                       = errors.New("failed to execute update detected")
+	FailedToExecuteSelectCriteriaLanguages              = errors.New("failed to execute select criteria languages")
+	FailedToExecuteCollectRowsInSelectCriteriaLanguages = errors.New("failed to execute collect rows in select criteria languages")
+	FailedToAuthorizeUser                               = errors.New("failed to authorize user")
+)
+
+const (
+	FailedToBackfillLanguages string = "Failed to backfill languages"
+)
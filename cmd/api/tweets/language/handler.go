@@ -0,0 +1,12 @@
+package language
+
+import (
+	"net/http"
+
+	"ahbcc/cmd/api/tweets/backfill"
+)
+
+// BackfillHandlerV1 HTTP Handler of the endpoint POST /tweets/language/backfill/v1
+func BackfillHandlerV1(backfillLanguages Backfill) http.HandlerFunc {
+	return backfill.HandlerV1(backfillLanguages, FailedToBackfillLanguages, "Languages successfully backfilled")
+}
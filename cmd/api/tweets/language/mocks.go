@@ -0,0 +1,34 @@
+package language
+
+import "context"
+
+// MockSelectPending mocks SelectPending function
+func MockSelectPending(pending []PendingDAO, err error) SelectPending {
+	return func(ctx context.Context, limit int) ([]PendingDAO, error) {
+		return pending, err
+	}
+}
+
+// MockSelectCriteriaLanguages mocks SelectCriteriaLanguages function
+func MockSelectCriteriaLanguages(languages []string, err error) SelectCriteriaLanguages {
+	return func(ctx context.Context, tweetID int) ([]string, error) {
+		return languages, err
+	}
+}
+
+// MockUpdateDetected mocks UpdateDetected function
+func MockUpdateDetected(err error) UpdateDetected {
+	return func(ctx context.Context, detected []DetectedDAO) error {
+		return err
+	}
+}
+
+// MockPendingDAOs mocks a slice of PendingDAO
+func MockPendingDAOs() []PendingDAO {
+	textContent := "No puedo más con esta ansiedad, hoy tampoco comí nada"
+
+	return []PendingDAO{
+		{ID: 1, TextContent: &textContent},
+		{ID: 2, TextContent: nil},
+	}
+}
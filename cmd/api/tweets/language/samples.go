@@ -0,0 +1,98 @@
+package language
+
+// samples are the texts used to build the trigram profile of each supported language, identified by its ISO 639-1 code.
+// They are everyday texts written in the informal register of the tweets, so the profiles resemble the texts to detect
+var samples = map[string]string{
+	"es": `hoy me desperté muy temprano y no pude volver a dormir porque tenía muchas cosas en la cabeza. la verdad es que
+		estoy cansada de sentirme así todos los días, como si nada de lo que hago tuviera sentido. mis amigos me dicen que
+		tengo que salir más y hablar con alguien, pero cuesta mucho cuando una no tiene ganas de nada. ayer fuimos a comer
+		con mi familia y la pasamos bien, aunque después me sentí peor que antes. no sé por qué me pasa esto ni cómo hacer
+		para que se termine. a veces pienso que si dejo de comer por un tiempo voy a estar mejor conmigo misma, pero sé que
+		no es así. el trabajo también me tiene muy mal, mi jefe no para de pedirme cosas y nunca está conforme con lo que
+		hago. quiero irme de vacaciones a la playa, tomar sol y olvidarme de todo por unas semanas. ojalá el año que viene
+		sea distinto y pueda cumplir con todo lo que me propongo. gracias a todos los que me escribieron estos días, de
+		verdad no saben cuánto me ayudaron sus mensajes. mañana tengo que ir al médico para ver qué me dice sobre los
+		análisis, espero que esté todo bien. la gente que habla mal de los demás sin conocerlos me da mucha bronca, cada
+		uno tiene sus propios problemas y nadie sabe lo que está viviendo el otro. estoy harto de que la policía no haga
+		nada mientras nos roban todos los días en el barrio. esta noche juega la selección y vamos a verlo en la casa de
+		mis viejos, después salimos a tomar algo. me encanta esta canción, la escucho todo el tiempo desde que salió.
+		nunca pensé que iba a extrañar tanto a mi abuela, todavía me cuesta creer que ya no está con nosotros.`,
+	"pt": `hoje acordei muito cedo e não consegui dormir de novo porque estava com muitas coisas na cabeça. a verdade é
+		que estou cansada de me sentir assim todos os dias, como se nada do que eu faço tivesse sentido. meus amigos dizem
+		que eu preciso sair mais e conversar com alguém, mas é muito difícil quando a gente não tem vontade de nada. ontem
+		fomos almoçar com a minha família e foi legal, mas depois eu me senti pior do que antes. não sei por que isso
+		acontece comigo nem o que fazer para acabar com isso. às vezes eu penso que se eu parar de comer por um tempo vou
+		ficar melhor comigo mesma, mas sei que não é assim. o trabalho também está me deixando muito mal, meu chefe não
+		para de me pedir coisas e nunca fica satisfeito com o que eu faço. quero tirar férias e ir para a praia, tomar sol
+		e esquecer de tudo por algumas semanas. tomara que o ano que vem seja diferente e eu consiga fazer tudo o que eu
+		quero. obrigada a todos que me mandaram mensagem esses dias, vocês não sabem o quanto me ajudaram. amanhã tenho
+		que ir ao médico para ver o que ele fala sobre os exames, espero que esteja tudo bem. as pessoas que falam mal dos
+		outros sem conhecer me deixam com muita raiva, cada um tem os seus próprios problemas e ninguém sabe o que o outro
+		está vivendo. estou cansado de a polícia não fazer nada enquanto roubam a gente todo dia no bairro. hoje à noite
+		tem jogo da seleção e vamos assistir na casa dos meus pais, depois a gente sai para beber alguma coisa. eu amo essa
+		música, escuto o tempo todo desde que saiu. nunca pensei que ia sentir tanta falta da minha avó, ainda não consigo
+		acreditar que ela não está mais com a gente.`,
+	"en": `today i woke up really early and could not get back to sleep because i had so many things on my mind. the
+		truth is that i am tired of feeling like this every single day, as if nothing i do makes any sense. my friends
+		keep telling me that i should go out more and talk to someone, but it is so hard when you do not feel like doing
+		anything. yesterday we went out for lunch with my family and it was nice, but afterwards i felt even worse than
+		before. i do not know why this happens to me or what to do to make it stop. sometimes i think that if i stop
+		eating for a while i will feel better about myself, but i know that is not true. work is also getting to me, my
+		boss never stops asking me for things and he is never happy with what i do. i want to go on vacation to the beach,
+		get some sun and forget about everything for a few weeks. i hope next year will be different and that i can do
+		everything i want. thanks to everyone who texted me these days, you have no idea how much your messages helped me.
+		tomorrow i have to go to the doctor to see what he says about the tests, i hope everything is fine. people who
+		talk badly about others without knowing them make me so angry, everyone has their own problems and nobody knows
+		what the other person is going through. i am sick of the police doing nothing while they rob us every day in the
+		neighborhood. tonight the national team is playing and we are going to watch the game at my parents house, then we
+		will go out for a drink. i love this song, i have been listening to it all the time since it came out. i never
+		thought i would miss my grandmother this much, i still cannot believe she is not with us anymore.`,
+	"fr": `aujourd'hui je me suis réveillée très tôt et je n'ai pas pu me rendormir parce que j'avais beaucoup de choses
+		dans la tête. la vérité c'est que je suis fatiguée de me sentir comme ça tous les jours, comme si rien de ce que je
+		fais n'avait de sens. mes amis me disent que je dois sortir plus et parler à quelqu'un, mais c'est très difficile
+		quand on n'a envie de rien. hier nous sommes allés manger avec ma famille et c'était bien, mais après je me suis
+		sentie encore plus mal qu'avant. je ne sais pas pourquoi ça m'arrive ni comment faire pour que ça s'arrête. parfois
+		je pense que si j'arrête de manger pendant un moment je vais me sentir mieux avec moi même, mais je sais que ce
+		n'est pas vrai. le travail aussi me rend malade, mon chef n'arrête pas de me demander des choses et il n'est jamais
+		content de ce que je fais. je veux partir en vacances à la plage, prendre le soleil et tout oublier pendant quelques
+		semaines. j'espère que l'année prochaine sera différente et que je pourrai faire tout ce que je veux. merci à tous
+		ceux qui m'ont écrit ces jours ci, vous ne savez pas à quel point vos messages m'ont aidée. demain je dois aller
+		chez le médecin pour voir ce qu'il dit des analyses, j'espère que tout va bien. les gens qui disent du mal des
+		autres sans les connaître m'énervent beaucoup, chacun a ses propres problèmes et personne ne sait ce que vit
+		l'autre. j'en ai marre que la police ne fasse rien pendant qu'on se fait voler tous les jours dans le quartier. ce
+		soir l'équipe nationale joue et on va regarder le match chez mes parents, ensuite on sortira boire un verre. j'adore
+		cette chanson, je l'écoute tout le temps depuis qu'elle est sortie. je n'aurais jamais pensé que ma grand mère me
+		manquerait autant, je n'arrive toujours pas à croire qu'elle n'est plus avec nous.`,
+	"it": `oggi mi sono svegliata molto presto e non sono riuscita a riaddormentarmi perché avevo tante cose per la testa.
+		la verità è che sono stanca di sentirmi così tutti i giorni, come se niente di quello che faccio avesse senso. i
+		miei amici mi dicono che devo uscire di più e parlare con qualcuno, ma è molto difficile quando non hai voglia di
+		fare niente. ieri siamo andati a pranzo con la mia famiglia ed è stato bello, però dopo mi sono sentita peggio di
+		prima. non so perché mi succede questo né cosa fare perché finisca. a volte penso che se smetto di mangiare per un
+		po' starò meglio con me stessa, ma so che non è così. anche il lavoro mi fa stare molto male, il mio capo non smette
+		di chiedermi cose e non è mai contento di quello che faccio. voglio andare in vacanza al mare, prendere il sole e
+		dimenticare tutto per qualche settimana. spero che l'anno prossimo sia diverso e che riesca a fare tutto quello che
+		voglio. grazie a tutti quelli che mi hanno scritto in questi giorni, non sapete quanto mi hanno aiutato i vostri
+		messaggi. domani devo andare dal medico per vedere cosa dice delle analisi, spero che sia tutto a posto. le persone
+		che parlano male degli altri senza conoscerli mi fanno molto arrabbiare, ognuno ha i suoi problemi e nessuno sa
+		cosa sta vivendo l'altro. sono stufo che la polizia non faccia niente mentre ci rubano tutti i giorni nel quartiere.
+		stasera gioca la nazionale e andiamo a vedere la partita a casa dei miei genitori, poi usciamo a bere qualcosa. amo
+		questa canzone, la ascolto sempre da quando è uscita. non avrei mai pensato che mi sarebbe mancata così tanto mia
+		nonna, ancora non riesco a credere che non sia più con noi.`,
+	"de": `heute bin ich sehr früh aufgewacht und konnte nicht mehr einschlafen, weil mir so viele dinge durch den kopf
+		gingen. die wahrheit ist, dass ich es leid bin, mich jeden tag so zu fühlen, als ob nichts von dem, was ich mache,
+		einen sinn hätte. meine freunde sagen mir, dass ich mehr rausgehen und mit jemandem reden soll, aber das ist sehr
+		schwer, wenn man zu nichts lust hat. gestern waren wir mit meiner familie essen und es war schön, aber danach habe
+		ich mich noch schlechter gefühlt als vorher. ich weiß nicht, warum mir das passiert und was ich tun soll, damit es
+		aufhört. manchmal denke ich, dass ich mich besser fühlen werde, wenn ich eine zeit lang nichts esse, aber ich weiß,
+		dass das nicht stimmt. auch die arbeit macht mich fertig, mein chef hört nicht auf, dinge von mir zu verlangen, und
+		ist nie zufrieden mit dem, was ich mache. ich will in den urlaub an den strand fahren, in der sonne liegen und für
+		ein paar wochen alles vergessen. ich hoffe, dass das nächste jahr anders wird und ich alles schaffe, was ich mir
+		vornehme. danke an alle, die mir in den letzten tagen geschrieben haben, ihr wisst gar nicht, wie sehr mir eure
+		nachrichten geholfen haben. morgen muss ich zum arzt, um zu sehen, was er zu den ergebnissen sagt, ich hoffe, dass
+		alles in ordnung ist. leute, die schlecht über andere reden, ohne sie zu kennen, machen mich wütend, jeder hat
+		seine eigenen probleme und niemand weiß, was der andere gerade durchmacht. ich habe es satt, dass die polizei nichts
+		tut, während wir jeden tag im viertel beklaut werden. heute abend spielt die nationalmannschaft und wir schauen das
+		spiel bei meinen eltern, danach gehen wir noch etwas trinken. ich liebe dieses lied, ich höre es die ganze zeit,
+		seit es rausgekommen ist. ich hätte nie gedacht, dass ich meine oma so sehr vermissen würde, ich kann immer noch
+		nicht glauben, dass sie nicht mehr bei uns ist.`,
+}
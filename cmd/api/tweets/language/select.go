@@ -0,0 +1,67 @@
+package language
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// SelectPending retrieves the tweets whose language wasn't detected yet
+type SelectPending func(ctx context.Context, limit int) ([]PendingDAO, error)
+
+// MakeSelectPending creates a new SelectPending
+func MakeSelectPending(db database.Connection, collectRows database.CollectRows[PendingDAO]) SelectPending {
+	const query string = `
+		SELECT t.id, t.text_content
+		FROM tweets AS t
+		WHERE t.language IS NULL
+		ORDER BY t.id
+		LIMIT $1;
+	`
+
+	return func(ctx context.Context, limit int) ([]PendingDAO, error) {
+		rows, err := db.Query(ctx, query, limit)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectPending
+		}
+
+		pending, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectPending
+		}
+
+		return pending, nil
+	}
+}
+
+// SelectCriteriaLanguages retrieves the languages of all the search criteria the given tweet is related to
+type SelectCriteriaLanguages func(ctx context.Context, tweetID int) ([]string, error)
+
+// MakeSelectCriteriaLanguages creates a new SelectCriteriaLanguages
+func MakeSelectCriteriaLanguages(db database.Connection, collectRows database.CollectRows[string]) SelectCriteriaLanguages {
+	const query string = `
+		SELECT DISTINCT sc.language
+		FROM tweets_search_criteria AS tsc
+		INNER JOIN search_criteria AS sc ON sc.id = tsc.search_criteria_id
+		WHERE tsc.tweet_id = $1;
+	`
+
+	return func(ctx context.Context, tweetID int) ([]string, error) {
+		rows, err := db.Query(ctx, query, tweetID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectCriteriaLanguages
+		}
+
+		languages, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectCriteriaLanguages
+		}
+
+		return languages, nil
+	}
+}
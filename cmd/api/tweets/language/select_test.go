@@ -0,0 +1,106 @@
+package language_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/language"
+	"ahbcc/internal/database"
+)
+
+func TestSelectPending_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockPending := language.MockPendingDAOs()
+	mockCollectRows := database.MockCollectRows[language.PendingDAO](mockPending, nil)
+
+	selectPending := language.MakeSelectPending(mockPostgresConnection, mockCollectRows)
+
+	want := mockPending
+	got, err := selectPending(context.Background(), 100)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectPending_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select pending"))
+	mockCollectRows := database.MockCollectRows[language.PendingDAO](nil, nil)
+
+	selectPending := language.MakeSelectPending(mockPostgresConnection, mockCollectRows)
+
+	want := language.FailedToExecuteSelectPending
+	_, got := selectPending(context.Background(), 100)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectPending_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[language.PendingDAO](nil, errors.New("failed to collect rows"))
+
+	selectPending := language.MakeSelectPending(mockPostgresConnection, mockCollectRows)
+
+	want := language.FailedToExecuteCollectRowsInSelectPending
+	_, got := selectPending(context.Background(), 100)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectCriteriaLanguages_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, []any{1}).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[string]([]string{"es", "pt"}, nil)
+
+	selectCriteriaLanguages := language.MakeSelectCriteriaLanguages(mockPostgresConnection, mockCollectRows)
+
+	want := []string{"es", "pt"}
+	got, err := selectCriteriaLanguages(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectCriteriaLanguages_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select criteria languages"))
+	mockCollectRows := database.MockCollectRows[string](nil, nil)
+
+	selectCriteriaLanguages := language.MakeSelectCriteriaLanguages(mockPostgresConnection, mockCollectRows)
+
+	want := language.FailedToExecuteSelectCriteriaLanguages
+	_, got := selectCriteriaLanguages(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectCriteriaLanguages_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[string](nil, errors.New("failed to collect rows"))
+
+	selectCriteriaLanguages := language.MakeSelectCriteriaLanguages(mockPostgresConnection, mockCollectRows)
+
+	want := language.FailedToExecuteCollectRowsInSelectCriteriaLanguages
+	_, got := selectCriteriaLanguages(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
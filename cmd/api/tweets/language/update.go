@@ -0,0 +1,46 @@
+package language
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// UpdateDetected stores the detected languages of the tweets
+type UpdateDetected func(ctx context.Context, detected []DetectedDAO) error
+
+// MakeUpdateDetected creates a new UpdateDetected
+func MakeUpdateDetected(db database.Connection) UpdateDetected {
+	const (
+		query string = `
+			UPDATE tweets AS t
+			SET language = d.language, language_confidence = d.language_confidence
+			FROM (VALUES %s) AS d(id, language, language_confidence)
+			WHERE t.id = d.id;
+		`
+		parameters = 3
+	)
+
+	return func(ctx context.Context, detected []DetectedDAO) error {
+		placeholders := make([]string, 0, len(detected))
+		values := make([]any, 0, len(detected)*parameters)
+		for i, dao := range detected {
+			idx := i * parameters
+			placeholders = append(placeholders, fmt.Sprintf("($%d::INTEGER, $%d::TEXT, $%d::DOUBLE PRECISION)", idx+1, idx+2, idx+3))
+			values = append(values, dao.ID, dao.Detection.Language, dao.Detection.Confidence)
+		}
+
+		queryToExecute := fmt.Sprintf(query, strings.Join(placeholders, ","))
+
+		_, err := db.Exec(ctx, queryToExecute, values...)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteUpdateDetected
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,39 @@
+package language_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/language"
+	"ahbcc/internal/database"
+)
+
+func TestUpdateDetected_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+
+	updateDetected := language.MakeUpdateDetected(mockPostgresConnection)
+
+	got := updateDetected(context.Background(), []language.DetectedDAO{{ID: 1, Detection: language.DetectionDTO{Language: "es", Confidence: 0.99}}})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateDetected_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update detected"))
+
+	updateDetected := language.MakeUpdateDetected(mockPostgresConnection)
+
+	want := language.FailedToExecuteUpdateDetected
+	got := updateDetected(context.Background(), []language.DetectedDAO{{ID: 1, Detection: language.DetectionDTO{Language: "es", Confidence: 0.99}}})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -2,22 +2,31 @@ package tweets
 
 import (
 	"context"
+	"strings"
 	"time"
 
+	"ahbcc/cmd/api/tweets/entities"
 	"ahbcc/cmd/api/tweets/quotes"
 )
 
 // MockInsert mocks Insert function
-func MockInsert(err error) Insert {
-	return func(ctx context.Context, tweet []TweetDTO) error {
-		return err
+func MockInsert(results []InsertResultDTO, err error) Insert {
+	return func(ctx context.Context, tweet []TweetDTO) ([]InsertResultDTO, error) {
+		return results, err
 	}
 }
 
-// MockSelectBySearchCriteriaIDYearAndMonth mocks SelectBySearchCriteriaIDYearAndMonth function
-func MockSelectBySearchCriteriaIDYearAndMonth(tweets []CustomTweetDTO, err error) SelectBySearchCriteriaIDYearAndMonth {
-	return func(ctx context.Context, searchCriteriaID, year, month, limit int, token string) ([]CustomTweetDTO, error) {
-		return tweets, err
+// MockSelectBySearchCriteriaID mocks SelectBySearchCriteriaID function
+func MockSelectBySearchCriteriaID(page PageDTO, err error) SelectBySearchCriteriaID {
+	return func(ctx context.Context, searchCriteriaID int, filters Filters, token string) (PageDTO, error) {
+		return page, err
+	}
+}
+
+// MockSearch mocks Search function
+func MockSearch(page SearchPageDTO, err error) Search {
+	return func(ctx context.Context, search string, searchCriteriaID int, filters Filters, token string) (SearchPageDTO, error) {
+		return page, err
 	}
 }
 
@@ -28,6 +37,13 @@ func MockSelectByID(tweetDAO DAO, err error) SelectByID {
 	}
 }
 
+// MockSelectByIDs mocks SelectByIDs function
+func MockSelectByIDs(tweetsDAO []DAO, err error) SelectByIDs {
+	return func(ctx context.Context, ids []int) ([]DAO, error) {
+		return tweetsDAO, err
+	}
+}
+
 // MockTweetDTO mocks a TweetDTO
 func MockTweetDTO() TweetDTO {
 	avatar := "https://testuseravatar.com"
@@ -57,27 +73,48 @@ func MockTweetsDTOs() []TweetDTO {
 	}
 }
 
+// MockInsertResultDTOs mocks the []InsertResultDTO of MockTweetsDTOs
+func MockInsertResultDTOs() []InsertResultDTO {
+	return []InsertResultDTO{
+		{StatusID: "1234567890987654321", Result: InsertResultInserted},
+		{StatusID: "1234567890987654321", Result: InsertResultDuplicate},
+	}
+}
+
+// MockRelatedDAOs mocks a slice of RelatedDAO
+func MockRelatedDAOs() []RelatedDAO {
+	return []RelatedDAO{
+		{StatusID: "1234567890987654321", SearchCriteriaID: 1},
+	}
+}
+
 // MockCustomTweetDTO mocks a CustomTweetDTO
 func MockCustomTweetDTO() CustomTweetDTO {
 	avatar := "https://testuseravatar.com"
 
 	textContent := "test"
 	quoteID := 3
+	detectedLanguage := "es"
+	languageConfidence := 0.99
 	searchCriteriaID := 1
 	quote := quotes.MockCustomQuoteDTO()
+	postedAt := time.Now()
 
 	return CustomTweetDTO{
-		ID:               1,
-		StatusID:         "1234567890987654321",
-		IsAReply:         true,
-		Author:           "TestAuthor",
-		Avatar:           &avatar,
-		PostedAt:         time.Now(),
-		TextContent:      &textContent,
-		Images:           []string{"test1", "test2"},
-		QuoteID:          &quoteID,
-		SearchCriteriaID: &searchCriteriaID,
-		Quote:            &quote,
+		ID:                 1,
+		StatusID:           "1234567890987654321",
+		IsAReply:           true,
+		Author:             "TestAuthor",
+		Avatar:             &avatar,
+		PostedAt:           &postedAt,
+		TextContent:        &textContent,
+		Images:             []string{"test1", "test2"},
+		Entities:           entities.MockEntities(),
+		Language:           &detectedLanguage,
+		LanguageConfidence: &languageConfidence,
+		QuoteID:            &quoteID,
+		SearchCriteriaID:   &searchCriteriaID,
+		Quote:              &quote,
 	}
 }
 
@@ -89,6 +126,56 @@ func MockCustomTweetDTOs() []CustomTweetDTO {
 	}
 }
 
+// MockPageDTO mocks a PageDTO
+func MockPageDTO() PageDTO {
+	postedAt := time.Date(2025, time.January, 1, 0, 0, 0, 0, time.UTC)
+	nextCursor := Cursor{PostedAt: &postedAt, ID: 1}.Encode()
+
+	return PageDTO{
+		Tweets:     MockCustomTweetDTOs(),
+		NextCursor: &nextCursor,
+	}
+}
+
+// MockSearchResultDTO mocks a SearchResultDTO
+func MockSearchResultDTO() SearchResultDTO {
+	highlight := "<mark>test</mark>"
+	quoteHighlight := "<mark>test</mark> quote"
+
+	return SearchResultDTO{
+		CustomTweetDTO: MockCustomTweetDTO(),
+		Rank:           0.6079271,
+		Highlight:      &highlight,
+		QuoteHighlight: &quoteHighlight,
+	}
+}
+
+// MockSearchPageDTO mocks a SearchPageDTO
+func MockSearchPageDTO() SearchPageDTO {
+	nextCursor := Cursor{Rank: 0.6079271, ID: 1}.Encode()
+
+	return SearchPageDTO{
+		Results:    []SearchResultDTO{MockSearchResultDTO(), MockSearchResultDTO()},
+		NextCursor: &nextCursor,
+	}
+}
+
+// MockSearchResultCollectedRow mocks a row with the Tweet, its Quote, its rank and its highlights, obtained from a search
+func MockSearchResultCollectedRow(result SearchResultDTO) []any {
+	return append(MockTweetCollectedRow(result.CustomTweetDTO), result.Rank, mockHeadline(result.Highlight), mockHeadline(result.QuoteHighlight))
+}
+
+// mockHeadline mocks the headline returned by the database for the given highlight, with the matches delimited by
+// highlightStart and highlightStop instead of the <mark> tags
+func mockHeadline(highlight *string) *string {
+	if highlight == nil {
+		return nil
+	}
+
+	headline := strings.NewReplacer("<mark>", highlightStart, "</mark>", highlightStop).Replace(*highlight)
+	return &headline
+}
+
 // MockTweetCollectedRow mocks a row with the Tweet, and its Quote, information, obtained from a select
 func MockTweetCollectedRow(tweet CustomTweetDTO) []any {
 	row := []any{
@@ -100,6 +187,15 @@ func MockTweetCollectedRow(tweet CustomTweetDTO) []any {
 		tweet.IsAReply,
 		tweet.TextContent,
 		tweet.Images,
+		tweet.Entities.Hashtags,
+		tweet.Entities.Mentions,
+		tweet.Entities.URLs,
+		tweet.Entities.Emojis,
+		tweet.Entities.Cashtags,
+		tweet.Language,
+		tweet.LanguageConfidence,
+		tweet.RepresentativeID,
+		tweet.InReplyToStatusID,
 		tweet.QuoteID,
 		tweet.SearchCriteriaID,
 	}
@@ -112,9 +208,14 @@ func MockTweetCollectedRow(tweet CustomTweetDTO) []any {
 			tweet.Quote.IsAReply,
 			tweet.Quote.TextContent,
 			tweet.Quote.Images,
+			tweet.Quote.Entities.Hashtags,
+			tweet.Quote.Entities.Mentions,
+			tweet.Quote.Entities.URLs,
+			tweet.Quote.Entities.Emojis,
+			tweet.Quote.Entities.Cashtags,
 		)
 	} else {
-		row = append(row, nil, nil, nil, nil, nil, nil)
+		row = append(row, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil)
 	}
 
 	return row
@@ -125,18 +226,23 @@ func MockTweetDAO() DAO {
 	avatar := "https://testuseravatar.com"
 	textContent := "test"
 	quoteID := 3
+	detectedLanguage := "es"
+	languageConfidence := 0.99
 
 	return DAO{
-		ID:               1,
-		StatusID:         "1234567890987654321",
-		Author:           "TestAuthor",
-		Avatar:           &avatar,
-		PostedAt:         time.Now(),
-		IsAReply:         true,
-		TextContent:      &textContent,
-		Images:           []string{"test1", "test2"},
-		QuoteID:          &quoteID,
-		SearchCriteriaID: 1,
+		ID:                 1,
+		StatusID:           "1234567890987654321",
+		Author:             "TestAuthor",
+		Avatar:             &avatar,
+		PostedAt:           time.Now(),
+		IsAReply:           true,
+		TextContent:        &textContent,
+		Images:             []string{"test1", "test2"},
+		Entities:           entities.MockEntities(),
+		Language:           &detectedLanguage,
+		LanguageConfidence: &languageConfidence,
+		QuoteID:            &quoteID,
+		SearchCriteriaID:   1,
 	}
 }
 
@@ -151,6 +257,15 @@ func MockScanTweetDAOValues(dao DAO) []any {
 		dao.IsAReply,
 		dao.TextContent,
 		dao.Images,
+		dao.Hashtags,
+		dao.Mentions,
+		dao.URLs,
+		dao.Emojis,
+		dao.Cashtags,
+		dao.Language,
+		dao.LanguageConfidence,
+		dao.RepresentativeID,
+		dao.InReplyToStatusID,
 		dao.QuoteID,
 		dao.SearchCriteriaID,
 	}
@@ -0,0 +1,15 @@
+package parents
+
+// DAO represents a tweet of a conversation, obtained from the 'tweets_parents' table
+type DAO struct {
+	StatusID    string  `json:"status_id"`
+	Author      string  `json:"author"`
+	TextContent *string `json:"text_content,omitempty"`
+}
+
+// LinkDAO represents a tweet of the conversation that leads to the given status ID, and its distance to it
+type LinkDAO struct {
+	StartStatusID string
+	DAO
+	Depth int
+}
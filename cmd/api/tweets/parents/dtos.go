@@ -0,0 +1,11 @@
+package parents
+
+// ParentDTO represents the tweet another tweet replies to, that will be inserted in the 'tweets_parents' table.
+// The StatusID is the number after the /status/ of the parent tweet url, and it is used to store each parent only once.
+// The InReplyTo is the tweet the parent itself replies to, if known, so a whole conversation can be sent at once
+type ParentDTO struct {
+	StatusID    string     `json:"status_id"`
+	Author      string     `json:"author"`
+	TextContent *string    `json:"text_content,omitempty"`
+	InReplyTo   *ParentDTO `json:"in_reply_to,omitempty"`
+}
@@ -0,0 +1,10 @@
+package parents
+
+import "errors"
+
+var (
+	NothingToInsertWhenParentIsNil                  = errors.New("nothing to insert when parent is nil")
+	FailedToInsertParents                           = errors.New("failed to insert parents")
+	FailedToExecuteSelectConversations              = errors.New("failed to execute select conversations")
+	FailedToExecuteCollectRowsInSelectConversations = errors.New("failed to execute collect rows in select conversations")
+)
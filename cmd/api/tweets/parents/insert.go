@@ -0,0 +1,105 @@
+package parents
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// MaxDepth is the maximum number of tweets of a conversation that are stored, or retrieved, above a tweet
+const MaxDepth int = 10
+
+// InsertChains inserts the given ParentDTOs, and the tweets they reply to, into the 'tweets_parents' table.
+// A parent that was already inserted is reused, only its missing text and parent are completed. Each chain stops at the
+// first parent without a status ID or an author, at a status ID repeated in the same chain, or after MaxDepth parents.
+// The parents are inserted in chunks, using the given transaction if it is not nil
+type InsertChains func(tx pgx.Tx, ctx context.Context, parents []*ParentDTO) error
+
+// MakeInsertChains creates a new InsertChains
+func MakeInsertChains(db database.Connection) InsertChains {
+	const (
+		query string = `
+			INSERT INTO tweets_parents(status_id, author, text_content, in_reply_to_status_id)
+			VALUES %s
+			ON CONFLICT (status_id) DO UPDATE SET
+				text_content = COALESCE(tweets_parents.text_content, EXCLUDED.text_content),
+				in_reply_to_status_id = COALESCE(tweets_parents.in_reply_to_status_id, EXCLUDED.in_reply_to_status_id);
+		`
+		parameters = 4
+
+		// chunkSize keeps each query far below the limit of 65535 parameters of Postgres
+		chunkSize = 1000
+	)
+
+	return func(tx pgx.Tx, ctx context.Context, parents []*ParentDTO) error {
+		var conn database.Connection = db
+		if tx != nil {
+			conn = tx
+		}
+
+		// The same parent can appear in more than one chain, but a single statement can't upsert a row twice, so its
+		// repetitions only complete the missing text and parent of the first one
+		rows := make([][]any, 0, len(parents))
+		rowsByStatusID := make(map[string][]any)
+		for _, parent := range parents {
+			seen := make(map[string]bool)
+			for current := parent; current != nil && len(seen) < MaxDepth; current = current.InReplyTo {
+				if current.StatusID == "" || current.Author == "" || seen[current.StatusID] {
+					break
+				}
+				seen[current.StatusID] = true
+
+				var inReplyToStatusID *string
+				if current.InReplyTo != nil && current.InReplyTo.StatusID != "" {
+					inReplyToStatusID = &current.InReplyTo.StatusID
+				}
+
+				row, alreadyAdded := rowsByStatusID[current.StatusID]
+				if !alreadyAdded {
+					row = []any{current.StatusID, current.Author, current.TextContent, inReplyToStatusID}
+					rowsByStatusID[current.StatusID] = row
+					rows = append(rows, row)
+					continue
+				}
+
+				if row[2].(*string) == nil {
+					row[2] = current.TextContent
+				}
+				if row[3].(*string) == nil {
+					row[3] = inReplyToStatusID
+				}
+			}
+		}
+
+		if len(rows) == 0 {
+			return NothingToInsertWhenParentIsNil
+		}
+
+		for start := 0; start < len(rows); start += chunkSize {
+			end := min(start+chunkSize, len(rows))
+
+			placeholders := make([]string, 0, end-start)
+			values := make([]any, 0, (end-start)*parameters)
+			for _, row := range rows[start:end] {
+				idx := len(values)
+				placeholders = append(placeholders, fmt.Sprintf("($%d, $%d, $%d, $%d)", idx+1, idx+2, idx+3, idx+4))
+				values = append(values, row...)
+			}
+
+			queryToExecute := fmt.Sprintf(query, strings.Join(placeholders, ","))
+
+			_, err := conn.Exec(ctx, queryToExecute, values...)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return FailedToInsertParents
+			}
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,106 @@
+package parents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/parents"
+	"ahbcc/internal/database"
+)
+
+func TestInsertChains_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockParentDTO := parents.MockParentDTO()
+
+	insertChains := parents.MakeInsertChains(mockPostgresConnection)
+
+	got := insertChains(nil, context.Background(), []*parents.ParentDTO{&mockParentDTO})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertChains_successUsingTheTransaction(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPostgresTx.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockParentDTO := parents.MockParentDTO()
+
+	insertChains := parents.MakeInsertChains(mockPostgresConnection)
+
+	got := insertChains(mockPostgresTx, context.Background(), []*parents.ParentDTO{&mockParentDTO})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+	mockPostgresTx.AssertExpectations(t)
+}
+
+func TestInsertChains_successStoppingAtTheParentsWithoutStatusID(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockParentDTO := parents.MockParentDTO()
+	mockParentDTO.InReplyTo.StatusID = ""
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{mockParentDTO.StatusID, mockParentDTO.Author, mockParentDTO.TextContent, (*string)(nil)}).Return(pgconn.CommandTag{}, nil)
+
+	insertChains := parents.MakeInsertChains(mockPostgresConnection)
+
+	got := insertChains(nil, context.Background(), []*parents.ParentDTO{&mockParentDTO})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertChains_successInsertingTheParentsSharedByMoreThanOneChainOnlyOnce(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockParentDTO := parents.MockParentDTO()
+	mockIncompleteParentDTO := parents.MockParentDTO()
+	mockIncompleteParentDTO.TextContent = nil
+	mockIncompleteParentDTO.InReplyTo = nil
+	root := mockParentDTO.InReplyTo
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{
+		mockParentDTO.StatusID, mockParentDTO.Author, mockParentDTO.TextContent, &root.StatusID,
+		root.StatusID, root.Author, root.TextContent, (*string)(nil),
+	}).Return(pgconn.CommandTag{}, nil)
+
+	insertChains := parents.MakeInsertChains(mockPostgresConnection)
+
+	got := insertChains(nil, context.Background(), []*parents.ParentDTO{&mockIncompleteParentDTO, &mockParentDTO})
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertChains_failsWhenThereIsNothingToInsert(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockParentDTO := parents.MockParentDTO()
+	mockParentDTO.Author = ""
+
+	insertChains := parents.MakeInsertChains(mockPostgresConnection)
+
+	for _, chains := range [][]*parents.ParentDTO{nil, {nil}, {&mockParentDTO}} {
+		want := parents.NothingToInsertWhenParentIsNil
+		got := insertChains(nil, context.Background(), chains)
+
+		assert.Equal(t, want, got)
+	}
+	mockPostgresConnection.AssertNotCalled(t, "Exec")
+}
+
+func TestInsertChains_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert parents"))
+	mockParentDTO := parents.MockParentDTO()
+
+	insertChains := parents.MakeInsertChains(mockPostgresConnection)
+
+	want := parents.FailedToInsertParents
+	got := insertChains(nil, context.Background(), []*parents.ParentDTO{&mockParentDTO})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
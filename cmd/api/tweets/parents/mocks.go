@@ -0,0 +1,59 @@
+package parents
+
+import (
+	"context"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// MockInsertChains mocks InsertChains function
+func MockInsertChains(err error) InsertChains {
+	return func(tx pgx.Tx, ctx context.Context, parents []*ParentDTO) error {
+		return err
+	}
+}
+
+// MockSelectConversations mocks SelectConversations function
+func MockSelectConversations(conversations map[string][]DAO, err error) SelectConversations {
+	return func(ctx context.Context, statusIDs []string) (map[string][]DAO, error) {
+		return conversations, err
+	}
+}
+
+// MockParentDTO mocks a ParentDTO that replies to another tweet
+func MockParentDTO() ParentDTO {
+	textContent := "test parent"
+	rootTextContent := "test root"
+
+	return ParentDTO{
+		StatusID:    "1111111111111111111",
+		Author:      "TestParentAuthor",
+		TextContent: &textContent,
+		InReplyTo: &ParentDTO{
+			StatusID:    "2222222222222222222",
+			Author:      "TestRootAuthor",
+			TextContent: &rootTextContent,
+		},
+	}
+}
+
+// MockConversation mocks the conversation of MockParentDTO, sorted from its first tweet
+func MockConversation() []DAO {
+	textContent := "test parent"
+	rootTextContent := "test root"
+
+	return []DAO{
+		{StatusID: "2222222222222222222", Author: "TestRootAuthor", TextContent: &rootTextContent},
+		{StatusID: "1111111111111111111", Author: "TestParentAuthor", TextContent: &textContent},
+	}
+}
+
+// MockLinkDAOs mocks the LinkDAOs of MockConversation, as they are retrieved by SelectConversations
+func MockLinkDAOs() []LinkDAO {
+	conversation := MockConversation()
+
+	return []LinkDAO{
+		{StartStatusID: "1111111111111111111", DAO: conversation[0], Depth: 2},
+		{StartStatusID: "1111111111111111111", DAO: conversation[1], Depth: 1},
+	}
+}
@@ -0,0 +1,58 @@
+package parents
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// SelectConversations retrieves the conversation that leads to each of the given status IDs, that is, the tweet with
+// that status ID followed by the tweets it replies to, up to MaxDepth tweets. Each conversation is sorted from its first
+// tweet to the one with the given status ID, so it can be read as a thread. The status IDs without a stored parent are
+// not present in the result
+type SelectConversations func(ctx context.Context, statusIDs []string) (map[string][]DAO, error)
+
+// MakeSelectConversations creates a new SelectConversations
+func MakeSelectConversations(db database.Connection, collectRows database.CollectRows[LinkDAO]) SelectConversations {
+	const query string = `
+		WITH RECURSIVE conversations AS (
+			SELECT p.status_id AS start_status_id, p.status_id, p.author, p.text_content, p.in_reply_to_status_id, 1 AS depth
+			FROM tweets_parents AS p
+			WHERE p.status_id = ANY($1)
+			UNION ALL
+			SELECT c.start_status_id, p.status_id, p.author, p.text_content, p.in_reply_to_status_id, c.depth + 1
+			FROM conversations AS c
+			INNER JOIN tweets_parents AS p ON p.status_id = c.in_reply_to_status_id
+			WHERE c.depth < $2
+		)
+		SELECT c.start_status_id, c.status_id, c.author, c.text_content, c.depth
+		FROM conversations AS c
+		ORDER BY c.start_status_id, c.depth DESC;
+	`
+
+	return func(ctx context.Context, statusIDs []string) (map[string][]DAO, error) {
+		conversations := make(map[string][]DAO)
+		if len(statusIDs) == 0 {
+			return conversations, nil
+		}
+
+		rows, err := db.Query(ctx, query, statusIDs, MaxDepth)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectConversations
+		}
+
+		links, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectConversations
+		}
+
+		for _, link := range links {
+			conversations[link.StartStatusID] = append(conversations[link.StartStatusID], link.DAO)
+		}
+
+		return conversations, nil
+	}
+}
@@ -0,0 +1,72 @@
+package parents_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets/parents"
+	"ahbcc/internal/database"
+)
+
+func TestSelectConversations_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[parents.LinkDAO](parents.MockLinkDAOs(), nil)
+
+	selectConversations := parents.MakeSelectConversations(mockPostgresConnection, mockCollectRows)
+
+	want := map[string][]parents.DAO{"1111111111111111111": parents.MockConversation()}
+	got, err := selectConversations(context.Background(), []string{"1111111111111111111", "3333333333333333333"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectConversations_successWhenThereAreNoStatusIDs(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockCollectRows := database.MockCollectRows[parents.LinkDAO](nil, nil)
+
+	selectConversations := parents.MakeSelectConversations(mockPostgresConnection, mockCollectRows)
+
+	got, err := selectConversations(context.Background(), []string{})
+
+	assert.Nil(t, err)
+	assert.Empty(t, got)
+	mockPostgresConnection.AssertNotCalled(t, "Query")
+}
+
+func TestSelectConversations_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select conversations"))
+	mockCollectRows := database.MockCollectRows[parents.LinkDAO](nil, nil)
+
+	selectConversations := parents.MakeSelectConversations(mockPostgresConnection, mockCollectRows)
+
+	want := parents.FailedToExecuteSelectConversations
+	_, got := selectConversations(context.Background(), []string{"1111111111111111111"})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectConversations_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[parents.LinkDAO](nil, errors.New("failed to collect rows"))
+
+	selectConversations := parents.MakeSelectConversations(mockPostgresConnection, mockCollectRows)
+
+	want := parents.FailedToExecuteCollectRowsInSelectConversations
+	_, got := selectConversations(context.Background(), []string{"1111111111111111111"})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -1,8 +1,12 @@
 package quotes
 
-import "time"
+import (
+	"time"
 
-// DAO represents a quote from the 'tweets_quotes' table
+	"ahbcc/cmd/api/tweets/entities"
+)
+
+// DAO represents a quote from the 'tweets_quotes' table. The entities are nil when they weren't extracted yet
 type DAO struct {
 	ID          int       `json:"id"`
 	Author      string    `json:"author"`
@@ -11,4 +15,5 @@ type DAO struct {
 	IsAReply    bool      `json:"is_a_reply"`
 	TextContent *string   `json:"text_content,omitempty"`
 	Images      []string  `json:"images,omitempty"`
+	entities.Entities
 }
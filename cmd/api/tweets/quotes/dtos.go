@@ -1,10 +1,16 @@
 package quotes
 
-import "time"
+import (
+	"time"
+
+	"ahbcc/cmd/api/tweets/entities"
+)
 
 type (
-	// QuoteDTO represents a quote of a tweet that will be inserted in the 'tweets_quotes' table
+	// QuoteDTO represents a quote of a tweet that will be inserted in the 'tweets_quotes' table.
+	// The StatusID is the number after the /status/ of the quoted tweet url, and it is used to store each quote only once
 	QuoteDTO struct {
+		StatusID    string   `json:"status_id,omitempty"`
 		Author      string   `json:"author"`
 		Avatar      *string  `json:"avatar,omitempty"`
 		PostedAt    string   `json:"posted_at"`
@@ -16,11 +22,12 @@ type (
 	// CustomQuoteDTO represents a quote of a tweet obtained from the database. Note that the PostedAt param type
 	// is different from the QuoteDTO.PostedAt param type
 	CustomQuoteDTO struct {
-		Author      string    `json:"author"`
-		Avatar      *string   `json:"avatar,omitempty"`
-		PostedAt    time.Time `json:"posted_at"`
-		IsAReply    bool      `json:"is_a_reply"`
-		TextContent *string   `json:"text_content,omitempty"`
-		Images      []string  `json:"images,omitempty"`
+		Author      string            `json:"author"`
+		Avatar      *string           `json:"avatar,omitempty"`
+		PostedAt    time.Time         `json:"posted_at"`
+		IsAReply    bool              `json:"is_a_reply"`
+		TextContent *string           `json:"text_content,omitempty"`
+		Images      []string          `json:"images,omitempty"`
+		Entities    entities.Entities `json:"entities"`
 	}
 )
@@ -2,27 +2,43 @@ package quotes
 
 import (
 	"context"
-	"errors"
 	"time"
 
 	"github.com/jackc/pgx/v5"
 
+	"ahbcc/cmd/api/tweets/entities"
 	"ahbcc/internal/database"
 	"ahbcc/internal/log"
 )
 
-// InsertSingle inserts a new QuoteDTO into 'quotes' table and returns the PK
-type InsertSingle func(ctx context.Context, quote *QuoteDTO) (int, error)
+// InsertSingle inserts a new QuoteDTO into 'tweets_quotes' table and returns the PK.
+// If a quote with the same status ID was already inserted, it is reused and its PK is returned instead, so the same
+// quoted tweet is shared between all the tweets that quote it. Quotes without a status ID are always inserted.
+// The entities of the text (hashtags, mentions, URLs, emojis and cashtags) are extracted before inserting it.
+// It uses the given transaction if it is not nil
+type InsertSingle func(tx pgx.Tx, ctx context.Context, quote *QuoteDTO) (int, error)
 
 // MakeInsertSingle creates a new InsertSingle
 func MakeInsertSingle(db database.Connection) InsertSingle {
 	const query string = `
-			INSERT INTO tweets_quotes(is_a_reply, author, avatar, posted_at, text_content, images) 
-			VALUES ($1, $2, $3, $4, $5, $6)
+			INSERT INTO tweets_quotes(status_id, is_a_reply, author, avatar, posted_at, text_content, images, hashtags, mentions, urls, emojis, cashtags) 
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)
+			ON CONFLICT (status_id) DO UPDATE SET 
+				avatar = COALESCE(EXCLUDED.avatar, tweets_quotes.avatar),
+				hashtags = COALESCE(tweets_quotes.hashtags, EXCLUDED.hashtags),
+				mentions = COALESCE(tweets_quotes.mentions, EXCLUDED.mentions),
+				urls = COALESCE(tweets_quotes.urls, EXCLUDED.urls),
+				emojis = COALESCE(tweets_quotes.emojis, EXCLUDED.emojis),
+				cashtags = COALESCE(tweets_quotes.cashtags, EXCLUDED.cashtags)
 			RETURNING id;
 		`
 
-	return func(ctx context.Context, quote *QuoteDTO) (int, error) {
+	return func(tx pgx.Tx, ctx context.Context, quote *QuoteDTO) (int, error) {
+		var conn database.Connection = db
+		if tx != nil {
+			conn = tx
+		}
+
 		if quote == nil {
 			return -1, NothingToInsertWhenQuoteIsNil
 		}
@@ -37,9 +53,16 @@ func MakeInsertSingle(db database.Connection) InsertSingle {
 			}
 		}
 
+		var statusID *string
+		if quote.StatusID != "" {
+			statusID = &quote.StatusID
+		}
+
+		extracted := entities.Extract(quote.TextContent)
+
 		var quoteID int
-		err := db.QueryRow(ctx, query, quote.IsAReply, quote.Author, quote.Avatar, postedAt, quote.TextContent, quote.Images).Scan(&quoteID)
-		if errors.Is(err, pgx.ErrNoRows) {
+		err := conn.QueryRow(ctx, query, statusID, quote.IsAReply, quote.Author, quote.Avatar, postedAt, quote.TextContent, quote.Images, extracted.Hashtags, extracted.Mentions, extracted.URLs, extracted.Emojis, extracted.Cashtags).Scan(&quoteID)
+		if err != nil {
 			log.Error(ctx, err.Error())
 			return -1, FailedToInsertQuote
 		}
@@ -22,7 +22,7 @@ func TestInsertSingle_success(t *testing.T) {
 	insertSingleQuote := quotes.MakeInsertSingle(mockPostgresConnection)
 
 	want := 1
-	got, err := insertSingleQuote(context.Background(), &mockQuoteDTO)
+	got, err := insertSingleQuote(nil, context.Background(), &mockQuoteDTO)
 
 	assert.Nil(t, err)
 	assert.Equal(t, want, got)
@@ -41,7 +41,7 @@ func TestInsertSingle_successEvenWhenTheTimestampParseFails(t *testing.T) {
 	insertSingleQuote := quotes.MakeInsertSingle(mockPostgresConnection)
 
 	want := 1
-	got, err := insertSingleQuote(context.Background(), &mockQuoteDTO)
+	got, err := insertSingleQuote(nil, context.Background(), &mockQuoteDTO)
 
 	assert.Nil(t, err)
 	assert.Equal(t, want, got)
@@ -49,13 +49,33 @@ func TestInsertSingle_successEvenWhenTheTimestampParseFails(t *testing.T) {
 	mockPgxRow.AssertExpectations(t)
 }
 
+func TestInsertSingle_successUsingTheTransaction(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresTx := new(database.MockPgxTx)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{1}, t)
+	mockPostgresTx.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+	mockQuoteDTO := quotes.MockQuoteDTO()
+
+	insertSingleQuote := quotes.MakeInsertSingle(mockPostgresConnection)
+
+	want := 1
+	got, err := insertSingleQuote(mockPostgresTx, context.Background(), &mockQuoteDTO)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertNotCalled(t, "QueryRow", mock.Anything, mock.Anything, mock.Anything)
+	mockPostgresTx.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
 func TestInsertSingle_failsWhenQuoteIsNil(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 
 	insertSingleQuote := quotes.MakeInsertSingle(mockPostgresConnection)
 
 	want := quotes.NothingToInsertWhenQuoteIsNil
-	_, got := insertSingleQuote(context.Background(), nil)
+	_, got := insertSingleQuote(nil, context.Background(), nil)
 
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
@@ -71,8 +91,27 @@ func TestInsertSingle_failsWhenInsertOperationThrowsError(t *testing.T) {
 	insertSingleQuote := quotes.MakeInsertSingle(mockPostgresConnection)
 
 	want := quotes.FailedToInsertQuote
-	_, got := insertSingleQuote(context.Background(), &mockQuoteDTO)
+	_, got := insertSingleQuote(nil, context.Background(), &mockQuoteDTO)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
 
+func TestInsertSingle_successWithoutStatusID(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{1}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+	mockQuoteDTO := quotes.MockQuoteDTO()
+	mockQuoteDTO.StatusID = ""
+
+	insertSingleQuote := quotes.MakeInsertSingle(mockPostgresConnection)
+
+	want := 1
+	got, err := insertSingleQuote(nil, context.Background(), &mockQuoteDTO)
+
+	assert.Nil(t, err)
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
 	mockPgxRow.AssertExpectations(t)
@@ -3,22 +3,19 @@ package quotes
 import (
 	"context"
 	"time"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/cmd/api/tweets/entities"
 )
 
 // MockInsertSingle mocks InsertSingle function
 func MockInsertSingle(quoteID int, err error) InsertSingle {
-	return func(ctx context.Context, q *QuoteDTO) (int, error) {
+	return func(tx pgx.Tx, ctx context.Context, q *QuoteDTO) (int, error) {
 		return quoteID, err
 	}
 }
 
-// MockDeleteOrphans mocks DeleteOrphans function
-func MockDeleteOrphans(err error) DeleteOrphans {
-	return func(ctx context.Context, ids []int) error {
-		return err
-	}
-}
-
 // MockSelectByID mocks SelectByID function
 func MockSelectByID(quoteDAO DAO, err error) SelectByID {
 	return func(ctx context.Context, id int) (DAO, error) {
@@ -32,6 +29,7 @@ func MockQuoteDTO() QuoteDTO {
 	avatar := "https://testquoteavatar.com"
 
 	return QuoteDTO{
+		StatusID:    "1234567890123456789",
 		IsAReply:    true,
 		Author:      "TestQuoteAuthor",
 		Avatar:      &avatar,
@@ -53,6 +51,7 @@ func MockCustomQuoteDTO() CustomQuoteDTO {
 		PostedAt:    time.Now(),
 		TextContent: &textContent,
 		Images:      []string{"test1", "test2"},
+		Entities:    entities.MockEntities(),
 	}
 }
 
@@ -69,6 +68,7 @@ func MockTweetQuoteDAO() DAO {
 		IsAReply:    false,
 		TextContent: &textContent,
 		Images:      []string{"test1", "test2"},
+		Entities:    entities.MockEntities(),
 	}
 }
 
@@ -82,5 +82,10 @@ func MockScanTweetQuoteDAOValues(dao DAO) []any {
 		dao.IsAReply,
 		dao.TextContent,
 		dao.Images,
+		dao.Hashtags,
+		dao.Mentions,
+		dao.URLs,
+		dao.Emojis,
+		dao.Cashtags,
 	}
 }
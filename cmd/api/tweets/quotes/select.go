@@ -15,7 +15,7 @@ type SelectByID func(ctx context.Context, id int) (DAO, error)
 
 // MakeSelectByID creates a new SelectByID function
 func MakeSelectByID(db database.Connection) SelectByID {
-	const query string = `SELECT tq.id, tq.author, tq.avatar, tq.posted_at, tq.is_a_reply, tq.text_content, tq.images
+	const query string = `SELECT tq.id, tq.author, tq.avatar, tq.posted_at, tq.is_a_reply, tq.text_content, tq.images, tq.hashtags, tq.mentions, tq.urls, tq.emojis, tq.cashtags
 						  FROM tweets_quotes AS tq
 						  WHERE tq.id = $1`
 
@@ -29,6 +29,11 @@ func MakeSelectByID(db database.Connection) SelectByID {
 			&tweet.IsAReply,
 			&tweet.TextContent,
 			&tweet.Images,
+			&tweet.Hashtags,
+			&tweet.Mentions,
+			&tweet.URLs,
+			&tweet.Emojis,
+			&tweet.Cashtags,
 		)
 		if errors.Is(err, pgx.ErrNoRows) {
 			log.Error(ctx, err.Error())
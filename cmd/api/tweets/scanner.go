@@ -1,64 +1,115 @@
 package tweets
 
 import (
+	"html"
+	"strings"
+
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
 
+	"ahbcc/cmd/api/tweets/entities"
 	"ahbcc/cmd/api/tweets/quotes"
 )
 
 // CustomScanner is a custom scanner to parse the row retrieved and return a TweetDTO which also contains a quotes.QuoteDTO
 func CustomScanner() pgx.RowToFunc[CustomTweetDTO] {
 	return func(row pgx.CollectableRow) (CustomTweetDTO, error) {
+		return scanCustomTweet(row)
+	}
+}
+
+// SearchResultScanner is a custom scanner to parse the row retrieved by a search and return a SearchResultDTO
+func SearchResultScanner() pgx.RowToFunc[SearchResultDTO] {
+	return func(row pgx.CollectableRow) (SearchResultDTO, error) {
 		var (
-			tweetDTO CustomTweetDTO
-
-			// Nullable variables for scanning
-			quoteAuthor      pgtype.Text
-			quoteAvatar      pgtype.Text
-			quotePostedAt    pgtype.Timestamp
-			quoteIsAReply    pgtype.Bool
-			quoteTextContent pgtype.Text
-			quoteImages      []string
+			result         SearchResultDTO
+			highlight      pgtype.Text
+			quoteHighlight pgtype.Text
 		)
 
-		err := row.Scan(
-			&tweetDTO.ID,
-			&tweetDTO.StatusID,
-			&tweetDTO.Author,
-			&tweetDTO.Avatar,
-			&tweetDTO.PostedAt,
-			&tweetDTO.IsAReply,
-			&tweetDTO.TextContent,
-			&tweetDTO.Images,
-			&tweetDTO.QuoteID,
-			&tweetDTO.SearchCriteriaID,
-			&quoteAuthor,
-			&quoteAvatar,
-			&quotePostedAt,
-			&quoteIsAReply,
-			&quoteTextContent,
-			&quoteImages,
-		)
+		tweetDTO, err := scanCustomTweet(row, &result.Rank, &highlight, &quoteHighlight)
 		if err != nil {
-			return CustomTweetDTO{}, err
+			return SearchResultDTO{}, err
 		}
 
+		result.CustomTweetDTO = tweetDTO
+		result.Highlight = toHighlight(highlight)
 		if tweetDTO.QuoteID != nil {
-			tweetDTO.Quote = &quotes.CustomQuoteDTO{
-				Author:      quoteAuthor.String,
-				Avatar:      pgTextToStringPtr(quoteAvatar),
-				PostedAt:    quotePostedAt.Time,
-				IsAReply:    quoteIsAReply.Bool,
-				TextContent: pgTextToStringPtr(quoteTextContent),
-				Images:      quoteImages,
-			}
+			result.QuoteHighlight = toHighlight(quoteHighlight)
 		}
 
-		return tweetDTO, nil
+		return result, nil
 	}
 }
 
+// scanCustomTweet scans the tweet and quote columns of the row, followed by the given extra columns
+func scanCustomTweet(row pgx.CollectableRow, extra ...any) (CustomTweetDTO, error) {
+	var (
+		tweetDTO CustomTweetDTO
+
+		// Nullable variables for scanning
+		quoteAuthor      pgtype.Text
+		quoteAvatar      pgtype.Text
+		quotePostedAt    pgtype.Timestamp
+		quoteIsAReply    pgtype.Bool
+		quoteTextContent pgtype.Text
+		quoteImages      []string
+		quoteEntities    entities.Entities
+	)
+
+	dest := []any{
+		&tweetDTO.ID,
+		&tweetDTO.StatusID,
+		&tweetDTO.Author,
+		&tweetDTO.Avatar,
+		&tweetDTO.PostedAt,
+		&tweetDTO.IsAReply,
+		&tweetDTO.TextContent,
+		&tweetDTO.Images,
+		&tweetDTO.Entities.Hashtags,
+		&tweetDTO.Entities.Mentions,
+		&tweetDTO.Entities.URLs,
+		&tweetDTO.Entities.Emojis,
+		&tweetDTO.Entities.Cashtags,
+		&tweetDTO.Language,
+		&tweetDTO.LanguageConfidence,
+		&tweetDTO.RepresentativeID,
+		&tweetDTO.InReplyToStatusID,
+		&tweetDTO.QuoteID,
+		&tweetDTO.SearchCriteriaID,
+		&quoteAuthor,
+		&quoteAvatar,
+		&quotePostedAt,
+		&quoteIsAReply,
+		&quoteTextContent,
+		&quoteImages,
+		&quoteEntities.Hashtags,
+		&quoteEntities.Mentions,
+		&quoteEntities.URLs,
+		&quoteEntities.Emojis,
+		&quoteEntities.Cashtags,
+	}
+
+	err := row.Scan(append(dest, extra...)...)
+	if err != nil {
+		return CustomTweetDTO{}, err
+	}
+
+	if tweetDTO.QuoteID != nil {
+		tweetDTO.Quote = &quotes.CustomQuoteDTO{
+			Author:      quoteAuthor.String,
+			Avatar:      pgTextToStringPtr(quoteAvatar),
+			PostedAt:    quotePostedAt.Time,
+			IsAReply:    quoteIsAReply.Bool,
+			TextContent: pgTextToStringPtr(quoteTextContent),
+			Images:      quoteImages,
+			Entities:    quoteEntities,
+		}
+	}
+
+	return tweetDTO, nil
+}
+
 func pgTextToStringPtr(text pgtype.Text) *string {
 	if text.Valid && text.String != "" {
 		return &text.String
@@ -66,3 +117,18 @@ func pgTextToStringPtr(text pgtype.Text) *string {
 
 	return nil
 }
+
+// highlightReplacer replaces the delimiters of the matches of a headline by the <mark> tags
+var highlightReplacer = strings.NewReplacer(highlightStart, "<mark>", highlightStop, "</mark>")
+
+// toHighlight HTML-escapes the text of the given headline, so the markup of a tweet isn't mistaken for the one of the
+// API, and wraps its matches by <mark> tags
+func toHighlight(headline pgtype.Text) *string {
+	text := pgTextToStringPtr(headline)
+	if text == nil {
+		return nil
+	}
+
+	highlight := highlightReplacer.Replace(html.EscapeString(*text))
+	return &highlight
+}
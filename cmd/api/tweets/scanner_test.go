@@ -82,3 +82,50 @@ func TestCustomScanner_failsWhenScanThrowsError(t *testing.T) {
 	assert.Equal(t, want, got)
 	mockPgxCollectableRow.AssertExpectations(t)
 }
+
+func TestSearchResultScanner_success(t *testing.T) {
+	mockPgxCollectableRow := new(database.MockPgxCollectableRow)
+	mockSearchResultDTO := tweets.MockSearchResultDTO()
+	mockSearchResultCollectedRow := tweets.MockSearchResultCollectedRow(mockSearchResultDTO)
+	database.MockPgxCollectableRowMethods(mockPgxCollectableRow, mockSearchResultCollectedRow, t)
+
+	searchResultScanner := tweets.SearchResultScanner()
+
+	want := mockSearchResultDTO
+	got, err := searchResultScanner(mockPgxCollectableRow)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPgxCollectableRow.AssertExpectations(t)
+}
+
+func TestSearchResultScanner_successEscapingTheHTMLOfTheTexts(t *testing.T) {
+	mockPgxCollectableRow := new(database.MockPgxCollectableRow)
+	mockSearchResultDTO := tweets.MockSearchResultDTO()
+	highlight := `<img src=x onerror="alert(1)"> <mark>test</mark> & more`
+	mockSearchResultDTO.Highlight = &highlight
+	mockSearchResultCollectedRow := tweets.MockSearchResultCollectedRow(mockSearchResultDTO)
+	database.MockPgxCollectableRowMethods(mockPgxCollectableRow, mockSearchResultCollectedRow, t)
+
+	searchResultScanner := tweets.SearchResultScanner()
+
+	want := `&lt;img src=x onerror=&#34;alert(1)&#34;&gt; <mark>test</mark> &amp; more`
+	got, err := searchResultScanner(mockPgxCollectableRow)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, *got.Highlight)
+	mockPgxCollectableRow.AssertExpectations(t)
+}
+
+func TestSearchResultScanner_failsWhenScanThrowsError(t *testing.T) {
+	mockPgxCollectableRow := new(database.MockPgxCollectableRow)
+	want := errors.New("scan error")
+	mockPgxCollectableRow.On("Scan", mock.Anything).Return(want)
+
+	searchResultScanner := tweets.SearchResultScanner()
+
+	_, got := searchResultScanner(mockPgxCollectableRow)
+
+	assert.Equal(t, want, got)
+	mockPgxCollectableRow.AssertExpectations(t)
+}
@@ -0,0 +1,109 @@
+package tweets
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// highlightStart and highlightStop delimit the matches in the headlines of the search results. They are private use
+// characters, removed from the texts beforehand, so the headlines can be HTML-escaped before replacing them by the
+// <mark> tags
+const (
+	highlightStart string = "\uE000"
+	highlightStop  string = "\uE001"
+)
+
+// Search retrieves a page of the tweets whose text, or the text of their quote, matches the search query, applying the
+// given filters. If the searchCriteriaID is 0, the tweets of all the criteria are searched.
+// The results are sorted by rank, and the page starts right after the cursor of the filters, if present.
+// The search query syntax is described in toTSQuery
+type Search func(ctx context.Context, search string, searchCriteriaID int, filters Filters, token string) (SearchPageDTO, error)
+
+// MakeSearch creates a new Search
+func MakeSearch(db database.Connection, collectRows database.CollectRows[SearchResultDTO], selectUserIDByToken session.SelectUserIDByToken) Search {
+	// Each tweet is matched using the text search configuration of the language of its criteria, so the search query is
+	// parsed once for each of the configurations in use
+	const query string = `
+		WITH queries AS (
+			SELECT c.config, to_tsquery(c.config, $1) AS query
+			FROM (SELECT DISTINCT search_config_for_language(sc.language) AS config FROM search_criteria AS sc) AS c
+		),
+		matches AS (
+			SELECT t.id, qs.config, qs.query, ts_rank(t.text_search, qs.query)::FLOAT8 AS rank
+			FROM tweets AS t
+			INNER JOIN queries AS qs ON qs.config = t.search_config
+			WHERE %s
+		)
+		SELECT t.id, t.status_id, t.author, t.avatar, t.posted_at, t.is_a_reply, t.text_content, t.images, t.hashtags, t.mentions, t.urls, t.emojis, t.cashtags, t.language, t.language_confidence, t.representative_id, t.in_reply_to_status_id, t.quote_id, t.search_criteria_id,
+			   q.author, q.avatar, q.posted_at, q.is_a_reply, q.text_content, q.images, q.hashtags, q.mentions, q.urls, q.emojis, q.cashtags,
+			   m.rank,
+			   ts_headline(m.config, translate(COALESCE(t.text_content, ''), chr(57344) || chr(57345), ''), m.query, 'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345)),
+			   ts_headline(m.config, translate(COALESCE(q.text_content, ''), chr(57344) || chr(57345), ''), m.query, 'HighlightAll=true, StartSel=' || chr(57344) || ', StopSel=' || chr(57345))
+		FROM (
+			SELECT * FROM matches AS m
+			WHERE %s
+			ORDER BY m.rank DESC, m.id DESC
+			LIMIT %s
+		) AS m
+		INNER JOIN tweets AS t ON t.id = m.id
+		LEFT JOIN tweets_quotes AS q ON t.quote_id = q.id
+		ORDER BY m.rank DESC, m.id DESC`
+
+	return func(ctx context.Context, search string, searchCriteriaID int, filters Filters, token string) (SearchPageDTO, error) {
+		tsQuery, err := toTSQuery(search)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return SearchPageDTO{}, InvalidSearchQuery
+		}
+		ctx = log.With(ctx, log.Param("ts_query", tsQuery))
+
+		userID, err := selectUserIDByToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return SearchPageDTO{}, FailedToRetrieveUserID
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		args := arguments{tsQuery}
+		predicates := []string{"t.text_search @@ qs.query"}
+		if searchCriteriaID != 0 {
+			predicates = append(predicates, fmt.Sprintf("EXISTS (SELECT 1 FROM tweets_search_criteria AS tc WHERE tc.tweet_id = t.id AND tc.search_criteria_id = %s)", args.add(searchCriteriaID)))
+		}
+		predicates = append(predicates, filters.predicates(&args, userID)...)
+
+		cursorPredicate := "TRUE"
+		if filters.Cursor != nil {
+			cursorPredicate = fmt.Sprintf("(m.rank, m.id) < (%s, %s)", args.add(filters.Cursor.Rank), args.add(filters.Cursor.ID))
+		}
+
+		// One extra result is retrieved to know if there is a next page
+		queryToExecute := fmt.Sprintf(query, strings.Join(predicates, " AND "), cursorPredicate, args.add(filters.Limit+1))
+
+		rows, err := db.Query(ctx, queryToExecute, args...)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return SearchPageDTO{}, FailedToExecuteSearchTweets
+		}
+
+		results, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return SearchPageDTO{}, FailedToExecuteCollectRowsInSearchTweets
+		}
+
+		var nextCursor *string
+		if len(results) > filters.Limit {
+			results = results[:filters.Limit]
+			last := results[len(results)-1]
+			encoded := Cursor{Rank: last.Rank, ID: last.ID}.Encode()
+			nextCursor = &encoded
+		}
+
+		return SearchPageDTO{Results: results, NextCursor: nextCursor}, nil
+	}
+}
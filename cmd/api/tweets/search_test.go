@@ -0,0 +1,122 @@
+package tweets_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/tweets"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+)
+
+func TestSearch_success(t *testing.T) {
+	tests := []struct {
+		searchCriteriaID int
+		filters          tweets.Filters
+	}{
+		{searchCriteriaID: 0, filters: tweets.Filters{Categorization: tweets.CategorizationAll, Limit: 10}},
+		{searchCriteriaID: 1, filters: tweets.Filters{Year: 2025, Month: 4, Author: "TestAuthor", Limit: 10}},
+		{searchCriteriaID: 1, filters: tweets.Filters{Categorization: "NEGATIVE", Cursor: &tweets.Cursor{Rank: 0.5, ID: 10}, Limit: 10}},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRows := new(database.MockPgxRows)
+		mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+		mockResults := tweets.MockSearchPageDTO().Results
+		mockCollectRows := database.MockCollectRows[tweets.SearchResultDTO](mockResults, nil)
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+
+		search := tweets.MakeSearch(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+
+		want := tweets.SearchPageDTO{Results: mockResults}
+		got, err := search(context.Background(), `"hate speech" OR violen*`, tt.searchCriteriaID, tt.filters, "token")
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestSearch_successWithNextCursor(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockResults := tweets.MockSearchPageDTO().Results
+	mockCollectRows := database.MockCollectRows[tweets.SearchResultDTO](mockResults, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+
+	search := tweets.MakeSearch(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+
+	nextCursor := tweets.Cursor{Rank: mockResults[0].Rank, ID: mockResults[0].ID}.Encode()
+	want := tweets.SearchPageDTO{Results: mockResults[:1], NextCursor: &nextCursor}
+	got, err := search(context.Background(), "hate", 0, tweets.Filters{Categorization: tweets.CategorizationAll, Limit: 1}, "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSearch_failsWhenTheSearchQueryHasNoWords(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockCollectRows := database.MockCollectRows[tweets.SearchResultDTO](nil, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+
+	search := tweets.MakeSearch(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+
+	want := tweets.InvalidSearchQuery
+	_, got := search(context.Background(), `"" -`, 0, tweets.Filters{Limit: 10}, "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSearch_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockCollectRows := database.MockCollectRows[tweets.SearchResultDTO](nil, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(-1, errors.New("failed to select user id by token"))
+
+	search := tweets.MakeSearch(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+
+	want := tweets.FailedToRetrieveUserID
+	_, got := search(context.Background(), "hate", 0, tweets.Filters{Limit: 10}, "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSearch_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to search tweets"))
+	mockCollectRows := database.MockCollectRows[tweets.SearchResultDTO](nil, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+
+	search := tweets.MakeSearch(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+
+	want := tweets.FailedToExecuteSearchTweets
+	_, got := search(context.Background(), "hate", 0, tweets.Filters{Limit: 10}, "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSearch_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[tweets.SearchResultDTO](nil, errors.New("failed to collect rows"))
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+
+	search := tweets.MakeSearch(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+
+	want := tweets.FailedToExecuteCollectRowsInSearchTweets
+	_, got := search(context.Background(), "hate", 0, tweets.Filters{Limit: 10}, "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -4,81 +4,161 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strings"
 
 	"github.com/jackc/pgx/v5"
 
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/user/session"
 	"ahbcc/internal/database"
 	"ahbcc/internal/log"
 )
 
 type (
-	// SelectBySearchCriteriaIDYearAndMonth retrieves the user's uncategorized tweets from a criteria, seeking by year and month.
-	// It also limits the number of tweets retrieved to the limit param.
-	SelectBySearchCriteriaIDYearAndMonth func(ctx context.Context, searchCriteriaID, year, month, limit int, token string) ([]CustomTweetDTO, error)
+	// SelectBySearchCriteriaID retrieves a page of tweets from a criteria, applying the given filters.
+	// The tweets are sorted by posted_at and ID, and the page starts right after the cursor of the filters, if present.
+	// When only the user's uncategorized tweets are requested, gold tweets are never retrieved as part of the regular
+	// tweets, instead, a portion of the limit is filled with uncategorized gold tweets of the same criteria, placed at
+	// random positions, so the annotator can't tell them apart. The gold tweets are sorted by ID and the cursor keeps
+	// the last one served, so they aren't repeated across the pages.
+	// The replies are retrieved with the conversation that leads to them.
+	SelectBySearchCriteriaID func(ctx context.Context, searchCriteriaID int, filters Filters, token string) (PageDTO, error)
 
 	// SelectByID retrieves a tweet DAO by its ID
 	SelectByID func(ctx context.Context, id int) (DAO, error)
+
+	// SelectByIDs retrieves all the tweets DAOs whose IDs are present in the given slice. The IDs that don't
+	// correspond to any tweet are ignored
+	SelectByIDs func(ctx context.Context, ids []int) ([]DAO, error)
 )
 
-// MakeSelectBySearchCriteriaIDYearAndMonth creates a new SelectBySearchCriteriaIDYearAndMonth
-func MakeSelectBySearchCriteriaIDYearAndMonth(db database.Connection, collectRows database.CollectRows[CustomTweetDTO], selectUserIDByToken session.SelectUserIDByToken) SelectBySearchCriteriaIDYearAndMonth {
-	const query string = `SELECT t.id, t.status_id, t.author, t.avatar, t.posted_at, t.is_a_reply, t.text_content, t.images, t.quote_id, t.search_criteria_id,
-         							q.author, q.avatar, q.posted_at, q.is_a_reply, q.text_content, q.images
+// MakeSelectBySearchCriteriaID creates a new SelectBySearchCriteriaID
+// The goldRate is the fraction (from 0 to 1) of each page that is filled with gold tweets. At least one slot of each
+// page is always left for the regular tweets, so the cursor keeps moving forward.
+func MakeSelectBySearchCriteriaID(db database.Connection, collectRows database.CollectRows[CustomTweetDTO], selectUserIDByToken session.SelectUserIDByToken, selectConversations parents.SelectConversations, goldRate float64) SelectBySearchCriteriaID {
+	const query string = `SELECT t.id, t.status_id, t.author, t.avatar, t.posted_at, t.is_a_reply, t.text_content, t.images, t.hashtags, t.mentions, t.urls, t.emojis, t.cashtags, t.language, t.language_confidence, t.representative_id, t.in_reply_to_status_id, t.quote_id, tc.search_criteria_id,
+         							q.author, q.avatar, q.posted_at, q.is_a_reply, q.text_content, q.images, q.hashtags, q.mentions, q.urls, q.emojis, q.cashtags
 						  FROM tweets AS t
+						  INNER JOIN tweets_search_criteria AS tc ON tc.tweet_id = t.id
 						  LEFT JOIN tweets_quotes AS q ON t.quote_id = q.id
-						  WHERE t.id NOT IN (SELECT c.tweet_id FROM categorized_tweets AS c WHERE c.user_id = $1)
-						    AND t.search_criteria_id = $2`
+						  WHERE %s
+						  ORDER BY ` + postedAtSortKey + `, t.id
+						  LIMIT %s`
 
-	return func(ctx context.Context, searchCriteriaID, year, month, limit int, token string) ([]CustomTweetDTO, error) {
-		queryToExecute := query
+	const goldQuery string = `SELECT t.id, t.status_id, t.author, t.avatar, t.posted_at, t.is_a_reply, t.text_content, t.images, t.hashtags, t.mentions, t.urls, t.emojis, t.cashtags, t.language, t.language_confidence, t.representative_id, t.in_reply_to_status_id, t.quote_id, tc.search_criteria_id,
+         							q.author, q.avatar, q.posted_at, q.is_a_reply, q.text_content, q.images, q.hashtags, q.mentions, q.urls, q.emojis, q.cashtags
+						  FROM tweets AS t
+						  INNER JOIN tweets_search_criteria AS tc ON tc.tweet_id = t.id
+						  INNER JOIN gold_tweets AS g ON g.tweet_id = t.id
+						  LEFT JOIN tweets_quotes AS q ON t.quote_id = q.id
+						  WHERE %s
+						  ORDER BY t.id
+						  LIMIT %s`
 
+	return func(ctx context.Context, searchCriteriaID int, filters Filters, token string) (PageDTO, error) {
 		userID, err := selectUserIDByToken(ctx, token)
 		if err != nil {
 			log.Error(ctx, err.Error())
-			return nil, FailedToRetrieveUserID
+			return PageDTO{}, FailedToRetrieveUserID
 		}
 		ctx = log.With(ctx, log.Param("user_id", userID))
 
-		args := make([]any, 0, 5)
-		currentParamNum := 3
+		var goldTweets []CustomTweetDTO
+		goldLimit := min(int(math.Ceil(float64(filters.Limit)*goldRate)), filters.Limit-1)
+		if filters.uncategorized() && goldLimit > 0 {
+			var goldArgs arguments
+			goldPredicates := append([]string{fmt.Sprintf("tc.search_criteria_id = %s", goldArgs.add(searchCriteriaID))}, filters.predicates(&goldArgs, userID)...)
+			if filters.Cursor != nil && filters.Cursor.GoldID > 0 {
+				goldPredicates = append(goldPredicates, fmt.Sprintf("t.id > %s", goldArgs.add(filters.Cursor.GoldID)))
+			}
+			goldQueryToExecute := fmt.Sprintf(goldQuery, strings.Join(goldPredicates, " AND "), goldArgs.add(goldLimit))
 
-		args = append(args, userID, searchCriteriaID)
+			rows, err := db.Query(ctx, goldQueryToExecute, goldArgs...)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return PageDTO{}, FailedToRetrieveUserUncategorizedGoldTweets
+			}
 
-		if year != 0 {
-			queryToExecute = queryToExecute + fmt.Sprintf(" AND EXTRACT(YEAR FROM t.posted_at) = $%d", currentParamNum)
-			currentParamNum++
-			args = append(args, year)
-			if month != 0 {
-				queryToExecute = queryToExecute + fmt.Sprintf(" AND EXTRACT(MONTH FROM t.posted_at) = $%d", currentParamNum)
-				currentParamNum++
-				args = append(args, month)
+			goldTweets, err = collectRows(rows)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return PageDTO{}, FailedToExecuteCollectRowsInSelectUserUncategorizedGoldTweets
 			}
 		}
 
-		queryToExecute = queryToExecute + fmt.Sprintf(" LIMIT $%d", currentParamNum)
-		currentParamNum++
-		args = append(args, limit)
+		var args arguments
+		predicates := append([]string{fmt.Sprintf("tc.search_criteria_id = %s", args.add(searchCriteriaID))}, filters.predicates(&args, userID)...)
+		if filters.uncategorized() {
+			predicates = append(predicates, "NOT EXISTS (SELECT 1 FROM gold_tweets AS g WHERE g.tweet_id = t.id)")
+		}
+		if filters.Cursor != nil {
+			predicates = append(predicates, filters.Cursor.after(&args))
+		}
+
+		// One extra tweet is retrieved to know if there is a next page
+		limit := filters.Limit - len(goldTweets)
+		queryToExecute := fmt.Sprintf(query, strings.Join(predicates, " AND "), args.add(limit+1))
 
 		rows, err := db.Query(ctx, queryToExecute, args...)
 		if err != nil {
 			log.Error(ctx, err.Error())
-			return nil, FailedToRetrieveUserUncategorizedTweets
+			return PageDTO{}, FailedToRetrieveCriteriaTweets
+		}
+
+		criteriaTweets, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return PageDTO{}, FailedToExecuteCollectRowsInSelectCriteriaTweets
+		}
+
+		var nextCursor *string
+		if len(criteriaTweets) > limit {
+			criteriaTweets = criteriaTweets[:limit]
+			last := criteriaTweets[len(criteriaTweets)-1]
+			cursor := Cursor{PostedAt: last.PostedAt, ID: last.ID}
+			if len(goldTweets) > 0 {
+				cursor.GoldID = goldTweets[len(goldTweets)-1].ID
+			} else if filters.Cursor != nil {
+				cursor.GoldID = filters.Cursor.GoldID
+			}
+			encoded := cursor.Encode()
+			nextCursor = &encoded
+		}
+
+		for _, goldTweet := range goldTweets {
+			position := rand.IntN(len(criteriaTweets) + 1)
+			criteriaTweets = slices.Insert(criteriaTweets, position, goldTweet)
+		}
+
+		inReplyToStatusIDs := make([]string, 0, len(criteriaTweets))
+		for _, tweet := range criteriaTweets {
+			if tweet.InReplyToStatusID != nil {
+				inReplyToStatusIDs = append(inReplyToStatusIDs, *tweet.InReplyToStatusID)
+			}
 		}
 
-		uncategorizedTweets, err := collectRows(rows)
+		conversations, err := selectConversations(ctx, inReplyToStatusIDs)
 		if err != nil {
 			log.Error(ctx, err.Error())
-			return nil, FailedToExecuteCollectRowsInSelectUserUncategorizedTweets
+			return PageDTO{}, FailedToRetrieveTweetsConversations
 		}
 
-		return uncategorizedTweets, nil
+		for i, tweet := range criteriaTweets {
+			if tweet.InReplyToStatusID != nil {
+				criteriaTweets[i].Conversation = conversations[*tweet.InReplyToStatusID]
+			}
+		}
+
+		return PageDTO{Tweets: criteriaTweets, NextCursor: nextCursor}, nil
 	}
 }
 
 // MakeSelectByID creates a new SelectByID
 func MakeSelectByID(db database.Connection) SelectByID {
-	const query string = `SELECT t.id, t.status_id, t.author, t.avatar, t.posted_at, t.is_a_reply, t.text_content, t.images, t.quote_id, t.search_criteria_id
+	const query string = `SELECT t.id, t.status_id, t.author, t.avatar, t.posted_at, t.is_a_reply, t.text_content, t.images, t.hashtags, t.mentions, t.urls, t.emojis, t.cashtags, t.language, t.language_confidence, t.representative_id, t.in_reply_to_status_id, t.quote_id, t.search_criteria_id
 						  FROM tweets AS t
 						  WHERE t.id = $1`
 
@@ -93,6 +173,15 @@ func MakeSelectByID(db database.Connection) SelectByID {
 			&tweet.IsAReply,
 			&tweet.TextContent,
 			&tweet.Images,
+			&tweet.Hashtags,
+			&tweet.Mentions,
+			&tweet.URLs,
+			&tweet.Emojis,
+			&tweet.Cashtags,
+			&tweet.Language,
+			&tweet.LanguageConfidence,
+			&tweet.RepresentativeID,
+			&tweet.InReplyToStatusID,
 			&tweet.QuoteID,
 			&tweet.SearchCriteriaID,
 		)
@@ -107,3 +196,35 @@ func MakeSelectByID(db database.Connection) SelectByID {
 		return tweet, nil
 	}
 }
+
+// MakeSelectByIDs creates a new SelectByIDs
+func MakeSelectByIDs(db database.Connection, collectRows database.CollectRows[DAO]) SelectByIDs {
+	const query string = `SELECT t.id, t.status_id, t.author, t.avatar, t.posted_at, t.is_a_reply, t.text_content, t.images, t.hashtags, t.mentions, t.urls, t.emojis, t.cashtags, t.language, t.language_confidence, t.representative_id, t.in_reply_to_status_id, t.quote_id, t.search_criteria_id
+						  FROM tweets AS t
+						  WHERE t.id IN (%s)`
+
+	return func(ctx context.Context, ids []int) ([]DAO, error) {
+		placeholders := make([]string, len(ids))
+		values := make([]any, len(ids))
+		for i, id := range ids {
+			placeholders[i] = fmt.Sprintf("$%d", i+1)
+			values[i] = id
+		}
+
+		queryToExecute := fmt.Sprintf(query, strings.Join(placeholders, ","))
+
+		rows, err := db.Query(ctx, queryToExecute, values...)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectTweetsByIDs
+		}
+
+		tweets, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectTweetsByIDs
+		}
+
+		return tweets, nil
+	}
+}
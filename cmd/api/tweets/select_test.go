@@ -3,29 +3,71 @@ package tweets_test
 import (
 	"context"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 
 	"ahbcc/cmd/api/tweets"
+	"ahbcc/cmd/api/tweets/parents"
 	"ahbcc/cmd/api/user/session"
 	"ahbcc/internal/database"
 )
 
-func TestSelectBySearchCriteriaIDYearAndMonth_success(t *testing.T) {
+func TestSelectBySearchCriteriaID_success(t *testing.T) {
+	postedAt := time.Now()
+
+	tests := []struct {
+		filters tweets.Filters
+	}{
+		{filters: tweets.Filters{Year: 2025, Month: 4, Limit: 10}},
+		{filters: tweets.Filters{Year: 2025, Limit: 10}},
+		{filters: tweets.Filters{Limit: 10}},
+		{filters: tweets.Filters{IsAReply: new(bool), HasQuote: new(bool), HasImages: new(bool), Author: "TestAuthor", Categorization: tweets.CategorizationCategorized, Limit: 10}},
+		{filters: tweets.Filters{Categorization: tweets.CategorizationAll, Cursor: &tweets.Cursor{PostedAt: &postedAt, ID: 1}, Limit: 10}},
+		{filters: tweets.Filters{Categorization: tweets.CategorizationAll, Cursor: &tweets.Cursor{ID: 1}, Limit: 10}},
+		{filters: tweets.Filters{Categorization: "POSITIVE", Limit: 10}},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRows := new(database.MockPgxRows)
+		mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+		mockTweetsDTOs := tweets.MockCustomTweetDTOs()
+		mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
+		mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+		mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+
+		selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0)
+
+		want := tweets.PageDTO{Tweets: mockTweetsDTOs}
+		got, err := selectBySearchCriteriaID(context.Background(), 1, tt.filters, "token")
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRows.AssertExpectations(t)
+	}
+}
+
+func TestSelectBySearchCriteriaID_successWithNextCursor(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPgxRows := new(database.MockPgxRows)
 	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
 	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
 	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
 	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 
-	selectBySearchCriteriaIDYearAndMonth := tweets.MakeSelectBySearchCriteriaIDYearAndMonth(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0)
 
-	want := mockTweetsDTOs
-	got, err := selectBySearchCriteriaIDYearAndMonth(context.Background(), 1, 2025, 04, 10, "token")
+	nextCursor := tweets.Cursor{PostedAt: mockTweetsDTOs[0].PostedAt, ID: mockTweetsDTOs[0].ID}.Encode()
+	want := tweets.PageDTO{Tweets: mockTweetsDTOs[:1], NextCursor: &nextCursor}
+	got, err := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Limit: 1}, "token")
 
 	assert.Nil(t, err)
 	assert.Equal(t, want, got)
@@ -33,91 +75,93 @@ func TestSelectBySearchCriteriaIDYearAndMonth_success(t *testing.T) {
 	mockPgxRows.AssertExpectations(t)
 }
 
-func TestSelectBySearchCriteriaIDYearAndMonth_successWithMonthZero(t *testing.T) {
+func TestSelectBySearchCriteriaID_successWithTheConversationsOfTheReplies(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPgxRows := new(database.MockPgxRows)
 	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	inReplyToStatusID := "1111111111111111111"
 	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
+	mockTweetsDTOs[0].InReplyToStatusID = &inReplyToStatusID
 	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
 	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{inReplyToStatusID: parents.MockConversation()}, nil)
 
-	selectBySearchCriteriaIDYearAndMonth := tweets.MakeSelectBySearchCriteriaIDYearAndMonth(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0)
 
-	want := mockTweetsDTOs
-	got, err := selectBySearchCriteriaIDYearAndMonth(context.Background(), 1, 2025, 0, 10, "token")
+	got, err := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Limit: 10}, "token")
 
 	assert.Nil(t, err)
-	assert.Equal(t, want, got)
+	assert.Equal(t, parents.MockConversation(), got.Tweets[0].Conversation)
+	assert.Nil(t, got.Tweets[1].Conversation)
 	mockPostgresConnection.AssertExpectations(t)
-	mockPgxRows.AssertExpectations(t)
 }
 
-func TestSelectBySearchCriteriaIDYearAndMonth_successWithYearZeroAndMonthZero(t *testing.T) {
+func TestSelectBySearchCriteriaID_failsWhenSelectConversationsThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPgxRows := new(database.MockPgxRows)
 	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
-	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
-	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
+	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](tweets.MockCustomTweetDTOs(), nil)
 	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(nil, errors.New("failed to select conversations"))
 
-	selectBySearchCriteriaIDYearAndMonth := tweets.MakeSelectBySearchCriteriaIDYearAndMonth(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0)
 
-	want := mockTweetsDTOs
-	got, err := selectBySearchCriteriaIDYearAndMonth(context.Background(), 1, 0, 0, 10, "token")
+	want := tweets.FailedToRetrieveTweetsConversations
+	_, got := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Limit: 10}, "token")
 
-	assert.Nil(t, err)
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
-	mockPgxRows.AssertExpectations(t)
 }
 
-func TestSelectBySearchCriteriaIDYearAndMonth_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
+func TestSelectBySearchCriteriaID_failsWhenSelectUserIDByTokenThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPgxRows := new(database.MockPgxRows)
-	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
 	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
 	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
 	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, errors.New("failed to select user id by token"))
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 
-	selectBySearchCriteriaIDYearAndMonth := tweets.MakeSelectBySearchCriteriaIDYearAndMonth(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0)
 
 	want := tweets.FailedToRetrieveUserID
-	_, got := selectBySearchCriteriaIDYearAndMonth(context.Background(), 1, 2025, 04, 10, "token")
+	_, got := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Year: 2025, Month: 4, Limit: 10}, "token")
 
 	assert.Equal(t, want, got)
 	mockPgxRows.AssertExpectations(t)
 }
 
-func TestSelectBySearchCriteriaIDYearAndMonth_failsWhenSelectOperationThrowsError(t *testing.T) {
+func TestSelectBySearchCriteriaID_failsWhenSelectOperationThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPgxRows := new(database.MockPgxRows)
-	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select by search criteria id year and month"))
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select by search criteria id"))
 	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
 	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
 	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 
-	selectBySearchCriteriaIDYearAndMonth := tweets.MakeSelectBySearchCriteriaIDYearAndMonth(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0)
 
-	want := tweets.FailedToRetrieveUserUncategorizedTweets
-	_, got := selectBySearchCriteriaIDYearAndMonth(context.Background(), 1, 2025, 04, 10, "token")
+	want := tweets.FailedToRetrieveCriteriaTweets
+	_, got := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Year: 2025, Month: 4, Limit: 10}, "token")
 
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
 	mockPgxRows.AssertExpectations(t)
 }
 
-func TestSelectBySearchCriteriaIDYearAndMonth_failsWhenCollectRowsThrowsError(t *testing.T) {
+func TestSelectBySearchCriteriaID_failsWhenCollectRowsThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPgxRows := new(database.MockPgxRows)
 	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
 	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
 	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, errors.New("failed to collect rows"))
 	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
 
-	selectBySearchCriteriaIDYearAndMonth := tweets.MakeSelectBySearchCriteriaIDYearAndMonth(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken)
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0)
 
-	want := tweets.FailedToExecuteCollectRowsInSelectUserUncategorizedTweets
-	_, got := selectBySearchCriteriaIDYearAndMonth(context.Background(), 1, 2025, 04, 10, "token")
+	want := tweets.FailedToExecuteCollectRowsInSelectCriteriaTweets
+	_, got := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Year: 2025, Month: 4, Limit: 10}, "token")
 
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
@@ -167,3 +211,156 @@ func TestSelectByID_failsWhenSelectOperationFails(t *testing.T) {
 		assert.Equal(t, want, got)
 	}
 }
+
+func TestSelectByIDs_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockTweetsDAOs := []tweets.DAO{tweets.MockTweetDAO()}
+	mockCollectRows := database.MockCollectRows[tweets.DAO](mockTweetsDAOs, nil)
+
+	selectByIDs := tweets.MakeSelectByIDs(mockPostgresConnection, mockCollectRows)
+
+	want := mockTweetsDAOs
+	got, err := selectByIDs(context.Background(), []int{1, 2})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRows.AssertExpectations(t)
+}
+
+func TestSelectByIDs_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select tweets by ids"))
+	mockCollectRows := database.MockCollectRows[tweets.DAO](nil, nil)
+
+	selectByIDs := tweets.MakeSelectByIDs(mockPostgresConnection, mockCollectRows)
+
+	want := tweets.FailedToExecuteSelectTweetsByIDs
+	_, got := selectByIDs(context.Background(), []int{1, 2})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectByIDs_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[tweets.DAO](nil, errors.New("failed to collect rows"))
+
+	selectByIDs := tweets.MakeSelectByIDs(mockPostgresConnection, mockCollectRows)
+
+	want := tweets.FailedToExecuteCollectRowsInSelectTweetsByIDs
+	_, got := selectByIDs(context.Background(), []int{1, 2})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectBySearchCriteriaID_successMixingGoldTweets(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil).Twice()
+	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
+	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0.2)
+
+	want := append(slices.Clone(mockTweetsDTOs), mockTweetsDTOs...)
+	got, err := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Year: 2025, Month: 4, Limit: 10}, "token")
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, want, got.Tweets)
+	assert.Nil(t, got.NextCursor)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRows.AssertExpectations(t)
+}
+
+func TestSelectBySearchCriteriaID_successContinuingTheGoldTweetsAfterTheCursor(t *testing.T) {
+	isGoldQuery := func(query string) bool { return strings.Contains(query, "INNER JOIN gold_tweets AS g") }
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool { return isGoldQuery(query) && strings.Contains(query, "t.id > $3") }), []any{1, 1, 40, 1}).Return(mockPgxRows, nil).Once()
+	mockPostgresConnection.On("Query", mock.Anything, mock.MatchedBy(func(query string) bool { return !isGoldQuery(query) }), mock.Anything).Return(mockPgxRows, nil).Once()
+	mockGoldTweet := tweets.MockCustomTweetDTO()
+	mockGoldTweet.ID = 50
+	mockTweetsDTOs := append(tweets.MockCustomTweetDTOs(), tweets.MockCustomTweetDTO())
+	collected := [][]tweets.CustomTweetDTO{{mockGoldTweet}, mockTweetsDTOs}
+	mockCollectRows := func(rows pgx.Rows) ([]tweets.CustomTweetDTO, error) {
+		next := collected[0]
+		collected = collected[1:]
+		return next, nil
+	}
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0.2)
+
+	want := append(slices.Clone(mockTweetsDTOs[:2]), mockGoldTweet)
+	wantCursor := tweets.Cursor{PostedAt: mockTweetsDTOs[1].PostedAt, ID: mockTweetsDTOs[1].ID, GoldID: mockGoldTweet.ID}.Encode()
+	got, err := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Cursor: &tweets.Cursor{ID: 1, GoldID: 40}, Limit: 3}, "token")
+
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, want, got.Tweets)
+	assert.Equal(t, &wantCursor, got.NextCursor)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectBySearchCriteriaID_successWithoutGoldTweetsWhenTheCategorizedTweetsAreRequested(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil).Once()
+	mockTweetsDTOs := tweets.MockCustomTweetDTOs()
+	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](mockTweetsDTOs, nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0.2)
+
+	want := tweets.PageDTO{Tweets: mockTweetsDTOs}
+	got, err := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Categorization: tweets.CategorizationCategorized, Limit: 10}, "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRows.AssertExpectations(t)
+}
+
+func TestSelectBySearchCriteriaID_failsWhenSelectGoldTweetsOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to select gold tweets"))
+	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](tweets.MockCustomTweetDTOs(), nil)
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0.2)
+
+	want := tweets.FailedToRetrieveUserUncategorizedGoldTweets
+	_, got := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Year: 2025, Month: 4, Limit: 10}, "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectBySearchCriteriaID_failsWhenCollectGoldTweetsRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[tweets.CustomTweetDTO](nil, errors.New("failed to collect rows"))
+	mockSelectUserIDByToken := session.MockSelectUserIDByToken(1, nil)
+	mockSelectConversations := parents.MockSelectConversations(map[string][]parents.DAO{}, nil)
+
+	selectBySearchCriteriaID := tweets.MakeSelectBySearchCriteriaID(mockPostgresConnection, mockCollectRows, mockSelectUserIDByToken, mockSelectConversations, 0.2)
+
+	want := tweets.FailedToExecuteCollectRowsInSelectUserUncategorizedGoldTweets
+	_, got := selectBySearchCriteriaID(context.Background(), 1, tweets.Filters{Year: 2025, Month: 4, Limit: 10}, "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
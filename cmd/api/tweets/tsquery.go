@@ -0,0 +1,124 @@
+package tweets
+
+import (
+	"strings"
+	"unicode"
+)
+
+// searchTerm represents a word, or a group of words, of a search query
+type searchTerm struct {
+	words   []string
+	negated bool
+	prefix  bool
+	or      bool
+}
+
+// toTSQuery translates a search query into the tsquery syntax expected by the Postgres to_tsquery function.
+// The search query supports:
+//   - Words, which must all be present: hate speech
+//   - Phrases, between double quotes: "hate speech"
+//   - Prefixes, ending with an asterisk: discrimin*
+//   - Alternatives, separated by OR: hate OR violence
+//   - Exclusions, starting with a hyphen: hate -love, -"hate speech"
+//
+// The punctuation of the words is discarded, so the result never contains user provided tsquery operators
+func toTSQuery(search string) (string, error) {
+	terms := parseSearchTerms(search)
+
+	groups := make([][]string, 0)
+	current := make([]string, 0)
+	for _, term := range terms {
+		if term.or {
+			if len(current) > 0 {
+				groups = append(groups, current)
+				current = make([]string, 0)
+			}
+			continue
+		}
+
+		lexemes := make([]string, len(term.words))
+		for i, word := range term.words {
+			lexemes[i] = "'" + word + "'"
+		}
+		if term.prefix {
+			lexemes[len(lexemes)-1] += ":*"
+		}
+
+		expression := strings.Join(lexemes, " <-> ")
+		if term.negated {
+			if len(lexemes) > 1 {
+				expression = "(" + expression + ")"
+			}
+			expression = "!" + expression
+		}
+
+		current = append(current, expression)
+	}
+
+	if len(current) > 0 {
+		groups = append(groups, current)
+	}
+
+	if len(groups) == 0 {
+		return "", InvalidSearchQuery
+	}
+
+	ors := make([]string, len(groups))
+	for i, group := range groups {
+		ors[i] = strings.Join(group, " & ")
+	}
+
+	return strings.Join(ors, " | "), nil
+}
+
+// parseSearchTerms splits the search query into terms, discarding the ones without words
+func parseSearchTerms(search string) []searchTerm {
+	terms := make([]searchTerm, 0)
+
+	runes := []rune(search)
+	for i := 0; i < len(runes); {
+		if unicode.IsSpace(runes[i]) {
+			i++
+			continue
+		}
+
+		var term searchTerm
+		if runes[i] == '-' {
+			term.negated = true
+			i++
+		}
+
+		var raw string
+		if i < len(runes) && runes[i] == '"' {
+			end := i + 1
+			for end < len(runes) && runes[end] != '"' {
+				end++
+			}
+			raw = string(runes[i+1 : min(end, len(runes))])
+			i = end + 1
+		} else {
+			end := i
+			for end < len(runes) && !unicode.IsSpace(runes[end]) && runes[end] != '"' {
+				end++
+			}
+			raw = string(runes[i:end])
+			i = end
+
+			if raw == "OR" && !term.negated {
+				terms = append(terms, searchTerm{or: true})
+				continue
+			}
+
+			term.prefix = strings.HasSuffix(raw, "*")
+		}
+
+		term.words = strings.FieldsFunc(strings.ToLower(raw), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		})
+		if len(term.words) > 0 {
+			terms = append(terms, term)
+		}
+	}
+
+	return terms
+}
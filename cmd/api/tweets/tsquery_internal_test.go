@@ -0,0 +1,52 @@
+package tweets
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestToTSQuery_success(t *testing.T) {
+	tests := []struct {
+		search string
+		want   string
+	}{
+		{search: "hate", want: "'hate'"},
+		{search: "hate speech", want: "'hate' & 'speech'"},
+		{search: `"hate speech"`, want: "'hate' <-> 'speech'"},
+		{search: "discrimin*", want: "'discrimin':*"},
+		{search: `"hate spee*"`, want: "'hate' <-> 'spee'"},
+		{search: "hate OR violence", want: "'hate' | 'violence'"},
+		{search: "hate speech OR violence -love", want: "'hate' & 'speech' | 'violence' & !'love'"},
+		{search: `-"hate speech" bullying`, want: "!('hate' <-> 'speech') & 'bullying'"},
+		{search: "e-mail", want: "'e' <-> 'mail'"},
+		{search: "odio'); DROP TABLE tweets; --", want: "'odio' & 'drop' & 'table' & 'tweets'"},
+		{search: "OR hate OR", want: "'hate'"},
+		{search: `"unterminated phrase`, want: "'unterminated' <-> 'phrase'"},
+		{search: "añoranza ÑANDÚ", want: "'añoranza' & 'ñandú'"},
+	}
+
+	for _, tt := range tests {
+		got, err := toTSQuery(tt.search)
+
+		assert.Nil(t, err)
+		assert.Equal(t, tt.want, got, tt.search)
+	}
+}
+
+func TestToTSQuery_failsWhenTheSearchHasNoWords(t *testing.T) {
+	tests := []struct {
+		search string
+	}{
+		{search: ""},
+		{search: "   "},
+		{search: `"" - * OR`},
+	}
+
+	for _, tt := range tests {
+		want := InvalidSearchQuery
+		_, got := toTSQuery(tt.search)
+
+		assert.Equal(t, want, got)
+	}
+}
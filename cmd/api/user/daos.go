@@ -2,10 +2,17 @@ package user
 
 import "time"
 
-// DAO represents a user
+// DAO represents a user. DisabledAt is nil unless an admin disabled the user
 type DAO struct {
-	ID           int       `json:"id"`
-	Username     string    `json:"username"`
-	PasswordHash string    `json:"password_hash"`
-	CreatedAt    time.Time `json:"created_at"`
+	ID           int        `json:"id"`
+	Username     string     `json:"username"`
+	PasswordHash string     `json:"password_hash"`
+	CreatedAt    time.Time  `json:"created_at"`
+	Role         string     `json:"role"`
+	DisabledAt   *time.Time `json:"disabled_at"`
 }
+
+const (
+	AnnotatorRole string = "ANNOTATOR"
+	AdminRole     string = "ADMIN"
+)
@@ -6,5 +6,9 @@ var (
 	FailedToInsertUser                  = errors.New("failed to insert user")
 	FailedToRetrieveIfUserAlreadyExists = errors.New("failed to retrieve if user already exists")
 	NoUserFoundForTheGivenUsername      = errors.New("no user found for the given username")
+	NoUserFoundForTheGivenID            = errors.New("no user found for the given id")
 	FailedExecuteQueryToRetrieveUser    = errors.New("failed to execute query to retrieve user")
+	FailedToUpdateUserPassword          = errors.New("failed to update user password")
+	FailedToUpdateUsername              = errors.New("failed to update username")
+	FailedToUpdateUserDisabled          = errors.New("failed to update if the user is disabled")
 )
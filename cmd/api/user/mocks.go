@@ -19,6 +19,13 @@ func MockSelectByUsername(userDAO DAO, err error) SelectByUsername {
 	}
 }
 
+// MockSelectByID mocks SelectByID function
+func MockSelectByID(userDAO DAO, err error) SelectByID {
+	return func(ctx context.Context, id int) (DAO, error) {
+		return userDAO, err
+	}
+}
+
 // MockInsert mocks Insert function
 func MockInsert(err error) Insert {
 	return func(ctx context.Context, user DTO) error {
@@ -26,6 +33,27 @@ func MockInsert(err error) Insert {
 	}
 }
 
+// MockUpdatePassword mocks UpdatePassword function
+func MockUpdatePassword(err error) UpdatePassword {
+	return func(ctx context.Context, userID int, passwordHash string) error {
+		return err
+	}
+}
+
+// MockUpdateUsername mocks UpdateUsername function
+func MockUpdateUsername(err error) UpdateUsername {
+	return func(ctx context.Context, userID int, username string) error {
+		return err
+	}
+}
+
+// MockUpdateDisabled mocks UpdateDisabled function
+func MockUpdateDisabled(err error) UpdateDisabled {
+	return func(ctx context.Context, userID int, disabled bool) error {
+		return err
+	}
+}
+
 // MockDTO mocks user DTO
 func MockDTO() DTO {
 	return DTO{
@@ -41,6 +69,7 @@ func MockDAO() DAO {
 		Username:     "username",
 		PasswordHash: "$2b$12$AEscv2yxile/9dNaaRbIPe2x.9ousol85rtBCPu83cS5F9gXPBZQK\n",
 		CreatedAt:    time.Date(2006, time.January, 1, 0, 0, 0, 0, time.Local),
+		Role:         AnnotatorRole,
 	}
 }
 
@@ -51,5 +80,7 @@ func MockScanUserDAOValues(dao DAO) []any {
 		dao.Username,
 		dao.PasswordHash,
 		dao.CreatedAt,
+		dao.Role,
+		dao.DisabledAt,
 	}
 }
@@ -0,0 +1,12 @@
+package password
+
+import "time"
+
+// ResetTokenDAO represents a single-use password reset token issued by an admin. The Token is never stored, only its
+// hash
+type ResetTokenDAO struct {
+	UserID    int       `json:"user_id"`
+	Token     string    `json:"token"`
+	CreatedBy int       `json:"created_by"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
@@ -0,0 +1,15 @@
+package password
+
+import "errors"
+
+var (
+	PasswordTooShort                        = errors.New("the password is shorter than the minimum length of the password policy")
+	PasswordTooLong                         = errors.New("the password is longer than 72 bytes")
+	PasswordTooWeak                         = errors.New("the password doesn't combine enough character classes: lowercase and uppercase letters, digits and symbols")
+	PasswordEqualToUsername                 = errors.New("the password can't be the username")
+	FailedToCreateResetToken                = errors.New("failed to create password reset token")
+	FailedToInsertResetToken                = errors.New("failed to insert password reset token")
+	NoValidResetTokenFound                  = errors.New("no valid password reset token found, it may be expired or already used")
+	FailedToExecuteSelectUserIDByResetToken = errors.New("failed to execute select user id by password reset token")
+	FailedToResetPasswordWithToken          = errors.New("failed to reset password with token")
+)
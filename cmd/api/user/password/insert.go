@@ -0,0 +1,36 @@
+package password
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// InsertResetToken inserts a password reset token into the 'users_password_reset_tokens' table, deleting the previous
+// tokens of the user that weren't used. Only the hash of the token is stored
+type InsertResetToken func(ctx context.Context, resetToken ResetTokenDAO) error
+
+// MakeInsertResetToken creates a new InsertResetToken
+func MakeInsertResetToken(db database.Connection) InsertResetToken {
+	const query string = `
+		WITH deleted AS (
+			DELETE FROM users_password_reset_tokens
+			WHERE user_id = $1
+			  AND used_at IS NULL
+		)
+		INSERT INTO users_password_reset_tokens(user_id, token_hash, created_by, expires_at)
+		VALUES ($1, $2, $3, $4);
+	`
+
+	return func(ctx context.Context, resetToken ResetTokenDAO) error {
+		_, err := db.Exec(ctx, query, resetToken.UserID, session.HashToken(resetToken.Token), resetToken.CreatedBy, resetToken.ExpiresAt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertResetToken
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,41 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user/password"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+)
+
+func TestInsertResetToken_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockResetTokenDAO := password.MockResetTokenDAO()
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{mockResetTokenDAO.UserID, session.HashToken(mockResetTokenDAO.Token), mockResetTokenDAO.CreatedBy, mockResetTokenDAO.ExpiresAt}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	insertResetToken := password.MakeInsertResetToken(mockPostgresConnection)
+
+	got := insertResetToken(context.Background(), mockResetTokenDAO)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertResetToken_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert password reset token"))
+
+	insertResetToken := password.MakeInsertResetToken(mockPostgresConnection)
+
+	want := password.FailedToInsertResetToken
+	got := insertResetToken(context.Background(), password.MockResetTokenDAO())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,59 @@
+package password
+
+import (
+	"context"
+	"time"
+)
+
+// MockValidate mocks a Validate function
+func MockValidate(err error) Validate {
+	return func(username string, password string) error {
+		return err
+	}
+}
+
+// MockCreateResetToken mocks a CreateResetToken function
+func MockCreateResetToken(token string, expiresAt time.Time, err error) CreateResetToken {
+	return func(ctx context.Context, userID int, createdBy int) (string, time.Time, error) {
+		return token, expiresAt, err
+	}
+}
+
+// MockInsertResetToken mocks an InsertResetToken function
+func MockInsertResetToken(err error) InsertResetToken {
+	return func(ctx context.Context, resetToken ResetTokenDAO) error {
+		return err
+	}
+}
+
+// MockSelectUserIDByResetToken mocks a SelectUserIDByResetToken function
+func MockSelectUserIDByResetToken(userID int, err error) SelectUserIDByResetToken {
+	return func(ctx context.Context, token string) (int, error) {
+		return userID, err
+	}
+}
+
+// MockResetWithToken mocks a ResetWithToken function
+func MockResetWithToken(userID int, err error) ResetWithToken {
+	return func(ctx context.Context, token string, passwordHash string) (int, error) {
+		return userID, err
+	}
+}
+
+// MockPolicy mocks a Policy
+func MockPolicy() Policy {
+	return Policy{
+		MinLength:           8,
+		MinCharacterClasses: 2,
+	}
+}
+
+// MockResetTokenDAO mocks a ResetTokenDAO
+func MockResetTokenDAO() ResetTokenDAO {
+	return ResetTokenDAO{
+		UserID:    1,
+		Token:     "reset token",
+		CreatedBy: 2,
+		ExpiresAt: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC),
+	}
+}
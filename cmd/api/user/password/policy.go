@@ -0,0 +1,74 @@
+package password
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Validate verifies that the password of the given username meets the password policy
+type Validate func(username string, password string) error
+
+// Policy configures the requirements of the passwords. The character classes are the lowercase letters, the uppercase
+// letters, the digits and the rest of the characters
+type Policy struct {
+	MinLength           int
+	MinCharacterClasses int
+}
+
+// maxLength is the maximum length of a password, in bytes, since bcrypt doesn't accept longer ones
+const maxLength int = 72
+
+// policyViolations are the errors returned by Validate when the password doesn't meet the policy
+var policyViolations = []error{PasswordTooShort, PasswordTooLong, PasswordTooWeak, PasswordEqualToUsername}
+
+// MakeValidate creates a new Validate
+func MakeValidate(policy Policy) Validate {
+	return func(username string, password string) error {
+		if utf8.RuneCountInString(password) < policy.MinLength {
+			return PasswordTooShort
+		}
+
+		if len(password) > maxLength {
+			return PasswordTooLong
+		}
+
+		if characterClasses(password) < policy.MinCharacterClasses {
+			return PasswordTooWeak
+		}
+
+		if strings.EqualFold(password, username) {
+			return PasswordEqualToUsername
+		}
+
+		return nil
+	}
+}
+
+// IsPolicyViolation reports whether the error was returned because a password doesn't meet the policy
+func IsPolicyViolation(err error) bool {
+	return slices.ContainsFunc(policyViolations, func(violation error) bool {
+		return errors.Is(err, violation)
+	})
+}
+
+// characterClasses returns the amount of different character classes used in the password
+func characterClasses(password string) int {
+	var lower, upper, digit, other int
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = 1
+		case unicode.IsUpper(r):
+			upper = 1
+		case unicode.IsDigit(r):
+			digit = 1
+		default:
+			other = 1
+		}
+	}
+
+	return lower + upper + digit + other
+}
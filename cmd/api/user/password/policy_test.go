@@ -0,0 +1,75 @@
+package password_test
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/password"
+)
+
+func TestValidate_success(t *testing.T) {
+	tests := []struct {
+		password string
+	}{
+		{password: "password1"},
+		{password: "Password"},
+		{password: "contraseña!"},
+		{password: strings.Repeat("a", 71) + "1"},
+	}
+
+	for _, tt := range tests {
+		validate := password.MakeValidate(password.MockPolicy())
+
+		got := validate("username", tt.password)
+
+		assert.Nil(t, got)
+	}
+}
+
+func TestValidate_failsWhenThePasswordDoesNotMeetThePolicy(t *testing.T) {
+	tests := []struct {
+		username string
+		password string
+		expected error
+	}{
+		{username: "username", password: "pass1", expected: password.PasswordTooShort},
+		{username: "username", password: strings.Repeat("a", 72) + "1", expected: password.PasswordTooLong},
+		{username: "username", password: "password", expected: password.PasswordTooWeak},
+		{username: "username", password: "12345678", expected: password.PasswordTooWeak},
+		{username: "Username1", password: "username1", expected: password.PasswordEqualToUsername},
+	}
+
+	for _, tt := range tests {
+		validate := password.MakeValidate(password.MockPolicy())
+
+		want := tt.expected
+		got := validate(tt.username, tt.password)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestIsPolicyViolation_success(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected bool
+	}{
+		{err: password.PasswordTooShort, expected: true},
+		{err: password.PasswordTooLong, expected: true},
+		{err: password.PasswordTooWeak, expected: true},
+		{err: password.PasswordEqualToUsername, expected: true},
+		{err: password.NoValidResetTokenFound, expected: false},
+		{err: errors.New("another error"), expected: false},
+		{err: nil, expected: false},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got := password.IsPolicyViolation(tt.err)
+
+		assert.Equal(t, want, got)
+	}
+}
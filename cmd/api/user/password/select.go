@@ -0,0 +1,41 @@
+package password
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// SelectUserIDByResetToken retrieves the ID of the user of the given password reset token, as long as the token wasn't
+// used and it hasn't expired
+type SelectUserIDByResetToken func(ctx context.Context, token string) (int, error)
+
+// MakeSelectUserIDByResetToken creates a new SelectUserIDByResetToken
+func MakeSelectUserIDByResetToken(db database.Connection) SelectUserIDByResetToken {
+	const query string = `
+		SELECT user_id
+		FROM users_password_reset_tokens
+		WHERE token_hash = $1
+		  AND used_at IS NULL
+		  AND expires_at > NOW();
+	`
+
+	return func(ctx context.Context, token string) (int, error) {
+		var userID int
+		err := db.QueryRow(ctx, query, session.HashToken(token)).Scan(&userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return 0, NoValidResetTokenFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToExecuteSelectUserIDByResetToken
+		}
+
+		return userID, nil
+	}
+}
@@ -0,0 +1,57 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user/password"
+	"ahbcc/internal/database"
+)
+
+func TestSelectUserIDByResetToken_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{1}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectUserIDByResetToken := password.MakeSelectUserIDByResetToken(mockPostgresConnection)
+
+	want := 1
+	got, err := selectUserIDByResetToken(context.Background(), "reset token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectUserIDByResetToken_failsWhenSelectOperationThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: password.NoValidResetTokenFound},
+		{err: errors.New("failed to execute select operation"), expected: password.FailedToExecuteSelectUserIDByResetToken},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		selectUserIDByResetToken := password.MakeSelectUserIDByResetToken(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := selectUserIDByResetToken(context.Background(), "reset token")
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRow.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,44 @@
+package password
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+// CreateResetToken creates a new single-use password reset token for the given user, issued by the given admin. The
+// previous tokens of the user that weren't used stop being valid. It returns the token with its expiration time
+type CreateResetToken func(ctx context.Context, userID int, createdBy int) (string, time.Time, error)
+
+// resetTokenSize is the amount of random bytes of each reset token
+const resetTokenSize int = 32
+
+// MakeCreateResetToken creates a new CreateResetToken
+func MakeCreateResetToken(insertResetToken InsertResetToken, duration time.Duration) CreateResetToken {
+	return func(ctx context.Context, userID int, createdBy int) (string, time.Time, error) {
+		random := make([]byte, resetTokenSize)
+		_, err := rand.Read(random)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCreateResetToken
+		}
+
+		resetToken := ResetTokenDAO{
+			UserID:    userID,
+			Token:     base64.RawURLEncoding.EncodeToString(random),
+			CreatedBy: createdBy,
+			ExpiresAt: time.Now().Add(duration),
+		}
+
+		err = insertResetToken(ctx, resetToken)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCreateResetToken
+		}
+
+		return resetToken.Token, resetToken.ExpiresAt, nil
+	}
+}
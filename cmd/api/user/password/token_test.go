@@ -0,0 +1,51 @@
+package password_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/password"
+)
+
+func TestCreateResetToken_success(t *testing.T) {
+	var inserted password.ResetTokenDAO
+	insertResetToken := func(ctx context.Context, resetToken password.ResetTokenDAO) error {
+		inserted = resetToken
+		return nil
+	}
+
+	createResetToken := password.MakeCreateResetToken(insertResetToken, time.Hour)
+
+	before := time.Now()
+	token, expiresAt, err := createResetToken(context.Background(), 1, 2)
+
+	assert.Nil(t, err)
+	assert.NotEmpty(t, token)
+	assert.Equal(t, token, inserted.Token)
+	assert.Equal(t, 1, inserted.UserID)
+	assert.Equal(t, 2, inserted.CreatedBy)
+	assert.Equal(t, expiresAt, inserted.ExpiresAt)
+	assert.WithinDuration(t, before.Add(time.Hour), expiresAt, time.Minute)
+}
+
+func TestCreateResetToken_successCreatesDifferentTokens(t *testing.T) {
+	createResetToken := password.MakeCreateResetToken(password.MockInsertResetToken(nil), time.Hour)
+
+	first, _, _ := createResetToken(context.Background(), 1, 2)
+	second, _, _ := createResetToken(context.Background(), 1, 2)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestCreateResetToken_failsWhenInsertResetTokenThrowsError(t *testing.T) {
+	createResetToken := password.MakeCreateResetToken(password.MockInsertResetToken(errors.New("failed to insert password reset token")), time.Hour)
+
+	want := password.FailedToCreateResetToken
+	_, _, got := createResetToken(context.Background(), 1, 2)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,50 @@
+package password
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// ResetWithToken marks the given password reset token as used and replaces the password hash of its user by the given
+// one, in a single statement, so a token can't be used twice. The token must not have expired. It returns the ID of the
+// user
+type ResetWithToken func(ctx context.Context, token string, passwordHash string) (int, error)
+
+// MakeResetWithToken creates a new ResetWithToken
+func MakeResetWithToken(db database.Connection) ResetWithToken {
+	const query string = `
+		WITH used AS (
+			UPDATE users_password_reset_tokens
+			SET used_at = NOW()
+			WHERE token_hash = $1
+			  AND used_at IS NULL
+			  AND expires_at > NOW()
+			RETURNING user_id
+		)
+		UPDATE users AS u
+		SET password_hash = $2, password_changed_at = NOW()
+		FROM used
+		WHERE u.id = used.user_id
+		RETURNING u.id;
+	`
+
+	return func(ctx context.Context, token string, passwordHash string) (int, error) {
+		var userID int
+		err := db.QueryRow(ctx, query, session.HashToken(token), passwordHash).Scan(&userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return 0, NoValidResetTokenFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToResetPasswordWithToken
+		}
+
+		return userID, nil
+	}
+}
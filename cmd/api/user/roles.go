@@ -0,0 +1,19 @@
+package user
+
+import (
+	"slices"
+	"strings"
+)
+
+// ParseRoles returns the space or comma separated roles, uppercased as they are stored, without duplicates
+func ParseRoles(roles string) []string {
+	var parsed []string
+	for _, role := range strings.FieldsFunc(roles, func(r rune) bool { return r == ' ' || r == ',' }) {
+		role = strings.ToUpper(role)
+		if !slices.Contains(parsed, role) {
+			parsed = append(parsed, role)
+		}
+	}
+
+	return parsed
+}
@@ -0,0 +1,26 @@
+package user_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user"
+)
+
+func TestParseRoles_success(t *testing.T) {
+	tests := []struct {
+		roles    string
+		expected []string
+	}{
+		{roles: "", expected: nil},
+		{roles: "ADMIN", expected: []string{user.AdminRole}},
+		{roles: "admin, annotator,ADMIN", expected: []string{user.AdminRole, user.AnnotatorRole}},
+	}
+
+	for _, tt := range tests {
+		got := user.ParseRoles(tt.roles)
+
+		assert.Equal(t, tt.expected, got)
+	}
+}
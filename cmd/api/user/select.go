@@ -16,6 +16,9 @@ type (
 
 	// SelectByUsername retrieves a user by its username
 	SelectByUsername func(ctx context.Context, username string) (DAO, error)
+
+	// SelectByID retrieves a user by its ID
+	SelectByID func(ctx context.Context, id int) (DAO, error)
 )
 
 // MakeExists creates a new Exists
@@ -44,7 +47,7 @@ func MakeExists(db database.Connection) Exists {
 // MakeSelectByUsername creates a new SelectByUsername
 func MakeSelectByUsername(db database.Connection) SelectByUsername {
 	const query string = `
-		SELECT id, username, password_hash, created_at
+		SELECT id, username, password_hash, created_at, role, disabled_at
 		FROM users
 		WHERE username = $1;
 	`
@@ -56,6 +59,8 @@ func MakeSelectByUsername(db database.Connection) SelectByUsername {
 			&user.Username,
 			&user.PasswordHash,
 			&user.CreatedAt,
+			&user.Role,
+			&user.DisabledAt,
 		)
 		if errors.Is(err, pgx.ErrNoRows) {
 			log.Error(ctx, err.Error())
@@ -68,3 +73,33 @@ func MakeSelectByUsername(db database.Connection) SelectByUsername {
 		return user, nil
 	}
 }
+
+// MakeSelectByID creates a new SelectByID
+func MakeSelectByID(db database.Connection) SelectByID {
+	const query string = `
+		SELECT id, username, password_hash, created_at, role, disabled_at
+		FROM users
+		WHERE id = $1;
+	`
+
+	return func(ctx context.Context, id int) (DAO, error) {
+		var user DAO
+		err := db.QueryRow(ctx, query, id).Scan(
+			&user.ID,
+			&user.Username,
+			&user.PasswordHash,
+			&user.CreatedAt,
+			&user.Role,
+			&user.DisabledAt,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return DAO{}, NoUserFoundForTheGivenID
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return DAO{}, FailedExecuteQueryToRetrieveUser
+		}
+
+		return user, nil
+	}
+}
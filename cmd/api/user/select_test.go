@@ -89,3 +89,48 @@ func TestSelectByUsername_failsWhenSelectOperationFails(t *testing.T) {
 		mockPgxRow.AssertExpectations(t)
 	}
 }
+
+func TestSelectByID_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockUser := user.MockDAO()
+	mockScanUserDAOValues := user.MockScanUserDAOValues(mockUser)
+	database.MockScan(mockPgxRow, mockScanUserDAOValues, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	selectUserByID := user.MakeSelectByID(mockPostgresConnection)
+
+	want := mockUser
+	got, err := selectUserByID(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectByID_failsWhenSelectOperationFails(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: user.NoUserFoundForTheGivenID},
+		{err: errors.New("failed to execute select operation"), expected: user.FailedExecuteQueryToRetrieveUser},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		selectUserByID := user.MakeSelectByID(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := selectUserByID(context.Background(), 1)
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRow.AssertExpectations(t)
+	}
+}
@@ -2,9 +2,28 @@ package session
 
 import "time"
 
-// DAO represents a session DAO
-type DAO struct {
-	UserID    int       `json:"user_id"`
-	Token     string    `json:"token"`
-	ExpiresAt time.Time `json:"expires_at"`
-}
+type (
+	// DAO represents a session DAO. The Token is never stored, only its hash. KeyID is the ID of the key that signed
+	// the token
+	DAO struct {
+		UserID    int       `json:"user_id"`
+		Token     string    `json:"token"`
+		KeyID     string    `json:"key_id"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// ActiveDAO represents a session that hasn't expired yet
+	ActiveDAO struct {
+		ID        int       `json:"id"`
+		TokenHash string    `json:"token_hash"`
+		CreatedAt time.Time `json:"created_at"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+
+	// KeyUsageDAO represents the amount of sessions, that haven't expired yet, whose tokens were signed with a key
+	KeyUsageDAO struct {
+		KeyID         string    `json:"key_id"`
+		LiveSessions  int       `json:"live_sessions"`
+		LastExpiresAt time.Time `json:"last_expires_at"`
+	}
+)
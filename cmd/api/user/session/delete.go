@@ -2,34 +2,52 @@ package session
 
 import (
 	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
 
 	"ahbcc/internal/database"
 	"ahbcc/internal/log"
 )
 
 type (
-	// Delete deletes a session, seeking it by its token
-	Delete func(ctx context.Context, token string) error
+	// Delete deletes a session, seeking it by its token, and returns the ID of its user. It is -1 when there was no
+	// session for the token
+	Delete func(ctx context.Context, token string) (int, error)
 
 	// DeleteExpiredSessions deletes the expired sessions of a given user
 	DeleteExpiredSessions func(ctx context.Context, userID int) error
+
+	// DeleteByID deletes a session of the given user, seeking it by its ID
+	DeleteByID func(ctx context.Context, userID int, sessionID int) error
+
+	// DeleteOthers deletes all the sessions of the given user except the one of the given token, and returns the amount
+	// of sessions deleted
+	DeleteOthers func(ctx context.Context, userID int, token string) (int, error)
+
+	// DeleteAll deletes all the sessions of the given user, and returns the amount of sessions deleted
+	DeleteAll func(ctx context.Context, userID int) (int, error)
 )
 
 // MakeDelete creates a new Delete
 func MakeDelete(db database.Connection) Delete {
 	const query string = `
 		DELETE FROM users_sessions
-		WHERE token = $1
+		WHERE token_hash = $1
+		RETURNING user_id
 	`
 
-	return func(ctx context.Context, token string) error {
-		_, err := db.Exec(ctx, query, token)
-		if err != nil {
+	return func(ctx context.Context, token string) (int, error) {
+		var userID int
+		err := db.QueryRow(ctx, query, HashToken(token)).Scan(&userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			return -1, nil
+		} else if err != nil {
 			log.Error(ctx, err.Error())
-			return FailedToDeleteUserSession
+			return -1, FailedToDeleteUserSession
 		}
 
-		return nil
+		return userID, nil
 	}
 }
 
@@ -51,3 +69,64 @@ func MakeDeleteExpiredSessions(db database.Connection) DeleteExpiredSessions {
 		return nil
 	}
 }
+
+// MakeDeleteByID creates a new DeleteByID
+func MakeDeleteByID(db database.Connection) DeleteByID {
+	const query string = `
+		DELETE FROM users_sessions
+		WHERE id = $1
+		  AND user_id = $2
+	`
+
+	return func(ctx context.Context, userID int, sessionID int) error {
+		commandTag, err := db.Exec(ctx, query, sessionID, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDeleteUserSession
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			log.Error(ctx, NoSessionFoundForTheGivenID.Error())
+			return NoSessionFoundForTheGivenID
+		}
+
+		return nil
+	}
+}
+
+// MakeDeleteOthers creates a new DeleteOthers
+func MakeDeleteOthers(db database.Connection) DeleteOthers {
+	const query string = `
+		DELETE FROM users_sessions
+		WHERE user_id = $1
+		  AND token_hash <> $2
+	`
+
+	return func(ctx context.Context, userID int, token string) (int, error) {
+		commandTag, err := db.Exec(ctx, query, userID, HashToken(token))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToDeleteOtherUserSessions
+		}
+
+		return int(commandTag.RowsAffected()), nil
+	}
+}
+
+// MakeDeleteAll creates a new DeleteAll
+func MakeDeleteAll(db database.Connection) DeleteAll {
+	const query string = `
+		DELETE FROM users_sessions
+		WHERE user_id = $1
+	`
+
+	return func(ctx context.Context, userID int) (int, error) {
+		commandTag, err := db.Exec(ctx, query, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToDeleteAllUserSessions
+		}
+
+		return int(commandTag.RowsAffected()), nil
+	}
+}
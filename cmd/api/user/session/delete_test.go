@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -15,24 +16,46 @@ import (
 
 func TestDelete_success(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{1}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
 
 	deleteSession := session.MakeDelete(mockPostgresConnection)
 
-	got := deleteSession(context.Background(), "token")
+	got, err := deleteSession(context.Background(), "token")
 
-	assert.Nil(t, got)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestDelete_successWhenTheSessionDoesNotExist(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(pgx.ErrNoRows)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	deleteSession := session.MakeDelete(mockPostgresConnection)
+
+	got, err := deleteSession(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, -1, got)
 	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
 }
 
 func TestDelete_failsWhenDeleteOperationThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to delete user session"))
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(errors.New("failed to delete user session"))
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
 
 	deleteSession := session.MakeDelete(mockPostgresConnection)
 
 	want := session.FailedToDeleteUserSession
-	got := deleteSession(context.Background(), "token")
+	_, got := deleteSession(context.Background(), "token")
 
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
@@ -62,3 +85,93 @@ func TestDeleteExpiredSessions_failsWhenDeleteOperationThrowsError(t *testing.T)
 	assert.Equal(t, want, got)
 	mockPostgresConnection.AssertExpectations(t)
 }
+
+func TestDeleteByID_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("DELETE 1"), nil)
+
+	deleteByID := session.MakeDeleteByID(mockPostgresConnection)
+
+	got := deleteByID(context.Background(), 1, 2)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestDeleteByID_failsWhenDeleteOperationFails(t *testing.T) {
+	tests := []struct {
+		commandTag pgconn.CommandTag
+		err        error
+		expected   error
+	}{
+		{commandTag: pgconn.NewCommandTag("DELETE 0"), expected: session.NoSessionFoundForTheGivenID},
+		{err: errors.New("failed to delete user session"), expected: session.FailedToDeleteUserSession},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(tt.commandTag, tt.err)
+
+		deleteByID := session.MakeDeleteByID(mockPostgresConnection)
+
+		want := tt.expected
+		got := deleteByID(context.Background(), 1, 2)
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestDeleteOthers_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1, session.HashToken("token")}).Return(pgconn.NewCommandTag("DELETE 3"), nil)
+
+	deleteOthers := session.MakeDeleteOthers(mockPostgresConnection)
+
+	want := 3
+	got, err := deleteOthers(context.Background(), 1, "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestDeleteOthers_failsWhenDeleteOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to delete other user sessions"))
+
+	deleteOthers := session.MakeDeleteOthers(mockPostgresConnection)
+
+	want := session.FailedToDeleteOtherUserSessions
+	_, got := deleteOthers(context.Background(), 1, "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestDeleteAll_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("DELETE 2"), nil)
+
+	deleteAll := session.MakeDeleteAll(mockPostgresConnection)
+
+	want := 2
+	got, err := deleteAll(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestDeleteAll_failsWhenDeleteOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to delete all user sessions"))
+
+	deleteAll := session.MakeDeleteAll(mockPostgresConnection)
+
+	want := session.FailedToDeleteAllUserSessions
+	_, got := deleteAll(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -3,10 +3,29 @@ package session
 import "errors"
 
 var (
-	FailedToInsertUserSession            = errors.New("failed to insert user session")
-	FailedToCreatUserSessionToken        = errors.New("failed to create user session token")
-	FailedToDeleteUserSession            = errors.New("failed to delete user session")
-	FailedToDeleteExpiredSessions        = errors.New("failed to delete expired sessions")
-	NoUserIDFoundForTheGivenToken        = errors.New("no user id found for the given token")
-	FailedToExecuteQueryToRetrieveUserID = errors.New("failed to execute query to retrieve user id")
+	FailedToInsertUserSession                        = errors.New("failed to insert user session")
+	FailedToCreatUserSessionToken                    = errors.New("failed to create user session token")
+	FailedToRefreshUserSessionToken                  = errors.New("failed to refresh user session token")
+	FailedToUpdateUserSessionToken                   = errors.New("failed to update user session token")
+	FailedToDeleteUserSession                        = errors.New("failed to delete user session")
+	FailedToDeleteExpiredSessions                    = errors.New("failed to delete expired sessions")
+	FailedToDeleteOtherUserSessions                  = errors.New("failed to delete other user sessions")
+	FailedToDeleteAllUserSessions                    = errors.New("failed to delete all user sessions")
+	NoUserIDFoundForTheGivenToken                    = errors.New("no user id found for the given token")
+	NoSessionFoundForTheGivenToken                   = errors.New("no session found for the given token")
+	NoSessionFoundForTheGivenID                      = errors.New("no session found for the given id")
+	FailedToExecuteQueryToRetrieveUserID             = errors.New("failed to execute query to retrieve user id")
+	FailedToExecuteSelectActiveSessions              = errors.New("failed to execute select active sessions")
+	FailedToExecuteCollectRowsInSelectActiveSessions = errors.New("failed to execute collect rows in select active sessions")
+	MalformedSessionToken                            = errors.New("malformed session token")
+	InvalidSessionTokenSignature                     = errors.New("invalid session token signature")
+	ExpiredSessionToken                              = errors.New("expired session token")
+	UnknownSessionSigningKey                         = errors.New("unknown session signing key")
+	MalformedSessionSigningKey                       = errors.New("malformed session signing key, the format must be 'id:secret'")
+	DuplicatedSessionSigningKeyID                    = errors.New("duplicated session signing key id")
+	UnknownActiveSessionSigningKeyID                 = errors.New("the active session signing key id is not in the keyring")
+	MissingSessionSigningKey                         = errors.New("no session signing key is configured")
+	FailedToExecuteSelectKeysUsage                   = errors.New("failed to execute select keys usage")
+	FailedToExecuteCollectRowsInSelectKeysUsage      = errors.New("failed to execute collect rows in select keys usage")
+	UserDisabled                                     = errors.New("the user is disabled or doesn't exist")
 )
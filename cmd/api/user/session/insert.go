@@ -7,23 +7,32 @@ import (
 	"ahbcc/internal/log"
 )
 
-// Insert inserts a new session DAO into 'user_sessions' table
+// Insert inserts a new session DAO into 'user_sessions' table. Only the hash of its token is stored. The disabled users
+// can't have new sessions
 type Insert func(ctx context.Context, session DAO) error
 
 // MakeInsert creates a new Insert function
 func MakeInsert(db database.Connection) Insert {
 	const query string = `
-		INSERT INTO users_sessions(user_id, token, expires_at) 
-		VALUES ($1, $2, $3)
+		INSERT INTO users_sessions(user_id, token_hash, key_id, expires_at) 
+		SELECT id, $2, $3, $4
+		FROM users
+		WHERE id = $1
+		  AND disabled_at IS NULL;
 	`
 
 	return func(ctx context.Context, session DAO) error {
-		_, err := db.Exec(ctx, query, session.UserID, session.Token, session.ExpiresAt)
+		commandTag, err := db.Exec(ctx, query, session.UserID, HashToken(session.Token), session.KeyID, session.ExpiresAt)
 		if err != nil {
 			log.Error(ctx, err.Error())
 			return FailedToInsertUserSession
 		}
 
+		if commandTag.RowsAffected() == 0 {
+			log.Error(ctx, UserDisabled.Error())
+			return UserDisabled
+		}
+
 		return nil
 	}
 }
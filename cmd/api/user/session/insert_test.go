@@ -15,7 +15,7 @@ import (
 
 func TestInsert_success(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
-	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, nil)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
 	mockUserSessionDAO := session.MockUserSessionDAO()
 
 	insertUserSession := session.MakeInsert(mockPostgresConnection)
@@ -26,6 +26,20 @@ func TestInsert_success(t *testing.T) {
 	mockPostgresConnection.AssertExpectations(t)
 }
 
+func TestInsert_failsWhenTheUserIsDisabled(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("INSERT 0 0"), nil)
+	mockUserSessionDAO := session.MockUserSessionDAO()
+
+	insertUserSession := session.MakeInsert(mockPostgresConnection)
+
+	want := session.UserDisabled
+	got := insertUserSession(context.Background(), mockUserSessionDAO)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
 func TestInsert_failsWhenInsertOperationThrowsError(t *testing.T) {
 	mockPostgresConnection := new(database.MockPostgresConnection)
 	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert user session"))
@@ -0,0 +1,70 @@
+package session
+
+import (
+	"regexp"
+	"strings"
+)
+
+// LegacyKeyID is the ID of the key that verifies the tokens created before the ID of their signing key was added to
+// them. Those tokens were signed with the SESSION_SECRET_KEY
+const LegacyKeyID string = "legacy"
+
+// keyIDPattern restricts the key IDs to the characters that can't be confused with the separators of the tokens
+var keyIDPattern = regexp.MustCompile(`^[A-Za-z0-9_-]+$`)
+
+// Keyring contains the secret keys used to sign the session tokens, by their IDs. The new tokens are signed with the
+// active key, while the rest of the keys are retiring: the tokens signed with them are still verified until they
+// expire. Removing a key from the keyring invalidates all the tokens signed with it
+type Keyring struct {
+	ActiveKeyID string
+	Keys        map[string]string
+}
+
+// ParseKeyring creates a Keyring from a comma separated list of keys in the format 'id:secret' and the ID of the active
+// key. If the active key ID is empty, the first key of the list is the active one.
+// The legacySecretKey, if present, is added as a retiring key with the LegacyKeyID, or as the active one when the list
+// of keys is empty, so the tokens signed before the keyring existed are still verified
+func ParseKeyring(keys string, activeKeyID string, legacySecretKey string) (Keyring, error) {
+	keyring := Keyring{Keys: make(map[string]string)}
+
+	for _, key := range strings.Split(keys, ",") {
+		key = strings.TrimSpace(key)
+		if key == "" {
+			continue
+		}
+
+		id, secret, found := strings.Cut(key, ":")
+		if !found || !keyIDPattern.MatchString(id) || id == LegacyKeyID || secret == "" {
+			return Keyring{}, MalformedSessionSigningKey
+		}
+
+		if _, exists := keyring.Keys[id]; exists {
+			return Keyring{}, DuplicatedSessionSigningKeyID
+		}
+
+		if keyring.ActiveKeyID == "" {
+			keyring.ActiveKeyID = id
+		}
+		keyring.Keys[id] = secret
+	}
+
+	if legacySecretKey != "" {
+		keyring.Keys[LegacyKeyID] = legacySecretKey
+		if keyring.ActiveKeyID == "" {
+			keyring.ActiveKeyID = LegacyKeyID
+		}
+	}
+
+	if len(keyring.Keys) == 0 {
+		return Keyring{}, MissingSessionSigningKey
+	}
+
+	if activeKeyID != "" {
+		if _, exists := keyring.Keys[activeKeyID]; !exists {
+			return Keyring{}, UnknownActiveSessionSigningKeyID
+		}
+		keyring.ActiveKeyID = activeKeyID
+	}
+
+	return keyring, nil
+}
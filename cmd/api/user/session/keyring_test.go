@@ -0,0 +1,57 @@
+package session_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/session"
+)
+
+func TestParseKeyring_success(t *testing.T) {
+	tests := []struct {
+		keys            string
+		activeKeyID     string
+		legacySecretKey string
+		expected        session.Keyring
+	}{
+		{keys: "v2:secret v2, v1:secret:v1", expected: session.Keyring{ActiveKeyID: "v2", Keys: map[string]string{"v2": "secret v2", "v1": "secret:v1"}}},
+		{keys: "v1:secret v1,v2:secret v2", activeKeyID: "v2", expected: session.Keyring{ActiveKeyID: "v2", Keys: map[string]string{"v1": "secret v1", "v2": "secret v2"}}},
+		{keys: "v1:secret v1", legacySecretKey: "secret", expected: session.Keyring{ActiveKeyID: "v1", Keys: map[string]string{"v1": "secret v1", session.LegacyKeyID: "secret"}}},
+		{legacySecretKey: "secret", expected: session.Keyring{ActiveKeyID: session.LegacyKeyID, Keys: map[string]string{session.LegacyKeyID: "secret"}}},
+		{keys: "v1:secret v1", activeKeyID: session.LegacyKeyID, legacySecretKey: "secret", expected: session.Keyring{ActiveKeyID: session.LegacyKeyID, Keys: map[string]string{"v1": "secret v1", session.LegacyKeyID: "secret"}}},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got, err := session.ParseKeyring(tt.keys, tt.activeKeyID, tt.legacySecretKey)
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestParseKeyring_failsWhenTheConfigurationIsInvalid(t *testing.T) {
+	tests := []struct {
+		keys            string
+		activeKeyID     string
+		legacySecretKey string
+		expected        error
+	}{
+		{keys: "secret", expected: session.MalformedSessionSigningKey},
+		{keys: "v1:", expected: session.MalformedSessionSigningKey},
+		{keys: "v.1:secret", expected: session.MalformedSessionSigningKey},
+		{keys: "legacy:secret", expected: session.MalformedSessionSigningKey},
+		{keys: "v1:secret,v1:another secret", expected: session.DuplicatedSessionSigningKeyID},
+		{keys: "v1:secret", activeKeyID: "v2", expected: session.UnknownActiveSessionSigningKeyID},
+		{activeKeyID: session.LegacyKeyID, expected: session.MissingSessionSigningKey},
+		{expected: session.MissingSessionSigningKey},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		_, got := session.ParseKeyring(tt.keys, tt.activeKeyID, tt.legacySecretKey)
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -19,13 +19,34 @@ func MockCreateToken(token string, expiresAt time.Time, err error) CreateToken {
 	}
 }
 
-// MockDelete mocks a Delete function
-func MockDelete(err error) Delete {
-	return func(ctx context.Context, token string) error {
+// MockVerifyToken mocks a VerifyToken function
+func MockVerifyToken(userID int, err error) VerifyToken {
+	return func(ctx context.Context, token string) (int, error) {
+		return userID, err
+	}
+}
+
+// MockRefreshToken mocks a RefreshToken function
+func MockRefreshToken(refreshed DAO, err error) RefreshToken {
+	return func(ctx context.Context, oldToken string) (DAO, error) {
+		return refreshed, err
+	}
+}
+
+// MockUpdateToken mocks an UpdateToken function
+func MockUpdateToken(err error) UpdateToken {
+	return func(ctx context.Context, token string, session DAO) error {
 		return err
 	}
 }
 
+// MockDelete mocks a Delete function
+func MockDelete(userID int, err error) Delete {
+	return func(ctx context.Context, token string) (int, error) {
+		return userID, err
+	}
+}
+
 // MockDeleteExpiredSessions mocks a DeleteExpiredSessions function
 func MockDeleteExpiredSessions(err error) DeleteExpiredSessions {
 	return func(ctx context.Context, userID int) error {
@@ -33,6 +54,27 @@ func MockDeleteExpiredSessions(err error) DeleteExpiredSessions {
 	}
 }
 
+// MockDeleteByID mocks a DeleteByID function
+func MockDeleteByID(err error) DeleteByID {
+	return func(ctx context.Context, userID int, sessionID int) error {
+		return err
+	}
+}
+
+// MockDeleteOthers mocks a DeleteOthers function
+func MockDeleteOthers(deleted int, err error) DeleteOthers {
+	return func(ctx context.Context, userID int, token string) (int, error) {
+		return deleted, err
+	}
+}
+
+// MockDeleteAll mocks a DeleteAll function
+func MockDeleteAll(deleted int, err error) DeleteAll {
+	return func(ctx context.Context, userID int) (int, error) {
+		return deleted, err
+	}
+}
+
 // MockSelectUserIDByToken mocks a SelectUserIDByToken function
 func MockSelectUserIDByToken(userID int, err error) SelectUserIDByToken {
 	return func(ctx context.Context, token string) (int, error) {
@@ -40,11 +82,55 @@ func MockSelectUserIDByToken(userID int, err error) SelectUserIDByToken {
 	}
 }
 
+// MockSelectActiveByUserID mocks a SelectActiveByUserID function
+func MockSelectActiveByUserID(sessions []ActiveDAO, err error) SelectActiveByUserID {
+	return func(ctx context.Context, userID int) ([]ActiveDAO, error) {
+		return sessions, err
+	}
+}
+
+// MockSelectKeysUsage mocks a SelectKeysUsage function
+func MockSelectKeysUsage(usage []KeyUsageDAO, err error) SelectKeysUsage {
+	return func(ctx context.Context) ([]KeyUsageDAO, error) {
+		return usage, err
+	}
+}
+
+// MockKeyring mocks a Keyring with an active key, 'v2', and two retiring ones, 'v1' and the legacy one
+func MockKeyring() Keyring {
+	return Keyring{
+		ActiveKeyID: "v2",
+		Keys: map[string]string{
+			"v2":        "secret v2",
+			"v1":        "secret v1",
+			LegacyKeyID: "secret",
+		},
+	}
+}
+
+// MockKeyUsageDAOs mocks a []KeyUsageDAO
+func MockKeyUsageDAOs() []KeyUsageDAO {
+	return []KeyUsageDAO{
+		{KeyID: LegacyKeyID, LiveSessions: 3, LastExpiresAt: time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)},
+		{KeyID: "v0", LiveSessions: 1, LastExpiresAt: time.Date(2025, time.March, 15, 0, 0, 0, 0, time.UTC)},
+		{KeyID: "v2", LiveSessions: 5, LastExpiresAt: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)},
+	}
+}
+
 // MockUserSessionDAO mocks a session DAO
 func MockUserSessionDAO() DAO {
 	return DAO{
 		UserID:    1,
 		Token:     "abcd1234",
+		KeyID:     "v2",
 		ExpiresAt: time.Date(2006, time.January, 1, 0, 0, 0, 0, time.Local),
 	}
 }
+
+// MockActiveDAOs mocks a []ActiveDAO, the first one being the session of the token "token"
+func MockActiveDAOs() []ActiveDAO {
+	return []ActiveDAO{
+		{ID: 2, TokenHash: HashToken("token"), CreatedAt: time.Date(2025, time.March, 2, 0, 0, 0, 0, time.UTC), ExpiresAt: time.Date(2025, time.April, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: 1, TokenHash: HashToken("another token"), CreatedAt: time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC), ExpiresAt: time.Date(2025, time.March, 31, 0, 0, 0, 0, time.UTC)},
+	}
+}
@@ -5,23 +5,47 @@ import (
 	"errors"
 
 	"github.com/jackc/pgx/v5"
-	
+
 	"ahbcc/internal/database"
 	"ahbcc/internal/log"
 )
 
-type SelectUserIDByToken func(ctx context.Context, token string) (int, error)
+type (
+	// SelectUserIDByToken retrieves the ID of the user that owns the given session token. The signature and the
+	// expiration of the token are verified before looking for its session, which must not have expired either. The
+	// sessions of the disabled users aren't found
+	SelectUserIDByToken func(ctx context.Context, token string) (int, error)
+
+	// SelectActiveByUserID retrieves the sessions of the given user that haven't expired yet, from the newest to the
+	// oldest
+	SelectActiveByUserID func(ctx context.Context, userID int) ([]ActiveDAO, error)
 
-func MakeSelectUserIDByToken(db database.Connection) SelectUserIDByToken {
+	// SelectKeysUsage retrieves, for each signing key, the amount of sessions that haven't expired yet and the
+	// expiration of the last of them
+	SelectKeysUsage func(ctx context.Context) ([]KeyUsageDAO, error)
+)
+
+// MakeSelectUserIDByToken creates a new SelectUserIDByToken
+func MakeSelectUserIDByToken(db database.Connection, verifyToken VerifyToken) SelectUserIDByToken {
 	const query string = `
-		SELECT user_id
-		FROM users_sessions
-		WHERE token = $1;
+		SELECT s.user_id
+		FROM users_sessions AS s
+		INNER JOIN users AS u ON u.id = s.user_id
+		WHERE s.token_hash = $1
+		  AND s.user_id = $2
+		  AND s.expires_at > NOW()
+		  AND u.disabled_at IS NULL;
 	`
 
 	return func(ctx context.Context, token string) (int, error) {
+		tokenUserID, err := verifyToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, err
+		}
+
 		var userID int
-		err := db.QueryRow(ctx, query, token).Scan(&userID)
+		err = db.QueryRow(ctx, query, HashToken(token), tokenUserID).Scan(&userID)
 		if errors.Is(err, pgx.ErrNoRows) {
 			log.Error(ctx, err.Error())
 			return 0, NoUserIDFoundForTheGivenToken
@@ -29,7 +53,60 @@ func MakeSelectUserIDByToken(db database.Connection) SelectUserIDByToken {
 			log.Error(ctx, err.Error())
 			return 0, FailedToExecuteQueryToRetrieveUserID
 		}
-
 		return userID, nil
 	}
 }
+
+// MakeSelectActiveByUserID creates a new SelectActiveByUserID
+func MakeSelectActiveByUserID(db database.Connection, collectRows database.CollectRows[ActiveDAO]) SelectActiveByUserID {
+	const query string = `
+		SELECT id, token_hash, created_at, expires_at
+		FROM users_sessions
+		WHERE user_id = $1
+		  AND expires_at > NOW()
+		ORDER BY created_at DESC, id DESC;
+	`
+
+	return func(ctx context.Context, userID int) ([]ActiveDAO, error) {
+		rows, err := db.Query(ctx, query, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectActiveSessions
+		}
+
+		sessions, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectActiveSessions
+		}
+
+		return sessions, nil
+	}
+}
+
+// MakeSelectKeysUsage creates a new SelectKeysUsage
+func MakeSelectKeysUsage(db database.Connection, collectRows database.CollectRows[KeyUsageDAO]) SelectKeysUsage {
+	const query string = `
+		SELECT key_id, COUNT(*), MAX(expires_at)
+		FROM users_sessions
+		WHERE expires_at > NOW()
+		GROUP BY key_id
+		ORDER BY key_id;
+	`
+
+	return func(ctx context.Context) ([]KeyUsageDAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectKeysUsage
+		}
+
+		usage, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectKeysUsage
+		}
+
+		return usage, nil
+	}
+}
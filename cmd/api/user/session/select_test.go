@@ -19,7 +19,7 @@ func TestSelectUserIDByToken_success(t *testing.T) {
 	database.MockScan(mockPgxRow, []any{1}, t)
 	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
 
-	selectUserIDByToken := session.MakeSelectUserIDByToken(mockPostgresConnection)
+	selectUserIDByToken := session.MakeSelectUserIDByToken(mockPostgresConnection, session.MockVerifyToken(1, nil))
 
 	want := 1
 	got, err := selectUserIDByToken(context.Background(), "token")
@@ -45,7 +45,7 @@ func TestSelectUserIDByToken_failsWhenSelectOperationThrowsError(t *testing.T) {
 		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
 		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
 
-		selectUserIDByToken := session.MakeSelectUserIDByToken(mockPostgresConnection)
+		selectUserIDByToken := session.MakeSelectUserIDByToken(mockPostgresConnection, session.MockVerifyToken(1, nil))
 
 		want := tt.expected
 		_, got := selectUserIDByToken(context.Background(), "token")
@@ -55,3 +55,109 @@ func TestSelectUserIDByToken_failsWhenSelectOperationThrowsError(t *testing.T) {
 		mockPgxRow.AssertExpectations(t)
 	}
 }
+
+func TestSelectUserIDByToken_failsWhenVerifyTokenThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+
+	selectUserIDByToken := session.MakeSelectUserIDByToken(mockPostgresConnection, session.MockVerifyToken(-1, session.ExpiredSessionToken))
+
+	want := session.ExpiredSessionToken
+	_, got := selectUserIDByToken(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertNotCalled(t, "QueryRow")
+}
+
+func TestSelectActiveByUserID_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockActiveDAOs := session.MockActiveDAOs()
+	mockCollectRows := database.MockCollectRows[session.ActiveDAO](mockActiveDAOs, nil)
+
+	selectActiveByUserID := session.MakeSelectActiveByUserID(mockPostgresConnection, mockCollectRows)
+
+	want := mockActiveDAOs
+	got, err := selectActiveByUserID(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectActiveByUserID_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[session.ActiveDAO](nil, nil)
+
+	selectActiveByUserID := session.MakeSelectActiveByUserID(mockPostgresConnection, mockCollectRows)
+
+	want := session.FailedToExecuteSelectActiveSessions
+	_, got := selectActiveByUserID(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectActiveByUserID_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[session.ActiveDAO](nil, errors.New("failed to collect rows"))
+
+	selectActiveByUserID := session.MakeSelectActiveByUserID(mockPostgresConnection, mockCollectRows)
+
+	want := session.FailedToExecuteCollectRowsInSelectActiveSessions
+	_, got := selectActiveByUserID(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectKeysUsage_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockKeyUsageDAOs := session.MockKeyUsageDAOs()
+	mockCollectRows := database.MockCollectRows[session.KeyUsageDAO](mockKeyUsageDAOs, nil)
+
+	selectKeysUsage := session.MakeSelectKeysUsage(mockPostgresConnection, mockCollectRows)
+
+	want := mockKeyUsageDAOs
+	got, err := selectKeysUsage(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectKeysUsage_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[session.KeyUsageDAO](nil, nil)
+
+	selectKeysUsage := session.MakeSelectKeysUsage(mockPostgresConnection, mockCollectRows)
+
+	want := session.FailedToExecuteSelectKeysUsage
+	_, got := selectKeysUsage(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectKeysUsage_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[session.KeyUsageDAO](nil, errors.New("failed to collect rows"))
+
+	selectKeysUsage := session.MakeSelectKeysUsage(mockPostgresConnection, mockCollectRows)
+
+	want := session.FailedToExecuteCollectRowsInSelectKeysUsage
+	_, got := selectKeysUsage(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -3,37 +3,60 @@ package session
 import (
 	"context"
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"ahbcc/internal/log"
 )
 
-// CreateToken creates a new session token for the user login action
-type CreateToken func(ctx context.Context, userID int) (string, time.Time, error)
+type (
+	// CreateToken creates a new session token for the user login action. UserDisabled is returned when the user is
+	// disabled
+	CreateToken func(ctx context.Context, userID int) (string, time.Time, error)
+
+	// VerifyToken verifies the signature and the expiration of the given session token, without accessing the database,
+	// and returns the ID of the user it was created for. The signature is verified with the key of the keyring whose ID
+	// the token carries
+	VerifyToken func(ctx context.Context, token string) (int, error)
+
+	// RefreshToken replaces the given session token by a new one, that expires after a whole session duration from now.
+	// The session keeps its ID and creation date, and the given token stops being valid. It returns the refreshed session,
+	// with its user, its new token and its new expiration. The sessions of the disabled users can't be refreshed
+	RefreshToken func(ctx context.Context, token string) (DAO, error)
+)
+
+// nonceSize is the amount of random bytes added to each token, so two tokens of the same user are never equal
+const nonceSize int = 16
 
 // MakeCreateToken creates a new CreateToken function
-func MakeCreateToken(insertUserSession Insert) CreateToken {
+func MakeCreateToken(insertUserSession Insert, keyring Keyring, duration time.Duration) CreateToken {
 	return func(ctx context.Context, userID int) (string, time.Time, error) {
-		expiresAt := time.Now().Add(30 * 24 * time.Hour) // 30 days session expiry
-		payload := fmt.Sprintf("%d:%d", userID, expiresAt.Unix())
-
-		mac := hmac.New(sha256.New, []byte(os.Getenv("SESSION_SECRET_KEY")))
-		mac.Write([]byte(payload))
-		signature := base64.URLEncoding.EncodeToString(mac.Sum(nil))
-		token := base64.URLEncoding.EncodeToString([]byte(payload)) + "." + signature
+		expiresAt := time.Now().Add(duration)
+		token, err := newToken(keyring, userID, expiresAt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCreatUserSessionToken
+		}
 
 		session := DAO{
 			UserID:    userID,
 			Token:     token,
+			KeyID:     keyring.ActiveKeyID,
 			ExpiresAt: expiresAt,
 		}
 
-		err := insertUserSession(ctx, session)
-		if err != nil {
+		err = insertUserSession(ctx, session)
+		if errors.Is(err, UserDisabled) {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, UserDisabled
+		} else if err != nil {
 			log.Error(ctx, err.Error())
 			return "", time.Time{}, FailedToCreatUserSessionToken
 		}
@@ -41,3 +64,123 @@ func MakeCreateToken(insertUserSession Insert) CreateToken {
 		return token, expiresAt, nil
 	}
 }
+
+// MakeVerifyToken creates a new VerifyToken function
+func MakeVerifyToken(keyring Keyring) VerifyToken {
+	return func(ctx context.Context, token string) (int, error) {
+		// The tokens created before the keyring existed don't have the ID of their signing key
+		keyID, encodedPayload, signature := LegacyKeyID, "", ""
+		tokenParts := strings.Split(token, ".")
+		switch len(tokenParts) {
+		case 2:
+			encodedPayload, signature = tokenParts[0], tokenParts[1]
+		case 3:
+			keyID, encodedPayload, signature = tokenParts[0], tokenParts[1], tokenParts[2]
+		default:
+			log.Error(ctx, MalformedSessionToken.Error())
+			return -1, MalformedSessionToken
+		}
+		ctx = log.With(ctx, log.Param("key_id", keyID))
+
+		secretKey, found := keyring.Keys[keyID]
+		if !found {
+			log.Error(ctx, UnknownSessionSigningKey.Error())
+			return -1, UnknownSessionSigningKey
+		}
+
+		payload, err := base64.URLEncoding.DecodeString(encodedPayload)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return -1, MalformedSessionToken
+		}
+
+		if !hmac.Equal([]byte(signature), []byte(sign(secretKey, string(payload)))) {
+			log.Error(ctx, InvalidSessionTokenSignature.Error())
+			return -1, InvalidSessionTokenSignature
+		}
+
+		// The tokens created before the nonce was added only have the user ID and the expiration
+		parts := strings.Split(string(payload), ":")
+		if len(parts) != 2 && len(parts) != 3 {
+			log.Error(ctx, MalformedSessionToken.Error())
+			return -1, MalformedSessionToken
+		}
+
+		userID, err := strconv.Atoi(parts[0])
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return -1, MalformedSessionToken
+		}
+
+		expiresAt, err := strconv.ParseInt(parts[1], 10, 64)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return -1, MalformedSessionToken
+		}
+
+		if !time.Now().Before(time.Unix(expiresAt, 0)) {
+			log.Error(ctx, ExpiredSessionToken.Error())
+			return -1, ExpiredSessionToken
+		}
+
+		return userID, nil
+	}
+}
+
+// MakeRefreshToken creates a new RefreshToken function
+func MakeRefreshToken(verifyToken VerifyToken, updateToken UpdateToken, keyring Keyring, duration time.Duration) RefreshToken {
+	return func(ctx context.Context, token string) (DAO, error) {
+		userID, err := verifyToken(ctx, token)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return DAO{}, err
+		}
+
+		expiresAt := time.Now().Add(duration)
+		newSessionToken, err := newToken(keyring, userID, expiresAt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return DAO{}, FailedToRefreshUserSessionToken
+		}
+
+		refreshed := DAO{UserID: userID, Token: newSessionToken, KeyID: keyring.ActiveKeyID, ExpiresAt: expiresAt}
+		err = updateToken(ctx, token, refreshed)
+		if errors.Is(err, NoSessionFoundForTheGivenToken) {
+			log.Error(ctx, err.Error())
+			return DAO{}, NoSessionFoundForTheGivenToken
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return DAO{}, FailedToRefreshUserSessionToken
+		}
+
+		return refreshed, nil
+	}
+}
+
+// HashToken returns the hex encoded SHA-256 hash of the given token, which is what is stored in the database
+func HashToken(token string) string {
+	hash := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(hash[:])
+}
+
+// newToken creates a token with the user ID, the expiration and a random nonce as payload, signed with the active key
+// of the keyring, whose ID is prepended to the token
+func newToken(keyring Keyring, userID int, expiresAt time.Time) (string, error) {
+	nonce := make([]byte, nonceSize)
+	_, err := rand.Read(nonce)
+	if err != nil {
+		return "", err
+	}
+
+	payload := fmt.Sprintf("%d:%d:%s", userID, expiresAt.Unix(), base64.RawURLEncoding.EncodeToString(nonce))
+
+	return keyring.ActiveKeyID + "." + base64.URLEncoding.EncodeToString([]byte(payload)) + "." + sign(keyring.Keys[keyring.ActiveKeyID], payload), nil
+}
+
+// sign returns the base64 encoded HMAC-SHA256 signature of the payload
+func sign(secretKey string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(payload))
+
+	return base64.URLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -2,9 +2,15 @@ package session_test
 
 import (
 	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
+	"fmt"
 	"os"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/joho/godotenv"
 	"github.com/stretchr/testify/assert"
@@ -12,30 +18,207 @@ import (
 	"ahbcc/cmd/api/user/session"
 )
 
+const (
+	mockLegacySecretKey string        = "secret"
+	mockDuration        time.Duration = time.Hour
+)
+
 func TestMain(m *testing.M) {
 	_ = godotenv.Load()
 	os.Exit(m.Run())
 }
 
 func TestCreateToken_success(t *testing.T) {
-	mockInsertUserSession := session.MockInsertUserSession(nil)
+	var insertedSession session.DAO
+	mockInsertUserSession := func(ctx context.Context, dao session.DAO) error {
+		insertedSession = dao
+		return nil
+	}
 
-	createSessionToken := session.MakeCreateToken(mockInsertUserSession)
+	createSessionToken := session.MakeCreateToken(mockInsertUserSession, session.MockKeyring(), mockDuration)
 
-	token, createdAt, got := createSessionToken(context.Background(), 1)
+	token, expiresAt, got := createSessionToken(context.Background(), 1)
 
 	assert.Nil(t, got)
-	assert.NotNil(t, createdAt)
-	assert.NotNil(t, token)
+	assert.WithinDuration(t, time.Now().Add(mockDuration), expiresAt, time.Minute)
+	assert.True(t, strings.HasPrefix(token, "v2."))
+	assert.Equal(t, session.DAO{UserID: 1, Token: token, KeyID: "v2", ExpiresAt: expiresAt}, insertedSession)
+}
+
+func TestCreateToken_successCreatingDifferentTokensForTheSameUser(t *testing.T) {
+	mockInsertUserSession := session.MockInsertUserSession(nil)
+
+	createSessionToken := session.MakeCreateToken(mockInsertUserSession, session.MockKeyring(), mockDuration)
+
+	first, _, _ := createSessionToken(context.Background(), 1)
+	second, _, _ := createSessionToken(context.Background(), 1)
+
+	assert.NotEqual(t, first, second)
 }
 
 func TestCreateToken_failsWhenInsertUserSessionThrowsError(t *testing.T) {
 	mockInsertUserSession := session.MockInsertUserSession(errors.New("failed to insert user session"))
 
-	createSessionToken := session.MakeCreateToken(mockInsertUserSession)
+	createSessionToken := session.MakeCreateToken(mockInsertUserSession, session.MockKeyring(), mockDuration)
 
 	want := session.FailedToCreatUserSessionToken
 	_, _, got := createSessionToken(context.Background(), 1)
 
 	assert.Equal(t, want, got)
 }
+
+func TestCreateToken_failsWhenTheUserIsDisabled(t *testing.T) {
+	mockInsertUserSession := session.MockInsertUserSession(session.UserDisabled)
+
+	createSessionToken := session.MakeCreateToken(mockInsertUserSession, session.MockKeyring(), mockDuration)
+
+	want := session.UserDisabled
+	_, _, got := createSessionToken(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+}
+
+func TestVerifyToken_success(t *testing.T) {
+	createSessionToken := session.MakeCreateToken(session.MockInsertUserSession(nil), session.MockKeyring(), mockDuration)
+	token, _, _ := createSessionToken(context.Background(), 1234)
+
+	verifyToken := session.MakeVerifyToken(session.MockKeyring())
+
+	want := 1234
+	got, err := verifyToken(context.Background(), token)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestVerifyToken_successWithATokenCreatedWithoutNonce(t *testing.T) {
+	verifyToken := session.MakeVerifyToken(session.MockKeyring())
+
+	want := 1234
+	got, err := verifyToken(context.Background(), mockSignedToken(mockLegacySecretKey, fmt.Sprintf("1234:%d", time.Now().Add(time.Hour).Unix())))
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestVerifyToken_successWithATokenSignedWithARetiringKey(t *testing.T) {
+	verifyToken := session.MakeVerifyToken(session.MockKeyring())
+
+	want := 1234
+	got, err := verifyToken(context.Background(), mockSignedTokenWithKeyID("v1", "secret v1", fmt.Sprintf("1234:%d:nonce", time.Now().Add(time.Hour).Unix())))
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestVerifyToken_failsWhenTheTokenIsInvalid(t *testing.T) {
+	createSessionToken := session.MakeCreateToken(session.MockInsertUserSession(nil), session.MockKeyring(), mockDuration)
+	token, _, _ := createSessionToken(context.Background(), 1234)
+	tokenParts := strings.Split(token, ".")
+	validPayload := fmt.Sprintf("1234:%d:nonce", time.Now().Add(time.Hour).Unix())
+
+	tests := []struct {
+		token    string
+		expected error
+	}{
+		{token: "token", expected: session.MalformedSessionToken},
+		{token: "%%%." + "signature", expected: session.MalformedSessionToken},
+		{token: "v2.payload.signature.extra", expected: session.MalformedSessionToken},
+		{token: tokenParts[0] + "." + tokenParts[1] + ".signature", expected: session.InvalidSessionTokenSignature},
+		{token: mockSignedTokenWithKeyID("v1", "secret v2", validPayload), expected: session.InvalidSessionTokenSignature},
+		{token: mockSignedTokenWithKeyID("v9", "secret v9", validPayload), expected: session.UnknownSessionSigningKey},
+		{token: mockSignedToken("another secret", "1234:1"), expected: session.InvalidSessionTokenSignature},
+		{token: mockSignedToken(mockLegacySecretKey, "1234"), expected: session.MalformedSessionToken},
+		{token: mockSignedToken(mockLegacySecretKey, "user:1"), expected: session.MalformedSessionToken},
+		{token: mockSignedToken(mockLegacySecretKey, "1234:never"), expected: session.MalformedSessionToken},
+		{token: mockSignedToken(mockLegacySecretKey, fmt.Sprintf("1234:%d:nonce", time.Now().Add(-time.Hour).Unix())), expected: session.ExpiredSessionToken},
+	}
+
+	verifyToken := session.MakeVerifyToken(session.MockKeyring())
+
+	for _, tt := range tests {
+		want := tt.expected
+		_, got := verifyToken(context.Background(), tt.token)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRefreshToken_success(t *testing.T) {
+	mockVerifyToken := session.MockVerifyToken(1234, nil)
+	var updatedSession session.DAO
+	mockUpdateToken := func(ctx context.Context, token string, dao session.DAO) error {
+		updatedSession = dao
+		return nil
+	}
+
+	refreshToken := session.MakeRefreshToken(mockVerifyToken, mockUpdateToken, session.MockKeyring(), mockDuration)
+
+	got, err := refreshToken(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.NotEqual(t, "token", got.Token)
+	assert.WithinDuration(t, time.Now().Add(mockDuration), got.ExpiresAt, time.Minute)
+	assert.True(t, strings.HasPrefix(got.Token, "v2."))
+	assert.Equal(t, session.DAO{UserID: 1234, Token: got.Token, KeyID: "v2", ExpiresAt: got.ExpiresAt}, got)
+	assert.Equal(t, got, updatedSession)
+
+	userID, err := session.MakeVerifyToken(session.MockKeyring())(context.Background(), got.Token)
+	assert.Nil(t, err)
+	assert.Equal(t, 1234, userID)
+}
+
+func TestRefreshToken_failsWhenVerifyTokenThrowsError(t *testing.T) {
+	mockVerifyToken := session.MockVerifyToken(-1, session.ExpiredSessionToken)
+	mockUpdateToken := session.MockUpdateToken(nil)
+
+	refreshToken := session.MakeRefreshToken(mockVerifyToken, mockUpdateToken, session.MockKeyring(), mockDuration)
+
+	want := session.ExpiredSessionToken
+	_, got := refreshToken(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestRefreshToken_failsWhenUpdateTokenThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: session.NoSessionFoundForTheGivenToken, expected: session.NoSessionFoundForTheGivenToken},
+		{err: errors.New("failed to update token"), expected: session.FailedToRefreshUserSessionToken},
+	}
+
+	for _, tt := range tests {
+		mockVerifyToken := session.MockVerifyToken(1234, nil)
+		mockUpdateToken := session.MockUpdateToken(tt.err)
+
+		refreshToken := session.MakeRefreshToken(mockVerifyToken, mockUpdateToken, session.MockKeyring(), mockDuration)
+
+		want := tt.expected
+		_, got := refreshToken(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestHashToken_success(t *testing.T) {
+	want := "3c469e9d6c5875d37a43f353d4f88e61fcf812c66eee3457465a40b0da4153e0"
+	got := session.HashToken("token")
+
+	assert.Equal(t, want, got)
+}
+
+// mockSignedToken creates a token with the given payload, signed with the given secret key, without the ID of the key
+func mockSignedToken(secretKey string, payload string) string {
+	mac := hmac.New(sha256.New, []byte(secretKey))
+	mac.Write([]byte(payload))
+
+	return base64.URLEncoding.EncodeToString([]byte(payload)) + "." + base64.URLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+// mockSignedTokenWithKeyID creates a token with the given payload, signed with the given secret key, prepending the
+// given key ID
+func mockSignedTokenWithKeyID(keyID string, secretKey string, payload string) string {
+	return keyID + "." + mockSignedToken(secretKey, payload)
+}
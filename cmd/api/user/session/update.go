@@ -0,0 +1,42 @@
+package session
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// UpdateToken replaces the token, the ID of its signing key and the expiration of the session of the given token by the
+// ones of the given session DAO. The session must belong to the user of the DAO, it must not have expired and its user
+// must not be disabled
+type UpdateToken func(ctx context.Context, token string, session DAO) error
+
+// MakeUpdateToken creates a new UpdateToken
+func MakeUpdateToken(db database.Connection) UpdateToken {
+	const query string = `
+		UPDATE users_sessions us
+		SET token_hash = $1, key_id = $2, expires_at = $3
+		FROM users u
+		WHERE us.token_hash = $4
+		  AND us.user_id = $5
+		  AND us.expires_at > NOW()
+		  AND u.id = us.user_id
+		  AND u.disabled_at IS NULL
+	`
+
+	return func(ctx context.Context, token string, session DAO) error {
+		commandTag, err := db.Exec(ctx, query, HashToken(session.Token), session.KeyID, session.ExpiresAt, HashToken(token), session.UserID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpdateUserSessionToken
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			log.Error(ctx, NoSessionFoundForTheGivenToken.Error())
+			return NoSessionFoundForTheGivenToken
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,64 @@
+package session_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+)
+
+func TestUpdateToken_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockUserSessionDAO := session.MockUserSessionDAO()
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{session.HashToken(mockUserSessionDAO.Token), mockUserSessionDAO.KeyID, mockUserSessionDAO.ExpiresAt, session.HashToken("token"), mockUserSessionDAO.UserID}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	updateToken := session.MakeUpdateToken(mockPostgresConnection)
+
+	got := updateToken(context.Background(), "token", mockUserSessionDAO)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateToken_successOnlyUpdatingTheSessionsOfTheEnabledUsers(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.MatchedBy(func(query string) bool { return strings.Contains(query, "u.disabled_at IS NULL") }), mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	updateToken := session.MakeUpdateToken(mockPostgresConnection)
+
+	got := updateToken(context.Background(), "token", session.MockUserSessionDAO())
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateToken_failsWhenUpdateOperationFails(t *testing.T) {
+	tests := []struct {
+		commandTag pgconn.CommandTag
+		err        error
+		expected   error
+	}{
+		{commandTag: pgconn.NewCommandTag("UPDATE 0"), expected: session.NoSessionFoundForTheGivenToken},
+		{err: errors.New("failed to update user session"), expected: session.FailedToUpdateUserSessionToken},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(tt.commandTag, tt.err)
+
+		updateToken := session.MakeUpdateToken(mockPostgresConnection)
+
+		want := tt.expected
+		got := updateToken(context.Background(), "token", session.MockUserSessionDAO())
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
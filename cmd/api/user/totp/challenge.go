@@ -0,0 +1,43 @@
+package totp
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+// CreateChallenge creates a short-lived login challenge for the user, which is completed with a code of its second
+// factor. It returns the challenge with its expiration time
+type CreateChallenge func(ctx context.Context, userID int) (string, time.Time, error)
+
+// challengeSize is the amount of random bytes of each login challenge
+const challengeSize int = 32
+
+// MakeCreateChallenge creates a new CreateChallenge
+func MakeCreateChallenge(insertChallenge InsertChallenge, duration time.Duration) CreateChallenge {
+	return func(ctx context.Context, userID int) (string, time.Time, error) {
+		random := make([]byte, challengeSize)
+		_, err := rand.Read(random)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCreateChallenge
+		}
+
+		challenge := ChallengeDAO{
+			UserID:    userID,
+			Challenge: base64.RawURLEncoding.EncodeToString(random),
+			ExpiresAt: time.Now().Add(duration),
+		}
+
+		err = insertChallenge(ctx, challenge)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return "", time.Time{}, FailedToCreateChallenge
+		}
+
+		return challenge.Challenge, challenge.ExpiresAt, nil
+	}
+}
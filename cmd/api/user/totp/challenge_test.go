@@ -0,0 +1,40 @@
+package totp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestCreateChallenge_success(t *testing.T) {
+	var insertedChallenge totp.ChallengeDAO
+	mockInsertChallenge := func(ctx context.Context, challenge totp.ChallengeDAO) error {
+		insertedChallenge = challenge
+		return nil
+	}
+
+	createChallenge := totp.MakeCreateChallenge(mockInsertChallenge, 5*time.Minute)
+
+	challenge, expiresAt, err := createChallenge(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, insertedChallenge.Challenge, challenge)
+	assert.Equal(t, insertedChallenge.ExpiresAt, expiresAt)
+	assert.Equal(t, 1, insertedChallenge.UserID)
+	assert.Len(t, challenge, 43)
+	assert.WithinDuration(t, time.Now().Add(5*time.Minute), expiresAt, time.Second)
+}
+
+func TestCreateChallenge_failsWhenInsertChallengeThrowsError(t *testing.T) {
+	createChallenge := totp.MakeCreateChallenge(totp.MockInsertChallenge(errors.New("failed to insert challenge")), 5*time.Minute)
+
+	want := totp.FailedToCreateChallenge
+	_, _, got := createChallenge(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,115 @@
+package totp_test
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestCode_success(t *testing.T) {
+	// Test vectors of the RFC 6238, Appendix B, truncated to 6 digits
+	tests := []struct {
+		time     int64
+		expected string
+	}{
+		{time: 59, expected: "287082"},
+		{time: 1111111109, expected: "081804"},
+		{time: 1111111111, expected: "050471"},
+		{time: 1234567890, expected: "005924"},
+		{time: 2000000000, expected: "279037"},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got, err := totp.Code(totp.MockSecret(), totp.Step(time.Unix(tt.time, 0)))
+
+		assert.Nil(t, err)
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestCode_failsWhenTheSecretIsNotBase32(t *testing.T) {
+	_, err := totp.Code("not base32!", 1)
+
+	assert.NotNil(t, err)
+}
+
+func TestMatch_success(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := totp.Step(now)
+	previousCode, _ := totp.Code(totp.MockSecret(), step-1)
+	currentCode, _ := totp.Code(totp.MockSecret(), step)
+	nextCode, _ := totp.Code(totp.MockSecret(), step+1)
+
+	tests := []struct {
+		code     string
+		expected int64
+	}{
+		{code: previousCode, expected: step - 1},
+		{code: currentCode, expected: step},
+		{code: " " + nextCode + " ", expected: step + 1},
+	}
+
+	for _, tt := range tests {
+		got, ok := totp.Match(totp.MockSecret(), tt.code, now, 0)
+
+		assert.True(t, ok)
+		assert.Equal(t, tt.expected, got)
+	}
+}
+
+func TestMatch_failsWhenTheCodeIsNotAccepted(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+	step := totp.Step(now)
+	oldCode, _ := totp.Code(totp.MockSecret(), step-2)
+	currentCode, _ := totp.Code(totp.MockSecret(), step)
+
+	tests := []struct {
+		code         string
+		lastUsedStep int64
+	}{
+		{code: oldCode, lastUsedStep: 0},
+		{code: currentCode, lastUsedStep: step},
+		{code: "12345", lastUsedStep: 0},
+		{code: "", lastUsedStep: 0},
+	}
+
+	for _, tt := range tests {
+		_, ok := totp.Match(totp.MockSecret(), tt.code, now, tt.lastUsedStep)
+
+		assert.False(t, ok)
+	}
+}
+
+func TestNewSecret_success(t *testing.T) {
+	first, err := totp.NewSecret()
+	assert.Nil(t, err)
+	second, err := totp.NewSecret()
+	assert.Nil(t, err)
+
+	assert.Len(t, first, 32)
+	assert.NotEqual(t, first, second)
+	_, err = totp.Code(first, 1)
+	assert.Nil(t, err)
+}
+
+func TestProvisioningURI_success(t *testing.T) {
+	got := totp.ProvisioningURI("AHBCC", "jane doe", totp.MockSecret())
+
+	parsed, err := url.Parse(got)
+	assert.Nil(t, err)
+	assert.Equal(t, "otpauth", parsed.Scheme)
+	assert.Equal(t, "totp", parsed.Host)
+	assert.Equal(t, "/AHBCC:jane doe", parsed.Path)
+	assert.Equal(t, url.Values{
+		"secret":    {totp.MockSecret()},
+		"issuer":    {"AHBCC"},
+		"algorithm": {"SHA1"},
+		"digits":    {"6"},
+		"period":    {"30"},
+	}, parsed.Query())
+}
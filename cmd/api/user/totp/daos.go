@@ -0,0 +1,22 @@
+package totp
+
+import "time"
+
+type (
+	// DAO represents the TOTP second factor of a user. It is enrolled but not enabled until EnabledAt is set, which
+	// happens once the user confirms a code of the secret. LastUsedStep is the time step of the last accepted code
+	DAO struct {
+		UserID       int        `json:"user_id"`
+		Secret       string     `json:"secret"`
+		EnabledAt    *time.Time `json:"enabled_at"`
+		LastUsedStep int64      `json:"last_used_step"`
+	}
+
+	// ChallengeDAO represents the second step of a login, pending of a code of the second factor of the user. The
+	// Challenge is never stored, only its hash
+	ChallengeDAO struct {
+		UserID    int       `json:"user_id"`
+		Challenge string    `json:"challenge"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+)
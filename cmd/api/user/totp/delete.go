@@ -0,0 +1,64 @@
+package totp
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// Delete deletes the second factor of the user, with its recovery codes. It fails with TwoFactorNotEnabled when the
+	// user has no second factor enabled
+	Delete func(ctx context.Context, userID int) error
+
+	// DeleteChallenge deletes the given login challenge, once it was completed
+	DeleteChallenge func(ctx context.Context, challenge string) error
+)
+
+// MakeDelete creates a new Delete
+func MakeDelete(db database.Connection) Delete {
+	const query string = `
+		WITH codes AS (
+			DELETE FROM users_recovery_codes
+			WHERE user_id = $1
+		)
+		DELETE FROM users_two_factor
+		WHERE user_id = $1
+		  AND enabled_at IS NOT NULL;
+	`
+
+	return func(ctx context.Context, userID int) error {
+		result, err := db.Exec(ctx, query, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDeleteTwoFactor
+		}
+
+		if result.RowsAffected() == 0 {
+			log.Error(ctx, TwoFactorNotEnabled.Error())
+			return TwoFactorNotEnabled
+		}
+
+		return nil
+	}
+}
+
+// MakeDeleteChallenge creates a new DeleteChallenge
+func MakeDeleteChallenge(db database.Connection) DeleteChallenge {
+	const query string = `
+		DELETE FROM login_challenges
+		WHERE challenge_hash = $1;
+	`
+
+	return func(ctx context.Context, challenge string) error {
+		_, err := db.Exec(ctx, query, session.HashToken(challenge))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDeleteChallenge
+		}
+
+		return nil
+	}
+}
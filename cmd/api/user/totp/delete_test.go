@@ -0,0 +1,76 @@
+package totp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/internal/database"
+)
+
+func TestDelete_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1}).Return(pgconn.NewCommandTag("DELETE 1"), nil)
+
+	deleteTwoFactor := totp.MakeDelete(mockPostgresConnection)
+
+	got := deleteTwoFactor(context.Background(), 1)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestDelete_failsWhenDeleteOperationFails(t *testing.T) {
+	tests := []struct {
+		commandTag pgconn.CommandTag
+		err        error
+		expected   error
+	}{
+		{commandTag: pgconn.NewCommandTag("DELETE 0"), err: nil, expected: totp.TwoFactorNotEnabled},
+		{commandTag: pgconn.CommandTag{}, err: errors.New("failed to delete"), expected: totp.FailedToDeleteTwoFactor},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(tt.commandTag, tt.err)
+
+		deleteTwoFactor := totp.MakeDelete(mockPostgresConnection)
+
+		want := tt.expected
+		got := deleteTwoFactor(context.Background(), 1)
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestDeleteChallenge_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{session.HashToken("challenge")}).Return(pgconn.NewCommandTag("DELETE 1"), nil)
+
+	deleteChallenge := totp.MakeDeleteChallenge(mockPostgresConnection)
+
+	got := deleteChallenge(context.Background(), "challenge")
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestDeleteChallenge_failsWhenDeleteOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to delete challenge"))
+
+	deleteChallenge := totp.MakeDeleteChallenge(mockPostgresConnection)
+
+	want := totp.FailedToDeleteChallenge
+	got := deleteChallenge(context.Background(), "challenge")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,7 @@
+package totp
+
+// EnrollmentDTO represents the secret of a new TOTP second factor, with its otpauth provisioning URI
+type EnrollmentDTO struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
@@ -0,0 +1,82 @@
+package totp
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+type (
+	// Enroll creates a new secret for the user and stores it as its pending second factor, returning it with its
+	// provisioning URI. The second factor isn't enabled until it is confirmed
+	Enroll func(ctx context.Context, userID int, username string) (EnrollmentDTO, error)
+
+	// Confirm enables the pending second factor of the user, once the user proves it set it up with a code of it, and
+	// returns the new recovery codes of the user
+	Confirm func(ctx context.Context, userID int, code string) ([]string, error)
+)
+
+// MakeEnroll creates a new Enroll
+func MakeEnroll(upsertSecret UpsertSecret, issuer string) Enroll {
+	return func(ctx context.Context, userID int, username string) (EnrollmentDTO, error) {
+		secret, err := NewSecret()
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return EnrollmentDTO{}, FailedToCreateSecret
+		}
+
+		err = upsertSecret(ctx, userID, secret)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return EnrollmentDTO{}, err
+		}
+
+		return EnrollmentDTO{
+			Secret:          secret,
+			ProvisioningURI: ProvisioningURI(issuer, username, secret),
+		}, nil
+	}
+}
+
+// MakeConfirm creates a new Confirm
+func MakeConfirm(selectByUserID SelectByUserID, enable Enable, createRecoveryCodes CreateRecoveryCodes) Confirm {
+	return func(ctx context.Context, userID int, code string) ([]string, error) {
+		secondFactor, err := selectByUserID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, err
+		}
+
+		if secondFactor.EnabledAt != nil {
+			log.Error(ctx, TwoFactorAlreadyEnabled.Error())
+			return nil, TwoFactorAlreadyEnabled
+		}
+
+		step, ok := Match(secondFactor.Secret, code, time.Now(), secondFactor.LastUsedStep)
+		if !ok {
+			log.Error(ctx, WrongCode.Error())
+			return nil, WrongCode
+		}
+
+		err = enable(ctx, userID, step)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, err
+		}
+
+		recoveryCodes, err := createRecoveryCodes(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, err
+		}
+
+		return recoveryCodes, nil
+	}
+}
+
+// IsNotEnrolled reports whether the error is caused by the user not having a second factor to use
+func IsNotEnrolled(err error) bool {
+	return errors.Is(err, TwoFactorNotEnrolled) || errors.Is(err, TwoFactorNotEnabled)
+}
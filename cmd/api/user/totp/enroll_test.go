@@ -0,0 +1,88 @@
+package totp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestEnroll_success(t *testing.T) {
+	var upsertedSecret string
+	mockUpsertSecret := func(ctx context.Context, userID int, secret string) error {
+		upsertedSecret = secret
+		return nil
+	}
+
+	enroll := totp.MakeEnroll(mockUpsertSecret, "AHBCC")
+
+	got, err := enroll(context.Background(), 1, "username")
+
+	assert.Nil(t, err)
+	assert.Equal(t, upsertedSecret, got.Secret)
+	assert.Equal(t, totp.ProvisioningURI("AHBCC", "username", upsertedSecret), got.ProvisioningURI)
+}
+
+func TestEnroll_failsWhenUpsertSecretThrowsError(t *testing.T) {
+	tests := []struct {
+		err error
+	}{
+		{err: totp.TwoFactorAlreadyEnabled},
+		{err: totp.FailedToUpsertSecret},
+	}
+
+	for _, tt := range tests {
+		enroll := totp.MakeEnroll(totp.MockUpsertSecret(tt.err), "AHBCC")
+
+		want := tt.err
+		_, got := enroll(context.Background(), 1, "username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestConfirm_success(t *testing.T) {
+	mockDAO := totp.MockDAO()
+	mockDAO.EnabledAt = nil
+	code, _ := totp.Code(mockDAO.Secret, totp.Step(time.Now()))
+
+	confirm := totp.MakeConfirm(totp.MockSelectByUserID(mockDAO, nil), totp.MockEnable(nil), totp.MockCreateRecoveryCodes(totp.MockRecoveryCodes(), nil))
+
+	want := totp.MockRecoveryCodes()
+	got, err := confirm(context.Background(), 1, code)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestConfirm_failsWhenTheSecondFactorCantBeConfirmed(t *testing.T) {
+	pendingDAO := totp.MockDAO()
+	pendingDAO.EnabledAt = nil
+	code, _ := totp.Code(pendingDAO.Secret, totp.Step(time.Now()))
+
+	tests := []struct {
+		selectByUserID      totp.SelectByUserID
+		code                string
+		enable              totp.Enable
+		createRecoveryCodes totp.CreateRecoveryCodes
+		expected            error
+	}{
+		{selectByUserID: totp.MockSelectByUserID(totp.DAO{}, totp.TwoFactorNotEnrolled), code: code, enable: totp.MockEnable(nil), createRecoveryCodes: totp.MockCreateRecoveryCodes(nil, nil), expected: totp.TwoFactorNotEnrolled},
+		{selectByUserID: totp.MockSelectByUserID(totp.MockDAO(), nil), code: code, enable: totp.MockEnable(nil), createRecoveryCodes: totp.MockCreateRecoveryCodes(nil, nil), expected: totp.TwoFactorAlreadyEnabled},
+		{selectByUserID: totp.MockSelectByUserID(pendingDAO, nil), code: "000000x", enable: totp.MockEnable(nil), createRecoveryCodes: totp.MockCreateRecoveryCodes(nil, nil), expected: totp.WrongCode},
+		{selectByUserID: totp.MockSelectByUserID(pendingDAO, nil), code: code, enable: totp.MockEnable(totp.FailedToEnableTwoFactor), createRecoveryCodes: totp.MockCreateRecoveryCodes(nil, nil), expected: totp.FailedToEnableTwoFactor},
+		{selectByUserID: totp.MockSelectByUserID(pendingDAO, nil), code: code, enable: totp.MockEnable(nil), createRecoveryCodes: totp.MockCreateRecoveryCodes(nil, totp.FailedToCreateRecoveryCodes), expected: totp.FailedToCreateRecoveryCodes},
+	}
+
+	for _, tt := range tests {
+		confirm := totp.MakeConfirm(tt.selectByUserID, tt.enable, tt.createRecoveryCodes)
+
+		want := tt.expected
+		_, got := confirm(context.Background(), 1, tt.code)
+
+		assert.Equal(t, want, got)
+	}
+}
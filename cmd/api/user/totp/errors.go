@@ -0,0 +1,25 @@
+package totp
+
+import "errors"
+
+var (
+	FailedToCreateSecret          = errors.New("failed to create TOTP secret")
+	FailedToUpsertSecret          = errors.New("failed to upsert TOTP secret")
+	TwoFactorAlreadyEnabled       = errors.New("two-factor authentication is already enabled")
+	TwoFactorNotEnrolled          = errors.New("two-factor authentication is not enrolled")
+	TwoFactorNotEnabled           = errors.New("two-factor authentication is not enabled")
+	FailedToExecuteSelectByUserID = errors.New("failed to execute select TOTP second factor by user id")
+	FailedToExecuteSelectEnabled  = errors.New("failed to execute select if two-factor authentication is enabled")
+	FailedToEnableTwoFactor       = errors.New("failed to enable two-factor authentication")
+	WrongCode                     = errors.New("wrong, expired or already used code")
+	FailedToUpdateLastUsedStep    = errors.New("failed to update last used TOTP step")
+	FailedToCreateRecoveryCodes   = errors.New("failed to create recovery codes")
+	FailedToInsertRecoveryCodes   = errors.New("failed to insert recovery codes")
+	FailedToUseRecoveryCode       = errors.New("failed to use recovery code")
+	FailedToDeleteTwoFactor       = errors.New("failed to delete two-factor authentication")
+	FailedToCreateChallenge       = errors.New("failed to create login challenge")
+	FailedToInsertChallenge       = errors.New("failed to insert login challenge")
+	NoValidChallengeFound         = errors.New("no valid login challenge found, it may be expired or out of attempts")
+	FailedToUseChallenge          = errors.New("failed to use login challenge")
+	FailedToDeleteChallenge       = errors.New("failed to delete login challenge")
+)
@@ -0,0 +1,97 @@
+package totp
+
+import (
+	"context"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// UpsertSecret stores the given secret as the pending second factor of the user, replacing the previous one that
+	// wasn't confirmed. It fails with TwoFactorAlreadyEnabled when the user already has it enabled
+	UpsertSecret func(ctx context.Context, userID int, secret string) error
+
+	// InsertRecoveryCodes replaces the recovery codes of the user by the given ones. Only their hashes are stored
+	InsertRecoveryCodes func(ctx context.Context, userID int, codes []string) error
+
+	// InsertChallenge inserts a login challenge into the 'login_challenges' table, deleting the expired ones. Only the
+	// hash of the challenge is stored
+	InsertChallenge func(ctx context.Context, challenge ChallengeDAO) error
+)
+
+// MakeUpsertSecret creates a new UpsertSecret
+func MakeUpsertSecret(db database.Connection) UpsertSecret {
+	const query string = `
+		INSERT INTO users_two_factor(user_id, secret)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, last_used_step = 0, created_at = NOW()
+		WHERE users_two_factor.enabled_at IS NULL;
+	`
+
+	return func(ctx context.Context, userID int, secret string) error {
+		result, err := db.Exec(ctx, query, userID, secret)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpsertSecret
+		}
+
+		if result.RowsAffected() == 0 {
+			log.Error(ctx, TwoFactorAlreadyEnabled.Error())
+			return TwoFactorAlreadyEnabled
+		}
+
+		return nil
+	}
+}
+
+// MakeInsertRecoveryCodes creates a new InsertRecoveryCodes
+func MakeInsertRecoveryCodes(db database.Connection) InsertRecoveryCodes {
+	const query string = `
+		WITH previous AS (
+			DELETE FROM users_recovery_codes
+			WHERE user_id = $1
+		)
+		INSERT INTO users_recovery_codes(user_id, code_hash)
+		SELECT $1, UNNEST($2::TEXT[]);
+	`
+
+	return func(ctx context.Context, userID int, codes []string) error {
+		hashes := make([]string, 0, len(codes))
+		for _, code := range codes {
+			hashes = append(hashes, session.HashToken(NormalizeRecoveryCode(code)))
+		}
+
+		_, err := db.Exec(ctx, query, userID, hashes)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertRecoveryCodes
+		}
+
+		return nil
+	}
+}
+
+// MakeInsertChallenge creates a new InsertChallenge
+func MakeInsertChallenge(db database.Connection) InsertChallenge {
+	const query string = `
+		WITH expired AS (
+			DELETE FROM login_challenges
+			WHERE expires_at <= NOW()
+		)
+		INSERT INTO login_challenges(user_id, challenge_hash, expires_at)
+		VALUES ($1, $2, $3);
+	`
+
+	return func(ctx context.Context, challenge ChallengeDAO) error {
+		_, err := db.Exec(ctx, query, challenge.UserID, session.HashToken(challenge.Challenge), challenge.ExpiresAt)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToInsertChallenge
+		}
+
+		return nil
+	}
+}
@@ -0,0 +1,104 @@
+package totp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/internal/database"
+)
+
+func TestUpsertSecret_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1, totp.MockSecret()}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	upsertSecret := totp.MakeUpsertSecret(mockPostgresConnection)
+
+	got := upsertSecret(context.Background(), 1, totp.MockSecret())
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpsertSecret_failsWhenUpsertOperationFails(t *testing.T) {
+	tests := []struct {
+		commandTag pgconn.CommandTag
+		err        error
+		expected   error
+	}{
+		{commandTag: pgconn.NewCommandTag("INSERT 0 0"), err: nil, expected: totp.TwoFactorAlreadyEnabled},
+		{commandTag: pgconn.CommandTag{}, err: errors.New("failed to upsert secret"), expected: totp.FailedToUpsertSecret},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(tt.commandTag, tt.err)
+
+		upsertSecret := totp.MakeUpsertSecret(mockPostgresConnection)
+
+		want := tt.expected
+		got := upsertSecret(context.Background(), 1, totp.MockSecret())
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestInsertRecoveryCodes_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockHashes := []string{session.HashToken("abcdefghijklmnop"), session.HashToken("qrstuvwxyz234567")}
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1, mockHashes}).Return(pgconn.NewCommandTag("INSERT 0 2"), nil)
+
+	insertRecoveryCodes := totp.MakeInsertRecoveryCodes(mockPostgresConnection)
+
+	got := insertRecoveryCodes(context.Background(), 1, totp.MockRecoveryCodes())
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertRecoveryCodes_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert recovery codes"))
+
+	insertRecoveryCodes := totp.MakeInsertRecoveryCodes(mockPostgresConnection)
+
+	want := totp.FailedToInsertRecoveryCodes
+	got := insertRecoveryCodes(context.Background(), 1, totp.MockRecoveryCodes())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertChallenge_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockChallengeDAO := totp.ChallengeDAO{UserID: 1, Challenge: "challenge", ExpiresAt: time.Date(2025, time.March, 8, 0, 5, 0, 0, time.UTC)}
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1, session.HashToken("challenge"), mockChallengeDAO.ExpiresAt}).Return(pgconn.NewCommandTag("INSERT 0 1"), nil)
+
+	insertChallenge := totp.MakeInsertChallenge(mockPostgresConnection)
+
+	got := insertChallenge(context.Background(), mockChallengeDAO)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestInsertChallenge_failsWhenInsertOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to insert challenge"))
+
+	insertChallenge := totp.MakeInsertChallenge(mockPostgresConnection)
+
+	want := totp.FailedToInsertChallenge
+	got := insertChallenge(context.Background(), totp.ChallengeDAO{})
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -0,0 +1,148 @@
+package totp
+
+import (
+	"context"
+	"time"
+)
+
+// MockUpsertSecret mocks an UpsertSecret function
+func MockUpsertSecret(err error) UpsertSecret {
+	return func(ctx context.Context, userID int, secret string) error {
+		return err
+	}
+}
+
+// MockInsertRecoveryCodes mocks an InsertRecoveryCodes function
+func MockInsertRecoveryCodes(err error) InsertRecoveryCodes {
+	return func(ctx context.Context, userID int, codes []string) error {
+		return err
+	}
+}
+
+// MockInsertChallenge mocks an InsertChallenge function
+func MockInsertChallenge(err error) InsertChallenge {
+	return func(ctx context.Context, challenge ChallengeDAO) error {
+		return err
+	}
+}
+
+// MockSelectByUserID mocks a SelectByUserID function
+func MockSelectByUserID(secondFactor DAO, err error) SelectByUserID {
+	return func(ctx context.Context, userID int) (DAO, error) {
+		return secondFactor, err
+	}
+}
+
+// MockIsEnabled mocks an IsEnabled function
+func MockIsEnabled(enabled bool, err error) IsEnabled {
+	return func(ctx context.Context, userID int) (bool, error) {
+		return enabled, err
+	}
+}
+
+// MockEnable mocks an Enable function
+func MockEnable(err error) Enable {
+	return func(ctx context.Context, userID int, step int64) error {
+		return err
+	}
+}
+
+// MockUpdateLastUsedStep mocks an UpdateLastUsedStep function
+func MockUpdateLastUsedStep(err error) UpdateLastUsedStep {
+	return func(ctx context.Context, userID int, step int64) error {
+		return err
+	}
+}
+
+// MockUseRecoveryCode mocks a UseRecoveryCode function
+func MockUseRecoveryCode(err error) UseRecoveryCode {
+	return func(ctx context.Context, userID int, code string) error {
+		return err
+	}
+}
+
+// MockUseChallenge mocks a UseChallenge function
+func MockUseChallenge(userID int, err error) UseChallenge {
+	return func(ctx context.Context, challenge string, maxAttempts int) (int, error) {
+		return userID, err
+	}
+}
+
+// MockDelete mocks a Delete function
+func MockDelete(err error) Delete {
+	return func(ctx context.Context, userID int) error {
+		return err
+	}
+}
+
+// MockDeleteChallenge mocks a DeleteChallenge function
+func MockDeleteChallenge(err error) DeleteChallenge {
+	return func(ctx context.Context, challenge string) error {
+		return err
+	}
+}
+
+// MockEnroll mocks an Enroll function
+func MockEnroll(enrollment EnrollmentDTO, err error) Enroll {
+	return func(ctx context.Context, userID int, username string) (EnrollmentDTO, error) {
+		return enrollment, err
+	}
+}
+
+// MockConfirm mocks a Confirm function
+func MockConfirm(recoveryCodes []string, err error) Confirm {
+	return func(ctx context.Context, userID int, code string) ([]string, error) {
+		return recoveryCodes, err
+	}
+}
+
+// MockCreateRecoveryCodes mocks a CreateRecoveryCodes function
+func MockCreateRecoveryCodes(recoveryCodes []string, err error) CreateRecoveryCodes {
+	return func(ctx context.Context, userID int) ([]string, error) {
+		return recoveryCodes, err
+	}
+}
+
+// MockVerify mocks a Verify function
+func MockVerify(err error) Verify {
+	return func(ctx context.Context, userID int, code string) error {
+		return err
+	}
+}
+
+// MockCreateChallenge mocks a CreateChallenge function
+func MockCreateChallenge(challenge string, expiresAt time.Time, err error) CreateChallenge {
+	return func(ctx context.Context, userID int) (string, time.Time, error) {
+		return challenge, expiresAt, err
+	}
+}
+
+// MockSecret mocks a base32 secret, the one of the test vectors of the RFC 6238
+func MockSecret() string {
+	return "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+}
+
+// MockDAO mocks an enabled DAO with the MockSecret
+func MockDAO() DAO {
+	enabledAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+
+	return DAO{
+		UserID:       1,
+		Secret:       MockSecret(),
+		EnabledAt:    &enabledAt,
+		LastUsedStep: 0,
+	}
+}
+
+// MockEnrollmentDTO mocks an EnrollmentDTO
+func MockEnrollmentDTO() EnrollmentDTO {
+	return EnrollmentDTO{
+		Secret:          MockSecret(),
+		ProvisioningURI: ProvisioningURI("AHBCC", "username", MockSecret()),
+	}
+}
+
+// MockRecoveryCodes mocks a slice of recovery codes
+func MockRecoveryCodes() []string {
+	return []string{"abcd-efgh-ijkl-mnop", "qrst-uvwx-yz23-4567"}
+}
@@ -0,0 +1,69 @@
+package totp
+
+import (
+	"context"
+	"crypto/rand"
+	"strings"
+
+	"ahbcc/internal/log"
+)
+
+// CreateRecoveryCodes creates new single-use recovery codes for the user, replacing the previous ones, and returns them.
+// They can't be retrieved afterward
+type CreateRecoveryCodes func(ctx context.Context, userID int) ([]string, error)
+
+const (
+	// recoveryCodesAmount is the amount of recovery codes each user has
+	recoveryCodesAmount int = 10
+
+	// recoveryCodeSize is the amount of random bytes of each recovery code
+	recoveryCodeSize int = 10
+
+	// recoveryCodeGroupSize is the amount of characters of each dash separated group of a recovery code
+	recoveryCodeGroupSize int = 4
+)
+
+// MakeCreateRecoveryCodes creates a new CreateRecoveryCodes
+func MakeCreateRecoveryCodes(insertRecoveryCodes InsertRecoveryCodes) CreateRecoveryCodes {
+	return func(ctx context.Context, userID int) ([]string, error) {
+		codes := make([]string, 0, recoveryCodesAmount)
+		for range recoveryCodesAmount {
+			random := make([]byte, recoveryCodeSize)
+			_, err := rand.Read(random)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return nil, FailedToCreateRecoveryCodes
+			}
+			codes = append(codes, formatRecoveryCode(strings.ToLower(encoding.EncodeToString(random))))
+		}
+
+		err := insertRecoveryCodes(ctx, userID, codes)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToCreateRecoveryCodes
+		}
+
+		return codes, nil
+	}
+}
+
+// NormalizeRecoveryCode returns the recovery code without its separators and in lowercase, so it can be typed either way
+func NormalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
+
+// IsRecoveryCode reports whether the given code has the shape of a recovery code rather than of a TOTP code
+func IsRecoveryCode(code string) bool {
+	return len(NormalizeRecoveryCode(code)) > digits
+}
+
+// formatRecoveryCode splits the recovery code in dash separated groups, to make it easier to copy
+func formatRecoveryCode(code string) string {
+	groups := make([]string, 0, len(code)/recoveryCodeGroupSize+1)
+	for len(code) > recoveryCodeGroupSize {
+		groups = append(groups, code[:recoveryCodeGroupSize])
+		code = code[recoveryCodeGroupSize:]
+	}
+
+	return strings.Join(append(groups, code), "-")
+}
@@ -0,0 +1,64 @@
+package totp_test
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestCreateRecoveryCodes_success(t *testing.T) {
+	var insertedCodes []string
+	mockInsertRecoveryCodes := func(ctx context.Context, userID int, codes []string) error {
+		insertedCodes = codes
+		return nil
+	}
+
+	createRecoveryCodes := totp.MakeCreateRecoveryCodes(mockInsertRecoveryCodes)
+
+	got, err := createRecoveryCodes(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Len(t, got, 10)
+	assert.Equal(t, insertedCodes, got)
+	for _, code := range got {
+		assert.Regexp(t, regexp.MustCompile(`^[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}-[a-z2-7]{4}$`), code)
+		assert.True(t, totp.IsRecoveryCode(code))
+	}
+}
+
+func TestCreateRecoveryCodes_failsWhenInsertRecoveryCodesThrowsError(t *testing.T) {
+	createRecoveryCodes := totp.MakeCreateRecoveryCodes(totp.MockInsertRecoveryCodes(errors.New("failed to insert recovery codes")))
+
+	want := totp.FailedToCreateRecoveryCodes
+	_, got := createRecoveryCodes(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+}
+
+func TestNormalizeRecoveryCode_success(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{code: "abcd-efgh-ijkl-mnop", expected: "abcdefghijklmnop"},
+		{code: "ABCD EFGH IJKL MNOP", expected: "abcdefghijklmnop"},
+		{code: "abcdefghijklmnop", expected: "abcdefghijklmnop"},
+	}
+
+	for _, tt := range tests {
+		want := tt.expected
+		got := totp.NormalizeRecoveryCode(tt.code)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestIsRecoveryCode_success(t *testing.T) {
+	assert.True(t, totp.IsRecoveryCode("abcd-efgh-ijkl-mnop"))
+	assert.False(t, totp.IsRecoveryCode("123456"))
+}
@@ -0,0 +1,70 @@
+package totp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// SelectByUserID retrieves the TOTP second factor of the given user, enabled or not
+	SelectByUserID func(ctx context.Context, userID int) (DAO, error)
+
+	// IsEnabled returns whether the given user has the two-factor authentication enabled
+	IsEnabled func(ctx context.Context, userID int) (bool, error)
+)
+
+// MakeSelectByUserID creates a new SelectByUserID
+func MakeSelectByUserID(db database.Connection) SelectByUserID {
+	const query string = `
+		SELECT user_id, secret, enabled_at, last_used_step
+		FROM users_two_factor
+		WHERE user_id = $1;
+	`
+
+	return func(ctx context.Context, userID int) (DAO, error) {
+		var secondFactor DAO
+		err := db.QueryRow(ctx, query, userID).Scan(
+			&secondFactor.UserID,
+			&secondFactor.Secret,
+			&secondFactor.EnabledAt,
+			&secondFactor.LastUsedStep,
+		)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return DAO{}, TwoFactorNotEnrolled
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return DAO{}, FailedToExecuteSelectByUserID
+		}
+
+		return secondFactor, nil
+	}
+}
+
+// MakeIsEnabled creates a new IsEnabled
+func MakeIsEnabled(db database.Connection) IsEnabled {
+	const query string = `
+		SELECT EXISTS (
+			SELECT 1
+			FROM users_two_factor
+			WHERE user_id = $1
+			  AND enabled_at IS NOT NULL
+		);
+	`
+
+	return func(ctx context.Context, userID int) (bool, error) {
+		var enabled bool
+		err := db.QueryRow(ctx, query, userID).Scan(&enabled)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return false, FailedToExecuteSelectEnabled
+		}
+
+		return enabled, nil
+	}
+}
@@ -0,0 +1,90 @@
+package totp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/internal/database"
+)
+
+func TestSelectByUserID_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockDAO := totp.MockDAO()
+	database.MockScan(mockPgxRow, []any{mockDAO.UserID, mockDAO.Secret, mockDAO.EnabledAt, mockDAO.LastUsedStep}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{1}).Return(mockPgxRow)
+
+	selectByUserID := totp.MakeSelectByUserID(mockPostgresConnection)
+
+	want := mockDAO
+	got, err := selectByUserID(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestSelectByUserID_failsWhenSelectOperationThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: totp.TwoFactorNotEnrolled},
+		{err: errors.New("failed to execute select operation"), expected: totp.FailedToExecuteSelectByUserID},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		selectByUserID := totp.MakeSelectByUserID(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := selectByUserID(context.Background(), 1)
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRow.AssertExpectations(t)
+	}
+}
+
+func TestIsEnabled_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{true}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{1}).Return(mockPgxRow)
+
+	isEnabled := totp.MakeIsEnabled(mockPostgresConnection)
+
+	got, err := isEnabled(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.True(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestIsEnabled_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	mockPgxRow.On("Scan", mock.Anything).Return(errors.New("failed to execute select operation"))
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+	isEnabled := totp.MakeIsEnabled(mockPostgresConnection)
+
+	want := totp.FailedToExecuteSelectEnabled
+	_, got := isEnabled(context.Background(), 1)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
@@ -0,0 +1,132 @@
+package totp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// Enable enables the pending second factor of the user, recording the time step of the code that confirmed it
+	Enable func(ctx context.Context, userID int, step int64) error
+
+	// UpdateLastUsedStep records the time step of the last accepted code of the user. It fails with WrongCode when the
+	// step isn't after the last used one, so two requests can't use the same code
+	UpdateLastUsedStep func(ctx context.Context, userID int, step int64) error
+
+	// UseRecoveryCode marks the given recovery code of the user as used. It fails with WrongCode when the user has no
+	// such unused recovery code
+	UseRecoveryCode func(ctx context.Context, userID int, code string) error
+
+	// UseChallenge consumes an attempt of the given unexpired login challenge and returns the ID of its user. It fails
+	// with NoValidChallengeFound when the challenge has no attempts left
+	UseChallenge func(ctx context.Context, challenge string, maxAttempts int) (int, error)
+)
+
+// MakeEnable creates a new Enable
+func MakeEnable(db database.Connection) Enable {
+	const query string = `
+		UPDATE users_two_factor
+		SET enabled_at = NOW(), last_used_step = $2
+		WHERE user_id = $1
+		  AND enabled_at IS NULL;
+	`
+
+	return func(ctx context.Context, userID int, step int64) error {
+		result, err := db.Exec(ctx, query, userID, step)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToEnableTwoFactor
+		}
+
+		if result.RowsAffected() == 0 {
+			log.Error(ctx, TwoFactorAlreadyEnabled.Error())
+			return TwoFactorAlreadyEnabled
+		}
+
+		return nil
+	}
+}
+
+// MakeUpdateLastUsedStep creates a new UpdateLastUsedStep
+func MakeUpdateLastUsedStep(db database.Connection) UpdateLastUsedStep {
+	const query string = `
+		UPDATE users_two_factor
+		SET last_used_step = $2
+		WHERE user_id = $1
+		  AND enabled_at IS NOT NULL
+		  AND last_used_step < $2;
+	`
+
+	return func(ctx context.Context, userID int, step int64) error {
+		result, err := db.Exec(ctx, query, userID, step)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpdateLastUsedStep
+		}
+
+		if result.RowsAffected() == 0 {
+			log.Error(ctx, WrongCode.Error())
+			return WrongCode
+		}
+
+		return nil
+	}
+}
+
+// MakeUseRecoveryCode creates a new UseRecoveryCode
+func MakeUseRecoveryCode(db database.Connection) UseRecoveryCode {
+	const query string = `
+		UPDATE users_recovery_codes
+		SET used_at = NOW()
+		WHERE user_id = $1
+		  AND code_hash = $2
+		  AND used_at IS NULL;
+	`
+
+	return func(ctx context.Context, userID int, code string) error {
+		result, err := db.Exec(ctx, query, userID, session.HashToken(NormalizeRecoveryCode(code)))
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUseRecoveryCode
+		}
+
+		if result.RowsAffected() == 0 {
+			log.Error(ctx, WrongCode.Error())
+			return WrongCode
+		}
+
+		return nil
+	}
+}
+
+// MakeUseChallenge creates a new UseChallenge
+func MakeUseChallenge(db database.Connection) UseChallenge {
+	const query string = `
+		UPDATE login_challenges
+		SET attempts = attempts + 1
+		WHERE challenge_hash = $1
+		  AND expires_at > NOW()
+		  AND attempts < $2
+		RETURNING user_id;
+	`
+
+	return func(ctx context.Context, challenge string, maxAttempts int) (int, error) {
+		var userID int
+		err := db.QueryRow(ctx, query, session.HashToken(challenge), maxAttempts).Scan(&userID)
+		if errors.Is(err, pgx.ErrNoRows) {
+			log.Error(ctx, err.Error())
+			return 0, NoValidChallengeFound
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return 0, FailedToUseChallenge
+		}
+
+		return userID, nil
+	}
+}
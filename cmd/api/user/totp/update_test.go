@@ -0,0 +1,167 @@
+package totp_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/user/totp"
+	"ahbcc/internal/database"
+)
+
+func TestEnable_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1, int64(37037036)}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	enable := totp.MakeEnable(mockPostgresConnection)
+
+	got := enable(context.Background(), 1, 37037036)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestEnable_failsWhenUpdateOperationFails(t *testing.T) {
+	tests := []struct {
+		commandTag pgconn.CommandTag
+		err        error
+		expected   error
+	}{
+		{commandTag: pgconn.NewCommandTag("UPDATE 0"), err: nil, expected: totp.TwoFactorAlreadyEnabled},
+		{commandTag: pgconn.CommandTag{}, err: errors.New("failed to enable"), expected: totp.FailedToEnableTwoFactor},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(tt.commandTag, tt.err)
+
+		enable := totp.MakeEnable(mockPostgresConnection)
+
+		want := tt.expected
+		got := enable(context.Background(), 1, 37037036)
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestUpdateLastUsedStep_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1, int64(37037036)}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	updateLastUsedStep := totp.MakeUpdateLastUsedStep(mockPostgresConnection)
+
+	got := updateLastUsedStep(context.Background(), 1, 37037036)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateLastUsedStep_failsWhenUpdateOperationFails(t *testing.T) {
+	tests := []struct {
+		commandTag pgconn.CommandTag
+		err        error
+		expected   error
+	}{
+		{commandTag: pgconn.NewCommandTag("UPDATE 0"), err: nil, expected: totp.WrongCode},
+		{commandTag: pgconn.CommandTag{}, err: errors.New("failed to update last used step"), expected: totp.FailedToUpdateLastUsedStep},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(tt.commandTag, tt.err)
+
+		updateLastUsedStep := totp.MakeUpdateLastUsedStep(mockPostgresConnection)
+
+		want := tt.expected
+		got := updateLastUsedStep(context.Background(), 1, 37037036)
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestUseRecoveryCode_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, []any{1, session.HashToken("abcdefghijklmnop")}).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	useRecoveryCode := totp.MakeUseRecoveryCode(mockPostgresConnection)
+
+	got := useRecoveryCode(context.Background(), 1, "ABCD-EFGH-IJKL-MNOP")
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUseRecoveryCode_failsWhenUpdateOperationFails(t *testing.T) {
+	tests := []struct {
+		commandTag pgconn.CommandTag
+		err        error
+		expected   error
+	}{
+		{commandTag: pgconn.NewCommandTag("UPDATE 0"), err: nil, expected: totp.WrongCode},
+		{commandTag: pgconn.CommandTag{}, err: errors.New("failed to use recovery code"), expected: totp.FailedToUseRecoveryCode},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(tt.commandTag, tt.err)
+
+		useRecoveryCode := totp.MakeUseRecoveryCode(mockPostgresConnection)
+
+		want := tt.expected
+		got := useRecoveryCode(context.Background(), 1, "abcd-efgh-ijkl-mnop")
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestUseChallenge_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRow := new(database.MockPgxRow)
+	database.MockScan(mockPgxRow, []any{1}, t)
+	mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, []any{session.HashToken("challenge"), 5}).Return(mockPgxRow)
+
+	useChallenge := totp.MakeUseChallenge(mockPostgresConnection)
+
+	want := 1
+	got, err := useChallenge(context.Background(), "challenge", 5)
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+	mockPgxRow.AssertExpectations(t)
+}
+
+func TestUseChallenge_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: pgx.ErrNoRows, expected: totp.NoValidChallengeFound},
+		{err: errors.New("failed to execute update operation"), expected: totp.FailedToUseChallenge},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPgxRow := new(database.MockPgxRow)
+		mockPgxRow.On("Scan", mock.Anything).Return(tt.err)
+		mockPostgresConnection.On("QueryRow", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRow)
+
+		useChallenge := totp.MakeUseChallenge(mockPostgresConnection)
+
+		want := tt.expected
+		_, got := useChallenge(context.Background(), "challenge", 5)
+
+		assert.Equal(t, want, got)
+		mockPostgresConnection.AssertExpectations(t)
+		mockPgxRow.AssertExpectations(t)
+	}
+}
@@ -0,0 +1,53 @@
+package totp
+
+import (
+	"context"
+	"time"
+
+	"ahbcc/internal/log"
+)
+
+// Verify verifies the given code of the enabled second factor of the user. It accepts either a TOTP code, which can't
+// be used twice, or one of the unused recovery codes of the user, which is used up
+type Verify func(ctx context.Context, userID int, code string) error
+
+// MakeVerify creates a new Verify
+func MakeVerify(selectByUserID SelectByUserID, updateLastUsedStep UpdateLastUsedStep, useRecoveryCode UseRecoveryCode) Verify {
+	return func(ctx context.Context, userID int, code string) error {
+		secondFactor, err := selectByUserID(ctx, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return err
+		}
+
+		if secondFactor.EnabledAt == nil {
+			log.Error(ctx, TwoFactorNotEnabled.Error())
+			return TwoFactorNotEnabled
+		}
+
+		if IsRecoveryCode(code) {
+			err = useRecoveryCode(ctx, userID, code)
+			if err != nil {
+				log.Error(ctx, err.Error())
+				return err
+			}
+
+			log.Info(ctx, "Recovery code used")
+			return nil
+		}
+
+		step, ok := Match(secondFactor.Secret, code, time.Now(), secondFactor.LastUsedStep)
+		if !ok {
+			log.Error(ctx, WrongCode.Error())
+			return WrongCode
+		}
+
+		err = updateLastUsedStep(ctx, userID, step)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return err
+		}
+
+		return nil
+	}
+}
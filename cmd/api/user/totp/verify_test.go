@@ -0,0 +1,61 @@
+package totp_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/user/totp"
+)
+
+func TestVerify_success(t *testing.T) {
+	code, _ := totp.Code(totp.MockSecret(), totp.Step(time.Now()))
+
+	tests := []struct {
+		code string
+	}{
+		{code: code},
+		{code: "abcd-efgh-ijkl-mnop"},
+	}
+
+	for _, tt := range tests {
+		verify := totp.MakeVerify(totp.MockSelectByUserID(totp.MockDAO(), nil), totp.MockUpdateLastUsedStep(nil), totp.MockUseRecoveryCode(nil))
+
+		got := verify(context.Background(), 1, tt.code)
+
+		assert.Nil(t, got)
+	}
+}
+
+func TestVerify_failsWhenTheCodeIsNotAccepted(t *testing.T) {
+	code, _ := totp.Code(totp.MockSecret(), totp.Step(time.Now()))
+	usedDAO := totp.MockDAO()
+	usedDAO.LastUsedStep = totp.Step(time.Now()) + 1
+	pendingDAO := totp.MockDAO()
+	pendingDAO.EnabledAt = nil
+
+	tests := []struct {
+		selectByUserID     totp.SelectByUserID
+		updateLastUsedStep totp.UpdateLastUsedStep
+		useRecoveryCode    totp.UseRecoveryCode
+		code               string
+		expected           error
+	}{
+		{selectByUserID: totp.MockSelectByUserID(totp.DAO{}, totp.TwoFactorNotEnrolled), updateLastUsedStep: totp.MockUpdateLastUsedStep(nil), useRecoveryCode: totp.MockUseRecoveryCode(nil), code: code, expected: totp.TwoFactorNotEnrolled},
+		{selectByUserID: totp.MockSelectByUserID(pendingDAO, nil), updateLastUsedStep: totp.MockUpdateLastUsedStep(nil), useRecoveryCode: totp.MockUseRecoveryCode(nil), code: code, expected: totp.TwoFactorNotEnabled},
+		{selectByUserID: totp.MockSelectByUserID(usedDAO, nil), updateLastUsedStep: totp.MockUpdateLastUsedStep(nil), useRecoveryCode: totp.MockUseRecoveryCode(nil), code: code, expected: totp.WrongCode},
+		{selectByUserID: totp.MockSelectByUserID(totp.MockDAO(), nil), updateLastUsedStep: totp.MockUpdateLastUsedStep(totp.WrongCode), useRecoveryCode: totp.MockUseRecoveryCode(nil), code: code, expected: totp.WrongCode},
+		{selectByUserID: totp.MockSelectByUserID(totp.MockDAO(), nil), updateLastUsedStep: totp.MockUpdateLastUsedStep(nil), useRecoveryCode: totp.MockUseRecoveryCode(totp.WrongCode), code: "abcd-efgh-ijkl-mnop", expected: totp.WrongCode},
+	}
+
+	for _, tt := range tests {
+		verify := totp.MakeVerify(tt.selectByUserID, tt.updateLastUsedStep, tt.useRecoveryCode)
+
+		want := tt.expected
+		got := verify(context.Background(), 1, tt.code)
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,92 @@
+package user
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+type (
+	// UpdatePassword replaces the password hash of the given user
+	UpdatePassword func(ctx context.Context, userID int, passwordHash string) error
+
+	// UpdateUsername replaces the username of the given user, which must not belong to another user
+	UpdateUsername func(ctx context.Context, userID int, username string) error
+
+	// UpdateDisabled disables or enables the given user. Disabling an already disabled user keeps the time it was
+	// first disabled
+	UpdateDisabled func(ctx context.Context, userID int, disabled bool) error
+)
+
+// MakeUpdatePassword creates a new UpdatePassword
+func MakeUpdatePassword(db database.Connection) UpdatePassword {
+	const query string = `
+		UPDATE users
+		SET password_hash = $1, password_changed_at = NOW()
+		WHERE id = $2;
+	`
+
+	return func(ctx context.Context, userID int, passwordHash string) error {
+		commandTag, err := db.Exec(ctx, query, passwordHash, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpdateUserPassword
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			log.Error(ctx, NoUserFoundForTheGivenID.Error())
+			return NoUserFoundForTheGivenID
+		}
+
+		return nil
+	}
+}
+
+// MakeUpdateUsername creates a new UpdateUsername
+func MakeUpdateUsername(db database.Connection) UpdateUsername {
+	const query string = `
+		UPDATE users
+		SET username = $1
+		WHERE id = $2;
+	`
+
+	return func(ctx context.Context, userID int, username string) error {
+		commandTag, err := db.Exec(ctx, query, username, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpdateUsername
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			log.Error(ctx, NoUserFoundForTheGivenID.Error())
+			return NoUserFoundForTheGivenID
+		}
+
+		return nil
+	}
+}
+
+// MakeUpdateDisabled creates a new UpdateDisabled
+func MakeUpdateDisabled(db database.Connection) UpdateDisabled {
+	const query string = `
+		UPDATE users
+		SET disabled_at = CASE WHEN $1 THEN COALESCE(disabled_at, NOW()) END
+		WHERE id = $2;
+	`
+
+	return func(ctx context.Context, userID int, disabled bool) error {
+		commandTag, err := db.Exec(ctx, query, disabled, userID)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpdateUserDisabled
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			log.Error(ctx, NoUserFoundForTheGivenID.Error())
+			return NoUserFoundForTheGivenID
+		}
+
+		return nil
+	}
+}
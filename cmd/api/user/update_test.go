@@ -0,0 +1,128 @@
+package user_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/user"
+	"ahbcc/internal/database"
+)
+
+func TestUpdatePassword_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	updatePassword := user.MakeUpdatePassword(mockPostgresConnection)
+
+	got := updatePassword(context.Background(), 1, "password hash")
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdatePassword_failsWhenUserDoesNotExist(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+
+	updatePassword := user.MakeUpdatePassword(mockPostgresConnection)
+
+	want := user.NoUserFoundForTheGivenID
+	got := updatePassword(context.Background(), 1, "password hash")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdatePassword_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update user password"))
+
+	updatePassword := user.MakeUpdatePassword(mockPostgresConnection)
+
+	want := user.FailedToUpdateUserPassword
+	got := updatePassword(context.Background(), 1, "password hash")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateUsername_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	updateUsername := user.MakeUpdateUsername(mockPostgresConnection)
+
+	got := updateUsername(context.Background(), 1, "new username")
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateUsername_failsWhenUserDoesNotExist(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+
+	updateUsername := user.MakeUpdateUsername(mockPostgresConnection)
+
+	want := user.NoUserFoundForTheGivenID
+	got := updateUsername(context.Background(), 1, "new username")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateUsername_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update user"))
+
+	updateUsername := user.MakeUpdateUsername(mockPostgresConnection)
+
+	want := user.FailedToUpdateUsername
+	got := updateUsername(context.Background(), 1, "new username")
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateDisabled_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 1"), nil)
+
+	updateDisabled := user.MakeUpdateDisabled(mockPostgresConnection)
+
+	got := updateDisabled(context.Background(), 1, true)
+
+	assert.Nil(t, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateDisabled_failsWhenUserDoesNotExist(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("UPDATE 0"), nil)
+
+	updateDisabled := user.MakeUpdateDisabled(mockPostgresConnection)
+
+	want := user.NoUserFoundForTheGivenID
+	got := updateDisabled(context.Background(), 1, true)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestUpdateDisabled_failsWhenUpdateOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to update user"))
+
+	updateDisabled := user.MakeUpdateDisabled(mockPostgresConnection)
+
+	want := user.FailedToUpdateUserDisabled
+	got := updateDisabled(context.Background(), 1, true)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
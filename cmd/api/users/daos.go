@@ -0,0 +1,17 @@
+package users
+
+import "time"
+
+// DAO represents a user alongside its activity: the amount of tweets it categorized, when it categorized the last one
+// and the amount of sessions it has open. LastCategorizedAt is nil when the user never categorized a tweet, or when its
+// categorizations were made before their timestamp was stored
+type DAO struct {
+	ID                int        `json:"id"`
+	Username          string     `json:"username"`
+	Role              string     `json:"role"`
+	CreatedAt         time.Time  `json:"created_at"`
+	DisabledAt        *time.Time `json:"disabled_at"`
+	Categorizations   int        `json:"categorizations"`
+	LastCategorizedAt *time.Time `json:"last_categorized_at"`
+	ActiveSessions    int        `json:"active_sessions"`
+}
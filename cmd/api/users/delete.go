@@ -0,0 +1,95 @@
+package users
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+const (
+	// CascadeCategorizations deletes the categorizations of the user alongside it
+	CascadeCategorizations string = "cascade"
+
+	// AnonymizeCategorizations keeps the categorizations of the user without anyone as their author
+	AnonymizeCategorizations string = "anonymize"
+
+	// ReassignCategorizations moves the categorizations of the user to another user. The ones of the tweets that the
+	// other user already categorized are deleted, so each user keeps a single categorization per tweet
+	ReassignCategorizations string = "reassign"
+)
+
+// Delete removes the user of the given ID, alongside its sessions, its second factor and the rest of its credentials.
+// Its categorizations are deleted, anonymized or reassigned to the user of the reassignTo ID, according to the given
+// mode. Both operations are made in a single statement, so none of them is made if the other one fails
+type Delete func(ctx context.Context, userID int, mode string, reassignTo int) error
+
+// MakeDelete creates a new Delete
+func MakeDelete(db database.Connection) Delete {
+	const (
+		cascadeQuery string = `
+			WITH deleted_categorizations AS (
+				DELETE FROM categorized_tweets
+				WHERE user_id = $1
+			)
+			DELETE FROM users
+			WHERE id = $1;
+		`
+
+		anonymizeQuery string = `
+			WITH anonymized_categorizations AS (
+				UPDATE categorized_tweets
+				SET user_id = NULL
+				WHERE user_id = $1
+			)
+			DELETE FROM users
+			WHERE id = $1;
+		`
+
+		reassignQuery string = `
+			WITH duplicated_categorizations AS (
+				DELETE FROM categorized_tweets AS c
+				USING categorized_tweets AS r
+				WHERE c.user_id = $1 AND r.user_id = $2 AND r.tweet_id = c.tweet_id
+			), reassigned_categorizations AS (
+				UPDATE categorized_tweets AS c
+				SET user_id = $2
+				WHERE c.user_id = $1 AND NOT EXISTS (
+					SELECT 1 FROM categorized_tweets AS r WHERE r.user_id = $2 AND r.tweet_id = c.tweet_id
+				)
+			)
+			DELETE FROM users
+			WHERE id = $1;
+		`
+	)
+
+	return func(ctx context.Context, userID int, mode string, reassignTo int) error {
+		var query string
+		args := []any{userID}
+		switch mode {
+		case CascadeCategorizations:
+			query = cascadeQuery
+		case AnonymizeCategorizations:
+			query = anonymizeQuery
+		case ReassignCategorizations:
+			query = reassignQuery
+			args = append(args, reassignTo)
+		default:
+			log.Error(ctx, InvalidCategorizationsMode.Error())
+			return InvalidCategorizationsMode
+		}
+
+		commandTag, err := db.Exec(ctx, query, args...)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToExecuteDeleteUser
+		}
+
+		if commandTag.RowsAffected() == 0 {
+			log.Error(ctx, NoUserFoundForTheGivenID.Error())
+			return NoUserFoundForTheGivenID
+		}
+
+		return nil
+	}
+}
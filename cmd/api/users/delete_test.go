@@ -0,0 +1,75 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/users"
+	"ahbcc/internal/database"
+)
+
+func TestDelete_success(t *testing.T) {
+	tests := []struct {
+		mode string
+		args []any
+	}{
+		{mode: users.CascadeCategorizations, args: []any{2}},
+		{mode: users.AnonymizeCategorizations, args: []any{2}},
+		{mode: users.ReassignCategorizations, args: []any{2, 3}},
+	}
+
+	for _, tt := range tests {
+		mockPostgresConnection := new(database.MockPostgresConnection)
+		mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, tt.args).Return(pgconn.NewCommandTag("DELETE 1"), nil)
+
+		deleteUser := users.MakeDelete(mockPostgresConnection)
+
+		got := deleteUser(context.Background(), 2, tt.mode, 3)
+
+		assert.Nil(t, got)
+		mockPostgresConnection.AssertExpectations(t)
+	}
+}
+
+func TestDelete_failsWhenTheModeIsInvalid(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+
+	deleteUser := users.MakeDelete(mockPostgresConnection)
+
+	want := users.InvalidCategorizationsMode
+	got := deleteUser(context.Background(), 2, "invalid", 0)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertNotCalled(t, "Exec", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestDelete_failsWhenDeleteOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.CommandTag{}, errors.New("failed to delete user"))
+
+	deleteUser := users.MakeDelete(mockPostgresConnection)
+
+	want := users.FailedToExecuteDeleteUser
+	got := deleteUser(context.Background(), 2, users.CascadeCategorizations, 0)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestDelete_failsWhenTheUserDoesNotExist(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPostgresConnection.On("Exec", mock.Anything, mock.Anything, mock.Anything).Return(pgconn.NewCommandTag("DELETE 0"), nil)
+
+	deleteUser := users.MakeDelete(mockPostgresConnection)
+
+	want := users.NoUserFoundForTheGivenID
+	got := deleteUser(context.Background(), 2, users.AnonymizeCategorizations, 0)
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
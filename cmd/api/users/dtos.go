@@ -0,0 +1,13 @@
+package users
+
+type (
+	// StatusDTO is the body of the endpoint PUT /users/{user_id}/status/v1
+	StatusDTO struct {
+		Disabled *bool `json:"disabled"`
+	}
+
+	// UsernameDTO is the body of the endpoint PUT /users/{user_id}/username/v1
+	UsernameDTO struct {
+		Username string `json:"username"`
+	}
+)
@@ -0,0 +1,42 @@
+package users
+
+import "errors"
+
+var (
+	FailedToExecuteSelectAll              = errors.New("failed to execute select all users")
+	FailedToExecuteCollectRowsInSelectAll = errors.New("failed to execute collect rows in select all users")
+	FailedToExecuteDeleteUser             = errors.New("failed to execute delete user")
+	NoUserFoundForTheGivenID              = errors.New("no user found for the given id")
+	InvalidCategorizationsMode            = errors.New("invalid categorizations mode, it must be cascade, anonymize or reassign")
+	FailedToAuthorizeUser                 = errors.New("failed to authorize user")
+	FailedToRetrieveUsers                 = errors.New("failed to retrieve users")
+	CannotManageOwnAccount                = errors.New("admins can't disable or delete their own account")
+	FailedToUpdateUserStatus              = errors.New("failed to update user status")
+	UsernameAlreadyTaken                  = errors.New("username already taken")
+	FailedToRetrieveIfUsernameIsTaken     = errors.New("failed to retrieve if username is taken")
+	FailedToRenameUser                    = errors.New("failed to rename user")
+	InvalidReassignTarget                 = errors.New("invalid reassign target, it must be another existing user")
+	FailedToRetrieveReassignTarget        = errors.New("failed to retrieve reassign target")
+	FailedToDeleteUser                    = errors.New("failed to delete user")
+	AuthorizationTokenIsRequired          = errors.New("authorization token is required")
+	InvalidUserID                         = errors.New("invalid user id")
+	MissingDisabled                       = errors.New("missing disabled")
+	MissingUsername                       = errors.New("missing username")
+	InvalidReassignTo                     = errors.New("invalid reassign_to")
+)
+
+const (
+	AuthorizationTokenRequired  string = "Authorization token is required"
+	InvalidRequestBody          string = "Invalid request body"
+	InvalidURLParameter         string = "Invalid url parameter"
+	InvalidQueryParameterFormat string = "Invalid query parameter format"
+	UserNotAuthorized           string = "User not authorized"
+	InvalidOrExpiredToken       string = "Invalid or expired session token"
+	UserNotFound                string = "User not found"
+	OwnAccountCannotBeManaged   string = "Admins can't disable or delete their own account"
+	UsernameIsAlreadyTaken      string = "Username already taken"
+	FailedToListUsers           string = "Failed to retrieve users"
+	FailedToChangeUserStatus    string = "Failed to update user status"
+	FailedToChangeUsername      string = "Failed to rename user"
+	FailedToRemoveUser          string = "Failed to delete user"
+)
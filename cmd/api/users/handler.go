@@ -0,0 +1,210 @@
+package users
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/internal/http/response"
+	"ahbcc/internal/log"
+)
+
+// ListHandlerV1 HTTP Handler of the endpoint GET /users/v1
+func ListHandlerV1(list List) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		users, err := list(ctx, token)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToListUsers, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "Users successfully retrieved", users, nil)
+	}
+}
+
+// SetStatusHandlerV1 HTTP Handler of the endpoint PUT /users/{user_id}/status/v1
+func SetStatusHandlerV1(setStatus SetStatus) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		userID, err := strconv.Atoi(r.PathValue("user_id"))
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidURLParameter, nil, InvalidUserID)
+			return
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		var body StatusDTO
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		if body.Disabled == nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, MissingDisabled)
+			return
+		}
+		ctx = log.With(ctx, log.Param("disabled", *body.Disabled))
+
+		err = setStatus(ctx, token, userID, *body.Disabled)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			case errors.Is(err, CannotManageOwnAccount):
+				response.Send(ctx, w, http.StatusConflict, OwnAccountCannotBeManaged, nil, err)
+			case errors.Is(err, NoUserFoundForTheGivenID):
+				response.Send(ctx, w, http.StatusNotFound, UserNotFound, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToChangeUserStatus, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "User status successfully updated", nil, nil)
+	}
+}
+
+// RenameHandlerV1 HTTP Handler of the endpoint PUT /users/{user_id}/username/v1
+func RenameHandlerV1(rename Rename) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		userID, err := strconv.Atoi(r.PathValue("user_id"))
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidURLParameter, nil, InvalidUserID)
+			return
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		var body UsernameDTO
+		err = json.NewDecoder(r.Body).Decode(&body)
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, err)
+			return
+		}
+
+		username := strings.TrimSpace(body.Username)
+		if username == "" {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidRequestBody, nil, MissingUsername)
+			return
+		}
+		ctx = log.With(ctx, log.Param("username", username))
+
+		err = rename(ctx, token, userID, username)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			case errors.Is(err, UsernameAlreadyTaken):
+				response.Send(ctx, w, http.StatusConflict, UsernameIsAlreadyTaken, nil, err)
+			case errors.Is(err, NoUserFoundForTheGivenID):
+				response.Send(ctx, w, http.StatusNotFound, UserNotFound, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToChangeUsername, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "User successfully renamed", nil, nil)
+	}
+}
+
+// RemoveHandlerV1 HTTP Handler of the endpoint DELETE /users/{user_id}/v1. The categorizations query parameter sets
+// what happens with the categorizations of the user: cascade (the default), anonymize or reassign. The last one
+// requires the reassign_to query parameter, with the ID of the user that receives them
+func RemoveHandlerV1(remove Remove) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+
+		token := r.Header.Get("X-Session-Token")
+		if token == "" {
+			response.Send(ctx, w, http.StatusUnauthorized, AuthorizationTokenRequired, nil, AuthorizationTokenIsRequired)
+			return
+		}
+
+		userID, err := strconv.Atoi(r.PathValue("user_id"))
+		if err != nil {
+			response.Send(ctx, w, http.StatusBadRequest, InvalidURLParameter, nil, InvalidUserID)
+			return
+		}
+		ctx = log.With(ctx, log.Param("user_id", userID))
+
+		mode := r.URL.Query().Get("categorizations")
+		switch mode {
+		case "":
+			mode = CascadeCategorizations
+		case CascadeCategorizations, AnonymizeCategorizations, ReassignCategorizations:
+		default:
+			response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, InvalidCategorizationsMode)
+			return
+		}
+		ctx = log.With(ctx, log.Param("categorizations", mode))
+
+		var reassignTo int
+		if mode == ReassignCategorizations {
+			reassignTo, err = strconv.Atoi(r.URL.Query().Get("reassign_to"))
+			if err != nil {
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, InvalidReassignTo)
+				return
+			}
+			ctx = log.With(ctx, log.Param("reassign_to", reassignTo))
+		}
+
+		err = remove(ctx, token, userID, mode, reassignTo)
+		if err != nil {
+			switch {
+			case errors.Is(err, auth.InvalidSessionToken):
+				response.Send(ctx, w, http.StatusUnauthorized, InvalidOrExpiredToken, nil, err)
+			case errors.Is(err, auth.UserNotAuthorized):
+				response.Send(ctx, w, http.StatusForbidden, UserNotAuthorized, nil, err)
+			case errors.Is(err, CannotManageOwnAccount):
+				response.Send(ctx, w, http.StatusConflict, OwnAccountCannotBeManaged, nil, err)
+			case errors.Is(err, InvalidReassignTarget), errors.Is(err, InvalidCategorizationsMode):
+				response.Send(ctx, w, http.StatusBadRequest, InvalidQueryParameterFormat, nil, err)
+			case errors.Is(err, NoUserFoundForTheGivenID):
+				response.Send(ctx, w, http.StatusNotFound, UserNotFound, nil, err)
+			default:
+				response.Send(ctx, w, http.StatusInternalServerError, FailedToRemoveUser, nil, err)
+			}
+			return
+		}
+
+		response.Send(ctx, w, http.StatusOK, "User successfully deleted", nil, nil)
+	}
+}
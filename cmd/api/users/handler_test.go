@@ -0,0 +1,358 @@
+package users_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/users"
+)
+
+func TestListHandlerV1_success(t *testing.T) {
+	mockList := users.MockList(users.MockDAOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/users/v1", nil)
+	mockRequest.Header.Set("X-Session-Token", "token")
+
+	listHandlerV1 := users.ListHandlerV1(mockList)
+
+	listHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+
+	var response struct {
+		Data []users.DAO `json:"data"`
+	}
+	err := json.NewDecoder(mockResponseWriter.Body).Decode(&response)
+	assert.NoError(t, err)
+	assert.Equal(t, users.MockDAOs(), response.Data)
+}
+
+func TestListHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockList := users.MockList(users.MockDAOs(), nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/users/v1", nil)
+
+	listHandlerV1 := users.ListHandlerV1(mockList)
+
+	listHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestListHandlerV1_failsWhenListThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: errors.New("failed to list users"), expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockList := users.MockList(nil, tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodGet, "/users/v1", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+
+		listHandlerV1 := users.ListHandlerV1(mockList)
+
+		listHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSetStatusHandlerV1_success(t *testing.T) {
+	mockSetStatus := users.MockSetStatus(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/status/v1", bytes.NewReader([]byte(`{"disabled": true}`)))
+	mockRequest.Header.Set("X-Session-Token", "token")
+	mockRequest.SetPathValue("user_id", "2")
+
+	setStatusHandlerV1 := users.SetStatusHandlerV1(mockSetStatus)
+
+	setStatusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestSetStatusHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockSetStatus := users.MockSetStatus(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/status/v1", bytes.NewReader([]byte(`{"disabled": true}`)))
+	mockRequest.SetPathValue("user_id", "2")
+
+	setStatusHandlerV1 := users.SetStatusHandlerV1(mockSetStatus)
+
+	setStatusHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestSetStatusHandlerV1_failsWhenTheRequestIsInvalid(t *testing.T) {
+	tests := []struct {
+		userID string
+		body   string
+	}{
+		{userID: "invalid", body: `{"disabled": true}`},
+		{userID: "2", body: `{"disabled": "yes"}`},
+		{userID: "2", body: `{}`},
+	}
+
+	for _, tt := range tests {
+		mockSetStatus := users.MockSetStatus(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/status/v1", bytes.NewReader([]byte(tt.body)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("user_id", tt.userID)
+
+		setStatusHandlerV1 := users.SetStatusHandlerV1(mockSetStatus)
+
+		setStatusHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSetStatusHandlerV1_failsWhenSetStatusThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: users.CannotManageOwnAccount, expected: http.StatusConflict},
+		{err: users.NoUserFoundForTheGivenID, expected: http.StatusNotFound},
+		{err: users.FailedToUpdateUserStatus, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockSetStatus := users.MockSetStatus(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/status/v1", bytes.NewReader([]byte(`{"disabled": false}`)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("user_id", "2")
+
+		setStatusHandlerV1 := users.SetStatusHandlerV1(mockSetStatus)
+
+		setStatusHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRenameHandlerV1_success(t *testing.T) {
+	mockRename := users.MockRename(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/username/v1", bytes.NewReader([]byte(`{"username": "new username"}`)))
+	mockRequest.Header.Set("X-Session-Token", "token")
+	mockRequest.SetPathValue("user_id", "2")
+
+	renameHandlerV1 := users.RenameHandlerV1(mockRename)
+
+	renameHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusOK
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRenameHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockRename := users.MockRename(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/username/v1", bytes.NewReader([]byte(`{"username": "new username"}`)))
+	mockRequest.SetPathValue("user_id", "2")
+
+	renameHandlerV1 := users.RenameHandlerV1(mockRename)
+
+	renameHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRenameHandlerV1_failsWhenTheRequestIsInvalid(t *testing.T) {
+	tests := []struct {
+		userID string
+		body   string
+	}{
+		{userID: "invalid", body: `{"username": "new username"}`},
+		{userID: "2", body: `{"username": 1}`},
+		{userID: "2", body: `{"username": "  "}`},
+	}
+
+	for _, tt := range tests {
+		mockRename := users.MockRename(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/username/v1", bytes.NewReader([]byte(tt.body)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("user_id", tt.userID)
+
+		renameHandlerV1 := users.RenameHandlerV1(mockRename)
+
+		renameHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRenameHandlerV1_failsWhenRenameThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: users.UsernameAlreadyTaken, expected: http.StatusConflict},
+		{err: users.NoUserFoundForTheGivenID, expected: http.StatusNotFound},
+		{err: users.FailedToRenameUser, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockRename := users.MockRename(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodPut, "/users/2/username/v1", bytes.NewReader([]byte(`{"username": "new username"}`)))
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("user_id", "2")
+
+		renameHandlerV1 := users.RenameHandlerV1(mockRename)
+
+		renameHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRemoveHandlerV1_success(t *testing.T) {
+	for _, query := range []string{"", "?categorizations=cascade", "?categorizations=anonymize", "?categorizations=reassign&reassign_to=3"} {
+		mockRemove := users.MockRemove(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/users/2/v1"+query, nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("user_id", "2")
+
+		removeHandlerV1 := users.RemoveHandlerV1(mockRemove)
+
+		removeHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusOK
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRemoveHandlerV1_failsWhenTheTokenIsNotInTheHeader(t *testing.T) {
+	mockRemove := users.MockRemove(nil)
+	mockResponseWriter := httptest.NewRecorder()
+	mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/users/2/v1", nil)
+	mockRequest.SetPathValue("user_id", "2")
+
+	removeHandlerV1 := users.RemoveHandlerV1(mockRemove)
+
+	removeHandlerV1(mockResponseWriter, mockRequest)
+
+	want := http.StatusUnauthorized
+	got := mockResponseWriter.Result().StatusCode
+
+	assert.Equal(t, want, got)
+}
+
+func TestRemoveHandlerV1_failsWhenTheRequestIsInvalid(t *testing.T) {
+	tests := []struct {
+		userID string
+		query  string
+	}{
+		{userID: "invalid", query: ""},
+		{userID: "2", query: "?categorizations=invalid"},
+		{userID: "2", query: "?categorizations=reassign"},
+		{userID: "2", query: "?categorizations=reassign&reassign_to=invalid"},
+	}
+
+	for _, tt := range tests {
+		mockRemove := users.MockRemove(nil)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/users/2/v1"+tt.query, nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("user_id", tt.userID)
+
+		removeHandlerV1 := users.RemoveHandlerV1(mockRemove)
+
+		removeHandlerV1(mockResponseWriter, mockRequest)
+
+		want := http.StatusBadRequest
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRemoveHandlerV1_failsWhenRemoveThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected int
+	}{
+		{err: auth.UserNotAuthorized, expected: http.StatusForbidden},
+		{err: auth.InvalidSessionToken, expected: http.StatusUnauthorized},
+		{err: users.CannotManageOwnAccount, expected: http.StatusConflict},
+		{err: users.InvalidReassignTarget, expected: http.StatusBadRequest},
+		{err: users.NoUserFoundForTheGivenID, expected: http.StatusNotFound},
+		{err: users.FailedToDeleteUser, expected: http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		mockRemove := users.MockRemove(tt.err)
+		mockResponseWriter := httptest.NewRecorder()
+		mockRequest, _ := http.NewRequestWithContext(context.Background(), http.MethodDelete, "/users/2/v1?categorizations=reassign&reassign_to=3", nil)
+		mockRequest.Header.Set("X-Session-Token", "token")
+		mockRequest.SetPathValue("user_id", "2")
+
+		removeHandlerV1 := users.RemoveHandlerV1(mockRemove)
+
+		removeHandlerV1(mockResponseWriter, mockRequest)
+
+		want := tt.expected
+		got := mockResponseWriter.Result().StatusCode
+
+		assert.Equal(t, want, got)
+	}
+}
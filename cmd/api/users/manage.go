@@ -0,0 +1,159 @@
+package users
+
+import (
+	"context"
+	"errors"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/internal/log"
+)
+
+type (
+	// List returns all the users with their activity. Only the admins are allowed to do it
+	List func(ctx context.Context, token string) ([]DAO, error)
+
+	// SetStatus disables or enables the user of the given ID. A disabled user can't log in, and all its sessions are
+	// revoked. Only the admins are allowed to do it, and never on their own account
+	SetStatus func(ctx context.Context, token string, userID int, disabled bool) error
+
+	// Rename replaces the username of the user of the given ID, as long as no other user has the new one. Only the
+	// admins are allowed to do it
+	Rename func(ctx context.Context, token string, userID int, username string) error
+
+	// Remove deletes the user of the given ID, and deletes, anonymizes or reassigns its categorizations according to
+	// the given mode. When they are reassigned, the reassignTo user must exist. Only the admins are allowed to do it,
+	// and never on their own account
+	Remove func(ctx context.Context, token string, userID int, mode string, reassignTo int) error
+)
+
+// MakeList creates a new List
+func MakeList(authorize auth.Authorize, selectAll SelectAll) List {
+	return func(ctx context.Context, token string) ([]DAO, error) {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		users, err := selectAll(ctx)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToRetrieveUsers
+		}
+
+		return users, nil
+	}
+}
+
+// MakeSetStatus creates a new SetStatus
+func MakeSetStatus(authorize auth.Authorize, updateDisabled user.UpdateDisabled, deleteAllSessions session.DeleteAll) SetStatus {
+	return func(ctx context.Context, token string, userID int, disabled bool) error {
+		adminID, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		if adminID == userID {
+			log.Error(ctx, CannotManageOwnAccount.Error())
+			return CannotManageOwnAccount
+		}
+
+		err = updateDisabled(ctx, userID, disabled)
+		if errors.Is(err, user.NoUserFoundForTheGivenID) {
+			log.Error(ctx, err.Error())
+			return NoUserFoundForTheGivenID
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToUpdateUserStatus
+		}
+
+		if disabled {
+			// The sessions are already unusable once the user is disabled, so failing to delete them isn't an error
+			_, err = deleteAllSessions(ctx, userID)
+			if err != nil {
+				log.Warn(ctx, err.Error())
+			}
+		}
+
+		return nil
+	}
+}
+
+// MakeRename creates a new Rename
+func MakeRename(authorize auth.Authorize, userExists user.Exists, updateUsername user.UpdateUsername) Rename {
+	return func(ctx context.Context, token string, userID int, username string) error {
+		_, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		exists, err := userExists(ctx, username)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToRetrieveIfUsernameIsTaken
+		}
+
+		if exists {
+			log.Error(ctx, UsernameAlreadyTaken.Error())
+			return UsernameAlreadyTaken
+		}
+
+		err = updateUsername(ctx, userID, username)
+		if errors.Is(err, user.NoUserFoundForTheGivenID) {
+			log.Error(ctx, err.Error())
+			return NoUserFoundForTheGivenID
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToRenameUser
+		}
+
+		return nil
+	}
+}
+
+// MakeRemove creates a new Remove
+func MakeRemove(authorize auth.Authorize, selectUserByID user.SelectByID, deleteUser Delete) Remove {
+	return func(ctx context.Context, token string, userID int, mode string, reassignTo int) error {
+		adminID, err := authorize(ctx, token, user.AdminRole)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return auth.AuthorizationError(err, FailedToAuthorizeUser)
+		}
+
+		if adminID == userID {
+			log.Error(ctx, CannotManageOwnAccount.Error())
+			return CannotManageOwnAccount
+		}
+
+		if mode == ReassignCategorizations {
+			if reassignTo == userID {
+				log.Error(ctx, InvalidReassignTarget.Error())
+				return InvalidReassignTarget
+			}
+
+			_, err = selectUserByID(ctx, reassignTo)
+			if errors.Is(err, user.NoUserFoundForTheGivenID) {
+				log.Error(ctx, err.Error())
+				return InvalidReassignTarget
+			} else if err != nil {
+				log.Error(ctx, err.Error())
+				return FailedToRetrieveReassignTarget
+			}
+		}
+
+		err = deleteUser(ctx, userID, mode, reassignTo)
+		if errors.Is(err, NoUserFoundForTheGivenID) || errors.Is(err, InvalidCategorizationsMode) {
+			log.Error(ctx, err.Error())
+			return err
+		} else if err != nil {
+			log.Error(ctx, err.Error())
+			return FailedToDeleteUser
+		}
+
+		return nil
+	}
+}
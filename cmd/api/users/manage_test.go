@@ -0,0 +1,315 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/cmd/api/auth"
+	"ahbcc/cmd/api/user"
+	"ahbcc/cmd/api/user/session"
+	"ahbcc/cmd/api/users"
+)
+
+func TestList_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectAll := users.MockSelectAll(users.MockDAOs(), nil)
+
+	list := users.MakeList(mockAuthorize, mockSelectAll)
+
+	want := users.MockDAOs()
+	got, err := list(context.Background(), "token")
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+}
+
+func TestList_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: users.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectAll := users.MockSelectAll(users.MockDAOs(), nil)
+
+		list := users.MakeList(mockAuthorize, mockSelectAll)
+
+		want := tt.expected
+		_, got := list(context.Background(), "token")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestList_failsWhenSelectAllThrowsError(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectAll := users.MockSelectAll(nil, errors.New("failed to select all"))
+
+	list := users.MakeList(mockAuthorize, mockSelectAll)
+
+	want := users.FailedToRetrieveUsers
+	_, got := list(context.Background(), "token")
+
+	assert.Equal(t, want, got)
+}
+
+func TestSetStatus_success(t *testing.T) {
+	for _, disabled := range []bool{true, false} {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockUpdateDisabled := user.MockUpdateDisabled(nil)
+		mockDeleteAllSessions := session.MockDeleteAll(0, errors.New("failed to delete all sessions"))
+
+		setStatus := users.MakeSetStatus(mockAuthorize, mockUpdateDisabled, mockDeleteAllSessions)
+
+		got := setStatus(context.Background(), "token", 2, disabled)
+
+		assert.Nil(t, got)
+	}
+}
+
+func TestSetStatus_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: users.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockUpdateDisabled := user.MockUpdateDisabled(nil)
+		mockDeleteAllSessions := session.MockDeleteAll(0, nil)
+
+		setStatus := users.MakeSetStatus(mockAuthorize, mockUpdateDisabled, mockDeleteAllSessions)
+
+		want := tt.expected
+		got := setStatus(context.Background(), "token", 2, true)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestSetStatus_failsWhenTheAdminManagesItsOwnAccount(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockUpdateDisabled := user.MockUpdateDisabled(nil)
+	mockDeleteAllSessions := session.MockDeleteAll(0, nil)
+
+	setStatus := users.MakeSetStatus(mockAuthorize, mockUpdateDisabled, mockDeleteAllSessions)
+
+	want := users.CannotManageOwnAccount
+	got := setStatus(context.Background(), "token", 1, true)
+
+	assert.Equal(t, want, got)
+}
+
+func TestSetStatus_failsWhenUpdateDisabledThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: user.NoUserFoundForTheGivenID, expected: users.NoUserFoundForTheGivenID},
+		{err: user.FailedToUpdateUserDisabled, expected: users.FailedToUpdateUserStatus},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockUpdateDisabled := user.MockUpdateDisabled(tt.err)
+		mockDeleteAllSessions := session.MockDeleteAll(0, nil)
+
+		setStatus := users.MakeSetStatus(mockAuthorize, mockUpdateDisabled, mockDeleteAllSessions)
+
+		want := tt.expected
+		got := setStatus(context.Background(), "token", 2, true)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRename_success(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockUserExists := user.MockExists(false, nil)
+	mockUpdateUsername := user.MockUpdateUsername(nil)
+
+	rename := users.MakeRename(mockAuthorize, mockUserExists, mockUpdateUsername)
+
+	got := rename(context.Background(), "token", 2, "new username")
+
+	assert.Nil(t, got)
+}
+
+func TestRename_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: users.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockUserExists := user.MockExists(false, nil)
+		mockUpdateUsername := user.MockUpdateUsername(nil)
+
+		rename := users.MakeRename(mockAuthorize, mockUserExists, mockUpdateUsername)
+
+		want := tt.expected
+		got := rename(context.Background(), "token", 2, "new username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRename_failsWhenUserExistsThrowsError(t *testing.T) {
+	tests := []struct {
+		exists   bool
+		err      error
+		expected error
+	}{
+		{exists: true, expected: users.UsernameAlreadyTaken},
+		{err: user.FailedToRetrieveIfUserAlreadyExists, expected: users.FailedToRetrieveIfUsernameIsTaken},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockUserExists := user.MockExists(tt.exists, tt.err)
+		mockUpdateUsername := user.MockUpdateUsername(nil)
+
+		rename := users.MakeRename(mockAuthorize, mockUserExists, mockUpdateUsername)
+
+		want := tt.expected
+		got := rename(context.Background(), "token", 2, "new username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRename_failsWhenUpdateUsernameThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: user.NoUserFoundForTheGivenID, expected: users.NoUserFoundForTheGivenID},
+		{err: user.FailedToUpdateUsername, expected: users.FailedToRenameUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockUserExists := user.MockExists(false, nil)
+		mockUpdateUsername := user.MockUpdateUsername(tt.err)
+
+		rename := users.MakeRename(mockAuthorize, mockUserExists, mockUpdateUsername)
+
+		want := tt.expected
+		got := rename(context.Background(), "token", 2, "new username")
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRemove_success(t *testing.T) {
+	for _, mode := range []string{users.CascadeCategorizations, users.AnonymizeCategorizations, users.ReassignCategorizations} {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockDeleteUser := users.MockDelete(nil)
+
+		remove := users.MakeRemove(mockAuthorize, mockSelectUserByID, mockDeleteUser)
+
+		got := remove(context.Background(), "token", 2, mode, 3)
+
+		assert.Nil(t, got)
+	}
+}
+
+func TestRemove_failsWhenAuthorizeThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: auth.UserNotAuthorized, expected: auth.UserNotAuthorized},
+		{err: errors.New("failed to authorize"), expected: users.FailedToAuthorizeUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(-1, tt.err)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockDeleteUser := users.MockDelete(nil)
+
+		remove := users.MakeRemove(mockAuthorize, mockSelectUserByID, mockDeleteUser)
+
+		want := tt.expected
+		got := remove(context.Background(), "token", 2, users.CascadeCategorizations, 0)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRemove_failsWhenTheAdminManagesItsOwnAccount(t *testing.T) {
+	mockAuthorize := auth.MockAuthorize(1, nil)
+	mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+	mockDeleteUser := users.MockDelete(nil)
+
+	remove := users.MakeRemove(mockAuthorize, mockSelectUserByID, mockDeleteUser)
+
+	want := users.CannotManageOwnAccount
+	got := remove(context.Background(), "token", 1, users.CascadeCategorizations, 0)
+
+	assert.Equal(t, want, got)
+}
+
+func TestRemove_failsWhenTheReassignTargetIsInvalid(t *testing.T) {
+	tests := []struct {
+		reassignTo int
+		err        error
+		expected   error
+	}{
+		{reassignTo: 2, expected: users.InvalidReassignTarget},
+		{reassignTo: 3, err: user.NoUserFoundForTheGivenID, expected: users.InvalidReassignTarget},
+		{reassignTo: 3, err: user.FailedExecuteQueryToRetrieveUser, expected: users.FailedToRetrieveReassignTarget},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.DAO{}, tt.err)
+		mockDeleteUser := users.MockDelete(nil)
+
+		remove := users.MakeRemove(mockAuthorize, mockSelectUserByID, mockDeleteUser)
+
+		want := tt.expected
+		got := remove(context.Background(), "token", 2, users.ReassignCategorizations, tt.reassignTo)
+
+		assert.Equal(t, want, got)
+	}
+}
+
+func TestRemove_failsWhenDeleteUserThrowsError(t *testing.T) {
+	tests := []struct {
+		err      error
+		expected error
+	}{
+		{err: users.NoUserFoundForTheGivenID, expected: users.NoUserFoundForTheGivenID},
+		{err: users.InvalidCategorizationsMode, expected: users.InvalidCategorizationsMode},
+		{err: users.FailedToExecuteDeleteUser, expected: users.FailedToDeleteUser},
+	}
+
+	for _, tt := range tests {
+		mockAuthorize := auth.MockAuthorize(1, nil)
+		mockSelectUserByID := user.MockSelectByID(user.MockDAO(), nil)
+		mockDeleteUser := users.MockDelete(tt.err)
+
+		remove := users.MakeRemove(mockAuthorize, mockSelectUserByID, mockDeleteUser)
+
+		want := tt.expected
+		got := remove(context.Background(), "token", 2, users.CascadeCategorizations, 0)
+
+		assert.Equal(t, want, got)
+	}
+}
@@ -0,0 +1,61 @@
+package users
+
+import (
+	"context"
+	"time"
+)
+
+// MockSelectAll mocks a SelectAll function
+func MockSelectAll(daos []DAO, err error) SelectAll {
+	return func(ctx context.Context) ([]DAO, error) {
+		return daos, err
+	}
+}
+
+// MockDelete mocks a Delete function
+func MockDelete(err error) Delete {
+	return func(ctx context.Context, userID int, mode string, reassignTo int) error {
+		return err
+	}
+}
+
+// MockList mocks a List function
+func MockList(users []DAO, err error) List {
+	return func(ctx context.Context, token string) ([]DAO, error) {
+		return users, err
+	}
+}
+
+// MockSetStatus mocks a SetStatus function
+func MockSetStatus(err error) SetStatus {
+	return func(ctx context.Context, token string, userID int, disabled bool) error {
+		return err
+	}
+}
+
+// MockRename mocks a Rename function
+func MockRename(err error) Rename {
+	return func(ctx context.Context, token string, userID int, username string) error {
+		return err
+	}
+}
+
+// MockRemove mocks a Remove function
+func MockRemove(err error) Remove {
+	return func(ctx context.Context, token string, userID int, mode string, reassignTo int) error {
+		return err
+	}
+}
+
+// MockDAOs mocks a []DAO
+func MockDAOs() []DAO {
+	createdAt := time.Date(2025, time.March, 1, 0, 0, 0, 0, time.UTC)
+	lastCategorizedAt := time.Date(2025, time.March, 8, 12, 0, 0, 0, time.UTC)
+	disabledAt := time.Date(2025, time.March, 10, 0, 0, 0, 0, time.UTC)
+
+	return []DAO{
+		{ID: 1, Username: "admin", Role: "ADMIN", CreatedAt: createdAt, ActiveSessions: 1},
+		{ID: 2, Username: "annotator", Role: "ANNOTATOR", CreatedAt: createdAt, Categorizations: 25, LastCategorizedAt: &lastCategorizedAt, ActiveSessions: 2},
+		{ID: 3, Username: "disabled", Role: "ANNOTATOR", CreatedAt: createdAt, DisabledAt: &disabledAt, Categorizations: 4, LastCategorizedAt: &lastCategorizedAt},
+	}
+}
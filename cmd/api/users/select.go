@@ -0,0 +1,47 @@
+package users
+
+import (
+	"context"
+
+	"ahbcc/internal/database"
+	"ahbcc/internal/log"
+)
+
+// SelectAll returns all the users with their activity, ordered by ID
+type SelectAll func(ctx context.Context) ([]DAO, error)
+
+// MakeSelectAll creates a new SelectAll
+func MakeSelectAll(db database.Connection, collectRows database.CollectRows[DAO]) SelectAll {
+	const query string = `
+		SELECT u.id, u.username, u.role, u.created_at, u.disabled_at, COALESCE(c.categorizations, 0), c.last_categorized_at, COALESCE(s.active_sessions, 0)
+		FROM users AS u
+		LEFT JOIN (
+			SELECT user_id, COUNT(id) AS categorizations, MAX(created_at) AS last_categorized_at
+			FROM categorized_tweets
+			GROUP BY user_id
+		) AS c ON c.user_id = u.id
+		LEFT JOIN (
+			SELECT user_id, COUNT(id) AS active_sessions
+			FROM users_sessions
+			WHERE expires_at > NOW()
+			GROUP BY user_id
+		) AS s ON s.user_id = u.id
+		ORDER BY u.id;
+	`
+
+	return func(ctx context.Context) ([]DAO, error) {
+		rows, err := db.Query(ctx, query)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteSelectAll
+		}
+
+		users, err := collectRows(rows)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return nil, FailedToExecuteCollectRowsInSelectAll
+		}
+
+		return users, nil
+	}
+}
@@ -0,0 +1,60 @@
+package users_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+
+	"ahbcc/cmd/api/users"
+	"ahbcc/internal/database"
+)
+
+func TestSelectAll_success(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockDAOs := users.MockDAOs()
+	mockCollectRows := database.MockCollectRows[users.DAO](mockDAOs, nil)
+
+	selectAll := users.MakeSelectAll(mockPostgresConnection, mockCollectRows)
+
+	want := mockDAOs
+	got, err := selectAll(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectAll_failsWhenSelectOperationThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, errors.New("failed to execute select"))
+	mockCollectRows := database.MockCollectRows[users.DAO](nil, nil)
+
+	selectAll := users.MakeSelectAll(mockPostgresConnection, mockCollectRows)
+
+	want := users.FailedToExecuteSelectAll
+	_, got := selectAll(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
+
+func TestSelectAll_failsWhenCollectRowsThrowsError(t *testing.T) {
+	mockPostgresConnection := new(database.MockPostgresConnection)
+	mockPgxRows := new(database.MockPgxRows)
+	mockPostgresConnection.On("Query", mock.Anything, mock.Anything, mock.Anything).Return(mockPgxRows, nil)
+	mockCollectRows := database.MockCollectRows[users.DAO](nil, errors.New("failed to collect rows"))
+
+	selectAll := users.MakeSelectAll(mockPostgresConnection, mockCollectRows)
+
+	want := users.FailedToExecuteCollectRowsInSelectAll
+	_, got := selectAll(context.Background())
+
+	assert.Equal(t, want, got)
+	mockPostgresConnection.AssertExpectations(t)
+}
@@ -242,8 +242,18 @@ func parseScanValue(val any, dest interface{}, t *testing.T) {
 		}
 	case **string:
 		*d = val.(*string)
+	case *float64:
+		*d = val.(float64)
+	case **float64:
+		*d = val.(*float64)
 	case *time.Time:
 		*d = val.(time.Time)
+	case **time.Time:
+		*d = val.(*time.Time)
+	case *int64:
+		*d = val.(int64)
+	case **int64:
+		*d = val.(*int64)
 	case *bool:
 		*d = val.(bool)
 	case *[]string:
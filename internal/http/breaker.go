@@ -0,0 +1,96 @@
+package http
+
+import (
+	"sync"
+	"time"
+)
+
+type (
+	// breaker is a circuit breaker per host. After threshold consecutive failures against a host, its circuit opens
+	// and the requests to it fail fast until the cooldown passes. Then a single request is let through to probe the
+	// host: if it succeeds the circuit closes, otherwise it opens again
+	breaker struct {
+		mu        sync.Mutex
+		threshold int
+		cooldown  time.Duration
+		circuits  map[string]*circuit
+		now       func() time.Time
+	}
+
+	// circuit is the state of the breaker for a single host
+	circuit struct {
+		failures int
+		openedAt time.Time
+		probing  bool
+	}
+)
+
+// newBreaker creates a new breaker. A threshold lower than 1 disables it
+func newBreaker(threshold int, cooldown time.Duration) *breaker {
+	return &breaker{
+		threshold: threshold,
+		cooldown:  cooldown,
+		circuits:  make(map[string]*circuit),
+		now:       time.Now,
+	}
+}
+
+// allow returns true when a request can be made to the given host
+func (b *breaker) allow(host string) bool {
+	if b.threshold < 1 {
+		return true
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, found := b.circuits[host]
+	if !found || c.failures < b.threshold {
+		return true
+	}
+
+	if c.probing || b.now().Sub(c.openedAt) < b.cooldown {
+		return false
+	}
+
+	c.probing = true
+	return true
+}
+
+// succeeded closes the circuit of the given host
+func (b *breaker) succeeded(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	delete(b.circuits, host)
+}
+
+// failed counts a failure against the given host, opening its circuit when the threshold is reached
+func (b *breaker) failed(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, found := b.circuits[host]
+	if !found {
+		c = &circuit{}
+		b.circuits[host] = c
+	}
+
+	c.probing = false
+	c.failures++
+	if c.failures >= b.threshold {
+		c.openedAt = b.now()
+	}
+}
+
+// released frees the probe of the given host without counting it as a success nor as a failure, as when its request
+// is canceled by the caller
+func (b *breaker) released(host string) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	c, found := b.circuits[host]
+	if found {
+		c.probing = false
+	}
+}
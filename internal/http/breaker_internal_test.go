@@ -0,0 +1,69 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBreaker_opensAfterTheThresholdAndProbesAfterTheCooldown(t *testing.T) {
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(2, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.failed("host")
+	assert.True(t, b.allow("host"))
+
+	b.failed("host")
+	assert.False(t, b.allow("host"))
+	assert.True(t, b.allow("other-host"))
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow("host"))
+	assert.False(t, b.allow("host"))
+
+	b.failed("host")
+	assert.False(t, b.allow("host"))
+
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow("host"))
+	b.succeeded("host")
+	assert.True(t, b.allow("host"))
+	assert.True(t, b.allow("host"))
+}
+
+func TestBreaker_releasesTheProbeWithoutClosingTheCircuit(t *testing.T) {
+	now := time.Date(2025, 3, 8, 12, 0, 0, 0, time.UTC)
+	b := newBreaker(1, time.Minute)
+	b.now = func() time.Time { return now }
+
+	b.failed("host")
+	now = now.Add(time.Minute)
+	assert.True(t, b.allow("host"))
+	assert.False(t, b.allow("host"))
+
+	b.released("host")
+	assert.True(t, b.allow("host"))
+}
+
+func TestBreaker_isDisabledWhenTheThresholdIsLowerThanOne(t *testing.T) {
+	b := newBreaker(0, time.Minute)
+
+	b.failed("host")
+	b.failed("host")
+
+	assert.True(t, b.allow("host"))
+}
+
+func TestBackoff_isCappedToTheMax(t *testing.T) {
+	for retry := 1; retry <= 10; retry++ {
+		got := backoff(retry, 100*time.Millisecond, time.Second)
+
+		assert.GreaterOrEqual(t, got, time.Duration(0))
+		assert.LessOrEqual(t, got, time.Second)
+	}
+
+	assert.LessOrEqual(t, backoff(1, 100*time.Millisecond, time.Second), 100*time.Millisecond)
+	assert.Equal(t, time.Duration(0), backoff(1, 0, time.Second))
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
 	"time"
@@ -20,22 +21,49 @@ type (
 	// CustomClient represent a custom http.CustomClient
 	CustomClient struct {
 		HTTPClient *http.Client
+		config     Config
+		breaker    *breaker
+	}
+
+	// Config sets the timeouts, retries and circuit breaker of the CustomClient
+	Config struct {
+		// Timeout is the time limit of each attempt of a request
+		Timeout time.Duration
+
+		// MaxRetries is the amount of times an idempotent request is retried after a transient failure
+		MaxRetries  int
+		BackoffBase time.Duration
+		BackoffMax  time.Duration
+
+		// BreakerThreshold is the amount of consecutive failures against a host that opens its circuit, failing fast
+		// the requests to it during BreakerCooldown. A threshold lower than 1 disables the circuit breaker
+		BreakerThreshold int
+		BreakerCooldown  time.Duration
+
+		// RequestID returns the ID of the request being served, sent in the X-Request-ID header. It is optional
+		RequestID func(ctx context.Context) string
 	}
 
 	// Response represent the necessary data of the request response
 	Response struct {
-		Body   string
-		Status string
+		Body       string
+		Status     string
+		StatusCode int
 	}
 )
 
 // NewClient create a new CustomClient
-func NewClient() *CustomClient {
+func NewClient(config Config) *CustomClient {
 	return &CustomClient{
-		HTTPClient: &http.Client{Timeout: 3 * time.Second},
+		HTTPClient: &http.Client{Timeout: config.Timeout},
+		config:     config,
+		breaker:    newBreaker(config.BreakerThreshold, config.BreakerCooldown),
 	}
 }
 
+// NewRequest executes a request, canceled when the given context is done. A response with a non-2xx status code is
+// returned alongside a StatusError. When the method is idempotent, the transient failures are retried with a jittered
+// backoff, or after the time asked by the Retry-After header of the response, as long as it doesn't exceed BackoffMax
 func (c *CustomClient) NewRequest(ctx context.Context, method, url string, body interface{}) (Response, error) {
 	var jsonData []byte
 	var err error
@@ -51,17 +79,63 @@ func (c *CustomClient) NewRequest(ctx context.Context, method, url string, body
 		}
 	}
 
-	req, err := http.NewRequest(method, url, bytes.NewBuffer(jsonData))
+	retries := 0
+	if isIdempotent(method) {
+		retries = c.config.MaxRetries
+	}
+
+	resp, err := c.do(ctx, method, url, jsonData)
+	for retry := 1; retry <= retries && isRetryable(err); retry++ {
+		delay, ok := retryDelay(err, retry, c.config.BackoffBase, c.config.BackoffMax)
+		if !ok {
+			log.Warn(ctx, fmt.Sprintf("Not retrying %s %s, the server asked to wait longer than the backoff max: %s", method, url, err.Error()))
+			break
+		}
+
+		log.Warn(ctx, fmt.Sprintf("Retrying %s %s after: %s", method, url, err.Error()))
+
+		err = wait(ctx, delay)
+		if err != nil {
+			log.Error(ctx, err.Error())
+			return Response{}, err
+		}
+
+		resp, err = c.do(ctx, method, url, jsonData)
+	}
+
+	return resp, err
+}
+
+// do executes a single attempt of a request, recording its outcome in the circuit breaker of the host
+func (c *CustomClient) do(ctx context.Context, method, url string, jsonData []byte) (Response, error) {
+	req, err := http.NewRequestWithContext(ctx, method, url, bytes.NewReader(jsonData))
 	if err != nil {
 		log.Error(ctx, err.Error())
 		return Response{}, FailedToCreateRequest
 	}
 
 	req.Header.Set("Content-Type", "application/json")
+	if c.config.RequestID != nil {
+		if requestID := c.config.RequestID(ctx); requestID != "" {
+			req.Header.Set("X-Request-ID", requestID)
+		}
+	}
+
+	host := req.URL.Host
+	if !c.breaker.allow(host) {
+		log.Error(ctx, fmt.Sprintf("%s: %s", CircuitOpen.Error(), host))
+		return Response{}, CircuitOpen
+	}
 
 	resp, err := c.HTTPClient.Do(req)
 	if err != nil {
 		log.Error(ctx, err.Error())
+		if ctx.Err() != nil {
+			c.breaker.released(host)
+			return Response{}, ctx.Err()
+		}
+
+		c.breaker.failed(host)
 		return Response{}, FailedToExecuteRequest
 	}
 	defer func(body io.ReadCloser) {
@@ -74,11 +148,36 @@ func (c *CustomClient) NewRequest(ctx context.Context, method, url string, body
 	respBody, err := io.ReadAll(resp.Body)
 	if err != nil {
 		log.Error(ctx, err.Error())
+		if ctx.Err() != nil {
+			c.breaker.released(host)
+			return Response{}, ctx.Err()
+		}
+
+		c.breaker.failed(host)
 		return Response{}, FailedToReadResponse
 	}
 
-	return Response{
-		Body:   string(respBody),
-		Status: resp.Status,
-	}, nil
+	response := Response{
+		Body:       string(respBody),
+		Status:     resp.Status,
+		StatusCode: resp.StatusCode,
+	}
+
+	if resp.StatusCode >= http.StatusInternalServerError {
+		c.breaker.failed(host)
+	} else {
+		c.breaker.succeeded(host)
+	}
+
+	if resp.StatusCode < http.StatusOK || resp.StatusCode >= http.StatusMultipleChoices {
+		statusErr := &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Body: response.Body}
+		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusServiceUnavailable {
+			statusErr.RetryAfter = parseRetryAfter(resp.Header.Get("Retry-After"), time.Now())
+		}
+		err = statusErr
+		log.Error(ctx, err.Error())
+		return response, err
+	}
+
+	return response, nil
 }
@@ -0,0 +1,221 @@
+package http_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	_http "ahbcc/internal/http"
+)
+
+func mockConfig() _http.Config {
+	return _http.Config{
+		Timeout:          time.Second,
+		MaxRetries:       2,
+		BackoffBase:      time.Millisecond,
+		BackoffMax:       time.Millisecond,
+		BreakerThreshold: 3,
+		BreakerCooldown:  time.Minute,
+		RequestID: func(ctx context.Context) string {
+			return "request-1"
+		},
+	}
+}
+
+func TestNewRequest_success(t *testing.T) {
+	var gotBody, gotContentType, gotRequestID string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		gotBody = string(body)
+		gotContentType = r.Header.Get("Content-Type")
+		gotRequestID = r.Header.Get("X-Request-ID")
+		_, _ = w.Write([]byte(`{"test": "response"}`))
+	}))
+	defer server.Close()
+
+	client := _http.NewClient(mockConfig())
+
+	want := _http.Response{Body: `{"test": "response"}`, Status: "200 OK", StatusCode: http.StatusOK}
+	got, err := client.NewRequest(context.Background(), http.MethodPost, server.URL, map[string]string{"test": "body"})
+
+	assert.Nil(t, err)
+	assert.Equal(t, want, got)
+	assert.Equal(t, `{"test":"body"}`, gotBody)
+	assert.Equal(t, "application/json", gotContentType)
+	assert.Equal(t, "request-1", gotRequestID)
+}
+
+func TestNewRequest_successAfterRetryingAnIdempotentRequest(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := _http.NewClient(mockConfig())
+
+	got, err := client.NewRequest(context.Background(), http.MethodPut, server.URL, []byte(`{}`))
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, got.StatusCode)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestNewRequest_successAfterRetryingWhenTheServerAsksForIt(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 2 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		_, _ = w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := _http.NewClient(mockConfig())
+
+	got, err := client.NewRequest(context.Background(), http.MethodGet, server.URL, nil)
+
+	assert.Nil(t, err)
+	assert.Equal(t, http.StatusOK, got.StatusCode)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestNewRequest_failsWithoutRetryingWhenTheServerAsksToWaitLongerThanTheBackoffMax(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Retry-After", "120")
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	client := _http.NewClient(mockConfig())
+
+	_, err := client.NewRequest(context.Background(), http.MethodGet, server.URL, nil)
+
+	var statusErr *_http.StatusError
+	assert.True(t, errors.As(err, &statusErr))
+	assert.Equal(t, 120*time.Second, statusErr.RetryAfter)
+	assert.Equal(t, int32(1), calls.Load())
+}
+
+func TestNewRequest_failsWhenTheResponseStatusCodeIsNot2xx(t *testing.T) {
+	tests := []struct {
+		method        string
+		statusCode    int
+		expectedCalls int32
+	}{
+		{method: http.MethodPost, statusCode: http.StatusServiceUnavailable, expectedCalls: 1},
+		{method: http.MethodGet, statusCode: http.StatusServiceUnavailable, expectedCalls: 3},
+		{method: http.MethodGet, statusCode: http.StatusNotFound, expectedCalls: 1},
+		{method: http.MethodGet, statusCode: http.StatusMultipleChoices, expectedCalls: 1},
+	}
+
+	for _, tt := range tests {
+		var calls atomic.Int32
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			calls.Add(1)
+			w.WriteHeader(tt.statusCode)
+			_, _ = w.Write([]byte(`{"error": "test"}`))
+		}))
+
+		client := _http.NewClient(mockConfig())
+
+		got, err := client.NewRequest(context.Background(), tt.method, server.URL, nil)
+
+		var statusErr *_http.StatusError
+		assert.ErrorIs(t, err, _http.UnexpectedStatusCode)
+		assert.True(t, errors.As(err, &statusErr))
+		assert.Equal(t, tt.statusCode, statusErr.StatusCode)
+		assert.Equal(t, `{"error": "test"}`, statusErr.Body)
+		assert.Equal(t, tt.statusCode, got.StatusCode)
+		assert.Equal(t, tt.expectedCalls, calls.Load())
+
+		server.Close()
+	}
+}
+
+func TestNewRequest_failsWhenTheContextIsDone(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	client := _http.NewClient(mockConfig())
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	want := context.DeadlineExceeded
+	_, got := client.NewRequest(ctx, http.MethodGet, server.URL, nil)
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewRequest_failsWhenTheCircuitOfTheHostIsOpen(t *testing.T) {
+	var calls atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := _http.NewClient(mockConfig())
+
+	_, err := client.NewRequest(context.Background(), http.MethodGet, server.URL, nil)
+	assert.ErrorIs(t, err, _http.UnexpectedStatusCode)
+
+	want := _http.CircuitOpen
+	_, got := client.NewRequest(context.Background(), http.MethodGet, server.URL, nil)
+
+	assert.Equal(t, want, got)
+	assert.Equal(t, int32(3), calls.Load())
+}
+
+func TestNewRequest_failsWhenTheRequestCantBeExecuted(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	url := server.URL
+	server.Close()
+
+	client := _http.NewClient(mockConfig())
+
+	want := _http.FailedToExecuteRequest
+	_, got := client.NewRequest(context.Background(), http.MethodPost, url, nil)
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewRequest_failsWhenTheRequestCantBeCreated(t *testing.T) {
+	client := _http.NewClient(mockConfig())
+
+	want := _http.FailedToCreateRequest
+	_, got := client.NewRequest(context.Background(), "INVALID METHOD", "http://example.com", nil)
+
+	assert.Equal(t, want, got)
+}
+
+func TestNewRequest_failsWhenTheBodyCantBeMarshaled(t *testing.T) {
+	client := _http.NewClient(mockConfig())
+
+	want := _http.FailedToMarshalBody
+	_, got := client.NewRequest(context.Background(), http.MethodPost, "http://example.com", make(chan int))
+
+	assert.Equal(t, want, got)
+}
@@ -1,10 +1,36 @@
 package http
 
-import "errors"
+import (
+	"errors"
+	"fmt"
+	"time"
+)
 
 var (
 	FailedToMarshalBody    = errors.New("failed to marshal body")
 	FailedToCreateRequest  = errors.New("failed to create request")
 	FailedToExecuteRequest = errors.New("failed to execute request")
 	FailedToReadResponse   = errors.New("failed to read response")
+	UnexpectedStatusCode   = errors.New("unexpected status code")
+	CircuitOpen            = errors.New("circuit breaker is open for the host")
 )
+
+// StatusError is returned when the response has a non-2xx status code. It wraps UnexpectedStatusCode, so it can be
+// checked with errors.Is, and it keeps the status code and the body of the response, retrievable with errors.As
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Body       string
+
+	// RetryAfter is the time the server asked to wait before retrying, through the Retry-After header of a
+	// 429 Too Many Requests or a 503 Service Unavailable response. It is zero when the server didn't ask for it
+	RetryAfter time.Duration
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("%s: %s", UnexpectedStatusCode.Error(), e.Status)
+}
+
+func (e *StatusError) Unwrap() error {
+	return UnexpectedStatusCode
+}
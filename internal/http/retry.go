@@ -0,0 +1,100 @@
+package http
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// isIdempotent returns true when a request with the given method can be safely retried
+func isIdempotent(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete, http.MethodTrace:
+		return true
+	default:
+		return false
+	}
+}
+
+// isRetryable returns true when the given error is a transient failure, worth retrying
+func isRetryable(err error) bool {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) {
+		return isTransientStatusCode(statusErr.StatusCode)
+	}
+
+	return errors.Is(err, FailedToExecuteRequest) || errors.Is(err, FailedToReadResponse)
+}
+
+// isTransientStatusCode returns true when the given status code is expected to change if the request is retried
+func isTransientStatusCode(statusCode int) bool {
+	switch statusCode {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+// backoff returns the time to wait before the given retry, starting from 1. It is a random duration between zero and
+// the base doubled on each retry, capped to the max, so the retries of different requests don't happen at once
+func backoff(retry int, base, max time.Duration) time.Duration {
+	ceiling := base
+	for i := 1; i < retry && ceiling < max; i++ {
+		ceiling *= 2
+	}
+	ceiling = min(ceiling, max)
+
+	if ceiling <= 0 {
+		return 0
+	}
+
+	return rand.N(ceiling + 1)
+}
+
+// retryDelay returns the time to wait before the given retry of a request that failed with the given error. It is the
+// Retry-After of the StatusError, if any, or the backoff otherwise. It returns false when the server asked to wait
+// longer than the max, so the request isn't retried earlier than asked
+func retryDelay(err error, retry int, base, max time.Duration) (time.Duration, bool) {
+	var statusErr *StatusError
+	if errors.As(err, &statusErr) && statusErr.RetryAfter > 0 {
+		return statusErr.RetryAfter, statusErr.RetryAfter <= max
+	}
+
+	return backoff(retry, base, max), true
+}
+
+// parseRetryAfter returns the duration set in the given Retry-After header value, either as an amount of seconds or as
+// an HTTP date, relative to now. It is zero when the value is empty, invalid or already past
+func parseRetryAfter(value string, now time.Time) time.Duration {
+	if value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return max(time.Duration(seconds)*time.Second, 0)
+	}
+
+	date, err := http.ParseTime(value)
+	if err != nil {
+		return 0
+	}
+
+	return max(date.Sub(now), 0)
+}
+
+// wait blocks during the given duration, or until the context is done
+func wait(ctx context.Context, duration time.Duration) error {
+	timer := time.NewTimer(duration)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
@@ -0,0 +1,51 @@
+package http
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRetryDelay_usesTheRetryAfterOfTheResponse(t *testing.T) {
+	err := &StatusError{StatusCode: http.StatusTooManyRequests, RetryAfter: time.Second}
+
+	got, ok := retryDelay(err, 1, time.Millisecond, 2*time.Second)
+
+	assert.True(t, ok)
+	assert.Equal(t, time.Second, got)
+
+	_, ok = retryDelay(err, 1, time.Millisecond, 500*time.Millisecond)
+
+	assert.False(t, ok)
+}
+
+func TestRetryDelay_usesTheBackoffWithoutRetryAfter(t *testing.T) {
+	got, ok := retryDelay(FailedToExecuteRequest, 1, 100*time.Millisecond, time.Second)
+
+	assert.True(t, ok)
+	assert.LessOrEqual(t, got, 100*time.Millisecond)
+}
+
+func TestParseRetryAfter_success(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		value string
+		want  time.Duration
+	}{
+		{value: "30", want: 30 * time.Second},
+		{value: now.Add(time.Minute).Format(http.TimeFormat), want: time.Minute},
+		{value: now.Add(-time.Minute).Format(http.TimeFormat), want: 0},
+		{value: "-5", want: 0},
+		{value: "invalid", want: 0},
+		{value: "", want: 0},
+	}
+
+	for _, tt := range tests {
+		got := parseRetryAfter(tt.value, now)
+
+		assert.Equal(t, tt.want, got)
+	}
+}
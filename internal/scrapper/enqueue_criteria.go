@@ -20,7 +20,7 @@ func MakeEnqueueCriteria(httpClient http.Client, domain string) EnqueueCriteria
 		resp, err := httpClient.NewRequest(ctx, "POST", url, body)
 		if err != nil {
 			log.Error(ctx, err.Error())
-			return FailedToExecuteRequest
+			return fmt.Errorf("%w: %w", FailedToExecuteRequest, err)
 		}
 		ctx = log.With(ctx, log.Param("body", body))
 
@@ -35,6 +35,22 @@ func TestEnqueueCriteria_failsWhenNewRequestThrowsError(t *testing.T) {
 	want := scrapper.FailedToExecuteRequest
 	got := enqueueCriteria(context.Background(), scrapper.MockCriteriaDTO(), 1)
 
-	assert.Equal(t, want, got)
+	assert.ErrorIs(t, got, want)
+	mockHTTPClient.AssertExpectations(t)
+}
+
+func TestEnqueueCriteria_failsKeepingTheStatusErrorOfTheResponse(t *testing.T) {
+	mockHTTPClient := new(http.MockHTTPClient)
+	statusErr := &http.StatusError{StatusCode: 503, Status: "503 Service Unavailable", Body: `{"error": "test"}`}
+	mockHTTPClient.On("NewRequest", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(http.Response{StatusCode: 503}, statusErr)
+	enqueueCriteria := scrapper.MakeEnqueueCriteria(mockHTTPClient, "http://example.com")
+
+	got := enqueueCriteria(context.Background(), scrapper.MockCriteriaDTO(), 1)
+
+	var gotStatusErr *http.StatusError
+	assert.ErrorIs(t, got, scrapper.FailedToExecuteRequest)
+	assert.ErrorIs(t, got, http.UnexpectedStatusCode)
+	assert.True(t, errors.As(got, &gotStatusErr))
+	assert.Equal(t, 503, gotStatusErr.StatusCode)
 	mockHTTPClient.AssertExpectations(t)
 }
@@ -0,0 +1,50 @@
+package setup
+
+import (
+	"os"
+	"strconv"
+)
+
+// EnvFloat returns the value of the environment variable named by the key parsed as a float64. If the variable is not
+// present or its value can't be parsed, the fallback is returned
+func EnvFloat(key string, fallback float64) float64 {
+	value, err := strconv.ParseFloat(os.Getenv(key), 64)
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+// EnvBool returns the value of the environment variable named by the key parsed as a bool. If the variable is not
+// present or its value can't be parsed, the fallback is returned
+func EnvBool(key string, fallback bool) bool {
+	value, err := strconv.ParseBool(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+// EnvInt returns the value of the environment variable named by the key parsed as an int. If the variable is not
+// present or its value can't be parsed, the fallback is returned
+func EnvInt(key string, fallback int) int {
+	value, err := strconv.Atoi(os.Getenv(key))
+	if err != nil {
+		return fallback
+	}
+
+	return value
+}
+
+// EnvString returns the value of the environment variable named by the key. If the variable is not present or empty,
+// the fallback is returned
+func EnvString(key string, fallback string) string {
+	value := os.Getenv(key)
+	if value == "" {
+		return fallback
+	}
+
+	return value
+}
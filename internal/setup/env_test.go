@@ -0,0 +1,81 @@
+package setup_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"ahbcc/internal/setup"
+)
+
+func TestEnvFloat_success(t *testing.T) {
+	t.Setenv("TEST_FLOAT", "0.25")
+
+	want := 0.25
+	got := setup.EnvFloat("TEST_FLOAT", 1)
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnvFloat_returnsTheFallbackWhenTheValueIsInvalid(t *testing.T) {
+	t.Setenv("TEST_FLOAT", "invalid")
+
+	want := 1.0
+	got := setup.EnvFloat("TEST_FLOAT", 1)
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnvBool_success(t *testing.T) {
+	t.Setenv("TEST_BOOL", "true")
+
+	want := true
+	got := setup.EnvBool("TEST_BOOL", false)
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnvBool_returnsTheFallbackWhenTheValueIsInvalid(t *testing.T) {
+	t.Setenv("TEST_BOOL", "invalid")
+
+	want := true
+	got := setup.EnvBool("TEST_BOOL", true)
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnvInt_success(t *testing.T) {
+	t.Setenv("TEST_INT", "5")
+
+	want := 5
+	got := setup.EnvInt("TEST_INT", 1)
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnvInt_returnsTheFallbackWhenTheValueIsInvalid(t *testing.T) {
+	t.Setenv("TEST_INT", "")
+
+	want := 1
+	got := setup.EnvInt("TEST_INT", 1)
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnvString_success(t *testing.T) {
+	t.Setenv("TEST_STRING", "value")
+
+	want := "value"
+	got := setup.EnvString("TEST_STRING", "fallback")
+
+	assert.Equal(t, want, got)
+}
+
+func TestEnvString_returnsTheFallbackWhenTheValueIsEmpty(t *testing.T) {
+	t.Setenv("TEST_STRING", "")
+
+	want := "fallback"
+	got := setup.EnvString("TEST_STRING", "fallback")
+
+	assert.Equal(t, want, got)
+}
@@ -0,0 +1,8 @@
+-- Create the enum type for the user role
+SELECT create_enum_type_if_not_exists('user_role', ARRAY['ANNOTATOR', 'ADMIN']);
+
+-- Add the role column to the users table
+ALTER TABLE users ADD COLUMN IF NOT EXISTS role user_role NOT NULL DEFAULT 'ANNOTATOR';
+
+-- Table comments
+COMMENT ON COLUMN users.role IS 'Role of the user. It can be ANNOTATOR or ADMIN. Only admins can manage gold tweets and access the quality reports';
@@ -0,0 +1,23 @@
+-- Create the enum type for categorization
+SELECT create_enum_type_if_not_exists('verdict', ARRAY['POSITIVE', 'INDETERMINATE', 'NEGATIVE']);
+
+-- Create the gold_tweets table
+CREATE TABLE IF NOT EXISTS gold_tweets (
+    id              SERIAL PRIMARY KEY,
+    tweet_id        INTEGER NOT NULL,
+    categorization  verdict NOT NULL,
+    created_by      INTEGER NOT NULL,
+    created_at      TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_gold_tweets_tweet_id UNIQUE (tweet_id),
+    CONSTRAINT fk_gold_tweets_tweet_id FOREIGN KEY(tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_gold_tweets_created_by FOREIGN KEY(created_by) REFERENCES users(id)
+);
+
+-- Table comments
+COMMENT ON TABLE gold_tweets                 IS 'Contains the tweets with a known verdict, used to measure the accuracy of the annotators';
+COMMENT ON COLUMN gold_tweets.id             IS 'Auto-incrementing ID of the gold tweet, agnostic to business logic';
+COMMENT ON COLUMN gold_tweets.tweet_id       IS 'Foreign key referencing the ID of the tweet';
+COMMENT ON COLUMN gold_tweets.categorization IS 'Expected verdict of the tweet. It can be POSITIVE, INDETERMINATE or NEGATIVE';
+COMMENT ON COLUMN gold_tweets.created_by     IS 'Foreign key referencing the ID of the admin who marked the tweet as gold';
+COMMENT ON COLUMN gold_tweets.created_at     IS 'Timestamp of when the tweet was marked as gold';
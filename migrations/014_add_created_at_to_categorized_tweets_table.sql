@@ -0,0 +1,11 @@
+-- Add the created_at column to the categorized_tweets table.
+-- The default value is set after adding the column, so the rows categorized before this migration keep a NULL value
+-- instead of the time in which the migration was run
+ALTER TABLE categorized_tweets ADD COLUMN IF NOT EXISTS created_at TIMESTAMP;
+ALTER TABLE categorized_tweets ALTER COLUMN created_at SET DEFAULT CURRENT_TIMESTAMP;
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_categorized_tweets_user_id_created_at ON categorized_tweets(user_id, created_at);
+
+-- Table comments
+COMMENT ON COLUMN categorized_tweets.created_at IS 'Timestamp of when the tweet was categorized. It is NULL for the tweets categorized before this column was added';
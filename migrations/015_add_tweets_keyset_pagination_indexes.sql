@@ -0,0 +1,5 @@
+-- Indexes used to page through the tweets of a criteria, sorted by posted_at and id, without scanning the skipped rows
+CREATE INDEX IF NOT EXISTS idx_tweets_criteria_posted_id ON tweets(search_criteria_id, posted_at, id);
+
+-- Index used to check whether a tweet was categorized by a user
+CREATE INDEX IF NOT EXISTS idx_categorized_tweets_tweet_id_user_id ON categorized_tweets(tweet_id, user_id);
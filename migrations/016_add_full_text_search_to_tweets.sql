@@ -0,0 +1,73 @@
+-- Returns the text search configuration that corresponds to the language of a search criteria (ISO 639-1 code).
+-- Languages without a specific configuration fall back to 'simple', which only lowercases the words
+CREATE OR REPLACE FUNCTION search_config_for_language(
+    language TEXT
+) RETURNS regconfig AS $$
+BEGIN
+    RETURN CASE lower(language)
+        WHEN 'ar' THEN 'arabic'
+        WHEN 'da' THEN 'danish'
+        WHEN 'de' THEN 'german'
+        WHEN 'en' THEN 'english'
+        WHEN 'es' THEN 'spanish'
+        WHEN 'fi' THEN 'finnish'
+        WHEN 'fr' THEN 'french'
+        WHEN 'hu' THEN 'hungarian'
+        WHEN 'it' THEN 'italian'
+        WHEN 'nl' THEN 'dutch'
+        WHEN 'no' THEN 'norwegian'
+        WHEN 'pt' THEN 'portuguese'
+        WHEN 'ro' THEN 'romanian'
+        WHEN 'ru' THEN 'russian'
+        WHEN 'sv' THEN 'swedish'
+        WHEN 'tr' THEN 'turkish'
+        ELSE 'simple'
+    END::regconfig;
+END;
+$$ LANGUAGE plpgsql IMMUTABLE;
+
+-- Add the full text search columns
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS search_config regconfig NOT NULL DEFAULT 'simple';
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS text_search tsvector;
+ALTER TABLE tweets_quotes ADD COLUMN IF NOT EXISTS text_search tsvector;
+
+-- Keeps the full text search columns up to date.
+-- The quote is indexed with the configuration of the tweet that quotes it, and its lexemes are also added to the tweet
+-- with a lower weight, so a single index lookup finds the tweets by the text of both the tweet and its quote
+CREATE OR REPLACE FUNCTION update_tweets_text_search() RETURNS TRIGGER AS $$
+DECLARE
+    quote_text_search tsvector;
+BEGIN
+    NEW.search_config := COALESCE(
+        (SELECT search_config_for_language(sc.language) FROM search_criteria AS sc WHERE sc.id = NEW.search_criteria_id),
+        'simple'
+    );
+
+    IF NEW.quote_id IS NOT NULL THEN
+        UPDATE tweets_quotes
+        SET text_search = setweight(to_tsvector(NEW.search_config, COALESCE(text_content, '')), 'B')
+        WHERE id = NEW.quote_id
+        RETURNING text_search INTO quote_text_search;
+    END IF;
+
+    NEW.text_search := setweight(to_tsvector(NEW.search_config, COALESCE(NEW.text_content, '')), 'A') || COALESCE(quote_text_search, ''::tsvector);
+
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER trg_tweets_text_search
+    BEFORE INSERT OR UPDATE OF text_content, quote_id, search_criteria_id ON tweets
+    FOR EACH ROW EXECUTE FUNCTION update_tweets_text_search();
+
+-- Fill the full text search columns of the existing tweets
+UPDATE tweets SET text_content = text_content;
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_tweets_text_search ON tweets USING GIN(text_search);
+CREATE INDEX IF NOT EXISTS idx_tweets_quotes_text_search ON tweets_quotes USING GIN(text_search);
+
+-- Table comments
+COMMENT ON COLUMN tweets.search_config      IS 'Text search configuration chosen from the language of the search criteria';
+COMMENT ON COLUMN tweets.text_search        IS 'Lexemes of the text content of the tweet (weight A) and of its quote (weight B)';
+COMMENT ON COLUMN tweets_quotes.text_search IS 'Lexemes of the text content of the quote, using the configuration of the tweet that quotes it';
@@ -0,0 +1,85 @@
+-- Create the tweets_search_criteria table
+CREATE TABLE IF NOT EXISTS tweets_search_criteria (
+    tweet_id            INTEGER NOT NULL,
+    search_criteria_id  INTEGER NOT NULL,
+
+    CONSTRAINT pk_tweets_search_criteria PRIMARY KEY (search_criteria_id, tweet_id),
+    CONSTRAINT fk_tweets_search_criteria_tweet_id FOREIGN KEY(tweet_id) REFERENCES tweets(id) ON DELETE CASCADE,
+    CONSTRAINT fk_tweets_search_criteria_search_criteria_id FOREIGN KEY(search_criteria_id) REFERENCES search_criteria(id)
+);
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_tweets_search_criteria_tweet_id ON tweets_search_criteria(tweet_id);
+CREATE INDEX IF NOT EXISTS idx_tweets_posted_at_id ON tweets(posted_at, id);
+
+-- The same tweet retrieved by different criteria was stored once per criteria. The tweet with the lowest ID becomes the
+-- canonical one, and the rest of them are merged into it
+CREATE TEMPORARY TABLE duplicated_tweets AS
+SELECT t.id AS duplicate_id, t.canonical_id
+FROM (SELECT id, MIN(id) OVER (PARTITION BY status_id) AS canonical_id FROM tweets) AS t
+WHERE t.id <> t.canonical_id;
+
+INSERT INTO tweets_search_criteria(tweet_id, search_criteria_id)
+SELECT COALESCE(d.canonical_id, t.id), t.search_criteria_id
+FROM tweets AS t
+LEFT JOIN duplicated_tweets AS d ON d.duplicate_id = t.id
+ON CONFLICT DO NOTHING;
+
+-- Keep the first categorization of each user for each canonical tweet, and move it to the canonical tweet
+DELETE FROM categorized_tweets AS c
+USING (
+    SELECT ct.id, ROW_NUMBER() OVER (PARTITION BY ct.user_id, COALESCE(d.canonical_id, ct.tweet_id) ORDER BY ct.id) AS position
+    FROM categorized_tweets AS ct
+    LEFT JOIN duplicated_tweets AS d ON d.duplicate_id = ct.tweet_id
+) AS ranked
+WHERE ranked.id = c.id AND ranked.position > 1;
+
+UPDATE categorized_tweets AS c
+SET tweet_id = d.canonical_id, search_criteria_id = t.search_criteria_id
+FROM duplicated_tweets AS d
+INNER JOIN tweets AS t ON t.id = d.canonical_id
+WHERE c.tweet_id = d.duplicate_id;
+
+-- Keep the first gold mark of each canonical tweet, and move it to the canonical tweet
+DELETE FROM gold_tweets AS g
+USING (
+    SELECT gt.id, ROW_NUMBER() OVER (PARTITION BY COALESCE(d.canonical_id, gt.tweet_id) ORDER BY gt.id) AS position
+    FROM gold_tweets AS gt
+    LEFT JOIN duplicated_tweets AS d ON d.duplicate_id = gt.tweet_id
+) AS ranked
+WHERE ranked.id = g.id AND ranked.position > 1;
+
+UPDATE gold_tweets AS g
+SET tweet_id = d.canonical_id
+FROM duplicated_tweets AS d
+WHERE g.tweet_id = d.duplicate_id;
+
+-- Keep a quote for the canonical tweets that were stored without one
+UPDATE tweets AS t
+SET quote_id = q.quote_id
+FROM (
+    SELECT DISTINCT ON (d.canonical_id) d.canonical_id, dt.quote_id
+    FROM duplicated_tweets AS d
+    INNER JOIN tweets AS dt ON dt.id = d.duplicate_id
+    WHERE dt.quote_id IS NOT NULL
+    ORDER BY d.canonical_id, d.duplicate_id
+) AS q
+WHERE t.id = q.canonical_id AND t.quote_id IS NULL;
+
+DELETE FROM tweets WHERE id IN (SELECT duplicate_id FROM duplicated_tweets);
+
+DELETE FROM tweets_quotes WHERE id NOT IN (SELECT quote_id FROM tweets WHERE quote_id IS NOT NULL);
+
+DROP TABLE duplicated_tweets;
+
+-- Table constraints
+ALTER TABLE tweets DROP CONSTRAINT IF EXISTS uq_id_posted_at_search_criteria;
+ALTER TABLE tweets ADD CONSTRAINT uq_tweets_status_id UNIQUE (status_id);
+ALTER TABLE categorized_tweets ADD CONSTRAINT uq_categorized_tweets_user_id_tweet_id UNIQUE (user_id, tweet_id);
+
+-- Table comments
+COMMENT ON TABLE tweets_search_criteria                     IS 'Contains the search criteria that retrieved each tweet. A tweet can be retrieved by more than one criteria';
+COMMENT ON COLUMN tweets_search_criteria.tweet_id           IS 'Foreign key referencing the ID of the tweet';
+COMMENT ON COLUMN tweets_search_criteria.search_criteria_id IS 'Foreign key referencing the ID of the search criteria';
+COMMENT ON COLUMN tweets.search_criteria_id                 IS 'Foreign key referencing the ID of the search criteria that retrieved the tweet first. All the criteria that retrieved it are in the tweets_search_criteria table';
+COMMENT ON COLUMN categorized_tweets.search_criteria_id     IS 'ID of the search criteria that retrieved the tweet first. This field can also be obtained from the tweet itself, but it was added in this table for query optimization reasons';
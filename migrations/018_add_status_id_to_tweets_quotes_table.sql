@@ -0,0 +1,28 @@
+-- Add the status_id column to the tweets_quotes table. The quotes stored before this migration don't have one
+ALTER TABLE tweets_quotes ADD COLUMN IF NOT EXISTS status_id TEXT NULL;
+
+-- The same quoted tweet was stored once per tweet quoting it. As their status ID is unknown, the quotes with the same
+-- content are considered the same quoted tweet. The quote with the lowest ID becomes the canonical one
+CREATE TEMPORARY TABLE duplicated_quotes AS
+SELECT q.id AS duplicate_id, q.canonical_id
+FROM (
+    SELECT id, MIN(id) OVER (PARTITION BY author, posted_at, is_a_reply, text_content, images) AS canonical_id
+    FROM tweets_quotes
+    WHERE status_id IS NULL
+) AS q
+WHERE q.id <> q.canonical_id;
+
+UPDATE tweets AS t
+SET quote_id = d.canonical_id
+FROM duplicated_quotes AS d
+WHERE t.quote_id = d.duplicate_id;
+
+DELETE FROM tweets_quotes WHERE id IN (SELECT duplicate_id FROM duplicated_quotes);
+
+DROP TABLE duplicated_quotes;
+
+-- Table constraints
+ALTER TABLE tweets_quotes ADD CONSTRAINT uq_tweets_quotes_status_id UNIQUE (status_id);
+
+-- Table comments
+COMMENT ON COLUMN tweets_quotes.status_id IS 'The number after the /status/ of the quoted tweet url. It is NULL for the quotes stored before this column was added';
@@ -0,0 +1,37 @@
+-- Create the enum type for the media fetch status
+SELECT create_enum_type_if_not_exists('media_status', ARRAY['PENDING', 'ARCHIVED', 'FAILED']);
+
+-- Create the media table
+CREATE TABLE IF NOT EXISTS media (
+    id                SERIAL PRIMARY KEY,
+    url               TEXT NOT NULL,
+    hash              TEXT,
+    size              BIGINT,
+    mime_type         TEXT,
+    status            media_status NOT NULL DEFAULT 'PENDING',
+    attempts          INTEGER NOT NULL DEFAULT 0,
+    last_error        TEXT,
+    next_attempt_at   TIMESTAMP WITH TIME ZONE,
+    archived_at       TIMESTAMP WITH TIME ZONE,
+    created_at        TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_media_url UNIQUE (url)
+);
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_media_status_next_attempt_at ON media(status, next_attempt_at);
+CREATE INDEX IF NOT EXISTS idx_media_hash ON media(hash);
+
+-- Table comments
+COMMENT ON TABLE media                  IS 'Contains the images and avatars referenced by the tweets and the quotes, and the state of their local archive copy';
+COMMENT ON COLUMN media.id              IS 'Auto-incrementing ID of the media, agnostic to business logic';
+COMMENT ON COLUMN media.url             IS 'Remote URL of the media, as it was scrapped';
+COMMENT ON COLUMN media.hash            IS 'SHA-256 of the content of the media. The archived file is stored under this name, so equal files are stored once';
+COMMENT ON COLUMN media.size            IS 'Size of the archived file in bytes';
+COMMENT ON COLUMN media.mime_type       IS 'MIME type of the archived file';
+COMMENT ON COLUMN media.status          IS 'Fetch status of the media. It can be PENDING, ARCHIVED or FAILED';
+COMMENT ON COLUMN media.attempts        IS 'Number of times the media was tried to be fetched';
+COMMENT ON COLUMN media.last_error      IS 'Reason of the last failed fetch';
+COMMENT ON COLUMN media.next_attempt_at IS 'Timestamp from which a failed fetch can be retried. It grows exponentially with the attempts';
+COMMENT ON COLUMN media.archived_at     IS 'Timestamp of when the media was archived';
+COMMENT ON COLUMN media.created_at      IS 'Timestamp of when the media was registered';
@@ -0,0 +1,52 @@
+-- Add the entities extracted from the text to the tweets and tweets_quotes tables. They are NULL for the rows stored
+-- before this migration, until they are backfilled by the POST /tweets/entities/backfill/v1 endpoint
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS hashtags TEXT[] NULL;
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS mentions TEXT[] NULL;
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS urls     TEXT[] NULL;
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS emojis   TEXT[] NULL;
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS cashtags TEXT[] NULL;
+
+ALTER TABLE tweets_quotes ADD COLUMN IF NOT EXISTS hashtags TEXT[] NULL;
+ALTER TABLE tweets_quotes ADD COLUMN IF NOT EXISTS mentions TEXT[] NULL;
+ALTER TABLE tweets_quotes ADD COLUMN IF NOT EXISTS urls     TEXT[] NULL;
+ALTER TABLE tweets_quotes ADD COLUMN IF NOT EXISTS emojis   TEXT[] NULL;
+ALTER TABLE tweets_quotes ADD COLUMN IF NOT EXISTS cashtags TEXT[] NULL;
+
+-- Add the entities of the tweet and of the quote to the corpus table
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS tweet_hashtags TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS tweet_mentions TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS tweet_urls     TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS tweet_emojis   TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS tweet_cashtags TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS quote_hashtags TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS quote_mentions TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS quote_urls     TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS quote_emojis   TEXT[] NULL;
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS quote_cashtags TEXT[] NULL;
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_tweets_hashtags ON tweets USING GIN (hashtags);
+CREATE INDEX IF NOT EXISTS idx_tweets_pending_entities ON tweets (id) WHERE hashtags IS NULL;
+CREATE INDEX IF NOT EXISTS idx_tweets_quotes_pending_entities ON tweets_quotes (id) WHERE hashtags IS NULL;
+
+-- Table comments
+COMMENT ON COLUMN tweets.hashtags               IS 'The hashtags of the text, lowercase and without the #. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets.mentions               IS 'The mentioned users of the text, lowercase and without the @. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets.urls                   IS 'The URLs of the text. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets.emojis                 IS 'The emojis of the text. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets.cashtags               IS 'The cashtags of the text, uppercase and without the $. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets_quotes.hashtags        IS 'The hashtags of the quote''s text, lowercase and without the #. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets_quotes.mentions        IS 'The mentioned users of the quote''s text, lowercase and without the @. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets_quotes.urls            IS 'The URLs of the quote''s text. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets_quotes.emojis          IS 'The emojis of the quote''s text. It is NULL until the entities are extracted';
+COMMENT ON COLUMN tweets_quotes.cashtags        IS 'The cashtags of the quote''s text, uppercase and without the $. It is NULL until the entities are extracted';
+COMMENT ON COLUMN corpus.tweet_hashtags         IS 'The hashtags of the tweet, if any';
+COMMENT ON COLUMN corpus.tweet_mentions         IS 'The mentioned users of the tweet, if any';
+COMMENT ON COLUMN corpus.tweet_urls             IS 'The URLs of the tweet, if any';
+COMMENT ON COLUMN corpus.tweet_emojis           IS 'The emojis of the tweet, if any';
+COMMENT ON COLUMN corpus.tweet_cashtags         IS 'The cashtags of the tweet, if any';
+COMMENT ON COLUMN corpus.quote_hashtags         IS 'The hashtags of the quote, if any';
+COMMENT ON COLUMN corpus.quote_mentions         IS 'The mentioned users of the quote, if any';
+COMMENT ON COLUMN corpus.quote_urls             IS 'The URLs of the quote, if any';
+COMMENT ON COLUMN corpus.quote_emojis           IS 'The emojis of the quote, if any';
+COMMENT ON COLUMN corpus.quote_cashtags         IS 'The cashtags of the quote, if any';
@@ -0,0 +1,11 @@
+-- Add the language detected from the text of the tweets. They are NULL for the tweets stored before this migration,
+-- until they are backfilled by the POST /tweets/language/backfill/v1 endpoint
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS language            TEXT NULL;
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS language_confidence DOUBLE PRECISION NULL;
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_tweets_pending_language ON tweets (id) WHERE language IS NULL;
+
+-- Table comments
+COMMENT ON COLUMN tweets.language               IS 'ISO 639-1 code of the language detected from the text, or und if it could not be identified. It is NULL until the language is detected';
+COMMENT ON COLUMN tweets.language_confidence    IS 'Confidence of the detected language, between 0 and 1. It is NULL until the language is detected';
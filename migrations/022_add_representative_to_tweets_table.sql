@@ -0,0 +1,10 @@
+-- Add the representative of the near-duplicate group of the tweets, such as copy-pasted texts or bot spam.
+-- All the tweets of a group, including the representative itself, reference the representative. It is NULL for the
+-- tweets that aren't near-duplicates of any other one. The groups are built by the POST /tweets/duplicates/cluster/v1 endpoint
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS representative_id INTEGER NULL REFERENCES tweets(id) ON DELETE SET NULL;
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_tweets_representative_id ON tweets (representative_id) WHERE representative_id IS NOT NULL;
+
+-- Table comments
+COMMENT ON COLUMN tweets.representative_id IS 'ID of the representative tweet of the near-duplicate group of the tweet, or NULL if the tweet is not a near-duplicate of any other one';
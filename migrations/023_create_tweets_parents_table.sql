@@ -0,0 +1,26 @@
+-- Create the tweets_parents table
+CREATE TABLE IF NOT EXISTS tweets_parents (
+    id                      SERIAL PRIMARY KEY,
+    status_id               TEXT NOT NULL,
+    author                  TEXT NOT NULL,
+    text_content            TEXT NULL,
+    in_reply_to_status_id   TEXT NULL,
+
+    CONSTRAINT uq_tweets_parents_status_id UNIQUE (status_id)
+);
+
+-- Add the tweet each tweet replies to. It is not a foreign key, so a reply can be stored before its parent
+ALTER TABLE tweets ADD COLUMN IF NOT EXISTS in_reply_to_status_id TEXT NULL;
+
+-- Add the text of the tweet each corpus entry replies to
+ALTER TABLE corpus ADD COLUMN IF NOT EXISTS tweet_parent_text TEXT NULL;
+
+-- Table comments
+COMMENT ON TABLE tweets_parents                         IS 'Contains the tweets the scrapped tweets reply to, and the tweets those reply to, so the conversation of a reply can be rebuilt';
+COMMENT ON COLUMN tweets_parents.id                     IS 'Auto-incrementing ID of the parent, agnostic to business logic';
+COMMENT ON COLUMN tweets_parents.status_id              IS 'The number after the /status/ of the parent tweet url';
+COMMENT ON COLUMN tweets_parents.author                 IS 'The user that wrote the parent tweet';
+COMMENT ON COLUMN tweets_parents.text_content           IS 'Text content of the parent tweet, if known';
+COMMENT ON COLUMN tweets_parents.in_reply_to_status_id  IS 'Status ID of the tweet the parent tweet replies to, if known';
+COMMENT ON COLUMN tweets.in_reply_to_status_id          IS 'Status ID of the tweet the tweet replies to, if known. Its information is in the tweets_parents table';
+COMMENT ON COLUMN corpus.tweet_parent_text              IS 'Text content of the tweet the tweet replies to, if it was requested as context and it is known';
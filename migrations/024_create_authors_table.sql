@@ -0,0 +1,61 @@
+-- Create the authors table
+CREATE TABLE IF NOT EXISTS authors (
+    id              SERIAL PRIMARY KEY,
+    handle          TEXT NOT NULL,
+    first_seen_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT uq_authors_handle UNIQUE (handle)
+);
+
+-- Create the authors_avatars table
+CREATE TABLE IF NOT EXISTS authors_avatars (
+    id              SERIAL PRIMARY KEY,
+    author_id       INTEGER NOT NULL,
+    avatar          TEXT NOT NULL,
+    first_seen_at   TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_seen_at    TIMESTAMP WITH TIME ZONE NOT NULL,
+
+    CONSTRAINT uq_authors_avatars_author_id_avatar UNIQUE (author_id, avatar),
+    CONSTRAINT fk_author_id FOREIGN KEY(author_id) REFERENCES authors(id) ON DELETE CASCADE
+);
+
+-- The tweets of an author are retrieved by its normalized handle
+CREATE INDEX IF NOT EXISTS idx_tweets_author_handle ON tweets(LOWER(LTRIM(author, '@')));
+
+-- Backfill the authors, and their avatars, of the tweets and quotes stored before this table was created
+INSERT INTO authors(handle, first_seen_at, last_seen_at)
+SELECT LOWER(LTRIM(s.author, '@')), MIN(COALESCE(s.posted_at, NOW())), MAX(COALESCE(s.posted_at, NOW()))
+FROM (
+    SELECT t.author, t.posted_at FROM tweets AS t
+    UNION ALL
+    SELECT q.author, q.posted_at FROM tweets_quotes AS q
+) AS s
+WHERE LTRIM(s.author, '@') <> ''
+GROUP BY LOWER(LTRIM(s.author, '@'))
+ON CONFLICT (handle) DO NOTHING;
+
+INSERT INTO authors_avatars(author_id, avatar, first_seen_at, last_seen_at)
+SELECT a.id, s.avatar, MIN(COALESCE(s.posted_at, NOW())), MAX(COALESCE(s.posted_at, NOW()))
+FROM (
+    SELECT t.author, t.avatar, t.posted_at FROM tweets AS t
+    UNION ALL
+    SELECT q.author, q.avatar, q.posted_at FROM tweets_quotes AS q
+) AS s
+INNER JOIN authors AS a ON a.handle = LOWER(LTRIM(s.author, '@'))
+WHERE s.avatar IS NOT NULL AND s.avatar <> ''
+GROUP BY a.id, s.avatar
+ON CONFLICT (author_id, avatar) DO NOTHING;
+
+-- Table comments
+COMMENT ON TABLE authors                        IS 'Contains the authors of the scrapped tweets and quotes';
+COMMENT ON COLUMN authors.id                    IS 'Auto-incrementing ID of the author, agnostic to business logic';
+COMMENT ON COLUMN authors.handle                IS 'The user that wrote the tweets, in lowercase and without the leading @';
+COMMENT ON COLUMN authors.first_seen_at         IS 'Timestamp of the oldest tweet, or quote, of the author. The insertion time is used when the tweet has no posted_at';
+COMMENT ON COLUMN authors.last_seen_at          IS 'Timestamp of the newest tweet, or quote, of the author. The insertion time is used when the tweet has no posted_at';
+COMMENT ON TABLE authors_avatars                IS 'Contains the profile images used by each author over time';
+COMMENT ON COLUMN authors_avatars.id            IS 'Auto-incrementing ID of the avatar, agnostic to business logic';
+COMMENT ON COLUMN authors_avatars.author_id     IS 'Foreign key referencing the ID of the author';
+COMMENT ON COLUMN authors_avatars.avatar        IS 'The user profile image';
+COMMENT ON COLUMN authors_avatars.first_seen_at IS 'Timestamp of the oldest tweet, or quote, of the author with this profile image';
+COMMENT ON COLUMN authors_avatars.last_seen_at  IS 'Timestamp of the newest tweet, or quote, of the author with this profile image';
@@ -0,0 +1,18 @@
+-- Store the hash of the session tokens instead of the tokens themselves, so a leak of the table doesn't allow to
+-- impersonate the users. The tokens of the existing sessions are hashed, so they keep working until they expire
+ALTER TABLE users_sessions ADD COLUMN IF NOT EXISTS token_hash TEXT NULL;
+UPDATE users_sessions SET token_hash = ENCODE(SHA256(CONVERT_TO(token, 'UTF8')), 'hex') WHERE token_hash IS NULL;
+ALTER TABLE users_sessions ALTER COLUMN token_hash SET NOT NULL;
+
+DROP INDEX IF EXISTS idx_users_sessions_token;
+ALTER TABLE users_sessions DROP COLUMN IF EXISTS token;
+
+-- The expiration is compared against NOW(), so it is stored with its time zone
+ALTER TABLE users_sessions ALTER COLUMN expires_at TYPE TIMESTAMP WITH TIME ZONE USING expires_at AT TIME ZONE 'UTC';
+
+-- Table indexes
+CREATE UNIQUE INDEX IF NOT EXISTS uq_users_sessions_token_hash ON users_sessions(token_hash);
+
+-- Table comments
+COMMENT ON COLUMN users_sessions.token_hash IS 'Hex encoded SHA-256 hash of the session token. The token itself is never stored';
+COMMENT ON COLUMN users_sessions.expires_at IS 'Expiration time of the session token. It is extended each time the session is refreshed';
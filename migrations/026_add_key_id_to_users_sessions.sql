@@ -0,0 +1,10 @@
+-- Store the ID of the key that signed the token of each session, so the retiring keys can be removed from the keyring
+-- once they have no live sessions. The existing sessions were signed with the SESSION_SECRET_KEY, the legacy key
+ALTER TABLE users_sessions ADD COLUMN IF NOT EXISTS key_id TEXT NOT NULL DEFAULT 'legacy';
+ALTER TABLE users_sessions ALTER COLUMN key_id DROP DEFAULT;
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_users_sessions_key_id_expires_at ON users_sessions(key_id, expires_at);
+
+-- Table comments
+COMMENT ON COLUMN users_sessions.key_id IS 'ID of the key of the keyring that signed the session token. The tokens created before the keyring existed have the legacy key ID';
@@ -0,0 +1,34 @@
+-- Create the enum type for the outcome of the login attempts
+SELECT create_enum_type_if_not_exists('login_attempt_outcome', ARRAY['SUCCEEDED', 'FAILED', 'THROTTLED', 'LOCKED']);
+
+-- Create the login_attempts table
+CREATE TABLE IF NOT EXISTS login_attempts (
+    id              SERIAL PRIMARY KEY,
+    username        TEXT NOT NULL,
+    ip              TEXT NOT NULL,
+    outcome         login_attempt_outcome NOT NULL,
+    attempted_at    TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Create the login_lockouts table
+CREATE TABLE IF NOT EXISTS login_lockouts (
+    username        TEXT PRIMARY KEY,
+    locked_until    TIMESTAMP WITH TIME ZONE,
+    reset_at        TIMESTAMP WITH TIME ZONE NOT NULL
+);
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_login_attempts_failed_username ON login_attempts(username, attempted_at) WHERE outcome = 'FAILED';
+CREATE INDEX IF NOT EXISTS idx_login_attempts_failed_ip ON login_attempts(ip, attempted_at) WHERE outcome = 'FAILED';
+
+-- Table comments
+COMMENT ON TABLE login_attempts               IS 'Audit trail of the login attempts. The failed ones are also used to throttle the logins when the attempts state is kept in the database';
+COMMENT ON COLUMN login_attempts.id           IS 'Auto-incrementing ID of the login attempt, agnostic to business logic';
+COMMENT ON COLUMN login_attempts.username     IS 'Username sent in the login attempt. It may not belong to any user';
+COMMENT ON COLUMN login_attempts.ip           IS 'IP address of the client that made the login attempt';
+COMMENT ON COLUMN login_attempts.outcome      IS 'Outcome of the login attempt: SUCCEEDED, FAILED (unknown username or wrong password), THROTTLED (too many failed attempts) or LOCKED (the account was locked)';
+COMMENT ON COLUMN login_attempts.attempted_at IS 'Timestamp of the login attempt';
+COMMENT ON TABLE login_lockouts               IS 'Lockout state of the usernames, used when the attempts state is kept in the database';
+COMMENT ON COLUMN login_lockouts.username     IS 'Username the lockout state belongs to';
+COMMENT ON COLUMN login_lockouts.locked_until IS 'Timestamp until which the account is locked. NULL when it was unlocked by a successful login or by an admin';
+COMMENT ON COLUMN login_lockouts.reset_at     IS 'Timestamp of the last successful login, lock or unlock of the account. The failed attempts previous to it are not taken into account';
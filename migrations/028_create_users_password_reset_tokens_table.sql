@@ -0,0 +1,31 @@
+-- Store when the password of each user was last changed, either by the user or through a password reset
+ALTER TABLE users ADD COLUMN IF NOT EXISTS password_changed_at TIMESTAMP WITH TIME ZONE;
+
+-- Create the users_password_reset_tokens table
+CREATE TABLE IF NOT EXISTS users_password_reset_tokens (
+    id              SERIAL PRIMARY KEY,
+    user_id         INTEGER NOT NULL,
+    token_hash      TEXT NOT NULL,
+    created_by      INTEGER,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at         TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_users_password_reset_tokens_token_hash UNIQUE (token_hash),
+    CONSTRAINT fk_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE,
+    CONSTRAINT fk_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_users_password_reset_tokens_user_id ON users_password_reset_tokens(user_id) WHERE used_at IS NULL;
+
+-- Table comments
+COMMENT ON COLUMN users.password_changed_at               IS 'Timestamp of the last change of the password. NULL when it was never changed since the user signed up';
+COMMENT ON TABLE users_password_reset_tokens              IS 'Contains the single-use password reset tokens issued by the admins';
+COMMENT ON COLUMN users_password_reset_tokens.id          IS 'Auto-incrementing ID of the password reset token, agnostic to business logic';
+COMMENT ON COLUMN users_password_reset_tokens.user_id     IS 'Foreign key referencing the ID of the user whose password can be reset with the token';
+COMMENT ON COLUMN users_password_reset_tokens.token_hash  IS 'SHA-256 hash of the password reset token. The token itself is never stored';
+COMMENT ON COLUMN users_password_reset_tokens.created_by  IS 'Foreign key referencing the ID of the admin that issued the token. NULL when the admin was deleted';
+COMMENT ON COLUMN users_password_reset_tokens.expires_at  IS 'Timestamp from which the token is no longer valid';
+COMMENT ON COLUMN users_password_reset_tokens.used_at     IS 'Timestamp of when the token was used to reset the password. NULL while it was not used';
+COMMENT ON COLUMN users_password_reset_tokens.created_at  IS 'Timestamp of when the token was issued';
@@ -0,0 +1,47 @@
+-- Create the invites table
+CREATE TABLE IF NOT EXISTS invites (
+    id              SERIAL PRIMARY KEY,
+    code_hash       TEXT NOT NULL,
+    role            user_role NOT NULL,
+    max_uses        INTEGER NOT NULL,
+    uses            INTEGER NOT NULL DEFAULT 0,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_by      INTEGER,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_invites_code_hash UNIQUE (code_hash),
+    CONSTRAINT chk_invites_uses CHECK (uses >= 0 AND uses <= max_uses),
+    CONSTRAINT fk_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL
+);
+
+-- Create the invites_redemptions table
+CREATE TABLE IF NOT EXISTS invites_redemptions (
+    id              SERIAL PRIMARY KEY,
+    invite_id       INTEGER NOT NULL,
+    user_id         INTEGER NOT NULL,
+    redeemed_at     TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_invites_redemptions_user_id UNIQUE (user_id),
+    CONSTRAINT fk_invite_id FOREIGN KEY(invite_id) REFERENCES invites(id) ON DELETE CASCADE,
+    CONSTRAINT fk_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_invites_expires_at ON invites(expires_at);
+CREATE INDEX IF NOT EXISTS idx_invites_redemptions_invite_id ON invites_redemptions(invite_id);
+
+-- Table comments
+COMMENT ON TABLE invites                          IS 'Contains the invites to sign up created by the admins';
+COMMENT ON COLUMN invites.id                      IS 'Auto-incrementing ID of the invite, agnostic to business logic';
+COMMENT ON COLUMN invites.code_hash               IS 'SHA-256 hash of the invite code. The code itself is never stored';
+COMMENT ON COLUMN invites.role                    IS 'Role assigned to the users that sign up with the invite';
+COMMENT ON COLUMN invites.max_uses                IS 'Maximum amount of users that can sign up with the invite';
+COMMENT ON COLUMN invites.uses                    IS 'Amount of users that signed up with the invite';
+COMMENT ON COLUMN invites.expires_at              IS 'Timestamp from which the invite can no longer be redeemed';
+COMMENT ON COLUMN invites.created_by              IS 'Foreign key referencing the ID of the admin that created the invite. NULL when the admin was deleted';
+COMMENT ON COLUMN invites.created_at              IS 'Timestamp of when the invite was created';
+COMMENT ON TABLE invites_redemptions              IS 'Contains the users that signed up with each invite';
+COMMENT ON COLUMN invites_redemptions.id          IS 'Auto-incrementing ID of the redemption, agnostic to business logic';
+COMMENT ON COLUMN invites_redemptions.invite_id   IS 'Foreign key referencing the ID of the redeemed invite';
+COMMENT ON COLUMN invites_redemptions.user_id     IS 'Foreign key referencing the ID of the user that signed up with the invite';
+COMMENT ON COLUMN invites_redemptions.redeemed_at IS 'Timestamp of when the user signed up with the invite';
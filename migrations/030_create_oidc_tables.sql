@@ -0,0 +1,42 @@
+-- Create the oidc_login_states table
+CREATE TABLE IF NOT EXISTS oidc_login_states (
+    id              SERIAL PRIMARY KEY,
+    state_hash      TEXT NOT NULL,
+    nonce           TEXT NOT NULL,
+    code_verifier   TEXT NOT NULL,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_oidc_login_states_state_hash UNIQUE (state_hash)
+);
+
+-- Create the users_identities table
+CREATE TABLE IF NOT EXISTS users_identities (
+    id              SERIAL PRIMARY KEY,
+    user_id         INTEGER NOT NULL,
+    issuer          TEXT NOT NULL,
+    subject         TEXT NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_users_identities_issuer_subject UNIQUE (issuer, subject),
+    CONSTRAINT fk_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_oidc_login_states_expires_at ON oidc_login_states(expires_at);
+CREATE INDEX IF NOT EXISTS idx_users_identities_user_id ON users_identities(user_id);
+
+-- Table comments
+COMMENT ON TABLE oidc_login_states                IS 'Contains the single sign-on logins started against the OpenID Connect provider that did not finish yet';
+COMMENT ON COLUMN oidc_login_states.id            IS 'Auto-incrementing ID of the login state, agnostic to business logic';
+COMMENT ON COLUMN oidc_login_states.state_hash    IS 'SHA-256 hash of the state sent to the provider. The state itself is never stored';
+COMMENT ON COLUMN oidc_login_states.nonce         IS 'Nonce the ID token issued by the provider must contain';
+COMMENT ON COLUMN oidc_login_states.code_verifier IS 'PKCE code verifier sent to the provider to exchange the authorization code';
+COMMENT ON COLUMN oidc_login_states.expires_at    IS 'Timestamp from which the login can no longer be finished';
+COMMENT ON COLUMN oidc_login_states.created_at    IS 'Timestamp of when the login was started';
+COMMENT ON TABLE users_identities                 IS 'Links the users to their identities in the OpenID Connect providers';
+COMMENT ON COLUMN users_identities.id             IS 'Auto-incrementing ID of the identity, agnostic to business logic';
+COMMENT ON COLUMN users_identities.user_id        IS 'Foreign key referencing the ID of the user of the identity';
+COMMENT ON COLUMN users_identities.issuer         IS 'Issuer URL of the provider of the identity';
+COMMENT ON COLUMN users_identities.subject        IS 'Subject of the identity, unique within its issuer';
+COMMENT ON COLUMN users_identities.created_at     IS 'Timestamp of when the identity was linked to the user';
@@ -0,0 +1,60 @@
+-- Create the users_two_factor table
+CREATE TABLE IF NOT EXISTS users_two_factor (
+    user_id         INTEGER PRIMARY KEY,
+    secret          TEXT NOT NULL,
+    enabled_at      TIMESTAMP WITH TIME ZONE,
+    last_used_step  BIGINT NOT NULL DEFAULT 0,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT fk_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create the users_recovery_codes table
+CREATE TABLE IF NOT EXISTS users_recovery_codes (
+    id              SERIAL PRIMARY KEY,
+    user_id         INTEGER NOT NULL,
+    code_hash       TEXT NOT NULL,
+    used_at         TIMESTAMP WITH TIME ZONE,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_users_recovery_codes_user_id_code_hash UNIQUE (user_id, code_hash),
+    CONSTRAINT fk_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Create the login_challenges table
+CREATE TABLE IF NOT EXISTS login_challenges (
+    id              SERIAL PRIMARY KEY,
+    user_id         INTEGER NOT NULL,
+    challenge_hash  TEXT NOT NULL,
+    attempts        INTEGER NOT NULL DEFAULT 0,
+    expires_at      TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at      TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+
+    CONSTRAINT uq_login_challenges_challenge_hash UNIQUE (challenge_hash),
+    CONSTRAINT fk_user_id FOREIGN KEY(user_id) REFERENCES users(id) ON DELETE CASCADE
+);
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_login_challenges_expires_at ON login_challenges(expires_at);
+CREATE INDEX IF NOT EXISTS idx_login_challenges_user_id ON login_challenges(user_id);
+
+-- Table comments
+COMMENT ON TABLE users_two_factor                 IS 'Contains the TOTP second factor of the users, which is not enabled until it is confirmed with a code';
+COMMENT ON COLUMN users_two_factor.user_id        IS 'Foreign key referencing the ID of the user of the second factor';
+COMMENT ON COLUMN users_two_factor.secret         IS 'Base32 encoded TOTP secret shared with the authenticator app of the user';
+COMMENT ON COLUMN users_two_factor.enabled_at     IS 'Timestamp of when the second factor was confirmed, NULL while it is only enrolled';
+COMMENT ON COLUMN users_two_factor.last_used_step IS 'Last TOTP time step accepted, so a code can never be used twice';
+COMMENT ON COLUMN users_two_factor.created_at     IS 'Timestamp of when the second factor was enrolled';
+COMMENT ON TABLE users_recovery_codes             IS 'Contains the single-use recovery codes of the users with the second factor enabled';
+COMMENT ON COLUMN users_recovery_codes.id         IS 'Auto-incrementing ID of the recovery code, agnostic to business logic';
+COMMENT ON COLUMN users_recovery_codes.user_id    IS 'Foreign key referencing the ID of the user of the recovery code';
+COMMENT ON COLUMN users_recovery_codes.code_hash  IS 'SHA-256 hash of the normalized recovery code. The code itself is never stored';
+COMMENT ON COLUMN users_recovery_codes.used_at    IS 'Timestamp of when the recovery code was used, NULL while it is unused';
+COMMENT ON COLUMN users_recovery_codes.created_at IS 'Timestamp of when the recovery code was created';
+COMMENT ON TABLE login_challenges                 IS 'Contains the logins waiting for the second factor of the user to be completed';
+COMMENT ON COLUMN login_challenges.id             IS 'Auto-incrementing ID of the login challenge, agnostic to business logic';
+COMMENT ON COLUMN login_challenges.user_id        IS 'Foreign key referencing the ID of the user logging in';
+COMMENT ON COLUMN login_challenges.challenge_hash IS 'SHA-256 hash of the login challenge. The challenge itself is never stored';
+COMMENT ON COLUMN login_challenges.attempts       IS 'Amount of codes tried against the login challenge';
+COMMENT ON COLUMN login_challenges.expires_at     IS 'Timestamp from which the login challenge can no longer be completed';
+COMMENT ON COLUMN login_challenges.created_at     IS 'Timestamp of when the login challenge was issued';
@@ -0,0 +1,32 @@
+-- Add the disabled_at column to the users table
+ALTER TABLE users ADD COLUMN IF NOT EXISTS disabled_at TIMESTAMP WITH TIME ZONE;
+
+-- Usernames were only unique together with the password hash. The user with the lowest ID keeps the username, and the
+-- rest of them are renamed appending their own ID, so they can still log in with a name that is known to the admins
+UPDATE users AS u
+SET username = u.username || '-' || u.id
+FROM (
+    SELECT id, ROW_NUMBER() OVER (PARTITION BY username ORDER BY id) AS position
+    FROM users
+) AS ranked
+WHERE ranked.id = u.id AND ranked.position > 1;
+
+-- Table constraints
+ALTER TABLE users DROP CONSTRAINT IF EXISTS uq_username_password_hash;
+ALTER TABLE users ADD CONSTRAINT uq_users_username UNIQUE (username);
+
+-- The unique constraint already creates an index on the username
+DROP INDEX IF EXISTS idx_users_username;
+
+-- The categorizations of a deleted user can be anonymized, and the gold tweets of a deleted admin are kept
+ALTER TABLE categorized_tweets ALTER COLUMN user_id DROP NOT NULL;
+ALTER TABLE gold_tweets ALTER COLUMN created_by DROP NOT NULL;
+ALTER TABLE gold_tweets DROP CONSTRAINT IF EXISTS fk_gold_tweets_created_by;
+ALTER TABLE gold_tweets ADD CONSTRAINT fk_gold_tweets_created_by FOREIGN KEY(created_by) REFERENCES users(id) ON DELETE SET NULL;
+
+-- Table comments
+COMMENT ON COLUMN users.username                IS 'Username, must be unique';
+COMMENT ON COLUMN users.password_hash           IS 'Hashed password for authentication';
+COMMENT ON COLUMN users.disabled_at             IS 'Timestamp of when an admin disabled the user, NULL while the user is enabled. Disabled users can neither log in nor use their sessions';
+COMMENT ON COLUMN categorized_tweets.user_id    IS 'Foreign key referencing the ID of the user who categorized the tweet. It is NULL when the categorization was anonymized after deleting the user';
+COMMENT ON COLUMN gold_tweets.created_by        IS 'Foreign key referencing the ID of the admin who marked the tweet as gold. It is NULL when the admin was deleted';
@@ -0,0 +1,46 @@
+-- Create the audit_log table
+-- The actor isn't a foreign key on purpose: the entries of a user must outlive it
+CREATE TABLE IF NOT EXISTS audit_log (
+    id              BIGSERIAL PRIMARY KEY,
+    actor_id        INTEGER,
+    action          TEXT NOT NULL,
+    target          TEXT NOT NULL DEFAULT '',
+    status          INTEGER NOT NULL,
+    request_id      TEXT NOT NULL,
+    ip              TEXT NOT NULL,
+    payload_digest  TEXT NOT NULL DEFAULT '',
+    occurred_at     TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The audit log is append-only: its entries can't be updated nor deleted
+CREATE OR REPLACE FUNCTION prevent_audit_log_changes() RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'the audit log is append-only, its entries can''t be updated nor deleted';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE TRIGGER trg_audit_log_append_only
+    BEFORE UPDATE OR DELETE ON audit_log
+    FOR EACH ROW EXECUTE FUNCTION prevent_audit_log_changes();
+
+CREATE OR REPLACE TRIGGER trg_audit_log_no_truncate
+    BEFORE TRUNCATE ON audit_log
+    FOR EACH STATEMENT EXECUTE FUNCTION prevent_audit_log_changes();
+
+-- Table indexes
+CREATE INDEX IF NOT EXISTS idx_audit_log_actor_id ON audit_log(actor_id, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_action ON audit_log(action, id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_request_id ON audit_log(request_id);
+CREATE INDEX IF NOT EXISTS idx_audit_log_occurred_at ON audit_log(occurred_at);
+
+-- Table comments
+COMMENT ON TABLE audit_log                 IS 'Append-only log of the requests that changed, or tried to change, the state of the application, and of the ones in which a service identified the user that made them';
+COMMENT ON COLUMN audit_log.id             IS 'Auto-incrementing ID of the entry, agnostic to business logic. It follows the order in which the entries were recorded';
+COMMENT ON COLUMN audit_log.actor_id       IS 'ID of the user that made the request. NULL when the request was made without a valid session token, as the ones of GoXCrap';
+COMMENT ON COLUMN audit_log.action         IS 'Route of the request, with its method and path pattern. For example: PUT /criteria-executions/{execution_id}/v1';
+COMMENT ON COLUMN audit_log.target         IS 'Path parameters of the request, identifying the resource it changed. For example: execution_id=12';
+COMMENT ON COLUMN audit_log.status         IS 'HTTP status code of the response';
+COMMENT ON COLUMN audit_log.request_id     IS 'ID of the request, as responded in the X-Request-ID header';
+COMMENT ON COLUMN audit_log.ip             IS 'IP address of the client that made the request';
+COMMENT ON COLUMN audit_log.payload_digest IS 'HMAC-SHA256 of the body of the request, keyed with AUDIT_PAYLOAD_KEY. Empty when the request had no body, when its route carries credentials, as the login, or when no key is configured';
+COMMENT ON COLUMN audit_log.occurred_at    IS 'Timestamp of when the entry was recorded';
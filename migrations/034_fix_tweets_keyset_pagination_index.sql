@@ -0,0 +1,8 @@
+-- The tweets of a criteria are filtered through the tweets_search_criteria table, whose primary key already starts with
+-- the search_criteria_id, so the index on the legacy search_criteria_id column of the tweets is never used to page them
+DROP INDEX IF EXISTS idx_tweets_criteria_posted_id;
+
+-- The pages are sorted, and the cursor compared, by posted_at with the tweets without it placed last, and id. The index on
+-- the same expressions lets the row comparison of the cursor skip the previous pages
+DROP INDEX IF EXISTS idx_tweets_posted_at_id;
+CREATE INDEX IF NOT EXISTS idx_tweets_posted_at_id ON tweets((COALESCE(posted_at, 'infinity'::timestamptz)), id);